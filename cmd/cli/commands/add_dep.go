@@ -2,41 +2,92 @@ package commands
 
 import (
 	"fmt"
+	"io"
+	"slices"
+	"strings"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
 	"github.com/user/pom-manager/internal/core/pom"
 )
 
 var (
-	depGroup    string
-	depArtifact string
-	depVersion  string
-	depScope    string
-	depFile     string
+	depGroup      string
+	depArtifact   string
+	depVersion    string
+	depScope      string
+	depFile       string
+	depFromGradle bool
+	depPosition   string
 )
 
 var AddDepCmd = &cobra.Command{
 	Use:   "add-dep",
 	Short: "Add a dependency to a POM file",
-	Long:  `Add a Maven dependency to an existing POM file.`,
+	Long: `Add a Maven dependency to an existing POM file.
+
+The version may be omitted when dependencyManagement (of the POM, a parent,
+or an imported BOM) manages it.
+
+With --from-gradle, dependencies are read in Gradle notation from the
+arguments, or from standard input when there are none. Configurations such as
+testImplementation or compileOnly become Maven scopes.
+
+New dependencies are appended unless --position says otherwise:
+alphabetical inserts before the first dependency with a greater
+groupId:artifactId, scope after the last one with the same scope.
+Updated dependencies keep their place.`,
 	Example: `  pom-manager add-dep --group junit --artifact junit --version 4.13.2 --scope test
-  pom-manager add-dep -g org.slf4j -a slf4j-api -v 2.0.0 --file myproject/pom.xml`,
+  pom-manager add-dep -g org.slf4j -a slf4j-api -v 2.0.0 --file myproject/pom.xml
+  pom-manager add-dep -g org.springframework.boot -a spring-boot-starter-web
+  pom-manager add-dep -g org.mockito -a mockito-core -V 5.11.0 -s test --position scope
+  pom-manager add-dep --from-gradle "implementation 'org.slf4j:slf4j-api:2.0.9'"
+  pbpaste | pom-manager add-dep --from-gradle`,
+	Args: cobra.ArbitraryArgs,
 	RunE: runAddDep,
 }
 
 func init() {
 	AddDepCmd.Flags().StringVarP(&depGroup, "group", "g", "", "dependency groupId (required)")
 	AddDepCmd.Flags().StringVarP(&depArtifact, "artifact", "a", "", "dependency artifactId (required)")
-	AddDepCmd.Flags().StringVarP(&depVersion, "version", "V", "", "dependency version (required)")
+	AddDepCmd.Flags().StringVarP(&depVersion, "version", "V", "", "dependency version (omit when managed)")
 	AddDepCmd.Flags().StringVarP(&depScope, "scope", "s", "compile", "dependency scope")
+	AddDepCmd.RegisterFlagCompletionFunc("scope", cobra.FixedCompletions(pom.ValidDependencyScopes, cobra.ShellCompDirectiveNoFileComp))
 	AddDepCmd.Flags().StringVarP(&depFile, "file", "f", "pom.xml", "POM file to modify")
-	AddDepCmd.MarkFlagRequired("group")
-	AddDepCmd.MarkFlagRequired("artifact")
-	AddDepCmd.MarkFlagRequired("version")
+	AddDepCmd.Flags().BoolVar(&depFromGradle, "from-gradle", false, "read dependencies in Gradle notation from the arguments or stdin")
+	AddDepCmd.Flags().StringVar(&depPosition, "position", pom.InsertAtEnd, "where new dependencies go: end, alphabetical or scope")
+	AddDepCmd.RegisterFlagCompletionFunc("position", cobra.FixedCompletions(addDepPositions, cobra.ShellCompDirectiveNoFileComp))
+	markWrites(AddDepCmd)
 }
 
+// addDepPositions are the insert positions add-dep offers; there is no
+// selected dependency to insert after
+var addDepPositions = []string{pom.InsertAtEnd, pom.InsertAlphabetical, pom.InsertByScope}
+
 func runAddDep(cmd *cobra.Command, args []string) error {
+	if !slices.Contains(addDepPositions, depPosition) {
+		return fmt.Errorf("invalid --position %q (expected %s)", depPosition, strings.Join(addDepPositions, ", "))
+	}
+
+	var deps []pom.Dependency
+	if depFromGradle {
+		imported, err := gradleDependencies(cmd, args)
+		if err != nil {
+			return err
+		}
+		deps = imported
+	} else {
+		if depGroup == "" || depArtifact == "" {
+			return fmt.Errorf(`required flag(s) "group", "artifact" not set`)
+		}
+		deps = []pom.Dependency{{
+			GroupID:    depGroup,
+			ArtifactID: depArtifact,
+			Version:    depVersion,
+			Scope:      depScope,
+		}}
+	}
+
 	// Parse existing POM
 	parser := pom.NewParser()
 	project, err := parser.ParseFile(depFile)
@@ -44,49 +95,78 @@ func runAddDep(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("parsing POM: %w", err)
 	}
 
-	// Add dependency
-	dep := pom.Dependency{
-		GroupID:    depGroup,
-		ArtifactID: depArtifact,
-		Version:    depVersion,
-		Scope:      depScope,
+	// Add dependencies, updating existing ones
+	added, updated := pom.MergeDependenciesAt(project, deps, depPosition)
+	for range updated {
+		logging.Info("Updated existing dependency")
 	}
-
-	// Check if already exists
-	exists := false
-	for i, existing := range project.Dependencies {
-		if existing.GroupID == dep.GroupID && existing.ArtifactID == dep.ArtifactID {
-			project.Dependencies[i] = dep // Update version
-			exists = true
-			color.Yellow("Updated existing dependency")
-			break
-		}
+	for range added {
+		logging.Info("Added new dependency")
 	}
 
-	if !exists {
-		project.Dependencies = append(project.Dependencies, dep)
-		color.Green("Added new dependency")
+	// Validate; a missing version is fine when the parent chain manages it
+	var inheritance *pom.Inheritance
+	if project.Parent != nil {
+		parents, _ := pom.NewParentResolver(parser).ResolveChain(depFile, project)
+		inheritance = pom.ComputeInheritance(project, parents)
 	}
-
-	// Validate
 	validator := pom.NewValidator()
-	result := validator.Validate(project)
+	result := validator.ValidateWithInheritance(project, inheritance)
 	if !result.Valid {
-		color.Red("✗ Validation failed after adding dependency:")
-		for _, err := range result.Errors.AllErrors() {
-			color.Red("  - %s", err.Error())
-		}
+		logging.ErrorDetails("Validation failed after adding dependency", errorMessages(result.Errors.BySeverity(pom.SeverityError)))
 		return fmt.Errorf("validation failed")
 	}
 
 	// Write back
+	coords := make([]string, len(deps))
+	for i, dep := range deps {
+		coords[i] = strings.TrimSuffix(dep.GroupID+":"+dep.ArtifactID+":"+dep.Version, ":")
+	}
 	generator := pom.NewGenerator()
-	if err := generator.GenerateToFile(project, depFile); err != nil {
+	err = track(depFile, "add-dep "+strings.Join(coords, ", "), func() error {
+		return generator.GenerateToFile(project, depFile)
+	})
+	if err != nil {
 		return fmt.Errorf("writing file: %w", err)
 	}
 
-	color.Green("✓ Dependency added to %s", depFile)
-	fmt.Printf("  %s:%s:%s [%s]\n", depGroup, depArtifact, depVersion, depScope)
+	if len(deps) == 1 {
+		logging.Success("Dependency added to %s", depFile)
+	} else {
+		logging.Success("%d dependencies added to %s", len(deps), depFile)
+	}
+	for _, dep := range deps {
+		if dep.Version == "" {
+			fmt.Printf("  %s:%s (managed) [%s]\n", dep.GroupID, dep.ArtifactID, dep.Scope)
+		} else {
+			fmt.Printf("  %s:%s:%s [%s]\n", dep.GroupID, dep.ArtifactID, dep.Version, dep.Scope)
+		}
+	}
 
 	return nil
 }
+
+// gradleDependencies reads dependencies in Gradle notation from the
+// arguments, one per argument, or from stdin when there are none
+func gradleDependencies(cmd *cobra.Command, args []string) ([]pom.Dependency, error) {
+	text := strings.Join(args, "\n")
+	if len(args) == 0 {
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return nil, fmt.Errorf("reading stdin: %w", err)
+		}
+		text = string(data)
+	}
+
+	imported, err := pom.ParseGradleDependencies([]byte(text))
+	if err != nil {
+		return nil, err
+	}
+	for _, warning := range imported.Warnings {
+		logging.Warn("%s", warning)
+	}
+	if len(imported.Dependencies) == 0 {
+		return nil, fmt.Errorf("no dependencies found in Gradle notation")
+	}
+	return imported.Dependencies, nil
+}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/appdir"
+	"github.com/user/pom-manager/internal/core/catalog"
+	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/core/remote"
+)
+
+var (
+	catalogTimeout time.Duration
+	catalogStrict  bool
+)
+
+var CatalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Show the default versions offered for well-known artifacts",
+	Long: `Show the versions templates and the GUI offer for well-known plugins and
+dependencies. Refreshed versions come from the cached catalog; artifacts
+never refreshed use the version pinned in pom-manager.`,
+	Example: `  pom-manager catalog
+  pom-manager catalog refresh`,
+	Args: cobra.NoArgs,
+	RunE: runCatalog,
+}
+
+var catalogRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Look up the newest releases on Maven Central",
+	Long: `Look up the newest stable release of every well-known plugin and dependency
+on Maven Central and cache them for later runs, including offline ones.
+Mirrors, proxies and credentials in ~/.m2/settings.xml are used. Metadata
+not matching its published checksum is skipped with a security warning;
+metadata without a published checksum is used with a warning, or skipped
+with --strict.`,
+	Args: cobra.NoArgs,
+	RunE: runCatalogRefresh,
+}
+
+func init() {
+	catalogRefreshCmd.Flags().DurationVar(&catalogTimeout, "timeout", time.Minute, "time limit for all lookups")
+	catalogRefreshCmd.Flags().BoolVar(&catalogStrict, "strict", false, "skip metadata without a published checksum")
+	markWrites(catalogRefreshCmd)
+	CatalogCmd.AddCommand(catalogRefreshCmd)
+}
+
+func runCatalog(cmd *cobra.Command, args []string) error {
+	dir, err := appdir.CacheDir()
+	if err != nil {
+		return err
+	}
+	cached, err := catalog.Load(dir)
+	if err != nil {
+		return err
+	}
+
+	if cached.Refreshed.IsZero() {
+		logging.Print(logging.StyleInfo, "Default versions (never refreshed):\n")
+	} else {
+		logging.Print(logging.StyleInfo, "Default versions (refreshed %s):\n", cached.Refreshed.Format("2006-01-02 15:04"))
+	}
+	for _, key := range pom.DefaultVersionKeys() {
+		groupID, artifactID := pom.SplitVersionKey(key)
+		source := "pinned"
+		if _, ok := cached.Versions[key]; ok {
+			source = "refreshed"
+		}
+		fmt.Printf("  %-55s %-12s (%s)\n", key, pom.DefaultVersion(groupID, artifactID), source)
+	}
+	return nil
+}
+
+func runCatalogRefresh(cmd *cobra.Command, args []string) error {
+	dir, err := appdir.CacheDir()
+	if err != nil {
+		return err
+	}
+
+	cached, err := catalog.Load(dir)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), catalogTimeout)
+	defer cancel()
+
+	settings := mavenSettings()
+	client := remote.NewClient(nil, httpClient(settings, catalogTimeout), settings, downloadVerifier(nil, catalogStrict))
+	found, err := cached.Refresh(ctx, client)
+	if found == 0 {
+		return fmt.Errorf("refreshing catalog: %w", err)
+	}
+	if err != nil {
+		logging.Warn("Some artifacts keep their previous version:\n%v", err)
+	}
+	if err := cached.Save(dir); err != nil {
+		return err
+	}
+	cached.Apply()
+
+	logging.Success("Refreshed %d default version(s)", found)
+	return nil
+}
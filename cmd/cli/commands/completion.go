@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// completeTemplates completes the names of built-in and custom templates,
+// with their descriptions where the shell shows them
+func completeTemplates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var names []string
+	for _, info := range templateManager().List() {
+		description := info.Description
+		if info.Path != "" && description == "" {
+			description = "custom template"
+		}
+		names = append(names, info.Name+"\t"+description)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeCustomTemplates completes the names of custom templates only
+func completeCustomTemplates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	dir, err := customTemplateDir()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, info := range pom.NewTemplateManager(dir).List() {
+		if info.Path != "" {
+			names = append(names, info.Name+"\t"+info.Description)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTemplatesOrFiles completes template names plus files, for
+// commands that also take a template file
+func completeTemplatesOrFiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names, _ := completeTemplates(cmd, args, toComplete)
+	return names, cobra.ShellCompDirectiveDefault
+}
+
+// completeOnce limits completion to the first argument
+func completeOnce(complete cobra.CompletionFunc) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/convert"
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+var (
+	convertTo     string
+	convertOutput string
+	convertForce  bool
+)
+
+var ConvertCmd = &cobra.Command{
+	Use:   "convert [file]",
+	Short: "Convert a POM to another build tool's format",
+	Long: `Generate an equivalent build file for another build tool from a POM.
+
+Supported formats:
+  gradle       build.gradle (Gradle Groovy DSL)
+  gradle-kts   build.gradle.kts (Gradle Kotlin DSL)
+  bazel        maven_artifacts.bzl (rules_jvm_external maven_install artifact list)
+
+Plugins are mapped where Gradle has an equivalent; everything that cannot
+be converted exactly (profiles, plugin configuration, unmapped plugins, ...)
+is listed as a warning.`,
+	Example: `  pom-manager convert --to gradle
+  pom-manager convert --to gradle-kts
+  pom-manager convert service/pom.xml --to gradle-kts --output -
+  pom-manager convert --to bazel`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConvert,
+}
+
+func init() {
+	ConvertCmd.Flags().StringVarP(&convertTo, "to", "t", "", fmt.Sprintf("output format (%s)", strings.Join(convert.Formats, ", ")))
+	ConvertCmd.Flags().StringVarP(&convertOutput, "output", "o", "", `output file ("-" for stdout; default: next to the POM)`)
+	ConvertCmd.Flags().BoolVar(&convertForce, "force", false, "overwrite an existing output file")
+	ConvertCmd.MarkFlagRequired("to")
+}
+
+func runConvert(cmd *cobra.Command, args []string) error {
+	file := "pom.xml"
+	if len(args) > 0 {
+		file = args[0]
+	}
+
+	parser := pom.NewParser()
+	project, err := parser.ParseFile(file)
+	if err != nil {
+		return fmt.Errorf("parsing POM: %w", err)
+	}
+
+	output, err := convert.Convert(project, convertTo)
+	if err != nil {
+		return err
+	}
+
+	// Warnings go to stderr so stdout output stays usable
+	for _, warning := range output.Warnings {
+		logging.Warn("%s", warning)
+	}
+
+	if convertOutput == "-" {
+		_, err := os.Stdout.Write(output.Content)
+		return err
+	}
+
+	target := convertOutput
+	if target == "" {
+		target = filepath.Join(filepath.Dir(file), output.FileName)
+	}
+	if _, err := os.Stat(target); err == nil && !convertForce {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", target)
+	}
+	err = track(target, "convert "+file, func() error {
+		return os.WriteFile(target, output.Content, 0644)
+	})
+	if err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	logging.Success("Converted %s to %s", file, target)
+	if len(output.Warnings) > 0 {
+		logging.Warn("%d construct(s) could not be converted exactly, see warnings above", len(output.Warnings))
+	}
+	return nil
+}
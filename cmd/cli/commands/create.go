@@ -3,11 +3,14 @@ package commands
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
-	"github.com/fatih/color"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
 	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/core/workspace"
 )
 
 var (
@@ -17,20 +20,47 @@ var (
 	template   string
 	output     string
 	force      bool
+	createSet  []string
+	scaffold   bool
 )
 
 var CreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new Maven POM file",
-	Long:  `Create a new Maven POM file from a template or with custom coordinates.`,
+	Long: `Create a new Maven POM file from a template or with custom coordinates.
+
+Missing coordinates are prompted for when stdin is a terminal. Otherwise,
+or with --yes, --group, --artifact and --version are required and an
+existing output file is only overwritten with --force or --yes.
+
+Templates may declare variables, such as javaVersion for the built-in Java
+templates. They are set with --set name=value; in interactive mode the
+others are prompted for, otherwise they get their default.
+
+--scaffold also creates the source tree next to the POM, as the Maven
+quickstart archetype does: the source and test directories, an App class
+in the package of the groupId with an AppTest when the template has a test
+framework, and a .gitignore. Existing files are left alone.`,
 	Example: `  # Interactive mode
   pom-manager create
 
   # Non-interactive with flags
   pom-manager create --group com.example --artifact my-app --version 1.0.0
 
+  # In CI: never prompt, overwrite an existing pom.xml
+  pom-manager create --yes --group com.example --artifact my-app --version 1.0.0
+
   # With template
-  pom-manager create --template java-library --group com.example --artifact my-lib --version 1.0.0`,
+  pom-manager create --template java-library --group com.example --artifact my-lib --version 1.0.0
+
+  # Set template variables
+  pom-manager create -t java-library -g com.example -a my-lib -V 1.0.0 --set javaVersion=21
+
+  # Spring Boot service with a specific Boot version
+  pom-manager create -t spring-boot -g com.example -a my-service -V 1.0.0 --set springBootVersion=3.3.5
+
+  # New project directory with sources to start from
+  pom-manager create -g com.example -a my-app -V 1.0.0 -o my-app/pom.xml --scaffold`,
 	RunE: runCreate,
 }
 
@@ -41,31 +71,48 @@ func init() {
 	CreateCmd.Flags().StringVarP(&template, "template", "t", "basic-java", "template name")
 	CreateCmd.Flags().StringVarP(&output, "output", "o", "pom.xml", "output file path")
 	CreateCmd.Flags().BoolVarP(&force, "force", "f", false, "overwrite existing file")
+	CreateCmd.Flags().StringArrayVar(&createSet, "set", nil, "set a template variable (name=value, repeatable)")
+	CreateCmd.Flags().BoolVar(&scaffold, "scaffold", false, "also create src/main, src/test, sample classes and a .gitignore")
+	CreateCmd.RegisterFlagCompletionFunc("template", completeTemplates)
+	markWrites(CreateCmd)
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
 	// Check if file exists
-	if !force {
+	if !force && !AssumeYes {
 		if _, err := os.Stat(output); err == nil {
+			if !canPrompt() {
+				return fmt.Errorf("%s already exists (use --force or --yes to overwrite)", output)
+			}
 			prompt := promptui.Prompt{
 				Label:     fmt.Sprintf("File %s already exists. Overwrite", output),
 				IsConfirm: true,
 			}
 			result, err := prompt.Run()
 			if err != nil || result != "y" {
-				color.Yellow("Cancelled")
+				logging.Info("Cancelled")
 				return nil
 			}
 		}
 	}
 
 	// Interactive mode if coordinates not provided
-	if groupID == "" || artifactID == "" || version == "" {
+	interactive := groupID == "" || artifactID == "" || version == ""
+	if interactive {
+		if !canPrompt() {
+			return requireFlags(cmd, "group", "artifact", "version")
+		}
 		if err := interactiveCreate(); err != nil {
 			return err
 		}
 	}
 
+	tm := templateManager()
+	values, err := createValues(tm, interactive)
+	if err != nil {
+		return err
+	}
+
 	// Create coordinates
 	coords := pom.Coordinates{
 		GroupID:    groupID,
@@ -74,43 +121,84 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create project from template
-	tm := pom.NewTemplateManager()
-	project, err := tm.Create(template, coords)
+	project, err := tm.CreateWithValues(template, coords, values)
 	if err != nil {
 		return fmt.Errorf("creating project: %w", err)
 	}
 
-	// Validate
+	// Validate; a missing version is fine when the parent chain manages it
+	var inheritance *pom.Inheritance
+	if project.Parent != nil {
+		parents, _ := pom.NewParentResolver(pom.NewParser()).ResolveChain(output, project)
+		inheritance = pom.ComputeInheritance(project, parents)
+	}
 	validator := pom.NewValidator()
-	result := validator.Validate(project)
+	result := validator.ValidateWithInheritance(project, inheritance)
 	if !result.Valid {
-		color.Red("✗ Validation failed:")
-		for _, err := range result.Errors.AllErrors() {
-			color.Red("  - %s", err.Error())
-		}
+		logging.ErrorDetails("Validation failed", errorMessages(result.Errors.BySeverity(pom.SeverityError)))
 		return fmt.Errorf("project validation failed")
 	}
 
 	// Generate and write
+	// With a source tree, the output usually goes to a new project directory
+	if dir := filepath.Dir(output); scaffold {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("creating %s: %w", dir, err)
+		}
+	}
 	generator := pom.NewGenerator()
-	if err := generator.GenerateToFile(project, output); err != nil {
+	err = track(output, fmt.Sprintf("create %s from template %s", project.Coordinates.String(), template), func() error {
+		return generator.GenerateToFile(project, output)
+	})
+	if err != nil {
 		return fmt.Errorf("writing file: %w", err)
 	}
 
-	color.Green("✓ Created POM file: %s", output)
-	color.Cyan("  Group ID:    %s", project.GroupID)
-	color.Cyan("  Artifact ID: %s", project.ArtifactID)
-	color.Cyan("  Version:     %s", project.Version)
-	color.Cyan("  Template:    %s", template)
+	logging.Success("Created POM file: %s", output)
+	logging.Info("  Group ID:    %s", project.GroupID)
+	logging.Info("  Artifact ID: %s", project.ArtifactID)
+	logging.Info("  Version:     %s", project.Version)
+	logging.Info("  Template:    %s", template)
 
+	if scaffold {
+		return scaffoldSources(project)
+	}
+	return nil
+}
+
+// scaffoldSources creates the source tree of the new project next to the
+// POM and lists what was written
+func scaffoldSources(project *pom.Project) error {
+	dir := filepath.Dir(output)
+	result, err := workspace.Scaffold(dir, project)
+	if err != nil {
+		return fmt.Errorf("creating source tree: %w", err)
+	}
+
+	relative := func(path string) string {
+		if rel, err := filepath.Rel(dir, path); err == nil {
+			return filepath.ToSlash(rel)
+		}
+		return path
+	}
+	logging.Success("Created source tree in %s", dir)
+	for _, path := range result.Created {
+		fmt.Printf("  %s\n", relative(path))
+	}
+	for _, path := range result.Existing {
+		logging.Info("Kept existing %s", relative(path))
+	}
+	if result.TestFramework == "" && project.Packaging != pom.PackagingPom {
+		logging.Warn("No test framework dependency, so no test class: add JUnit to test in src/test")
+	}
 	return nil
 }
 
 func interactiveCreate() error {
-	color.Cyan("=== Create New Maven Project ===\n")
+	logging.Info("=== Create New Maven Project ===\n")
 
 	// Select template
-	tm := pom.NewTemplateManager()
+	tm := templateManager()
 	templates := tm.List()
 	templateNames := make([]string, len(templates))
 	for i, t := range templates {
@@ -158,3 +246,52 @@ func interactiveCreate() error {
 	fmt.Println()
 	return nil
 }
+
+// createValues returns the values of the template's variables: those set
+// with --set and, in interactive mode, the others as prompted for.
+// Variables without a default are prompted for whenever possible.
+func createValues(tm pom.TemplateManager, interactive bool) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, set := range createSet {
+		name, value, ok := strings.Cut(set, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --set %q (expected name=value)", set)
+		}
+		values[name] = value
+	}
+
+	info, ok := findTemplate(tm, template)
+	if !ok {
+		return values, nil // Create reports the unknown template
+	}
+	var missing []string
+	for _, variable := range info.Variables {
+		if _, set := values[variable.Name]; set {
+			continue
+		}
+		if !canPrompt() || (!interactive && variable.Default != "") {
+			if variable.Default == "" {
+				missing = append(missing, "--set "+variable.Name+"=...")
+			}
+			continue
+		}
+
+		label := variable.Name
+		if variable.Description != "" {
+			label = fmt.Sprintf("%s (%s)", variable.Name, variable.Description)
+		}
+		prompt := promptui.Prompt{
+			Label:   label,
+			Default: variable.Default,
+		}
+		value, err := prompt.Run()
+		if err != nil {
+			return nil, err
+		}
+		values[variable.Name] = value
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("template %s needs %s", template, strings.Join(missing, ", "))
+	}
+	return values, nil
+}
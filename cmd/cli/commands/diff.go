@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/diff"
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+var (
+	diffJSON      bool
+	diffChangelog bool
+)
+
+var DiffCmd = &cobra.Command{
+	Use:   "diff <old> <new>",
+	Short: "Compare two POM files semantically",
+	Long: `Compare two POM files by what they declare rather than line by line:
+coordinates, parent, properties, dependencies and their exclusions, managed
+dependencies, plugins and their executions, modules and profiles.
+
+Reordering elements or reformatting the XML is not reported. With --json the
+changes are printed as a JSON object for scripts; with --changelog they are
+written as a commit message, a one-line summary followed by each change.`,
+	Example: `  pom-manager diff old/pom.xml pom.xml
+  git show HEAD:pom.xml > /tmp/pom.xml && pom-manager diff /tmp/pom.xml pom.xml
+  pom-manager diff --json a/pom.xml b/pom.xml | jq '.changes[].description'
+  git show HEAD:pom.xml > /tmp/pom.xml && git commit -m "$(pom-manager diff --changelog /tmp/pom.xml pom.xml)" pom.xml`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+func init() {
+	DiffCmd.Flags().BoolVar(&diffJSON, "json", false, "output in JSON format")
+	DiffCmd.Flags().BoolVar(&diffChangelog, "changelog", false, "output the changes as a commit message")
+	DiffCmd.MarkFlagsMutuallyExclusive("json", "changelog")
+}
+
+// diffResult is the JSON form of a comparison
+type diffResult struct {
+	Old     string        `json:"old"`
+	New     string        `json:"new"`
+	Changes []diff.Change `json:"changes"`
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	parser := pom.NewParser()
+	old, err := parser.ParseFile(args[0])
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", args[0], err)
+	}
+	new, err := parser.ParseFile(args[1])
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", args[1], err)
+	}
+
+	changes := diff.Projects(old, new)
+
+	if diffChangelog {
+		fmt.Println(diff.Changelog(changes))
+		return nil
+	}
+
+	if diffJSON {
+		result := diffResult{Old: args[0], New: args[1], Changes: changes}
+		if result.Changes == nil {
+			result.Changes = []diff.Change{}
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	logging.Print(logging.StyleInfo, "=== %s → %s ===\n", args[0], args[1])
+	if len(changes) == 0 {
+		logging.Success("No differences")
+		return nil
+	}
+	for _, change := range changes {
+		switch change.Kind {
+		case diff.Added:
+			logging.Print(logging.StyleGood, "+ %s", change.Description)
+		case diff.Removed:
+			logging.Print(logging.StyleBad, "− %s", change.Description)
+		default:
+			logging.Print(logging.StyleNotice, "~ %s", change.Description)
+		}
+	}
+	fmt.Printf("\n%d change(s)\n", len(changes))
+	return nil
+}
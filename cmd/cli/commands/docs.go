@@ -0,0 +1,208 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/user/pom-manager/cmd/cli/logging"
+)
+
+var docsDir string
+
+var DocsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate man pages for every command",
+	Long: `Write a man page for pom-manager and for each of its commands, such as
+pom-manager-create.1, to a directory. Install them into a man directory
+listed in MANPATH to read them with man pom-manager.
+
+The date in the pages is taken from SOURCE_DATE_EPOCH when set, so packaged
+pages are reproducible.`,
+	Example: `  pom-manager docs
+  pom-manager docs --dir /usr/local/share/man/man1`,
+	Args: cobra.NoArgs,
+	RunE: runDocs,
+}
+
+func init() {
+	DocsCmd.Flags().StringVarP(&docsDir, "dir", "d", "man", "directory to write the man pages to")
+	DocsCmd.MarkFlagDirname("dir")
+	markWrites(DocsCmd)
+}
+
+func runDocs(cmd *cobra.Command, args []string) error {
+	if err := os.MkdirAll(docsDir, 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", docsDir, err)
+	}
+
+	date := time.Now()
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		seconds, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid SOURCE_DATE_EPOCH: %w", err)
+		}
+		date = time.Unix(seconds, 0).UTC()
+	}
+
+	count := 0
+	var write func(*cobra.Command) error
+	write = func(c *cobra.Command) error {
+		if !c.IsAvailableCommand() || c.Name() == "help" {
+			return nil
+		}
+		path := filepath.Join(docsDir, manName(c)+".1")
+		err := track(path, "docs", func() error {
+			return os.WriteFile(path, manPage(c, date), 0644)
+		})
+		if err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+		count++
+		for _, child := range c.Commands() {
+			if err := write(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := write(cmd.Root()); err != nil {
+		return err
+	}
+
+	logging.Success("Wrote %d man page(s) to %s", count, docsDir)
+	return nil
+}
+
+// manName returns the man page name of a command, e.g. pom-manager-module-add
+func manName(c *cobra.Command) string {
+	return strings.ReplaceAll(c.CommandPath(), " ", "-")
+}
+
+// manPage renders a command's help as a man page in roff
+func manPage(c *cobra.Command, date time.Time) []byte {
+	var out bytes.Buffer
+	root := c.Root()
+	fmt.Fprintf(&out, ".TH %q 1 %q %q %q\n", strings.ToUpper(manName(c)), date.Format("Jan 2006"),
+		strings.TrimSpace(root.Name()+" "+root.Version), "POM Manager Manual")
+
+	fmt.Fprintf(&out, ".SH NAME\n%s \\- %s\n", roffEscape(manName(c)), roffEscape(c.Short))
+
+	fmt.Fprintf(&out, ".SH SYNOPSIS\n.B %s\n", roffEscape(c.CommandPath()))
+	if c.HasAvailableSubCommands() && !c.Runnable() {
+		out.WriteString("<command>\n")
+	} else if _, args, ok := strings.Cut(c.Use, " "); ok {
+		out.WriteString(roffEscape(args) + "\n")
+	}
+	if c.HasAvailableFlags() {
+		out.WriteString("[flags]\n")
+	}
+
+	description := c.Long
+	if description == "" {
+		description = c.Short
+	}
+	out.WriteString(".SH DESCRIPTION\n")
+	writeParagraphs(&out, description)
+
+	writeFlags(&out, "OPTIONS", c.NonInheritedFlags())
+	writeFlags(&out, "OPTIONS INHERITED FROM PARENT COMMANDS", c.InheritedFlags())
+
+	if c.Example != "" {
+		out.WriteString(".SH EXAMPLES\n.nf\n")
+		for _, line := range strings.Split(c.Example, "\n") {
+			out.WriteString(roffLine(line) + "\n")
+		}
+		out.WriteString(".fi\n")
+	}
+
+	var related []string
+	if c.HasParent() {
+		related = append(related, roffEscape(manName(c.Parent()))+"(1)")
+	}
+	for _, child := range c.Commands() {
+		if child.IsAvailableCommand() && child.Name() != "help" {
+			related = append(related, roffEscape(manName(child))+"(1)")
+		}
+	}
+	if len(related) > 0 {
+		fmt.Fprintf(&out, ".SH SEE ALSO\n%s\n", strings.Join(related, ", "))
+	}
+	return out.Bytes()
+}
+
+// writeParagraphs writes text with blank lines starting new paragraphs and
+// indented lines kept as they are
+func writeParagraphs(out *bytes.Buffer, text string) {
+	preformatted := false
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		indented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		switch {
+		case strings.TrimSpace(line) == "":
+			if preformatted {
+				out.WriteString(".fi\n")
+				preformatted = false
+			}
+			out.WriteString(".PP\n")
+			continue
+		case indented && !preformatted:
+			out.WriteString(".nf\n")
+			preformatted = true
+		case !indented && preformatted:
+			out.WriteString(".fi\n")
+			preformatted = false
+		}
+		out.WriteString(roffLine(line) + "\n")
+	}
+	if preformatted {
+		out.WriteString(".fi\n")
+	}
+}
+
+// writeFlags writes a section listing flags, skipping it when there are none
+func writeFlags(out *bytes.Buffer, title string, flags *pflag.FlagSet) {
+	var items bytes.Buffer
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+		name, usage := pflag.UnquoteUsage(flag)
+		header := "\\-\\-" + roffEscape(flag.Name)
+		if flag.Shorthand != "" {
+			header = "\\-" + flag.Shorthand + ", " + header
+		}
+		if name != "" {
+			header += " " + name
+		}
+		if flag.DefValue != "" && flag.DefValue != "false" && flag.DefValue != "[]" {
+			usage += fmt.Sprintf(" (default %s)", flag.DefValue)
+		}
+		fmt.Fprintf(&items, ".TP\n\\fB%s\\fR\n%s\n", header, roffLine(usage))
+	})
+	if items.Len() > 0 {
+		fmt.Fprintf(out, ".SH %s\n", title)
+		out.Write(items.Bytes())
+	}
+}
+
+// roffEscape escapes backslashes and hyphens, which roff would otherwise
+// turn into escapes and typographic dashes
+func roffEscape(text string) string {
+	return strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(text)
+}
+
+// roffLine escapes a line of text, guarding a leading . or ' that roff
+// would read as a request
+func roffLine(line string) string {
+	line = roffEscape(line)
+	if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+		line = `\&` + line
+	}
+	return line
+}
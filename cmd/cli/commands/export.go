@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+var (
+	exportFormat string
+	exportOutput string
+	exportForce  bool
+)
+
+var ExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export a POM as a JSON or YAML project definition",
+	Long: `Write a POM as structured data, for scripts and pipelines that generate or
+inspect POMs. Keys follow the POM element names (groupId, dependencies,
+build.plugins, profiles, ...).
+
+The definition can be turned back into a pom.xml with 'pom-manager import'.`,
+	Example: `  pom-manager export --format json
+  pom-manager export service/pom.xml --format yaml --output service.yaml
+  pom-manager export --format json | jq '.dependencies[].artifactId'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExport,
+}
+
+func init() {
+	ExportCmd.Flags().StringVar(&exportFormat, "format", pom.DefinitionFormatJSON, fmt.Sprintf("output format (%s)", strings.Join(pom.DefinitionFormats, ", ")))
+	ExportCmd.Flags().StringVarP(&exportOutput, "output", "o", "-", `output file ("-" for stdout)`)
+	ExportCmd.Flags().BoolVar(&exportForce, "force", false, "overwrite an existing output file")
+	ExportCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(pom.DefinitionFormats, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	file := "pom.xml"
+	if len(args) > 0 {
+		file = args[0]
+	}
+
+	parser := pom.NewParser()
+	project, err := parser.ParseFile(file)
+	if err != nil {
+		return fmt.Errorf("parsing POM: %w", err)
+	}
+
+	data, err := pom.NewProjectDefinition(project).Marshal(exportFormat)
+	if err != nil {
+		return err
+	}
+
+	if exportOutput == "-" {
+		_, err := cmd.OutOrStdout().Write(data)
+		return err
+	}
+
+	if _, err := os.Stat(exportOutput); err == nil && !exportForce {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", exportOutput)
+	}
+	err = track(exportOutput, fmt.Sprintf("export %s as %s", file, exportFormat), func() error {
+		return os.WriteFile(exportOutput, data, 0644)
+	})
+	if err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	logging.Success("Exported %s to %s", file, exportOutput)
+	return nil
+}
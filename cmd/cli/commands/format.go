@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/diff"
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+var (
+	formatIndent   string
+	formatCheck    bool
+	formatDiff     bool
+	formatSortDeps bool
+)
+
+var FormatCmd = &cobra.Command{
+	Use:   "format [file...]",
+	Short: "Rewrite POM files with canonical ordering and indentation",
+	Long: `Rewrite POM files with elements in the order of the Maven POM conventions,
+consistent indentation and sorted dependencies (by scope, then groupId and
+artifactId; BOM imports keep their order).
+
+Comments, plugin configuration and unknown elements are kept. Without
+arguments, pom.xml in the current directory is formatted.
+
+With --check, files are not changed; the command lists the files that would
+change and exits with an error, so it can guard formatting in CI.`,
+	Example: `  pom-manager format
+  pom-manager format --indent 2 module-a/pom.xml module-b/pom.xml
+  pom-manager format --check --diff`,
+	RunE: runFormat,
+}
+
+func init() {
+	FormatCmd.Flags().StringVar(&formatIndent, "indent", pom.IndentFourSpaces, "indentation: 2 or 4 spaces, or tab")
+	FormatCmd.RegisterFlagCompletionFunc("indent", cobra.FixedCompletions(pom.IndentStyles, cobra.ShellCompDirectiveNoFileComp))
+	FormatCmd.Flags().BoolVar(&formatCheck, "check", false, "report files that need formatting without changing them")
+	FormatCmd.Flags().BoolVar(&formatDiff, "diff", false, "show the changes formatting makes")
+	FormatCmd.Flags().BoolVar(&formatSortDeps, "sort-dependencies", true, "sort dependencies by scope, groupId and artifactId")
+}
+
+func runFormat(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		args = []string{"pom.xml"}
+	}
+	options := pom.FormatOptions{Indent: formatIndent, SortDependencies: formatSortDeps}
+
+	unformatted := 0
+	for _, path := range args {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		formatted, err := pom.Format(data, options)
+		if err != nil {
+			return fmt.Errorf("formatting %s: %w", path, err)
+		}
+		if bytes.Equal(data, formatted) {
+			continue
+		}
+
+		unformatted++
+		if formatDiff {
+			fmt.Print(diff.Unified(path, path+" (formatted)", string(data), string(formatted), 3))
+		}
+		if formatCheck {
+			logging.Warn("%s needs formatting", path)
+			continue
+		}
+		// A new banner alone does not make a file unformatted
+		if banner := pom.BannerFor(path); banner != nil {
+			stamped := options
+			stamped.Banner = banner
+			if formatted, err = pom.Format(data, stamped); err != nil {
+				return fmt.Errorf("formatting %s: %w", path, err)
+			}
+		}
+		err = track(path, "format", func() error {
+			return os.WriteFile(path, formatted, 0644)
+		})
+		if err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+		logging.Success("Formatted %s", path)
+	}
+
+	if formatCheck && unformatted > 0 {
+		return fmt.Errorf("%d file(s) need formatting", unformatted)
+	}
+	if unformatted == 0 {
+		logging.Success("%d file(s) already formatted", len(args))
+	}
+	return nil
+}
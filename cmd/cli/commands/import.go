@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+var (
+	importFormat string
+	importFile   string
+	importDryRun bool
+	importForce  bool
+)
+
+var ImportCmd = &cobra.Command{
+	Use:   "import <build-file>",
+	Short: "Import dependencies from another build tool, or a project definition",
+	Long: `Read the dependencies declared in an Ivy, SBT or Gradle build file and add
+them to a POM, for migrating legacy builds to Maven, or generate a POM from
+a JSON or YAML project definition.
+
+Supported formats:
+  ivy   <dependency> elements of an ivy.xml; conf="compile->default" style
+        configurations become Maven scopes, transitive="false" excludes all
+        transitive dependencies
+  sbt   libraryDependencies of a build.sbt; %% appends the Scala binary
+        version, configurations such as Test or "provided" become scopes
+  gradle  dependencies of a build.gradle or build.gradle.kts; configurations
+          such as testImplementation or compileOnly become scopes
+  json, yaml  a whole project, as written by 'pom-manager export'; the POM
+          is generated from it ("-" reads the definition from stdin)
+
+The format is detected from the file name unless --from is given.
+Dependencies already in the POM are updated.`,
+	Example: `  pom-manager import ivy.xml
+  pom-manager import build.sbt --file service/pom.xml
+  pom-manager import app/build.gradle.kts
+  pom-manager import deps.xml --from ivy --dry-run
+  pom-manager import project.yaml --file service/pom.xml
+  generate-project | pom-manager import - --from json --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	formats := append(append([]string{}, pom.ImportFormats...), pom.DefinitionFormats...)
+	ImportCmd.Flags().StringVar(&importFormat, "from", "", fmt.Sprintf("input format (%s); detected from the file name by default", strings.Join(formats, ", ")))
+	ImportCmd.Flags().StringVarP(&importFile, "file", "f", "pom.xml", "POM file to modify, or to generate from a project definition")
+	ImportCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "list the dependencies, or print the generated POM, without writing it")
+	ImportCmd.Flags().BoolVar(&importForce, "force", false, "overwrite an existing POM when importing a project definition")
+	ImportCmd.RegisterFlagCompletionFunc("from", cobra.FixedCompletions(formats, cobra.ShellCompDirectiveNoFileComp))
+	markWrites(ImportCmd)
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	source := args[0]
+
+	format := importFormat
+	if format == "" {
+		format = pom.DetectDefinitionFormat(source)
+	}
+	if format == pom.DefinitionFormatJSON || format == pom.DefinitionFormatYAML {
+		return importDefinition(cmd, source, format)
+	}
+	if format == "" {
+		detected, err := pom.DetectImportFormat(source)
+		if err != nil {
+			return fmt.Errorf("%w (use --from)", err)
+		}
+		format = detected
+	}
+
+	imported, err := pom.ImportDependencies(source, format)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range imported.Warnings {
+		logging.Warn("%s", warning)
+	}
+	if len(imported.Dependencies) == 0 {
+		return fmt.Errorf("no dependencies found in %s", source)
+	}
+
+	if importDryRun {
+		logging.Print(logging.StyleInfo, "Dependencies in %s:", source)
+		for _, dep := range imported.Dependencies {
+			fmt.Printf("  %s [%s]\n", dep.Notation(), dep.Scope)
+		}
+		return nil
+	}
+
+	// Edited in place, so what the model does not hold, such as comments
+	// and <repositories>, is kept
+	var added, updated []string
+	err = editPOMFile(importFile, fmt.Sprintf("import %d dependencies from %s", len(imported.Dependencies), source), func(data []byte, banner *pom.Banner) ([]byte, error) {
+		var edited []byte
+		edited, added, updated, err = pom.MergeDependencyElements(data, imported.Dependencies, banner)
+		return edited, err
+	})
+	if err != nil {
+		return err
+	}
+
+	logging.Success("Imported %d dependencies from %s into %s", len(imported.Dependencies), source, importFile)
+	for _, key := range added {
+		fmt.Printf("  + %s\n", key)
+	}
+	for _, key := range updated {
+		fmt.Printf("  ~ %s\n", key)
+	}
+
+	return nil
+}
+
+// importDefinition generates the POM from a JSON or YAML project definition
+func importDefinition(cmd *cobra.Command, source, format string) error {
+	var data []byte
+	var err error
+	if source == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return fmt.Errorf("reading project definition: %w", err)
+	}
+
+	definition, err := pom.ParseProjectDefinition(data, format)
+	if err != nil {
+		return err
+	}
+	project, err := definition.Project()
+	if err != nil {
+		return err
+	}
+
+	generator := pom.NewGenerator()
+	if importDryRun {
+		xmlData, err := generator.Generate(project)
+		if err != nil {
+			return err
+		}
+		_, err = cmd.OutOrStdout().Write(xmlData)
+		return err
+	}
+
+	if _, err := os.Stat(importFile); err == nil && !importForce {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", importFile)
+	}
+	err = track(importFile, "import project from "+source, func() error {
+		return generator.GenerateToFile(project, importFile)
+	})
+	if err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	logging.Success("Generated %s from %s", importFile, source)
+	return nil
+}
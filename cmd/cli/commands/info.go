@@ -4,27 +4,34 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
 	"github.com/user/pom-manager/internal/core/pom"
 )
 
 var (
 	jsonOutput bool
+	gavOutput  bool
 )
 
 var InfoCmd = &cobra.Command{
 	Use:   "info <file>",
 	Short: "Display POM file information",
-	Long:  `Display information about a Maven POM file including coordinates, dependencies, and plugins.`,
+	Long: `Display information about a Maven POM file including coordinates, dependencies, and plugins.
+
+With --gav, only print the project's groupId:artifactId:version, for use in
+scripts.`,
 	Example: `  pom-manager info pom.xml
-  pom-manager info --json pom.xml`,
+  pom-manager info --json pom.xml
+  pom-manager info --gav pom.xml`,
 	Args: cobra.ExactArgs(1),
 	RunE: runInfo,
 }
 
 func init() {
 	InfoCmd.Flags().BoolVar(&jsonOutput, "json", false, "output in JSON format")
+	InfoCmd.Flags().BoolVar(&gavOutput, "gav", false, "only print the project's groupId:artifactId:version")
+	InfoCmd.MarkFlagsMutuallyExclusive("json", "gav")
 }
 
 func runInfo(cmd *cobra.Command, args []string) error {
@@ -37,6 +44,11 @@ func runInfo(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("parsing POM: %w", err)
 	}
 
+	if gavOutput {
+		fmt.Println(project.Coordinates.String())
+		return nil
+	}
+
 	if jsonOutput {
 		data, err := json.MarshalIndent(project, "", "  ")
 		if err != nil {
@@ -47,9 +59,9 @@ func runInfo(cmd *cobra.Command, args []string) error {
 	}
 
 	// Display info
-	color.Cyan("=== POM Information ===\n")
+	logging.Print(logging.StyleInfo, "=== POM Information ===\n")
 
-	color.Green("Project:")
+	logging.Print(logging.StyleGood, "Project:")
 	fmt.Printf("  Group ID:    %s\n", project.GroupID)
 	fmt.Printf("  Artifact ID: %s\n", project.ArtifactID)
 	fmt.Printf("  Version:     %s\n", project.Version)
@@ -58,9 +70,12 @@ func runInfo(cmd *cobra.Command, args []string) error {
 	if project.Name != "" {
 		fmt.Printf("  Name:        %s\n", project.Name)
 	}
+	if project.Banner != nil {
+		fmt.Printf("  Written by:  %s %s on %s\n", project.Banner.Tool, project.Banner.Version, project.Banner.Time.Format("2006-01-02 15:04"))
+	}
 
 	if len(project.Dependencies) > 0 {
-		color.Green("\nDependencies (%d):", len(project.Dependencies))
+		logging.Print(logging.StyleGood, "\nDependencies (%d):", len(project.Dependencies))
 		for _, dep := range project.Dependencies {
 			scope := dep.Scope
 			if scope == "" {
@@ -71,7 +86,7 @@ func runInfo(cmd *cobra.Command, args []string) error {
 	}
 
 	if project.Build != nil && len(project.Build.Plugins) > 0 {
-		color.Green("\nPlugins (%d):", len(project.Build.Plugins))
+		logging.Print(logging.StyleGood, "\nPlugins (%d):", len(project.Build.Plugins))
 		for _, plugin := range project.Build.Plugins {
 			fmt.Printf("  - %s:%s", plugin.GroupID, plugin.ArtifactID)
 			if plugin.Version != "" {
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
+)
+
+// AssumeYes answers confirmation prompts with yes and disables interactive
+// prompts altogether. It is bound to the global --yes and --non-interactive
+// flags.
+var AssumeYes bool
+
+// canPrompt reports whether the user can be asked for input: prompts need a
+// terminal on stdin and are off with --yes
+func canPrompt() bool {
+	if AssumeYes {
+		return false
+	}
+	fd := os.Stdin.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// requireFlags fails with the names of the flags that have no value, for
+// commands that would otherwise prompt for them
+func requireFlags(cmd *cobra.Command, names ...string) error {
+	var missing []string
+	for _, name := range names {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Value.String() == "" {
+			missing = append(missing, "--"+name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("missing %s (not prompting: stdin is not a terminal or --yes is set)", strings.Join(missing, ", "))
+}
+
+// confirmImpact lists what else an operation affects and asks before going
+// on. Without a terminal it fails unless --yes is set; with nothing else
+// affected there is nothing to ask.
+func confirmImpact(action string, impact []string) (bool, error) {
+	if len(impact) == 0 {
+		return true, nil
+	}
+	logging.Warn("%s also affects:", action)
+	for _, item := range impact {
+		fmt.Printf("  - %s\n", item)
+	}
+	if AssumeYes {
+		return true, nil
+	}
+	if !canPrompt() {
+		return false, fmt.Errorf("%s affects %d other place(s) (use --yes to go ahead)", action, len(impact))
+	}
+
+	prompt := promptui.Prompt{
+		Label:     "Continue",
+		IsConfirm: true,
+	}
+	result, err := prompt.Run()
+	return err == nil && result == "y", nil
+}
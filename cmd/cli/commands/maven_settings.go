@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/appdir"
+	"github.com/user/pom-manager/internal/core/audit"
+	"github.com/user/pom-manager/internal/core/httpcache"
+	"github.com/user/pom-manager/internal/core/remote"
+)
+
+// CacheTTL is how long remote lookups are answered from the HTTP cache
+// without asking the repository again
+var CacheTTL = httpcache.DefaultTTL
+
+// mavenSettings returns the user's settings.xml, whose mirrors, proxies and
+// credentials remote lookups use. Returns nil when there is none, or when
+// it cannot be read, which is reported.
+func mavenSettings() *remote.Settings {
+	settings, err := remote.LoadSettings(remote.DefaultSettingsPath())
+	if err != nil {
+		logging.Warn("Ignoring Maven settings: %v", err)
+		return nil
+	}
+	return settings
+}
+
+// httpClient returns the client of remote lookups, reaching repositories
+// through the proxies and credentials of settings, which may be nil, and
+// answering from the HTTP cache in the cache directory
+func httpClient(settings *remote.Settings, timeout time.Duration) *http.Client {
+	var cache *httpcache.Cache
+	if dir, err := appdir.CacheDir(); err == nil {
+		cache = httpcache.New(filepath.Join(dir, httpcache.DirName), CacheTTL)
+	}
+	return settings.HTTPClient(timeout, cache)
+}
+
+// downloadVerifier returns the verifier of downloads, checking signatures
+// with keyring when it is not nil. Failures are reported as security
+// warnings, on the terminal and in the audit log. Downloads without a
+// published checksum are rejected when strict, and otherwise accepted with
+// a warning.
+func downloadVerifier(keyring *remote.Keyring, strict bool) *remote.Verifier {
+	recorder, err := auditRecorder()
+	if err != nil {
+		logging.Warn("Security warnings will not be recorded: %v", err)
+	}
+	return &remote.Verifier{
+		Keyring: keyring,
+		Strict:  strict,
+		OnFailure: func(failure remote.Failure) {
+			logging.Warn("Security warning: %s", failure.Message())
+			if recorder != nil {
+				if err := recorder.Warn(failure.URL, audit.OperationVerify, failure.Err.Error()); err != nil {
+					logging.Warn("Recording security warning: %v", err)
+				}
+			}
+		},
+		OnWarning: func(warning remote.Failure) {
+			logging.Warn("%s: not verified, no checksum published (--strict rejects it)", warning.URL)
+		},
+	}
+}
@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+var (
+	moduleFile     string
+	moduleScaffold bool
+	moduleTemplate string
+)
+
+var ModuleCmd = &cobra.Command{
+	Use:   "module",
+	Short: "Manage modules of an aggregator POM",
+	Long:  `Add, remove, and list entries in the <modules> section of an aggregator POM.`,
+	Example: `  pom-manager module list
+  pom-manager module add core --scaffold
+  pom-manager module remove core --file parent/pom.xml`,
+}
+
+var moduleAddCmd = &cobra.Command{
+	Use:   "add <module>",
+	Short: "Add a module to the aggregator POM",
+	Long: `Add a module path to the <modules> section of an aggregator POM.
+
+With --scaffold, the module directory is created with its own pom.xml whose
+<parent> references the aggregator and inherits its groupId and version.`,
+	Example: `  pom-manager module add core
+  pom-manager module add services/api --scaffold --template java-library`,
+	Args: cobra.ExactArgs(1),
+	RunE: runModuleAdd,
+}
+
+var moduleRemoveCmd = &cobra.Command{
+	Use:     "remove <module>",
+	Short:   "Remove a module from the aggregator POM",
+	Long:    `Remove a module path from the <modules> section. The module directory is left untouched.`,
+	Example: `  pom-manager module remove core`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runModuleRemove,
+}
+
+var moduleListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List modules of the aggregator POM",
+	Long:    `List the modules declared in the aggregator POM and whether each module's pom.xml exists.`,
+	Example: `  pom-manager module list --file parent/pom.xml`,
+	Args:    cobra.NoArgs,
+	RunE:    runModuleList,
+}
+
+func init() {
+	ModuleCmd.PersistentFlags().StringVarP(&moduleFile, "file", "f", "pom.xml", "aggregator POM file")
+
+	moduleAddCmd.Flags().BoolVar(&moduleScaffold, "scaffold", false, "create the module directory with a child pom.xml")
+	moduleAddCmd.Flags().StringVarP(&moduleTemplate, "template", "t", "basic-java", "template for the scaffolded child POM")
+	moduleAddCmd.RegisterFlagCompletionFunc("template", completeTemplates)
+	markWrites(moduleAddCmd)
+	markWrites(moduleRemoveCmd)
+
+	ModuleCmd.AddCommand(moduleAddCmd)
+	ModuleCmd.AddCommand(moduleRemoveCmd)
+	ModuleCmd.AddCommand(moduleListCmd)
+}
+
+func runModuleAdd(cmd *cobra.Command, args []string) error {
+	module := args[0]
+
+	// Parse aggregator POM
+	parser := pom.NewParser()
+	project, err := parser.ParseFile(moduleFile)
+	if err != nil {
+		return fmt.Errorf("parsing POM: %w", err)
+	}
+
+	packagingChanged, err := pom.AddModule(project, module)
+	if err != nil {
+		return err
+	}
+	if packagingChanged {
+		logging.Info("Packaging changed to 'pom' (required for aggregator projects)")
+	}
+
+	// Scaffold child module before touching the aggregator
+	if moduleScaffold {
+		childPath := filepath.Join(filepath.Dir(moduleFile), filepath.FromSlash(module), "pom.xml")
+		if _, err := os.Stat(childPath); err == nil {
+			return fmt.Errorf("module POM already exists: %s", childPath)
+		}
+
+		child, err := pom.NewChildProject(project, module, templateManager(), moduleTemplate)
+		if err != nil {
+			return fmt.Errorf("creating module POM: %w", err)
+		}
+
+		err = track(childPath, "module add "+module+" --scaffold", func() error {
+			return pom.NewGenerator().GenerateToFile(child, childPath)
+		})
+		if err != nil {
+			return fmt.Errorf("writing module POM: %w", err)
+		}
+		logging.Success("Created module POM: %s", childPath)
+	}
+
+	// Edited in place, so what the model does not hold, such as comments
+	// and <repositories>, is kept
+	err = editPOMFile(moduleFile, "module add "+module, func(data []byte, banner *pom.Banner) ([]byte, error) {
+		return pom.AddModuleElement(data, module, banner)
+	})
+	if err != nil {
+		return err
+	}
+
+	logging.Success("Module '%s' added to %s", module, moduleFile)
+	return nil
+}
+
+func runModuleRemove(cmd *cobra.Command, args []string) error {
+	module := args[0]
+
+	parser := pom.NewParser()
+	project, err := parser.ParseFile(moduleFile)
+	if err != nil {
+		return fmt.Errorf("parsing POM: %w", err)
+	}
+
+	if err := pom.RemoveModule(project, module); err != nil {
+		return err
+	}
+
+	err = editPOMFile(moduleFile, "module remove "+module, func(data []byte, banner *pom.Banner) ([]byte, error) {
+		return pom.RemoveModuleElement(data, module, banner)
+	})
+	if err != nil {
+		return err
+	}
+
+	logging.Success("Module '%s' removed from %s", module, moduleFile)
+	return nil
+}
+
+func runModuleList(cmd *cobra.Command, args []string) error {
+	parser := pom.NewParser()
+	project, err := parser.ParseFile(moduleFile)
+	if err != nil {
+		return fmt.Errorf("parsing POM: %w", err)
+	}
+
+	if len(project.Modules) == 0 {
+		logging.Info("No modules declared in %s", moduleFile)
+		return nil
+	}
+
+	logging.Print(logging.StyleInfo, "Modules (%d):", len(project.Modules))
+	baseDir := filepath.Dir(moduleFile)
+	for _, module := range project.Modules {
+		childPath := filepath.Join(baseDir, filepath.FromSlash(module), "pom.xml")
+		if _, err := os.Stat(childPath); err == nil {
+			fmt.Printf("  - %s\n", module)
+		} else {
+			fmt.Printf("  - %s ", module)
+			logging.Print(logging.StyleBad, "(missing %s)", childPath)
+		}
+	}
+
+	return nil
+}
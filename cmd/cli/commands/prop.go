@@ -0,0 +1,179 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+var propFile string
+
+// propertyName matches names that can be written as an XML element
+var propertyName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.\-]*$`)
+
+var PropCmd = &cobra.Command{
+	Use:   "prop",
+	Short: "Read and change POM properties",
+	Long: `Read and change the <properties> of a POM file, so scripts can bump
+maven.compiler.release or version properties without editing XML.
+
+Only properties declared in the POM itself are shown, not inherited ones.`,
+	Example: `  pom-manager prop set maven.compiler.release 21
+  pom-manager prop get junit.version
+  pom-manager prop remove junit.version
+  pom-manager prop list --file module/pom.xml`,
+}
+
+var propSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a property, adding it when missing",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runPropSet,
+}
+
+var propGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the value of a property",
+	Long:  `Print the value of a property. Exits with an error when the POM does not declare it.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPropGet,
+}
+
+var propRemoveCmd = &cobra.Command{
+	Use:   "remove <key>",
+	Short: "Remove a property",
+	Long: `Remove a property from the POM. When other parts of the POM refer to it as
+${key}, they are listed and you are asked to confirm, since their values
+no longer interpolate; without a terminal, pass --yes to remove it anyway.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPropRemove,
+}
+
+var propListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Print all properties as key=value lines",
+	Args:  cobra.NoArgs,
+	RunE:  runPropList,
+}
+
+func init() {
+	PropCmd.PersistentFlags().StringVarP(&propFile, "file", "f", "pom.xml", "POM file")
+
+	markWrites(propSetCmd)
+	markWrites(propRemoveCmd)
+
+	PropCmd.AddCommand(propSetCmd)
+	PropCmd.AddCommand(propGetCmd)
+	PropCmd.AddCommand(propRemoveCmd)
+	PropCmd.AddCommand(propListCmd)
+}
+
+func runPropSet(cmd *cobra.Command, args []string) error {
+	key, value := args[0], args[1]
+	if !propertyName.MatchString(key) {
+		return fmt.Errorf("%w: %q is not a valid property name", pom.ErrInvalidFormat, key)
+	}
+
+	parser := pom.NewParser()
+	project, err := parser.ParseFile(propFile)
+	if err != nil {
+		return fmt.Errorf("parsing POM: %w", err)
+	}
+
+	old, exists := project.Properties[key]
+	if exists && old == value {
+		logging.Info("%s is already %s", key, value)
+		return nil
+	}
+
+	// Edited in place, so what the model does not hold, such as comments
+	// and <repositories>, is kept
+	err = editPOMFile(propFile, fmt.Sprintf("prop set %s=%s", key, value), func(data []byte, banner *pom.Banner) ([]byte, error) {
+		if exists {
+			return pom.SetProperty(data, key, value, banner)
+		}
+		return pom.AddProperty(data, key, value, banner)
+	})
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		logging.Success("Updated %s from %s to %s in %s", key, old, value, propFile)
+	} else {
+		logging.Success("Added %s = %s to %s", key, value, propFile)
+	}
+	return nil
+}
+
+func runPropGet(cmd *cobra.Command, args []string) error {
+	parser := pom.NewParser()
+	project, err := parser.ParseFile(propFile)
+	if err != nil {
+		return fmt.Errorf("parsing POM: %w", err)
+	}
+
+	value, ok := project.Properties[args[0]]
+	if !ok {
+		return fmt.Errorf("property %s is not set in %s", args[0], propFile)
+	}
+	fmt.Println(value)
+	return nil
+}
+
+func runPropRemove(cmd *cobra.Command, args []string) error {
+	key := args[0]
+
+	parser := pom.NewParser()
+	project, err := parser.ParseFile(propFile)
+	if err != nil {
+		return fmt.Errorf("parsing POM: %w", err)
+	}
+
+	value, ok := project.Properties[key]
+	if !ok {
+		return fmt.Errorf("property %s is not set in %s", key, propFile)
+	}
+
+	confirmed, err := confirmImpact("Removing "+key, pom.PropertyRemovalImpact(project, key))
+	if err != nil {
+		cmd.SilenceUsage = true // Not a usage mistake
+		return err
+	}
+	if !confirmed {
+		logging.Info("Cancelled")
+		return nil
+	}
+
+	err = editPOMFile(propFile, "prop remove "+key, func(data []byte, banner *pom.Banner) ([]byte, error) {
+		return pom.RemoveProperty(data, key, banner)
+	})
+	if err != nil {
+		return err
+	}
+
+	logging.Success("Removed %s (was %s) from %s", key, value, propFile)
+	return nil
+}
+
+func runPropList(cmd *cobra.Command, args []string) error {
+	parser := pom.NewParser()
+	project, err := parser.ParseFile(propFile)
+	if err != nil {
+		return fmt.Errorf("parsing POM: %w", err)
+	}
+
+	keys := make([]string, 0, len(project.Properties))
+	for key := range project.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Printf("%s=%s\n", key, project.Properties[key])
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// runCommand executes args on the test root and returns what it printed
+// to stdout
+func runCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Expected a pipe, got %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+
+	output := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, reader)
+		output <- buf.String()
+	}()
+
+	root := testRoot()
+	root.SetArgs(args)
+	err = root.Execute()
+	writer.Close()
+	return <-output, err
+}
+
+func TestPropCommands(t *testing.T) {
+	if readOnlyBuild {
+		t.Skip("files cannot be changed in a read-only build")
+	}
+	setTestHome(t)
+	t.Setenv(ReadOnlyEnv, "")
+	path := writeTestPOM(t)
+
+	if _, err := runCommand(t, "prop", "set", "junit.version", "4.13.2", "-f", path); err != nil {
+		t.Fatalf("Expected prop set to add a property, got %v", err)
+	}
+	if _, err := runCommand(t, "prop", "set", "maven.compiler.release", "17", "-f", path); err != nil {
+		t.Fatalf("Expected prop set to add a property, got %v", err)
+	}
+	if _, err := runCommand(t, "prop", "set", "maven.compiler.release", "21", "-f", path); err != nil {
+		t.Fatalf("Expected prop set to update a property, got %v", err)
+	}
+
+	if got, err := runCommand(t, "prop", "get", "maven.compiler.release", "-f", path); err != nil || got != "21\n" {
+		t.Errorf("Expected prop get to print 21, got %q, %v", got, err)
+	}
+	want := "junit.version=4.13.2\nmaven.compiler.release=21\n"
+	if got, err := runCommand(t, "prop", "list", "-f", path); err != nil || got != want {
+		t.Errorf("Expected prop list to print sorted key=value lines %q, got %q, %v", want, got, err)
+	}
+
+	project, err := pom.NewParser().ParseFile(path)
+	if err != nil || project.Properties["maven.compiler.release"] != "21" {
+		t.Errorf("Expected the property written to the POM, got %v", err)
+	}
+}
+
+func TestPropCommandErrors(t *testing.T) {
+	setTestHome(t)
+	t.Setenv(ReadOnlyEnv, "")
+	path := writeTestPOM(t)
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"get undefined", []string{"prop", "get", "missing.version", "-f", path}, "property missing.version is not set"},
+		{"set invalid name", []string{"prop", "set", "1st version", "1.0", "-f", path}, "is not a valid property name"},
+		{"remove undefined", []string{"prop", "remove", "missing.version", "-f", path}, "property missing.version is not set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runCommand(t, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+	if data, _ := os.ReadFile(path); string(data) != readOnlyTestPOM {
+		t.Errorf("Expected the POM to be left alone, got:\n%s", data)
+	}
+}
+
+const propKeepTestPOM = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- Licensed under the Apache License -->
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <modelVersion>4.0.0</modelVersion>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0.0</version>
+    <relativePath/>
+  </parent>
+  <artifactId>demo</artifactId>
+  <properties>
+    <!-- Kept in step with the parent -->
+    <junit.version>4.13.2</junit.version>
+  </properties>
+  <repositories>
+    <repository>
+      <id>internal</id>
+      <url>https://repo.example.com/maven</url>
+    </repository>
+  </repositories>
+  <build>
+    <resources>
+      <resource>
+        <directory>src/main/config</directory>
+      </resource>
+    </resources>
+    <plugins>
+      <plugin>
+        <groupId>org.apache.maven.plugins</groupId>
+        <artifactId>maven-antrun-plugin</artifactId>
+        <configuration><target><echo message="kept as written"/></target></configuration>
+      </plugin>
+    </plugins>
+  </build>
+  <distributionManagement>
+    <site>
+      <id>docs</id>
+      <url>scp://docs.example.com/demo</url>
+    </site>
+  </distributionManagement>
+</project>
+`
+
+func TestPropCommandsKeepUnmodeledContent(t *testing.T) {
+	if readOnlyBuild {
+		t.Skip("files cannot be changed in a read-only build")
+	}
+	setTestHome(t)
+	t.Setenv(ReadOnlyEnv, "")
+	path := filepath.Join(t.TempDir(), "pom.xml")
+	if err := os.WriteFile(path, []byte(propKeepTestPOM), 0644); err != nil {
+		t.Fatalf("Expected test POM to be written, got %v", err)
+	}
+
+	if _, err := runCommand(t, "prop", "set", "junit.version", "4.13.3", "-f", path); err != nil {
+		t.Fatalf("Expected prop set to update a property, got %v", err)
+	}
+	if _, err := runCommand(t, "prop", "set", "java.version", "17", "-f", path); err != nil {
+		t.Fatalf("Expected prop set to add a property, got %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	want := strings.Replace(propKeepTestPOM, "<junit.version>4.13.2</junit.version>",
+		"<junit.version>4.13.3</junit.version>\n    <java.version>17</java.version>", 1)
+	if string(data) != want {
+		t.Errorf("Expected only the properties to change, got:\n%s", data)
+	}
+
+	if _, err := runCommand(t, "prop", "remove", "java.version", "-f", path); err != nil {
+		t.Fatalf("Expected prop remove to remove a property, got %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	if want := strings.Replace(propKeepTestPOM, "4.13.2", "4.13.3", 1); string(data) != want {
+		t.Errorf("Expected the POM back as written apart from the update, got:\n%s", data)
+	}
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ReadOnlyEnv is the environment variable that turns on read-only mode
+const ReadOnlyEnv = "POM_MANAGER_READ_ONLY"
+
+// writesAnnotation marks commands and flags that change files
+const writesAnnotation = "pom-manager/writes"
+
+// ErrReadOnly is returned for anything that would write a file in
+// read-only mode
+var ErrReadOnly = errors.New("read-only mode")
+
+// ReadOnly reports whether commands that change files are disabled, because
+// the binary was built with the readonly tag or ReadOnlyEnv is set. Values
+// that are not booleans turn it on, so a typo never allows writes.
+func ReadOnly() bool {
+	if readOnlyBuild {
+		return true
+	}
+	value, ok := os.LookupEnv(ReadOnlyEnv)
+	if !ok || value == "" {
+		return false
+	}
+	enabled, err := strconv.ParseBool(value)
+	return enabled || err != nil
+}
+
+// markWrites marks a command as changing files, so read-only mode disables it
+func markWrites(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[writesAnnotation] = "true"
+}
+
+// markWritesFlag marks a flag that makes its command change files
+func markWritesFlag(cmd *cobra.Command, name string) {
+	cmd.Flags().SetAnnotation(name, writesAnnotation, []string{"true"})
+}
+
+// HideWriteCommands hides the commands and flags that change files from
+// help and completion below root
+func HideWriteCommands(root *cobra.Command) {
+	for _, cmd := range root.Commands() {
+		if cmd.Annotations[writesAnnotation] != "" {
+			cmd.Hidden = true
+			continue
+		}
+		cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			if len(flag.Annotations[writesAnnotation]) > 0 {
+				flag.Hidden = true
+			}
+		})
+		HideWriteCommands(cmd)
+	}
+}
+
+// CheckReadOnly returns ErrReadOnly in read-only mode when cmd, or one of
+// the flags it was given, changes files. A flag given its default, such as
+// --fix=false, changes nothing.
+func CheckReadOnly(cmd *cobra.Command) error {
+	if !ReadOnly() {
+		return nil
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[writesAnnotation] != "" {
+			return fmt.Errorf("%w: '%s' changes files and is disabled", ErrReadOnly, cmd.CommandPath())
+		}
+	}
+	var err error
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if err == nil && len(flag.Annotations[writesAnnotation]) > 0 && flag.Value.String() != flag.DefValue {
+			err = fmt.Errorf("%w: --%s changes files and is disabled", ErrReadOnly, flag.Name)
+		}
+	})
+	return err
+}
@@ -0,0 +1,7 @@
+//go:build readonly
+
+package commands
+
+// readOnlyBuild makes read-only mode permanent in binaries built with
+// -tags readonly, for CI runners where writes must be impossible
+const readOnlyBuild = true
@@ -0,0 +1,6 @@
+//go:build !readonly
+
+package commands
+
+// readOnlyBuild is false unless built with -tags readonly
+const readOnlyBuild = false
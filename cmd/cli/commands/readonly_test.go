@@ -0,0 +1,243 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+const readOnlyTestPOM = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <modelVersion>4.0.0</modelVersion>
+  <groupId>com.example</groupId>
+  <artifactId>demo</artifactId>
+  <version>1.0.0</version>
+  <dependencies>
+    <dependency>
+      <groupId>junit</groupId>
+      <artifactId>junit</artifactId>
+      <version>4.13.2</version>
+      <scope>test</scope>
+    </dependency>
+  </dependencies>
+</project>
+`
+
+var (
+	readOnlyRoot     *cobra.Command
+	readOnlyRootOnce sync.Once
+)
+
+// testRoot returns a root command checking read-only mode like the one of
+// the CLI; a command has a single parent, so it is built once
+func testRoot() *cobra.Command {
+	readOnlyRootOnce.Do(func() {
+		readOnlyRoot = &cobra.Command{
+			Use:           "pom-manager",
+			SilenceErrors: true,
+			SilenceUsage:  true,
+			PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+				return CheckReadOnly(cmd)
+			},
+		}
+		readOnlyRoot.AddCommand(CreateCmd, ValidateCmd, AddDepCmd, RemoveDepCmd, RemovePluginCmd,
+			UpdateDepCmd, TemplatesCmd, TemplateCmd, InfoCmd, ModuleCmd, VersionsCmd, PropCmd,
+			ImportCmd, ConvertCmd, ExportCmd, FormatCmd, DiffCmd, ClasspathCmd, CatalogCmd,
+			DocsCmd, AuditLogCmd)
+	})
+	return readOnlyRoot
+}
+
+// setReadOnly turns on read-only mode for the test
+func setReadOnly(t *testing.T) {
+	t.Helper()
+	setTestHome(t)
+	t.Setenv(ReadOnlyEnv, "1")
+}
+
+// setTestHome keeps settings, caches and the audit log of the test out of
+// the home directory
+func setTestHome(t *testing.T) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, ".local", "state"))
+}
+
+// writeTestPOM writes readOnlyTestPOM to a temporary directory
+func writeTestPOM(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pom.xml")
+	if err := os.WriteFile(path, []byte(readOnlyTestPOM), 0644); err != nil {
+		t.Fatalf("Expected test POM to be written, got %v", err)
+	}
+	return path
+}
+
+func TestReadOnly(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		set   bool
+		want  bool
+	}{
+		{"unset", "", false, false},
+		{"empty", "", true, false},
+		{"zero", "0", true, false},
+		{"false", "false", true, false},
+		{"one", "1", true, true},
+		{"true", "true", true, true},
+		{"not a boolean", "yes", true, true}, // A typo never allows writes
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.set {
+				t.Setenv(ReadOnlyEnv, tt.value)
+			} else {
+				t.Setenv(ReadOnlyEnv, "")
+				os.Unsetenv(ReadOnlyEnv)
+			}
+			if got := ReadOnly(); got != (tt.want || readOnlyBuild) {
+				t.Errorf("Expected ReadOnly() %v, got %v", tt.want || readOnlyBuild, got)
+			}
+		})
+	}
+}
+
+func TestCheckReadOnlyRejectsWriteCommands(t *testing.T) {
+	setReadOnly(t)
+
+	var writers []string
+	var visit func(cmd *cobra.Command)
+	visit = func(cmd *cobra.Command) {
+		for _, c := range cmd.Commands() {
+			visit(c)
+		}
+		if cmd.Annotations[writesAnnotation] == "" {
+			if err := CheckReadOnly(cmd); err != nil {
+				t.Errorf("Expected '%s' to be allowed, got %v", cmd.CommandPath(), err)
+			}
+			return
+		}
+		writers = append(writers, cmd.CommandPath())
+		if err := CheckReadOnly(cmd); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("Expected ErrReadOnly for '%s', got %v", cmd.CommandPath(), err)
+		}
+	}
+	visit(testRoot())
+
+	// Catches a command that changes files but was never marked
+	want := []string{
+		"pom-manager add-dep",
+		"pom-manager catalog refresh",
+		"pom-manager create",
+		"pom-manager docs",
+		"pom-manager import",
+		"pom-manager module add",
+		"pom-manager module remove",
+		"pom-manager prop remove",
+		"pom-manager prop set",
+		"pom-manager remove-dep",
+		"pom-manager remove-plugin",
+		"pom-manager template delete",
+		"pom-manager template new",
+		"pom-manager template save",
+		"pom-manager template update",
+		"pom-manager update-dep",
+		"pom-manager versions import",
+		"pom-manager versions sync",
+	}
+	sort.Strings(writers)
+	if !reflect.DeepEqual(writers, want) {
+		t.Errorf("Expected write commands %v, got %v", want, writers)
+	}
+}
+
+func TestReadOnlyCommandsFail(t *testing.T) {
+	setReadOnly(t)
+	path := writeTestPOM(t)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"remove-dep", []string{"remove-dep", "-g", "junit", "-a", "junit", "-f", path}},
+		{"update-dep", []string{"update-dep", "-g", "junit", "-a", "junit", "-V", "4.13.3", "-f", path}},
+		{"validate --fix", []string{"validate", "--fix", path}},
+	}
+	defer resetFlag(t, ValidateCmd, "fix")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := testRoot()
+			root.SetArgs(tt.args)
+			if err := root.Execute(); !errors.Is(err, ErrReadOnly) {
+				t.Errorf("Expected ErrReadOnly, got %v", err)
+			}
+			if data, _ := os.ReadFile(path); string(data) != readOnlyTestPOM {
+				t.Errorf("Expected the POM to be left alone, got:\n%s", data)
+			}
+		})
+	}
+}
+
+func TestReadOnlyInspectCommandsWork(t *testing.T) {
+	setReadOnly(t)
+	path := writeTestPOM(t)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"validate", []string{"validate", path}},
+		{"validate --fix=false", []string{"validate", "--fix=false", path}},
+		{"info", []string{"info", path}},
+		{"diff", []string{"diff", path, path}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := testRoot()
+			root.SetArgs(tt.args)
+			if err := root.Execute(); err != nil {
+				t.Errorf("Expected %s to work in read-only mode, got %v", tt.name, err)
+			}
+		})
+	}
+}
+
+func TestTrackRefusesWrites(t *testing.T) {
+	setReadOnly(t)
+
+	wrote := false
+	err := track(filepath.Join(t.TempDir(), "pom.xml"), "test", func() error {
+		wrote = true
+		return nil
+	})
+	if !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly, got %v", err)
+	}
+	if wrote {
+		t.Error("Expected the write not to run in read-only mode")
+	}
+}
+
+// resetFlag restores a flag to its default after executions of the shared
+// command tree
+func resetFlag(t *testing.T, cmd *cobra.Command, name string) {
+	t.Helper()
+	flag := cmd.Flags().Lookup(name)
+	if err := flag.Value.Set(flag.DefValue); err != nil {
+		t.Fatalf("Expected --%s to reset, got %v", name, err)
+	}
+	flag.Changed = false
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+var (
+	removeDepGroup    string
+	removeDepArtifact string
+	removeDepFile     string
+)
+
+var RemoveDepCmd = &cobra.Command{
+	Use:   "remove-dep",
+	Short: "Remove a dependency from a POM file",
+	Long: `Remove a Maven dependency from an existing POM file. Every declaration of
+the groupId:artifactId is removed, whatever its classifier or type. The
+rest of the file, comments and formatting included, is kept as written.`,
+	Example: `  pom-manager remove-dep --group junit --artifact junit
+  pom-manager remove-dep -g org.slf4j -a slf4j-api --file myproject/pom.xml`,
+	Args: cobra.NoArgs,
+	RunE: runRemoveDep,
+}
+
+func init() {
+	RemoveDepCmd.Flags().StringVarP(&removeDepGroup, "group", "g", "", "dependency groupId (required)")
+	RemoveDepCmd.Flags().StringVarP(&removeDepArtifact, "artifact", "a", "", "dependency artifactId (required)")
+	RemoveDepCmd.Flags().StringVarP(&removeDepFile, "file", "f", "pom.xml", "POM file to modify")
+	RemoveDepCmd.MarkFlagRequired("group")
+	RemoveDepCmd.MarkFlagRequired("artifact")
+	markWrites(RemoveDepCmd)
+}
+
+func runRemoveDep(cmd *cobra.Command, args []string) error {
+	repo := pom.NewRepository()
+	data, err := repo.Read(removeDepFile)
+	if err != nil {
+		return err
+	}
+	// Edited in place, so what the model does not hold, such as comments
+	// and <repositories>, is kept
+	edited, removed, err := pom.RemoveDependency(data, removeDepGroup, removeDepArtifact, pom.BannerFor(removeDepFile))
+	if err != nil {
+		return fmt.Errorf("%w in %s", err, removeDepFile)
+	}
+
+	err = track(removeDepFile, fmt.Sprintf("remove-dep %s:%s", removeDepGroup, removeDepArtifact), func() error {
+		return repo.Write(removeDepFile, edited)
+	})
+	if err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	logging.Success("Removed %s:%s from %s", removeDepGroup, removeDepArtifact, removeDepFile)
+	if removed > 1 {
+		fmt.Printf("  %d declarations removed\n", removed)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+var (
+	removePluginGroup    string
+	removePluginArtifact string
+	removePluginFile     string
+)
+
+var RemovePluginCmd = &cobra.Command{
+	Use:   "remove-plugin",
+	Short: "Remove a build plugin from a POM file",
+	Long: `Remove a build plugin from an existing POM file, together with its
+executions and configuration. When the plugin has executions or
+configuration, they are listed and you are asked to confirm; without a
+terminal, pass --yes to remove them anyway.
+
+Only plugins of the main build are removed, not those in profiles.`,
+	Example: `  pom-manager remove-plugin --artifact maven-jar-plugin
+  pom-manager remove-plugin -g org.jetbrains.kotlin -a kotlin-maven-plugin --yes`,
+	Args: cobra.NoArgs,
+	RunE: runRemovePlugin,
+}
+
+func init() {
+	RemovePluginCmd.Flags().StringVarP(&removePluginGroup, "group", "g", pom.DefaultPluginGroupID, "plugin groupId")
+	RemovePluginCmd.Flags().StringVarP(&removePluginArtifact, "artifact", "a", "", "plugin artifactId (required)")
+	RemovePluginCmd.Flags().StringVarP(&removePluginFile, "file", "f", "pom.xml", "POM file to modify")
+	RemovePluginCmd.MarkFlagRequired("artifact")
+	markWrites(RemovePluginCmd)
+}
+
+func runRemovePlugin(cmd *cobra.Command, args []string) error {
+	parser := pom.NewParser()
+	project, err := parser.ParseFile(removePluginFile)
+	if err != nil {
+		return fmt.Errorf("parsing POM: %w", err)
+	}
+
+	name := removePluginGroup + ":" + removePluginArtifact
+	index := -1
+	if project.Build != nil {
+		for i, plugin := range project.Build.Plugins {
+			if plugin.GroupID == removePluginGroup && plugin.ArtifactID == removePluginArtifact {
+				index = i
+				break
+			}
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("%w: %s in %s", pom.ErrPluginNotFound, name, removePluginFile)
+	}
+
+	ok, err := confirmImpact("Removing "+name, pom.PluginRemovalImpact(project.Build.Plugins[index]))
+	if err != nil {
+		cmd.SilenceUsage = true // Not a usage mistake
+		return err
+	}
+	if !ok {
+		logging.Info("Cancelled")
+		return nil
+	}
+
+	// Edited in place, so what the model does not hold, such as comments
+	// and plugin configuration it cannot represent, is kept
+	err = editPOMFile(removePluginFile, "remove-plugin "+name, func(data []byte, banner *pom.Banner) ([]byte, error) {
+		return pom.RemovePlugin(data, removePluginGroup, removePluginArtifact, banner)
+	})
+	if err != nil {
+		return err
+	}
+
+	logging.Success("Removed %s from %s", name, removePluginFile)
+	return nil
+}
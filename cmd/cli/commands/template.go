@@ -0,0 +1,530 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/appdir"
+	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/core/registry"
+)
+
+var (
+	templateDir         string
+	templateFrom        string
+	templateDescription string
+	templateForce       bool
+	templateSet         []string
+	templateOutput      string
+	templateName        string
+	templatePOM         string
+)
+
+var TemplateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage POM templates",
+	Long: `List, inspect, create, check, preview, and delete POM templates.
+
+Custom templates are YAML files in the template directory (by default
+"templates" in the config directory). String values may contain {{name}}
+placeholders: {{groupId}}, {{artifactId}} and {{version}} are filled in from
+the new project's coordinates, other names from --set. Variables declares
+placeholders with a description and a default; "create" asks for them.
+Templates shared by a team are fetched with "template update", and
+"template verify" checks with Maven that templates still build.
+
+  name: my-stack
+  description: Service with logging
+  variables:
+    - name: javaVersion
+      description: Java release to compile for
+      default: "17"
+  packaging: jar
+  properties:
+    maven.compiler.release: "{{javaVersion}}"
+  dependencies:
+    - groupId: org.slf4j
+      artifactId: slf4j-api
+      version: 2.0.9`,
+	Example: `  pom-manager template list
+  pom-manager template new my-stack --from java-library
+  pom-manager template save --name my-stack --from pom.xml
+  pom-manager template validate my-stack
+  pom-manager template update --url https://git.example.com/acme/templates.git --ref v2.1
+  pom-manager template render my-stack --set groupId=com.acme --set javaVersion=17`,
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List built-in, custom and registry templates",
+	Args:  cobra.NoArgs,
+	RunE:  runTemplateList,
+}
+
+var templateShowCmd = &cobra.Command{
+	Use:   "show <name|file>",
+	Short: "Print a template definition and its variables",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTemplateShow,
+}
+
+var templateNewCmd = &cobra.Command{
+	Use:   "new <name>",
+	Short: "Create a custom template",
+	Long: `Write a new template definition to the template directory, starting
+from a built-in or custom template (--from) or from an empty one.`,
+	Example: `  pom-manager template new my-stack
+  pom-manager template new my-lib --from java-library --description "Library with JUnit 5"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateNew,
+}
+
+var templateSaveCmd = &cobra.Command{
+	Use:   "save",
+	Short: "Save a project's POM as a custom template",
+	Long: `Write a custom template reproducing an existing POM: its packaging, parent,
+properties, dependencies and plugins. The project's own coordinates become
+the {{groupId}}, {{artifactId}} and {{version}} placeholders, as do those of
+dependencies on sibling artifacts released with it. The description is the
+POM's <description> unless --description is given.`,
+	Example: `  pom-manager template save --name my-stack
+  pom-manager template save --name my-service --from service/pom.xml --description "Service with logging"`,
+	Args: cobra.NoArgs,
+	RunE: runTemplateSave,
+}
+
+var templateDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a custom template",
+	Long: `Delete the file of a custom template from the template directory.
+Built-in templates cannot be deleted.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateDelete,
+}
+
+var templateValidateCmd = &cobra.Command{
+	Use:   "validate <name|file>",
+	Short: "Check that a template renders a valid POM",
+	Long: `Parse a template definition, render it with sample coordinates and the
+values given with --set, and validate the resulting POM.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateValidate,
+}
+
+var templateRenderCmd = &cobra.Command{
+	Use:   "render <name|file>",
+	Short: "Preview the POM a template generates",
+	Long: `Render a template to POM XML without creating a project. Coordinates
+are set with --set groupId=..., --set artifactId=..., --set version=...`,
+	Example: `  pom-manager template render java-library --set artifactId=my-lib
+  pom-manager template render ./my-stack.yaml --set javaVersion=21 -o preview.xml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateRender,
+}
+
+func init() {
+	TemplateCmd.PersistentFlags().StringVar(&templateDir, "dir", "", "custom template directory (default: templates in the config directory)")
+
+	templateNewCmd.Flags().StringVar(&templateFrom, "from", "", "template to start from")
+	templateNewCmd.RegisterFlagCompletionFunc("from", completeTemplatesOrFiles)
+	templateNewCmd.Flags().StringVarP(&templateDescription, "description", "d", "", "template description")
+	templateNewCmd.Flags().BoolVarP(&templateForce, "force", "f", false, "overwrite an existing template")
+	markWrites(templateNewCmd)
+
+	templateSaveCmd.Flags().StringVarP(&templateName, "name", "n", "", "template name")
+	templateSaveCmd.Flags().StringVar(&templatePOM, "from", "pom.xml", "POM file to save as a template")
+	templateSaveCmd.MarkFlagFilename("from", "xml")
+	templateSaveCmd.Flags().StringVarP(&templateDescription, "description", "d", "", "template description (default: the POM's description)")
+	templateSaveCmd.Flags().BoolVarP(&templateForce, "force", "f", false, "overwrite an existing template")
+	templateSaveCmd.MarkFlagRequired("name")
+	markWrites(templateSaveCmd)
+
+	templateDeleteCmd.ValidArgsFunction = completeOnce(completeCustomTemplates)
+	markWrites(templateDeleteCmd)
+
+	for _, cmd := range []*cobra.Command{templateValidateCmd, templateRenderCmd} {
+		cmd.Flags().StringArrayVar(&templateSet, "set", nil, "set a template variable (name=value, repeatable)")
+	}
+	templateRenderCmd.Flags().StringVarP(&templateOutput, "output", "o", "", "write the POM to a file instead of stdout")
+
+	for _, cmd := range []*cobra.Command{templateShowCmd, templateValidateCmd, templateRenderCmd} {
+		cmd.ValidArgsFunction = completeOnce(completeTemplatesOrFiles)
+	}
+
+	TemplateCmd.AddCommand(templateListCmd, templateShowCmd, templateNewCmd, templateSaveCmd, templateDeleteCmd, templateValidateCmd, templateRenderCmd)
+}
+
+// customTemplateDir returns the directory holding custom templates
+func customTemplateDir() (string, error) {
+	if templateDir != "" {
+		return templateDir, nil
+	}
+	configDir, err := appdir.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "templates"), nil
+}
+
+// customTemplates returns the template files in the template directory
+// by template name
+func customTemplates() (map[string]string, error) {
+	dir, err := customTemplateDir()
+	if err != nil {
+		return nil, err
+	}
+	return pom.TemplateFiles(dir)
+}
+
+// templateManager returns a template manager offering the built-in, the
+// custom and the registry templates
+func templateManager() pom.TemplateManager {
+	var dirs []string
+	if dir, err := customTemplateDir(); err == nil {
+		dirs = append(dirs, dir)
+	}
+	if dir := registryTemplateDir(); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	return pom.NewTemplateManager(dirs...)
+}
+
+// loadTemplate resolves a template argument: a template file, a custom
+// template name, a registry template name, or a built-in template name. Returns the definition and
+// where it came from.
+func loadTemplate(arg string) (*pom.TemplateDefinition, string, error) {
+	if pom.IsTemplateFile(arg) {
+		definition, err := pom.LoadTemplateDefinition(arg)
+		return definition, arg, err
+	}
+
+	custom, err := customTemplates()
+	if err != nil {
+		return nil, "", err
+	}
+	if path, ok := custom[arg]; ok {
+		definition, err := pom.LoadTemplateDefinition(path)
+		return definition, path, err
+	}
+	if dir := registryTemplateDir(); dir != "" {
+		shared, err := pom.TemplateFiles(dir)
+		if err != nil {
+			return nil, "", err
+		}
+		if path, ok := shared[arg]; ok && !pom.IsBuiltinTemplate(arg) {
+			definition, err := pom.LoadTemplateDefinition(path)
+			return definition, path, err
+		}
+	}
+
+	// Built-in templates are created with placeholder coordinates and
+	// variables
+	tm := pom.NewTemplateManager()
+	info, ok := findTemplate(tm, arg)
+	if !ok {
+		_, err := tm.Create(arg, pom.Coordinates{})
+		return nil, "", err
+	}
+	values := make(map[string]string)
+	for _, variable := range info.Variables {
+		values[variable.Name] = "{{" + variable.Name + "}}"
+	}
+	project, err := tm.CreateWithValues(arg, pom.Coordinates{
+		GroupID:    "{{" + pom.TemplateVarGroupID + "}}",
+		ArtifactID: "{{" + pom.TemplateVarArtifactID + "}}",
+		Version:    "{{" + pom.TemplateVarVersion + "}}",
+	}, values)
+	if err != nil {
+		return nil, "", err
+	}
+	definition := pom.NewTemplateDefinition(info.Name, info.Description, project)
+	definition.Variables = info.Variables
+	return definition, "built-in", nil
+}
+
+// findTemplate looks up a template offered by tm by name
+func findTemplate(tm pom.TemplateManager, name string) (pom.TemplateInfo, bool) {
+	for _, info := range tm.List() {
+		if info.Name == name {
+			return info, true
+		}
+	}
+	return pom.TemplateInfo{}, false
+}
+
+// templateValues parses --set flags; coordinates default to sample values
+func templateValues() (pom.Coordinates, map[string]string, error) {
+	coords := pom.Coordinates{GroupID: "com.example", ArtifactID: "my-app", Version: "1.0.0-SNAPSHOT"}
+	values := make(map[string]string)
+	for _, set := range templateSet {
+		name, value, ok := strings.Cut(set, "=")
+		if !ok || name == "" {
+			return coords, nil, fmt.Errorf("invalid --set %q (expected name=value)", set)
+		}
+		switch name {
+		case pom.TemplateVarGroupID:
+			coords.GroupID = value
+		case pom.TemplateVarArtifactID:
+			coords.ArtifactID = value
+		case pom.TemplateVarVersion:
+			coords.Version = value
+		default:
+			values[name] = value
+		}
+	}
+	return coords, values, nil
+}
+
+func runTemplateList(cmd *cobra.Command, args []string) error {
+	logging.Print(logging.StyleInfo, "Built-in templates:")
+	for _, t := range pom.NewTemplateManager().List() {
+		logging.Print(logging.StyleGood, "  %s", t.Name)
+		fmt.Printf("    %s\n", t.Description)
+	}
+
+	custom, err := customTemplates()
+	if err != nil {
+		return err
+	}
+	dir, _ := customTemplateDir()
+	if len(custom) == 0 {
+		logging.Info("No custom templates in %s", dir)
+	} else {
+		logging.Print(logging.StyleInfo, "\nCustom templates (%s):", dir)
+		printTemplateFiles(custom)
+	}
+
+	cacheDir, err := appdir.CacheDir()
+	if err != nil {
+		return nil
+	}
+	state, err := registry.LoadState(cacheDir)
+	if err != nil || state == nil {
+		return err
+	}
+	shared, err := pom.TemplateFiles(registry.Dir(cacheDir))
+	if err != nil {
+		return err
+	}
+	logging.Print(logging.StyleInfo, "\nRegistry templates (%s at %s):", state.Source, state.ShortRevision())
+	for name := range shared {
+		if _, ok := custom[name]; ok || pom.IsBuiltinTemplate(name) {
+			delete(shared, name)
+		}
+	}
+	printTemplateFiles(shared)
+	return nil
+}
+
+// printTemplateFiles lists template files by name with their descriptions,
+// or why they failed to load
+func printTemplateFiles(files map[string]string) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		definition, err := pom.LoadTemplateDefinition(files[name])
+		if err != nil {
+			logging.Print(logging.StyleBad, "  %s", name)
+			fmt.Printf("    %v\n", err)
+			continue
+		}
+		logging.Print(logging.StyleGood, "  %s", name)
+		if definition.Description != "" {
+			fmt.Printf("    %s\n", definition.Description)
+		}
+	}
+}
+
+func runTemplateShow(cmd *cobra.Command, args []string) error {
+	definition, source, err := loadTemplate(args[0])
+	if err != nil {
+		return err
+	}
+
+	data, err := definition.Marshal()
+	if err != nil {
+		return fmt.Errorf("formatting template: %w", err)
+	}
+
+	logging.Print(logging.StyleInfo, "# %s (%s)", definition.Name, source)
+	logging.Print(logging.StyleInfo, "# Variables: %s", strings.Join(definition.Placeholders(), ", "))
+	fmt.Print(string(data))
+	return nil
+}
+
+func runTemplateNew(cmd *cobra.Command, args []string) error {
+	definition := &pom.TemplateDefinition{
+		Packaging: pom.DefaultPackaging,
+		Properties: map[string]string{
+			"project.build.sourceEncoding": "UTF-8",
+		},
+	}
+	if templateFrom != "" {
+		from, _, err := loadTemplate(templateFrom)
+		if err != nil {
+			return err
+		}
+		definition = from
+	}
+	definition.Name = args[0]
+	if templateDescription != "" {
+		definition.Description = templateDescription
+	}
+
+	path, err := writeTemplate(definition, "template new "+definition.Name)
+	if err != nil {
+		return err
+	}
+	logging.Success("Created template %s", path)
+	return nil
+}
+
+func runTemplateSave(cmd *cobra.Command, args []string) error {
+	project, err := pom.NewParser().ParseFile(templatePOM)
+	if err != nil {
+		return fmt.Errorf("parsing POM: %w", err)
+	}
+	description := project.Description
+	if templateDescription != "" {
+		description = templateDescription
+	}
+	definition := pom.NewTemplateDefinition(templateName, description, project)
+
+	path, err := writeTemplate(definition, "template save "+templateName+" from "+templatePOM)
+	if err != nil {
+		return err
+	}
+	logging.Success("Saved %s as template %s (%s)", templatePOM, templateName, path)
+	logging.Info("Create projects from it with: pom-manager create --template %s", templateName)
+	return nil
+}
+
+// writeTemplate writes a definition to the template directory as a file
+// named after it, refusing to overwrite one unless --force is given
+func writeTemplate(definition *pom.TemplateDefinition, operation string) (string, error) {
+	dir, err := customTemplateDir()
+	if err != nil {
+		return "", err
+	}
+	path, err := pom.TemplatePath(dir, definition.Name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err == nil && !templateForce {
+		return "", fmt.Errorf("%s already exists (use --force to overwrite)", path)
+	}
+
+	err = track(path, operation, func() error {
+		return definition.WriteFile(path)
+	})
+	if err != nil {
+		return "", fmt.Errorf("writing template: %w", err)
+	}
+	return path, nil
+}
+
+func runTemplateDelete(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if pom.IsBuiltinTemplate(name) {
+		return fmt.Errorf("%q is a built-in template and cannot be deleted", name)
+	}
+	custom, err := customTemplates()
+	if err != nil {
+		return err
+	}
+	path, ok := custom[name]
+	if !ok {
+		dir, _ := customTemplateDir()
+		return fmt.Errorf("%w: no custom template %q in %s", pom.ErrTemplateNotFound, name, dir)
+	}
+
+	err = track(path, "template delete "+name, func() error {
+		return os.Remove(path)
+	})
+	if err != nil {
+		return fmt.Errorf("deleting template: %w", err)
+	}
+	logging.Success("Deleted template %s (%s)", name, path)
+	return nil
+}
+
+func runTemplateValidate(cmd *cobra.Command, args []string) error {
+	definition, source, err := loadTemplate(args[0])
+	if err != nil {
+		return err
+	}
+	logging.Info("Template: %s (%s)", definition.Name, source)
+
+	coords, values, err := templateValues()
+	if err != nil {
+		return err
+	}
+	project, err := definition.Render(coords, values)
+	if err != nil {
+		if errors.Is(err, pom.ErrTemplateVariable) {
+			logging.Error("%v", err)
+			return fmt.Errorf("set the missing variables with --set name=value")
+		}
+		return err
+	}
+
+	result := pom.NewValidator().Validate(project)
+	for _, w := range result.Errors.BySeverity(pom.SeverityWarning) {
+		logging.Warn("%s%s", w.Error(), ruleSuffix(w))
+	}
+	if !result.Valid {
+		var messages []string
+		for _, e := range result.Errors.BySeverity(pom.SeverityError) {
+			messages = append(messages, e.Error()+ruleSuffix(e))
+		}
+		logging.ErrorDetails("Validation failed", messages)
+		return fmt.Errorf("template renders an invalid POM")
+	}
+
+	logging.Success("Template is valid")
+	return nil
+}
+
+func runTemplateRender(cmd *cobra.Command, args []string) error {
+	definition, _, err := loadTemplate(args[0])
+	if err != nil {
+		return err
+	}
+
+	coords, values, err := templateValues()
+	if err != nil {
+		return err
+	}
+	project, err := definition.Render(coords, values)
+	if err != nil {
+		return err
+	}
+
+	data, err := pom.NewGenerator().Generate(project)
+	if err != nil {
+		return fmt.Errorf("generating POM: %w", err)
+	}
+
+	if templateOutput == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	err = track(templateOutput, "template render "+definition.Name, func() error {
+		return os.WriteFile(templateOutput, data, 0644)
+	})
+	if err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+	logging.Success("Rendered %s to %s", definition.Name, templateOutput)
+	return nil
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/appdir"
+	"github.com/user/pom-manager/internal/core/registry"
+)
+
+var (
+	registryURL     string
+	registryRef     string
+	registryTimeout time.Duration
+)
+
+var templateUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Fetch the templates of a shared template registry",
+	Long: `Fetch the templates of a template registry into the cache directory, where
+"create", "template list" and the GUI offer them after your own custom
+templates. Templates with the name of a custom or built-in template are
+left out.
+
+A registry is a Git repository holding template files at its top level or
+in a templates directory, or an HTTPS URL of a .zip archive of template
+files or of a single template file. --ref pins a Git registry to a tag,
+branch or commit; downloads are pinned by the URL itself.
+
+Without --url, the registry of the last update is fetched again, with the
+version given by --ref or else the one pinned last time.`,
+	Example: `  pom-manager template update --url https://git.example.com/acme/templates.git --ref v2.1
+  pom-manager template update --url https://example.com/templates-2.1.zip
+  pom-manager template update`,
+	Args: cobra.NoArgs,
+	RunE: runTemplateUpdate,
+}
+
+func init() {
+	templateUpdateCmd.Flags().StringVar(&registryURL, "url", "", "Git repository or HTTPS URL of the registry (default: the last one)")
+	templateUpdateCmd.Flags().StringVar(&registryRef, "ref", "", "Git tag, branch or commit to pin")
+	templateUpdateCmd.Flags().DurationVar(&registryTimeout, "timeout", 2*time.Minute, "time limit for fetching")
+	markWrites(templateUpdateCmd)
+	TemplateCmd.AddCommand(templateUpdateCmd)
+}
+
+func runTemplateUpdate(cmd *cobra.Command, args []string) error {
+	cacheDir, err := appdir.CacheDir()
+	if err != nil {
+		return err
+	}
+	previous, err := registry.LoadState(cacheDir)
+	if err != nil {
+		return err
+	}
+
+	source := registry.Source{URL: registryURL, Ref: registryRef}
+	if source.URL == "" {
+		if previous == nil {
+			return fmt.Errorf("no template registry yet: pass --url")
+		}
+		source.URL = previous.Source.URL
+		if !cmd.Flags().Changed("ref") {
+			source.Ref = previous.Source.Ref
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), registryTimeout)
+	defer cancel()
+
+	logging.Info("Fetching templates from %s", source)
+	state, err := registry.Update(ctx, cacheDir, source, &http.Client{Timeout: registryTimeout})
+	if err != nil {
+		return fmt.Errorf("updating template registry: %w", err)
+	}
+
+	for _, skipped := range state.Skipped {
+		logging.Warn("Left out %s", skipped)
+	}
+	if previous != nil && previous.Source == source && previous.Revision == state.Revision {
+		logging.Success("Template registry is up to date (%d template(s))", len(state.Templates))
+	} else {
+		logging.Success("Fetched %d template(s) at %s", len(state.Templates), state.ShortRevision())
+	}
+	for _, name := range state.Templates {
+		fmt.Printf("  %s\n", name)
+	}
+	return nil
+}
+
+// registryTemplateDir returns the directory holding the templates of the
+// last registry update, or "" when there is none
+func registryTemplateDir() string {
+	cacheDir, err := appdir.CacheDir()
+	if err != nil {
+		return ""
+	}
+	if state, err := registry.LoadState(cacheDir); err != nil || state == nil {
+		return ""
+	}
+	return registry.Dir(cacheDir)
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/maven"
+)
+
+var (
+	verifyMaven   string
+	verifyOffline bool
+	verifyTimeout time.Duration
+	verifyVerbose bool
+)
+
+var templateVerifyCmd = &cobra.Command{
+	Use:   "verify [template...]",
+	Short: "Build projects generated from templates with Maven",
+	Long: `Generate a temporary project from each template and run mvn -q verify
+on it, to confirm the templates still build after plugin versions change.
+Without arguments every template is verified.
+
+Maven is looked up with --mvn, then MAVEN_HOME, M2_HOME and the PATH.`,
+	Example: `  pom-manager template verify
+  pom-manager template verify basic-java web-app
+  pom-manager template verify --offline --mvn /opt/maven/bin/mvn`,
+	RunE: runTemplateVerify,
+}
+
+func init() {
+	templateVerifyCmd.Flags().StringVar(&verifyMaven, "mvn", "", "path to the mvn launcher")
+	templateVerifyCmd.Flags().BoolVar(&verifyOffline, "offline", false, "build offline with the local repository only")
+	templateVerifyCmd.Flags().DurationVar(&verifyTimeout, "timeout", 10*time.Minute, "time limit for all builds")
+	templateVerifyCmd.Flags().BoolVarP(&verifyVerbose, "verbose", "v", false, "show Maven output of failed builds")
+	templateVerifyCmd.ValidArgsFunction = completeTemplates
+	TemplateCmd.AddCommand(templateVerifyCmd)
+}
+
+func runTemplateVerify(cmd *cobra.Command, args []string) error {
+	mvn, err := maven.Find(verifyMaven)
+	if err != nil {
+		return err
+	}
+
+	var mvnArgs []string
+	if verifyOffline {
+		mvnArgs = append(mvnArgs, "-o")
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), verifyTimeout)
+	defer cancel()
+
+	logging.Info("Verifying templates with %s", mvn)
+	failed := 0
+	for _, result := range maven.VerifyTemplates(ctx, mvn, templateManager(), args, mvnArgs...) {
+		if result.OK() {
+			logging.Success("%s (%s)", result.Template, result.Duration.Round(time.Second))
+			continue
+		}
+		failed++
+		logging.Error("%s: %v", result.Template, result.Err)
+		if verifyVerbose && len(result.Output) > 0 {
+			fmt.Println(string(result.Output))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d template(s) failed to build", failed)
+	}
+	return nil
+}
@@ -3,27 +3,34 @@ package commands
 import (
 	"fmt"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/cmd/cli/logging"
 )
 
 var TemplatesCmd = &cobra.Command{
 	Use:   "templates",
 	Short: "List available POM templates",
-	Long:  `List all available Maven POM templates with descriptions.`,
+	Long: `List all available Maven POM templates with descriptions: the built-in
+ones and the custom template files (YAML definitions or POMs with {{name}}
+placeholders) in the templates directory of the config directory.`,
 	Example: `  pom-manager templates`,
 	RunE: runTemplates,
 }
 
 func runTemplates(cmd *cobra.Command, args []string) error {
-	tm := pom.NewTemplateManager()
+	tm := templateManager()
 	templates := tm.List()
 
-	color.Cyan("Available POM Templates:\n")
+	logging.Print(logging.StyleInfo, "Available POM Templates:\n")
 	for _, t := range templates {
-		color.Green("  %s", t.Name)
-		fmt.Printf("    %s\n", t.Description)
+		if t.Path != "" {
+			logging.Print(logging.StyleGood, "  %s (custom: %s)", t.Name, t.Path)
+		} else {
+			logging.Print(logging.StyleGood, "  %s", t.Name)
+		}
+		if t.Description != "" {
+			fmt.Printf("    %s\n", t.Description)
+		}
 	}
 
 	return nil
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/core/remote"
+)
+
+var (
+	updateDepGroup    string
+	updateDepArtifact string
+	updateDepVersion  string
+	updateDepLatest   bool
+	updateDepFile     string
+	updateDepStrict   bool
+)
+
+var UpdateDepCmd = &cobra.Command{
+	Use:   "update-dep",
+	Short: "Change the version of a dependency in a POM file",
+	Long: `Change the version of a dependency declared in an existing POM file.
+
+With --latest, the newest release is looked up on Maven Central, through
+the mirrors and proxies in ~/.m2/settings.xml; metadata not matching its
+published checksum is rejected, and metadata without one is used with a
+warning, or rejected with --strict. When the version is a property reference
+such as ${junit.version}, the property is updated instead, so other
+dependencies sharing it stay in step. The rest of the file, comments and
+formatting included, is kept as written.`,
+	Example: `  pom-manager update-dep -g junit -a junit -V 4.13.2
+  pom-manager update-dep -g org.slf4j -a slf4j-api --latest
+  pom-manager update-dep -g org.slf4j -a slf4j-api --latest --file myproject/pom.xml`,
+	Args: cobra.NoArgs,
+	RunE: runUpdateDep,
+}
+
+func init() {
+	UpdateDepCmd.Flags().StringVarP(&updateDepGroup, "group", "g", "", "dependency groupId (required)")
+	UpdateDepCmd.Flags().StringVarP(&updateDepArtifact, "artifact", "a", "", "dependency artifactId (required)")
+	UpdateDepCmd.Flags().StringVarP(&updateDepVersion, "version", "V", "", "new version")
+	UpdateDepCmd.Flags().BoolVar(&updateDepLatest, "latest", false, "use the newest release on Maven Central")
+	UpdateDepCmd.Flags().StringVarP(&updateDepFile, "file", "f", "pom.xml", "POM file to modify")
+	UpdateDepCmd.Flags().BoolVar(&updateDepStrict, "strict", false, "with --latest, reject metadata without a published checksum")
+	UpdateDepCmd.MarkFlagRequired("group")
+	UpdateDepCmd.MarkFlagRequired("artifact")
+	UpdateDepCmd.MarkFlagsMutuallyExclusive("version", "latest")
+	UpdateDepCmd.MarkFlagsOneRequired("version", "latest")
+	markWrites(UpdateDepCmd)
+}
+
+func runUpdateDep(cmd *cobra.Command, args []string) error {
+	parser := pom.NewParser()
+	project, err := parser.ParseFile(updateDepFile)
+	if err != nil {
+		return fmt.Errorf("parsing POM: %w", err)
+	}
+
+	index := -1
+	for i, dep := range project.Dependencies {
+		if dep.GroupID == updateDepGroup && dep.ArtifactID == updateDepArtifact {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("%w: %s:%s in %s", pom.ErrDependencyNotFound, updateDepGroup, updateDepArtifact, updateDepFile)
+	}
+	dep := &project.Dependencies[index]
+
+	version := updateDepVersion
+	if updateDepLatest {
+		ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+		defer cancel()
+		settings := mavenSettings()
+		client := remote.NewClient(nil, httpClient(settings, 30*time.Second), settings, downloadVerifier(nil, updateDepStrict))
+		metadata, err := client.Metadata(ctx, dep.GroupID, dep.ArtifactID)
+		if err != nil {
+			return fmt.Errorf("looking up the latest version: %w", err)
+		}
+		version = metadata.LatestRelease()
+		if version == "" {
+			return fmt.Errorf("no released version of %s:%s on Maven Central", dep.GroupID, dep.ArtifactID)
+		}
+	}
+
+	// Update the property a ${name} reference points to, so dependencies
+	// sharing it stay in step
+	current := dep.Version
+	if name, ok := propertyReference(current); ok {
+		if _, defined := project.Properties[name]; defined {
+			current = project.Properties[name]
+			if current == version {
+				logging.Info("%s:%s is already at %s", dep.GroupID, dep.ArtifactID, version)
+				return nil
+			}
+			operation := fmt.Sprintf("update-dep %s:%s: property %s %s -> %s", dep.GroupID, dep.ArtifactID, name, current, version)
+			err := editPOMFile(updateDepFile, operation, func(data []byte, banner *pom.Banner) ([]byte, error) {
+				return pom.SetProperty(data, name, version, banner)
+			})
+			if err != nil {
+				return err
+			}
+			logging.Success("Updated property %s from %s to %s in %s", name, current, version, updateDepFile)
+			return nil
+		}
+	}
+
+	if current == version {
+		logging.Info("%s:%s is already at %s", dep.GroupID, dep.ArtifactID, version)
+		return nil
+	}
+	if current == "" {
+		logging.Warn("%s:%s was managed by dependencyManagement; the explicit version overrides it", dep.GroupID, dep.ArtifactID)
+	}
+	operation := fmt.Sprintf("update-dep %s:%s: %s -> %s", dep.GroupID, dep.ArtifactID, current, version)
+	err = editPOMFile(updateDepFile, operation, func(data []byte, banner *pom.Banner) ([]byte, error) {
+		return pom.SetDependencyVersion(data, dep.GroupID, dep.ArtifactID, version, banner)
+	})
+	if err != nil {
+		return err
+	}
+
+	if current == "" {
+		current = "(managed)"
+	}
+	logging.Success("Updated %s:%s from %s to %s in %s", dep.GroupID, dep.ArtifactID, current, version, updateDepFile)
+	return nil
+}
+
+// propertyReference returns the property name of a version that is exactly
+// one ${name} reference
+func propertyReference(version string) (string, bool) {
+	if !strings.HasPrefix(version, "${") || !strings.HasSuffix(version, "}") || strings.Count(version, "${") != 1 {
+		return "", false
+	}
+	return version[2 : len(version)-1], true
+}
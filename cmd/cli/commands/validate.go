@@ -1,74 +1,462 @@
 package commands
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
 	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/core/report"
+	"github.com/user/pom-manager/internal/core/workspace"
+)
+
+var (
+	validateStrict bool
+	validateFix    bool
+	validateJobs   int
+	validateOutput string
 )
 
 var ValidateCmd = &cobra.Command{
-	Use:   "validate <file>",
-	Short: "Validate a Maven POM file",
-	Long:  `Parse and validate a Maven POM file against Maven conventions.`,
+	Use:   "validate <file|pattern>...",
+	Short: "Validate Maven POM files",
+	Long: `Parse and validate Maven POM files against Maven conventions.
+
+Findings are errors (Maven cannot build the POM), warnings (convention and
+style issues such as a non-lowercase artifactId), or info notes. Only errors
+fail validation unless --strict is given.
+
+Several files and glob patterns can be given; "**" matches any number of
+directories, and target and VCS directories are skipped. Quote patterns so
+the shell does not expand them. Files are validated concurrently, then a
+summary table is printed and the command fails if any file failed, which
+makes it a CI gate for multi-module repositories.
+
+For CI servers, --output sarif prints a SARIF 2.1.0 log for code scanning
+upload and --output junit prints JUnit XML with one test case per POM, so
+failing POMs show up as failed tests. Findings are then only in the report;
+the exit code still tells whether validation passed.
+
+Rules can be disabled or tuned per project in a .pom-manager.yaml file next
+to the POM or in a parent directory:
+
+  validation:
+    rules:
+      groupid-format: off       # allow uppercase groupIds
+      version-format: error     # fail on non-semver versions
+    external_properties:        # supplied with -D or in settings.xml
+      - deploy.target
+
+Each finding names the rule that reported it.
+
+With --fix, findings that have an automatic correction (a non-lowercase
+groupId, a missing modelVersion, a duplicate dependency, ...) are fixed and
+the POM is rewritten before the remaining findings are reported.`,
 	Example: `  pom-manager validate pom.xml
-  pom-manager validate --verbose pom.xml`,
-	Args: cobra.ExactArgs(1),
+  pom-manager validate --verbose pom.xml
+  pom-manager validate --strict pom.xml
+  pom-manager validate --fix pom.xml
+  pom-manager validate '**/pom.xml'
+  pom-manager validate --strict pom.xml 'services/*/pom.xml'
+  pom-manager validate --output sarif '**/pom.xml' > pom-manager.sarif
+  pom-manager validate --output junit '**/pom.xml' > TEST-pom-manager.xml`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: runValidate,
 }
 
+func init() {
+	ValidateCmd.Flags().BoolVar(&validateStrict, "strict", false, "fail on warnings as well as errors")
+	ValidateCmd.Flags().BoolVar(&validateFix, "fix", false, "apply automatic fixes and rewrite the POM")
+	markWritesFlag(ValidateCmd, "fix")
+	ValidateCmd.Flags().IntVarP(&validateJobs, "jobs", "j", runtime.NumCPU(), "number of files validated at once")
+	ValidateCmd.Flags().StringVarP(&validateOutput, "output", "o", report.FormatText,
+		fmt.Sprintf("report format: %s", strings.Join(report.Formats, ", ")))
+	ValidateCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(report.Formats, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// fileValidation is the outcome of validating one file
+type fileValidation struct {
+	File     string
+	Errors   int
+	Warnings int
+	Err      error // Set when the file failed validation or could not be read
+	Output   bytes.Buffer
+	Findings []pom.ValidationError
+	Duration time.Duration
+}
+
+// report returns the outcome in the form report writers take
+func (v *fileValidation) report() report.File {
+	file := report.File{Path: v.File, Findings: v.Findings, Duration: v.Duration}
+	if v.Findings == nil {
+		file.Err = v.Err
+	} else {
+		file.Failed = v.Err != nil
+	}
+	return file
+}
+
 func runValidate(cmd *cobra.Command, args []string) error {
-	file := args[0]
+	if !containsFormat(validateOutput) {
+		return fmt.Errorf("unknown output format %q (use %s)", validateOutput, strings.Join(report.Formats, ", "))
+	}
+	files, err := expandPatterns(args)
+	if err != nil {
+		return err
+	}
+
+	// A single file is reported as it is validated, without a summary
+	if len(files) == 1 && validateOutput == report.FormatText {
+		result := &fileValidation{File: files[0]}
+		validateFile(os.Stdout, result)
+		return result.Err
+	}
+
+	jobs := validateJobs
+	if jobs < 1 {
+		jobs = 1
+	}
+	results := make([]*fileValidation, len(files))
+	sem := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+	for i, file := range files {
+		results[i] = &fileValidation{File: file}
+		wg.Add(1)
+		go func(result *fileValidation) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			validateFile(&result.Output, result)
+		}(results[i])
+	}
+	wg.Wait()
+
+	if validateOutput != report.FormatText {
+		return writeValidationReport(cmd, results)
+	}
+
+	failed := 0
+	for _, result := range results {
+		logging.Print(logging.StyleInfo, "=== %s ===", result.File)
+		os.Stdout.Write(result.Output.Bytes())
+		// Files that could not be read have no findings to show
+		if result.Output.Len() == 0 && result.Err != nil {
+			logging.Error("%v", result.Err)
+		}
+		fmt.Println()
+		if result.Err != nil {
+			failed++
+		}
+	}
+	printValidationSummary(results)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d file(s) failed validation", failed, len(files))
+	}
+	return nil
+}
+
+// writeValidationReport prints the results as a SARIF or JUnit report and
+// fails when any file failed validation
+func writeValidationReport(cmd *cobra.Command, results []*fileValidation) error {
+	files := make([]report.File, 0, len(results))
+	failed := 0
+	for _, result := range results {
+		files = append(files, result.report())
+		if result.Err != nil {
+			failed++
+		}
+	}
+
+	var data []byte
+	var err error
+	switch validateOutput {
+	case report.FormatSARIF:
+		data, err = report.SARIF(files, cmd.Root().Version)
+	case report.FormatJUnit:
+		data, err = report.JUnit(files, time.Now())
+	}
+	if err != nil {
+		return fmt.Errorf("writing %s report: %w", validateOutput, err)
+	}
+	fmt.Println(string(data))
+
+	if failed > 0 {
+		// The report is the output; the error only sets the exit code
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d file(s) failed validation", failed, len(results))
+	}
+	return nil
+}
+
+// containsFormat reports whether format is a known report format
+func containsFormat(format string) bool {
+	for _, known := range report.Formats {
+		if format == known {
+			return true
+		}
+	}
+	return false
+}
+
+// expandPatterns expands glob patterns into files, dropping duplicates; a
+// pattern matching nothing is an error, so typos don't pass CI silently
+func expandPatterns(patterns []string) ([]string, error) {
+	var files []string
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		matches, err := workspace.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %s", pattern)
+		}
+		for _, match := range matches {
+			if key := filepath.Clean(match); !seen[key] {
+				seen[key] = true
+				files = append(files, match)
+			}
+		}
+	}
+	return files, nil
+}
+
+// printValidationSummary prints one row per file with its finding counts
+func printValidationSummary(results []*fileValidation) {
+	width := len("FILE")
+	for _, result := range results {
+		width = max(width, len(result.File))
+	}
+
+	logging.Print(logging.StyleInfo, "=== Summary ===")
+	fmt.Printf("%-*s  %-6s  %6s  %8s\n", width, "FILE", "STATUS", "ERRORS", "WARNINGS")
+	passed := 0
+	for _, result := range results {
+		status := logging.Sprint(logging.StyleGood, "%-6s", "ok")
+		if result.Err != nil {
+			status = logging.Sprint(logging.StyleBad, "%-6s", "FAILED")
+		} else {
+			passed++
+		}
+		fmt.Printf("%-*s  %s  %6d  %8d\n", width, result.File, status, result.Errors, result.Warnings)
+	}
+	fmt.Printf("\n%d passed, %d failed\n", passed, len(results)-passed)
+}
+
+// validateFile validates one POM, writing its findings to out and its
+// counts and outcome to result
+func validateFile(out io.Writer, result *fileValidation) {
+	start := time.Now()
+	defer func() {
+		result.Duration = time.Since(start)
+	}()
+	file := result.File
 
 	// Parse POM
 	parser := pom.NewParser()
 	project, err := parser.ParseFile(file)
 	if err != nil {
-		return fmt.Errorf("parsing POM: %w", err)
+		result.Errors = 1
+		result.Err = fmt.Errorf("parsing POM: %w", err)
+		return
 	}
 
-	color.Cyan("Parsed: %s", project.Coordinates.String())
+	logging.Fprint(out, logging.StyleInfo, "Parsed: %s", project.Coordinates.String())
+
+	// Versions may be managed by the parent chain; an unresolvable parent
+	// is assumed to manage them
+	var inheritance *pom.Inheritance
+	if project.Parent != nil {
+		parents, _ := pom.NewParentResolver(parser).ResolveChain(file, project)
+		inheritance = pom.ComputeInheritance(project, parents)
+	}
 
 	// Validate
+	config, configPath, err := pom.ProjectConfigFor(file)
+	if err != nil {
+		result.Err = err
+		return
+	}
+	if configPath != "" {
+		logging.Fprint(out, logging.StyleInfo, "Using validation rules from %s", configPath)
+	}
+
 	validator := pom.NewValidator()
-	result := validator.Validate(project)
+	validate := func(project *pom.Project) pom.ValidationResult {
+		result := validator.ValidateWithInheritance(project, inheritance)
+		result.Add(pom.CheckPaths(project, filepath.Dir(file))...)
+		result.Add(pom.CheckPropertyReferences(project, inheritance, config.Validation.ExternalProperties)...)
+		if project.Positions != nil {
+			project.Positions.Locate(&result)
+		}
+		return config.Validation.Rules.Apply(result)
+	}
+	validation := validate(project)
 
-	if result.Valid {
-		color.Green("✓ POM is valid")
-		return nil
+	if validateFix {
+		if err := applyFixes(out, file, project, validate); err != nil {
+			result.Err = err
+			return
+		}
+		validation = validate(project)
+	}
+
+	for _, note := range validation.Errors.BySeverity(pom.SeverityInfo) {
+		logging.Fprint(out, logging.StyleInfo, "ℹ %s%s: %s%s", location(file, note), note.Value, note.Message, ruleSuffix(note))
+	}
+
+	result.Findings = validation.Errors.AllErrors()
+	if result.Findings == nil {
+		result.Findings = []pom.ValidationError{}
+	}
+	warnings := validation.Errors.BySeverity(pom.SeverityWarning)
+	result.Warnings = len(warnings)
+	result.Errors = len(validation.Errors.BySeverity(pom.SeverityError))
+	if len(warnings) > 0 {
+		logging.Fprint(out, logging.StyleNotice, "Warnings:")
+		for _, w := range warnings {
+			logging.Fprint(out, logging.StyleNotice, "  - %s%s%s", location(file, w), w.Error(), ruleSuffix(w))
+		}
+	}
+
+	if validation.Valid {
+		if validateStrict && len(warnings) > 0 {
+			logging.Fprint(out, logging.StyleBad, "✗ %d warning(s) in strict mode", len(warnings))
+			result.Err = fmt.Errorf("validation failed")
+			return
+		}
+		logging.Fprint(out, logging.StyleGood, "✓ POM is valid")
+		return
 	}
 
 	// Print errors
-	color.Red("✗ Validation failed:\n")
+	logging.Fprint(out, logging.StyleBad, "✗ Validation failed:\n")
 
-	if len(result.Errors.Coordinates) > 0 {
-		color.Yellow("Coordinate Errors:")
-		for _, err := range result.Errors.Coordinates {
-			color.Red("  - %s", err.Error())
+	groups := []struct {
+		title    string
+		findings []pom.ValidationError
+	}{
+		{"Coordinate Errors:", validation.Errors.Coordinates},
+		{"Dependency Errors:", validation.Errors.Dependencies},
+		{"Build Errors:", validation.Errors.Build},
+		{"General Errors:", validation.Errors.General},
+	}
+	for _, group := range groups {
+		var errors []pom.ValidationError
+		for _, err := range group.findings {
+			if err.Severity == pom.SeverityError {
+				errors = append(errors, err)
+			}
+		}
+		if len(errors) == 0 {
+			continue
+		}
+		logging.Fprint(out, logging.StyleNotice, group.title)
+		for _, err := range errors {
+			logging.Fprint(out, logging.StyleBad, "  - %s%s%s", location(file, err), err.Error(), ruleSuffix(err))
 		}
 	}
 
-	if len(result.Errors.Dependencies) > 0 {
-		color.Yellow("Dependency Errors:")
-		for _, err := range result.Errors.Dependencies {
-			color.Red("  - %s", err.Error())
+	result.Err = fmt.Errorf("validation failed")
+}
+
+// applyFixes applies every available fix and rewrites the file when a fix
+// changed the project, updating the project's positions to the new file.
+// The file is rewritten from the project, so fixes that edit the project
+// are refused when that would drop elements the model does not hold.
+func applyFixes(out io.Writer, file string, project *pom.Project, validate func(*pom.Project) pom.ValidationResult) error {
+	if editsProject(validate(project)) {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("reading file: %w", err)
+		}
+		lost, err := pom.UnmodeledElements(data)
+		if err != nil {
+			return fmt.Errorf("checking fixes: %w", err)
+		}
+		if len(lost) > 0 {
+			return fmt.Errorf("--fix would drop %s from %s; fix the findings by hand", strings.Join(lost, ", "), file)
 		}
 	}
 
-	if len(result.Errors.Build) > 0 {
-		color.Yellow("Build Errors:")
-		for _, err := range result.Errors.Build {
-			color.Red("  - %s", err.Error())
+	applied, err := pom.FixAll(project, validate)
+	edited := 0
+	for _, fix := range applied {
+		logging.Fprint(out, logging.StyleGood, "✓ Fixed: %s", fix.Description)
+		if !fix.ChangesFiles {
+			edited++
 		}
 	}
+	if err != nil {
+		return fmt.Errorf("applying fixes: %w", err)
+	}
+	if len(applied) == 0 {
+		logging.Fprint(out, logging.StyleInfo, "No automatic fixes available")
+		return nil
+	}
+	if edited == 0 {
+		return nil
+	}
+
+	data, err := pom.NewGenerator().Generate(pom.StampBanner(project, file))
+	if err != nil {
+		return fmt.Errorf("generating POM: %w", err)
+	}
+	err = track(file, fmt.Sprintf("validate --fix: %d fix(es)", edited), func() error {
+		return os.WriteFile(file, data, 0644)
+	})
+	if err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+	project.Positions = pom.BuildSourceMap(data)
 
-	if len(result.Errors.General) > 0 {
-		color.Yellow("General Errors:")
-		for _, err := range result.Errors.General {
-			color.Red("  - %s", err.Error())
+	logging.Fprint(out, logging.StyleGood, "✓ Applied %d fix(es) to %s", edited, file)
+	return nil
+}
+
+// editsProject reports whether a finding has a fix that edits the project
+// rather than creating files
+func editsProject(validation pom.ValidationResult) bool {
+	for _, finding := range validation.Errors.AllErrors() {
+		if finding.Fix != nil && !finding.Fix.ChangesFiles {
+			return true
 		}
 	}
+	return false
+}
 
-	return fmt.Errorf("validation failed")
+// errorMessages returns the messages of validation findings, for logging
+// them as details
+func errorMessages(errs []pom.ValidationError) []string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return messages
+}
+
+// ruleSuffix names the rule that reported a finding, for use in .pom-manager.yaml
+func ruleSuffix(err pom.ValidationError) string {
+	if err.Rule == "" {
+		return ""
+	}
+	return fmt.Sprintf(" [%s]", err.Rule)
+}
+
+// location returns a "file:line:column: " prefix for findings with a known
+// position, as compilers print them
+func location(file string, err pom.ValidationError) string {
+	if !err.Position.IsKnown() {
+		return ""
+	}
+	return fmt.Sprintf("%s:%s: ", file, err.Position)
 }
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateFixRefusesLossyRewrite(t *testing.T) {
+	if readOnlyBuild {
+		t.Skip("files cannot be changed in a read-only build")
+	}
+	setTestHome(t)
+	t.Setenv(ReadOnlyEnv, "")
+	defer resetFlag(t, ValidateCmd, "fix")
+
+	fixable := strings.Replace(readOnlyTestPOM, "<groupId>com.example</groupId>", "<groupId>Com.Example</groupId>", 1)
+	lossy := strings.Replace(fixable, "</project>", `  <repositories>
+    <repository>
+      <id>internal</id>
+      <url>https://repo.example.com/maven</url>
+    </repository>
+  </repositories>
+</project>`, 1)
+	path := filepath.Join(t.TempDir(), "pom.xml")
+	if err := os.WriteFile(path, []byte(lossy), 0644); err != nil {
+		t.Fatalf("Expected test POM to be written, got %v", err)
+	}
+
+	_, err := runCommand(t, "validate", "--fix", path)
+	if err == nil {
+		t.Error("Expected --fix to be refused")
+	}
+	if data, _ := os.ReadFile(path); string(data) != lossy {
+		t.Errorf("Expected the POM to be left alone, got:\n%s", data)
+	}
+
+	if err := os.WriteFile(path, []byte(fixable), 0644); err != nil {
+		t.Fatalf("Expected test POM to be written, got %v", err)
+	}
+	runCommand(t, "validate", "--fix", path)
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "<groupId>com.example</groupId>") {
+		t.Errorf("Expected --fix to lowercase the groupId, got:\n%s", data)
+	}
+}
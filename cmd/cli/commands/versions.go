@@ -0,0 +1,285 @@
+package commands
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/core/workspace"
+)
+
+var (
+	versionsCatalog    string
+	versionsFile       string
+	versionsRecursive  bool
+	versionsAddMissing bool
+	versionsLibraries  []string
+)
+
+var VersionsCmd = &cobra.Command{
+	Use:   "versions",
+	Short: "Keep POM version properties in sync with a central versions file",
+	Long: `Maintain versions in one central file and map them to POM properties,
+similar to a Gradle version catalog.
+
+The versions file is YAML or TOML with a top-level "versions" table:
+
+  versions:
+    junit: 4.13.2
+    slf4j: 2.0.9
+
+Each entry maps to a property named "<key>.version" (e.g. <junit.version>);
+keys already ending in "version" are used as the property name unchanged.`,
+	Example: `  pom-manager versions check
+  pom-manager versions sync --catalog libs.toml
+  pom-manager versions sync --recursive --add-missing`,
+}
+
+var versionsCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Report properties that drift from the versions file",
+	Long:  `List catalog entries whose POM property is missing or holds a different version. Exits with an error when drift is found.`,
+	Example: `  pom-manager versions check --file module/pom.xml
+  pom-manager versions check --recursive`,
+	Args: cobra.NoArgs,
+	RunE: runVersionsCheck,
+}
+
+var versionsSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Copy versions from the versions file into POM properties",
+	Long: `Update POM properties to the versions in the versions file.
+
+Only properties the POM already declares are updated unless --add-missing is given.`,
+	Example: `  pom-manager versions sync
+  pom-manager versions sync --recursive --add-missing`,
+	Args: cobra.NoArgs,
+	RunE: runVersionsSync,
+}
+
+var versionsImportCmd = &cobra.Command{
+	Use:   "import <libs.versions.toml>",
+	Short: "Import a Gradle version catalog into dependencyManagement",
+	Long: `Read a Gradle libs.versions.toml and add its libraries to the POM's
+dependencyManagement, so Maven and Gradle builds in the same repository
+share versions.
+
+Libraries using version.ref get a property named after the [versions] key
+("<key>.version") and reference it; literal versions are copied as-is.`,
+	Example: `  pom-manager versions import gradle/libs.versions.toml
+  pom-manager versions import libs.versions.toml --library guava --library junit-jupiter`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVersionsImport,
+}
+
+func init() {
+	VersionsCmd.PersistentFlags().StringVarP(&versionsCatalog, "catalog", "c", "versions.yaml", "versions file (.yaml, .yml, or .toml)")
+	VersionsCmd.PersistentFlags().StringVarP(&versionsFile, "file", "f", "pom.xml", "POM file")
+	VersionsCmd.PersistentFlags().BoolVarP(&versionsRecursive, "recursive", "r", false, "apply to every pom.xml below the POM's directory")
+
+	versionsSyncCmd.Flags().BoolVar(&versionsAddMissing, "add-missing", false, "add catalog properties the POM does not declare yet")
+
+	versionsImportCmd.Flags().StringSliceVarP(&versionsLibraries, "library", "l", nil, "catalog alias to import (repeatable; default all)")
+	markWrites(versionsSyncCmd)
+	markWrites(versionsImportCmd)
+
+	VersionsCmd.AddCommand(versionsCheckCmd)
+	VersionsCmd.AddCommand(versionsSyncCmd)
+	VersionsCmd.AddCommand(versionsImportCmd)
+}
+
+// versionsTargets returns the POM files the versions commands operate on
+func versionsTargets() ([]string, error) {
+	if !versionsRecursive {
+		return []string{versionsFile}, nil
+	}
+
+	ws, err := workspace.Open(filepath.Dir(versionsFile))
+	if err != nil {
+		return nil, err
+	}
+	return ws.POMs, nil
+}
+
+func runVersionsCheck(cmd *cobra.Command, args []string) error {
+	catalog, err := pom.LoadVersionCatalog(versionsCatalog)
+	if err != nil {
+		return err
+	}
+
+	targets, err := versionsTargets()
+	if err != nil {
+		return err
+	}
+
+	parser := pom.NewParser()
+	drifted := 0
+	for _, path := range targets {
+		project, err := parser.ParseFile(path)
+		if err != nil {
+			return fmt.Errorf("parsing POM: %w", err)
+		}
+
+		drift := pom.CatalogDrift(catalog, project)
+		// Entries a POM does not use are only drift for the POM that was asked about
+		if versionsRecursive {
+			drift = declaredDrift(drift)
+		}
+		if len(drift) == 0 {
+			logging.Success("%s matches %s", path, versionsCatalog)
+			continue
+		}
+
+		drifted++
+		logging.Print(logging.StyleNotice, "%s:", path)
+		for _, d := range drift {
+			if d.Missing {
+				logging.Print(logging.StyleBad, "  - %s missing (catalog: %s)", d.Property, d.Catalog)
+			} else {
+				logging.Print(logging.StyleBad, "  - %s is %s (catalog: %s)", d.Property, d.Current, d.Catalog)
+			}
+		}
+	}
+
+	if drifted > 0 {
+		return fmt.Errorf("%d POM file(s) drift from %s", drifted, versionsCatalog)
+	}
+	return nil
+}
+
+func runVersionsSync(cmd *cobra.Command, args []string) error {
+	catalog, err := pom.LoadVersionCatalog(versionsCatalog)
+	if err != nil {
+		return err
+	}
+
+	targets, err := versionsTargets()
+	if err != nil {
+		return err
+	}
+
+	parser := pom.NewParser()
+	for _, path := range targets {
+		project, err := parser.ParseFile(path)
+		if err != nil {
+			return fmt.Errorf("parsing POM: %w", err)
+		}
+
+		changed := pom.SyncCatalog(catalog, project, versionsAddMissing)
+		if len(changed) == 0 {
+			logging.Success("%s is up to date", path)
+			continue
+		}
+
+		err = editPOMFile(path, fmt.Sprintf("versions sync: %d propert(ies) from %s", len(changed), versionsCatalog), func(data []byte, banner *pom.Banner) ([]byte, error) {
+			for _, d := range changed {
+				if d.Missing {
+					data, err = pom.AddProperty(data, d.Property, d.Catalog, banner)
+				} else {
+					data, err = pom.SetProperty(data, d.Property, d.Catalog, banner)
+				}
+				if err != nil {
+					return nil, err
+				}
+			}
+			return data, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		logging.Print(logging.StyleInfo, "%s:", path)
+		for _, d := range changed {
+			if d.Missing {
+				fmt.Printf("  + %s = %s\n", d.Property, d.Catalog)
+			} else {
+				fmt.Printf("  ~ %s: %s → %s\n", d.Property, d.Current, d.Catalog)
+			}
+		}
+	}
+
+	return nil
+}
+
+func runVersionsImport(cmd *cobra.Command, args []string) error {
+	catalog, err := pom.LoadGradleCatalog(args[0])
+	if err != nil {
+		return err
+	}
+
+	// Catch typos in --library before touching the POM
+	known := make(map[string]bool, len(catalog.Libraries))
+	for _, library := range catalog.Libraries {
+		known[library.Alias] = true
+	}
+	for _, alias := range versionsLibraries {
+		if !known[alias] {
+			return fmt.Errorf("library %q not found in %s", alias, args[0])
+		}
+	}
+
+	parser := pom.NewParser()
+	project, err := parser.ParseFile(versionsFile)
+	if err != nil {
+		return fmt.Errorf("parsing POM: %w", err)
+	}
+
+	declared := maps.Clone(project.Properties)
+	result := pom.ImportGradleCatalog(catalog, project, versionsLibraries)
+	for _, alias := range result.Skipped {
+		logging.Warn("%s has no version, skipped", alias)
+	}
+	if len(result.Managed) == 0 && len(result.Properties) == 0 {
+		logging.Success("%s is up to date", versionsFile)
+		return nil
+	}
+
+	err = editPOMFile(versionsFile, "versions import from "+args[0], func(data []byte, banner *pom.Banner) ([]byte, error) {
+		for _, property := range result.Properties {
+			if _, ok := declared[property]; ok {
+				data, err = pom.SetProperty(data, property, project.Properties[property], banner)
+			} else {
+				data, err = pom.AddProperty(data, property, project.Properties[property], banner)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, managed := range result.Managed {
+			groupID, artifactID, _ := strings.Cut(managed, ":")
+			version, _ := project.ManagedVersion(groupID, artifactID)
+			data, err = pom.SetManagedVersion(data, groupID, artifactID, version, banner)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return data, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logging.Print(logging.StyleInfo, "%s:", versionsFile)
+	for _, property := range result.Properties {
+		fmt.Printf("  + property %s = %s\n", property, project.Properties[property])
+	}
+	for _, managed := range result.Managed {
+		fmt.Printf("  + managed %s\n", managed)
+	}
+	return nil
+}
+
+// declaredDrift drops entries for properties the POM does not declare
+func declaredDrift(drift []pom.VersionDrift) []pom.VersionDrift {
+	var declared []pom.VersionDrift
+	for _, d := range drift {
+		if !d.Missing {
+			declared = append(declared, d)
+		}
+	}
+	return declared
+}
@@ -0,0 +1,235 @@
+// Package logging writes the CLI's status messages: what a command did,
+// warnings and errors. Command output proper, such as reports and listings,
+// is printed by the commands themselves with Print, so it stays on stdout
+// whatever the log level and format.
+//
+// Messages are colored lines by default, successes and information on
+// stdout, warnings and errors on stderr. With the JSON format every message
+// is one JSON object on stderr, for tools wrapping the CLI.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+)
+
+// Log formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Formats lists the log formats understood by Configure
+var Formats = []string{FormatText, FormatJSON}
+
+// Levels beyond those of slog. Verbose messages are shown with --verbose,
+// debug messages only with --debug; --quiet keeps warnings and errors.
+const (
+	LevelDebug   = slog.LevelDebug
+	LevelVerbose = slog.Level(-2)
+	LevelInfo    = slog.LevelInfo
+	LevelSuccess = slog.Level(1)
+	LevelWarn    = slog.LevelWarn
+	LevelError   = slog.LevelError
+)
+
+// levelNames are the names of the custom levels in JSON output
+var levelNames = map[slog.Level]string{
+	LevelVerbose: "VERBOSE",
+	LevelSuccess: "SUCCESS",
+}
+
+// DetailsKey is the attribute holding the items listed under a message,
+// such as validation errors
+const DetailsKey = "details"
+
+// Options configures the logger from the global flags
+type Options struct {
+	Verbose bool
+	Debug   bool
+	Quiet   bool
+	NoColor bool   // Also set by the NO_COLOR environment variable
+	Format  string // One of Formats; "" means text
+}
+
+var (
+	mu     sync.Mutex
+	logger = slog.New(newTextHandler(LevelInfo, os.Stdout, os.Stderr))
+)
+
+// Configure sets up the logger; messages logged before are written as
+// text at the default level
+func Configure(options Options) error {
+	level := LevelInfo
+	switch {
+	case options.Quiet:
+		level = LevelWarn
+	case options.Debug:
+		level = LevelDebug
+	case options.Verbose:
+		level = LevelVerbose
+	}
+	// JSON logs are read by tools, which want output without escapes too
+	if options.NoColor || os.Getenv("NO_COLOR") != "" || options.Format == FormatJSON {
+		color.NoColor = true
+	}
+
+	var handler slog.Handler
+	switch options.Format {
+	case "", FormatText:
+		handler = newTextHandler(level, os.Stdout, os.Stderr)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+			Level:       level,
+			ReplaceAttr: replaceLevel,
+		})
+	default:
+		return fmt.Errorf("log format must be one of %s", strings.Join(Formats, ", "))
+	}
+
+	mu.Lock()
+	logger = slog.New(handler)
+	mu.Unlock()
+	return nil
+}
+
+// replaceLevel names the custom levels in JSON output
+func replaceLevel(groups []string, attr slog.Attr) slog.Attr {
+	if attr.Key == slog.LevelKey && len(groups) == 0 {
+		if name, ok := levelNames[attr.Value.Any().(slog.Level)]; ok {
+			attr.Value = slog.StringValue(name)
+		}
+	}
+	return attr
+}
+
+// Debug logs a message shown with --debug
+func Debug(format string, args ...any) {
+	logf(LevelDebug, format, args...)
+}
+
+// Verbose logs a message shown with --verbose or --debug
+func Verbose(format string, args ...any) {
+	logf(LevelVerbose, format, args...)
+}
+
+// Info logs a message about what a command is doing
+func Info(format string, args ...any) {
+	logf(LevelInfo, format, args...)
+}
+
+// Success logs that a command did what it was asked to
+func Success(format string, args ...any) {
+	logf(LevelSuccess, format, args...)
+}
+
+// Warn logs a problem that does not stop the command
+func Warn(format string, args ...any) {
+	logf(LevelWarn, format, args...)
+}
+
+// Error logs a problem that stops the command
+func Error(format string, args ...any) {
+	logf(LevelError, format, args...)
+}
+
+// ErrorDetails logs an error together with the items causing it, listed
+// below the message in text and as an array in JSON
+func ErrorDetails(message string, details []string) {
+	current().Log(context.Background(), LevelError, message, DetailsKey, details)
+}
+
+func logf(level slog.Level, format string, args ...any) {
+	l := current()
+	if !l.Enabled(context.Background(), level) {
+		return
+	}
+	l.Log(context.Background(), level, fmt.Sprintf(format, args...))
+}
+
+func current() *slog.Logger {
+	mu.Lock()
+	defer mu.Unlock()
+	return logger
+}
+
+// textHandler writes messages as colored lines, prefixed with a symbol for
+// successes, warnings and errors
+type textHandler struct {
+	level  slog.Level
+	stdout io.Writer
+	stderr io.Writer
+	attrs  []slog.Attr
+}
+
+func newTextHandler(level slog.Level, stdout, stderr io.Writer) *textHandler {
+	return &textHandler{level: level, stdout: stdout, stderr: stderr}
+}
+
+func (h *textHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *textHandler) Handle(_ context.Context, record slog.Record) error {
+	out := h.stdout
+	if record.Level >= LevelWarn {
+		out = h.stderr
+	}
+
+	var prefix string
+	style := color.New(color.Reset)
+	switch {
+	case record.Level >= LevelError:
+		prefix, style = "✗ ", color.New(color.FgRed)
+	case record.Level >= LevelWarn:
+		prefix, style = "⚠ ", color.New(color.FgYellow)
+	case record.Level >= LevelSuccess:
+		prefix, style = "✓ ", color.New(color.FgGreen)
+	case record.Level >= LevelInfo:
+		style = color.New(color.FgCyan)
+	case record.Level < LevelVerbose:
+		style = color.New(color.Faint)
+	}
+
+	var b strings.Builder
+	b.WriteString(prefix + record.Message)
+	var details []string
+	addAttr := func(attr slog.Attr) bool {
+		if items, ok := attr.Value.Any().([]string); ok && attr.Key == DetailsKey {
+			details = items
+		} else {
+			fmt.Fprintf(&b, " %s=%v", attr.Key, attr.Value)
+		}
+		return true
+	}
+	for _, attr := range h.attrs {
+		addAttr(attr)
+	}
+	record.Attrs(addAttr)
+	if len(details) > 0 {
+		b.WriteString(":")
+	}
+	style.Fprintln(out, b.String())
+	for _, detail := range details {
+		style.Fprintf(out, "  - %s\n", detail)
+	}
+	return nil
+}
+
+func (h *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append(append([]slog.Attr(nil), h.attrs...), attrs...)
+	return &clone
+}
+
+// WithGroup is not used by the CLI; groups are flattened
+func (h *textHandler) WithGroup(string) slog.Handler {
+	return h
+}
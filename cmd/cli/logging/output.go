@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Style is how a line of command output is highlighted
+type Style int
+
+// Styles of command output lines
+const (
+	StylePlain  Style = iota
+	StyleInfo         // Section titles and information
+	StyleGood         // Items that are fine or were added
+	StyleBad          // Items that failed or were removed
+	StyleNotice       // Items worth a look
+)
+
+// styleAttributes are the colors of the styles in text output
+var styleAttributes = map[Style]color.Attribute{
+	StylePlain:  color.Reset,
+	StyleInfo:   color.FgCyan,
+	StyleGood:   color.FgGreen,
+	StyleBad:    color.FgRed,
+	StyleNotice: color.FgYellow,
+}
+
+// Print writes a line of command output to stdout in style, adding a
+// newline unless format ends with one. Unlike messages, output is written
+// with --quiet; it is not colored with the JSON log format.
+func Print(style Style, format string, args ...any) {
+	Fprint(os.Stdout, style, format, args...)
+}
+
+// Fprint is Print writing to out, such as a buffer collecting the output
+// of one of several files checked concurrently
+func Fprint(out io.Writer, style Style, format string, args ...any) {
+	if !strings.HasSuffix(format, "\n") {
+		format += "\n"
+	}
+	color.New(styleAttributes[style]).Fprintf(out, format, args...)
+}
+
+// Sprint returns text in style, for a column of a table
+func Sprint(style Style, format string, args ...any) string {
+	return color.New(styleAttributes[style]).Sprint(fmt.Sprintf(format, args...))
+}
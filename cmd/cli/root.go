@@ -1,17 +1,24 @@
 package main
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/user/pom-manager/cmd/cli/commands"
+	"github.com/user/pom-manager/cmd/cli/logging"
+	"github.com/user/pom-manager/internal/core/appdir"
+	"github.com/user/pom-manager/internal/core/catalog"
+	"github.com/user/pom-manager/internal/core/pom"
 )
 
 var (
-	verbose bool
-	noColor bool
-	debug   bool
+	verbose   bool
+	quiet     bool
+	noColor   bool
+	debug     bool
+	logFormat string
+	configDir string
+	banner    bool
 )
 
 var rootCmd = &cobra.Command{
@@ -20,27 +27,104 @@ var rootCmd = &cobra.Command{
 	Long: `A CLI tool for creating, validating, and managing Maven POM files.
 
 Supports template-based project creation, dependency management,
-and POM validation following Maven conventions.`,
-	Version: "0.1.0-MVP",
+and POM validation following Maven conventions.
+
+On shared CI runners, set POM_MANAGER_READ_ONLY=1 or build with
+-tags readonly to disable every command that changes files; inspect,
+validate and report commands keep working.`,
+	Version:       "0.1.0-MVP",
+	SilenceErrors: true, // Logged by Execute
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		err := logging.Configure(logging.Options{
+			Verbose: verbose,
+			Debug:   debug,
+			Quiet:   quiet,
+			NoColor: noColor,
+			Format:  logFormat,
+		})
+		if err != nil {
+			return err
+		}
+		// Keep stderr machine-readable
+		if logFormat == logging.FormatJSON {
+			cmd.SilenceUsage = true
+		}
+		if err := commands.CheckReadOnly(cmd); err != nil {
+			cmd.SilenceUsage = true // Not a usage mistake
+			return err
+		}
+		pom.SetBannerTool("pom-manager", cmd.Root().Version)
+		pom.SetBannerDefault(banner)
+		if err := appdir.SetOverride(configDir); err != nil {
+			return err
+		}
+		// Moving legacy settings changes files too
+		if commands.ReadOnly() {
+			logging.Debug("Read-only mode: commands that change files are disabled")
+		} else if legacy, err := appdir.Migrate(); err != nil {
+			logging.Warn("%v", err)
+		} else if legacy != "" {
+			dir, _ := appdir.ConfigDir()
+			logging.Verbose("Moved %s to %s", legacy, dir)
+		}
+		// Offer the versions of the last catalog refresh; the pinned ones
+		// remain when there is none
+		if dir, err := appdir.CacheDir(); err == nil {
+			logging.Debug("Cache directory: %s", dir)
+			if _, err := catalog.LoadAndApply(dir); err != nil {
+				logging.Verbose("Using pinned default versions: %v", err)
+			}
+		}
+		return nil
+	},
 }
 
 func init() {
 	// Global flags
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
-	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable color output")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "only print warnings and errors besides command output")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable color output (also set by NO_COLOR)")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "enable debug logging")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", logging.FormatText, "format of log messages: text or json (on stderr)")
+	rootCmd.PersistentFlags().BoolVarP(&commands.AssumeYes, "yes", "y", false, "answer yes to confirmations and never prompt for input")
+	rootCmd.PersistentFlags().BoolVar(&commands.AssumeYes, "non-interactive", false, "same as --yes")
+	rootCmd.PersistentFlags().StringVar(&configDir, "config-dir", "", "keep settings, cache and logs in this directory")
+	rootCmd.PersistentFlags().DurationVar(&commands.CacheTTL, "cache-ttl", commands.CacheTTL, "how long remote lookups are answered from the cache before asking again")
+	rootCmd.PersistentFlags().BoolVar(&banner, "banner", false, "start written POMs with a generated-by comment, unless generator.banner in .pom-manager.yaml says otherwise")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "debug")
+	rootCmd.RegisterFlagCompletionFunc("log-format", cobra.FixedCompletions(logging.Formats, cobra.ShellCompDirectiveNoFileComp))
 
 	// Add subcommands
 	rootCmd.AddCommand(commands.CreateCmd)
 	rootCmd.AddCommand(commands.ValidateCmd)
 	rootCmd.AddCommand(commands.AddDepCmd)
+	rootCmd.AddCommand(commands.RemoveDepCmd)
+	rootCmd.AddCommand(commands.RemovePluginCmd)
+	rootCmd.AddCommand(commands.UpdateDepCmd)
 	rootCmd.AddCommand(commands.TemplatesCmd)
+	rootCmd.AddCommand(commands.TemplateCmd)
 	rootCmd.AddCommand(commands.InfoCmd)
+	rootCmd.AddCommand(commands.ModuleCmd)
+	rootCmd.AddCommand(commands.VersionsCmd)
+	rootCmd.AddCommand(commands.PropCmd)
+	rootCmd.AddCommand(commands.ImportCmd)
+	rootCmd.AddCommand(commands.ConvertCmd)
+	rootCmd.AddCommand(commands.ExportCmd)
+	rootCmd.AddCommand(commands.FormatCmd)
+	rootCmd.AddCommand(commands.DiffCmd)
+	rootCmd.AddCommand(commands.ClasspathCmd)
+	rootCmd.AddCommand(commands.CatalogCmd)
+	rootCmd.AddCommand(commands.DocsCmd)
+	rootCmd.AddCommand(commands.AuditLogCmd)
+	if commands.ReadOnly() {
+		commands.HideWriteCommands(rootCmd)
+	}
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+		logging.Error("%v", err)
 		os.Exit(1)
 	}
 }
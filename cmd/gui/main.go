@@ -10,10 +10,16 @@ package main
 // For development without CGO, the CLI interface is available at cmd/cli/
 
 import (
+	"flag"
+	"log"
+	"strings"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/theme"
 
+	"github.com/user/pom-manager/internal/core/appdir"
+	"github.com/user/pom-manager/internal/core/audit"
 	"github.com/user/pom-manager/internal/core/pom"
 	"github.com/user/pom-manager/internal/gui/presenters"
 	"github.com/user/pom-manager/internal/gui/state"
@@ -27,6 +33,18 @@ const (
 )
 
 func main() {
+	readOnly := flag.Bool("read-only", false, "open POM files in view-only mode")
+	configDir := flag.String("config-dir", "", "keep settings, cache and logs in this directory")
+	flag.Parse()
+
+	// Locate the config directory before anything reads from it
+	if err := appdir.SetOverride(*configDir); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := appdir.Migrate(); err != nil {
+		log.Printf("warning: %v", err)
+	}
+
 	// Create Fyne application
 	myApp := app.NewWithID(AppID)
 	myApp.SetIcon(nil) // TODO: Add application icon later
@@ -51,19 +69,36 @@ func main() {
 	)
 	window.Resize(windowSize)
 
+	// Name the application in generated-by comments, when they are enabled
+	pom.SetBannerTool("pom-manager-gui", AppVersion)
+	pom.SetBannerDefault(settings.GeneratorBanner)
+
 	// Initialize core engine components
 	parser := pom.NewParser()
 	generator := pom.NewGenerator()
 	validator := pom.NewValidator()
 	repository := pom.NewRepository()
-	templateManager := pom.NewTemplateManager()
+	templateManager := settings.NewTemplateManager()
 
 	// Initialize state with loaded settings
 	appState := state.NewAppState()
 	appState.SetSettings(settings)
 
+	// Record every save in the audit log, described by its changes
+	var recorder *audit.Recorder
+	var presenter presenters.MainPresenter
+	if auditPath, err := audit.DefaultPath(); err == nil {
+		recorder = audit.NewRecorder(audit.NewLog(auditPath), audit.ToolGUI)
+		repository = audit.NewRepository(repository, recorder, func(path string) string {
+			summary, _, _ := strings.Cut(presenter.SessionChangelog(), "\n")
+			return "save: " + summary
+		})
+	} else {
+		log.Printf("warning: audit log disabled: %v", err)
+	}
+
 	// Initialize presenter
-	presenter := presenters.NewMainPresenter(
+	presenter = presenters.NewMainPresenter(
 		parser,
 		generator,
 		validator,
@@ -72,8 +107,12 @@ func main() {
 		appState,
 	)
 
+	presenter.SetForceReadOnly(*readOnly)
+	presenter.SetRecorder(recorder)
+
 	// Create main window
 	mainWin := windows.NewMainWindow(window, presenter, appState)
+	mainWin.SetRecorder(recorder)
 
 	// Setup window close handler to save settings
 	window.SetOnClosed(func() {
@@ -83,18 +122,29 @@ func main() {
 		currentSettings.WindowWidth = int(size.Width)
 		currentSettings.WindowHeight = int(size.Height)
 
-		// Save current file path for session restore
-		currentSettings.LastOpenedFile = appState.GetFilePath()
+		// Scratch buffers are persisted silently so they survive restarts
+		_ = presenter.SaveScratchBuffers()
+		filePath := appState.GetFilePath()
+
+		// Save current file path for session restore; the open files and
+		// layout were recorded as the window closed
+		currentSettings.LastOpenedFile = filePath
+
+		// A clean exit leaves nothing to recover
+		_ = state.ClearRecovery()
 
 		// Save settings to disk
 		_ = state.SaveSettings(currentSettings)
 	})
 
-	// Restore last file if RestoreSession is enabled
-	if settings.RestoreSession && settings.LastOpenedFile != "" {
-		_ = presenter.LoadPOM(settings.LastOpenedFile)
+	// Reopen the last session's files if RestoreSession is enabled
+	if settings.RestoreSession {
+		mainWin.RestoreSession()
 	}
 
+	// Offer to recover changes from a crashed session, then start auto-save
+	mainWin.RecoverSession()
+
 	// Show main window
 	mainWin.Show()
 }
@@ -0,0 +1,216 @@
+// Package appdir locates the directories pom-manager keeps its files in.
+// It follows the platform conventions: the XDG base directories on Linux
+// and BSD (XDG_CONFIG_HOME, XDG_CACHE_HOME, XDG_STATE_HOME), AppData on
+// Windows, and ~/Library on macOS unless XDG variables are set. A single
+// override directory (--config-dir) replaces all of them.
+package appdir
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// Name is the directory name used below each base directory
+const Name = "pom-manager"
+
+// legacyDirName is the directory used in the home directory before
+// pom-manager followed platform conventions
+const legacyDirName = ".pom-manager"
+
+var (
+	mu       sync.RWMutex
+	override string
+)
+
+// SetOverride keeps config, cache and logs below dir instead of the platform
+// directories; an empty dir restores the defaults
+func SetOverride(dir string) error {
+	if dir != "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("resolving config directory %s: %w", dir, err)
+		}
+		dir = abs
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	override = dir
+	return nil
+}
+
+// getOverride returns the override directory, "" when unset
+func getOverride() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return override
+}
+
+// ConfigDir returns the directory for settings and other user data
+func ConfigDir() (string, error) {
+	if dir := getOverride(); dir != "" {
+		return dir, nil
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" && filepath.IsAbs(dir) {
+		return filepath.Join(dir, Name), nil
+	}
+
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+	return filepath.Join(base, Name), nil
+}
+
+// CacheDir returns the directory for data that can be re-created
+func CacheDir() (string, error) {
+	if dir := getOverride(); dir != "" {
+		return filepath.Join(dir, "cache"), nil
+	}
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" && filepath.IsAbs(dir) {
+		return filepath.Join(dir, Name), nil
+	}
+
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get cache directory: %w", err)
+	}
+	return filepath.Join(base, Name), nil
+}
+
+// LogDir returns the directory for log files
+func LogDir() (string, error) {
+	if dir := getOverride(); dir != "" {
+		return filepath.Join(dir, "logs"), nil
+	}
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" && filepath.IsAbs(dir) {
+		return filepath.Join(dir, Name, "logs"), nil
+	}
+
+	switch runtime.GOOS {
+	case "windows":
+		// Logs are machine-local, next to the cache in LocalAppData
+		cacheDir, err := CacheDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(cacheDir, "logs"), nil
+	case "darwin", "ios":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		return filepath.Join(home, "Library", "Logs", Name), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "state", Name, "logs"), nil
+}
+
+// Migrate moves files from the legacy ~/.pom-manager directory to the
+// config directory, and its cache subdirectory to the cache directory. It
+// does nothing when an override is set, there is no legacy directory, or
+// the config directory already exists. Returns the legacy directory when
+// files were moved.
+func Migrate() (string, error) {
+	if getOverride() != "" {
+		return "", nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", nil
+	}
+	legacy := filepath.Join(home, legacyDirName)
+	if info, err := os.Stat(legacy); err != nil || !info.IsDir() {
+		return "", nil
+	}
+
+	configDir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(configDir); err == nil {
+		return "", nil
+	}
+
+	if err := move(legacy, configDir); err != nil {
+		return "", fmt.Errorf("migrating %s to %s: %w", legacy, configDir, err)
+	}
+
+	// The cache lived inside the legacy directory
+	cacheDir, err := CacheDir()
+	if err != nil {
+		return legacy, err
+	}
+	legacyCache := filepath.Join(configDir, "cache")
+	if _, err := os.Stat(legacyCache); err == nil && legacyCache != cacheDir {
+		if _, err := os.Stat(cacheDir); os.IsNotExist(err) {
+			if err := move(legacyCache, cacheDir); err != nil {
+				return legacy, fmt.Errorf("migrating cache to %s: %w", cacheDir, err)
+			}
+		}
+	}
+
+	return legacy, nil
+}
+
+// move renames src to dst, copying across file systems when renaming fails
+func move(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	if err := copyTree(src, dst); err != nil {
+		os.RemoveAll(dst)
+		return err
+	}
+	return os.RemoveAll(src)
+}
+
+// copyTree copies a directory recursively, keeping file modes
+func copyTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm())
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
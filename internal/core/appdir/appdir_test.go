@@ -0,0 +1,79 @@
+package appdir
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirs(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(root, "cache"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(root, "state"))
+
+	if dir, _ := ConfigDir(); dir != filepath.Join(root, "config", Name) {
+		t.Errorf("Expected config dir below XDG_CONFIG_HOME, got %s", dir)
+	}
+	if dir, _ := CacheDir(); dir != filepath.Join(root, "cache", Name) {
+		t.Errorf("Expected cache dir below XDG_CACHE_HOME, got %s", dir)
+	}
+	if dir, _ := LogDir(); dir != filepath.Join(root, "state", Name, "logs") {
+		t.Errorf("Expected log dir below XDG_STATE_HOME, got %s", dir)
+	}
+
+	override := filepath.Join(root, "custom")
+	if err := SetOverride(override); err != nil {
+		t.Fatal(err)
+	}
+	defer SetOverride("")
+	if dir, _ := ConfigDir(); dir != override {
+		t.Errorf("Expected override config dir, got %s", dir)
+	}
+	if dir, _ := CacheDir(); dir != filepath.Join(override, "cache") {
+		t.Errorf("Expected cache dir inside override, got %s", dir)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+
+	legacy := filepath.Join(home, legacyDirName)
+	if err := os.MkdirAll(filepath.Join(legacy, "cache"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(legacy, "gui-config.yaml"), []byte("theme: dark\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(legacy, "cache", "index.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	moved, err := Migrate()
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if moved != legacy {
+		t.Errorf("Expected %s to be migrated, got %q", legacy, moved)
+	}
+
+	configDir, _ := ConfigDir()
+	if _, err := os.Stat(filepath.Join(configDir, "gui-config.yaml")); err != nil {
+		t.Errorf("Expected settings in %s: %v", configDir, err)
+	}
+	cacheDir, _ := CacheDir()
+	if _, err := os.Stat(filepath.Join(cacheDir, "index.json")); err != nil {
+		t.Errorf("Expected cache in %s: %v", cacheDir, err)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Error("Expected legacy directory to be removed")
+	}
+
+	// Migration happens only once
+	if moved, _ := Migrate(); moved != "" {
+		t.Errorf("Expected no second migration, got %q", moved)
+	}
+}
@@ -0,0 +1,247 @@
+// Package audit keeps an append-only log of the files pom-manager writes:
+// which file, what was done, the content hashes before and after, who did it
+// and when. Security warnings, such as downloads failing verification, are
+// logged alongside. The log is a file of JSON lines in the config
+// directory, so it can be inspected and shipped with ordinary tools.
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/user/pom-manager/internal/core/appdir"
+)
+
+// FileName is the name of the audit log in the config directory
+const FileName = "audit.log"
+
+// Tools recorded in entries
+const (
+	ToolCLI = "cli"
+	ToolGUI = "gui"
+)
+
+// OperationVerify is the operation of security warnings about downloads
+const OperationVerify = "verify download"
+
+// Entry records one write of a file, or a security warning
+type Entry struct {
+	Time      time.Time `json:"time"`
+	User      string    `json:"user"`
+	Tool      string    `json:"tool"`
+	Path      string    `json:"path"`
+	Operation string    `json:"operation"`
+	Before    string    `json:"before,omitempty"`  // SHA-256 of the previous content; empty for a new file
+	After     string    `json:"after,omitempty"`   // SHA-256 of the new content; empty when the file is gone
+	Error     string    `json:"error,omitempty"`   // Set when the write failed part way
+	Warning   string    `json:"warning,omitempty"` // Security warning about Path, which nothing was written to
+}
+
+// Log is an append-only audit log
+type Log interface {
+	Append(entry Entry) error
+	Entries() ([]Entry, error)
+	Path() string
+}
+
+// fileLog implements Log as a file of JSON lines
+type fileLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewLog returns the audit log stored at path; the file is created with
+// the first entry
+func NewLog(path string) Log {
+	return &fileLog{path: path}
+}
+
+// DefaultPath returns the path of the audit log in the config directory
+func DefaultPath() (string, error) {
+	dir, err := appdir.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, FileName), nil
+}
+
+// Path returns the file the log is stored in
+func (l *fileLog) Path() string {
+	return l.path
+}
+
+// Append adds an entry at the end of the log. The file is only ever opened
+// for appending, so earlier entries are never rewritten.
+func (l *fileLog) Append(entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encoding audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("creating audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("opening audit log: %w", err)
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("writing audit log: %w", err)
+	}
+	return file.Close()
+}
+
+// Entries reads the log, oldest entry first. A missing log has no entries.
+func (l *fileLog) Entries() ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	file, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return entries, fmt.Errorf("audit log line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return entries, fmt.Errorf("reading audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// Recorder logs the writes made by one tool
+type Recorder struct {
+	log  Log
+	tool string
+	user string
+}
+
+// NewRecorder creates a recorder appending to log on behalf of tool, one of
+// ToolCLI and ToolGUI
+func NewRecorder(log Log, tool string) *Recorder {
+	return &Recorder{log: log, tool: tool, user: currentUser()}
+}
+
+// Track runs write, which changes the file at path, and records it with a
+// short description of the operation. Writes that fail without touching
+// the file are not recorded. An error appending to the log is returned
+// when the write itself succeeded, since the file was changed unrecorded.
+func (r *Recorder) Track(path, operation string, write func() error) error {
+	return r.track([]string{path}, operation, write, true)
+}
+
+// TrackFiles is Track for a write that may change several files, such as a
+// refactoring across modules. Only the files it changed are recorded.
+func (r *Recorder) TrackFiles(paths []string, operation string, write func() error) error {
+	return r.track(paths, operation, write, false)
+}
+
+// track records the writes of files; written means a successful write
+// rewrote every path, so unchanged content is recorded too
+func (r *Recorder) track(paths []string, operation string, write func() error, written bool) error {
+	paths = append([]string(nil), paths...)
+	before := make([]string, len(paths))
+	for i, path := range paths {
+		if abs, err := filepath.Abs(path); err == nil {
+			paths[i] = abs
+		}
+		before[i] = hashFile(paths[i])
+	}
+	writeErr := write()
+
+	var appendErr error
+	now := time.Now().UTC()
+	for i, path := range paths {
+		after := hashFile(path)
+		if after == before[i] && (writeErr != nil || !written) {
+			continue
+		}
+		entry := Entry{
+			Time:      now,
+			User:      r.user,
+			Tool:      r.tool,
+			Path:      path,
+			Operation: operation,
+			Before:    before[i],
+			After:     after,
+		}
+		if writeErr != nil {
+			entry.Error = writeErr.Error()
+		}
+		if err := r.log.Append(entry); err != nil && appendErr == nil {
+			appendErr = fmt.Errorf("%s was written but not recorded: %w", path, err)
+		}
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+	return appendErr
+}
+
+// Warn records a security warning about path, such as the URL of a
+// download that failed verification
+func (r *Recorder) Warn(path, operation, warning string) error {
+	return r.log.Append(Entry{
+		Time:      time.Now().UTC(),
+		User:      r.user,
+		Tool:      r.tool,
+		Path:      path,
+		Operation: operation,
+		Warning:   warning,
+	})
+}
+
+// Hash returns the SHA-256 of data as recorded in entries
+func Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hashFile returns the hash of a file's content, "" when it can't be read
+func hashFile(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return Hash(data)
+}
+
+// currentUser returns the login name of the user running pom-manager
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	for _, name := range []string{"USER", "USERNAME"} {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return "unknown"
+}
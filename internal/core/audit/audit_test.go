@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTrack(t *testing.T) {
+	dir := t.TempDir()
+	log := NewLog(filepath.Join(dir, "config", FileName))
+	recorder := NewRecorder(log, ToolCLI)
+	path := filepath.Join(dir, "pom.xml")
+
+	write := func(data string) func() error {
+		return func() error { return os.WriteFile(path, []byte(data), 0644) }
+	}
+	if err := recorder.Track(path, "create", write("<project/>")); err != nil {
+		t.Fatalf("Track failed: %v", err)
+	}
+	if err := recorder.Track(path, "add-dep junit:junit", write("<project><dependencies/></project>")); err != nil {
+		t.Fatalf("Track failed: %v", err)
+	}
+
+	// A write failing before it touches the file is not recorded
+	failure := errors.New("disk full")
+	if err := recorder.Track(path, "format", func() error { return failure }); !errors.Is(err, failure) {
+		t.Errorf("Expected the write error, got %v", err)
+	}
+
+	entries, err := log.Entries()
+	if err != nil {
+		t.Fatalf("Entries failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	created, updated := entries[0], entries[1]
+	if created.Before != "" || created.After != Hash([]byte("<project/>")) {
+		t.Errorf("Expected a new file to have only an after hash, got %+v", created)
+	}
+	if updated.Before != created.After {
+		t.Errorf("Expected the before hash to match the previous content, got %s", updated.Before)
+	}
+	if updated.Operation != "add-dep junit:junit" || updated.Tool != ToolCLI || updated.Path != path {
+		t.Errorf("Expected operation, tool and path to be recorded, got %+v", updated)
+	}
+	if updated.User == "" || updated.Time.IsZero() {
+		t.Errorf("Expected user and time to be recorded, got %+v", updated)
+	}
+
+	info, err := os.Stat(log.Path())
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the log to be private, got %v", info.Mode().Perm())
+	}
+}
+
+func TestWarn(t *testing.T) {
+	log := NewLog(filepath.Join(t.TempDir(), FileName))
+	url := "https://repo.example.com/org/example/lib/1.0/lib-1.0.pom"
+	if err := NewRecorder(log, ToolGUI).Warn(url, OperationVerify, "checksum mismatch"); err != nil {
+		t.Fatalf("Warn failed: %v", err)
+	}
+
+	entries, err := log.Entries()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %v (%v)", entries, err)
+	}
+	if entries[0].Path != url || entries[0].Warning != "checksum mismatch" || entries[0].After != "" {
+		t.Errorf("Expected a warning without hashes, got %+v", entries[0])
+	}
+}
+
+func TestEntriesMissingLog(t *testing.T) {
+	entries, err := NewLog(filepath.Join(t.TempDir(), FileName)).Entries()
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Expected no entries and no error for a missing log, got %v, %v", entries, err)
+	}
+}
+
+func TestTrackFiles(t *testing.T) {
+	dir := t.TempDir()
+	log := NewLog(filepath.Join(dir, FileName))
+	recorder := NewRecorder(log, ToolGUI)
+
+	parent := filepath.Join(dir, "pom.xml")
+	child := filepath.Join(dir, "core", "pom.xml")
+	untouched := filepath.Join(dir, "api", "pom.xml")
+	if err := os.WriteFile(parent, []byte("<project/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := recorder.TrackFiles([]string{parent, child, untouched}, "new module core", func() error {
+		if err := os.MkdirAll(filepath.Dir(child), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(child, []byte("<project><parent/></project>"), 0644); err != nil {
+			return err
+		}
+		return os.WriteFile(parent, []byte("<project><modules/></project>"), 0644)
+	})
+	if err != nil {
+		t.Fatalf("TrackFiles failed: %v", err)
+	}
+
+	entries, err := log.Entries()
+	if err != nil {
+		t.Fatalf("Entries failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected entries for the 2 changed files, got %+v", entries)
+	}
+	if entries[0].Path != parent || entries[1].Path != child || entries[1].Before != "" {
+		t.Errorf("Expected the parent and the new child to be recorded, got %+v", entries)
+	}
+}
@@ -0,0 +1,25 @@
+package audit
+
+import (
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// auditedRepository records the writes of another repository
+type auditedRepository struct {
+	pom.Repository
+	recorder  *Recorder
+	operation func(path string) string
+}
+
+// NewRepository wraps a repository so that every Write is recorded;
+// operation describes the write of a file for its entry
+func NewRepository(repo pom.Repository, recorder *Recorder, operation func(path string) string) pom.Repository {
+	return &auditedRepository{Repository: repo, recorder: recorder, operation: operation}
+}
+
+// Write writes data through the wrapped repository and records it
+func (r *auditedRepository) Write(path string, data []byte) error {
+	return r.recorder.Track(path, r.operation(path), func() error {
+		return r.Repository.Write(path, data)
+	})
+}
@@ -0,0 +1,32 @@
+package audit
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+func TestRepository(t *testing.T) {
+	dir := t.TempDir()
+	log := NewLog(filepath.Join(dir, FileName))
+	repo := NewRepository(pom.NewRepository(), NewRecorder(log, ToolGUI), func(path string) string {
+		return "save " + filepath.Base(path)
+	})
+
+	path := filepath.Join(dir, "module", "pom.xml")
+	if err := repo.Write(path, []byte("<project/>")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if !repo.Exists(path) {
+		t.Error("Expected the wrapped repository to write the file")
+	}
+
+	entries, err := log.Entries()
+	if err != nil {
+		t.Fatalf("Entries failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Operation != "save pom.xml" || entries[0].Tool != ToolGUI {
+		t.Errorf("Expected one GUI save entry, got %+v", entries)
+	}
+}
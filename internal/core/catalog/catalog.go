@@ -0,0 +1,120 @@
+// Package catalog keeps the default versions offered for well-known plugins
+// and dependencies current. The newest stable releases are looked up in the
+// remote repositories and cached, so templates and dialogs offer them even
+// offline; artifacts missing from the cache keep the versions pinned in the
+// pom package.
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/core/remote"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the cached catalog in the cache directory
+const FileName = "catalog.yaml"
+
+// Catalog is the refreshed default versions and when they were looked up
+type Catalog struct {
+	Refreshed time.Time         `yaml:"refreshed"`
+	Versions  map[string]string `yaml:"versions"` // groupId:artifactId -> version
+}
+
+// Load reads the catalog cached in dir. A missing cache is not an error; it
+// yields an empty catalog that is always stale.
+func Load(dir string) (*Catalog, error) {
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Catalog{Versions: make(map[string]string)}, nil
+		}
+		return nil, fmt.Errorf("reading version catalog: %w", err)
+	}
+
+	var catalog Catalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("%w: parsing version catalog: %v", pom.ErrInvalidFormat, err)
+	}
+	if catalog.Versions == nil {
+		catalog.Versions = make(map[string]string)
+	}
+	return &catalog, nil
+}
+
+// Save writes the catalog to dir, creating it if needed
+func (c *Catalog) Save(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("encoding version catalog: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, FileName), data, 0644); err != nil {
+		return fmt.Errorf("writing version catalog: %w", err)
+	}
+	return nil
+}
+
+// Stale reports whether the catalog is older than maxAge
+func (c *Catalog) Stale(maxAge time.Duration) bool {
+	return c.Refreshed.IsZero() || time.Since(c.Refreshed) > maxAge
+}
+
+// Apply makes the catalog's versions the defaults offered by templates and
+// dialogs
+func (c *Catalog) Apply() {
+	pom.SetLatestVersions(c.Versions)
+}
+
+// Refresh looks up the newest stable release of every artifact with a
+// default version and returns how many were found. Artifacts that cannot be
+// looked up keep their previous version, or the pinned one, and their errors
+// are returned joined. The refresh time only moves when something was found.
+func (c *Catalog) Refresh(ctx context.Context, client remote.Client) (int, error) {
+	if c.Versions == nil {
+		c.Versions = make(map[string]string)
+	}
+
+	found := 0
+	var errs []error
+	for _, key := range pom.DefaultVersionKeys() {
+		groupID, artifactID := pom.SplitVersionKey(key)
+		metadata, err := client.Metadata(ctx, groupID, artifactID)
+		if err != nil {
+			if ctx.Err() != nil {
+				errs = append(errs, ctx.Err())
+				break
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			continue
+		}
+		if version := metadata.LatestStable(); version != "" {
+			c.Versions[key] = version
+			found++
+		}
+	}
+
+	if found > 0 {
+		c.Refreshed = time.Now()
+	}
+	return found, errors.Join(errs...)
+}
+
+// LoadAndApply applies the catalog cached in dir, if any. It is what
+// front ends call at startup.
+func LoadAndApply(dir string) (*Catalog, error) {
+	catalog, err := Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	catalog.Apply()
+	return catalog, nil
+}
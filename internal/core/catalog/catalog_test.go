@@ -0,0 +1,75 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/core/remote"
+)
+
+// fakeClient serves metadata from memory
+type fakeClient map[string]*remote.Metadata
+
+func (c fakeClient) Metadata(ctx context.Context, groupID, artifactID string) (*remote.Metadata, error) {
+	if metadata, ok := c[groupID+":"+artifactID]; ok {
+		return metadata, nil
+	}
+	return nil, remote.ErrNotFound
+}
+
+func TestRefreshAndApply(t *testing.T) {
+	defer pom.SetLatestVersions(nil)
+
+	client := fakeClient{
+		"org.apache.maven.plugins:maven-compiler-plugin": {
+			Release:  "4.0.0-beta-1",
+			Versions: []string{"3.11.0", "3.13.0", "4.0.0-beta-1"},
+		},
+	}
+	catalog := &Catalog{Versions: map[string]string{"junit:junit": "4.13.3"}}
+	found, err := catalog.Refresh(context.Background(), client)
+	if found != 1 || err == nil {
+		t.Error("Expected errors for artifacts the client does not have")
+	}
+	if got := catalog.Versions["org.apache.maven.plugins:maven-compiler-plugin"]; got != "3.13.0" {
+		t.Errorf("Expected compiler plugin 3.13.0, got %q", got)
+	}
+	if !errors.Is(err, remote.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound among the errors, got %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := catalog.Save(dir); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := LoadAndApply(dir)
+	if err != nil {
+		t.Fatalf("LoadAndApply failed: %v", err)
+	}
+	if loaded.Stale(time.Hour) {
+		t.Error("Expected a fresh catalog not to be stale")
+	}
+
+	if got := pom.DefaultVersion(pom.DefaultPluginGroupID, "maven-compiler-plugin"); got != "3.13.0" {
+		t.Errorf("Expected refreshed version 3.13.0, got %s", got)
+	}
+	if got := pom.DefaultVersion("junit", "junit"); got != "4.13.3" {
+		t.Errorf("Expected the previous version 4.13.3 for an artifact that failed to refresh, got %s", got)
+	}
+	if got := pom.DefaultVersion("javax.servlet", "javax.servlet-api"); got != "4.0.1" {
+		t.Errorf("Expected pinned version 4.0.1 for an artifact missing from the catalog, got %s", got)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	catalog, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !catalog.Stale(24 * time.Hour) {
+		t.Error("Expected a missing catalog to be stale")
+	}
+}
@@ -0,0 +1,450 @@
+// Package classpath resolves the transitive dependencies of a project into
+// the ordered compile, runtime and test classpaths Maven would build, for
+// tracking down "works on my machine" classpath differences.
+//
+// Resolution follows Maven's rules: the nearest declaration of an artifact
+// wins, transitive scopes are mediated through the scope of the dependency
+// that brings them in, optional and test or provided transitive
+// dependencies are left out, exclusions apply to everything below the
+// dependency declaring them, and the project's dependencyManagement pins
+// transitive versions. Version ranges and profiles are not evaluated.
+package classpath
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// Classpaths Maven builds
+const (
+	Compile = pom.ScopeCompile
+	Runtime = pom.ScopeRuntime
+	Test    = pom.ScopeTest
+)
+
+// Scopes lists the classpaths in build order
+var Scopes = []string{Compile, Runtime, Test}
+
+// classpathScopes are the dependency scopes on each classpath
+var classpathScopes = map[string][]string{
+	Compile: {pom.ScopeCompile, pom.ScopeProvided, pom.ScopeSystem},
+	Runtime: {pom.ScopeCompile, pom.ScopeRuntime},
+	Test:    {pom.ScopeCompile, pom.ScopeProvided, pom.ScopeRuntime, pom.ScopeTest, pom.ScopeSystem},
+}
+
+// Artifact is a resolved dependency
+type Artifact struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+	Type       string
+	Classifier string
+	Scope      string // Effective scope after mediation
+	Depth      int    // 1 for direct dependencies
+	Via        string // groupId:artifactId of the dependency that brought it in, "" when direct
+}
+
+// String returns groupId:artifactId[:type[:classifier]]:version, the
+// notation of mvn dependency:list
+func (a Artifact) String() string {
+	parts := []string{a.GroupID, a.ArtifactID}
+	if a.Classifier != "" || (a.Type != "" && a.Type != pom.DefaultDependencyType) {
+		parts = append(parts, typeOrDefault(a.Type))
+	}
+	if a.Classifier != "" {
+		parts = append(parts, a.Classifier)
+	}
+	return strings.Join(append(parts, a.Version), ":")
+}
+
+// Result is the resolved dependency tree, flattened
+type Result struct {
+	Artifacts []Artifact // Every resolved artifact, in classpath order
+	Warnings  []string   // POMs that could not be read, so their dependencies are missing
+}
+
+// Classpath returns the artifacts on a classpath (Compile, Runtime or
+// Test) in order. POM-type dependencies only contribute their own
+// dependencies and are left out.
+func (r *Result) Classpath(scope string) []Artifact {
+	var artifacts []Artifact
+	for _, artifact := range r.Artifacts {
+		if typeOrDefault(artifact.Type) != pom.PackagingPom && OnClasspath(artifact.Scope, scope) {
+			artifacts = append(artifacts, artifact)
+		}
+	}
+	return artifacts
+}
+
+// OnClasspath reports whether a dependency in scope is on a classpath
+// (Compile, Runtime or Test)
+func OnClasspath(scope, classpath string) bool {
+	return slices.Contains(classpathScopes[classpath], scopeOrDefault(scope))
+}
+
+// Resolver resolves the dependency tree of a project
+type Resolver interface {
+	// Resolve resolves the project's dependencies; inheritance may be nil
+	// when the parent chain is unknown
+	Resolve(ctx context.Context, project *pom.Project, inheritance *pom.Inheritance) (*Result, error)
+}
+
+// defaultResolver implements Resolver
+type defaultResolver struct {
+	source Source
+	parser pom.Parser
+}
+
+// NewResolver creates a Resolver reading dependency POMs from source
+func NewResolver(source Source, parser pom.Parser) Resolver {
+	return &defaultResolver{source: source, parser: parser}
+}
+
+// model is the effective content of a POM after inheritance, property
+// interpolation and BOM imports
+type model struct {
+	properties   map[string]string
+	managed      map[string]pom.Dependency // Keyed by Dependency.Key
+	dependencies []pom.Dependency
+}
+
+// node is an artifact in the resolved tree
+type node struct {
+	artifact Artifact
+	children []*node
+}
+
+// pending is a dependency waiting to be resolved
+type pending struct {
+	dep        pom.Dependency
+	parent     *node
+	depth      int
+	scope      string
+	exclusions []pom.Exclusion
+}
+
+// resolution holds the state of one Resolve call
+type resolution struct {
+	*defaultResolver
+	ctx      context.Context
+	models   map[string]*model // groupId:artifactId:version -> model, nil when unreadable
+	warnings []string
+}
+
+func (r *defaultResolver) Resolve(ctx context.Context, project *pom.Project, inheritance *pom.Inheritance) (*Result, error) {
+	if project == nil {
+		return nil, fmt.Errorf("no project to resolve")
+	}
+	res := &resolution{defaultResolver: r, ctx: ctx, models: make(map[string]*model)}
+
+	// The parent chain is already resolved; it stands in for a parent model
+	var parent *model
+	if inheritance != nil {
+		parent = &model{properties: make(map[string]string), managed: make(map[string]pom.Dependency)}
+		for _, property := range inheritance.Properties {
+			parent.properties[property.Name] = property.Value
+		}
+		for _, managed := range inheritance.Managed {
+			parent.managed[managed.Dependency.Key()] = managed.Dependency
+		}
+		for _, dep := range inheritance.Dependencies {
+			parent.dependencies = append(parent.dependencies, dep.Dependency)
+		}
+	}
+	root := res.newModel(project, parent, 0)
+
+	tree := &node{}
+	winners := make(map[string]*node)
+	queue := make([]pending, 0, len(root.dependencies))
+	for _, dep := range root.dependencies {
+		if dep.Scope == pom.ScopeImport {
+			continue
+		}
+		if dep.Version == "" {
+			dep.Version = root.managed[dep.Key()].Version
+		}
+		queue = append(queue, pending{dep: dep, parent: tree, depth: 1, scope: scopeOrDefault(dep.Scope), exclusions: dep.Exclusions})
+	}
+
+	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		item := queue[0]
+		queue = queue[1:]
+		dep := item.dep
+		key := dep.Key()
+
+		// The nearest declaration wins, but takes the widest scope of
+		// transitive declarations, as Maven does
+		if winner, ok := winners[key]; ok {
+			if winner.artifact.Depth > 1 && scopeRank(item.scope) < scopeRank(winner.artifact.Scope) {
+				winner.artifact.Scope = item.scope
+			}
+			continue
+		}
+		if dep.Version == "" {
+			res.warn("%s:%s has no version", dep.GroupID, dep.ArtifactID)
+			continue
+		}
+
+		n := &node{artifact: Artifact{
+			GroupID:    dep.GroupID,
+			ArtifactID: dep.ArtifactID,
+			Version:    dep.Version,
+			Type:       dep.Type,
+			Classifier: dep.Classifier,
+			Scope:      item.scope,
+			Depth:      item.depth,
+			Via:        item.parent.artifact.key(),
+		}}
+		winners[key] = n
+		item.parent.children = append(item.parent.children, n)
+
+		// System dependencies are files on disk without a POM
+		if item.scope == pom.ScopeSystem {
+			continue
+		}
+		m := res.load(dep.GroupID, dep.ArtifactID, dep.Version, 0)
+		if m == nil {
+			continue
+		}
+		for _, child := range m.dependencies {
+			scope := mediateScope(item.scope, scopeOrDefault(child.Scope))
+			if child.Optional || scope == "" || excluded(item.exclusions, child) {
+				continue
+			}
+			if managed, ok := root.managed[child.Key()]; ok && managed.Version != "" {
+				child.Version = managed.Version
+			} else if child.Version == "" {
+				child.Version = m.managed[child.Key()].Version
+			}
+			exclusions := append(append([]pom.Exclusion(nil), item.exclusions...), child.Exclusions...)
+			queue = append(queue, pending{dep: child, parent: n, depth: item.depth + 1, scope: scope, exclusions: exclusions})
+		}
+	}
+
+	result := &Result{Warnings: res.warnings}
+	var walk func(*node)
+	walk = func(n *node) {
+		for _, child := range n.children {
+			result.Artifacts = append(result.Artifacts, child.artifact)
+			walk(child)
+		}
+	}
+	walk(tree)
+	return result, nil
+}
+
+// load returns the model of an artifact's POM, or nil with a warning when
+// it cannot be read
+func (r *resolution) load(groupID, artifactID, version string, depth int) *model {
+	key := groupID + ":" + artifactID + ":" + version
+	if m, ok := r.models[key]; ok {
+		return m
+	}
+	r.models[key] = nil // Guards against cycles while loading
+
+	if depth > pom.MaxParentDepth {
+		r.warn("%s: more than %d ancestors", key, pom.MaxParentDepth)
+		return nil
+	}
+	data, err := r.source.POM(r.ctx, groupID, artifactID, version)
+	if err != nil {
+		r.warn("%v", err)
+		return nil
+	}
+	project, err := r.parser.Parse(data)
+	if err != nil {
+		r.warn("%s: %v", key, err)
+		return nil
+	}
+
+	var parent *model
+	if project.Parent != nil {
+		parent = r.load(project.Parent.GroupID, project.Parent.ArtifactID, project.Parent.Version, depth+1)
+	}
+	m := r.newModel(project, parent, depth)
+	r.models[key] = m
+	return m
+}
+
+// newModel builds the effective model of a project on top of its parent's
+func (r *resolution) newModel(project *pom.Project, parent *model, depth int) *model {
+	m := &model{properties: make(map[string]string), managed: make(map[string]pom.Dependency)}
+	if parent != nil {
+		for name, value := range parent.properties {
+			m.properties[name] = value
+		}
+	}
+	for name, value := range project.Properties {
+		m.properties[name] = value
+	}
+	for _, prefix := range []string{"project.", "pom."} {
+		m.properties[prefix+"groupId"] = project.GroupID
+		m.properties[prefix+"artifactId"] = project.ArtifactID
+		m.properties[prefix+"version"] = project.Version
+		if project.Parent != nil {
+			m.properties[prefix+"parent.groupId"] = project.Parent.GroupID
+			m.properties[prefix+"parent.version"] = project.Parent.Version
+		}
+	}
+
+	// Managed entries of the child win; imported BOMs come last
+	var boms []pom.Dependency
+	for _, managed := range project.DependencyManagement {
+		managed = m.interpolate(managed)
+		if managed.IsBOM() {
+			boms = append(boms, managed)
+			continue
+		}
+		m.managed[managed.Key()] = managed
+	}
+	if parent != nil {
+		// Entries of a parent chain resolved from disk are still raw
+		keys := make([]string, 0, len(parent.managed))
+		for key := range parent.managed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			managed := m.interpolate(parent.managed[key])
+			if managed.IsBOM() {
+				boms = append(boms, managed)
+			} else if _, ok := m.managed[managed.Key()]; !ok {
+				m.managed[managed.Key()] = managed
+			}
+		}
+	}
+	for _, bom := range boms {
+		imported := r.load(bom.GroupID, bom.ArtifactID, bom.Version, depth+1)
+		if imported == nil {
+			continue
+		}
+		for key, managed := range imported.managed {
+			if _, ok := m.managed[key]; !ok {
+				m.managed[key] = managed
+			}
+		}
+	}
+
+	// Dependencies are inherited too; the child's declaration wins
+	declared := make(map[string]bool)
+	for _, dep := range project.Dependencies {
+		dep = m.interpolate(dep)
+		declared[dep.Key()] = true
+		m.dependencies = append(m.dependencies, dep)
+	}
+	if parent != nil {
+		for _, dep := range parent.dependencies {
+			if !declared[dep.Key()] {
+				m.dependencies = append(m.dependencies, m.interpolate(dep))
+			}
+		}
+	}
+	return m
+}
+
+// propertyReference matches ${name}
+var propertyReference = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// interpolate replaces property references in a dependency's coordinates
+func (m *model) interpolate(dep pom.Dependency) pom.Dependency {
+	expand := func(value string) string {
+		// References may refer to further properties
+		for i := 0; i < 10 && strings.Contains(value, "${"); i++ {
+			expanded := propertyReference.ReplaceAllStringFunc(value, func(ref string) string {
+				if v, ok := m.properties[ref[2:len(ref)-1]]; ok {
+					return v
+				}
+				return ref
+			})
+			if expanded == value {
+				break
+			}
+			value = expanded
+		}
+		return value
+	}
+	dep.GroupID = expand(dep.GroupID)
+	dep.ArtifactID = expand(dep.ArtifactID)
+	dep.Version = expand(dep.Version)
+	dep.Classifier = expand(dep.Classifier)
+	dep.Scope = expand(dep.Scope)
+	dep.Type = expand(dep.Type)
+	return dep
+}
+
+func (r *resolution) warn(format string, args ...any) {
+	r.warnings = append(r.warnings, fmt.Sprintf(format, args...))
+}
+
+// key returns groupId:artifactId, "" for the tree's root
+func (a Artifact) key() string {
+	if a.GroupID == "" {
+		return ""
+	}
+	return a.GroupID + ":" + a.ArtifactID
+}
+
+// mediateScope returns the scope of a transitive dependency declared with
+// scope child below a dependency in scope parent, "" when it is left out
+func mediateScope(parent, child string) string {
+	switch child {
+	case pom.ScopeCompile, pom.ScopeRuntime:
+	default:
+		return "" // test, provided, system and import are not transitive
+	}
+	switch parent {
+	case pom.ScopeCompile:
+		return child
+	case pom.ScopeRuntime, pom.ScopeProvided, pom.ScopeTest:
+		return parent
+	}
+	return ""
+}
+
+// scopeRank orders scopes from widest to narrowest, for conflicts
+func scopeRank(scope string) int {
+	switch scope {
+	case pom.ScopeCompile:
+		return 0
+	case pom.ScopeRuntime:
+		return 1
+	case pom.ScopeProvided:
+		return 2
+	}
+	return 3
+}
+
+// excluded reports whether an exclusion, possibly with * wildcards,
+// matches a dependency
+func excluded(exclusions []pom.Exclusion, dep pom.Dependency) bool {
+	for _, exclusion := range exclusions {
+		if (exclusion.GroupID == "*" || exclusion.GroupID == dep.GroupID) &&
+			(exclusion.ArtifactID == "*" || exclusion.ArtifactID == dep.ArtifactID) {
+			return true
+		}
+	}
+	return false
+}
+
+func scopeOrDefault(scope string) string {
+	if scope == "" {
+		return pom.DefaultScope
+	}
+	return scope
+}
+
+func typeOrDefault(depType string) string {
+	if depType == "" {
+		return pom.DefaultDependencyType
+	}
+	return depType
+}
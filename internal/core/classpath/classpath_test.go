@@ -0,0 +1,135 @@
+package classpath
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// mapSource serves POMs from memory, keyed by groupId:artifactId:version
+type mapSource map[string]string
+
+func (s mapSource) POM(ctx context.Context, groupID, artifactID, version string) ([]byte, error) {
+	data, ok := s[groupID+":"+artifactID+":"+version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s:%s:%s", ErrNotFound, groupID, artifactID, version)
+	}
+	return []byte(data), nil
+}
+
+// testPOM builds a POM with the given coordinates and extra content
+func testPOM(artifactID, version, content string) string {
+	return `<project><modelVersion>4.0.0</modelVersion><groupId>g</groupId>` +
+		`<artifactId>` + artifactID + `</artifactId><version>` + version + `</version>` +
+		content + `</project>`
+}
+
+func dependency(artifactID, version, extra string) string {
+	return `<dependency><groupId>g</groupId><artifactId>` + artifactID + `</artifactId>` +
+		`<version>` + version + `</version>` + extra + `</dependency>`
+}
+
+func TestResolve(t *testing.T) {
+	source := mapSource{
+		"g:parent:1": testPOM("parent", "1", `<packaging>pom</packaging>`+
+			`<properties><b.version>2</b.version></properties>`+
+			`<dependencyManagement><dependencies>`+dependency("b", "${b.version}", "")+`</dependencies></dependencyManagement>`),
+		"g:a:1": `<project><modelVersion>4.0.0</modelVersion>` +
+			`<parent><groupId>g</groupId><artifactId>parent</artifactId><version>1</version></parent>` +
+			`<artifactId>a</artifactId><dependencies>` +
+			`<dependency><groupId>g</groupId><artifactId>b</artifactId></dependency>` +
+			dependency("x", "1", "") +
+			dependency("o", "1", "<optional>true</optional>") +
+			dependency("q", "1", "<scope>test</scope>") +
+			dependency("c", "1", "") +
+			`</dependencies></project>`,
+		"g:b:2": testPOM("b", "2", `<dependencies>`+dependency("d", "1", "")+`</dependencies>`),
+		"g:c:9": testPOM("c", "9", ""),
+		"g:d:2": testPOM("d", "2", ""),
+		"g:t:1": testPOM("t", "1", `<dependencies>`+dependency("d", "2", "")+`</dependencies>`),
+		"g:r:1": testPOM("r", "1", `<dependencies>`+dependency("m", "1", "")+`</dependencies>`),
+	}
+
+	project, err := pom.NewParser().Parse([]byte(testPOM("app", "1", `<dependencyManagement><dependencies>`+
+		dependency("c", "9", "")+`</dependencies></dependencyManagement><dependencies>`+
+		dependency("a", "1", "<exclusions><exclusion><groupId>g</groupId><artifactId>x</artifactId></exclusion></exclusions>")+
+		dependency("t", "1", "<scope>test</scope>")+
+		dependency("r", "1", "<scope>runtime</scope>")+
+		`</dependencies>`)))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	result, err := NewResolver(source, pom.NewParser()).Resolve(context.Background(), project, nil)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	var resolved []string
+	for _, artifact := range result.Artifacts {
+		resolved = append(resolved, fmt.Sprintf("%s(%s)", artifact, artifact.Scope))
+	}
+	// Optional, test and excluded transitive dependencies are left out; the
+	// nearest d wins with the widest scope; the project pins c
+	expected := "g:a:1(compile) g:b:2(compile) g:c:9(compile) g:t:1(test) g:d:2(compile) g:r:1(runtime) g:m:1(runtime)"
+	if got := strings.Join(resolved, " "); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "g:m:1") {
+		t.Errorf("Expected a warning for the missing g:m:1 POM, got %v", result.Warnings)
+	}
+	if d := result.Artifacts[4]; d.Depth != 2 || d.Via != "g:t" {
+		t.Errorf("Expected d at depth 2 via g:t, got depth %d via %s", d.Depth, d.Via)
+	}
+
+	for scope, expected := range map[string]int{Compile: 4, Runtime: 6, Test: 7} {
+		if got := len(result.Classpath(scope)); got != expected {
+			t.Errorf("Expected %d artifacts on the %s classpath, got %d", expected, scope, got)
+		}
+	}
+}
+
+func TestResolveBOM(t *testing.T) {
+	source := mapSource{
+		"g:bom:1": testPOM("bom", "1", `<packaging>pom</packaging><dependencyManagement><dependencies>`+
+			dependency("a", "3", "")+`</dependencies></dependencyManagement>`),
+		"g:a:3": testPOM("a", "3", ""),
+	}
+	project, err := pom.NewParser().Parse([]byte(testPOM("app", "1", `<dependencyManagement><dependencies>`+
+		dependency("bom", "1", "<type>pom</type><scope>import</scope>")+
+		`</dependencies></dependencyManagement><dependencies>`+
+		`<dependency><groupId>g</groupId><artifactId>a</artifactId></dependency></dependencies>`)))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	result, err := NewResolver(source, pom.NewParser()).Resolve(context.Background(), project, nil)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if len(result.Artifacts) != 1 || result.Artifacts[0].Version != "3" {
+		t.Errorf("Expected a:3 from the BOM, got %v", result.Artifacts)
+	}
+}
+
+func TestMediateScope(t *testing.T) {
+	tests := []struct {
+		parent, child, expected string
+	}{
+		{pom.ScopeCompile, pom.ScopeCompile, pom.ScopeCompile},
+		{pom.ScopeCompile, pom.ScopeRuntime, pom.ScopeRuntime},
+		{pom.ScopeRuntime, pom.ScopeCompile, pom.ScopeRuntime},
+		{pom.ScopeTest, pom.ScopeCompile, pom.ScopeTest},
+		{pom.ScopeProvided, pom.ScopeRuntime, pom.ScopeProvided},
+		{pom.ScopeCompile, pom.ScopeTest, ""},
+		{pom.ScopeCompile, pom.ScopeProvided, ""},
+	}
+	for _, tt := range tests {
+		if got := mediateScope(tt.parent, tt.child); got != tt.expected {
+			t.Errorf("Expected %s below %s to be %q, got %q", tt.child, tt.parent, tt.expected, got)
+		}
+	}
+}
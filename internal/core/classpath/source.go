@@ -0,0 +1,114 @@
+package classpath
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/core/remote"
+)
+
+// ErrNotFound indicates that no source has an artifact's POM
+var ErrNotFound = errors.New("POM not found")
+
+// maxPOMSize limits how much of a downloaded POM is read
+const maxPOMSize = 4 << 20
+
+// Source provides the POMs of artifacts
+type Source interface {
+	POM(ctx context.Context, groupID, artifactID, version string) ([]byte, error)
+}
+
+// localRepository reads POMs from a Maven local repository
+type localRepository struct {
+	dir string
+}
+
+// NewLocalRepository creates a Source reading a local repository such as
+// ~/.m2/repository
+func NewLocalRepository(dir string) Source {
+	return &localRepository{dir: dir}
+}
+
+func (r *localRepository) POM(ctx context.Context, groupID, artifactID, version string) ([]byte, error) {
+	data, err := os.ReadFile(pom.LocalRepositoryPath(r.dir, groupID, artifactID, version, "pom"))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s:%s:%s", ErrNotFound, groupID, artifactID, version)
+	}
+	return data, err
+}
+
+// remoteRepository downloads POMs from a repository over HTTP
+type remoteRepository struct {
+	url      string
+	client   *http.Client
+	verifier *remote.Verifier
+}
+
+// NewRemoteRepository creates a Source downloading from a repository URL
+// such as remote.MavenCentral. Downloaded POMs are checked against their
+// checksums, and signatures, by verifier, which may be nil.
+func NewRemoteRepository(url string, client *http.Client, verifier *remote.Verifier) Source {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &remoteRepository{url: strings.TrimSuffix(url, "/"), client: client, verifier: verifier}
+}
+
+func (r *remoteRepository) POM(ctx context.Context, groupID, artifactID, version string) ([]byte, error) {
+	url := fmt.Sprintf("%s/%s/%s/%s/%s-%s.pom", r.url, strings.ReplaceAll(groupID, ".", "/"),
+		artifactID, version, artifactID, version)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching POM: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s:%s:%s", ErrNotFound, groupID, artifactID, version)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPOMSize))
+	if err != nil {
+		return nil, fmt.Errorf("fetching POM: %w", err)
+	}
+	if err := r.verifier.Check(ctx, r.client, url, data, true); err != nil {
+		return nil, fmt.Errorf("verifying %s: %w", url, err)
+	}
+	return data, nil
+}
+
+// chain tries several sources in order
+type chain []Source
+
+// NewChain creates a Source trying each source in turn, e.g. the local
+// repository before a remote one
+func NewChain(sources ...Source) Source {
+	return chain(sources)
+}
+
+func (c chain) POM(ctx context.Context, groupID, artifactID, version string) ([]byte, error) {
+	var lastErr error = fmt.Errorf("%w: %s:%s:%s", ErrNotFound, groupID, artifactID, version)
+	for _, source := range c {
+		data, err := source.POM(ctx, groupID, artifactID, version)
+		if err == nil {
+			return data, nil
+		}
+		// Keep the most informative error; a network error beats not found
+		if !errors.Is(err, ErrNotFound) || errors.Is(lastErr, ErrNotFound) {
+			lastErr = err
+		}
+	}
+	return nil, lastErr
+}
@@ -0,0 +1,51 @@
+package classpath
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/user/pom-manager/internal/core/remote"
+)
+
+func TestSources(t *testing.T) {
+	repo := t.TempDir()
+	dir := filepath.Join(repo, "org", "example", "lib", "1.0")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "lib-1.0.pom"), []byte("<project/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/org/example/remote/2.0/remote-2.0.pom", "/org/example/tampered/1.0/tampered-1.0.pom":
+			w.Write([]byte("<project/>"))
+		case "/org/example/tampered/1.0/tampered-1.0.pom.sha1":
+			w.Write([]byte("0000000000000000000000000000000000000000"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	source := NewChain(NewLocalRepository(repo), NewRemoteRepository(server.URL+"/", nil, nil))
+	ctx := context.Background()
+	if _, err := source.POM(ctx, "org.example", "lib", "1.0"); err != nil {
+		t.Errorf("Expected the local POM, got %v", err)
+	}
+	if _, err := source.POM(ctx, "org.example", "remote", "2.0"); err != nil {
+		t.Errorf("Expected the remote POM, got %v", err)
+	}
+	if _, err := source.POM(ctx, "org.example", "missing", "1.0"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if _, err := source.POM(ctx, "org.example", "tampered", "1.0"); !errors.Is(err, remote.ErrChecksumMismatch) {
+		t.Errorf("Expected a POM not matching its checksum to be rejected, got %v", err)
+	}
+}
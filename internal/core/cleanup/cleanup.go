@@ -0,0 +1,259 @@
+// Package cleanup finds dependency declarations a project can do without:
+// exact duplicates, versions repeating what dependencyManagement already
+// manages, and dependencies other dependencies already bring in at the same
+// version.
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/user/pom-manager/internal/core/classpath"
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// Kind is the kind of redundancy
+type Kind string
+
+// Kinds of redundancy
+const (
+	// Duplicate is a declaration identical to an earlier one; it is removed
+	Duplicate Kind = "duplicate"
+	// Transitive is a dependency other dependencies already bring in at the
+	// same version and on the same classpaths; it is removed
+	Transitive Kind = "transitive"
+	// ManagedVersion is a version identical to the managed one; the version
+	// is removed, the dependency kept
+	ManagedVersion Kind = "managed version"
+)
+
+// Finding is a redundant dependency declaration
+type Finding struct {
+	Kind       Kind
+	Index      int // In the project's dependencies
+	Dependency pom.Dependency
+	Reason     string // Such as "brought in by org.example:core"
+}
+
+// Description describes the finding for display, such as
+// "Remove junit:junit:4.13.2 (declared twice)"
+func (f Finding) Description() string {
+	coordinates := fmt.Sprintf("%s:%s", f.Dependency.GroupID, f.Dependency.ArtifactID)
+	if f.Kind == ManagedVersion {
+		return fmt.Sprintf("Remove version %s of %s (%s)", f.Dependency.Version, coordinates, f.Reason)
+	}
+	if f.Dependency.Version != "" {
+		coordinates += ":" + f.Dependency.Version
+	}
+	return fmt.Sprintf("Remove %s (%s)", coordinates, f.Reason)
+}
+
+// Report is the outcome of Find
+type Report struct {
+	Findings []Finding // In dependency order
+	Warnings []string  // POMs that could not be read, so transitive dependencies may be missed
+}
+
+// Find looks for redundant declarations in the project's dependencies.
+// inheritance may be nil when the parent chain is unknown. Transitive
+// dependencies are resolved with resolver, once per candidate with the
+// candidate left out; a nil resolver skips them. Dependencies with
+// exclusions, optional ones and system ones are never reported as
+// transitive, as removing them would change more than the declaration.
+func Find(ctx context.Context, project *pom.Project, inheritance *pom.Inheritance, resolver classpath.Resolver) (*Report, error) {
+	if project == nil {
+		return nil, fmt.Errorf("no project to clean up")
+	}
+	report := &Report{}
+	removed := make(map[int]bool)
+
+	// Exact duplicates; the first declaration stays
+	for i, dep := range project.Dependencies {
+		for j := 0; j < i; j++ {
+			if !removed[j] && reflect.DeepEqual(project.Dependencies[j], dep) {
+				report.Findings = append(report.Findings, Finding{Kind: Duplicate, Index: i, Dependency: dep, Reason: "declared twice"})
+				removed[i] = true
+				break
+			}
+		}
+	}
+
+	if resolver != nil {
+		transitive, warnings, err := findTransitive(ctx, project, inheritance, resolver, removed)
+		if err != nil {
+			return nil, err
+		}
+		for _, finding := range transitive {
+			removed[finding.Index] = true
+		}
+		report.Findings = append(report.Findings, transitive...)
+		report.Warnings = warnings
+	}
+
+	// Versions dependencyManagement already hands out, for the dependencies
+	// that stay
+	properties := effectiveProperties(project, inheritance)
+	for i, dep := range project.Dependencies {
+		if removed[i] || dep.Version == "" {
+			continue
+		}
+		managed, source, ok := managedVersion(project, inheritance, dep)
+		if ok && expand(managed, properties) == expand(dep.Version, properties) {
+			report.Findings = append(report.Findings, Finding{Kind: ManagedVersion, Index: i, Dependency: dep, Reason: "managed by " + source})
+		}
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return report.Findings[i].Index < report.Findings[j].Index
+	})
+	return report, nil
+}
+
+// findTransitive reports the dependencies still resolved at the same
+// version, on every classpath they are on, when left out along with the
+// removed ones
+func findTransitive(ctx context.Context, project *pom.Project, inheritance *pom.Inheritance, resolver classpath.Resolver, removed map[int]bool) ([]Finding, []string, error) {
+	full, err := resolver.Resolve(ctx, project, inheritance)
+	if err != nil {
+		return nil, nil, err
+	}
+	direct := make(map[string]classpath.Artifact)
+	for _, artifact := range full.Artifacts {
+		if artifact.Depth == 1 {
+			direct[artifactKey(artifact)] = artifact
+		}
+	}
+
+	var findings []Finding
+	warnings := full.Warnings
+	for i, dep := range project.Dependencies {
+		declared, ok := direct[dep.Key()]
+		if removed[i] || !ok || len(dep.Exclusions) > 0 || dep.Optional || dep.Scope == pom.ScopeSystem || dep.Scope == pom.ScopeImport {
+			continue
+		}
+
+		without := project.Clone()
+		without.Dependencies = nil
+		for j, other := range project.Dependencies {
+			if j != i && !removed[j] {
+				without.Dependencies = append(without.Dependencies, other)
+			}
+		}
+		result, err := resolver.Resolve(ctx, without, inheritance)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, artifact := range result.Artifacts {
+			if artifactKey(artifact) != dep.Key() {
+				continue
+			}
+			if artifact.Version == declared.Version && coversScope(artifact.Scope, declared.Scope) {
+				findings = append(findings, Finding{Kind: Transitive, Index: i, Dependency: dep, Reason: "brought in by " + artifact.Via})
+			}
+			break
+		}
+	}
+	return findings, warnings, nil
+}
+
+// Apply removes the redundancies of findings from the project: duplicate
+// and transitive dependencies are removed, managed versions cleared
+func Apply(project *pom.Project, findings []Finding) {
+	remove := make(map[int]bool)
+	for _, finding := range findings {
+		if finding.Index < 0 || finding.Index >= len(project.Dependencies) {
+			continue
+		}
+		switch finding.Kind {
+		case Duplicate, Transitive:
+			remove[finding.Index] = true
+		case ManagedVersion:
+			project.Dependencies[finding.Index].Version = ""
+		}
+	}
+
+	kept := project.Dependencies[:0]
+	for i, dep := range project.Dependencies {
+		if !remove[i] {
+			kept = append(kept, dep)
+		}
+	}
+	project.Dependencies = kept
+}
+
+// managedVersion returns the version dependencyManagement, the project's
+// or else a parent's, declares for dep, and where it is declared. Maven
+// uses the last of repeated entries; entries managing different versions
+// are a mistake of their own (see pom.RuleManagedDuplicate), so they
+// manage nothing here rather than have a version removed on their account.
+func managedVersion(project *pom.Project, inheritance *pom.Inheritance, dep pom.Dependency) (string, string, bool) {
+	var matches []pom.Dependency
+	for _, managed := range project.DependencyManagement {
+		if managed.Key() == dep.Key() && !managed.IsBOM() {
+			matches = append(matches, managed)
+		}
+	}
+	if len(matches) > 0 {
+		last := matches[len(matches)-1]
+		for _, managed := range matches {
+			if managed.Version != last.Version {
+				return "", "", false
+			}
+		}
+		return last.Version, "dependencyManagement", true
+	}
+	if inheritance != nil {
+		for _, managed := range inheritance.Managed {
+			if managed.Key() == dep.Key() && !managed.IsBOM() {
+				return managed.Version, "parent " + managed.Source, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// effectiveProperties returns the project's properties over the inherited
+// ones
+func effectiveProperties(project *pom.Project, inheritance *pom.Inheritance) map[string]string {
+	properties := make(map[string]string)
+	if inheritance != nil {
+		for _, property := range inheritance.Properties {
+			properties[property.Name] = property.Value
+		}
+	}
+	for key, value := range project.Properties {
+		properties[key] = value
+	}
+	return properties
+}
+
+// expand replaces a version that is a single ${property} reference with
+// its value, so "${junit.version}" and "5.10.2" compare equal
+func expand(version string, properties map[string]string) string {
+	version = strings.TrimSpace(version)
+	if strings.HasPrefix(version, "${") && strings.HasSuffix(version, "}") {
+		if value, ok := properties[version[2:len(version)-1]]; ok {
+			return strings.TrimSpace(value)
+		}
+	}
+	return version
+}
+
+// coversScope reports whether an artifact in scope transitive is on every
+// classpath a declaration in scope declared puts it on
+func coversScope(transitive, declared string) bool {
+	for _, cp := range classpath.Scopes {
+		if classpath.OnClasspath(declared, cp) && !classpath.OnClasspath(transitive, cp) {
+			return false
+		}
+	}
+	return true
+}
+
+// artifactKey returns the pom.Dependency.Key of a resolved artifact
+func artifactKey(artifact classpath.Artifact) string {
+	return pom.Dependency{GroupID: artifact.GroupID, ArtifactID: artifact.ArtifactID, Type: artifact.Type, Classifier: artifact.Classifier}.Key()
+}
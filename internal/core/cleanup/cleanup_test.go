@@ -0,0 +1,132 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/user/pom-manager/internal/core/classpath"
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// mapSource serves POMs from memory, keyed by groupId:artifactId:version
+type mapSource map[string]string
+
+func (s mapSource) POM(ctx context.Context, groupID, artifactID, version string) ([]byte, error) {
+	data, ok := s[groupID+":"+artifactID+":"+version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s:%s:%s", classpath.ErrNotFound, groupID, artifactID, version)
+	}
+	return []byte(data), nil
+}
+
+func testPOM(artifactID, dependencies string) string {
+	return `<project><modelVersion>4.0.0</modelVersion><groupId>g</groupId>` +
+		`<artifactId>` + artifactID + `</artifactId><version>1</version>` +
+		`<dependencies>` + dependencies + `</dependencies></project>`
+}
+
+func dependency(artifactID, version, extra string) string {
+	return `<dependency><groupId>g</groupId><artifactId>` + artifactID + `</artifactId>` +
+		`<version>` + version + `</version>` + extra + `</dependency>`
+}
+
+func TestFind(t *testing.T) {
+	source := mapSource{
+		"g:core:1":  testPOM("core", dependency("util", "1", "")+dependency("log", "1", "<scope>runtime</scope>")),
+		"g:util:1":  testPOM("util", ""),
+		"g:util:2":  testPOM("util", ""),
+		"g:log:1":   testPOM("log", ""),
+		"g:other:1": testPOM("other", ""),
+		"g:test:1":  testPOM("test", ""),
+	}
+
+	project, err := pom.NewParser().Parse([]byte(`<project><modelVersion>4.0.0</modelVersion>` +
+		`<groupId>g</groupId><artifactId>app</artifactId><version>1</version>` +
+		`<properties><test.version>1</test.version></properties>` +
+		`<dependencyManagement><dependencies>` + dependency("test", "1", "") + `</dependencies></dependencyManagement>` +
+		`<dependencies>` +
+		dependency("core", "1", "") +
+		dependency("util", "1", "") + // Brought in by core
+		dependency("log", "1", "") + // Brought in by core, but only at runtime
+		dependency("other", "1", "") +
+		dependency("other", "1", "") + // Exact duplicate
+		dependency("test", "${test.version}", "<scope>test</scope>") + // Managed version
+		`</dependencies></project>`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	report, err := Find(context.Background(), project, nil, classpath.NewResolver(source, pom.NewParser()))
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+
+	var found []string
+	for _, finding := range report.Findings {
+		found = append(found, fmt.Sprintf("%d:%s", finding.Index, finding.Kind))
+	}
+	expected := "1:transitive 4:duplicate 5:managed version"
+	if got := strings.Join(found, " "); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+	if len(report.Findings) > 0 && report.Findings[0].Reason != "brought in by g:core" {
+		t.Errorf("Expected util to be brought in by g:core, got %q", report.Findings[0].Reason)
+	}
+
+	Apply(project, report.Findings)
+	var kept []string
+	for _, dep := range project.Dependencies {
+		kept = append(kept, dep.ArtifactID+":"+dep.Version)
+	}
+	if got := strings.Join(kept, " "); got != "core:1 log:1 other:1 test:" {
+		t.Errorf("Expected core:1 log:1 other:1 test:, got %s", got)
+	}
+}
+
+func TestFindDifferentVersion(t *testing.T) {
+	source := mapSource{
+		"g:core:1": testPOM("core", dependency("util", "1", "")),
+		"g:util:1": testPOM("util", ""),
+		"g:util:2": testPOM("util", ""),
+	}
+	project, err := pom.NewParser().Parse([]byte(testPOM("app", dependency("core", "1", "")+dependency("util", "2", ""))))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	report, err := Find(context.Background(), project, nil, classpath.NewResolver(source, pom.NewParser()))
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if len(report.Findings) != 0 {
+		t.Errorf("Expected a dependency overriding the transitive version to stay, got %+v", report.Findings)
+	}
+
+	// Without a resolver, transitive dependencies are not looked at
+	if report, err := Find(context.Background(), project, nil, nil); err != nil || len(report.Findings) != 0 {
+		t.Errorf("Expected no findings without a resolver, got %+v, %v", report, err)
+	}
+}
+
+func TestFindCollidingManagedVersions(t *testing.T) {
+	project, err := pom.NewParser().Parse([]byte(`<project><modelVersion>4.0.0</modelVersion>` +
+		`<groupId>g</groupId><artifactId>app</artifactId><version>1</version>` +
+		`<dependencyManagement><dependencies>` +
+		dependency("x", "1", "") + dependency("x", "2", "") + // Maven uses 2
+		dependency("y", "1", "") + dependency("y", "1", "") +
+		`</dependencies></dependencyManagement>` +
+		`<dependencies>` + dependency("x", "1", "") + dependency("y", "1", "") + `</dependencies></project>`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	report, err := Find(context.Background(), project, nil, nil)
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if len(report.Findings) != 1 || report.Findings[0].Dependency.ArtifactID != "y" {
+		t.Errorf("Expected only the version of y to be redundant, got %+v", report.Findings)
+	}
+}
@@ -0,0 +1,179 @@
+package convert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// mavenCentral is the repository maven_install resolves against
+const mavenCentral = "https://repo1.maven.org/maven2"
+
+// Bazel generates a Starlark file listing the project's dependencies in the
+// rules_jvm_external maven_install format. Versions are resolved from the
+// project's properties and dependencyManagement, since maven_install pins
+// each artifact to a concrete version.
+func Bazel(project *pom.Project) *Output {
+	b := &bazelWriter{gradleWriter{project: project}}
+	b.write()
+	return &Output{
+		FileName: "maven_artifacts.bzl",
+		Content:  []byte(b.out.String()),
+		Warnings: b.warnings,
+	}
+}
+
+// bazelWriter reuses the Gradle writer's output and warning handling
+type bazelWriter struct {
+	gradleWriter
+}
+
+func (b *bazelWriter) write() {
+	p := b.project
+
+	b.line(0, "# Converted from %s by pom-manager", p.Coordinates.String())
+	b.line(0, "#")
+	b.line(0, "# Usage in WORKSPACE:")
+	b.line(0, `#   load("@rules_jvm_external//:defs.bzl", "maven_install")`)
+	b.line(0, `#   load("//:maven_artifacts.bzl", "MAVEN_ARTIFACTS", "MAVEN_BOMS", "MAVEN_REPOSITORIES")`)
+	b.line(0, "#   maven_install(artifacts = MAVEN_ARTIFACTS, boms = MAVEN_BOMS, repositories = MAVEN_REPOSITORIES)")
+	b.out.WriteString("\n")
+	b.line(0, `load("@rules_jvm_external//:specs.bzl", "maven")`)
+	b.out.WriteString("\n")
+
+	var boms []string
+	for _, dep := range p.BOMs() {
+		if coords := b.coordinates(dep); coords != "" {
+			boms = append(boms, fmt.Sprintf("%q", coords))
+		}
+	}
+	b.list("MAVEN_BOMS", boms)
+	b.out.WriteString("\n")
+
+	var artifacts []string
+	for _, dep := range p.Dependencies {
+		if artifact := b.artifact(dep, len(boms) > 0); artifact != "" {
+			artifacts = append(artifacts, artifact)
+		}
+	}
+	b.list("MAVEN_ARTIFACTS", artifacts)
+	b.out.WriteString("\n")
+
+	b.list("MAVEN_REPOSITORIES", []string{fmt.Sprintf("%q", mavenCentral)})
+
+	if p.Parent != nil {
+		b.warn("parent %s:%s:%s is not resolved; versions and dependencies it manages are missing",
+			p.Parent.GroupID, p.Parent.ArtifactID, p.Parent.Version)
+	}
+	for _, profile := range p.Profiles {
+		b.warn("profile %s is not converted", profile.ID)
+	}
+}
+
+// list emits a Starlark list constant
+func (b *bazelWriter) list(name string, entries []string) {
+	if len(entries) == 0 {
+		b.line(0, "%s = []", name)
+		return
+	}
+	b.line(0, "%s = [", name)
+	for _, entry := range entries {
+		b.line(1, "%s,", entry)
+	}
+	b.line(0, "]")
+}
+
+// artifact returns the maven_install entry for a dependency, or "" when the
+// dependency cannot be expressed. withBOMs allows version-less entries,
+// which maven_install resolves through the BOMs.
+func (b *bazelWriter) artifact(dep pom.Dependency, withBOMs bool) string {
+	key := dep.GroupID + ":" + dep.ArtifactID
+	scope := dep.Scope
+	if scope == "" {
+		scope = pom.DefaultScope
+	}
+	if scope == pom.ScopeSystem || scope == pom.ScopeImport {
+		b.warn("dependency %s: scope %s has no maven_install equivalent, skipped", key, scope)
+		return ""
+	}
+
+	version := b.version(dep)
+	if version == "" && !withBOMs {
+		b.warn("dependency %s: version could not be resolved, skipped", key)
+		return ""
+	}
+	if dep.Optional {
+		b.warn("dependency %s: optional has no maven_install equivalent", key)
+	}
+
+	// Plain coordinates suffice unless attributes need maven.artifact
+	var attrs []string
+	if dep.Type != "" && dep.Type != pom.DefaultDependencyType {
+		attrs = append(attrs, fmt.Sprintf("packaging = %q", dep.Type))
+	}
+	if dep.Classifier != "" {
+		attrs = append(attrs, fmt.Sprintf("classifier = %q", dep.Classifier))
+	}
+	switch scope {
+	case pom.ScopeTest:
+		attrs = append(attrs, "testonly = True")
+	case pom.ScopeProvided:
+		attrs = append(attrs, "neverlink = True")
+	}
+	var exclusions []string
+	for _, excl := range dep.Exclusions {
+		if excl.GroupID == "*" || excl.ArtifactID == "*" {
+			b.warn("dependency %s: wildcard exclusion %s:%s is not supported by maven_install",
+				key, excl.GroupID, excl.ArtifactID)
+			continue
+		}
+		exclusions = append(exclusions, fmt.Sprintf("%q", excl.GroupID+":"+excl.ArtifactID))
+	}
+	if len(exclusions) > 0 {
+		attrs = append(attrs, fmt.Sprintf("exclusions = [%s]", strings.Join(exclusions, ", ")))
+	}
+
+	if len(attrs) == 0 {
+		if version == "" {
+			return fmt.Sprintf("%q", key)
+		}
+		return fmt.Sprintf("%q", key+":"+version)
+	}
+
+	args := []string{fmt.Sprintf("group = %q", dep.GroupID), fmt.Sprintf("artifact = %q", dep.ArtifactID)}
+	if version != "" {
+		args = append(args, fmt.Sprintf("version = %q", version))
+	}
+	return fmt.Sprintf("maven.artifact(%s)", strings.Join(append(args, attrs...), ", "))
+}
+
+// version returns the concrete version of a dependency, falling back to
+// the project's dependencyManagement; "" when it cannot be resolved
+func (b *bazelWriter) version(dep pom.Dependency) string {
+	version := dep.Version
+	if version == "" {
+		version, _ = b.project.ManagedVersion(dep.GroupID, dep.ArtifactID)
+	}
+	return b.resolve(b.builtins(version))
+}
+
+// coordinates returns groupId:artifactId:version with the version resolved
+func (b *bazelWriter) coordinates(dep pom.Dependency) string {
+	version := b.resolve(b.builtins(dep.Version))
+	if version == "" {
+		b.warn("BOM %s:%s: version could not be resolved, skipped", dep.GroupID, dep.ArtifactID)
+		return ""
+	}
+	return dep.GroupID + ":" + dep.ArtifactID + ":" + version
+}
+
+// builtins expands the project coordinate references Maven defines
+func (b *bazelWriter) builtins(value string) string {
+	return strings.NewReplacer(
+		"${project.version}", b.project.Version,
+		"${pom.version}", b.project.Version,
+		"${project.groupId}", b.project.GroupID,
+		"${pom.groupId}", b.project.GroupID,
+	).Replace(value)
+}
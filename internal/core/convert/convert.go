@@ -0,0 +1,46 @@
+// Package convert translates a Maven project into the build files of other
+// build tools. Constructs without an equivalent are reported as warnings
+// rather than silently dropped.
+package convert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// Output formats
+const (
+	FormatGradle    = "gradle"
+	FormatGradleKts = "gradle-kts"
+	FormatBazel     = "bazel"
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatGradle, FormatGradleKts, FormatBazel}
+
+// Output is a generated build file
+type Output struct {
+	FileName string // Conventional file name, e.g. build.gradle.kts
+	Content  []byte
+	Warnings []string // Constructs that could not be converted exactly
+}
+
+// Convert generates the build file for the given format
+func Convert(project *pom.Project, format string) (*Output, error) {
+	if project == nil {
+		return nil, fmt.Errorf("project cannot be nil")
+	}
+
+	switch format {
+	case FormatGradle:
+		return Gradle(project), nil
+	case FormatGradleKts:
+		return GradleKts(project), nil
+	case FormatBazel:
+		return Bazel(project), nil
+	}
+	return nil, fmt.Errorf("%w: unknown output format %q (expected one of: %s)",
+		pom.ErrInvalidFormat, format, strings.Join(Formats, ", "))
+}
@@ -0,0 +1,152 @@
+package convert
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+func TestGradleKts(t *testing.T) {
+	project := &pom.Project{
+		GroupID:    "com.example",
+		ArtifactID: "app",
+		Version:    "1.0.0",
+		Properties: map[string]string{
+			"maven.compiler.source": "1.8",
+			"junit.version":         "5.10.0",
+		},
+		DependencyManagement: []pom.Dependency{
+			pom.NewBOM("org.springframework.boot", "spring-boot-dependencies", "3.2.0"),
+		},
+		Dependencies: []pom.Dependency{
+			{GroupID: "org.springframework.boot", ArtifactID: "spring-boot-starter-web"},
+			{GroupID: "org.junit.jupiter", ArtifactID: "junit-jupiter", Version: "${junit.version}", Scope: pom.ScopeTest},
+			{GroupID: "com.example", ArtifactID: "native", Version: "1.0", Scope: pom.ScopeSystem},
+		},
+		Build: &pom.Build{
+			Plugins: []pom.Plugin{
+				{GroupID: "org.apache.maven.plugins", ArtifactID: "maven-compiler-plugin", Version: "3.11.0"},
+				{GroupID: "org.apache.maven.plugins", ArtifactID: "maven-enforcer-plugin", Version: "3.4.1"},
+			},
+		},
+		Profiles: []pom.Profile{{ID: "release"}},
+	}
+
+	output, err := Convert(project, FormatGradleKts)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	script := string(output.Content)
+
+	for _, expected := range []string{
+		`group = "com.example"`,
+		"languageVersion.set(JavaLanguageVersion.of(8))",
+		`implementation(platform("org.springframework.boot:spring-boot-dependencies:3.2.0"))`,
+		`implementation("org.springframework.boot:spring-boot-starter-web")`,
+		`testImplementation("org.junit.jupiter:junit-jupiter:${extra["junit.version"]}")`,
+		"useJUnitPlatform()",
+	} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("Expected script to contain %q:\n%s", expected, script)
+		}
+	}
+	if strings.Contains(script, "native") {
+		t.Error("Expected system scope dependency to be skipped")
+	}
+
+	// System scope, the enforcer plugin, and the profile cannot be converted
+	if len(output.Warnings) != 3 {
+		t.Errorf("Expected 3 warnings, got %d: %v", len(output.Warnings), output.Warnings)
+	}
+
+	if _, err := Convert(project, "ant"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
+
+func TestBazel(t *testing.T) {
+	project := &pom.Project{
+		GroupID:    "com.example",
+		ArtifactID: "app",
+		Version:    "1.0.0",
+		Properties: map[string]string{"guava.version": "32.1.2-jre"},
+		DependencyManagement: []pom.Dependency{
+			{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.9"},
+		},
+		Dependencies: []pom.Dependency{
+			{GroupID: "com.google.guava", ArtifactID: "guava", Version: "${guava.version}"},
+			{GroupID: "org.slf4j", ArtifactID: "slf4j-api"},
+			{GroupID: "junit", ArtifactID: "junit", Version: "4.13.2", Scope: pom.ScopeTest},
+			{GroupID: "com.example", ArtifactID: "unmanaged"},
+		},
+	}
+
+	output, err := Convert(project, FormatBazel)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	content := string(output.Content)
+
+	for _, expected := range []string{
+		`"com.google.guava:guava:32.1.2-jre",`,
+		`"org.slf4j:slf4j-api:2.0.9",`,
+		`maven.artifact(group = "junit", artifact = "junit", version = "4.13.2", testonly = True),`,
+		`"https://repo1.maven.org/maven2",`,
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q:\n%s", expected, content)
+		}
+	}
+
+	// Without a BOM the unmanaged dependency has no version to pin
+	if strings.Contains(content, "unmanaged") || len(output.Warnings) != 1 {
+		t.Errorf("Expected unresolved dependency to be skipped with a warning, got %v", output.Warnings)
+	}
+}
+
+func TestGradleGroovy(t *testing.T) {
+	project := &pom.Project{
+		GroupID:    "com.example",
+		ArtifactID: "app",
+		Version:    "1.0.0",
+		Properties: map[string]string{
+			"maven.compiler.release": "17",
+			"junit.version":          "5.10.0",
+		},
+		Dependencies: []pom.Dependency{
+			{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.9", Exclusions: []pom.Exclusion{{GroupID: "*", ArtifactID: "*"}}},
+			{GroupID: "org.junit.jupiter", ArtifactID: "junit-jupiter", Version: "${junit.version}", Scope: pom.ScopeTest},
+		},
+		Build: &pom.Build{
+			Plugins: []pom.Plugin{
+				{GroupID: "org.springframework.boot", ArtifactID: "spring-boot-maven-plugin", Version: "3.2.0"},
+			},
+		},
+	}
+
+	output, err := Convert(project, FormatGradle)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if output.FileName != "build.gradle" {
+		t.Errorf("Expected build.gradle, got %s", output.FileName)
+	}
+	script := string(output.Content)
+
+	for _, expected := range []string{
+		"id 'java'",
+		"id 'org.springframework.boot' version '3.2.0'",
+		"group = 'com.example'",
+		"languageVersion = JavaLanguageVersion.of(17)",
+		"ext['junit.version'] = '5.10.0'",
+		"implementation('org.slf4j:slf4j-api:2.0.9') {",
+		"transitive = false",
+		`testImplementation "org.junit.jupiter:junit-jupiter:${ext['junit.version']}"`,
+		"tasks.named('test') {",
+	} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("Expected script to contain %q:\n%s", expected, script)
+		}
+	}
+}
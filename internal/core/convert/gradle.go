@@ -0,0 +1,513 @@
+package convert
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// gradleConfigurations maps Maven scopes to Gradle dependency configurations
+var gradleConfigurations = map[string]string{
+	pom.ScopeCompile:  "implementation",
+	pom.ScopeProvided: "compileOnly",
+	pom.ScopeRuntime:  "runtimeOnly",
+	pom.ScopeTest:     "testImplementation",
+}
+
+// gradlePlugin is the Gradle counterpart of a Maven plugin
+type gradlePlugin struct {
+	id        string // Plugin id; "" when the java plugin covers it
+	versioned bool   // Append the Maven plugin's version to the entry
+}
+
+// gradlePlugins maps Maven plugins (groupId:artifactId) to Gradle plugins
+var gradlePlugins = map[string]gradlePlugin{
+	"org.apache.maven.plugins:maven-compiler-plugin":    {},
+	"org.apache.maven.plugins:maven-surefire-plugin":    {},
+	"org.apache.maven.plugins:maven-resources-plugin":   {},
+	"org.apache.maven.plugins:maven-jar-plugin":         {},
+	"org.apache.maven.plugins:maven-install-plugin":     {},
+	"org.apache.maven.plugins:maven-deploy-plugin":      {},
+	"org.apache.maven.plugins:maven-clean-plugin":       {},
+	"org.apache.maven.plugins:maven-source-plugin":      {},
+	"org.apache.maven.plugins:maven-javadoc-plugin":     {},
+	"org.apache.maven.plugins:maven-war-plugin":         {id: "war"},
+	"org.apache.maven.plugins:maven-checkstyle-plugin":  {id: "checkstyle"},
+	"org.apache.maven.plugins:maven-pmd-plugin":         {id: "pmd"},
+	"org.jacoco:jacoco-maven-plugin":                    {id: "jacoco"},
+	"org.codehaus.mojo:exec-maven-plugin":               {id: "application"},
+	"org.springframework.boot:spring-boot-maven-plugin": {id: "org.springframework.boot", versioned: true},
+	"io.quarkus:quarkus-maven-plugin":                   {id: "io.quarkus", versioned: true},
+	"io.quarkus.platform:quarkus-maven-plugin":          {id: "io.quarkus", versioned: true},
+	"org.jetbrains.kotlin:kotlin-maven-plugin":          {id: "org.jetbrains.kotlin.jvm", versioned: true},
+}
+
+// javaVersionProperties hold the Java version, most specific first
+var javaVersionProperties = []string{"maven.compiler.release", "maven.compiler.source", "maven.compiler.target", "java.version"}
+
+// propertyRef matches ${name} references
+var propertyRef = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// GradleKts generates a build.gradle.kts equivalent to the project
+func GradleKts(project *pom.Project) *Output {
+	return gradleOutput(&gradleWriter{project: project})
+}
+
+// Gradle generates a build.gradle (Groovy DSL) equivalent to the project
+func Gradle(project *pom.Project) *Output {
+	return gradleOutput(&gradleWriter{project: project, groovy: true})
+}
+
+func gradleOutput(g *gradleWriter) *Output {
+	g.write()
+	return &Output{
+		FileName: g.fileName("build"),
+		Content:  []byte(g.out.String()),
+		Warnings: g.warnings,
+	}
+}
+
+// gradleWriter accumulates the Kotlin or Groovy DSL script and conversion
+// warnings
+type gradleWriter struct {
+	project  *pom.Project
+	groovy   bool // Groovy DSL instead of Kotlin DSL
+	out      strings.Builder
+	warnings []string
+	consumed map[string]bool // Properties mapped to dedicated Gradle settings
+}
+
+func (g *gradleWriter) warn(format string, args ...interface{}) {
+	g.warnings = append(g.warnings, fmt.Sprintf(format, args...))
+}
+
+func (g *gradleWriter) line(indent int, format string, args ...interface{}) {
+	g.out.WriteString(strings.Repeat("    ", indent))
+	fmt.Fprintf(&g.out, format, args...)
+	g.out.WriteString("\n")
+}
+
+func (g *gradleWriter) write() {
+	p := g.project
+	g.consumed = make(map[string]bool)
+
+	g.line(0, "// Converted from %s by pom-manager", p.Coordinates.String())
+	g.out.WriteString("\n")
+
+	g.writePlugins()
+
+	g.line(0, "group = %s", g.str(p.GroupID))
+	g.line(0, "version = %s", g.str(p.Version))
+	if p.Description != "" {
+		g.line(0, "description = %s", g.str(p.Description))
+	}
+	g.out.WriteString("\n")
+
+	g.writeJava()
+	g.writeProperties()
+
+	g.line(0, "repositories {")
+	g.line(1, "mavenCentral()")
+	g.line(0, "}")
+	g.out.WriteString("\n")
+
+	g.writeDependencies()
+	g.writeBuild()
+	g.warnUnconvertible()
+}
+
+// writePlugins emits the plugins {} block from the packaging and build plugins
+func (g *gradleWriter) writePlugins() {
+	p := g.project
+	var plugins []string
+
+	switch p.Packaging {
+	case "", pom.PackagingJar:
+		plugins = append(plugins, g.pluginEntry("java"))
+	case pom.PackagingWar:
+		plugins = append(plugins, g.pluginEntry("war"))
+	case pom.PackagingPom:
+		g.warn("packaging pom: an aggregator or parent maps to %s and shared convention plugins", g.fileName("settings"))
+	default:
+		plugins = append(plugins, g.pluginEntry("java"))
+		g.warn("packaging %s has no Gradle equivalent, converted as a java project", p.Packaging)
+	}
+
+	if p.Build != nil {
+		for _, plugin := range p.Build.Plugins {
+			key := pluginKey(plugin)
+			mapped, ok := gradlePlugins[key]
+			if !ok {
+				g.warn("plugin %s has no Gradle equivalent", key)
+				continue
+			}
+			if plugin.Configuration != nil || len(plugin.Executions) > 0 {
+				g.warn("configuration and executions of plugin %s are not converted", key)
+			}
+			if mapped.id == "" {
+				continue
+			}
+
+			entry := g.pluginEntry(mapped.id)
+			if mapped.versioned {
+				if version := g.resolve(plugin.Version); version != "" {
+					entry += " version " + g.literal(version)
+				} else {
+					g.warn("plugin %s needs a version in the plugins block", key)
+				}
+			}
+			plugins = append(plugins, entry)
+		}
+	}
+
+	if len(plugins) == 0 {
+		return
+	}
+	g.line(0, "plugins {")
+	seen := make(map[string]bool)
+	for _, plugin := range plugins {
+		if seen[plugin] {
+			continue
+		}
+		seen[plugin] = true
+		g.line(1, "%s", plugin)
+	}
+	g.line(0, "}")
+	g.out.WriteString("\n")
+}
+
+// writeJava emits the toolchain and encoding from the compiler properties
+func (g *gradleWriter) writeJava() {
+	props := g.project.Properties
+
+	javaVersion := ""
+	for _, name := range javaVersionProperties {
+		if value, ok := props[name]; ok {
+			g.consumed[name] = true
+			if javaVersion == "" {
+				javaVersion = strings.TrimPrefix(g.resolve(value), "1.")
+			}
+		}
+	}
+	if javaVersion != "" {
+		g.line(0, "java {")
+		g.line(1, "toolchain {")
+		if g.groovy {
+			g.line(2, "languageVersion = JavaLanguageVersion.of(%s)", javaVersion)
+		} else {
+			g.line(2, "languageVersion.set(JavaLanguageVersion.of(%s))", javaVersion)
+		}
+		g.line(1, "}")
+		g.line(0, "}")
+		g.out.WriteString("\n")
+	}
+
+	if encoding, ok := props["project.build.sourceEncoding"]; ok {
+		g.consumed["project.build.sourceEncoding"] = true
+		if g.groovy {
+			g.line(0, "tasks.withType(JavaCompile).configureEach {")
+		} else {
+			g.line(0, "tasks.withType<JavaCompile> {")
+		}
+		g.line(1, "options.encoding = %s", g.str(encoding))
+		g.line(0, "}")
+		g.out.WriteString("\n")
+	}
+	// Reporting encoding has no Gradle counterpart worth keeping
+	g.consumed["project.reporting.outputEncoding"] = true
+}
+
+// writeProperties emits the remaining properties as extra properties
+func (g *gradleWriter) writeProperties() {
+	var names []string
+	for name := range g.project.Properties {
+		if !g.consumed[name] {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if g.groovy {
+			g.line(0, "ext[%s] = %s", g.literal(name), g.str(g.project.Properties[name]))
+		} else {
+			g.line(0, "extra[%q] = %s", name, g.str(g.project.Properties[name]))
+		}
+	}
+	g.out.WriteString("\n")
+}
+
+// writeDependencies emits platforms, constraints, and dependencies
+func (g *gradleWriter) writeDependencies() {
+	p := g.project
+	if len(p.Dependencies) == 0 && len(p.DependencyManagement) == 0 {
+		return
+	}
+
+	g.line(0, "dependencies {")
+
+	var constraints []pom.Dependency
+	for _, dep := range p.DependencyManagement {
+		if dep.IsBOM() {
+			g.line(1, "%s", g.call("implementation", "platform("+g.str(gav(dep))+")", false))
+			continue
+		}
+		constraints = append(constraints, dep)
+	}
+	if len(constraints) > 0 {
+		g.line(1, "constraints {")
+		for _, dep := range constraints {
+			g.line(2, "%s", g.call("implementation", g.str(gav(dep)), false))
+		}
+		g.line(1, "}")
+	}
+
+	for _, dep := range p.Dependencies {
+		key := dep.GroupID + ":" + dep.ArtifactID
+		scope := dep.Scope
+		if scope == "" {
+			scope = pom.DefaultScope
+		}
+		configuration, ok := gradleConfigurations[scope]
+		if !ok {
+			g.warn("dependency %s: scope %s has no Gradle equivalent, skipped", key, scope)
+			continue
+		}
+		if dep.Type != "" && dep.Type != pom.DefaultDependencyType {
+			g.warn("dependency %s: type %s is not converted", key, dep.Type)
+		}
+		if dep.Optional {
+			g.warn("dependency %s: optional has no Gradle equivalent, declared as %s", key, configuration)
+		}
+
+		if len(dep.Exclusions) == 0 {
+			g.line(1, "%s", g.call(configuration, g.str(gav(dep)), false))
+			continue
+		}
+		g.line(1, "%s", g.call(configuration, g.str(gav(dep)), true))
+		for _, excl := range dep.Exclusions {
+			switch {
+			case excl.GroupID == "*" && excl.ArtifactID == "*" && g.groovy:
+				g.line(2, "transitive = false")
+			case excl.GroupID == "*" && excl.ArtifactID == "*":
+				g.line(2, "isTransitive = false")
+			case excl.ArtifactID == "*" && g.groovy:
+				g.line(2, "exclude group: %s", g.str(excl.GroupID))
+			case excl.ArtifactID == "*":
+				g.line(2, "exclude(group = %s)", g.str(excl.GroupID))
+			case g.groovy:
+				g.line(2, "exclude group: %s, module: %s", g.str(excl.GroupID), g.str(excl.ArtifactID))
+			default:
+				g.line(2, "exclude(group = %s, module = %s)", g.str(excl.GroupID), g.str(excl.ArtifactID))
+			}
+		}
+		g.line(1, "}")
+	}
+
+	g.line(0, "}")
+}
+
+// writeBuild emits source sets and tasks for the build section
+func (g *gradleWriter) writeBuild() {
+	p := g.project
+	if p.Build == nil {
+		return
+	}
+
+	if p.Build.SourceDirectory != "" || p.Build.TestSourceDirectory != "" {
+		g.out.WriteString("\n")
+		g.line(0, "sourceSets {")
+		if p.Build.SourceDirectory != "" {
+			g.line(1, "main {")
+			g.writeSrcDirs(p.Build.SourceDirectory)
+			g.line(1, "}")
+		}
+		if p.Build.TestSourceDirectory != "" {
+			g.line(1, "test {")
+			g.writeSrcDirs(p.Build.TestSourceDirectory)
+			g.line(1, "}")
+		}
+		g.line(0, "}")
+	}
+	if p.Build.OutputDirectory != "" {
+		g.warn("build outputDirectory %s is not converted", p.Build.OutputDirectory)
+	}
+
+	// Surefire runs JUnit 5 tests only on the JUnit Platform
+	for _, dep := range p.Dependencies {
+		if dep.GroupID == "org.junit.jupiter" {
+			g.out.WriteString("\n")
+			if g.groovy {
+				g.line(0, "tasks.named('test') {")
+			} else {
+				g.line(0, "tasks.test {")
+			}
+			g.line(1, "useJUnitPlatform()")
+			g.line(0, "}")
+			break
+		}
+	}
+}
+
+// warnUnconvertible flags project sections Gradle has no counterpart for
+func (g *gradleWriter) warnUnconvertible() {
+	p := g.project
+	if p.Parent != nil {
+		g.warn("parent %s:%s:%s is not converted; share configuration through a convention plugin",
+			p.Parent.GroupID, p.Parent.ArtifactID, p.Parent.Version)
+	}
+	if len(p.Modules) > 0 {
+		modules := make([]string, len(p.Modules))
+		for i, module := range p.Modules {
+			modules[i] = g.literal(module)
+		}
+		g.warn("modules are declared in %s: %s", g.fileName("settings"), g.call("include", strings.Join(modules, ", "), false))
+	}
+	for _, profile := range p.Profiles {
+		g.warn("profile %s is not converted", profile.ID)
+	}
+}
+
+// writeSrcDirs emits the source directory of a source set's java block
+func (g *gradleWriter) writeSrcDirs(dir string) {
+	if g.groovy {
+		g.line(2, "java.srcDirs = [%s]", g.str(dir))
+		return
+	}
+	g.line(2, "java.setSrcDirs(listOf(%s))", g.str(dir))
+}
+
+// fileName returns the name of a Gradle script, e.g. build.gradle.kts
+func (g *gradleWriter) fileName(script string) string {
+	if g.groovy {
+		return script + ".gradle"
+	}
+	return script + ".gradle.kts"
+}
+
+// pluginEntry returns the plugins {} entry applying a plugin id
+func (g *gradleWriter) pluginEntry(id string) string {
+	switch {
+	case g.groovy:
+		return "id " + g.literal(id)
+	case !strings.Contains(id, "."):
+		return id // Core plugins have accessors in the Kotlin DSL
+	case strings.HasPrefix(id, "org.jetbrains.kotlin."):
+		return fmt.Sprintf("kotlin(%q)", strings.TrimPrefix(id, "org.jetbrains.kotlin."))
+	}
+	return fmt.Sprintf("id(%q)", id)
+}
+
+// call returns a method call with one argument; Groovy drops the
+// parentheses unless a closure follows
+func (g *gradleWriter) call(method, arg string, closure bool) string {
+	switch {
+	case closure:
+		return fmt.Sprintf("%s(%s) {", method, arg)
+	case g.groovy:
+		return method + " " + arg
+	}
+	return fmt.Sprintf("%s(%s)", method, arg)
+}
+
+// literal returns a string literal without interpolation
+func (g *gradleWriter) literal(value string) string {
+	if g.groovy {
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+	}
+	return fmt.Sprintf("%q", value)
+}
+
+// str returns a string literal; Maven ${...} references become string
+// templates reading the corresponding Gradle value. Groovy uses single
+// quotes unless the value needs interpolation.
+func (g *gradleWriter) str(value string) string {
+	if g.groovy && !propertyRef.MatchString(value) {
+		return g.literal(value)
+	}
+
+	var out strings.Builder
+	out.WriteByte('"')
+	last := 0
+	for _, match := range propertyRef.FindAllStringSubmatchIndex(value, -1) {
+		out.WriteString(escapeKotlin(value[last:match[0]]))
+		out.WriteString(g.template(value[match[2]:match[3]]))
+		last = match[1]
+	}
+	out.WriteString(escapeKotlin(value[last:]))
+	out.WriteByte('"')
+	return out.String()
+}
+
+// template converts a Maven property reference to a Kotlin string template
+func (g *gradleWriter) template(name string) string {
+	switch name {
+	case "project.version", "pom.version", "version":
+		return "${project.version}"
+	case "project.groupId", "pom.groupId":
+		return "${project.group}"
+	case "project.artifactId", "pom.artifactId":
+		return "${project.name}"
+	}
+	if _, ok := g.project.Properties[name]; !ok {
+		g.warn("property %s is not defined in the POM", name)
+	}
+	if g.consumed[name] {
+		// Consumed properties are not written as extra properties
+		return escapeKotlin(g.project.Properties[name])
+	}
+	if g.groovy {
+		return fmt.Sprintf("${ext[%s]}", g.literal(name))
+	}
+	return fmt.Sprintf("${extra[%q]}", name)
+}
+
+// resolve expands property references using the project's properties, for
+// places such as the plugins block that cannot read extra properties
+func (g *gradleWriter) resolve(value string) string {
+	resolved := propertyRef.ReplaceAllStringFunc(value, func(ref string) string {
+		name := ref[2 : len(ref)-1]
+		if v, ok := g.project.Properties[name]; ok {
+			return v
+		}
+		return ref
+	})
+	if propertyRef.MatchString(resolved) {
+		return ""
+	}
+	return resolved
+}
+
+// escapeKotlin escapes text for a Kotlin string literal, or a Groovy
+// double-quoted one
+func escapeKotlin(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`).Replace(s)
+}
+
+// pluginKey returns groupId:artifactId of a plugin; Maven defaults the
+// groupId to org.apache.maven.plugins
+func pluginKey(plugin pom.Plugin) string {
+	groupID := plugin.GroupID
+	if groupID == "" {
+		groupID = "org.apache.maven.plugins"
+	}
+	return groupID + ":" + plugin.ArtifactID
+}
+
+// gav returns the Gradle dependency notation of a dependency
+func gav(dep pom.Dependency) string {
+	notation := dep.GroupID + ":" + dep.ArtifactID
+	if dep.Version != "" || dep.Classifier != "" {
+		notation += ":" + dep.Version
+	}
+	if dep.Classifier != "" {
+		notation += ":" + dep.Classifier
+	}
+	return notation
+}
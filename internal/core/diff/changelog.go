@@ -0,0 +1,56 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SubjectLength is the length commit subject lines are kept to
+const SubjectLength = 72
+
+// Summarize joins the summaries of changes into one line, such as "Add
+// spring-boot-starter-web 3.2.0; bump junit to 5.10.2; add prod profile".
+// Once the line would grow past maxLength (0 for no limit), the remaining
+// changes are counted instead: "...; and 3 more".
+func Summarize(changes []Change, maxLength int) string {
+	var phrases []string
+	seen := make(map[string]bool)
+	for _, change := range changes {
+		if change.Summary == "" || seen[change.Summary] {
+			continue
+		}
+		seen[change.Summary] = true
+		phrases = append(phrases, change.Summary)
+	}
+	if len(phrases) == 0 {
+		return ""
+	}
+
+	line := phrases[0]
+	for i, phrase := range phrases[1:] {
+		next := line + "; " + phrase
+		if maxLength > 0 && len(next) > maxLength {
+			line += fmt.Sprintf("; and %d more", len(phrases)-1-i)
+			break
+		}
+		line = next
+	}
+	return strings.ToUpper(line[:1]) + line[1:]
+}
+
+// Changelog turns changes into a commit message: the summary as subject
+// line and, when there is more than one change, each change in full below
+func Changelog(changes []Change) string {
+	subject := Summarize(changes, SubjectLength)
+	if len(changes) < 2 {
+		return subject
+	}
+
+	var b strings.Builder
+	b.WriteString(subject)
+	b.WriteString("\n\n")
+	for _, change := range changes {
+		fmt.Fprintf(&b, "- %s\n", change.Description)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,51 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+func TestChangelog(t *testing.T) {
+	old := &pom.Project{
+		GroupID: "com.example", ArtifactID: "app", Version: "1.0.0",
+		Dependencies: []pom.Dependency{
+			{GroupID: "junit", ArtifactID: "junit", Version: "4.13.2", Scope: "test"},
+		},
+	}
+	new := old.Clone()
+	new.Dependencies = append([]pom.Dependency{
+		{GroupID: "org.springframework.boot", ArtifactID: "spring-boot-starter-web", Version: "3.2.0"},
+	}, new.Dependencies...)
+	new.Dependencies[1].Version = "5.10.2"
+	new.Profiles = []pom.Profile{{ID: "ci"}}
+
+	changes := Projects(old, new)
+	subject := "Add spring-boot-starter-web 3.2.0; bump junit to 5.10.2; add ci profile"
+	if got := Summarize(changes, 0); got != subject {
+		t.Errorf("Expected summary %q, got %q", subject, got)
+	}
+	if got := Summarize(changes, 40); got != "Add spring-boot-starter-web 3.2.0; and 2 more" {
+		t.Errorf("Expected shortened summary, got %q", got)
+	}
+
+	message := Changelog(changes)
+	if !strings.HasPrefix(message, subject+"\n\n- ") || !strings.Contains(message, "- added profile ci") {
+		t.Errorf("Expected subject and described changes, got:\n%s", message)
+	}
+
+	if got := Changelog(nil); got != "" {
+		t.Errorf("Expected empty changelog without changes, got %q", got)
+	}
+}
+
+func TestSummaryInProfile(t *testing.T) {
+	old := &pom.Project{Profiles: []pom.Profile{{ID: "prod"}}}
+	new := old.Clone()
+	new.Profiles[0].Properties = map[string]string{"env": "prod"}
+
+	if got := Summarize(Projects(old, new), 0); got != "Add property env in prod profile" {
+		t.Errorf("Expected the profile to be named, got %q", got)
+	}
+}
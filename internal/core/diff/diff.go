@@ -0,0 +1,173 @@
+// Package diff computes line-based differences between two texts, used to
+// review generated POM output against the file on disk, and semantic
+// differences between two projects that describe those changes in words.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Op is the kind of a diff line
+type Op int
+
+const (
+	// Equal lines appear in both texts
+	Equal Op = iota
+	// Delete lines only appear in the old text
+	Delete
+	// Insert lines only appear in the new text
+	Insert
+)
+
+// prefixes are the unified diff markers for each Op
+var prefixes = map[Op]string{
+	Equal:  " ",
+	Delete: "-",
+	Insert: "+",
+}
+
+// Line is one line of a diff
+type Line struct {
+	Op      Op
+	Text    string
+	OldLine int // 1-based line number in the old text (0 for inserts)
+	NewLine int // 1-based line number in the new text (0 for deletes)
+}
+
+// SplitLines splits text into lines, ignoring a trailing newline and
+// normalizing Windows line endings
+func SplitLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// Lines returns the line diff turning old into new, based on the longest
+// common subsequence
+func Lines(old, new []string) []Line {
+	// lcs[i][j] is the LCS length of old[i:] and new[j:]
+	lcs := make([][]int, len(old)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(new)+1)
+	}
+	for i := len(old) - 1; i >= 0; i-- {
+		for j := len(new) - 1; j >= 0; j-- {
+			if old[i] == new[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]Line, 0, max(len(old), len(new)))
+	i, j := 0, 0
+	for i < len(old) && j < len(new) {
+		switch {
+		case old[i] == new[j]:
+			lines = append(lines, Line{Op: Equal, Text: old[i], OldLine: i + 1, NewLine: j + 1})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Op: Delete, Text: old[i], OldLine: i + 1})
+			i++
+		default:
+			lines = append(lines, Line{Op: Insert, Text: new[j], NewLine: j + 1})
+			j++
+		}
+	}
+	for ; i < len(old); i++ {
+		lines = append(lines, Line{Op: Delete, Text: old[i], OldLine: i + 1})
+	}
+	for ; j < len(new); j++ {
+		lines = append(lines, Line{Op: Insert, Text: new[j], NewLine: j + 1})
+	}
+
+	return lines
+}
+
+// HasChanges reports whether the diff contains any insert or delete
+func HasChanges(lines []Line) bool {
+	for _, line := range lines {
+		if line.Op != Equal {
+			return true
+		}
+	}
+	return false
+}
+
+// Unified formats the diff of two texts in unified format with the given
+// number of context lines around each change. Returns "" when they are equal.
+func Unified(oldName, newName, old, new string, context int) string {
+	lines := Lines(SplitLines(old), SplitLines(new))
+	if !HasChanges(lines) {
+		return ""
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+
+	for start := 0; start < len(lines); {
+		// Find the next change
+		for start < len(lines) && lines[start].Op == Equal {
+			start++
+		}
+		if start == len(lines) {
+			break
+		}
+
+		// Extend the hunk while changes are within 2*context lines of each other
+		end := start
+		for end < len(lines) {
+			if lines[end].Op != Equal {
+				end++
+				continue
+			}
+			next := end
+			for next < len(lines) && lines[next].Op == Equal {
+				next++
+			}
+			if next == len(lines) || next-end > 2*context {
+				break
+			}
+			end = next
+		}
+
+		from := max(start-context, 0)
+		to := min(end+context, len(lines))
+		writeHunk(&out, lines[from:to])
+		start = to
+	}
+
+	return out.String()
+}
+
+// writeHunk writes one unified diff hunk with its @@ header
+func writeHunk(out *strings.Builder, hunk []Line) {
+	oldStart, newStart, oldCount, newCount := 0, 0, 0, 0
+	for _, line := range hunk {
+		if line.Op != Insert {
+			if oldStart == 0 {
+				oldStart = line.OldLine
+			}
+			oldCount++
+		}
+		if line.Op != Delete {
+			if newStart == 0 {
+				newStart = line.NewLine
+			}
+			newCount++
+		}
+	}
+
+	fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+	for _, line := range hunk {
+		out.WriteString(prefixes[line.Op])
+		out.WriteString(line.Text)
+		out.WriteString("\n")
+	}
+}
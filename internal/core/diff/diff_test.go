@@ -0,0 +1,41 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	old := []string{"a", "b", "c", "d"}
+	new := []string{"a", "c", "d", "e"}
+
+	lines := Lines(old, new)
+	var ops strings.Builder
+	for _, line := range lines {
+		ops.WriteString(prefixes[line.Op] + line.Text)
+	}
+	if ops.String() != " a-b c d+e" {
+		t.Errorf("Expected ' a-b c d+e', got '%s'", ops.String())
+	}
+
+	if HasChanges(Lines(old, old)) {
+		t.Error("Expected no changes for identical input")
+	}
+}
+
+func TestUnified(t *testing.T) {
+	if out := Unified("a", "b", "same\n", "same\n", 3); out != "" {
+		t.Errorf("Expected empty diff, got '%s'", out)
+	}
+
+	old := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	new := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\neleven\n"
+	out := Unified("pom.xml", "pom.xml (generated)", old, new, 1)
+
+	expected := "--- pom.xml\n+++ pom.xml (generated)\n" +
+		"@@ -2,3 +2,3 @@\n 2\n-3\n+three\n 4\n" +
+		"@@ -10,1 +10,2 @@\n 10\n+eleven\n"
+	if out != expected {
+		t.Errorf("Unexpected unified diff:\n%s\nexpected:\n%s", out, expected)
+	}
+}
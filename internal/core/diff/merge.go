@@ -0,0 +1,57 @@
+package diff
+
+import (
+	"slices"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// Merge compares what two sides changed since a common base, such as the
+// unsaved edits to a POM and the changes another program saved to its file
+type Merge struct {
+	Ours   []Change // Changes made on this side
+	Theirs []Change // Changes made on the other side
+	// Conflicts are the subjects, e.g. "junit:junit", both sides changed
+	// differently, in the order of Ours
+	Conflicts []string
+}
+
+// ThreeWay compares the changes turning base into ours and into theirs. A
+// subject changed on both sides conflicts unless both made the same changes
+// to it.
+func ThreeWay(base, ours, theirs *pom.Project) Merge {
+	merge := Merge{
+		Ours:   Projects(base, ours),
+		Theirs: Projects(base, theirs),
+	}
+
+	ourChanges := bySubject(merge.Ours)
+	theirChanges := bySubject(merge.Theirs)
+	for _, change := range merge.Ours {
+		if slices.Contains(merge.Conflicts, change.Subject) {
+			continue
+		}
+		theirs, ok := theirChanges[change.Subject]
+		if ok && !slices.Equal(ourChanges[change.Subject], theirs) {
+			merge.Conflicts = append(merge.Conflicts, change.Subject)
+		}
+	}
+	return merge
+}
+
+// HasConflicts reports whether both sides changed a subject differently
+func (m Merge) HasConflicts() bool {
+	return len(m.Conflicts) > 0
+}
+
+// bySubject groups the descriptions of changes by their subject, sorted
+func bySubject(changes []Change) map[string][]string {
+	subjects := make(map[string][]string)
+	for _, change := range changes {
+		subjects[change.Subject] = append(subjects[change.Subject], change.Description)
+	}
+	for _, descriptions := range subjects {
+		slices.Sort(descriptions)
+	}
+	return subjects
+}
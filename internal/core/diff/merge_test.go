@@ -0,0 +1,44 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+func TestThreeWay(t *testing.T) {
+	base := &pom.Project{
+		GroupID: "com.example", ArtifactID: "app", Version: "1.0.0",
+		Properties: map[string]string{"java.version": "17"},
+		Dependencies: []pom.Dependency{
+			{GroupID: "junit", ArtifactID: "junit", Version: "4.13.2", Scope: "test"},
+			{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.9"},
+		},
+	}
+
+	// Both sides upgrade junit, but to different versions; both set the
+	// same Java version; each side makes a change of its own
+	ours := base.Clone()
+	ours.Dependencies[0].Version = "4.13.3"
+	ours.Properties["java.version"] = "21"
+	ours.Description = "Local description"
+
+	theirs := base.Clone()
+	theirs.Dependencies[0].Version = "5.10.2"
+	theirs.Properties["java.version"] = "21"
+	theirs.Dependencies[1].Version = "2.0.10"
+
+	merge := ThreeWay(base, ours, theirs)
+	if len(merge.Ours) != 3 || len(merge.Theirs) != 3 {
+		t.Errorf("Expected 3 changes on each side, got %v and %v", merge.Ours, merge.Theirs)
+	}
+	if !merge.HasConflicts() || len(merge.Conflicts) != 1 || merge.Conflicts[0] != "junit:junit" {
+		t.Errorf("Expected junit:junit to conflict, got %v", merge.Conflicts)
+	}
+
+	// Changes on one side only never conflict
+	merge = ThreeWay(base, base, theirs)
+	if len(merge.Ours) != 0 || merge.HasConflicts() {
+		t.Errorf("Expected no conflicts without local changes, got %v", merge.Conflicts)
+	}
+}
@@ -0,0 +1,454 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// ChangeKind is the kind of a semantic change
+type ChangeKind int
+
+const (
+	// Added elements only appear in the new project
+	Added ChangeKind = iota
+	// Removed elements only appear in the old project
+	Removed
+	// Modified elements appear in both with different values
+	Modified
+)
+
+// String returns "added", "removed" or "modified"
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Modified:
+		return "modified"
+	}
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// MarshalText encodes the kind as its name, so JSON output reads "added"
+// rather than 0
+func (k ChangeKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// Change is one difference between two versions of a project, described
+// the way a reviewer thinks about it rather than as XML lines
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	// Subject is what changed, e.g. "junit:junit" or "property junit.version"
+	Subject string `json:"subject"`
+	// Description reads as a sentence, e.g. "junit:junit upgraded 4.13.2 → 5.10.2"
+	Description string `json:"description"`
+	// Summary is a short imperative phrase for changelogs, e.g. "bump junit to 5.10.2"
+	Summary string `json:"summary"`
+}
+
+// String returns the description
+func (c Change) String() string {
+	return c.Description
+}
+
+// Projects lists the semantic changes turning old into new: coordinates,
+// parent, properties, dependencies and their exclusions, managed
+// dependencies, plugins and their executions, modules and profiles
+func Projects(old, new *pom.Project) []Change {
+	c := &changeList{}
+	c.coordinates(old, new)
+	c.parent(old.Parent, new.Parent)
+	c.properties("", old.Properties, new.Properties)
+	c.dependencies("", "dependency", old.Dependencies, new.Dependencies)
+	c.dependencies("", "managed dependency", old.DependencyManagement, new.DependencyManagement)
+	c.plugins("", pluginsOf(old.Build), pluginsOf(new.Build))
+	c.modules("", old.Modules, new.Modules)
+	c.profiles(old.Profiles, new.Profiles)
+	return c.changes
+}
+
+// changeList collects changes as the sections are compared
+type changeList struct {
+	changes []Change
+	profile string // ID of the profile being compared, "" for the project
+}
+
+func (c *changeList) add(kind ChangeKind, subject, summary, format string, args ...any) {
+	if c.profile != "" {
+		summary += " in " + c.profile + " profile"
+	}
+	c.changes = append(c.changes, Change{
+		Kind:        kind,
+		Subject:     subject,
+		Description: fmt.Sprintf(format, args...),
+		Summary:     summary,
+	})
+}
+
+// coordinates compares the project's own coordinates and descriptive fields
+func (c *changeList) coordinates(old, new *pom.Project) {
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{"groupId", old.GroupID, new.GroupID},
+		{"artifactId", old.ArtifactID, new.ArtifactID},
+		{"version", old.Version, new.Version},
+		{"packaging", packaging(old.Packaging), packaging(new.Packaging)},
+		{"name", old.Name, new.Name},
+		{"description", old.Description, new.Description},
+	}
+	for _, field := range fields {
+		if field.old != field.new {
+			c.add(Modified, field.name, fmt.Sprintf("set %s to %s", field.name, orNone(field.new)), "%s changed %s → %s", field.name, orNone(field.old), orNone(field.new))
+		}
+	}
+}
+
+// parent compares the <parent> references
+func (c *changeList) parent(old, new *pom.Parent) {
+	switch {
+	case old == nil && new == nil:
+	case old == nil:
+		c.add(Added, "parent", "add parent "+withVersion(new.ArtifactID, new.Version), "parent %s:%s:%s added", new.GroupID, new.ArtifactID, new.Version)
+	case new == nil:
+		c.add(Removed, "parent", "remove parent "+old.ArtifactID, "parent %s:%s:%s removed", old.GroupID, old.ArtifactID, old.Version)
+	case old.GroupID != new.GroupID || old.ArtifactID != new.ArtifactID:
+		c.add(Modified, "parent", "change parent to "+new.ArtifactID, "parent changed %s:%s → %s:%s", old.GroupID, old.ArtifactID, new.GroupID, new.ArtifactID)
+	case old.Version != new.Version:
+		c.add(Modified, "parent", versionSummary("parent", old.Version, new.Version), "parent %s", versionChange(old.Version, new.Version))
+	}
+}
+
+// properties compares property maps; prefix names the profile, if any
+func (c *changeList) properties(prefix string, old, new map[string]string) {
+	for _, name := range unionKeys(old, new) {
+		oldValue, inOld := old[name]
+		newValue, inNew := new[name]
+		subject := "property " + name
+		switch {
+		case !inOld:
+			c.add(Added, subject, "add property "+name, "%sproperty %s = %s added", prefix, name, newValue)
+		case !inNew:
+			c.add(Removed, subject, "remove property "+name, "%sproperty %s removed (was %s)", prefix, name, oldValue)
+		case oldValue != newValue:
+			c.add(Modified, subject, fmt.Sprintf("set %s to %s", name, newValue), "%sproperty %s changed %s → %s", prefix, name, oldValue, newValue)
+		}
+	}
+}
+
+// dependencies compares dependency lists matched by groupId:artifactId,
+// type and classifier; kind is "dependency" or "managed dependency"
+func (c *changeList) dependencies(prefix, kind string, old, new []pom.Dependency) {
+	// Managed dependencies are told apart from dependencies in summaries
+	short := func(dep pom.Dependency) string {
+		if kind == "dependency" {
+			return dep.ArtifactID
+		}
+		return "managed " + dep.ArtifactID
+	}
+
+	oldByKey := make(map[string]pom.Dependency, len(old))
+	for _, dep := range old {
+		oldByKey[dep.Key()] = dep
+	}
+	newKeys := make(map[string]bool, len(new))
+
+	for _, dep := range new {
+		key := dep.Key()
+		newKeys[key] = true
+		name := dependencyName(dep)
+		before, ok := oldByKey[key]
+		if !ok {
+			c.add(Added, name, "add "+withVersion(short(dep), dep.Version), "%sadded %s %s%s", prefix, kind, withVersion(name, dep.Version), scopeNote(dep.Scope))
+			continue
+		}
+
+		if before.Version != dep.Version {
+			c.add(Modified, name, versionSummary(short(dep), before.Version, dep.Version),
+				"%s%s %s", prefix, name, versionChange(before.Version, dep.Version))
+		}
+		if scope(before.Scope) != scope(dep.Scope) {
+			c.add(Modified, name, fmt.Sprintf("make %s %s scoped", short(dep), scope(dep.Scope)), "%s%s scope changed %s → %s", prefix, name, scope(before.Scope), scope(dep.Scope))
+		}
+		if before.Optional != dep.Optional {
+			if dep.Optional {
+				c.add(Modified, name, "make "+short(dep)+" optional", "%s%s made optional", prefix, name)
+			} else {
+				c.add(Modified, name, "make "+short(dep)+" required", "%s%s no longer optional", prefix, name)
+			}
+		}
+		c.exclusions(prefix, name, short(dep), before.Exclusions, dep.Exclusions)
+	}
+
+	for _, dep := range old {
+		if !newKeys[dep.Key()] {
+			name := dependencyName(dep)
+			c.add(Removed, name, "remove "+short(dep), "%sremoved %s %s", prefix, kind, withVersion(name, dep.Version))
+		}
+	}
+}
+
+// exclusions compares the exclusions of one dependency
+func (c *changeList) exclusions(prefix, name, short string, old, new []pom.Exclusion) {
+	oldSet := make(map[string]bool, len(old))
+	for _, exclusion := range old {
+		oldSet[exclusion.GroupID+":"+exclusion.ArtifactID] = true
+	}
+	newSet := make(map[string]bool, len(new))
+	for _, exclusion := range new {
+		key := exclusion.GroupID + ":" + exclusion.ArtifactID
+		newSet[key] = true
+		if !oldSet[key] {
+			c.add(Modified, name, fmt.Sprintf("exclude %s from %s", exclusion.ArtifactID, short), "%s%s: added exclusion %s", prefix, name, key)
+		}
+	}
+	for _, exclusion := range old {
+		key := exclusion.GroupID + ":" + exclusion.ArtifactID
+		if !newSet[key] {
+			c.add(Modified, name, fmt.Sprintf("stop excluding %s from %s", exclusion.ArtifactID, short), "%s%s: removed exclusion %s", prefix, name, key)
+		}
+	}
+}
+
+// plugins compares build plugins matched by groupId:artifactId
+func (c *changeList) plugins(prefix string, old, new []pom.Plugin) {
+	oldByKey := make(map[string]pom.Plugin, len(old))
+	for _, plugin := range old {
+		oldByKey[pluginName(plugin)] = plugin
+	}
+	newKeys := make(map[string]bool, len(new))
+
+	for _, plugin := range new {
+		name := pluginName(plugin)
+		newKeys[name] = true
+		before, ok := oldByKey[name]
+		if !ok {
+			c.add(Added, name, "add "+withVersion(plugin.ArtifactID, plugin.Version), "%sadded plugin %s", prefix, withVersion(name, plugin.Version))
+			continue
+		}
+		if before.Version != plugin.Version {
+			c.add(Modified, name, versionSummary(plugin.ArtifactID, before.Version, plugin.Version),
+				"%s%s %s", prefix, name, versionChange(before.Version, plugin.Version))
+		}
+		c.executions(prefix, name, plugin.ArtifactID, before.Executions, plugin.Executions)
+	}
+
+	for _, plugin := range old {
+		name := pluginName(plugin)
+		if !newKeys[name] {
+			c.add(Removed, name, "remove "+plugin.ArtifactID, "%sremoved plugin %s", prefix, withVersion(name, plugin.Version))
+		}
+	}
+}
+
+// executions compares the executions of one plugin by ID
+func (c *changeList) executions(prefix, name, short string, old, new []pom.PluginExecution) {
+	oldByID := make(map[string]pom.PluginExecution, len(old))
+	for _, execution := range old {
+		oldByID[executionID(execution)] = execution
+	}
+	newIDs := make(map[string]bool, len(new))
+
+	for _, execution := range new {
+		id := executionID(execution)
+		newIDs[id] = true
+		before, ok := oldByID[id]
+		switch {
+		case !ok:
+			c.add(Modified, name, fmt.Sprintf("add %s execution %s", short, id), "%s%s: added execution %s%s", prefix, name, id, phaseNote(execution.Phase))
+		case before.Phase != execution.Phase:
+			c.add(Modified, name, fmt.Sprintf("move %s execution %s to %s", short, id, orNone(execution.Phase)), "%s%s: execution %s moved %s → %s", prefix, name, id, orNone(before.Phase), orNone(execution.Phase))
+		case strings.Join(before.Goals, ",") != strings.Join(execution.Goals, ","):
+			c.add(Modified, name, fmt.Sprintf("change goals of %s execution %s", short, id), "%s%s: execution %s goals changed %s → %s", prefix, name, id,
+				orNone(strings.Join(before.Goals, ", ")), orNone(strings.Join(execution.Goals, ", ")))
+		}
+	}
+	for _, execution := range old {
+		if id := executionID(execution); !newIDs[id] {
+			c.add(Modified, name, fmt.Sprintf("remove %s execution %s", short, id), "%s%s: removed execution %s", prefix, name, id)
+		}
+	}
+}
+
+// modules compares module lists
+func (c *changeList) modules(prefix string, old, new []string) {
+	oldSet := make(map[string]bool, len(old))
+	for _, module := range old {
+		oldSet[module] = true
+	}
+	newSet := make(map[string]bool, len(new))
+	for _, module := range new {
+		newSet[module] = true
+		if !oldSet[module] {
+			c.add(Added, "module "+module, "add module "+module, "%sadded module %s", prefix, module)
+		}
+	}
+	for _, module := range old {
+		if !newSet[module] {
+			c.add(Removed, "module "+module, "remove module "+module, "%sremoved module %s", prefix, module)
+		}
+	}
+}
+
+// profiles compares profiles by ID, and the contents of those in both
+func (c *changeList) profiles(old, new []pom.Profile) {
+	oldByID := make(map[string]pom.Profile, len(old))
+	for _, profile := range old {
+		oldByID[profile.ID] = profile
+	}
+	newIDs := make(map[string]bool, len(new))
+
+	for _, profile := range new {
+		newIDs[profile.ID] = true
+		before, ok := oldByID[profile.ID]
+		if !ok {
+			c.add(Added, "profile "+profile.ID, "add "+profile.ID+" profile", "added profile %s", profile.ID)
+			continue
+		}
+		prefix := "profile " + profile.ID + ": "
+		c.profile = profile.ID
+		c.properties(prefix, before.Properties, profile.Properties)
+		c.dependencies(prefix, "dependency", before.Dependencies, profile.Dependencies)
+		c.plugins(prefix, pluginsOf(before.Build), pluginsOf(profile.Build))
+		c.modules(prefix, before.Modules, profile.Modules)
+		c.profile = ""
+	}
+	for _, profile := range old {
+		if !newIDs[profile.ID] {
+			c.add(Removed, "profile "+profile.ID, "remove "+profile.ID+" profile", "removed profile %s", profile.ID)
+		}
+	}
+}
+
+// versionChange describes a version moving from old to new as an upgrade
+// or downgrade when both are comparable
+func versionChange(old, new string) string {
+	switch {
+	case old == "":
+		return "version set to " + new
+	case new == "":
+		return fmt.Sprintf("version %s removed (now managed)", old)
+	}
+
+	oldVersion, errOld := semver.NewVersion(old)
+	newVersion, errNew := semver.NewVersion(new)
+	if errOld != nil || errNew != nil {
+		return fmt.Sprintf("version changed %s → %s", old, new)
+	}
+	if newVersion.LessThan(oldVersion) {
+		return fmt.Sprintf("downgraded %s → %s", old, new)
+	}
+	return fmt.Sprintf("upgraded %s → %s", old, new)
+}
+
+// versionSummary is the changelog phrase for a version change of name
+func versionSummary(name, old, new string) string {
+	switch {
+	case new == "":
+		return "let " + name + " version be managed"
+	case old == "":
+		return fmt.Sprintf("set %s version to %s", name, new)
+	}
+	oldVersion, errOld := semver.NewVersion(old)
+	newVersion, errNew := semver.NewVersion(new)
+	if errOld == nil && errNew == nil && newVersion.LessThan(oldVersion) {
+		return fmt.Sprintf("downgrade %s to %s", name, new)
+	}
+	return fmt.Sprintf("bump %s to %s", name, new)
+}
+
+func pluginsOf(build *pom.Build) []pom.Plugin {
+	if build == nil {
+		return nil
+	}
+	return build.Plugins
+}
+
+func dependencyName(dep pom.Dependency) string {
+	name := dep.GroupID + ":" + dep.ArtifactID
+	if dep.Classifier != "" {
+		name += " (" + dep.Classifier + ")"
+	}
+	return name
+}
+
+func pluginName(plugin pom.Plugin) string {
+	groupID := plugin.GroupID
+	if groupID == "" {
+		groupID = pom.DefaultPluginGroupID
+	}
+	return groupID + ":" + plugin.ArtifactID
+}
+
+func executionID(execution pom.PluginExecution) string {
+	if execution.ID == "" {
+		return "default"
+	}
+	return execution.ID
+}
+
+func withVersion(name, version string) string {
+	if version == "" {
+		return name
+	}
+	return name + " " + version
+}
+
+func scopeNote(value string) string {
+	if scope(value) == pom.DefaultScope {
+		return ""
+	}
+	return " (" + value + ")"
+}
+
+func phaseNote(phase string) string {
+	if phase == "" {
+		return ""
+	}
+	return " in " + phase
+}
+
+func scope(value string) string {
+	if value == "" {
+		return pom.DefaultScope
+	}
+	return value
+}
+
+func packaging(value string) string {
+	if value == "" {
+		return pom.DefaultPackaging
+	}
+	return value
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+// unionKeys returns the keys of both maps, sorted
+func unionKeys(a, b map[string]string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var keys []string
+	for _, m := range []map[string]string{a, b} {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,71 @@
+package diff
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+func TestProjects(t *testing.T) {
+	old := &pom.Project{
+		GroupID: "com.example", ArtifactID: "app", Version: "1.0.0",
+		Properties: map[string]string{"java.version": "17", "old.flag": "true"},
+		Dependencies: []pom.Dependency{
+			{GroupID: "junit", ArtifactID: "junit", Version: "4.13.2", Scope: "test"},
+			{GroupID: "org.springframework", ArtifactID: "spring-core", Version: "6.1.0"},
+			{GroupID: "log4j", ArtifactID: "log4j", Version: "1.2.17"},
+		},
+		Build: &pom.Build{Plugins: []pom.Plugin{
+			{GroupID: pom.DefaultPluginGroupID, ArtifactID: "maven-compiler-plugin", Version: "3.11.0"},
+		}},
+	}
+	new := &pom.Project{
+		GroupID: "com.example", ArtifactID: "app", Version: "1.1.0",
+		Properties: map[string]string{"java.version": "21", "new.flag": "x"},
+		Dependencies: []pom.Dependency{
+			{GroupID: "junit", ArtifactID: "junit", Version: "5.10.2", Scope: "test"},
+			{GroupID: "org.springframework", ArtifactID: "spring-core", Version: "6.1.0",
+				Exclusions: []pom.Exclusion{{GroupID: "commons-logging", ArtifactID: "commons-logging"}}},
+			{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.9"},
+		},
+		Build: &pom.Build{Plugins: []pom.Plugin{
+			{GroupID: pom.DefaultPluginGroupID, ArtifactID: "maven-compiler-plugin", Version: "3.10.1"},
+		}},
+	}
+
+	var got []string
+	for _, change := range Projects(old, new) {
+		got = append(got, change.String())
+	}
+	expected := []string{
+		"version changed 1.0.0 → 1.1.0",
+		"property java.version changed 17 → 21",
+		"property new.flag = x added",
+		"property old.flag removed (was true)",
+		"junit:junit upgraded 4.13.2 → 5.10.2",
+		"org.springframework:spring-core: added exclusion commons-logging:commons-logging",
+		"added dependency org.slf4j:slf4j-api 2.0.9",
+		"removed dependency log4j:log4j 1.2.17",
+		"org.apache.maven.plugins:maven-compiler-plugin downgraded 3.11.0 → 3.10.1",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected changes:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+
+	if changes := Projects(old, old); len(changes) != 0 {
+		t.Errorf("Expected no changes for identical projects, got %v", changes)
+	}
+}
+
+func TestChangeJSON(t *testing.T) {
+	data, err := json.Marshal(Change{Kind: Removed, Subject: "log4j:log4j", Description: "removed dependency log4j:log4j 1.2.17", Summary: "remove log4j"})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	expected := `{"kind":"removed","subject":"log4j:log4j","description":"removed dependency log4j:log4j 1.2.17","summary":"remove log4j"}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
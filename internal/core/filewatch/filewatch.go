@@ -0,0 +1,139 @@
+// Package filewatch reports changes other programs make to files, such as
+// an IDE saving a POM or git pull replacing it.
+package filewatch
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// settleDelay is how long a file must stay unchanged before the change is
+// reported; editors and git often write a file in several steps
+const settleDelay = 200 * time.Millisecond
+
+// Watcher reports changes to a set of files
+type Watcher struct {
+	fs       *fsnotify.Watcher
+	onChange func(path string)
+
+	mutex  sync.Mutex
+	files  map[string]string // Watched files as given to Watch, by absolute path
+	dirs   map[string]bool   // Directories watched for them
+	timers map[string]*time.Timer
+}
+
+// New starts a Watcher, which calls onChange on a goroutine of its own
+// with the path of each watched file written, replaced or removed, as it
+// was given to Watch
+func New(onChange func(path string)) (*Watcher, error) {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start file watcher: %w", err)
+	}
+	w := &Watcher{
+		fs:       fsWatcher,
+		onChange: onChange,
+		files:    make(map[string]string),
+		dirs:     make(map[string]bool),
+		timers:   make(map[string]*time.Timer),
+	}
+	go w.run()
+	return w, nil
+}
+
+// Watch watches the files at paths in place of those watched before. Their
+// directories are watched rather than the files themselves, so a file
+// replaced by renaming a new version over it stays watched.
+func (w *Watcher) Watch(paths []string) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	files := make(map[string]string, len(paths))
+	dirs := make(map[string]bool)
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		files[abs] = path
+		dirs[filepath.Dir(abs)] = true
+	}
+
+	for dir := range w.dirs {
+		if !dirs[dir] {
+			_ = w.fs.Remove(dir)
+		}
+	}
+	var errs []error
+	for dir := range dirs {
+		if w.dirs[dir] {
+			continue
+		}
+		if err := w.fs.Add(dir); err != nil {
+			errs = append(errs, fmt.Errorf("failed to watch %s: %w", dir, err))
+			delete(dirs, dir)
+		}
+	}
+	w.files = files
+	w.dirs = dirs
+	return errors.Join(errs...)
+}
+
+// run forwards the events of watched files until the watcher is closed
+func (w *Watcher) run() {
+	for {
+		select {
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			// Permission changes leave the content alone
+			if event.Op != fsnotify.Chmod {
+				w.changed(event.Name)
+			}
+		case _, ok := <-w.fs.Errors:
+			// Dropped events only delay noticing a change until the next one
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// changed reports a change to path once it settles
+func (w *Watcher) changed(path string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if _, ok := w.files[path]; !ok {
+		return
+	}
+	if timer, ok := w.timers[path]; ok && timer.Stop() {
+		timer.Reset(settleDelay)
+		return
+	}
+	w.timers[path] = time.AfterFunc(settleDelay, func() {
+		w.mutex.Lock()
+		delete(w.timers, path)
+		given, watched := w.files[path]
+		w.mutex.Unlock()
+		if watched {
+			w.onChange(given)
+		}
+	})
+}
+
+// Close stops watching; changes not reported yet are dropped
+func (w *Watcher) Close() error {
+	w.mutex.Lock()
+	for _, timer := range w.timers {
+		timer.Stop()
+	}
+	w.files = make(map[string]string)
+	w.mutex.Unlock()
+	return w.fs.Close()
+}
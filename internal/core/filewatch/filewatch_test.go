@@ -0,0 +1,102 @@
+package filewatch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// waitFor returns the next path reported on changes, or "" after a while
+func waitFor(changes <-chan string) string {
+	select {
+	case path := <-changes:
+		return path
+	case <-time.After(2 * time.Second):
+		return ""
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pom.xml")
+	other := filepath.Join(dir, "other.xml")
+	for _, p := range []string{path, other} {
+		if err := os.WriteFile(p, []byte("<project/>"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	changes := make(chan string, 10)
+	watcher, err := New(func(path string) { changes <- path })
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer watcher.Close()
+	if err := watcher.Watch([]string{path}); err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	// Files next to the watched one are not reported
+	if err := os.WriteFile(other, []byte("<project></project>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Several writes in a row are reported once
+	for i := 0; i < 3; i++ {
+		if err := os.WriteFile(path, []byte("<project><version>1</version></project>"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := waitFor(changes); got != path {
+		t.Fatalf("Expected %s to be reported, got %q", path, got)
+	}
+	select {
+	case got := <-changes:
+		t.Errorf("Expected one report, also got %s", got)
+	case <-time.After(3 * settleDelay):
+	}
+
+	// Replacing the file by renaming a new version over it is reported too
+	replacement := filepath.Join(dir, "pom.xml.tmp")
+	if err := os.WriteFile(replacement, []byte("<project/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(replacement, path); err != nil {
+		t.Fatal(err)
+	}
+	if got := waitFor(changes); got != path {
+		t.Errorf("Expected the replaced %s to be reported, got %q", path, got)
+	}
+
+	// Paths are reported as they were given
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	relative, err := filepath.Rel(wd, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := watcher.Watch([]string{relative}); err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("<project><version>2</version></project>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := waitFor(changes); got != relative {
+		t.Errorf("Expected %s to be reported, got %q", relative, got)
+	}
+
+	// Files no longer watched are not reported
+	if err := watcher.Watch(nil); err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("<project/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-changes:
+		t.Errorf("Expected no report after unwatching, got %s", got)
+	case <-time.After(3 * settleDelay):
+	}
+}
@@ -0,0 +1,273 @@
+// Package git reads the status of files in a Git working tree, commits
+// them and fetches repositories, using the git command so no Git library
+// is needed.
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotInstalled is returned when the git command cannot be found
+	ErrNotInstalled = errors.New("git is not installed")
+
+	// ErrNotRepository is returned for paths outside a Git working tree
+	ErrNotRepository = errors.New("not in a git repository")
+)
+
+// commandTimeout bounds every git invocation, so a hung git (for example
+// waiting on a lock) cannot stall the caller
+const commandTimeout = 10 * time.Second
+
+// FileStatus is the state of a file relative to the index and HEAD
+type FileStatus struct {
+	Staged    bool // Changes are in the index
+	Modified  bool // The working tree differs from the index
+	Untracked bool // Git does not track the file
+}
+
+// Clean reports whether the file matches HEAD
+func (s FileStatus) Clean() bool {
+	return !s.Staged && !s.Modified && !s.Untracked
+}
+
+// Badge returns a short marker in the style of IDEs: "U" for untracked,
+// "A" for staged, "M" for modified and "AM" for both; "" when clean
+func (s FileStatus) Badge() string {
+	switch {
+	case s.Untracked:
+		return "U"
+	case s.Staged && s.Modified:
+		return "AM"
+	case s.Staged:
+		return "A"
+	case s.Modified:
+		return "M"
+	}
+	return ""
+}
+
+// String describes the status in words
+func (s FileStatus) String() string {
+	switch {
+	case s.Untracked:
+		return "untracked"
+	case s.Staged && s.Modified:
+		return "staged, modified"
+	case s.Staged:
+		return "staged"
+	case s.Modified:
+		return "modified"
+	}
+	return "unmodified"
+}
+
+// Repository is a snapshot of the status of a working tree
+type Repository struct {
+	Root  string
+	files map[string]FileStatus // Slash-separated path relative to Root -> status
+}
+
+// Open finds the working tree containing dir and reads its status
+func Open(dir string) (*Repository, error) {
+	out, err := run(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	repo := &Repository{Root: filepath.Clean(strings.TrimSpace(string(out)))}
+	if err := repo.Refresh(); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// Refresh reads the status of the working tree again
+func (r *Repository) Refresh() error {
+	out, err := run(r.Root, "status", "--porcelain=v1", "-z", "--untracked-files=all")
+	if err != nil {
+		return err
+	}
+	r.files = parseStatus(out)
+	return nil
+}
+
+// StatusOf returns the status of a file; files Git reports nothing about,
+// including those outside the working tree, are clean
+func (r *Repository) StatusOf(path string) FileStatus {
+	rel, ok := r.Relative(path)
+	if !ok {
+		return FileStatus{}
+	}
+	return r.files[rel]
+}
+
+// Commit stages a file and commits it alone, leaving other staged changes
+// in the index
+func (r *Repository) Commit(path, message string) error {
+	rel, ok := r.Relative(path)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotRepository, path)
+	}
+	if _, err := run(r.Root, "add", "--", rel); err != nil {
+		return err
+	}
+	if _, err := run(r.Root, "commit", "--quiet", "-m", message, "--", rel); err != nil {
+		return err
+	}
+	return r.Refresh()
+}
+
+// LastCommit describes the most recent commit that touched a file
+type LastCommit struct {
+	Hash    string // Abbreviated hash
+	Author  string
+	Date    time.Time
+	Subject string
+}
+
+// LastCommitOf returns the most recent commit that touched a file; ok is
+// false when the file has never been committed
+func (r *Repository) LastCommitOf(path string) (commit LastCommit, ok bool, err error) {
+	rel, inside := r.Relative(path)
+	if !inside {
+		return LastCommit{}, false, fmt.Errorf("%w: %s", ErrNotRepository, path)
+	}
+	out, err := run(r.Root, "log", "-1", "--format=%h%x00%an%x00%aI%x00%s", "--", rel)
+	if err != nil {
+		// A repository without commits has no HEAD to log from
+		if strings.Contains(err.Error(), "does not have any commits") {
+			return LastCommit{}, false, nil
+		}
+		return LastCommit{}, false, err
+	}
+	return parseLastCommit(out)
+}
+
+// Fetch checks out a single revision of the repository at url into dir,
+// which must be empty or missing, without history. ref is a branch, tag or
+// commit; empty means the remote's default branch. Returns the commit
+// checked out.
+func Fetch(ctx context.Context, url, ref, dir string) (string, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating checkout directory: %w", err)
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"fetch", "--quiet", "--depth", "1", url, ref},
+		{"checkout", "--quiet", "FETCH_HEAD"},
+	} {
+		if _, err := runContext(ctx, dir, args...); err != nil {
+			return "", err
+		}
+	}
+	out, err := runContext(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Relative returns path relative to the root with forward slashes, as git
+// status reports it
+func (r *Repository) Relative(path string) (string, bool) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	rel, err := filepath.Rel(r.Root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// parseStatus reads the NUL-separated output of git status --porcelain=v1 -z.
+// Each entry is "XY path", X describing the index and Y the working tree;
+// renames and copies are followed by an extra entry with the old path.
+func parseStatus(out []byte) map[string]FileStatus {
+	files := make(map[string]FileStatus)
+	entries := strings.Split(string(out), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		x, y, path := entry[0], entry[1], entry[3:]
+		if x == 'R' || x == 'C' {
+			i++ // Skip the old path
+		}
+
+		switch {
+		case x == '?' && y == '?':
+			files[path] = FileStatus{Untracked: true}
+		case x == '!' && y == '!':
+			// Ignored files are reported as clean
+		default:
+			files[path] = FileStatus{Staged: x != ' ', Modified: y != ' '}
+		}
+	}
+	return files
+}
+
+// parseLastCommit reads the NUL-separated output of git log -1 with the
+// format hash, author, ISO 8601 date and subject; empty output means no commit
+func parseLastCommit(out []byte) (LastCommit, bool, error) {
+	line := strings.TrimRight(string(out), "\n")
+	if line == "" {
+		return LastCommit{}, false, nil
+	}
+	fields := strings.SplitN(line, "\x00", 4)
+	if len(fields) != 4 {
+		return LastCommit{}, false, fmt.Errorf("git log: unexpected output %q", line)
+	}
+	date, err := time.Parse(time.RFC3339, fields[2])
+	if err != nil {
+		return LastCommit{}, false, fmt.Errorf("git log: %w", err)
+	}
+	return LastCommit{Hash: fields[0], Author: fields[1], Date: date, Subject: fields[3]}, true, nil
+}
+
+// run runs git in dir and returns its standard output
+func run(dir string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	return runContext(ctx, dir, args...)
+}
+
+// runContext runs git in dir until ctx is done and returns its standard
+// output
+func runContext(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, ErrNotInstalled
+	}
+
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if strings.Contains(message, "not a git repository") {
+			return nil, fmt.Errorf("%w: %s", ErrNotRepository, dir)
+		}
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, fmt.Errorf("git %s: %s", args[0], message)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,183 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// newRepository initializes a repository in a temporary directory, or skips
+// the test when git is not installed
+func newRepository(t *testing.T) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "-C", dir, "init", "--quiet").CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	return dir
+}
+
+func TestStatusAndCommit(t *testing.T) {
+	dir := newRepository(t)
+	pomPath := filepath.Join(dir, "core", "pom.xml")
+	if err := os.MkdirAll(filepath.Dir(pomPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pomPath, []byte("<project/>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := Open(filepath.Dir(pomPath))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if status := repo.StatusOf(pomPath); !status.Untracked || status.Badge() != "U" {
+		t.Errorf("Expected untracked, got %s", status)
+	}
+
+	if err := repo.Commit(pomPath, "Add core POM"); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if status := repo.StatusOf(pomPath); !status.Clean() {
+		t.Errorf("Expected clean after commit, got %s", status)
+	}
+
+	if err := os.WriteFile(pomPath, []byte("<project></project>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if status := repo.StatusOf(pomPath); !status.Modified || status.Staged {
+		t.Errorf("Expected modified, got %s", status)
+	}
+}
+
+func TestLastCommitOf(t *testing.T) {
+	dir := newRepository(t)
+	pomPath := filepath.Join(dir, "pom.xml")
+	if err := os.WriteFile(pomPath, []byte("<project/>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if _, ok, err := repo.LastCommitOf(pomPath); err != nil || ok {
+		t.Errorf("Expected no commit before the first one, got ok=%v err=%v", ok, err)
+	}
+
+	if err := repo.Commit(pomPath, "Add POM"); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	commit, ok, err := repo.LastCommitOf(pomPath)
+	if err != nil || !ok {
+		t.Fatalf("Expected a commit, got ok=%v err=%v", ok, err)
+	}
+	if commit.Subject != "Add POM" || commit.Author != "Test" || commit.Hash == "" || commit.Date.IsZero() {
+		t.Errorf("Unexpected commit %+v", commit)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	remote := newRepository(t)
+	pomPath := filepath.Join(remote, "pom.xml")
+	if err := os.WriteFile(pomPath, []byte("<project/>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := Open(remote)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if err := repo.Commit(pomPath, "First"); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if out, err := exec.Command("git", "-C", remote, "tag", "v1").CombinedOutput(); err != nil {
+		t.Fatalf("git tag failed: %v\n%s", err, out)
+	}
+	if err := os.WriteFile(pomPath, []byte("<project></project>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Commit(pomPath, "Second"); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "checkout")
+	revision, err := Fetch(context.Background(), remote, "v1", dir)
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "pom.xml")); string(data) != "<project/>\n" {
+		t.Errorf("Expected the tagged content, got %q", data)
+	}
+	if len(revision) != 40 {
+		t.Errorf("Expected a commit hash, got %q", revision)
+	}
+
+	latest, err := Fetch(context.Background(), remote, "", filepath.Join(t.TempDir(), "latest"))
+	if err != nil {
+		t.Fatalf("Fetch of the default branch failed: %v", err)
+	}
+	if latest == revision {
+		t.Error("Expected the default branch to be at the second commit")
+	}
+}
+
+func TestOpenOutsideRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("GIT_CEILING_DIRECTORIES", os.TempDir())
+
+	_, err := Open(t.TempDir())
+	if !errors.Is(err, ErrNotRepository) {
+		t.Errorf("Expected ErrNotRepository, got %v", err)
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	files := parseStatus([]byte("M  staged.xml\x00 M modified.xml\x00?? new.xml\x00R  renamed.xml\x00old.xml\x00MM both.xml\x00"))
+	expected := map[string]string{
+		"staged.xml":   "A",
+		"modified.xml": "M",
+		"new.xml":      "U",
+		"renamed.xml":  "A",
+		"both.xml":     "AM",
+	}
+	for path, badge := range expected {
+		if got := files[path].Badge(); got != badge {
+			t.Errorf("Expected %s to be %s, got %q", path, badge, got)
+		}
+	}
+	if _, ok := files["old.xml"]; ok {
+		t.Error("Expected the old path of a rename to be skipped")
+	}
+}
+
+func TestParseLastCommit(t *testing.T) {
+	commit, ok, err := parseLastCommit([]byte("abc1234\x00Jane Doe\x002024-05-01T10:30:00+02:00\x00Bump version\n"))
+	if err != nil || !ok {
+		t.Fatalf("Expected a commit, got ok=%v err=%v", ok, err)
+	}
+	if commit.Hash != "abc1234" || commit.Author != "Jane Doe" || commit.Subject != "Bump version" {
+		t.Errorf("Unexpected commit %+v", commit)
+	}
+	if commit.Date.Year() != 2024 || commit.Date.Hour() != 10 {
+		t.Errorf("Expected the author date, got %s", commit.Date)
+	}
+
+	if _, ok, err := parseLastCommit(nil); ok || err != nil {
+		t.Errorf("Expected no commit for empty output, got ok=%v err=%v", ok, err)
+	}
+}
@@ -0,0 +1,274 @@
+// Package httpcache keeps the responses of remote lookups on disk, so that
+// repeated lookups are answered without the network while fresh, and
+// revalidated with ETag and Last-Modified once stale. Stale responses are
+// still served when the repository cannot be reached.
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// DirName is the directory of the cache below the cache directory
+const DirName = "http"
+
+// DefaultTTL is how long responses are used without revalidation
+const DefaultTTL = 24 * time.Hour
+
+// maxEntrySize is the largest response body that is cached
+const maxEntrySize = 8 << 20
+
+// Cache stores the responses of GET requests on disk
+type Cache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// entry is a cached response
+type entry struct {
+	URL          string    `json:"url"`
+	StatusCode   int       `json:"status"`
+	ContentType  string    `json:"content_type,omitempty"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Stored       time.Time `json:"stored"` // When the response was last fetched or revalidated
+	Body         []byte    `json:"body"`
+}
+
+// New creates a cache of responses in dir, typically DirName below the
+// cache directory. Responses younger than ttl are served without asking
+// the server; a ttl of 0 revalidates every response.
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// Dir returns the directory of the cache
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Transport returns a RoundTripper answering GET requests from the cache,
+// and sending the others and cache misses to next
+func (c *Cache) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{cache: c, next: next}
+}
+
+// Size returns the number of cached responses and the bytes they take
+func (c *Cache) Size() (int, int64, error) {
+	count, size := 0, int64(0)
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == c.dir {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		count++
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("measuring HTTP cache: %w", err)
+	}
+	return count, size, nil
+}
+
+// Clear removes every cached response
+func (c *Cache) Clear() error {
+	if err := os.RemoveAll(c.dir); err != nil {
+		return fmt.Errorf("clearing HTTP cache: %w", err)
+	}
+	return nil
+}
+
+// FormatSize formats a number of bytes for display, such as "1.5 MiB"
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value, exponent := float64(size)/unit, 0
+	for value >= unit && exponent < 2 {
+		value /= unit
+		exponent++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, "KMG"[exponent])
+}
+
+// path returns the file of the response to url
+func (c *Cache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(c.dir, name[:2], name+".json")
+}
+
+// load reads the cached response to url, nil when there is none
+func (c *Cache) load(url string) *entry {
+	data, err := os.ReadFile(c.path(url))
+	if err != nil {
+		return nil
+	}
+	var cached entry
+	if err := json.Unmarshal(data, &cached); err != nil || cached.URL != url {
+		return nil
+	}
+	return &cached
+}
+
+// store writes a response to the cache, replacing the file atomically so
+// concurrent lookups never read half an entry
+func (c *Cache) store(cached *entry) error {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	path := c.path(cached.URL)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// transport answers requests from a Cache
+type transport struct {
+	cache *Cache
+	next  http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	url := req.URL.String()
+	cached := t.cache.load(url)
+	if cached != nil && t.cache.now().Sub(cached.Stored) < t.cache.ttl && req.Header.Get("Cache-Control") != "no-cache" {
+		return cached.response(req), nil
+	}
+
+	// Revalidate a stale response instead of downloading it again
+	if cached != nil && (cached.ETag != "" || cached.LastModified != "") {
+		req = req.Clone(req.Context())
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		// Unreachable: a stale answer beats none, unless canceled; the same
+		// goes for server errors below
+		if cached != nil && req.Context().Err() == nil {
+			return cached.response(req), nil
+		}
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		resp.Body.Close()
+		cached.Stored = t.cache.now()
+		t.cache.store(cached)
+		return cached.response(req), nil
+	case resp.StatusCode >= http.StatusInternalServerError && cached != nil:
+		resp.Body.Close()
+		return cached.response(req), nil
+	case resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound:
+		return resp, nil
+	}
+
+	// Read the body to cache it, unless it is too large
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxEntrySize+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxEntrySize {
+		resp.Body = readCloser{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	fresh := &entry{
+		URL:          url,
+		StatusCode:   resp.StatusCode,
+		ContentType:  resp.Header.Get("Content-Type"),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Stored:       t.cache.now(),
+		Body:         body,
+	}
+	// Failing to cache is not failing the request
+	t.cache.store(fresh)
+	return fresh.response(req), nil
+}
+
+// response returns the cached response as an answer to req
+func (e *entry) response(req *http.Request) *http.Response {
+	header := make(http.Header)
+	if e.ContentType != "" {
+		header.Set("Content-Type", e.ContentType)
+	}
+	if e.ETag != "" {
+		header.Set("ETag", e.ETag)
+	}
+	if e.LastModified != "" {
+		header.Set("Last-Modified", e.LastModified)
+	}
+	header.Set("Content-Length", strconv.Itoa(len(e.Body)))
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// readCloser reads from a reader and closes a closer
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,136 @@
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("Expected %s to be answered, got %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestTransport(t *testing.T) {
+	requests, revalidated := 0, 0
+	failing := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if failing {
+			http.Error(w, "down", http.StatusBadGateway)
+			return
+		}
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidated++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("metadata"))
+	}))
+	t.Cleanup(server.Close)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := New(t.TempDir(), time.Hour)
+	cache.now = func() time.Time { return now }
+	client := &http.Client{Transport: cache.Transport(nil)}
+
+	// First lookup goes to the server, the second is answered from disk
+	for i := 0; i < 2; i++ {
+		if status, body := get(t, client, server.URL+"/metadata"); status != http.StatusOK || body != "metadata" {
+			t.Errorf("Expected the metadata, got %d %q", status, body)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request while fresh, got %d", requests)
+	}
+
+	// Missing artifacts are cached too
+	get(t, client, server.URL+"/missing")
+	if status, _ := get(t, client, server.URL+"/missing"); status != http.StatusNotFound || requests != 2 {
+		t.Errorf("Expected a cached 404, got %d after %d requests", status, requests)
+	}
+
+	// Stale responses are revalidated with their ETag
+	now = now.Add(2 * time.Hour)
+	if status, body := get(t, client, server.URL+"/metadata"); status != http.StatusOK || body != "metadata" || revalidated != 1 {
+		t.Errorf("Expected the metadata to be revalidated, got %d %q after %d revalidations", status, body, revalidated)
+	}
+	get(t, client, server.URL+"/metadata")
+	if revalidated != 1 {
+		t.Errorf("Expected the revalidation to refresh the response, got %d revalidations", revalidated)
+	}
+
+	// Stale responses are served when the server fails
+	now = now.Add(2 * time.Hour)
+	failing = true
+	if status, body := get(t, client, server.URL+"/metadata"); status != http.StatusOK || body != "metadata" {
+		t.Errorf("Expected the stale metadata on server errors, got %d %q", status, body)
+	}
+	server.Close()
+	if status, body := get(t, client, server.URL+"/metadata"); status != http.StatusOK || body != "metadata" {
+		t.Errorf("Expected the stale metadata without the server, got %d %q", status, body)
+	}
+	if _, err := client.Get(server.URL + "/uncached"); err == nil {
+		t.Error("Expected uncached lookups to fail without the server")
+	}
+}
+
+func TestSizeAndClear(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	}))
+	t.Cleanup(server.Close)
+
+	cache := New(t.TempDir()+"/http", DefaultTTL)
+	if count, size, err := cache.Size(); err != nil || count != 0 || size != 0 {
+		t.Errorf("Expected an empty cache before the first lookup, got %d, %d, %v", count, size, err)
+	}
+
+	client := &http.Client{Transport: cache.Transport(nil)}
+	get(t, client, server.URL+"/a")
+	get(t, client, server.URL+"/b")
+	if count, size, err := cache.Size(); err != nil || count != 2 || size == 0 {
+		t.Errorf("Expected 2 cached responses, got %d, %d, %v", count, size, err)
+	}
+
+	if err := cache.Clear(); err != nil {
+		t.Fatalf("Failed to clear the cache: %v", err)
+	}
+	if count, _, err := cache.Size(); err != nil || count != 0 {
+		t.Errorf("Expected an empty cache after clearing, got %d, %v", count, err)
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		size int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1536, "1.5 KiB"},
+		{5 << 20, "5.0 MiB"},
+		{3 << 30, "3.0 GiB"},
+	}
+	for _, tt := range tests {
+		if got := FormatSize(tt.size); got != tt.want {
+			t.Errorf("Expected %d bytes to be %q, got %q", tt.size, tt.want, got)
+		}
+	}
+}
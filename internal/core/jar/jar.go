@@ -0,0 +1,97 @@
+// Package jar reads the Maven coordinates embedded in JAR files. Maven
+// writes them to META-INF/maven/<groupId>/<artifactId>/pom.properties of
+// every JAR it builds, so a JAR found on disk can be added as a dependency.
+package jar
+
+import (
+	"archive/zip"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// ErrNoCoordinates indicates that a JAR has no pom.properties, as it was
+// not built by Maven
+var ErrNoCoordinates = errors.New("no Maven coordinates found")
+
+// maxPropertiesSize limits how much of a pom.properties entry is read
+const maxPropertiesSize = 64 << 10
+
+// Coordinates returns the coordinates embedded in the JAR at jarPath,
+// sorted. Shaded JARs embed those of every artifact bundled in them.
+func Coordinates(jarPath string) ([]pom.Coordinates, error) {
+	reader, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filepath.Base(jarPath), err)
+	}
+	defer reader.Close()
+
+	var coords []pom.Coordinates
+	for _, file := range reader.File {
+		if !isPOMProperties(file.Name) {
+			continue
+		}
+		properties, err := readProperties(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s in %s: %w", file.Name, filepath.Base(jarPath), err)
+		}
+		c := pom.Coordinates{
+			GroupID:    properties["groupId"],
+			ArtifactID: properties["artifactId"],
+			Version:    properties["version"],
+		}
+		if c.GroupID != "" && c.ArtifactID != "" && c.Version != "" {
+			coords = append(coords, c)
+		}
+	}
+	if len(coords) == 0 {
+		return nil, fmt.Errorf("%w in %s", ErrNoCoordinates, filepath.Base(jarPath))
+	}
+
+	sort.Slice(coords, func(i, j int) bool {
+		return coords[i].String() < coords[j].String()
+	})
+	return coords, nil
+}
+
+// isPOMProperties reports whether name is
+// META-INF/maven/<groupId>/<artifactId>/pom.properties
+func isPOMProperties(name string) bool {
+	parts := strings.Split(name, "/")
+	return len(parts) == 5 && parts[0] == "META-INF" && parts[1] == "maven" &&
+		parts[4] == "pom.properties"
+}
+
+// readProperties reads the key=value pairs of a Java properties entry,
+// skipping comments. Line continuations and escapes are not needed for the
+// plain values Maven writes.
+func readProperties(file *zip.File) (map[string]string, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	properties := make(map[string]string)
+	scanner := bufio.NewScanner(io.LimitReader(rc, maxPropertiesSize))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			key, value, ok = strings.Cut(line, ":")
+		}
+		if ok {
+			properties[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return properties, scanner.Err()
+}
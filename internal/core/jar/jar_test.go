@@ -0,0 +1,80 @@
+package jar
+
+import (
+	"archive/zip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeJAR creates a JAR at dir/name holding files, by name
+func writeJAR(t *testing.T, dir, name string, files map[string]string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	out, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+
+	writer := zip.NewWriter(out)
+	for fileName, content := range files {
+		w, err := writer.Create(fileName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCoordinates(t *testing.T) {
+	dir := t.TempDir()
+	path := writeJAR(t, dir, "shaded.jar", map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\n",
+		"META-INF/maven/org.slf4j/slf4j-api/pom.properties": "#Generated by Maven\n" +
+			"artifactId=slf4j-api\ngroupId=org.slf4j\nversion=2.0.9\n",
+		"META-INF/maven/com.example/app/pom.properties": "groupId: com.example\r\n" +
+			"artifactId = app\r\nversion=1.0.0\r\n",
+		// Not where Maven writes coordinates
+		"META-INF/maven/pom.properties": "groupId=ignored\nartifactId=ignored\nversion=1\n",
+	})
+
+	coords, err := Coordinates(path)
+	if err != nil {
+		t.Fatalf("Coordinates failed: %v", err)
+	}
+	if len(coords) != 2 {
+		t.Fatalf("Expected 2 coordinates, got %v", coords)
+	}
+	if coords[0].String() != "com.example:app:1.0.0" || coords[1].String() != "org.slf4j:slf4j-api:2.0.9" {
+		t.Errorf("Expected sorted coordinates, got %v", coords)
+	}
+}
+
+func TestCoordinatesMissing(t *testing.T) {
+	dir := t.TempDir()
+	path := writeJAR(t, dir, "plain.jar", map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\n",
+		// Incomplete coordinates are skipped
+		"META-INF/maven/com.example/app/pom.properties": "groupId=com.example\nartifactId=app\n",
+	})
+
+	if _, err := Coordinates(path); !errors.Is(err, ErrNoCoordinates) {
+		t.Errorf("Expected ErrNoCoordinates, got %v", err)
+	}
+
+	notJAR := filepath.Join(dir, "notes.jar")
+	if err := os.WriteFile(notJAR, []byte("not a zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Coordinates(notJAR); err == nil {
+		t.Error("Expected an error for a file that is not a JAR")
+	}
+}
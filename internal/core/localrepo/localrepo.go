@@ -0,0 +1,156 @@
+// Package localrepo lists the artifacts installed in a Maven local
+// repository such as ~/.m2/repository, so they can be found and added as
+// dependencies without looking anything up remotely.
+package localrepo
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/core/remote"
+)
+
+// Artifact is an artifact installed in a local repository
+type Artifact struct {
+	GroupID    string
+	ArtifactID string
+	Versions   []string // Installed versions with a POM, oldest first
+}
+
+// Key returns groupId:artifactId
+func (a Artifact) Key() string {
+	return a.GroupID + ":" + a.ArtifactID
+}
+
+// Latest returns the newest installed version
+func (a Artifact) Latest() string {
+	if len(a.Versions) == 0 {
+		return ""
+	}
+	return a.Versions[len(a.Versions)-1]
+}
+
+// Dir returns the local repository Maven uses: the localRepository of the
+// settings, which may be nil, else ~/.m2/repository
+func Dir(settings *remote.Settings) string {
+	if settings != nil && settings.LocalRepository != "" {
+		return settings.LocalRepository
+	}
+	return pom.DefaultLocalRepository()
+}
+
+// POMPath returns the path of the POM of an installed version
+func POMPath(dir, groupID, artifactID, version string) string {
+	return pom.LocalRepositoryPath(dir, groupID, artifactID, version, "pom")
+}
+
+// Scan lists the artifacts installed in the local repository dir, sorted by
+// groupId:artifactId. A version counts as installed when its directory has
+// the version's POM, so versions with only metadata or a failed download
+// are left out.
+func Scan(ctx context.Context, dir string) ([]Artifact, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("local repository: %w", err)
+	}
+
+	artifacts := make(map[string]*Artifact)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			// Unreadable directories are skipped, not fatal
+			if entry != nil && entry.IsDir() && path != dir {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".pom") {
+			return nil
+		}
+
+		// The POM of an installed version is group/path/artifactId/version/artifactId-version.pom
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return nil
+		}
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		if len(parts) < 4 {
+			return nil
+		}
+		version := parts[len(parts)-2]
+		artifactID := parts[len(parts)-3]
+		if entry.Name() != artifactID+"-"+version+".pom" {
+			return nil
+		}
+		groupID := strings.Join(parts[:len(parts)-3], ".")
+
+		key := groupID + ":" + artifactID
+		artifact, ok := artifacts[key]
+		if !ok {
+			artifact = &Artifact{GroupID: groupID, ArtifactID: artifactID}
+			artifacts[key] = artifact
+		}
+		artifact.Versions = append(artifact.Versions, version)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Artifact, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		sort.Slice(artifact.Versions, func(i, j int) bool {
+			return compareVersions(artifact.Versions[i], artifact.Versions[j]) < 0
+		})
+		result = append(result, *artifact)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key() < result[j].Key()
+	})
+	return result, nil
+}
+
+// Filter returns the artifacts whose groupId:artifactId contains every
+// word of query, ignoring case. An empty query matches all artifacts.
+func Filter(artifacts []Artifact, query string) []Artifact {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return artifacts
+	}
+
+	var matches []Artifact
+	for _, artifact := range artifacts {
+		key := strings.ToLower(artifact.Key())
+		matched := true
+		for _, word := range words {
+			if !strings.Contains(key, word) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			matches = append(matches, artifact)
+		}
+	}
+	return matches
+}
+
+// compareVersions compares two versions semantically, falling back to
+// string order for versions semver cannot parse
+func compareVersions(a, b string) int {
+	va, errA := semver.NewVersion(a)
+	vb, errB := semver.NewVersion(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return va.Compare(vb)
+}
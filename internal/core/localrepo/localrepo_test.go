@@ -0,0 +1,90 @@
+package localrepo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/user/pom-manager/internal/core/remote"
+)
+
+func install(t *testing.T, dir, groupID, artifactID, version string) {
+	t.Helper()
+	path := POMPath(dir, groupID, artifactID, version)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("<project/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScan(t *testing.T) {
+	dir := t.TempDir()
+	install(t, dir, "org.slf4j", "slf4j-api", "2.0.9")
+	install(t, dir, "org.slf4j", "slf4j-api", "1.7.36")
+	install(t, dir, "org.slf4j", "slf4j-api", "2.0.10")
+	install(t, dir, "junit", "junit", "4.13.2")
+
+	// Metadata only, as left by a failed download
+	metadataOnly := filepath.Join(dir, "com", "example", "gone", "1.0")
+	if err := os.MkdirAll(metadataOnly, 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(metadataOnly, "gone-1.0.pom.lastUpdated"), nil, 0644)
+
+	artifacts, err := Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(artifacts) != 2 {
+		t.Fatalf("Expected 2 artifacts, got %+v", artifacts)
+	}
+	if artifacts[0].Key() != "junit:junit" || artifacts[1].Key() != "org.slf4j:slf4j-api" {
+		t.Errorf("Expected artifacts sorted by key, got %s, %s", artifacts[0].Key(), artifacts[1].Key())
+	}
+	if got := strings.Join(artifacts[1].Versions, " "); got != "1.7.36 2.0.9 2.0.10" {
+		t.Errorf("Expected versions oldest first, got %s", got)
+	}
+	if artifacts[1].Latest() != "2.0.10" {
+		t.Errorf("Expected latest 2.0.10, got %s", artifacts[1].Latest())
+	}
+
+	if _, err := Scan(context.Background(), filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("Expected an error for a missing repository")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Scan(ctx, dir); err == nil {
+		t.Errorf("Expected an error for a canceled scan")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	artifacts := []Artifact{
+		{GroupID: "junit", ArtifactID: "junit"},
+		{GroupID: "org.junit.jupiter", ArtifactID: "junit-jupiter-api"},
+		{GroupID: "org.slf4j", ArtifactID: "slf4j-api"},
+	}
+
+	if got := Filter(artifacts, ""); len(got) != 3 {
+		t.Errorf("Expected an empty query to match everything, got %d", len(got))
+	}
+	if got := Filter(artifacts, "JUnit API"); len(got) != 1 || got[0].ArtifactID != "junit-jupiter-api" {
+		t.Errorf("Expected every word to match, got %+v", got)
+	}
+	if got := Filter(artifacts, "log4j"); len(got) != 0 {
+		t.Errorf("Expected no matches, got %+v", got)
+	}
+}
+
+func TestDir(t *testing.T) {
+	if got := Dir(&remote.Settings{LocalRepository: "/data/m2"}); got != "/data/m2" {
+		t.Errorf("Expected the settings' local repository, got %s", got)
+	}
+	if got := Dir(nil); !strings.HasSuffix(got, filepath.Join(".m2", "repository")) {
+		t.Errorf("Expected ~/.m2/repository, got %s", got)
+	}
+}
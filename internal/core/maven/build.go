@@ -0,0 +1,146 @@
+package maven
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// LineKind is what a line of Maven output reports, for coloring it
+type LineKind int
+
+const (
+	LinePlain   LineKind = iota
+	LineHeading          // A module or plugin execution starts
+	LineWarning
+	LineError
+	LineSuccess // BUILD SUCCESS
+)
+
+// wrapperExecutable returns the name of the Maven wrapper script on this
+// platform
+func wrapperExecutable() string {
+	if runtime.GOOS == "windows" {
+		return "mvnw.cmd"
+	}
+	return "mvnw"
+}
+
+// FindWrapper returns the Maven wrapper of the project in dir, which in a
+// multi-module build sits next to the root POM, so dir's parents are
+// searched too. Returns "" when there is none.
+func FindWrapper(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		candidate := filepath.Join(dir, wrapperExecutable())
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() &&
+			(runtime.GOOS == "windows" || info.Mode()&0111 != 0) {
+			return candidate
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Locate returns the launcher for a build in dir: path when set, else the
+// project's Maven wrapper, else the mvn Find locates
+func Locate(dir, path string) (string, error) {
+	if path != "" {
+		return Find(path)
+	}
+	if wrapper := FindWrapper(dir); wrapper != "" {
+		return wrapper, nil
+	}
+	return Find("")
+}
+
+// Stream runs mvn with args in dir like Run, but passes each line of output
+// to line as soon as Maven prints it, rather than returning the output.
+// Canceling ctx stops the build.
+func Stream(ctx context.Context, mvn, dir string, args []string, line func(string)) error {
+	cmd := exec.CommandContext(ctx, mvn, append([]string{"-B"}, args...)...)
+	cmd.Dir = dir
+	// Forked JVMs may keep the output open after mvn is killed
+	cmd.WaitDelay = 5 * time.Second
+
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("running mvn: %w", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		writer.Close()
+		done <- err
+	}()
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line(scanner.Text())
+	}
+	// Keep Maven from blocking on output after an overlong line
+	io.Copy(io.Discard, reader)
+
+	if err := <-done; err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("running mvn: %w", ctx.Err())
+		}
+		return fmt.Errorf("running mvn: %w", err)
+	}
+	return nil
+}
+
+// ExitCode returns the exit status of a build from the error Run or Stream
+// returned: 0 for nil, -1 when Maven did not run to an exit
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.Exited() {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// ClassifyLine tells what a line of Maven's batch mode output reports
+func ClassifyLine(line string) LineKind {
+	switch {
+	case strings.HasPrefix(line, "[ERROR]"), strings.HasPrefix(line, "[FATAL]"):
+		return LineError
+	case strings.HasPrefix(line, "[WARNING]"), strings.HasPrefix(line, "[WARN]"):
+		return LineWarning
+	}
+
+	message, ok := strings.CutPrefix(line, "[INFO] ")
+	if !ok {
+		return LinePlain
+	}
+	switch {
+	case strings.HasPrefix(message, "BUILD SUCCESS"):
+		return LineSuccess
+	case strings.HasPrefix(message, "BUILD FAILURE"):
+		return LineError
+	case strings.HasPrefix(message, "--- "), strings.HasPrefix(message, "Building "),
+		strings.HasPrefix(message, "Reactor Summary"):
+		return LineHeading
+	}
+	return LinePlain
+}
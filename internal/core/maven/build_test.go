@@ -0,0 +1,87 @@
+package maven
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestFindWrapper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("wrapper is looked up as mvnw.cmd")
+	}
+	root := t.TempDir()
+	module := filepath.Join(root, "core")
+	if err := os.MkdirAll(module, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindWrapper(module); got != "" {
+		t.Errorf("Expected no wrapper, got %s", got)
+	}
+
+	wrapper := filepath.Join(root, "mvnw")
+	if err := os.WriteFile(wrapper, []byte("#!/bin/sh\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindWrapper(module); got != "" {
+		t.Errorf("Expected a wrapper that is not executable to be ignored, got %s", got)
+	}
+
+	if err := os.Chmod(wrapper, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindWrapper(module); got != wrapper {
+		t.Errorf("Expected the root's wrapper %s for a module, got %q", wrapper, got)
+	}
+	if got, err := Locate(module, ""); err != nil || got != wrapper {
+		t.Errorf("Expected Locate to prefer the wrapper, got %s (%v)", got, err)
+	}
+}
+
+func TestStream(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake launcher is a shell script")
+	}
+	// The fake launcher echoes its arguments, then fails
+	mvn := filepath.Join(t.TempDir(), "mvn")
+	script := "#!/bin/sh\necho \"[INFO] args: $*\"\necho '[ERROR] oops' >&2\nexit 3\n"
+	if err := os.WriteFile(mvn, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []string
+	err := Stream(context.Background(), mvn, t.TempDir(), []string{"-Pci", "verify"}, func(line string) {
+		lines = append(lines, line)
+	})
+	if got := ExitCode(err); got != 3 {
+		t.Errorf("Expected exit code 3, got %d (%v)", got, err)
+	}
+	if strings.Join(lines, "\n") != "[INFO] args: -B -Pci verify\n[ERROR] oops" {
+		t.Errorf("Expected both output streams line by line, got %q", lines)
+	}
+
+	if got := ExitCode(nil); got != 0 {
+		t.Errorf("Expected exit code 0 for success, got %d", got)
+	}
+}
+
+func TestClassifyLine(t *testing.T) {
+	tests := map[string]LineKind{
+		"[INFO] Scanning for projects...":                                    LinePlain,
+		"[INFO] Building my-app 1.0.0                                 [1/2]": LineHeading,
+		"[INFO] --- compiler:3.13.0:compile (default-compile) @ my-app ---":  LineHeading,
+		"[WARNING] Using platform encoding":                                  LineWarning,
+		"[ERROR] Failed to execute goal":                                     LineError,
+		"[INFO] BUILD SUCCESS":                                               LineSuccess,
+		"[INFO] BUILD FAILURE":                                               LineError,
+		"Tests run: 1, Failures: 0, Errors: 0, Skipped: 0":                   LinePlain,
+	}
+	for line, expected := range tests {
+		if got := ClassifyLine(line); got != expected {
+			t.Errorf("ClassifyLine(%q): expected %d, got %d", line, expected, got)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package maven
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Task is a common job with the goals that do it
+type Task struct {
+	Name        string
+	Goals       []string
+	Properties  map[string]string
+	Description string
+}
+
+// Tasks lists common jobs, offered as starting points for a Command
+var Tasks = []Task{
+	{Name: "Build and install", Goals: []string{"clean", "install"},
+		Description: "Rebuild from scratch, run the tests and install the artifact into the local repository"},
+	{Name: "Run tests", Goals: []string{"test"},
+		Description: "Compile and run the unit tests"},
+	{Name: "Run one test class", Goals: []string{"test"}, Properties: map[string]string{"test": "MyTest"},
+		Description: "Run only the named test class; MyTest#method runs a single method"},
+	{Name: "Package without tests", Goals: []string{"clean", "package"}, Properties: map[string]string{"skipTests": "true"},
+		Description: "Build the artifact in target/ without running the tests"},
+	{Name: "Run integration tests", Goals: []string{"verify"},
+		Description: "Run unit and integration tests and the checks bound to verify"},
+	{Name: "Show dependency tree", Goals: []string{"dependency:tree"},
+		Description: "Print the resolved dependencies and who brings them in"},
+	{Name: "Show effective POM", Goals: []string{"help:effective-pom"},
+		Description: "Print the POM after inheritance, profiles and interpolation"},
+	{Name: "Check for dependency updates", Goals: []string{"versions:display-dependency-updates"},
+		Description: "List dependencies with newer versions in the repositories"},
+}
+
+// Command is an mvn command line
+type Command struct {
+	Goals              []string
+	Profiles           []string          // Activated with -P
+	Properties         map[string]string // Set with -D; an empty value sets just the name
+	Projects           []string          // Modules built with -pl, all when empty
+	AlsoMake           bool              // -am: also build the modules the projects need
+	AlsoMakeDependents bool              // -amd: also build the modules needing the projects
+	File               string            // POM given with -f, "" for pom.xml
+	Offline            bool              // -o: use the local repository only
+}
+
+// Args returns the command's arguments, without mvn itself
+func (c Command) Args() []string {
+	var args []string
+	if c.File != "" {
+		args = append(args, "-f", c.File)
+	}
+	if len(c.Projects) > 0 {
+		args = append(args, "-pl", strings.Join(c.Projects, ","))
+		if c.AlsoMake {
+			args = append(args, "-am")
+		}
+		if c.AlsoMakeDependents {
+			args = append(args, "-amd")
+		}
+	}
+	if len(c.Profiles) > 0 {
+		args = append(args, "-P"+strings.Join(c.Profiles, ","))
+	}
+	for _, name := range c.propertyNames() {
+		if value := c.Properties[name]; value != "" {
+			args = append(args, "-D"+name+"="+value)
+		} else {
+			args = append(args, "-D"+name)
+		}
+	}
+	if c.Offline {
+		args = append(args, "-o")
+	}
+	return append(args, c.Goals...)
+}
+
+// String returns the command line, quoted for POSIX shells
+func (c Command) String() string {
+	words := []string{executable()}
+	for _, arg := range c.Args() {
+		words = append(words, quote(arg))
+	}
+	return strings.Join(words, " ")
+}
+
+// Explain describes what each part of the command does, one line each, to
+// teach the flags
+func (c Command) Explain() []string {
+	var lines []string
+	if c.File != "" {
+		lines = append(lines, fmt.Sprintf("-f %s: build this POM instead of pom.xml", c.File))
+	}
+	if len(c.Projects) > 0 {
+		lines = append(lines, fmt.Sprintf("-pl %s: build only these modules", strings.Join(c.Projects, ",")))
+		if c.AlsoMake {
+			lines = append(lines, "-am: also build the modules they depend on")
+		}
+		if c.AlsoMakeDependents {
+			lines = append(lines, "-amd: also build the modules that depend on them")
+		}
+	}
+	if len(c.Profiles) > 0 {
+		lines = append(lines, fmt.Sprintf("-P%s: activate these profiles", strings.Join(c.Profiles, ",")))
+	}
+	for _, name := range c.propertyNames() {
+		value := c.Properties[name]
+		if value == "" {
+			value = "true"
+		}
+		lines = append(lines, fmt.Sprintf("-D%s: set property %s to %s", name, name, value))
+	}
+	if c.Offline {
+		lines = append(lines, "-o: work offline with the local repository only")
+	}
+	for _, goal := range c.Goals {
+		if strings.Contains(goal, ":") {
+			lines = append(lines, fmt.Sprintf("%s: run this plugin goal", goal))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s: run the lifecycle up to the %s phase", goal, goal))
+		}
+	}
+	return lines
+}
+
+// propertyNames returns the property names in order
+func (c Command) propertyNames() []string {
+	names := make([]string, 0, len(c.Properties))
+	for name := range c.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// quote quotes an argument for POSIX shells when it needs it
+func quote(arg string) string {
+	if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_.,:=/@+%#") == "" {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
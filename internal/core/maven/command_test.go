@@ -0,0 +1,67 @@
+package maven
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCommandString(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("launcher is mvn.cmd")
+	}
+	command := Command{
+		Goals:      []string{"clean", "install"},
+		Profiles:   []string{"ci", "fast"},
+		Properties: map[string]string{"skipTests": "", "argLine": "-Xmx1g -ea"},
+		Projects:   []string{"core", "web"},
+		AlsoMake:   true,
+		File:       "build/pom.xml",
+	}
+	expected := "mvn -f build/pom.xml -pl core,web -am -Pci,fast '-DargLine=-Xmx1g -ea' -DskipTests clean install"
+	if got := command.String(); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+
+	// Module options only apply with -pl
+	command = Command{Goals: []string{"test"}, AlsoMake: true}
+	if got := command.String(); got != "mvn test" {
+		t.Errorf("Expected mvn test, got %s", got)
+	}
+}
+
+func TestCommandExplain(t *testing.T) {
+	command := Command{
+		Goals:      []string{"verify", "dependency:tree"},
+		Profiles:   []string{"ci"},
+		Properties: map[string]string{"skipITs": ""},
+		Offline:    true,
+	}
+	explained := strings.Join(command.Explain(), "\n")
+	for _, expected := range []string{
+		"-Pci: activate these profiles",
+		"-DskipITs: set property skipITs to true",
+		"-o: work offline",
+		"verify: run the lifecycle up to the verify phase",
+		"dependency:tree: run this plugin goal",
+	} {
+		if !strings.Contains(explained, expected) {
+			t.Errorf("Expected explanation to contain %q, got:\n%s", expected, explained)
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := map[string]string{
+		"clean":           "clean",
+		"-Dtest=MyTest#a": "-Dtest=MyTest#a",
+		"-Dname=a b":      "'-Dname=a b'",
+		"it's":            `'it'\''s'`,
+		"":                "''",
+	}
+	for arg, expected := range tests {
+		if got := quote(arg); got != expected {
+			t.Errorf("Expected %s quoted as %s, got %s", arg, expected, got)
+		}
+	}
+}
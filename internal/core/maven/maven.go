@@ -0,0 +1,71 @@
+// Package maven runs a local Maven installation on open and generated
+// projects
+package maven
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+)
+
+// ErrNotFound is returned when no Maven installation can be located
+var ErrNotFound = errors.New("maven not found")
+
+// executable returns the name of the Maven launcher on this platform
+func executable() string {
+	if runtime.GOOS == "windows" {
+		return "mvn.cmd"
+	}
+	return "mvn"
+}
+
+// Find locates the mvn launcher. An explicit path wins; otherwise
+// MAVEN_HOME, M2_HOME and the PATH are searched in that order.
+func Find(path string) (string, error) {
+	if path != "" {
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			return "", fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+		return path, nil
+	}
+
+	for _, env := range []string{"MAVEN_HOME", "M2_HOME"} {
+		home := os.Getenv(env)
+		if home == "" {
+			continue
+		}
+		candidate := filepath.Join(home, "bin", executable())
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+
+	found, err := exec.LookPath(executable())
+	if err != nil {
+		return "", fmt.Errorf("%w: install Maven or set MAVEN_HOME", ErrNotFound)
+	}
+	return found, nil
+}
+
+// Run runs mvn with args in dir and returns its combined output. Batch mode
+// is always on so the output has no colors or progress bars.
+func Run(ctx context.Context, mvn, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, mvn, append([]string{"-B"}, args...)...)
+	cmd.Dir = dir
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return output.Bytes(), fmt.Errorf("running mvn: %w", ctx.Err())
+		}
+		return output.Bytes(), fmt.Errorf("running mvn: %w", err)
+	}
+	return output.Bytes(), nil
+}
@@ -0,0 +1,76 @@
+package maven
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// TemplateResult is the outcome of building one template
+type TemplateResult struct {
+	Template string
+	Duration time.Duration
+	// Output is Maven's output, kept for failed builds
+	Output []byte
+	Err    error
+}
+
+// OK reports whether the template built
+func (r TemplateResult) OK() bool {
+	return r.Err == nil
+}
+
+// VerifyTemplates generates a throwaway project from each named template, or
+// from every template when names is empty, and runs mvn -q verify on it.
+// This catches templates broken by plugin version updates in the catalog.
+// Extra arguments, such as -o for offline builds, are passed to Maven.
+func VerifyTemplates(ctx context.Context, mvn string, tm pom.TemplateManager, names []string, args ...string) []TemplateResult {
+	if len(names) == 0 {
+		for _, info := range tm.List() {
+			names = append(names, info.Name)
+		}
+	}
+
+	results := make([]TemplateResult, 0, len(names))
+	for _, name := range names {
+		start := time.Now()
+		output, err := verifyTemplate(ctx, mvn, tm, name, args)
+		results = append(results, TemplateResult{
+			Template: name,
+			Duration: time.Since(start),
+			Output:   output,
+			Err:      err,
+		})
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return results
+}
+
+// verifyTemplate builds one template in a temporary directory
+func verifyTemplate(ctx context.Context, mvn string, tm pom.TemplateManager, name string, args []string) ([]byte, error) {
+	project, err := tm.Create(name, pom.Coordinates{
+		GroupID:    "com.example.verify",
+		ArtifactID: "verify-" + name,
+		Version:    "1.0.0-SNAPSHOT",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "pom-manager-verify-")
+	if err != nil {
+		return nil, fmt.Errorf("creating project directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := pom.NewGenerator().GenerateToFile(project, filepath.Join(dir, "pom.xml")); err != nil {
+		return nil, err
+	}
+	return Run(ctx, mvn, dir, append([]string{"-q"}, append(args, "verify")...)...)
+}
@@ -0,0 +1,73 @@
+package maven
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+func TestFindExplicitPath(t *testing.T) {
+	_, err := Find(filepath.Join(t.TempDir(), "mvn"))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing launcher, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "mvn")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	found, err := Find(path)
+	if err != nil || found != path {
+		t.Errorf("Expected %s, got %s (%v)", path, found, err)
+	}
+}
+
+func TestVerifyTemplatesRunsMaven(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake launcher is a shell script")
+	}
+	// The fake launcher fails unless it is started next to a pom.xml
+	mvn := filepath.Join(t.TempDir(), "mvn")
+	if err := os.WriteFile(mvn, []byte("#!/bin/sh\ntest -f pom.xml\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	results := VerifyTemplates(context.Background(), mvn, pom.NewTemplateManager(), []string{"basic-java", "no-such-template"})
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if !results[0].OK() {
+		t.Errorf("Expected basic-java to pass, got %v", results[0].Err)
+	}
+	if !errors.Is(results[1].Err, pom.ErrTemplateNotFound) {
+		t.Errorf("Expected ErrTemplateNotFound for an unknown template, got %v", results[1].Err)
+	}
+}
+
+// TestVerifyTemplates builds every template with the local Maven. It needs
+// Maven and network access, so it only runs when mvn is installed and
+// -short is not given.
+func TestVerifyTemplates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping template builds in short mode")
+	}
+	mvn, err := Find("")
+	if err != nil {
+		t.Skip("Maven is not installed")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	for _, result := range VerifyTemplates(ctx, mvn, pom.NewTemplateManager(), nil) {
+		if !result.OK() {
+			t.Errorf("Expected template %s to build: %v\n%s", result.Template, result.Err, result.Output)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package pom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Banner is a generated-by comment before the <project> element, naming
+// the tool that last wrote the POM
+type Banner struct {
+	Tool    string
+	Version string
+	Time    time.Time
+}
+
+// bannerPattern matches the text of a banner comment as written by String
+var bannerPattern = regexp.MustCompile(`^\s*Generated by (.+) (\S+) on (\S+)\s*$`)
+
+// String returns the text of the banner comment
+func (b Banner) String() string {
+	return fmt.Sprintf(" Generated by %s %s on %s ", b.Tool, b.Version, b.Time.UTC().Format(time.RFC3339))
+}
+
+// ParseBanner recognizes the text of a banner comment
+func ParseBanner(comment string) (Banner, bool) {
+	match := bannerPattern.FindStringSubmatch(comment)
+	if match == nil {
+		return Banner{}, false
+	}
+	generated, err := time.Parse(time.RFC3339, match[3])
+	if err != nil {
+		return Banner{}, false
+	}
+	return Banner{Tool: strings.TrimSpace(match[1]), Version: match[2], Time: generated}, true
+}
+
+// bannerDefaults are set by SetBannerTool and SetBannerDefault
+var bannerDefaults struct {
+	sync.RWMutex
+	enabled bool
+	tool    string
+	version string
+}
+
+// SetBannerTool sets the tool and version banners name
+func SetBannerTool(tool, version string) {
+	bannerDefaults.Lock()
+	defer bannerDefaults.Unlock()
+	bannerDefaults.tool = tool
+	bannerDefaults.version = version
+}
+
+// SetBannerDefault sets whether POMs written to files get a banner when
+// their project config does not say
+func SetBannerDefault(enabled bool) {
+	bannerDefaults.Lock()
+	defer bannerDefaults.Unlock()
+	bannerDefaults.enabled = enabled
+}
+
+// BannerFor returns the banner a POM written to pomPath gets now, or nil
+// when banners are off for it. The generator.banner setting of the
+// project's .pom-manager.yaml takes precedence over SetBannerDefault; an
+// invalid config is ignored here and reported by validation.
+func BannerFor(pomPath string) *Banner {
+	bannerDefaults.RLock()
+	enabled, tool, version := bannerDefaults.enabled, bannerDefaults.tool, bannerDefaults.version
+	bannerDefaults.RUnlock()
+
+	if path, ok := FindProjectConfig(pomPath); ok {
+		if config, err := LoadProjectConfig(path); err == nil && config.Generator.Banner != nil {
+			enabled = *config.Generator.Banner
+		}
+	}
+	if !enabled {
+		return nil
+	}
+	if tool == "" {
+		tool = "pom-manager"
+	}
+	if version == "" {
+		version = "dev"
+	}
+	return &Banner{Tool: tool, Version: version, Time: time.Now().UTC().Truncate(time.Second)}
+}
+
+// StampBanner returns a shallow copy of project carrying the banner of a
+// POM written to pomPath: a fresh one when banners are on, none otherwise,
+// since a banner left from an earlier write would be stale
+func StampBanner(project *Project, pomPath string) *Project {
+	stamped := *project
+	stamped.Banner = BannerFor(pomPath)
+	return &stamped
+}
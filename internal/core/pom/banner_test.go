@@ -0,0 +1,90 @@
+package pom
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseBanner(t *testing.T) {
+	generated := time.Date(2026, 10, 15, 7, 8, 18, 0, time.UTC)
+	tests := []struct {
+		name    string
+		comment string
+		want    Banner
+		wantOK  bool
+	}{
+		{"as written", " Generated by pom-manager 0.1.0-MVP on 2026-10-15T07:08:18Z ",
+			Banner{Tool: "pom-manager", Version: "0.1.0-MVP", Time: generated}, true},
+		{"tool with spaces", "Generated by POM Manager GUI 1.2 on 2026-10-15T07:08:18Z",
+			Banner{Tool: "POM Manager GUI", Version: "1.2", Time: generated}, true},
+		{"invalid time", " Generated by pom-manager 1.0 on yesterday ", Banner{}, false},
+		{"other comment", " Licensed under the Apache License ", Banner{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseBanner(tt.comment)
+			if ok != tt.wantOK || got.Tool != tt.want.Tool || got.Version != tt.want.Version || !got.Time.Equal(tt.want.Time) {
+				t.Errorf("Expected %+v %v, got %+v %v", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+
+	banner := Banner{Tool: "pom-manager", Version: "1.0", Time: generated}
+	if got, ok := ParseBanner(banner.String()); !ok || got != banner {
+		t.Errorf("Expected a written banner to parse back, got %+v %v", got, ok)
+	}
+}
+
+func TestBannerRepeatedSaves(t *testing.T) {
+	SetBannerTool("pom-manager", "1.0")
+	SetBannerDefault(true)
+	t.Cleanup(func() {
+		SetBannerTool("", "")
+		SetBannerDefault(false)
+	})
+
+	path := filepath.Join(t.TempDir(), "pom.xml")
+	project := &Project{
+		ModelVersion: DefaultModelVersion,
+		GroupID:      "com.example",
+		ArtifactID:   "demo",
+		Version:      "1.0.0",
+		Coordinates:  Coordinates{GroupID: "com.example", ArtifactID: "demo", Version: "1.0.0"},
+	}
+	generator := NewGenerator()
+	parser := NewParser()
+	for i := 0; i < 3; i++ {
+		if err := generator.GenerateToFile(project, path); err != nil {
+			t.Fatalf("Expected save %d to succeed, got %v", i+1, err)
+		}
+		var err error
+		if project, err = parser.ParseFile(path); err != nil {
+			t.Fatalf("Expected saved POM to parse, got %v", err)
+		}
+	}
+
+	data, _ := os.ReadFile(path)
+	if got := strings.Count(string(data), "Generated by"); got != 1 {
+		t.Errorf("Expected repeated saves to keep a single banner, got %d:\n%s", got, data)
+	}
+	if project.Banner == nil || project.Banner.Tool != "pom-manager" || project.Banner.Version != "1.0" {
+		t.Errorf("Expected the parser to recognize the banner, got %+v", project.Banner)
+	}
+
+	formatted, err := Format(data, FormatOptions{Banner: BannerFor(path)})
+	if err != nil || strings.Count(string(formatted), "Generated by") != 1 {
+		t.Errorf("Expected formatting to replace the banner, got %v:\n%s", err, formatted)
+	}
+
+	SetBannerDefault(false)
+	if err := generator.GenerateToFile(project, path); err != nil {
+		t.Fatalf("Expected save to succeed, got %v", err)
+	}
+	if data, _ := os.ReadFile(path); strings.Contains(string(data), "Generated by") {
+		t.Errorf("Expected a stale banner dropped when banners are off, got:\n%s", data)
+	}
+}
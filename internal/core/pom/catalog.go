@@ -0,0 +1,104 @@
+package pom
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// VersionCatalog is a central list of versions shared by several POMs, in
+// the spirit of Gradle version catalogs. Each entry maps to a POM property:
+// "junit" is kept in <junit.version>.
+type VersionCatalog struct {
+	Versions map[string]string `yaml:"versions" toml:"versions"`
+}
+
+// VersionDrift is a catalog entry whose POM property is missing or differs
+type VersionDrift struct {
+	Property string
+	Catalog  string // Version in the catalog
+	Current  string // Version in the POM ("" when missing)
+	Missing  bool
+}
+
+// LoadVersionCatalog reads a catalog from a YAML (.yaml, .yml) or TOML
+// (.toml) file with a top-level "versions" table
+func LoadVersionCatalog(path string) (*VersionCatalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrFileNotFound, path)
+		}
+		return nil, fmt.Errorf("reading version catalog %s: %w", path, err)
+	}
+
+	var catalog VersionCatalog
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &catalog)
+	case ".toml":
+		err = toml.Unmarshal(data, &catalog)
+	default:
+		return nil, fmt.Errorf("%w: version catalog must be .yaml, .yml, or .toml: %s", ErrInvalidFormat, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: parsing version catalog %s: %v", ErrInvalidFormat, path, err)
+	}
+
+	if catalog.Versions == nil {
+		catalog.Versions = make(map[string]string)
+	}
+	return &catalog, nil
+}
+
+// CatalogProperty returns the POM property holding a catalog entry's version.
+// Keys that already end in "version" are used as-is.
+func CatalogProperty(key string) string {
+	lower := strings.ToLower(key)
+	if strings.HasSuffix(lower, ".version") || strings.HasSuffix(lower, "-version") {
+		return key
+	}
+	return key + ".version"
+}
+
+// CatalogDrift lists catalog entries whose property is missing from the
+// project or holds a different version, sorted by property name
+func CatalogDrift(catalog *VersionCatalog, project *Project) []VersionDrift {
+	var drift []VersionDrift
+	for _, key := range sortedKeys(catalog.Versions) {
+		property := CatalogProperty(key)
+		current, ok := project.Properties[property]
+		if ok && current == catalog.Versions[key] {
+			continue
+		}
+		drift = append(drift, VersionDrift{
+			Property: property,
+			Catalog:  catalog.Versions[key],
+			Current:  current,
+			Missing:  !ok,
+		})
+	}
+	return drift
+}
+
+// SyncCatalog copies catalog versions into the project's properties and
+// returns what changed. Missing properties are only added when addMissing
+// is set, so a shared catalog does not litter every POM with unused entries.
+func SyncCatalog(catalog *VersionCatalog, project *Project, addMissing bool) []VersionDrift {
+	var changed []VersionDrift
+	for _, drift := range CatalogDrift(catalog, project) {
+		if drift.Missing && !addMissing {
+			continue
+		}
+		if project.Properties == nil {
+			project.Properties = make(map[string]string)
+		}
+		project.Properties[drift.Property] = drift.Catalog
+		changed = append(changed, drift)
+	}
+	return changed
+}
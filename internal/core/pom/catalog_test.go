@@ -0,0 +1,96 @@
+package pom
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadVersionCatalog(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"versions.yaml": "versions:\n  junit: 5.11.0\n  slf4j.version: 2.0.16\n",
+		"versions.toml": "[versions]\njunit = \"5.11.0\"\n\"slf4j.version\" = \"2.0.16\"\n",
+	}
+	want := map[string]string{"junit": "5.11.0", "slf4j.version": "2.0.16"}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("Expected catalog to be written, got %v", err)
+			}
+			catalog, err := LoadVersionCatalog(path)
+			if err != nil {
+				t.Fatalf("Expected catalog to load, got %v", err)
+			}
+			if !reflect.DeepEqual(catalog.Versions, want) {
+				t.Errorf("Expected %v, got %v", want, catalog.Versions)
+			}
+		})
+	}
+
+	empty := filepath.Join(dir, "empty.yml")
+	os.WriteFile(empty, []byte("# nothing yet\n"), 0644)
+	if catalog, err := LoadVersionCatalog(empty); err != nil || catalog.Versions == nil {
+		t.Errorf("Expected an empty catalog with a usable map, got %+v, %v", catalog, err)
+	}
+
+	unsupported := filepath.Join(dir, "versions.json")
+	os.WriteFile(unsupported, []byte("{}"), 0644)
+	if _, err := LoadVersionCatalog(unsupported); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected ErrInvalidFormat for a .json catalog, got %v", err)
+	}
+	if _, err := LoadVersionCatalog(filepath.Join(dir, "missing.yaml")); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("Expected ErrFileNotFound, got %v", err)
+	}
+}
+
+func TestCatalogProperty(t *testing.T) {
+	tests := map[string]string{
+		"junit":          "junit.version",
+		"slf4j.version":  "slf4j.version",
+		"spring-Version": "spring-Version",
+		"versions":       "versions.version",
+	}
+	for key, want := range tests {
+		if got := CatalogProperty(key); got != want {
+			t.Errorf("Expected %s for %s, got %s", want, key, got)
+		}
+	}
+}
+
+func TestCatalogDrift(t *testing.T) {
+	catalog := &VersionCatalog{Versions: map[string]string{
+		"junit":   "5.11.0",
+		"slf4j":   "2.0.16",
+		"jackson": "2.17.2",
+	}}
+	project := &Project{Properties: map[string]string{
+		"junit.version": "5.9.0",
+		"slf4j.version": "2.0.16",
+	}}
+
+	want := []VersionDrift{
+		{Property: "jackson.version", Catalog: "2.17.2", Missing: true},
+		{Property: "junit.version", Catalog: "5.11.0", Current: "5.9.0"},
+	}
+	if got := CatalogDrift(catalog, project); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected drift %+v, got %+v", want, got)
+	}
+
+	changed := SyncCatalog(catalog, project, false)
+	if len(changed) != 1 || project.Properties["junit.version"] != "5.11.0" {
+		t.Errorf("Expected only the differing property synced, got %+v", changed)
+	}
+	if _, ok := project.Properties["jackson.version"]; ok {
+		t.Error("Expected a missing property not to be added without addMissing")
+	}
+
+	SyncCatalog(catalog, project, true)
+	if got := CatalogDrift(catalog, project); len(got) != 0 {
+		t.Errorf("Expected no drift after syncing missing properties, got %+v", got)
+	}
+}
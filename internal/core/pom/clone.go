@@ -0,0 +1,146 @@
+package pom
+
+// Clone returns a deep copy of the project so it can be modified without
+// affecting the original
+func (p *Project) Clone() *Project {
+	if p == nil {
+		return nil
+	}
+
+	clone := *p
+	clone.Properties = cloneStringMap(p.Properties)
+	if p.PropertiesXML != nil {
+		clone.PropertiesXML = &Properties{Entries: cloneStringMap(p.PropertiesXML.Entries)}
+	}
+	clone.Dependencies = cloneDependencies(p.Dependencies)
+	clone.DependencyManagement = cloneDependencies(p.DependencyManagement)
+	clone.Build = p.Build.clone()
+	clone.Modules = cloneStrings(p.Modules)
+	if p.Parent != nil {
+		parent := *p.Parent
+		clone.Parent = &parent
+	}
+
+	if p.Profiles != nil {
+		clone.Profiles = make([]Profile, len(p.Profiles))
+		for i, profile := range p.Profiles {
+			clone.Profiles[i] = profile.clone()
+		}
+	}
+
+	return &clone
+}
+
+// clone returns a deep copy of the profile
+func (p Profile) clone() Profile {
+	clone := p
+	if p.Activation != nil {
+		activation := *p.Activation
+		if p.Activation.Property != nil {
+			property := *p.Activation.Property
+			activation.Property = &property
+		}
+		if p.Activation.OS != nil {
+			os := *p.Activation.OS
+			activation.OS = &os
+		}
+		if p.Activation.File != nil {
+			file := *p.Activation.File
+			activation.File = &file
+		}
+		clone.Activation = &activation
+	}
+	clone.Properties = cloneStringMap(p.Properties)
+	if p.PropertiesXML != nil {
+		clone.PropertiesXML = &Properties{Entries: cloneStringMap(p.PropertiesXML.Entries)}
+	}
+	clone.Dependencies = cloneDependencies(p.Dependencies)
+	clone.Build = p.Build.clone()
+	clone.Modules = cloneStrings(p.Modules)
+	return clone
+}
+
+// clone returns a deep copy of the build section
+func (b *Build) clone() *Build {
+	if b == nil {
+		return nil
+	}
+
+	clone := *b
+	if b.Plugins != nil {
+		clone.Plugins = make([]Plugin, len(b.Plugins))
+		for i, plugin := range b.Plugins {
+			clone.Plugins[i] = plugin.clone()
+		}
+	}
+	return &clone
+}
+
+// clone returns a deep copy of the plugin
+func (p Plugin) clone() Plugin {
+	clone := p
+	clone.Configuration = p.Configuration.clone()
+	if p.Executions != nil {
+		clone.Executions = make([]PluginExecution, len(p.Executions))
+		for i, exec := range p.Executions {
+			execClone := exec
+			execClone.Goals = cloneStrings(exec.Goals)
+			execClone.Configuration = exec.Configuration.clone()
+			clone.Executions[i] = execClone
+		}
+	}
+	return clone
+}
+
+// clone returns a copy of the configuration (nested values are shared)
+func (c *Configuration) clone() *Configuration {
+	if c == nil {
+		return nil
+	}
+
+	clone := &Configuration{}
+	if c.Data != nil {
+		clone.Data = make(map[string]interface{}, len(c.Data))
+		for key, value := range c.Data {
+			clone.Data[key] = value
+		}
+	}
+	return clone
+}
+
+// cloneDependencies returns a deep copy of a dependency slice
+func cloneDependencies(deps []Dependency) []Dependency {
+	if deps == nil {
+		return nil
+	}
+
+	clone := make([]Dependency, len(deps))
+	for i, dep := range deps {
+		clone[i] = dep
+		if dep.Exclusions != nil {
+			clone[i].Exclusions = append([]Exclusion(nil), dep.Exclusions...)
+		}
+	}
+	return clone
+}
+
+// cloneStringMap returns a copy of a string map
+func cloneStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+
+	clone := make(map[string]string, len(m))
+	for key, value := range m {
+		clone[key] = value
+	}
+	return clone
+}
+
+// cloneStrings returns a copy of a string slice
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string(nil), s...)
+}
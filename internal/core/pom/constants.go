@@ -16,12 +16,25 @@
 //     fmt.Println(project.Coordinates.String())
 package pom
 
+import "slices"
+
 // Maven POM model version
 const (
 	DefaultModelVersion = "4.0.0"
 )
 
-// Maven lifecycle phases in execution order
+// DefaultPluginGroupID is the groupId Maven assumes for plugins declared
+// without one
+const DefaultPluginGroupID = "org.apache.maven.plugins"
+
+// Phases of the clean lifecycle in execution order
+const (
+	PhasePreClean  = "pre-clean"
+	PhaseClean     = "clean"
+	PhasePostClean = "post-clean"
+)
+
+// Phases of the default lifecycle in execution order
 const (
 	PhaseValidate            = "validate"
 	PhaseInitialize          = "initialize"
@@ -48,8 +61,30 @@ const (
 	PhaseDeploy              = "deploy"
 )
 
-// MavenLifecyclePhases returns all Maven lifecycle phases in execution order
-var MavenLifecyclePhases = []string{
+// Phases of the site lifecycle in execution order
+const (
+	PhasePreSite    = "pre-site"
+	PhaseSite       = "site"
+	PhasePostSite   = "post-site"
+	PhaseSiteDeploy = "site-deploy"
+)
+
+// Maven's lifecycles
+const (
+	LifecycleClean   = "clean"
+	LifecycleDefault = "default"
+	LifecycleSite    = "site"
+)
+
+// CleanLifecyclePhases lists the phases of the clean lifecycle in execution order
+var CleanLifecyclePhases = []string{
+	PhasePreClean,
+	PhaseClean,
+	PhasePostClean,
+}
+
+// DefaultLifecyclePhases lists the phases of the default lifecycle in execution order
+var DefaultLifecyclePhases = []string{
 	PhaseValidate,
 	PhaseInitialize,
 	PhaseGenerateSources,
@@ -75,6 +110,31 @@ var MavenLifecyclePhases = []string{
 	PhaseDeploy,
 }
 
+// SiteLifecyclePhases lists the phases of the site lifecycle in execution order
+var SiteLifecyclePhases = []string{
+	PhasePreSite,
+	PhaseSite,
+	PhasePostSite,
+	PhaseSiteDeploy,
+}
+
+// MavenLifecyclePhases returns all Maven lifecycle phases: the clean, default
+// and site lifecycles, each in execution order, as in "mvn clean install site"
+var MavenLifecyclePhases = slices.Concat(CleanLifecyclePhases, DefaultLifecyclePhases, SiteLifecyclePhases)
+
+// LifecycleOf returns the lifecycle a phase belongs to, "" for unknown phases
+func LifecycleOf(phase string) string {
+	switch {
+	case slices.Contains(CleanLifecyclePhases, phase):
+		return LifecycleClean
+	case slices.Contains(DefaultLifecyclePhases, phase):
+		return LifecycleDefault
+	case slices.Contains(SiteLifecyclePhases, phase):
+		return LifecycleSite
+	}
+	return ""
+}
+
 // Maven dependency scopes
 const (
 	ScopeCompile  = "compile"
@@ -128,8 +188,15 @@ const (
 	MaxFileSizeBytes = 10 * 1024 * 1024 // 10MB
 )
 
+// Parent resolution
+const (
+	DefaultRelativePath = "../pom.xml"
+	MaxParentDepth      = 16 // Guards against runaway parent chains
+)
+
 // Default values
 const (
-	DefaultPackaging = PackagingJar
-	DefaultScope     = ScopeCompile
+	DefaultPackaging      = PackagingJar
+	DefaultScope          = ScopeCompile
+	DefaultDependencyType = "jar"
 )
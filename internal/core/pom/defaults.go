@@ -0,0 +1,74 @@
+package pom
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// pinnedVersions are the versions offered for well-known plugins and
+// dependencies, keyed by groupId:artifactId. They are used until a catalog
+// refresh supplies newer ones, and whenever the refreshed data is missing.
+var pinnedVersions = map[string]string{
+	DefaultPluginGroupID + ":maven-compiler-plugin": "3.11.0",
+	DefaultPluginGroupID + ":maven-jar-plugin":      "3.3.0",
+	DefaultPluginGroupID + ":maven-war-plugin":      "3.4.0",
+	DefaultPluginGroupID + ":maven-surefire-plugin": "3.1.2",
+	DefaultPluginGroupID + ":maven-assembly-plugin": "3.6.0",
+	"org.codehaus.mojo:exec-maven-plugin":           "3.1.0",
+	"junit:junit":                                   "4.13.2",
+	"javax.servlet:javax.servlet-api":               "4.0.1",
+	springBootParent:                                "3.3.5",
+	quarkusBOM:                                      "3.15.1",
+	kotlinStdlib:                                    "2.0.21",
+}
+
+var (
+	latestMu       sync.RWMutex
+	latestVersions map[string]string
+)
+
+// DefaultVersion returns the version to offer for a well-known plugin or
+// dependency: the refreshed one when known, else the pinned one, else ""
+func DefaultVersion(groupID, artifactID string) string {
+	key := groupID + ":" + artifactID
+
+	latestMu.RLock()
+	version := latestVersions[key]
+	latestMu.RUnlock()
+
+	if version != "" {
+		return version
+	}
+	return pinnedVersions[key]
+}
+
+// DefaultVersionKeys returns the groupId:artifactId of every artifact with
+// a default version, sorted
+func DefaultVersionKeys() []string {
+	keys := make([]string, 0, len(pinnedVersions))
+	for key := range pinnedVersions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SplitVersionKey splits a groupId:artifactId key
+func SplitVersionKey(key string) (groupID, artifactID string) {
+	groupID, artifactID, _ = strings.Cut(key, ":")
+	return groupID, artifactID
+}
+
+// SetLatestVersions replaces the refreshed versions, keyed by
+// groupId:artifactId; nil restores the pinned versions
+func SetLatestVersions(versions map[string]string) {
+	latest := make(map[string]string, len(versions))
+	for key, version := range versions {
+		latest[key] = version
+	}
+
+	latestMu.Lock()
+	latestVersions = latest
+	latestMu.Unlock()
+}
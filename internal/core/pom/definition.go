@@ -0,0 +1,426 @@
+package pom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats of project definitions
+const (
+	DefinitionFormatJSON = "json"
+	DefinitionFormatYAML = "yaml"
+)
+
+// DefinitionFormats lists the supported project definition formats
+var DefinitionFormats = []string{DefinitionFormatJSON, DefinitionFormatYAML}
+
+// ProjectDefinition is a project as structured data, for generating POMs
+// from scripts and pipelines. Keys follow the POM element names.
+type ProjectDefinition struct {
+	Parent               *ParentDefinition      `json:"parent,omitempty" yaml:"parent,omitempty"`
+	GroupID              string                 `json:"groupId,omitempty" yaml:"groupId,omitempty"`
+	ArtifactID           string                 `json:"artifactId" yaml:"artifactId"`
+	Version              string                 `json:"version,omitempty" yaml:"version,omitempty"`
+	Packaging            string                 `json:"packaging,omitempty" yaml:"packaging,omitempty"`
+	Name                 string                 `json:"name,omitempty" yaml:"name,omitempty"`
+	Description          string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	Modules              []string               `json:"modules,omitempty" yaml:"modules,omitempty"`
+	Properties           map[string]string      `json:"properties,omitempty" yaml:"properties,omitempty"`
+	DependencyManagement []DependencyDefinition `json:"dependencyManagement,omitempty" yaml:"dependencyManagement,omitempty"`
+	Dependencies         []DependencyDefinition `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	Build                *BuildDefinition       `json:"build,omitempty" yaml:"build,omitempty"`
+	Profiles             []ProfileDefinition    `json:"profiles,omitempty" yaml:"profiles,omitempty"`
+}
+
+// ParentDefinition is the parent POM of a project definition
+type ParentDefinition struct {
+	GroupID      string `json:"groupId" yaml:"groupId"`
+	ArtifactID   string `json:"artifactId" yaml:"artifactId"`
+	Version      string `json:"version" yaml:"version"`
+	RelativePath string `json:"relativePath,omitempty" yaml:"relativePath,omitempty"`
+}
+
+// DependencyDefinition is a dependency of a project definition
+type DependencyDefinition struct {
+	GroupID    string                `json:"groupId" yaml:"groupId"`
+	ArtifactID string                `json:"artifactId" yaml:"artifactId"`
+	Version    string                `json:"version,omitempty" yaml:"version,omitempty"`
+	Type       string                `json:"type,omitempty" yaml:"type,omitempty"`
+	Classifier string                `json:"classifier,omitempty" yaml:"classifier,omitempty"`
+	Scope      string                `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Optional   bool                  `json:"optional,omitempty" yaml:"optional,omitempty"`
+	SystemPath string                `json:"systemPath,omitempty" yaml:"systemPath,omitempty"`
+	Exclusions []ExclusionDefinition `json:"exclusions,omitempty" yaml:"exclusions,omitempty"`
+}
+
+// ExclusionDefinition is an excluded transitive dependency
+type ExclusionDefinition struct {
+	GroupID    string `json:"groupId" yaml:"groupId"`
+	ArtifactID string `json:"artifactId" yaml:"artifactId"`
+}
+
+// BuildDefinition is the build section of a project definition
+type BuildDefinition struct {
+	SourceDirectory     string             `json:"sourceDirectory,omitempty" yaml:"sourceDirectory,omitempty"`
+	TestSourceDirectory string             `json:"testSourceDirectory,omitempty" yaml:"testSourceDirectory,omitempty"`
+	OutputDirectory     string             `json:"outputDirectory,omitempty" yaml:"outputDirectory,omitempty"`
+	Plugins             []PluginDefinition `json:"plugins,omitempty" yaml:"plugins,omitempty"`
+}
+
+// PluginDefinition is a build plugin of a project definition
+type PluginDefinition struct {
+	GroupID       string                 `json:"groupId,omitempty" yaml:"groupId,omitempty"`
+	ArtifactID    string                 `json:"artifactId" yaml:"artifactId"`
+	Version       string                 `json:"version,omitempty" yaml:"version,omitempty"`
+	Configuration map[string]interface{} `json:"configuration,omitempty" yaml:"configuration,omitempty"`
+	Executions    []ExecutionDefinition  `json:"executions,omitempty" yaml:"executions,omitempty"`
+}
+
+// ExecutionDefinition is a plugin execution of a project definition
+type ExecutionDefinition struct {
+	ID            string                 `json:"id,omitempty" yaml:"id,omitempty"`
+	Phase         string                 `json:"phase,omitempty" yaml:"phase,omitempty"`
+	Goals         []string               `json:"goals,omitempty" yaml:"goals,omitempty"`
+	Configuration map[string]interface{} `json:"configuration,omitempty" yaml:"configuration,omitempty"`
+}
+
+// ProfileDefinition is a build profile of a project definition
+type ProfileDefinition struct {
+	ID           string                 `json:"id" yaml:"id"`
+	Activation   *ActivationDefinition  `json:"activation,omitempty" yaml:"activation,omitempty"`
+	Modules      []string               `json:"modules,omitempty" yaml:"modules,omitempty"`
+	Properties   map[string]string      `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Dependencies []DependencyDefinition `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	Build        *BuildDefinition       `json:"build,omitempty" yaml:"build,omitempty"`
+}
+
+// ActivationDefinition is the activation of a profile definition
+type ActivationDefinition struct {
+	ActiveByDefault bool   `json:"activeByDefault,omitempty" yaml:"activeByDefault,omitempty"`
+	JDK             string `json:"jdk,omitempty" yaml:"jdk,omitempty"`
+	Property        string `json:"property,omitempty" yaml:"property,omitempty"`           // Property name, or !name when it must be absent
+	PropertyValue   string `json:"propertyValue,omitempty" yaml:"propertyValue,omitempty"` // Value the property must have
+	OSFamily        string `json:"osFamily,omitempty" yaml:"osFamily,omitempty"`
+	OSName          string `json:"osName,omitempty" yaml:"osName,omitempty"`
+	OSArch          string `json:"osArch,omitempty" yaml:"osArch,omitempty"`
+	OSVersion       string `json:"osVersion,omitempty" yaml:"osVersion,omitempty"`
+	FileExists      string `json:"fileExists,omitempty" yaml:"fileExists,omitempty"`
+	FileMissing     string `json:"fileMissing,omitempty" yaml:"fileMissing,omitempty"`
+}
+
+// DetectDefinitionFormat tells the format of a project definition from its
+// file name: *.json is JSON, *.yaml and *.yml are YAML. It returns "" for
+// other files.
+func DetectDefinitionFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return DefinitionFormatJSON
+	case ".yaml", ".yml":
+		return DefinitionFormatYAML
+	}
+	return ""
+}
+
+// ParseProjectDefinition reads a project definition in the given format.
+// Unknown keys are rejected so typos don't silently drop settings.
+func ParseProjectDefinition(data []byte, format string) (*ProjectDefinition, error) {
+	var definition ProjectDefinition
+	switch format {
+	case DefinitionFormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&definition); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+		}
+	case DefinitionFormatYAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&definition); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown definition format %q (expected one of: %s)",
+			ErrInvalidFormat, format, strings.Join(DefinitionFormats, ", "))
+	}
+	return &definition, nil
+}
+
+// Marshal returns the definition in the given format
+func (d *ProjectDefinition) Marshal(format string) ([]byte, error) {
+	switch format {
+	case DefinitionFormatJSON:
+		data, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case DefinitionFormatYAML:
+		return yaml.Marshal(d)
+	}
+	return nil, fmt.Errorf("%w: unknown definition format %q (expected one of: %s)",
+		ErrInvalidFormat, format, strings.Join(DefinitionFormats, ", "))
+}
+
+// NewProjectDefinition creates the definition of a project. Coordinates
+// inherited from the parent are left out, as in the POM.
+func NewProjectDefinition(project *Project) *ProjectDefinition {
+	d := &ProjectDefinition{
+		GroupID:              project.GroupID,
+		ArtifactID:           project.ArtifactID,
+		Version:              project.Version,
+		Packaging:            project.Packaging,
+		Name:                 project.Name,
+		Description:          project.Description,
+		Modules:              project.Modules,
+		Properties:           project.Properties,
+		DependencyManagement: dependencyDefinitions(project.DependencyManagement),
+		Dependencies:         dependencyDefinitions(project.Dependencies),
+		Build:                buildDefinition(project.Build),
+	}
+	if d.Packaging == DefaultPackaging {
+		d.Packaging = ""
+	}
+	if project.Parent != nil {
+		d.Parent = &ParentDefinition{
+			GroupID:      project.Parent.GroupID,
+			ArtifactID:   project.Parent.ArtifactID,
+			Version:      project.Parent.Version,
+			RelativePath: project.Parent.RelativePath,
+		}
+		if project.InheritsGroupID && project.Parent.GroupID == project.GroupID {
+			d.GroupID = ""
+		}
+		if project.InheritsVersion && project.Parent.Version == project.Version {
+			d.Version = ""
+		}
+	}
+
+	for _, profile := range project.Profiles {
+		pd := ProfileDefinition{
+			ID:           profile.ID,
+			Modules:      profile.Modules,
+			Properties:   profile.Properties,
+			Dependencies: dependencyDefinitions(profile.Dependencies),
+			Build:        buildDefinition(profile.Build),
+		}
+		if a := profile.Activation; a != nil {
+			pd.Activation = &ActivationDefinition{ActiveByDefault: a.ActiveByDefault, JDK: a.JDK}
+			if a.Property != nil {
+				pd.Activation.Property = a.Property.Name
+				pd.Activation.PropertyValue = a.Property.Value
+			}
+			if a.OS != nil {
+				pd.Activation.OSFamily = a.OS.Family
+				pd.Activation.OSName = a.OS.Name
+				pd.Activation.OSArch = a.OS.Arch
+				pd.Activation.OSVersion = a.OS.Version
+			}
+			if a.File != nil {
+				pd.Activation.FileExists = a.File.Exists
+				pd.Activation.FileMissing = a.File.Missing
+			}
+		}
+		d.Profiles = append(d.Profiles, pd)
+	}
+	return d
+}
+
+// Project creates the project described by the definition. The groupId and
+// version may be left out when a parent supplies them. Fails with
+// ErrMissingRequired when coordinates are missing.
+func (d *ProjectDefinition) Project() (*Project, error) {
+	project := &Project{
+		XMLNS:                MavenXMLNamespace,
+		XSI:                  "http://www.w3.org/2001/XMLSchema-instance",
+		SchemaLocation:       MavenXMLSchemaLocation,
+		ModelVersion:         DefaultModelVersion,
+		GroupID:              d.GroupID,
+		ArtifactID:           d.ArtifactID,
+		Version:              d.Version,
+		Packaging:            d.Packaging,
+		Name:                 d.Name,
+		Description:          d.Description,
+		Modules:              d.Modules,
+		Properties:           make(map[string]string),
+		DependencyManagement: projectDependencies(d.DependencyManagement),
+		Dependencies:         projectDependencies(d.Dependencies),
+		Build:                projectBuild(d.Build),
+	}
+	if project.Packaging == "" {
+		project.Packaging = DefaultPackaging
+	}
+	for key, value := range d.Properties {
+		project.Properties[key] = value
+	}
+
+	if d.Parent != nil {
+		project.Parent = &Parent{
+			GroupID:      d.Parent.GroupID,
+			ArtifactID:   d.Parent.ArtifactID,
+			Version:      d.Parent.Version,
+			RelativePath: d.Parent.RelativePath,
+		}
+		if project.GroupID == "" {
+			project.GroupID = d.Parent.GroupID
+			project.InheritsGroupID = true
+		}
+		if project.Version == "" {
+			project.Version = d.Parent.Version
+			project.InheritsVersion = true
+		}
+	}
+
+	var missing []string
+	for _, field := range []struct{ name, value string }{
+		{"groupId", project.GroupID},
+		{"artifactId", project.ArtifactID},
+		{"version", project.Version},
+	} {
+		if field.value == "" {
+			missing = append(missing, field.name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMissingRequired, strings.Join(missing, ", "))
+	}
+	project.Coordinates = Coordinates{GroupID: project.GroupID, ArtifactID: project.ArtifactID, Version: project.Version}
+
+	for _, pd := range d.Profiles {
+		profile := Profile{
+			ID:           pd.ID,
+			Modules:      pd.Modules,
+			Properties:   pd.Properties,
+			Dependencies: projectDependencies(pd.Dependencies),
+			Build:        projectBuild(pd.Build),
+		}
+		if a := pd.Activation; a != nil {
+			profile.Activation = &Activation{ActiveByDefault: a.ActiveByDefault, JDK: a.JDK}
+			if a.Property != "" {
+				profile.Activation.Property = &ActivationProperty{Name: a.Property, Value: a.PropertyValue}
+			}
+			if a.OSFamily != "" || a.OSName != "" || a.OSArch != "" || a.OSVersion != "" {
+				profile.Activation.OS = &ActivationOS{Family: a.OSFamily, Name: a.OSName, Arch: a.OSArch, Version: a.OSVersion}
+			}
+			if a.FileExists != "" || a.FileMissing != "" {
+				profile.Activation.File = &ActivationFile{Exists: a.FileExists, Missing: a.FileMissing}
+			}
+		}
+		project.Profiles = append(project.Profiles, profile)
+	}
+	return project, nil
+}
+
+// dependencyDefinitions converts dependencies for a definition
+func dependencyDefinitions(deps []Dependency) []DependencyDefinition {
+	var out []DependencyDefinition
+	for _, dep := range deps {
+		dd := DependencyDefinition{
+			GroupID:    dep.GroupID,
+			ArtifactID: dep.ArtifactID,
+			Version:    dep.Version,
+			Type:       dep.Type,
+			Classifier: dep.Classifier,
+			Scope:      dep.Scope,
+			Optional:   dep.Optional,
+			SystemPath: dep.SystemPath,
+		}
+		for _, excl := range dep.Exclusions {
+			dd.Exclusions = append(dd.Exclusions, ExclusionDefinition{GroupID: excl.GroupID, ArtifactID: excl.ArtifactID})
+		}
+		out = append(out, dd)
+	}
+	return out
+}
+
+// projectDependencies converts the dependencies of a definition
+func projectDependencies(deps []DependencyDefinition) []Dependency {
+	var out []Dependency
+	for _, dd := range deps {
+		dep := Dependency{
+			GroupID:    dd.GroupID,
+			ArtifactID: dd.ArtifactID,
+			Version:    dd.Version,
+			Type:       dd.Type,
+			Classifier: dd.Classifier,
+			Scope:      dd.Scope,
+			Optional:   dd.Optional,
+			SystemPath: dd.SystemPath,
+		}
+		for _, excl := range dd.Exclusions {
+			dep.Exclusions = append(dep.Exclusions, Exclusion{GroupID: excl.GroupID, ArtifactID: excl.ArtifactID})
+		}
+		out = append(out, dep)
+	}
+	return out
+}
+
+// buildDefinition converts a build section for a definition
+func buildDefinition(build *Build) *BuildDefinition {
+	if build == nil {
+		return nil
+	}
+	bd := &BuildDefinition{
+		SourceDirectory:     build.SourceDirectory,
+		TestSourceDirectory: build.TestSourceDirectory,
+		OutputDirectory:     build.OutputDirectory,
+	}
+	for _, plugin := range build.Plugins {
+		pd := PluginDefinition{
+			GroupID:    plugin.GroupID,
+			ArtifactID: plugin.ArtifactID,
+			Version:    plugin.Version,
+		}
+		if plugin.Configuration != nil {
+			pd.Configuration = plugin.Configuration.Data
+		}
+		for _, exec := range plugin.Executions {
+			ed := ExecutionDefinition{ID: exec.ID, Phase: exec.Phase, Goals: exec.Goals}
+			if exec.Configuration != nil {
+				ed.Configuration = exec.Configuration.Data
+			}
+			pd.Executions = append(pd.Executions, ed)
+		}
+		bd.Plugins = append(bd.Plugins, pd)
+	}
+	return bd
+}
+
+// projectBuild converts the build section of a definition. Plugins without
+// a groupId get the default org.apache.maven.plugins.
+func projectBuild(bd *BuildDefinition) *Build {
+	if bd == nil {
+		return nil
+	}
+	build := &Build{
+		SourceDirectory:     bd.SourceDirectory,
+		TestSourceDirectory: bd.TestSourceDirectory,
+		OutputDirectory:     bd.OutputDirectory,
+	}
+	for _, pd := range bd.Plugins {
+		plugin := Plugin{
+			GroupID:    pd.GroupID,
+			ArtifactID: pd.ArtifactID,
+			Version:    pd.Version,
+		}
+		if plugin.GroupID == "" {
+			plugin.GroupID = DefaultPluginGroupID
+		}
+		if pd.Configuration != nil {
+			plugin.Configuration = &Configuration{Data: pd.Configuration}
+		}
+		for _, ed := range pd.Executions {
+			exec := PluginExecution{ID: ed.ID, Phase: ed.Phase, Goals: ed.Goals}
+			if ed.Configuration != nil {
+				exec.Configuration = &Configuration{Data: ed.Configuration}
+			}
+			plugin.Executions = append(plugin.Executions, exec)
+		}
+		build.Plugins = append(build.Plugins, plugin)
+	}
+	return build
+}
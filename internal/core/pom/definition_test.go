@@ -0,0 +1,123 @@
+package pom
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const definitionTestYAML = `parent:
+  groupId: com.example
+  artifactId: parent
+  version: 1.0.0
+artifactId: demo
+name: Demo
+properties:
+  java.version: "17"
+dependencies:
+  - groupId: org.slf4j
+    artifactId: slf4j-api
+    version: 2.0.16
+  - groupId: com.example
+    artifactId: lib
+    version: "1.0"
+    type: test-jar
+    classifier: tests
+    scope: test
+    exclusions:
+      - groupId: commons-logging
+        artifactId: commons-logging
+build:
+  plugins:
+    - groupId: org.apache.maven.plugins
+      artifactId: maven-compiler-plugin
+      version: 3.13.0
+      configuration:
+        release: ${java.version}
+      executions:
+        - id: compile
+          phase: compile
+          goals: [compile]
+profiles:
+  - id: ci
+    activation:
+      property: env.CI
+    properties:
+      skipTests: "false"
+`
+
+func TestParseProjectDefinitionRoundTrip(t *testing.T) {
+	definition, err := ParseProjectDefinition([]byte(definitionTestYAML), DefinitionFormatYAML)
+	if err != nil {
+		t.Fatalf("Expected definition to parse, got %v", err)
+	}
+	project, err := definition.Project()
+	if err != nil {
+		t.Fatalf("Expected a project, got %v", err)
+	}
+	if project.GroupID != "com.example" || !project.InheritsGroupID || project.Version != "1.0.0" || !project.InheritsVersion {
+		t.Errorf("Expected groupId and version inherited from the parent, got %+v", project.Coordinates)
+	}
+	if project.Packaging != DefaultPackaging {
+		t.Errorf("Expected the default packaging, got %s", project.Packaging)
+	}
+
+	for _, format := range DefinitionFormats {
+		t.Run(format, func(t *testing.T) {
+			data, err := NewProjectDefinition(project).Marshal(format)
+			if err != nil {
+				t.Fatalf("Expected definition to marshal, got %v", err)
+			}
+			again, err := ParseProjectDefinition(data, format)
+			if err != nil {
+				t.Fatalf("Expected marshalled definition to parse, got %v\n%s", err, data)
+			}
+			if !reflect.DeepEqual(again, definition) {
+				t.Errorf("Expected the definition to survive a round trip, got:\n%s", data)
+			}
+		})
+	}
+}
+
+func TestParseProjectDefinitionErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format string
+	}{
+		{"unknown JSON key", `{"artifactId": "demo", "dependancies": []}`, DefinitionFormatJSON},
+		{"unknown YAML key", "artifactId: demo\ndependancies: []\n", DefinitionFormatYAML},
+		{"invalid JSON", `{"artifactId": `, DefinitionFormatJSON},
+		{"unknown format", "artifactId = demo", "toml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseProjectDefinition([]byte(tt.data), tt.format); !errors.Is(err, ErrInvalidFormat) {
+				t.Errorf("Expected ErrInvalidFormat, got %v", err)
+			}
+		})
+	}
+
+	definition, err := ParseProjectDefinition([]byte(`{"artifactId": "demo"}`), DefinitionFormatJSON)
+	if err != nil {
+		t.Fatalf("Expected definition to parse, got %v", err)
+	}
+	if _, err := definition.Project(); !errors.Is(err, ErrMissingRequired) {
+		t.Errorf("Expected ErrMissingRequired without groupId and version, got %v", err)
+	}
+}
+
+func TestDetectDefinitionFormat(t *testing.T) {
+	tests := map[string]string{
+		"project.json": DefinitionFormatJSON,
+		"project.YAML": DefinitionFormatYAML,
+		"project.yml":  DefinitionFormatYAML,
+		"pom.xml":      "",
+	}
+	for path, want := range tests {
+		if got := DetectDefinitionFormat(path); got != want {
+			t.Errorf("Expected %q for %s, got %q", want, path, got)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package pom
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Formats understood by ImportDependencies
+const (
+	ImportFormatIvy    = "ivy"
+	ImportFormatSBT    = "sbt"
+	ImportFormatGradle = "gradle"
+)
+
+// ImportFormats lists the supported dependency import formats
+var ImportFormats = []string{ImportFormatIvy, ImportFormatSBT, ImportFormatGradle}
+
+// DependencyImport is the result of reading dependencies declared for
+// another build tool
+type DependencyImport struct {
+	Dependencies []Dependency
+	Warnings     []string // Entries that were skipped or mapped approximately
+}
+
+// DetectImportFormat guesses the format of a build file from its name:
+// ivy*.xml is Ivy, *.sbt is SBT, *.gradle and *.gradle.kts are Gradle
+func DetectImportFormat(path string) (string, error) {
+	name := strings.ToLower(filepath.Base(path))
+	switch {
+	case strings.HasSuffix(name, ".sbt"):
+		return ImportFormatSBT, nil
+	case strings.HasSuffix(name, ".gradle"), strings.HasSuffix(name, ".gradle.kts"):
+		return ImportFormatGradle, nil
+	case strings.HasPrefix(name, "ivy") && strings.HasSuffix(name, ".xml"):
+		return ImportFormatIvy, nil
+	}
+	return "", fmt.Errorf("%w: cannot tell the format of %s (expected ivy.xml, *.sbt or *.gradle[.kts])", ErrInvalidFormat, path)
+}
+
+// ImportDependencies reads the dependencies of a build file in the given
+// format (see ImportFormats)
+func ImportDependencies(path, format string) (*DependencyImport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrFileNotFound, path)
+		}
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	switch format {
+	case ImportFormatIvy:
+		return ParseIvyDependencies(data)
+	case ImportFormatSBT:
+		return ParseSBTDependencies(data)
+	case ImportFormatGradle:
+		return ParseGradleDependencies(data)
+	}
+	return nil, fmt.Errorf("%w: unknown import format %q (expected one of: %s)",
+		ErrInvalidFormat, format, strings.Join(ImportFormats, ", "))
+}
+
+// MergeDependencies adds the dependencies to the project, replacing existing
+// declarations with the same Key, and returns the keys of the added and
+// updated ones. Artifacts differing only in classifier or type, such as a
+// test-jar next to the main jar, are kept apart.
+func MergeDependencies(project *Project, deps []Dependency) (added, updated []string) {
+	return MergeDependenciesAt(project, deps, InsertAtEnd)
+}
+
+// MergeDependenciesAt is MergeDependencies inserting new dependencies at
+// position (see InsertDependency); InsertAfterEntry inserts at the end
+func MergeDependenciesAt(project *Project, deps []Dependency, position string) (added, updated []string) {
+	for _, dep := range deps {
+		key := dep.Key()
+		replaced := false
+		for i, existing := range project.Dependencies {
+			if existing.Key() == key {
+				project.Dependencies[i] = dep
+				replaced = true
+				break
+			}
+		}
+		if replaced {
+			updated = append(updated, key)
+			continue
+		}
+		project.Dependencies = InsertDependency(project.Dependencies, dep, position, -1)
+		added = append(added, key)
+	}
+	return added, updated
+}
+
+// configurationScopes maps Ivy and SBT configuration names to Maven scopes
+var configurationScopes = map[string]string{
+	"compile":         ScopeCompile,
+	"default":         ScopeCompile,
+	"master":          ScopeCompile,
+	"runtime":         ScopeRuntime,
+	"provided":        ScopeProvided,
+	"test":            ScopeTest,
+	"it":              ScopeTest,
+	"integrationtest": ScopeTest,
+	"system":          ScopeSystem,
+}
+
+// configurationScope maps a configuration name to a Maven scope; ok is false
+// for configurations Maven has no equivalent for
+func configurationScope(configuration string) (scope string, ok bool) {
+	scope, ok = configurationScopes[strings.ToLower(strings.TrimSpace(configuration))]
+	return scope, ok
+}
+
+// stripComments removes // line comments and /* */ block comments outside
+// string literals, which are delimited by any of quotes
+func stripComments(text, quotes string) string {
+	var out strings.Builder
+	var quote byte // Delimiter of the string literal being read, if any
+	lineComment, blockComment := false, false
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		next := byte(0)
+		if i+1 < len(text) {
+			next = text[i+1]
+		}
+
+		switch {
+		case lineComment:
+			if c == '\n' {
+				lineComment = false
+				out.WriteByte(c)
+			}
+		case blockComment:
+			if c == '*' && next == '/' {
+				blockComment = false
+				i++
+			}
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+			out.WriteByte(c)
+		case strings.IndexByte(quotes, c) >= 0:
+			quote = c
+			out.WriteByte(c)
+		case c == '/' && next == '/':
+			lineComment = true
+		case c == '/' && next == '*':
+			blockComment = true
+			i++
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,186 @@
+package pom
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseIvyDependencies(t *testing.T) {
+	tests := []struct {
+		name     string
+		ivy      string
+		want     []Dependency
+		warnings []string
+	}{
+		{
+			name: "scope from configuration",
+			ivy: `<dependencies>
+  <dependency org="junit" name="junit" rev="4.13.2" conf="test->default"/>
+</dependencies>`,
+			want: []Dependency{{GroupID: "junit", ArtifactID: "junit", Version: "4.13.2", Scope: ScopeTest}},
+		},
+		{
+			name: "classifier and type",
+			ivy: `<ivy-module version="2.0" xmlns:m="http://ant.apache.org/ivy/maven">
+  <dependencies>
+    <dependency org="com.example" name="lib" rev="1.0">
+      <artifact name="lib" type="jar"/>
+      <artifact name="lib" type="test-jar" m:classifier="tests"/>
+    </dependency>
+  </dependencies>
+</ivy-module>`,
+			want: []Dependency{
+				{GroupID: "com.example", ArtifactID: "lib", Version: "1.0", Scope: ScopeCompile},
+				{GroupID: "com.example", ArtifactID: "lib", Version: "1.0", Scope: ScopeCompile, Classifier: "tests", Type: "test-jar"},
+			},
+		},
+		{
+			name: "exclusions",
+			ivy: `<dependencies>
+  <dependency org="org.example" name="app" rev="2.0" transitive="false"/>
+  <dependency org="org.springframework" name="spring-core" rev="6.1.0">
+    <exclude org="commons-logging" module="commons-logging"/>
+  </dependency>
+</dependencies>`,
+			want: []Dependency{
+				{GroupID: "org.example", ArtifactID: "app", Version: "2.0", Scope: ScopeCompile, Exclusions: []Exclusion{{GroupID: "*", ArtifactID: "*"}}},
+				{GroupID: "org.springframework", ArtifactID: "spring-core", Version: "6.1.0", Scope: ScopeCompile,
+					Exclusions: []Exclusion{{GroupID: "commons-logging", ArtifactID: "commons-logging"}}},
+			},
+		},
+		{
+			name: "commented out",
+			ivy: `<dependencies>
+  <!-- <dependency org="junit" name="junit" rev="4.12"/> -->
+  <dependency org="org.slf4j" name="slf4j-api" rev="2.0.16"/>
+</dependencies>`,
+			want: []Dependency{{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.16", Scope: ScopeCompile}},
+		},
+		{
+			name: "dynamic revision and unknown configuration",
+			ivy: `<dependencies>
+  <dependency org="org.example" name="lib" rev="1.+" conf="docs->default"/>
+</dependencies>`,
+			want:     []Dependency{{GroupID: "org.example", ArtifactID: "lib", Version: "1.+", Scope: ScopeCompile}},
+			warnings: []string{"dynamic revision", `configuration "docs->default" has no Maven scope`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseIvyDependencies([]byte(tt.ivy))
+			if err != nil {
+				t.Fatalf("Expected ivy.xml to parse, got %v", err)
+			}
+			if !reflect.DeepEqual(result.Dependencies, tt.want) {
+				t.Errorf("Expected %+v, got %+v", tt.want, result.Dependencies)
+			}
+			assertWarnings(t, result.Warnings, tt.warnings)
+		})
+	}
+}
+
+func TestParseSBTDependencies(t *testing.T) {
+	tests := []struct {
+		name     string
+		sbt      string
+		want     []Dependency
+		warnings []string
+	}{
+		{
+			name: "scope and val version",
+			sbt: `val catsVersion = "2.10.0"
+libraryDependencies += "org.typelevel" % "cats-core_2.13" % catsVersion
+libraryDependencies += "org.scalatest" % "scalatest_2.13" % "3.2.18" % Test`,
+			want: []Dependency{
+				{GroupID: "org.typelevel", ArtifactID: "cats-core_2.13", Version: "2.10.0", Scope: ScopeCompile},
+				{GroupID: "org.scalatest", ArtifactID: "scalatest_2.13", Version: "3.2.18", Scope: ScopeTest},
+			},
+		},
+		{
+			name: "cross-built artifact",
+			sbt: `scalaVersion := "3.3.1"
+libraryDependencies += "org.typelevel" %% "cats-core" % "2.10.0"`,
+			want: []Dependency{{GroupID: "org.typelevel", ArtifactID: "cats-core_3", Version: "2.10.0", Scope: ScopeCompile}},
+		},
+		{
+			name: "classifier",
+			sbt: `libraryDependencies ++= Seq(
+  "com.example" % "lib" % "1.0" % Test classifier "tests",
+  "org.lwjgl" % "lwjgl" % "3.3.3" classifier("natives-linux")
+)`,
+			want: []Dependency{
+				{GroupID: "com.example", ArtifactID: "lib", Version: "1.0", Scope: ScopeTest, Classifier: "tests"},
+				{GroupID: "org.lwjgl", ArtifactID: "lwjgl", Version: "3.3.3", Scope: ScopeCompile, Classifier: "natives-linux"},
+			},
+		},
+		{
+			name: "exclusions",
+			sbt: `libraryDependencies += ("org.springframework" % "spring-core" % "6.1.0").exclude("commons-logging", "commons-logging")
+libraryDependencies += "org.example" % "app" % "2.0" intransitive()`,
+			want: []Dependency{
+				{GroupID: "org.springframework", ArtifactID: "spring-core", Version: "6.1.0", Scope: ScopeCompile,
+					Exclusions: []Exclusion{{GroupID: "commons-logging", ArtifactID: "commons-logging"}}},
+				{GroupID: "org.example", ArtifactID: "app", Version: "2.0", Scope: ScopeCompile, Exclusions: []Exclusion{{GroupID: "*", ArtifactID: "*"}}},
+			},
+		},
+		{
+			name: "comments",
+			sbt: `// libraryDependencies += "junit" % "junit" % "4.12"
+/* libraryDependencies += "org.old" % "gone" % "1.0" */
+libraryDependencies += "org.slf4j" % "slf4j-api" % "2.0.16" // "not" % "a" % "dependency"
+val url = "https://example.com" // A // inside a string is not a comment`,
+			want: []Dependency{{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.16", Scope: ScopeCompile}},
+		},
+		{
+			name:     "undefined version",
+			sbt:      `libraryDependencies += "org.example" % "lib" % Versions.lib`,
+			warnings: []string{"version Versions.lib is not defined"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseSBTDependencies([]byte(tt.sbt))
+			if err != nil {
+				t.Fatalf("Expected build.sbt to parse, got %v", err)
+			}
+			if !reflect.DeepEqual(result.Dependencies, tt.want) {
+				t.Errorf("Expected %+v, got %+v", tt.want, result.Dependencies)
+			}
+			assertWarnings(t, result.Warnings, tt.warnings)
+		})
+	}
+}
+
+func TestMergeDependenciesKeepsVariants(t *testing.T) {
+	project := &Project{Dependencies: []Dependency{
+		{GroupID: "com.example", ArtifactID: "lib", Version: "1.0"},
+	}}
+
+	added, updated := MergeDependencies(project, []Dependency{
+		{GroupID: "com.example", ArtifactID: "lib", Version: "1.0", Classifier: "tests", Type: "test-jar", Scope: ScopeTest},
+		{GroupID: "com.example", ArtifactID: "lib", Version: "1.1", Type: DefaultDependencyType},
+	})
+	if !reflect.DeepEqual(added, []string{"com.example:lib:test-jar:tests"}) || !reflect.DeepEqual(updated, []string{"com.example:lib:jar"}) {
+		t.Errorf("Expected the test-jar added and the jar updated, got %v added and %v updated", added, updated)
+	}
+	if len(project.Dependencies) != 2 || project.Dependencies[0].Version != "1.1" {
+		t.Errorf("Expected the main jar updated in place next to the test-jar, got %+v", project.Dependencies)
+	}
+}
+
+// assertWarnings fails unless each warning contains the matching want
+func assertWarnings(t *testing.T, warnings, want []string) {
+	t.Helper()
+	if len(warnings) != len(want) {
+		t.Errorf("Expected %d warning(s), got %q", len(want), warnings)
+		return
+	}
+	for i := range want {
+		if !strings.Contains(warnings[i], want[i]) {
+			t.Errorf("Expected warning containing %q, got %q", want[i], warnings[i])
+		}
+	}
+}
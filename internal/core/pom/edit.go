@@ -0,0 +1,526 @@
+package pom
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/beevik/etree"
+)
+
+// The edits below change POM XML in place. Unlike a parse and generate
+// round trip they keep everything they do not touch as written: comments,
+// indentation, empty elements such as <relativePath/>, and elements the
+// model does not know, such as <repositories>. A non-nil banner replaces
+// any banner before <project>, as FormatOptions.Banner does.
+
+// SetDependencyVersion sets the version of the first dependency of the
+// project declaring groupID:artifactID, adding a <version> when it has none
+func SetDependencyVersion(data []byte, groupID, artifactID, version string, banner *Banner) ([]byte, error) {
+	return editPOM(data, banner, func(root *etree.Element) error {
+		deps := matchingDependencies(root, groupID, artifactID)
+		if len(deps) == 0 {
+			return fmt.Errorf("%w: %s:%s", ErrDependencyNotFound, groupID, artifactID)
+		}
+		dep := deps[0]
+		if elem := dep.SelectElement("version"); elem != nil {
+			elem.SetText(version)
+			return nil
+		}
+		elem := etree.NewElement("version")
+		elem.SetText(version)
+		insertSiblingAfter(dep.SelectElement("artifactId"), elem)
+		return nil
+	})
+}
+
+// RemoveDependency removes every dependency of the project declaring
+// groupID:artifactID, whatever its classifier or type, and returns how many
+// it removed. A <dependencies> left empty is removed as well.
+func RemoveDependency(data []byte, groupID, artifactID string, banner *Banner) ([]byte, int, error) {
+	removed := 0
+	edited, err := editPOM(data, banner, func(root *etree.Element) error {
+		deps := matchingDependencies(root, groupID, artifactID)
+		if len(deps) == 0 {
+			return fmt.Errorf("%w: %s:%s", ErrDependencyNotFound, groupID, artifactID)
+		}
+		for _, dep := range deps {
+			removeWithIndent(dep)
+		}
+		removed = len(deps)
+		if list := root.SelectElement("dependencies"); isBlank(list) {
+			removeWithIndent(list)
+		}
+		return nil
+	})
+	return edited, removed, err
+}
+
+// MergeDependencyElements is MergeDependencies editing POM XML in place:
+// a dependency replaces the one with the same key, keeping its position,
+// and other dependencies are added at the end of <dependencies>
+func MergeDependencyElements(data []byte, deps []Dependency, banner *Banner) (edited []byte, added, updated []string, err error) {
+	edited, err = editPOM(data, banner, func(root *etree.Element) error {
+		list := root.SelectElement("dependencies")
+		for _, dep := range deps {
+			elem := dependencyElement(dep)
+			key := dep.Key()
+			if existing := dependencyWithKey(list, key); existing != nil {
+				replaceElement(existing, elem)
+				updated = append(updated, key)
+				continue
+			}
+			if list == nil {
+				list = etree.NewElement("dependencies")
+				addChild(root, list)
+			}
+			addChild(list, elem)
+			added = append(added, key)
+		}
+		return nil
+	})
+	return edited, added, updated, err
+}
+
+// RemovePlugin removes every plugin of the main build declaring
+// groupID:artifactID, a missing groupId being the default one. A <plugins>
+// and <build> left empty are removed as well.
+func RemovePlugin(data []byte, groupID, artifactID string, banner *Banner) ([]byte, error) {
+	return editPOM(data, banner, func(root *etree.Element) error {
+		var list *etree.Element
+		build := root.SelectElement("build")
+		if build != nil {
+			list = build.SelectElement("plugins")
+		}
+		var plugins []*etree.Element
+		if list != nil {
+			for _, plugin := range list.SelectElements("plugin") {
+				pluginGroupID := childText(plugin, "groupId")
+				if pluginGroupID == "" {
+					pluginGroupID = DefaultPluginGroupID
+				}
+				if pluginGroupID == groupID && childText(plugin, "artifactId") == artifactID {
+					plugins = append(plugins, plugin)
+				}
+			}
+		}
+		if len(plugins) == 0 {
+			return fmt.Errorf("%w: %s:%s", ErrPluginNotFound, groupID, artifactID)
+		}
+		for _, plugin := range plugins {
+			removeWithIndent(plugin)
+		}
+		if isBlank(list) {
+			removeWithIndent(list)
+		}
+		if isBlank(build) {
+			removeWithIndent(build)
+		}
+		return nil
+	})
+}
+
+// SetProperty sets the value of a property defined in the project's
+// <properties>
+func SetProperty(data []byte, name, value string, banner *Banner) ([]byte, error) {
+	return editPOM(data, banner, func(root *etree.Element) error {
+		var elem *etree.Element
+		if properties := root.SelectElement("properties"); properties != nil {
+			elem = properties.SelectElement(name)
+		}
+		if elem == nil {
+			return fmt.Errorf("property %s is not defined in <properties>", name)
+		}
+		elem.SetText(value)
+		return nil
+	})
+}
+
+// AddProperty adds a property to the project's <properties>, adding
+// <properties> when the project has none. Fails when the property is
+// already defined; SetProperty changes it.
+func AddProperty(data []byte, name, value string, banner *Banner) ([]byte, error) {
+	return editPOM(data, banner, func(root *etree.Element) error {
+		properties := root.SelectElement("properties")
+		if properties == nil {
+			properties = etree.NewElement("properties")
+			addChild(root, properties)
+		} else if properties.SelectElement(name) != nil {
+			return fmt.Errorf("property %s is already defined in <properties>", name)
+		}
+		elem := etree.NewElement(name)
+		elem.SetText(value)
+		addChild(properties, elem)
+		return nil
+	})
+}
+
+// RemoveProperty removes a property from the project's <properties>. A
+// <properties> left empty is removed as well.
+func RemoveProperty(data []byte, name string, banner *Banner) ([]byte, error) {
+	return editPOM(data, banner, func(root *etree.Element) error {
+		var elem *etree.Element
+		properties := root.SelectElement("properties")
+		if properties != nil {
+			elem = properties.SelectElement(name)
+		}
+		if elem == nil {
+			return fmt.Errorf("property %s is not defined in <properties>", name)
+		}
+		removeWithIndent(elem)
+		if isBlank(properties) {
+			removeWithIndent(properties)
+		}
+		return nil
+	})
+}
+
+// SetManagedVersion sets the version of the last dependencyManagement
+// entry declaring groupID:artifactID that is not a BOM import, adding an
+// entry when there is none, as ImportGradleCatalog does
+func SetManagedVersion(data []byte, groupID, artifactID, version string, banner *Banner) ([]byte, error) {
+	return editPOM(data, banner, func(root *etree.Element) error {
+		management := root.SelectElement("dependencyManagement")
+		if management == nil {
+			management = etree.NewElement("dependencyManagement")
+			addChild(root, management)
+		}
+		list := management.SelectElement("dependencies")
+		if list == nil {
+			list = etree.NewElement("dependencies")
+			addChild(management, list)
+		}
+		deps := list.SelectElements("dependency")
+		for i := len(deps) - 1; i >= 0; i-- {
+			dep := deps[i]
+			if childText(dep, "groupId") != groupID || childText(dep, "artifactId") != artifactID ||
+				(childText(dep, "scope") == ScopeImport && childText(dep, "type") == PackagingPom) {
+				continue
+			}
+			if elem := dep.SelectElement("version"); elem != nil {
+				elem.SetText(version)
+				return nil
+			}
+			elem := etree.NewElement("version")
+			elem.SetText(version)
+			insertSiblingAfter(dep.SelectElement("artifactId"), elem)
+			return nil
+		}
+		addChild(list, dependencyElement(Dependency{GroupID: groupID, ArtifactID: artifactID, Version: version}))
+		return nil
+	})
+}
+
+// AddModuleElement is AddModule editing POM XML in place: the module is
+// added to <modules> and the packaging set to "pom", adding either element
+// when the project has none
+func AddModuleElement(data []byte, module string, banner *Banner) ([]byte, error) {
+	module = normalizeModulePath(module)
+	return editPOM(data, banner, func(root *etree.Element) error {
+		modules := root.SelectElement("modules")
+		if modules == nil {
+			modules = etree.NewElement("modules")
+			addChild(root, modules)
+		}
+		for _, elem := range modules.SelectElements("module") {
+			if normalizeModulePath(elem.Text()) == module {
+				return fmt.Errorf("%w: module '%s' already declared", ErrDuplicateModule, module)
+			}
+		}
+		elem := etree.NewElement("module")
+		elem.SetText(module)
+		addChild(modules, elem)
+
+		packaging := root.SelectElement("packaging")
+		if packaging == nil {
+			packaging = etree.NewElement("packaging")
+			addChild(root, packaging)
+		}
+		packaging.SetText(PackagingPom)
+		return nil
+	})
+}
+
+// RemoveModuleElement is RemoveModule editing POM XML in place. A <modules>
+// left empty is removed as well; the packaging is left alone.
+func RemoveModuleElement(data []byte, module string, banner *Banner) ([]byte, error) {
+	module = normalizeModulePath(module)
+	return editPOM(data, banner, func(root *etree.Element) error {
+		modules := root.SelectElement("modules")
+		if modules != nil {
+			for _, elem := range modules.SelectElements("module") {
+				if normalizeModulePath(elem.Text()) == module {
+					removeWithIndent(elem)
+					if isBlank(modules) {
+						removeWithIndent(modules)
+					}
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("%w: %s", ErrModuleNotFound, module)
+	})
+}
+
+// editPOM applies edit to the <project> element of data and writes the
+// document back without reindenting it
+func editPOM(data []byte, banner *Banner, edit func(root *etree.Element) error) ([]byte, error) {
+	doc, root, err := readPOMDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := edit(root); err != nil {
+		return nil, err
+	}
+	if banner != nil {
+		stampBanner(doc, root, *banner)
+	}
+
+	var out bytes.Buffer
+	if _, err := doc.WriteTo(&out); err != nil {
+		return nil, fmt.Errorf("writing XML: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// stampBanner replaces the banners before root with banner, putting each
+// token before root on a line of its own since the document is not
+// reindented
+func stampBanner(doc *etree.Document, root *etree.Element, banner Banner) {
+	replaceBanner(doc, root, banner)
+	for _, token := range append([]etree.Token(nil), doc.Child...) {
+		if data, ok := token.(*etree.CharData); ok && data.IsWhitespace() && data.Index() < root.Index() {
+			doc.RemoveChild(data)
+		}
+	}
+	for i := root.Index(); i > 0; i-- {
+		doc.InsertChildAt(i, etree.NewText("\n"))
+	}
+}
+
+// readPOMDocument reads POM XML, keeping CDATA sections as written
+func readPOMDocument(data []byte) (*etree.Document, *etree.Element, error) {
+	doc := etree.NewDocument()
+	doc.ReadSettings.PreserveCData = true
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidXML, err)
+	}
+	root := doc.Root()
+	if root == nil || root.Tag != "project" {
+		return nil, nil, fmt.Errorf("%w: root element must be <project>", ErrInvalidXML)
+	}
+	return doc, root, nil
+}
+
+// matchingDependencies returns the <dependency> elements of the project
+// declaring groupID:artifactID, in document order
+func matchingDependencies(root *etree.Element, groupID, artifactID string) []*etree.Element {
+	list := root.SelectElement("dependencies")
+	if list == nil {
+		return nil
+	}
+	var deps []*etree.Element
+	for _, dep := range list.SelectElements("dependency") {
+		if childText(dep, "groupId") == groupID && childText(dep, "artifactId") == artifactID {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// dependencyWithKey returns the <dependency> of list with the given Key,
+// nil when there is none
+func dependencyWithKey(list *etree.Element, key string) *etree.Element {
+	if list == nil {
+		return nil
+	}
+	for _, elem := range list.SelectElements("dependency") {
+		dep := Dependency{
+			GroupID:    childText(elem, "groupId"),
+			ArtifactID: childText(elem, "artifactId"),
+			Type:       childText(elem, "type"),
+			Classifier: childText(elem, "classifier"),
+		}
+		if dep.Key() == key {
+			return elem
+		}
+	}
+	return nil
+}
+
+// dependencyElement returns a <dependency> element as the generator writes it
+func dependencyElement(dep Dependency) *etree.Element {
+	parent := etree.NewElement("dependencies")
+	(&defaultGenerator{}).addDependency(parent, dep)
+	elem := parent.SelectElement("dependency")
+	parent.RemoveChild(elem)
+	return elem
+}
+
+// replaceElement puts elem in the place of old, indenting its content like
+// the rest of the document
+func replaceElement(old, elem *etree.Element) {
+	parent := old.Parent()
+	parent.InsertChildAt(old.Index(), elem)
+	parent.RemoveChild(old)
+	indentTree(elem, lineIndent(elem), indentUnit(parent))
+}
+
+// addChild adds elem to parent on a line of its own, before the first
+// child that comes after it in canonical order (see elementOrder) or else
+// last, and indents the content of elem like the rest of the document
+func addChild(parent, elem *etree.Element) {
+	children := parent.ChildElements()
+	indent := lineIndent(parent) + indentUnit(parent)
+	if len(children) > 0 {
+		indent = lineIndent(children[0])
+	}
+
+	index := -1
+	rank := elementRank(parent.Tag, elem.Tag)
+	for _, child := range children {
+		if elementRank(parent.Tag, child.Tag) > rank {
+			index = child.Index()
+			if ws := indentBefore(child); ws != nil {
+				index = ws.Index()
+			}
+			break
+		}
+	}
+
+	switch {
+	case index >= 0:
+		parent.InsertChildAt(index, etree.NewText(indent))
+		parent.InsertChildAt(index+1, elem)
+	case len(children) > 0:
+		index = children[len(children)-1].Index() + 1
+		parent.InsertChildAt(index, etree.NewText(indent))
+		parent.InsertChildAt(index+1, elem)
+	default:
+		for _, token := range append([]etree.Token(nil), parent.Child...) {
+			if isWhitespace(token) {
+				parent.RemoveChild(token)
+			}
+		}
+		parent.AddChild(etree.NewText(indent))
+		parent.AddChild(elem)
+		parent.AddChild(etree.NewText(lineIndent(parent)))
+	}
+	indentTree(elem, indent, indentUnit(parent))
+}
+
+// indentTree puts each child of elem and its descendants on a line of its
+// own, one unit deeper than indent, the indentation of elem. Elements
+// holding only text are left alone.
+func indentTree(elem *etree.Element, indent, unit string) {
+	if len(elem.ChildElements()) == 0 {
+		return
+	}
+	var tokens []etree.Token
+	for _, token := range elem.Child {
+		if !isWhitespace(token) {
+			tokens = append(tokens, token)
+		}
+	}
+	for len(elem.Child) > 0 {
+		elem.RemoveChildAt(len(elem.Child) - 1)
+	}
+	for _, token := range tokens {
+		elem.AddChild(etree.NewText(indent + unit))
+		elem.AddChild(token)
+		if child, ok := token.(*etree.Element); ok {
+			indentTree(child, indent+unit, unit)
+		}
+	}
+	elem.AddChild(etree.NewText(indent))
+}
+
+// lineIndent returns a newline followed by the indentation of the line
+// elem starts on
+func lineIndent(elem *etree.Element) string {
+	if ws := indentBefore(elem); ws != nil {
+		if i := strings.LastIndex(ws.Data, "\n"); i >= 0 {
+			return ws.Data[i:]
+		}
+	}
+	return "\n"
+}
+
+// indentUnit returns one level of indentation of the document holding
+// elem, as used by the first indented child of the root element; four
+// spaces when there is none
+func indentUnit(elem *etree.Element) string {
+	for elem.Parent() != nil && elem.Parent().Parent() != nil {
+		elem = elem.Parent()
+	}
+	for _, child := range elem.ChildElements() {
+		if unit := strings.TrimPrefix(lineIndent(child), "\n"); unit != "" {
+			return unit
+		}
+	}
+	return "    "
+}
+
+// elementRank returns the position of tag among the children of parentTag
+// in canonical order, with unknown tags last
+func elementRank(parentTag, tag string) int {
+	order := elementOrder[parentTag]
+	if i := slices.Index(order, tag); i >= 0 {
+		return i
+	}
+	return len(order)
+}
+
+// insertSiblingAfter inserts elem after sibling, indented like it
+func insertSiblingAfter(sibling, elem *etree.Element) {
+	parent := sibling.Parent()
+	index := sibling.Index() + 1
+	if indent := indentBefore(sibling); indent != nil {
+		parent.InsertChildAt(index, etree.NewText(indent.Data))
+		index++
+	}
+	parent.InsertChildAt(index, elem)
+}
+
+// removeWithIndent removes elem with the whitespace indenting it
+func removeWithIndent(elem *etree.Element) {
+	parent := elem.Parent()
+	if indent := indentBefore(elem); indent != nil {
+		parent.RemoveChild(indent)
+	}
+	parent.RemoveChild(elem)
+}
+
+// indentBefore returns the whitespace right before elem, nil when there is
+// none
+func indentBefore(elem *etree.Element) *etree.CharData {
+	index := elem.Index()
+	if index <= 0 {
+		return nil
+	}
+	if data, ok := elem.Parent().Child[index-1].(*etree.CharData); ok && isWhitespace(data) {
+		return data
+	}
+	return nil
+}
+
+// isBlank reports whether elem exists and holds nothing but whitespace
+func isBlank(elem *etree.Element) bool {
+	if elem == nil {
+		return false
+	}
+	for _, token := range elem.Child {
+		if !isWhitespace(token) {
+			return false
+		}
+	}
+	return true
+}
+
+// isWhitespace reports whether token is text holding only whitespace;
+// unlike CharData.IsWhitespace it also holds for text added by an edit
+func isWhitespace(token etree.Token) bool {
+	data, ok := token.(*etree.CharData)
+	return ok && !data.IsCData() && strings.TrimSpace(data.Data) == ""
+}
@@ -0,0 +1,319 @@
+package pom
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const editTestPOM = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <modelVersion>4.0.0</modelVersion>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0.0</version>
+    <relativePath/>
+  </parent>
+  <artifactId>demo</artifactId>
+  <properties>
+    <junit.version>5.9.0</junit.version>
+  </properties>
+  <dependencies>
+    <!-- Logging -->
+    <dependency>
+      <groupId>org.slf4j</groupId>
+      <artifactId>slf4j-api</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>org.junit.jupiter</groupId>
+      <artifactId>junit-jupiter</artifactId>
+      <version>${junit.version}</version>
+      <scope>test</scope>
+    </dependency>
+  </dependencies>
+  <repositories>
+    <repository>
+      <id>internal</id>
+      <url>https://repo.example.com/maven</url>
+    </repository>
+  </repositories>
+</project>
+`
+
+// assertKept fails when an edit lost what the model does not hold
+func assertKept(t *testing.T, edited []byte) {
+	t.Helper()
+	for _, want := range []string{"<relativePath/>", "<!-- Logging -->", "<url>https://repo.example.com/maven</url>"} {
+		if !strings.Contains(string(edited), want) {
+			t.Errorf("Expected the edit to keep %s, got:\n%s", want, edited)
+		}
+	}
+}
+
+func TestSetDependencyVersion(t *testing.T) {
+	edited, err := SetDependencyVersion([]byte(editTestPOM), "org.slf4j", "slf4j-api", "2.0.16", nil)
+	if err != nil {
+		t.Fatalf("Expected the version to be set, got %v", err)
+	}
+	assertKept(t, edited)
+	want := "      <artifactId>slf4j-api</artifactId>\n      <version>2.0.16</version>\n    </dependency>"
+	if !strings.Contains(string(edited), want) {
+		t.Errorf("Expected a <version> indented like its siblings, got:\n%s", edited)
+	}
+
+	edited, err = SetDependencyVersion(edited, "org.slf4j", "slf4j-api", "2.0.17", nil)
+	if err != nil || strings.Count(string(edited), "<version>2.0.17</version>") != 1 || strings.Contains(string(edited), "2.0.16") {
+		t.Errorf("Expected the existing <version> to be replaced, got %v:\n%s", err, edited)
+	}
+
+	if _, err := SetDependencyVersion([]byte(editTestPOM), "com.example", "missing", "1.0", nil); !errors.Is(err, ErrDependencyNotFound) {
+		t.Errorf("Expected ErrDependencyNotFound, got %v", err)
+	}
+}
+
+func TestSetProperty(t *testing.T) {
+	edited, err := SetProperty([]byte(editTestPOM), "junit.version", "5.11.0", nil)
+	if err != nil {
+		t.Fatalf("Expected the property to be set, got %v", err)
+	}
+	assertKept(t, edited)
+	if !strings.Contains(string(edited), "<junit.version>5.11.0</junit.version>") ||
+		!strings.Contains(string(edited), "<version>${junit.version}</version>") {
+		t.Errorf("Expected the property to change and its reference to stay, got:\n%s", edited)
+	}
+
+	if _, err := SetProperty([]byte(editTestPOM), "slf4j.version", "2.0.16", nil); err == nil {
+		t.Error("Expected an error for an undefined property")
+	}
+}
+
+func TestRemoveDependency(t *testing.T) {
+	edited, removed, err := RemoveDependency([]byte(editTestPOM), "org.junit.jupiter", "junit-jupiter", nil)
+	if err != nil || removed != 1 {
+		t.Fatalf("Expected 1 dependency removed, got %d, %v", removed, err)
+	}
+	assertKept(t, edited)
+	if strings.Contains(string(edited), "junit-jupiter") {
+		t.Errorf("Expected the dependency to be removed, got:\n%s", edited)
+	}
+	if !strings.Contains(string(edited), "    </dependency>\n  </dependencies>") {
+		t.Errorf("Expected no blank line left behind, got:\n%s", edited)
+	}
+
+	project, err := NewParser().Parse(edited)
+	if err != nil || len(project.Dependencies) != 1 {
+		t.Errorf("Expected the edited POM to parse with 1 dependency left, got %v", err)
+	}
+
+	if _, _, err := RemoveDependency([]byte(editTestPOM), "com.example", "missing", nil); !errors.Is(err, ErrDependencyNotFound) {
+		t.Errorf("Expected ErrDependencyNotFound, got %v", err)
+	}
+}
+
+func TestEditReplacesBanner(t *testing.T) {
+	data := []byte(editTestPOM)
+	for _, version := range []string{"1.0", "1.1"} {
+		var err error
+		banner := &Banner{Tool: "pom-manager", Version: version}
+		if data, err = SetProperty(data, "junit.version", version, banner); err != nil {
+			t.Fatalf("Expected the property to be set, got %v", err)
+		}
+	}
+	if got := strings.Count(string(data), "pom-manager"); got != 1 {
+		t.Errorf("Expected repeated edits to keep a single banner, got %d:\n%s", got, data)
+	}
+	if !strings.Contains(string(data), "?>\n<!-- Generated by pom-manager 1.1 on ") || !strings.Contains(string(data), " -->\n<project") {
+		t.Errorf("Expected the banner on a line of its own, got:\n%s", data)
+	}
+}
+
+func TestAddProperty(t *testing.T) {
+	edited, err := AddProperty([]byte(editTestPOM), "maven.compiler.release", "21", nil)
+	if err != nil {
+		t.Fatalf("Expected the property to be added, got %v", err)
+	}
+	assertKept(t, edited)
+	want := "    <junit.version>5.9.0</junit.version>\n    <maven.compiler.release>21</maven.compiler.release>\n  </properties>"
+	if !strings.Contains(string(edited), want) {
+		t.Errorf("Expected the property last in <properties>, indented like the others, got:\n%s", edited)
+	}
+
+	if _, err := AddProperty(edited, "junit.version", "5.11.0", nil); err == nil {
+		t.Error("Expected an error for a property that is already defined")
+	}
+}
+
+func TestAddPropertyCreatesProperties(t *testing.T) {
+	withoutProperties := strings.Replace(editTestPOM, "  <properties>\n    <junit.version>5.9.0</junit.version>\n  </properties>\n", "", 1)
+	edited, err := AddProperty([]byte(withoutProperties), "java.version", "17", nil)
+	if err != nil {
+		t.Fatalf("Expected the property to be added, got %v", err)
+	}
+	assertKept(t, edited)
+	want := "  <artifactId>demo</artifactId>\n  <properties>\n    <java.version>17</java.version>\n  </properties>\n  <dependencies>"
+	if !strings.Contains(string(edited), want) {
+		t.Errorf("Expected <properties> added before <dependencies>, got:\n%s", edited)
+	}
+}
+
+func TestRemoveProperty(t *testing.T) {
+	edited, err := RemoveProperty([]byte(editTestPOM), "junit.version", nil)
+	if err != nil {
+		t.Fatalf("Expected the property to be removed, got %v", err)
+	}
+	assertKept(t, edited)
+	if strings.Contains(string(edited), "<properties>") {
+		t.Errorf("Expected the empty <properties> to be removed, got:\n%s", edited)
+	}
+	if !strings.Contains(string(edited), "  <artifactId>demo</artifactId>\n  <dependencies>") {
+		t.Errorf("Expected no blank line left behind, got:\n%s", edited)
+	}
+
+	if _, err := RemoveProperty([]byte(editTestPOM), "slf4j.version", nil); err == nil {
+		t.Error("Expected an error for an undefined property")
+	}
+}
+
+func TestSetManagedVersion(t *testing.T) {
+	edited, err := SetManagedVersion([]byte(editTestPOM), "org.slf4j", "slf4j-api", "2.0.16", nil)
+	if err != nil {
+		t.Fatalf("Expected the managed version to be set, got %v", err)
+	}
+	assertKept(t, edited)
+	want := `  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.slf4j</groupId>
+        <artifactId>slf4j-api</artifactId>
+        <version>2.0.16</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+  <dependencies>`
+	if !strings.Contains(string(edited), want) {
+		t.Errorf("Expected <dependencyManagement> added before <dependencies>, got:\n%s", edited)
+	}
+
+	edited, err = SetManagedVersion(edited, "org.slf4j", "slf4j-api", "${slf4j.version}", nil)
+	if err != nil {
+		t.Fatalf("Expected the managed version to be updated, got %v", err)
+	}
+	if strings.Count(string(edited), "slf4j-api") != 2 || !strings.Contains(string(edited), "<version>${slf4j.version}</version>") {
+		t.Errorf("Expected the managed entry to be updated in place, got:\n%s", edited)
+	}
+}
+
+func TestModuleElements(t *testing.T) {
+	edited, err := AddModuleElement([]byte(editTestPOM), "./core/", nil)
+	if err != nil {
+		t.Fatalf("Expected the module to be added, got %v", err)
+	}
+	assertKept(t, edited)
+	want := "  <artifactId>demo</artifactId>\n  <packaging>pom</packaging>\n  <modules>\n    <module>core</module>\n  </modules>\n  <properties>"
+	if !strings.Contains(string(edited), want) {
+		t.Errorf("Expected <packaging> and <modules> added in canonical order, got:\n%s", edited)
+	}
+	if _, err := AddModuleElement(edited, "core", nil); !errors.Is(err, ErrDuplicateModule) {
+		t.Errorf("Expected ErrDuplicateModule, got %v", err)
+	}
+
+	edited, err = RemoveModuleElement(edited, "core/", nil)
+	if err != nil {
+		t.Fatalf("Expected the module to be removed, got %v", err)
+	}
+	if strings.Contains(string(edited), "<modules>") || !strings.Contains(string(edited), "<packaging>pom</packaging>") {
+		t.Errorf("Expected the empty <modules> removed and the packaging kept, got:\n%s", edited)
+	}
+	if _, err := RemoveModuleElement(edited, "core", nil); !errors.Is(err, ErrModuleNotFound) {
+		t.Errorf("Expected ErrModuleNotFound, got %v", err)
+	}
+}
+
+func TestRemovePlugin(t *testing.T) {
+	withBuild := strings.Replace(editTestPOM, "</project>", `  <build>
+    <plugins>
+      <!-- Sources jar -->
+      <plugin>
+        <artifactId>maven-source-plugin</artifactId>
+      </plugin>
+      <plugin>
+        <groupId>org.apache.maven.plugins</groupId>
+        <artifactId>maven-antrun-plugin</artifactId>
+        <configuration><target><echo message="kept as written"/></target></configuration>
+      </plugin>
+    </plugins>
+  </build>
+</project>`, 1)
+
+	edited, err := RemovePlugin([]byte(withBuild), DefaultPluginGroupID, "maven-source-plugin", nil)
+	if err != nil {
+		t.Fatalf("Expected the plugin to be removed, got %v", err)
+	}
+	assertKept(t, edited)
+	want := "      <!-- Sources jar -->\n      <plugin>\n        <groupId>org.apache.maven.plugins</groupId>"
+	if strings.Contains(string(edited), "maven-source-plugin") || !strings.Contains(string(edited), want) {
+		t.Errorf("Expected only the plugin removed, got:\n%s", edited)
+	}
+
+	edited, err = RemovePlugin(edited, DefaultPluginGroupID, "maven-antrun-plugin", nil)
+	if err != nil {
+		t.Fatalf("Expected the plugin to be removed, got %v", err)
+	}
+	if strings.Contains(string(edited), "<plugin>") || !strings.Contains(string(edited), "<!-- Sources jar -->") {
+		t.Errorf("Expected <plugins> kept for its comment, got:\n%s", edited)
+	}
+
+	edited = []byte(strings.Replace(withBuild, "      <!-- Sources jar -->\n", "", 1))
+	for _, artifactID := range []string{"maven-source-plugin", "maven-antrun-plugin"} {
+		if edited, err = RemovePlugin(edited, DefaultPluginGroupID, artifactID, nil); err != nil {
+			t.Fatalf("Expected %s to be removed, got %v", artifactID, err)
+		}
+	}
+	if !strings.HasSuffix(string(edited), "  </repositories>\n</project>\n") {
+		t.Errorf("Expected the empty <build> removed, got:\n%s", edited)
+	}
+
+	if _, err := RemovePlugin([]byte(editTestPOM), DefaultPluginGroupID, "maven-source-plugin", nil); !errors.Is(err, ErrPluginNotFound) {
+		t.Errorf("Expected ErrPluginNotFound, got %v", err)
+	}
+}
+
+func TestMergeDependencyElements(t *testing.T) {
+	deps := []Dependency{
+		{GroupID: "org.junit.jupiter", ArtifactID: "junit-jupiter", Version: "5.11.0", Scope: ScopeTest},
+		{GroupID: "com.google.guava", ArtifactID: "guava", Version: "33.0.0-jre", Scope: ScopeCompile},
+	}
+	edited, added, updated, err := MergeDependencyElements([]byte(editTestPOM), deps, nil)
+	if err != nil {
+		t.Fatalf("Expected the dependencies to be merged, got %v", err)
+	}
+	assertKept(t, edited)
+	if !reflect.DeepEqual(added, []string{"com.google.guava:guava:jar"}) || !reflect.DeepEqual(updated, []string{"org.junit.jupiter:junit-jupiter:jar"}) {
+		t.Errorf("Expected guava added and junit-jupiter updated, got %q and %q", added, updated)
+	}
+	want := `      <artifactId>junit-jupiter</artifactId>
+      <version>5.11.0</version>
+      <scope>test</scope>
+    </dependency>
+    <dependency>
+      <groupId>com.google.guava</groupId>
+      <artifactId>guava</artifactId>
+      <version>33.0.0-jre</version>
+    </dependency>
+  </dependencies>`
+	if !strings.Contains(string(edited), want) {
+		t.Errorf("Expected junit-jupiter replaced in place and guava added last, got:\n%s", edited)
+	}
+
+	withoutDependencies := editTestPOM[:strings.Index(editTestPOM, "  <dependencies>")] + editTestPOM[strings.Index(editTestPOM, "  <repositories>"):]
+	edited, added, _, err = MergeDependencyElements([]byte(withoutDependencies), deps[1:], nil)
+	if err != nil || len(added) != 1 {
+		t.Fatalf("Expected the dependency to be added, got %q, %v", added, err)
+	}
+	if !strings.Contains(string(edited), "  </properties>\n  <dependencies>\n    <dependency>\n      <groupId>com.google.guava</groupId>") {
+		t.Errorf("Expected <dependencies> added after <properties>, got:\n%s", edited)
+	}
+}
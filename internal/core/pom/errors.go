@@ -12,6 +12,9 @@ var (
 
 	// ErrInvalidFormat indicates invalid format for a field
 	ErrInvalidFormat = errors.New("invalid format")
+
+	// ErrLimitExceeded indicates input beyond the limits of ParseBytesStrict
+	ErrLimitExceeded = errors.New("input limit exceeded")
 )
 
 // File operation errors
@@ -42,6 +45,37 @@ var (
 
 	// ErrInvalidProject indicates the project struct failed validation
 	ErrInvalidProject = errors.New("invalid project structure")
+
+	// ErrDependencyNotFound indicates a dependency is not declared
+	ErrDependencyNotFound = errors.New("dependency not found")
+
+	// ErrPluginNotFound indicates a build plugin is not declared
+	ErrPluginNotFound = errors.New("plugin not found")
+
+	// ErrFixNotApplicable indicates the project changed since a quick-fix
+	// was suggested
+	ErrFixNotApplicable = errors.New("fix no longer applies")
+
+	// ErrInvalidAID indicates a malformed JavaCard application identifier
+	ErrInvalidAID = errors.New("invalid JavaCard AID")
+)
+
+// Module errors
+var (
+	// ErrDuplicateModule indicates a module is already declared
+	ErrDuplicateModule = errors.New("duplicate module")
+
+	// ErrModuleNotFound indicates a module is not declared
+	ErrModuleNotFound = errors.New("module not found")
+)
+
+// Inheritance errors
+var (
+	// ErrParentNotFound indicates the parent POM could not be located
+	ErrParentNotFound = errors.New("parent POM not found")
+
+	// ErrParentCycle indicates the parent chain refers back to itself
+	ErrParentCycle = errors.New("parent POM cycle detected")
 )
 
 // Generation errors
@@ -54,4 +88,7 @@ var (
 var (
 	// ErrTemplateNotFound indicates unknown template name
 	ErrTemplateNotFound = errors.New("template not found")
+
+	// ErrTemplateVariable indicates a template placeholder without a value
+	ErrTemplateVariable = errors.New("unresolved template variable")
 )
@@ -0,0 +1,250 @@
+package pom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxFixRounds bounds FixAll in case fixes keep producing new findings
+const maxFixRounds = 100
+
+// Fix is an automatic correction for a validation finding. Apply returns
+// ErrFixNotApplicable when the project no longer matches the finding.
+type Fix struct {
+	Description  string
+	Apply        func(project *Project) error
+	ChangesFiles bool // Creates files next to the POM instead of editing the project
+}
+
+// FixAll applies quick-fixes until none are left, validating with validate
+// after each one since a fix can shift the indices other findings refer
+// to. Returns the fixes applied.
+func FixAll(project *Project, validate func(*Project) ValidationResult) ([]Fix, error) {
+	var applied []Fix
+	attempted := make(map[string]bool)
+
+	for round := 0; round < maxFixRounds; round++ {
+		var next *ValidationError
+		for _, finding := range validate(project).Errors.AllErrors() {
+			if finding.Fix != nil && !attempted[finding.Field+"\x00"+finding.Fix.Description] {
+				next = &finding
+				break
+			}
+		}
+		if next == nil {
+			return applied, nil
+		}
+
+		// A fix that does not clear its finding is not offered again
+		attempted[next.Field+"\x00"+next.Fix.Description] = true
+		if err := next.Fix.Apply(project); err != nil {
+			return applied, fmt.Errorf("%s: %w", next.Fix.Description, err)
+		}
+		applied = append(applied, *next.Fix)
+	}
+	return applied, nil
+}
+
+// setGroupIDFix replaces the project's groupId with a conforming one
+func setGroupIDFix(from, to string) *Fix {
+	return &Fix{
+		Description: fmt.Sprintf("Change groupId to %q", to),
+		Apply: func(project *Project) error {
+			if project.GroupID != from {
+				return ErrFixNotApplicable
+			}
+			project.GroupID = to
+			project.Coordinates.GroupID = to
+			project.InheritsGroupID = false
+			return nil
+		},
+	}
+}
+
+// setArtifactIDFix replaces the project's artifactId with a conforming one
+func setArtifactIDFix(from, to string) *Fix {
+	return &Fix{
+		Description: fmt.Sprintf("Change artifactId to %q", to),
+		Apply: func(project *Project) error {
+			if project.ArtifactID != from {
+				return ErrFixNotApplicable
+			}
+			project.ArtifactID = to
+			project.Coordinates.ArtifactID = to
+			return nil
+		},
+	}
+}
+
+// setModelVersionFix declares the POM model version
+func setModelVersionFix() *Fix {
+	return &Fix{
+		Description: fmt.Sprintf("Set modelVersion to %s", DefaultModelVersion),
+		Apply: func(project *Project) error {
+			project.ModelVersion = DefaultModelVersion
+			return nil
+		},
+	}
+}
+
+// setParentVersionFix points the parent reference at the version of the
+// parent POM found next to the project
+func setParentVersionFix(from, to string) *Fix {
+	return &Fix{
+		Description: fmt.Sprintf("Change parent version to %s", to),
+		Apply: func(project *Project) error {
+			if project.Parent == nil || project.Parent.Version != from {
+				return ErrFixNotApplicable
+			}
+			project.Parent.Version = to
+			if project.InheritsVersion {
+				project.Version = to
+				project.Coordinates.Version = to
+			}
+			return nil
+		},
+	}
+}
+
+// setPackagingFix changes the project's packaging
+func setPackagingFix(packaging string) *Fix {
+	return &Fix{
+		Description: fmt.Sprintf("Change packaging to %s", packaging),
+		Apply: func(project *Project) error {
+			project.Packaging = packaging
+			return nil
+		},
+	}
+}
+
+// setScopeFix corrects the scope of the dependency at index
+func setScopeFix(index int, dep Dependency, scope string) *Fix {
+	return &Fix{
+		Description: fmt.Sprintf("Change scope of %s:%s to %s", dep.GroupID, dep.ArtifactID, scope),
+		Apply: func(project *Project) error {
+			if !sameDependency(project.Dependencies, index, dep) {
+				return ErrFixNotApplicable
+			}
+			project.Dependencies[index].Scope = scope
+			return nil
+		},
+	}
+}
+
+// removeDependencyFix drops the repeated declaration at index
+func removeDependencyFix(index int, dep Dependency) *Fix {
+	return &Fix{
+		Description: fmt.Sprintf("Remove duplicate %s:%s", dep.GroupID, dep.ArtifactID),
+		Apply: func(project *Project) error {
+			if !sameDependency(project.Dependencies, index, dep) {
+				return ErrFixNotApplicable
+			}
+			project.Dependencies = append(project.Dependencies[:index], project.Dependencies[index+1:]...)
+			return nil
+		},
+	}
+}
+
+// removeManagedFix drops the repeated dependencyManagement entry at index
+func removeManagedFix(index int, dep Dependency) *Fix {
+	return &Fix{
+		Description: fmt.Sprintf("Remove duplicate managed %s:%s", dep.GroupID, dep.ArtifactID),
+		Apply: func(project *Project) error {
+			if !sameDependency(project.DependencyManagement, index, dep) {
+				return ErrFixNotApplicable
+			}
+			project.DependencyManagement = append(project.DependencyManagement[:index], project.DependencyManagement[index+1:]...)
+			return nil
+		},
+	}
+}
+
+// setImportTypeFix marks the imported BOM at index as type pom
+func setImportTypeFix(index int, dep Dependency) *Fix {
+	return &Fix{
+		Description: fmt.Sprintf("Set type of %s:%s to pom", dep.GroupID, dep.ArtifactID),
+		Apply: func(project *Project) error {
+			if !sameDependency(project.DependencyManagement, index, dep) {
+				return ErrFixNotApplicable
+			}
+			project.DependencyManagement[index].Type = PackagingPom
+			return nil
+		},
+	}
+}
+
+// moveToManagementFix moves a BOM imported from <dependencies> to
+// dependencyManagement, where import scope is allowed
+func moveToManagementFix(index int, dep Dependency) *Fix {
+	return &Fix{
+		Description: fmt.Sprintf("Move %s:%s to dependencyManagement", dep.GroupID, dep.ArtifactID),
+		Apply: func(project *Project) error {
+			if !sameDependency(project.Dependencies, index, dep) {
+				return ErrFixNotApplicable
+			}
+			bom := project.Dependencies[index]
+			bom.Type = PackagingPom
+			project.Dependencies = append(project.Dependencies[:index], project.Dependencies[index+1:]...)
+
+			for _, managed := range project.DependencyManagement {
+				if managed.GroupID == bom.GroupID && managed.ArtifactID == bom.ArtifactID && managed.Scope == ScopeImport {
+					return nil // Already imported
+				}
+			}
+			project.DependencyManagement = append(project.DependencyManagement, bom)
+			return nil
+		},
+	}
+}
+
+// sameDependency reports whether deps[index] is still the dependency a
+// finding was reported for
+func sameDependency(deps []Dependency, index int, dep Dependency) bool {
+	return index < len(deps) && deps[index].GroupID == dep.GroupID && deps[index].ArtifactID == dep.ArtifactID
+}
+
+// conformingGroupID suggests a groupId that passes the format check, ""
+// when there is no obvious one
+func conformingGroupID(groupID string) string {
+	suggestion := strings.ToLower(strings.TrimSpace(groupID))
+	suggestion = strings.NewReplacer("_", "-", " ", "-").Replace(suggestion)
+	if suggestion == groupID || !isValidGroupID(suggestion) {
+		return ""
+	}
+	return suggestion
+}
+
+// conformingArtifactID suggests an artifactId that passes the format check,
+// "" when there is no obvious one. CamelCase words are split by hyphens.
+func conformingArtifactID(artifactID string) string {
+	var b strings.Builder
+	trimmed := strings.TrimSpace(artifactID)
+	for i, r := range trimmed {
+		switch {
+		case r == '_' || r == '.' || r == ' ':
+			b.WriteRune('-')
+		case r >= 'A' && r <= 'Z':
+			if i > 0 && trimmed[i-1] >= 'a' && trimmed[i-1] <= 'z' {
+				b.WriteRune('-')
+			}
+			b.WriteRune(r + ('a' - 'A'))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	suggestion := b.String()
+	if suggestion == artifactID || !isValidArtifactID(suggestion) {
+		return ""
+	}
+	return suggestion
+}
+
+// conformingScope returns the valid scope a misspelt one differs from only
+// in case or whitespace, "" when there is none
+func conformingScope(scope string) string {
+	suggestion := strings.ToLower(strings.TrimSpace(scope))
+	if suggestion == scope || !isValidScope(suggestion) {
+		return ""
+	}
+	return suggestion
+}
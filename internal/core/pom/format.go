@@ -0,0 +1,199 @@
+package pom
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/beevik/etree"
+)
+
+// Indentation styles understood by Format
+const (
+	IndentTwoSpaces  = "2"
+	IndentFourSpaces = "4"
+	IndentTabs       = "tab"
+)
+
+// IndentStyles lists the indentation styles understood by Format
+var IndentStyles = []string{IndentTwoSpaces, IndentFourSpaces, IndentTabs}
+
+// FormatOptions controls how Format rewrites a POM
+type FormatOptions struct {
+	Indent           string  // One of IndentStyles; "" means four spaces
+	SortDependencies bool    // Sort every <dependencies> list as SortDependencies does
+	Banner           *Banner // Replaces any banner before <project>; nil leaves comments alone
+}
+
+// elementOrder is the canonical order of child elements, following the
+// Maven POM code conventions. Elements not listed keep their relative order
+// after the listed ones.
+var elementOrder = map[string][]string{
+	"project": {
+		"modelVersion", "parent", "groupId", "artifactId", "version", "packaging",
+		"name", "description", "url", "inceptionYear", "organization", "licenses",
+		"developers", "contributors", "mailingLists", "prerequisites", "modules",
+		"scm", "issueManagement", "ciManagement", "distributionManagement",
+		"properties", "dependencyManagement", "dependencies", "repositories",
+		"pluginRepositories", "build", "reporting", "profiles",
+	},
+	"parent":     {"groupId", "artifactId", "version", "relativePath"},
+	"dependency": {"groupId", "artifactId", "version", "type", "classifier", "scope", "systemPath", "optional", "exclusions"},
+	"exclusion":  {"groupId", "artifactId"},
+	"build": {
+		"defaultGoal", "directory", "finalName", "sourceDirectory", "scriptSourceDirectory",
+		"testSourceDirectory", "outputDirectory", "testOutputDirectory", "extensions",
+		"resources", "testResources", "filters", "pluginManagement", "plugins",
+	},
+	"plugin":    {"groupId", "artifactId", "version", "extensions", "executions", "dependencies", "goals", "inherited", "configuration"},
+	"execution": {"id", "phase", "goals", "inherited", "configuration"},
+	"profile": {
+		"id", "activation", "build", "modules", "distributionManagement", "properties",
+		"dependencyManagement", "dependencies", "repositories", "pluginRepositories", "reporting",
+	},
+}
+
+// Format rewrites POM XML with elements in canonical order and consistent
+// indentation, optionally sorting dependencies. Unlike a parse and generate
+// round trip it keeps everything else, such as comments and plugin
+// configuration.
+func Format(data []byte, options FormatOptions) ([]byte, error) {
+	doc, root, err := readPOMDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	formatElement(root, options)
+	if options.Banner != nil {
+		replaceBanner(doc, root, *options.Banner)
+	}
+
+	settings := etree.NewIndentSettings()
+	settings.PreserveLeafWhitespace = true
+	switch options.Indent {
+	case IndentTwoSpaces:
+		settings.Spaces = 2
+	case IndentTabs:
+		settings.UseTabs = true
+	case "", IndentFourSpaces:
+	default:
+		return nil, fmt.Errorf("%w: indent must be one of %s", ErrInvalidFormat, strings.Join(IndentStyles, ", "))
+	}
+	doc.IndentWithSettings(settings)
+
+	var out bytes.Buffer
+	if _, err := doc.WriteTo(&out); err != nil {
+		return nil, fmt.Errorf("writing XML: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// replaceBanner removes the banners before root and adds banner instead
+func replaceBanner(doc *etree.Document, root *etree.Element, banner Banner) {
+	for _, token := range append([]etree.Token(nil), doc.Child...) {
+		if comment, ok := token.(*etree.Comment); ok {
+			if _, ok := ParseBanner(comment.Data); ok {
+				doc.RemoveChild(comment)
+			}
+		}
+	}
+	doc.InsertChildAt(root.Index(), etree.NewComment(banner.String()))
+}
+
+// formatElement orders the children of elem and its descendants. The
+// contents of <configuration> are left alone, since plugins define them.
+func formatElement(elem *etree.Element, options FormatOptions) {
+	if elem.Tag == "configuration" {
+		return
+	}
+	for _, child := range elem.ChildElements() {
+		formatElement(child, options)
+	}
+
+	groups, trailing := childGroups(elem)
+	if order, ok := elementOrder[elem.Tag]; ok {
+		rank := make(map[string]int, len(order))
+		for i, tag := range order {
+			rank[tag] = i
+		}
+		sort.SliceStable(groups, func(i, j int) bool {
+			return tagRank(rank, groups[i].elem.Tag) < tagRank(rank, groups[j].elem.Tag)
+		})
+	}
+	if elem.Tag == "dependencies" && options.SortDependencies {
+		sort.SliceStable(groups, func(i, j int) bool {
+			return dependencyLess(groups[i].elem, groups[j].elem)
+		})
+	}
+
+	for len(elem.Child) > 0 {
+		elem.RemoveChildAt(len(elem.Child) - 1)
+	}
+	for _, group := range groups {
+		for _, token := range group.tokens {
+			elem.AddChild(token)
+		}
+	}
+	for _, token := range trailing {
+		elem.AddChild(token)
+	}
+}
+
+// childGroup is a child element together with the comments and other
+// tokens right before it, which move with it
+type childGroup struct {
+	elem   *etree.Element
+	tokens []etree.Token
+}
+
+// childGroups splits the children of elem into groups ending in an element,
+// plus the tokens after the last element
+func childGroups(elem *etree.Element) ([]childGroup, []etree.Token) {
+	var groups []childGroup
+	var pending []etree.Token
+	for _, token := range elem.Child {
+		if data, ok := token.(*etree.CharData); ok && data.IsWhitespace() {
+			continue // Indentation is recreated
+		}
+		pending = append(pending, token)
+		if child, ok := token.(*etree.Element); ok {
+			groups = append(groups, childGroup{elem: child, tokens: pending})
+			pending = nil
+		}
+	}
+	return groups, pending
+}
+
+// tagRank returns the position of tag in the canonical order, with unknown
+// tags last
+func tagRank(rank map[string]int, tag string) int {
+	if r, ok := rank[tag]; ok {
+		return r
+	}
+	return len(rank)
+}
+
+// dependencyLess orders <dependency> elements like SortDependencies. BOM
+// imports keep their order, since the first import of a version wins.
+func dependencyLess(a, b *etree.Element) bool {
+	scopeA, scopeB := childText(a, "scope"), childText(b, "scope")
+	if ra, rb := scopeRank(scopeA), scopeRank(scopeB); ra != rb {
+		return ra < rb
+	}
+	if scopeA == ScopeImport {
+		return false
+	}
+	if groupA, groupB := childText(a, "groupId"), childText(b, "groupId"); groupA != groupB {
+		return groupA < groupB
+	}
+	return childText(a, "artifactId") < childText(b, "artifactId")
+}
+
+// childText returns the trimmed text of a child element, "" when missing
+func childText(elem *etree.Element, tag string) string {
+	if child := elem.SelectElement(tag); child != nil {
+		return strings.TrimSpace(child.Text())
+	}
+	return ""
+}
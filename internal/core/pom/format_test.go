@@ -0,0 +1,93 @@
+package pom
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const formatTestPOM = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+<dependencies>
+   <dependency><scope>test</scope><artifactId>junit-jupiter</artifactId><groupId>org.junit.jupiter</groupId></dependency>
+  <!-- Logging -->
+  <dependency>
+        <groupId>org.slf4j</groupId>
+        <artifactId>slf4j-api</artifactId>
+  </dependency>
+  <dependency><groupId>com.google.guava</groupId><artifactId>guava</artifactId></dependency>
+</dependencies>
+  <version>1.0.0</version>
+  <artifactId>demo</artifactId>
+  <groupId>com.example</groupId>
+  <modelVersion>4.0.0</modelVersion>
+  <build>
+    <plugins>
+      <plugin>
+        <artifactId>maven-antrun-plugin</artifactId>
+        <configuration><target><echo message="kept as written"/></target></configuration>
+      </plugin>
+    </plugins>
+  </build>
+</project>
+`
+
+func TestFormat(t *testing.T) {
+	formatted, err := Format([]byte(formatTestPOM), FormatOptions{Indent: IndentTwoSpaces, SortDependencies: true})
+	if err != nil {
+		t.Fatalf("Expected POM to format, got %v", err)
+	}
+	text := string(formatted)
+
+	order := []string{"<modelVersion>", "<groupId>com.example", "<artifactId>demo", "<version>1.0.0", "<dependencies>", "<build>"}
+	last := -1
+	for _, tag := range order {
+		i := strings.Index(text, tag)
+		if i < last {
+			t.Errorf("Expected %s in canonical order, got:\n%s", tag, text)
+		}
+		last = i
+	}
+
+	guava := strings.Index(text, "guava")
+	slf4j := strings.Index(text, "<!-- Logging -->")
+	junit := strings.Index(text, "junit-jupiter")
+	if !(guava < slf4j && slf4j < junit) {
+		t.Errorf("Expected dependencies sorted by scope and groupId with their comments, got:\n%s", text)
+	}
+	for _, want := range []string{
+		"  <dependencies>\n    <dependency>\n      <groupId>com.google.guava</groupId>",
+		"<groupId>org.junit.jupiter</groupId>\n      <artifactId>junit-jupiter</artifactId>\n      <scope>test</scope>",
+		`<echo message="kept as written"/>`,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected formatted POM to contain %q, got:\n%s", want, text)
+		}
+	}
+
+	if _, err := Format([]byte(formatTestPOM), FormatOptions{Indent: "3"}); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected ErrInvalidFormat for an unknown indent, got %v", err)
+	}
+}
+
+func TestFormatIsIdempotent(t *testing.T) {
+	banner := &Banner{Tool: "pom-manager", Version: "1.0"}
+	for _, options := range []FormatOptions{
+		{},
+		{Indent: IndentTwoSpaces, SortDependencies: true},
+		{Indent: IndentTabs},
+		{SortDependencies: true, Banner: banner},
+	} {
+		once, err := Format([]byte(formatTestPOM), options)
+		if err != nil {
+			t.Fatalf("Expected POM to format with %+v, got %v", options, err)
+		}
+		twice, err := Format(once, options)
+		if err != nil {
+			t.Fatalf("Expected formatted POM to format with %+v, got %v", options, err)
+		}
+		if string(once) != string(twice) {
+			t.Errorf("Expected formatting twice with %+v to change nothing, got:\n%s\nthen:\n%s", options, once, twice)
+		}
+	}
+}
@@ -46,6 +46,9 @@ func (g *defaultGenerator) Generate(project *Project) ([]byte, error) {
 	// Create XML document
 	doc := etree.NewDocument()
 	doc.CreateProcInst("xml", `version="1.0" encoding="UTF-8"`)
+	if project.Banner != nil {
+		doc.CreateComment(project.Banner.String())
+	}
 
 	// Create project root element
 	root := doc.CreateElement("project")
@@ -66,15 +69,19 @@ func (g *defaultGenerator) Generate(project *Project) ([]byte, error) {
 		g.addParent(root, project.Parent)
 	}
 
-	// Add coordinates
-	groupID := root.CreateElement("groupId")
-	groupID.SetText(project.GroupID)
+	// Add coordinates (inherited ones are left out while they still match the parent)
+	if !project.InheritsGroupID || project.Parent == nil || project.Parent.GroupID != project.GroupID {
+		groupID := root.CreateElement("groupId")
+		groupID.SetText(project.GroupID)
+	}
 
 	artifactID := root.CreateElement("artifactId")
 	artifactID.SetText(project.ArtifactID)
 
-	version := root.CreateElement("version")
-	version.SetText(project.Version)
+	if !project.InheritsVersion || project.Parent == nil || project.Parent.Version != project.Version {
+		version := root.CreateElement("version")
+		version.SetText(project.Version)
+	}
 
 	// Add packaging
 	if project.Packaging != "" && project.Packaging != DefaultPackaging {
@@ -102,21 +109,14 @@ func (g *defaultGenerator) Generate(project *Project) ([]byte, error) {
 		}
 	}
 
-	// Add properties (sorted alphabetically for consistent output)
-	if len(project.Properties) > 0 {
-		properties := root.CreateElement("properties")
+	// Add properties
+	g.addProperties(root, project.Properties)
 
-		// Sort property keys alphabetically
-		keys := make([]string, 0, len(project.Properties))
-		for key := range project.Properties {
-			keys = append(keys, key)
-		}
-		sort.Strings(keys)
-
-		// Add properties in sorted order
-		for _, key := range keys {
-			prop := properties.CreateElement(key)
-			prop.SetText(project.Properties[key])
+	// Add dependency management
+	if len(project.DependencyManagement) > 0 {
+		managed := root.CreateElement("dependencyManagement").CreateElement("dependencies")
+		for _, dep := range project.DependencyManagement {
+			g.addDependency(managed, dep)
 		}
 	}
 
@@ -133,6 +133,14 @@ func (g *defaultGenerator) Generate(project *Project) ([]byte, error) {
 		g.addBuild(root, project.Build)
 	}
 
+	// Add profiles
+	if len(project.Profiles) > 0 {
+		profiles := root.CreateElement("profiles")
+		for _, profile := range project.Profiles {
+			g.addProfile(profiles, profile)
+		}
+	}
+
 	// Set indentation for pretty-print (4 spaces)
 	doc.Indent(4)
 
@@ -145,9 +153,10 @@ func (g *defaultGenerator) Generate(project *Project) ([]byte, error) {
 	return xmlBytes, nil
 }
 
-// GenerateToFile generates XML and writes to file
+// GenerateToFile generates XML and writes to file, with the banner
+// BannerFor gives the file
 func (g *defaultGenerator) GenerateToFile(project *Project, path string) error {
-	xmlBytes, err := g.Generate(project)
+	xmlBytes, err := g.Generate(StampBanner(project, path))
 	if err != nil {
 		return err
 	}
@@ -159,6 +168,53 @@ func (g *defaultGenerator) GenerateToFile(project *Project, path string) error {
 	return nil
 }
 
+// DependencySnippet returns the <dependency> elements of deps, as they would
+// appear in a POM, for pasting into another project
+func DependencySnippet(deps []Dependency) (string, error) {
+	return snippet(func(g *defaultGenerator, parent *etree.Element) {
+		for _, dep := range deps {
+			g.addDependency(parent, dep)
+		}
+	})
+}
+
+// PluginSnippet returns the <plugin> element of a plugin, as it would
+// appear in a POM
+func PluginSnippet(plugin Plugin) (string, error) {
+	return snippet(func(g *defaultGenerator, parent *etree.Element) {
+		g.addPlugin(parent, plugin)
+	})
+}
+
+// ProfileSnippet returns the <profile> element of a profile, as it would
+// appear in a POM
+func ProfileSnippet(profile Profile) (string, error) {
+	return snippet(func(g *defaultGenerator, parent *etree.Element) {
+		g.addProfile(parent, profile)
+	})
+}
+
+// PropertySnippet returns the element of a property, such as
+// <java.version>17</java.version>
+func PropertySnippet(key, value string) (string, error) {
+	return snippet(func(g *defaultGenerator, parent *etree.Element) {
+		parent.CreateElement(key).SetText(value)
+	})
+}
+
+// snippet returns the elements add creates, indented as in a POM
+func snippet(add func(g *defaultGenerator, parent *etree.Element)) (string, error) {
+	doc := etree.NewDocument()
+	add(&defaultGenerator{}, &doc.Element)
+	doc.Indent(4)
+
+	text, err := doc.WriteToString()
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrGenerationFailed, err)
+	}
+	return text, nil
+}
+
 // addDependency adds a dependency element
 func (g *defaultGenerator) addDependency(parent *etree.Element, dep Dependency) {
 	dependency := parent.CreateElement("dependency")
@@ -169,14 +225,32 @@ func (g *defaultGenerator) addDependency(parent *etree.Element, dep Dependency)
 	artifactID := dependency.CreateElement("artifactId")
 	artifactID.SetText(dep.ArtifactID)
 
-	version := dependency.CreateElement("version")
-	version.SetText(dep.Version)
+	// Managed dependencies take their version from dependencyManagement
+	if dep.Version != "" {
+		version := dependency.CreateElement("version")
+		version.SetText(dep.Version)
+	}
+
+	if dep.Type != "" && dep.Type != DefaultDependencyType {
+		depType := dependency.CreateElement("type")
+		depType.SetText(dep.Type)
+	}
+
+	if dep.Classifier != "" {
+		classifier := dependency.CreateElement("classifier")
+		classifier.SetText(dep.Classifier)
+	}
 
 	if dep.Scope != "" && dep.Scope != DefaultScope {
 		scope := dependency.CreateElement("scope")
 		scope.SetText(dep.Scope)
 	}
 
+	if dep.SystemPath != "" {
+		systemPath := dependency.CreateElement("systemPath")
+		systemPath.SetText(dep.SystemPath)
+	}
+
 	if dep.Optional {
 		optional := dependency.CreateElement("optional")
 		optional.SetText("true")
@@ -195,6 +269,108 @@ func (g *defaultGenerator) addDependency(parent *etree.Element, dep Dependency)
 	}
 }
 
+// addProperties adds a properties element, sorted alphabetically for
+// consistent output
+func (g *defaultGenerator) addProperties(parent *etree.Element, props map[string]string) {
+	if len(props) == 0 {
+		return
+	}
+	properties := parent.CreateElement("properties")
+
+	// Sort property keys alphabetically
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Add properties in sorted order
+	for _, key := range keys {
+		prop := properties.CreateElement(key)
+		prop.SetText(props[key])
+	}
+}
+
+// addProfile adds a profile element
+func (g *defaultGenerator) addProfile(parent *etree.Element, profile Profile) {
+	profileElem := parent.CreateElement("profile")
+
+	id := profileElem.CreateElement("id")
+	id.SetText(profile.ID)
+
+	if profile.Activation != nil {
+		g.addActivation(profileElem, profile.Activation)
+	}
+
+	if len(profile.Modules) > 0 {
+		modules := profileElem.CreateElement("modules")
+		for _, mod := range profile.Modules {
+			module := modules.CreateElement("module")
+			module.SetText(mod)
+		}
+	}
+
+	g.addProperties(profileElem, profile.Properties)
+
+	if len(profile.Dependencies) > 0 {
+		dependencies := profileElem.CreateElement("dependencies")
+		for _, dep := range profile.Dependencies {
+			g.addDependency(dependencies, dep)
+		}
+	}
+
+	if profile.Build != nil {
+		g.addBuild(profileElem, profile.Build)
+	}
+}
+
+// addActivation adds a profile's activation element
+func (g *defaultGenerator) addActivation(parent *etree.Element, activation *Activation) {
+	activationElem := parent.CreateElement("activation")
+
+	if activation.ActiveByDefault {
+		activeByDefault := activationElem.CreateElement("activeByDefault")
+		activeByDefault.SetText("true")
+	}
+
+	if activation.JDK != "" {
+		jdk := activationElem.CreateElement("jdk")
+		jdk.SetText(activation.JDK)
+	}
+
+	if activation.OS != nil {
+		osElem := activationElem.CreateElement("os")
+		for _, field := range []struct{ tag, value string }{
+			{"name", activation.OS.Name},
+			{"family", activation.OS.Family},
+			{"arch", activation.OS.Arch},
+			{"version", activation.OS.Version},
+		} {
+			if field.value != "" {
+				osElem.CreateElement(field.tag).SetText(field.value)
+			}
+		}
+	}
+
+	if activation.Property != nil {
+		property := activationElem.CreateElement("property")
+		property.CreateElement("name").SetText(activation.Property.Name)
+		if activation.Property.Value != "" {
+			property.CreateElement("value").SetText(activation.Property.Value)
+		}
+	}
+
+	if activation.File != nil {
+		file := activationElem.CreateElement("file")
+		if activation.File.Exists != "" {
+			file.CreateElement("exists").SetText(activation.File.Exists)
+		}
+		if activation.File.Missing != "" {
+			file.CreateElement("missing").SetText(activation.File.Missing)
+		}
+	}
+}
+
 // addBuild adds a build element
 func (g *defaultGenerator) addBuild(parent *etree.Element, build *Build) {
 	buildElem := parent.CreateElement("build")
@@ -238,6 +414,10 @@ func (g *defaultGenerator) addPlugin(parent *etree.Element, plugin Plugin) {
 		version.SetText(plugin.Version)
 	}
 
+	if plugin.Extensions {
+		pluginElem.CreateElement("extensions").SetText("true")
+	}
+
 	// Add executions
 	if len(plugin.Executions) > 0 {
 		executions := pluginElem.CreateElement("executions")
@@ -245,6 +425,8 @@ func (g *defaultGenerator) addPlugin(parent *etree.Element, plugin Plugin) {
 			g.addExecution(executions, exec)
 		}
 	}
+
+	g.addConfiguration(pluginElem, plugin.Configuration)
 }
 
 // addExecution adds an execution element
@@ -269,6 +451,57 @@ func (g *defaultGenerator) addExecution(parent *etree.Element, exec PluginExecut
 			goalElem.SetText(goal)
 		}
 	}
+
+	g.addConfiguration(execElem, exec.Configuration)
+}
+
+// addConfiguration adds a configuration element, with its parameters
+// sorted by name
+func (g *defaultGenerator) addConfiguration(parent *etree.Element, config *Configuration) {
+	if config == nil || len(config.Data) == 0 {
+		return
+	}
+	configElem := parent.CreateElement("configuration")
+	addConfigurationChildren(configElem, config.Data)
+}
+
+// addConfigurationChildren adds an element per key of values, sorted by
+// name, and an element per item of list values
+func addConfigurationChildren(parent *etree.Element, values map[string]interface{}) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if items, ok := values[key].([]interface{}); ok {
+			for _, item := range items {
+				addConfigurationValue(parent, key, item)
+			}
+			continue
+		}
+		addConfigurationValue(parent, key, values[key])
+	}
+}
+
+// addConfigurationValue adds the element of one configuration value
+func addConfigurationValue(parent *etree.Element, key string, value interface{}) {
+	switch v := value.(type) {
+	case ConfigurationXML:
+		doc := etree.NewDocument()
+		if err := doc.ReadFromString(string(v)); err == nil && doc.Root() != nil {
+			parent.AddChild(doc.Root())
+			return
+		}
+		parent.CreateElement(key).SetText(string(v))
+	case map[string]interface{}:
+		addConfigurationChildren(parent.CreateElement(key), v)
+	case nil:
+		parent.CreateElement(key)
+	default:
+		parent.CreateElement(key).SetText(fmt.Sprint(v))
+	}
 }
 
 // addParent adds a parent element
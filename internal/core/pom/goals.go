@@ -0,0 +1,214 @@
+package pom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PluginGoal is a goal of a plugin, as described by the plugin's descriptor
+type PluginGoal struct {
+	Name        string
+	Phase       string // Phase the goal binds to by default, "" when it has none
+	Description string
+}
+
+// helpGoal is generated into every plugin built with the plugin tools
+var helpGoal = PluginGoal{"help", "", "Shows the plugin's goals and their parameters"}
+
+// pluginGoals lists the goals of well-known plugins by groupId:artifactId,
+// most used first
+var pluginGoals = map[string][]PluginGoal{
+	DefaultPluginGroupID + ":maven-clean-plugin": {
+		{"clean", PhaseClean, "Deletes the build directory"},
+	},
+	DefaultPluginGroupID + ":maven-compiler-plugin": {
+		{"compile", PhaseCompile, "Compiles the main sources"},
+		{"testCompile", PhaseTestCompile, "Compiles the test sources"},
+	},
+	DefaultPluginGroupID + ":maven-resources-plugin": {
+		{"resources", PhaseProcessResources, "Copies the main resources to the output directory"},
+		{"testResources", PhaseProcessTestResources, "Copies the test resources to the test output directory"},
+		{"copy-resources", "", "Copies resources to a directory of your choice"},
+	},
+	DefaultPluginGroupID + ":maven-surefire-plugin": {
+		{"test", PhaseTest, "Runs the unit tests"},
+	},
+	DefaultPluginGroupID + ":maven-failsafe-plugin": {
+		{"integration-test", PhaseIntegrationTest, "Runs the integration tests without failing the build"},
+		{"verify", PhaseVerify, "Fails the build when integration tests failed"},
+	},
+	DefaultPluginGroupID + ":maven-jar-plugin": {
+		{"jar", PhasePackage, "Builds a jar of the main classes"},
+		{"test-jar", PhasePackage, "Builds a jar of the test classes"},
+	},
+	DefaultPluginGroupID + ":maven-war-plugin": {
+		{"war", PhasePackage, "Builds the web application archive"},
+		{"exploded", PhasePackage, "Assembles the web application in a directory"},
+		{"inplace", "", "Assembles the web application in the webapp source directory"},
+	},
+	DefaultPluginGroupID + ":maven-install-plugin": {
+		{"install", PhaseInstall, "Installs the project's artifacts in the local repository"},
+		{"install-file", "", "Installs a file in the local repository"},
+	},
+	DefaultPluginGroupID + ":maven-deploy-plugin": {
+		{"deploy", PhaseDeploy, "Deploys the project's artifacts to the remote repository"},
+		{"deploy-file", "", "Deploys a file to a remote repository"},
+	},
+	DefaultPluginGroupID + ":maven-source-plugin": {
+		{"jar-no-fork", PhasePackage, "Builds a jar of the main sources without running the lifecycle again"},
+		{"jar", PhasePackage, "Builds a jar of the main sources"},
+		{"test-jar-no-fork", PhasePackage, "Builds a jar of the test sources without running the lifecycle again"},
+		{"test-jar", PhasePackage, "Builds a jar of the test sources"},
+		{"aggregate", "", "Builds a jar of the sources of all modules"},
+	},
+	DefaultPluginGroupID + ":maven-javadoc-plugin": {
+		{"jar", PhasePackage, "Builds a jar of the Javadoc"},
+		{"javadoc", PhaseGenerateSources, "Generates the Javadoc"},
+		{"test-jar", PhasePackage, "Builds a jar of the test Javadoc"},
+		{"aggregate", "", "Generates the Javadoc of all modules"},
+		{"aggregate-jar", PhasePackage, "Builds a jar of the Javadoc of all modules"},
+	},
+	DefaultPluginGroupID + ":maven-assembly-plugin": {
+		{"single", PhasePackage, "Builds the assemblies of the configured descriptors"},
+	},
+	DefaultPluginGroupID + ":maven-shade-plugin": {
+		{"shade", PhasePackage, "Builds an uber-jar including the dependencies"},
+	},
+	DefaultPluginGroupID + ":maven-dependency-plugin": {
+		{"copy-dependencies", PhaseProcessSources, "Copies the project's dependencies to a directory"},
+		{"unpack-dependencies", PhaseProcessSources, "Unpacks the project's dependencies to a directory"},
+		{"copy", PhaseProcessSources, "Copies the configured artifacts to a directory"},
+		{"unpack", PhaseProcessSources, "Unpacks the configured artifacts to a directory"},
+		{"build-classpath", PhaseGenerateSources, "Writes the classpath of the dependencies to a file or property"},
+		{"resolve", PhaseGenerateSources, "Resolves the dependencies"},
+		{"analyze-only", PhaseVerify, "Reports used undeclared and unused declared dependencies"},
+		{"analyze", "", "Builds the project, then reports used undeclared and unused declared dependencies"},
+		{"tree", "", "Shows the dependency tree"},
+		{"list", "", "Lists the resolved dependencies"},
+		{"go-offline", "", "Downloads everything the build needs to run offline"},
+		{"purge-local-repository", "", "Removes the project's dependencies from the local repository"},
+	},
+	DefaultPluginGroupID + ":maven-enforcer-plugin": {
+		{"enforce", PhaseValidate, "Checks the configured rules, such as the Maven and Java versions"},
+		{"display-info", "", "Shows the Maven, Java and OS versions rules are checked against"},
+	},
+	DefaultPluginGroupID + ":maven-site-plugin": {
+		{"site", PhaseSite, "Generates the project's site"},
+		{"deploy", PhaseSiteDeploy, "Deploys the site to the distribution management site URL"},
+		{"stage", "", "Generates the site of all modules in a staging directory"},
+		{"stage-deploy", "", "Deploys the staged site"},
+		{"run", "", "Serves the site on a local web server"},
+		{"jar", PhasePackage, "Builds a jar of the site"},
+		{"attach-descriptor", PhasePackage, "Attaches the site descriptor to be installed and deployed"},
+		{"effective-site", "", "Shows the site descriptor after inheritance and interpolation"},
+	},
+	DefaultPluginGroupID + ":maven-antrun-plugin": {
+		{"run", "", "Runs the configured Ant tasks"},
+	},
+	DefaultPluginGroupID + ":maven-gpg-plugin": {
+		{"sign", PhaseVerify, "Signs the project's artifacts with GnuPG"},
+	},
+	"org.codehaus.mojo:exec-maven-plugin": {
+		{"java", "", "Runs a Java class in the Maven JVM"},
+		{"exec", "", "Runs a program in a separate process"},
+	},
+	"org.codehaus.mojo:build-helper-maven-plugin": {
+		{"add-source", PhaseGenerateSources, "Adds a source directory"},
+		{"add-test-source", PhaseGenerateTestSources, "Adds a test source directory"},
+		{"add-resource", PhaseGenerateResources, "Adds a resource directory"},
+		{"add-test-resource", PhaseGenerateTestResources, "Adds a test resource directory"},
+		{"attach-artifact", PhasePackage, "Attaches extra files to be installed and deployed"},
+		{"parse-version", PhaseValidate, "Splits the project version into properties"},
+	},
+	"org.codehaus.mojo:versions-maven-plugin": {
+		{"display-dependency-updates", "", "Lists dependencies with newer versions"},
+		{"display-plugin-updates", "", "Lists plugins with newer versions"},
+		{"display-property-updates", "", "Lists version properties with newer versions"},
+		{"use-latest-releases", "", "Updates dependencies to their latest releases"},
+		{"set", "", "Sets the project version"},
+		{"commit", "", "Removes the backup POMs of set"},
+		{"revert", "", "Restores the backup POMs of set"},
+	},
+	"org.jacoco:jacoco-maven-plugin": {
+		{"prepare-agent", PhaseInitialize, "Sets up the coverage agent for the tests"},
+		{"report", PhaseVerify, "Writes the coverage report of the unit tests"},
+		{"check", PhaseVerify, "Fails the build when coverage is below the configured limits"},
+		{"prepare-agent-integration", PhasePreIntegrationTest, "Sets up the coverage agent for the integration tests"},
+		{"report-integration", PhaseVerify, "Writes the coverage report of the integration tests"},
+		{"merge", PhaseGenerateResources, "Merges coverage data files"},
+		{"report-aggregate", PhaseVerify, "Writes a coverage report covering several modules"},
+	},
+	"org.springframework.boot:spring-boot-maven-plugin": {
+		{"repackage", PhasePackage, "Repackages the jar or war to be executable"},
+		{"build-info", PhaseGenerateResources, "Writes build information for the actuator"},
+		{"build-image", PhasePackage, "Builds an OCI image with buildpacks"},
+		{"run", "", "Runs the application"},
+		{"start", PhasePreIntegrationTest, "Starts the application for integration tests"},
+		{"stop", PhasePostIntegrationTest, "Stops the application started by start"},
+	},
+}
+
+// PluginGoals returns the goals of a plugin, most used first, or nil when
+// the plugin is not known
+func PluginGoals(plugin Plugin) []PluginGoal {
+	goals, ok := pluginGoals[pluginCatalogKey(plugin)]
+	if !ok {
+		return nil
+	}
+	return append(append([]PluginGoal(nil), goals...), helpGoal)
+}
+
+// LookupGoal returns a goal of a known plugin
+func LookupGoal(plugin Plugin, name string) (PluginGoal, bool) {
+	for _, goal := range PluginGoals(plugin) {
+		if goal.Name == name {
+			return goal, true
+		}
+	}
+	return PluginGoal{}, false
+}
+
+// CompleteGoal returns the goals of a plugin whose names start with prefix,
+// ignoring case, for completion while typing
+func CompleteGoal(plugin Plugin, prefix string) []PluginGoal {
+	var matches []PluginGoal
+	prefix = strings.ToLower(prefix)
+	for _, goal := range PluginGoals(plugin) {
+		if strings.HasPrefix(strings.ToLower(goal.Name), prefix) {
+			matches = append(matches, goal)
+		}
+	}
+	return matches
+}
+
+// CheckGoal returns an error when a known plugin has no goal name,
+// suggesting a close one. Goals of plugins not in the catalog, and
+// property references, are accepted.
+func CheckGoal(plugin Plugin, name string) error {
+	goals := PluginGoals(plugin)
+	if goals == nil || strings.Contains(name, "${") {
+		return nil
+	}
+
+	names := make([]string, len(goals))
+	for i, goal := range goals {
+		if goal.Name == name {
+			return nil
+		}
+		names[i] = goal.Name
+	}
+	if suggestion := closestMatch(name, names); suggestion != "" {
+		return fmt.Errorf("%s has no goal %s; did you mean %s?", plugin.ArtifactID, name, suggestion)
+	}
+	return fmt.Errorf("%s has no goal %s; its goals are %s", plugin.ArtifactID, name, strings.Join(names, ", "))
+}
+
+// pluginCatalogKey returns groupId:artifactId of a plugin, with Maven's
+// default groupId when none is declared
+func pluginCatalogKey(plugin Plugin) string {
+	groupID := plugin.GroupID
+	if groupID == "" {
+		groupID = DefaultPluginGroupID
+	}
+	return groupID + ":" + plugin.ArtifactID
+}
@@ -0,0 +1,142 @@
+package pom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// gradleStringNotation matches a configuration followed by a quoted
+	// "group:artifact:version" string, in Groovy (implementation 'g:a:v')
+	// and Kotlin DSL (implementation("g:a:v")), optionally wrapped in
+	// platform() or enforcedPlatform()
+	gradleStringNotation = regexp.MustCompile(`(?m)^\s*(\w+)\s*\(?\s*(?:(platform|enforcedPlatform)\s*\(\s*)?["']([^"'\s]+)["']`)
+
+	// gradleMapNotation matches group: 'g', name: 'a', version: 'v' in Groovy
+	// and group = "g", name = "a", version = "v" in Kotlin DSL
+	gradleMapNotation = regexp.MustCompile(`(?m)^\s*(\w+)\s*\(?\s*group\s*[:=]\s*["']([^"']+)["']\s*,\s*name\s*[:=]\s*["']([^"']+)["']` +
+		`(?:\s*,\s*version\s*[:=]\s*["']([^"']+)["'])?`)
+
+	// gradleProjectNotation matches dependencies on other projects of the
+	// build: project(':core') and project(path: ':core')
+	gradleProjectNotation = regexp.MustCompile(`(?m)^\s*\w+\s*\(?\s*project\s*\(\s*(?:path\s*[:=]\s*)?["']([^"']+)["']`)
+
+	// gradleCatalogNotation matches references to version catalog entries,
+	// such as libs.slf4j.api or platform(libs.spring.bom)
+	gradleCatalogNotation = regexp.MustCompile(`(?m)^\s*\w+\s*\(?\s*(?:(?:platform|enforcedPlatform)\s*\(\s*)?(libs\.[\w.]+)`)
+
+	// gradleVariable matches $name and ${name} interpolations in versions
+	gradleVariable = regexp.MustCompile(`\$\{?([\w.]+)\}?`)
+)
+
+// gradleConfigurations maps Gradle dependency configurations to Maven
+// scopes. Configurations missing here, such as annotationProcessor or
+// buildscript classpath, have no Maven equivalent.
+var gradleConfigurations = map[string]string{
+	"api":                           ScopeCompile,
+	"implementation":                ScopeCompile,
+	"compile":                       ScopeCompile,
+	"compileOnly":                   ScopeProvided,
+	"compileOnlyApi":                ScopeProvided,
+	"providedCompile":               ScopeProvided,
+	"runtimeOnly":                   ScopeRuntime,
+	"runtime":                       ScopeRuntime,
+	"providedRuntime":               ScopeProvided,
+	"testImplementation":            ScopeTest,
+	"testCompile":                   ScopeTest,
+	"testCompileOnly":               ScopeTest,
+	"testRuntimeOnly":               ScopeTest,
+	"testRuntime":                   ScopeTest,
+	"testFixturesApi":               ScopeTest,
+	"integrationTestImplementation": ScopeTest,
+}
+
+// ParseGradleDependencies reads dependencies in Gradle notation, either a
+// build.gradle(.kts) or lines pasted from documentation. Both the
+// "group:artifact:version[:classifier][@type]" string and the group/name/
+// version map notation are understood; configurations become Maven scopes.
+// Version variables become Maven property references. Dependencies on other
+// projects of the build and version catalog references (libs.*) cannot be
+// resolved from the file alone and are reported as warnings.
+func ParseGradleDependencies(data []byte) (*DependencyImport, error) {
+	text := stripComments(string(data), `"'`)
+	result := &DependencyImport{}
+
+	for _, match := range gradleStringNotation.FindAllStringSubmatch(text, -1) {
+		configuration, platform, notation := match[1], match[2], match[3]
+		if !strings.Contains(notation, ":") {
+			continue // Plugin ids and the like
+		}
+
+		dep, ok := parseGradleNotation(notation)
+		if !ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("skipped %s: expected group:artifact:version", notation))
+			continue
+		}
+		if platform != "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("skipped %s:%s: %s() imports a BOM, add it with Add BOM instead",
+				dep.GroupID, dep.ArtifactID, platform))
+			continue
+		}
+		addGradleDependency(result, configuration, dep)
+	}
+
+	for _, match := range gradleMapNotation.FindAllStringSubmatch(text, -1) {
+		dep := Dependency{GroupID: match[2], ArtifactID: match[3], Version: gradleVersionReference(match[4])}
+		addGradleDependency(result, match[1], dep)
+	}
+
+	for _, match := range gradleProjectNotation.FindAllStringSubmatch(text, -1) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("skipped project(%q): a project of the Gradle build, add its Maven artifact instead", match[1]))
+	}
+	for _, match := range gradleCatalogNotation.FindAllStringSubmatch(text, -1) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("skipped %s: version catalog entries are not resolved, import the catalog (libs.versions.toml) instead", match[1]))
+	}
+
+	for _, dep := range result.Dependencies {
+		if strings.Contains(dep.Version, "${") {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s:%s: define the property used by version %s",
+				dep.GroupID, dep.ArtifactID, dep.Version))
+		}
+	}
+
+	return result, nil
+}
+
+// parseGradleNotation splits "group:artifact[:version[:classifier]][@type]"
+func parseGradleNotation(notation string) (Dependency, bool) {
+	notation, depType, _ := strings.Cut(notation, "@")
+	parts := strings.Split(notation, ":")
+	if len(parts) < 2 || len(parts) > 4 || parts[0] == "" || parts[1] == "" {
+		return Dependency{}, false
+	}
+
+	dep := Dependency{GroupID: parts[0], ArtifactID: parts[1], Type: depType}
+	if len(parts) > 2 {
+		dep.Version = gradleVersionReference(parts[2])
+	}
+	if len(parts) > 3 {
+		dep.Classifier = parts[3]
+	}
+	return dep, true
+}
+
+// gradleVersionReference turns Gradle interpolations such as $slf4jVersion
+// into Maven property references
+func gradleVersionReference(version string) string {
+	return gradleVariable.ReplaceAllString(version, "$${$1}")
+}
+
+// addGradleDependency adds dep with the scope of its configuration, or a
+// warning when the configuration has no Maven equivalent
+func addGradleDependency(result *DependencyImport, configuration string, dep Dependency) {
+	scope, ok := gradleConfigurations[configuration]
+	if !ok {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("skipped %s:%s: configuration %q has no Maven scope",
+			dep.GroupID, dep.ArtifactID, configuration))
+		return
+	}
+	dep.Scope = scope
+	result.Dependencies = append(result.Dependencies, dep)
+}
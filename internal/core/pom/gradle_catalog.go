@@ -0,0 +1,214 @@
+package pom
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// GradleLibrary is one entry of the [libraries] table of a Gradle version
+// catalog (libs.versions.toml)
+type GradleLibrary struct {
+	Alias      string
+	GroupID    string
+	ArtifactID string
+	Version    string // Literal version ("" when it uses VersionRef or has none)
+	VersionRef string // Key in [versions] the version refers to
+}
+
+// GradleCatalog is the content of a Gradle libs.versions.toml relevant to Maven
+type GradleCatalog struct {
+	Versions  map[string]string
+	Libraries []GradleLibrary // Sorted by alias
+}
+
+// GradleImport summarizes what ImportGradleCatalog changed
+type GradleImport struct {
+	Properties []string // Properties added or updated
+	Managed    []string // groupId:artifactId entries added or updated
+	Skipped    []string // Aliases without a usable version
+}
+
+// LoadGradleCatalog reads a Gradle libs.versions.toml file
+func LoadGradleCatalog(path string) (*GradleCatalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrFileNotFound, path)
+		}
+		return nil, fmt.Errorf("reading Gradle catalog %s: %w", path, err)
+	}
+
+	catalog, err := ParseGradleCatalog(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return catalog, nil
+}
+
+// ParseGradleCatalog parses the [versions] and [libraries] tables of a Gradle
+// version catalog. Libraries may use the "group:artifact:version" string
+// notation or a table with module or group/name and version or version.ref.
+func ParseGradleCatalog(data []byte) (*GradleCatalog, error) {
+	var raw struct {
+		Versions  map[string]any `toml:"versions"`
+		Libraries map[string]any `toml:"libraries"`
+	}
+	if err := toml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: parsing Gradle catalog: %v", ErrInvalidFormat, err)
+	}
+
+	catalog := &GradleCatalog{Versions: make(map[string]string)}
+	for key, value := range raw.Versions {
+		catalog.Versions[key] = gradleVersion(value)
+	}
+
+	for alias, value := range raw.Libraries {
+		library, err := parseGradleLibrary(alias, value)
+		if err != nil {
+			return nil, err
+		}
+		catalog.Libraries = append(catalog.Libraries, library)
+	}
+	sort.Slice(catalog.Libraries, func(i, j int) bool {
+		return catalog.Libraries[i].Alias < catalog.Libraries[j].Alias
+	})
+
+	return catalog, nil
+}
+
+// parseGradleLibrary converts one [libraries] entry
+func parseGradleLibrary(alias string, value any) (GradleLibrary, error) {
+	library := GradleLibrary{Alias: alias}
+
+	switch v := value.(type) {
+	case string:
+		parts := strings.Split(v, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return library, fmt.Errorf("%w: library %s: expected group:artifact[:version], got %q", ErrInvalidFormat, alias, v)
+		}
+		library.GroupID, library.ArtifactID = parts[0], parts[1]
+		if len(parts) == 3 {
+			library.Version = parts[2]
+		}
+
+	case map[string]any:
+		if module, ok := v["module"].(string); ok {
+			group, name, found := strings.Cut(module, ":")
+			if !found {
+				return library, fmt.Errorf("%w: library %s: module must be group:artifact, got %q", ErrInvalidFormat, alias, module)
+			}
+			library.GroupID, library.ArtifactID = group, name
+		} else {
+			library.GroupID, _ = v["group"].(string)
+			library.ArtifactID, _ = v["name"].(string)
+		}
+
+		// version.ref = "x" decodes as version = { ref = "x" }
+		if version, ok := v["version"].(map[string]any); ok {
+			if ref, ok := version["ref"].(string); ok {
+				library.VersionRef = ref
+			} else {
+				library.Version = gradleVersion(version)
+			}
+		} else {
+			library.Version = gradleVersion(v["version"])
+		}
+
+	default:
+		return library, fmt.Errorf("%w: library %s: unsupported notation", ErrInvalidFormat, alias)
+	}
+
+	if library.GroupID == "" || library.ArtifactID == "" {
+		return library, fmt.Errorf("%w: library %s: group and artifact are required", ErrMissingRequired, alias)
+	}
+	return library, nil
+}
+
+// gradleVersion returns the version of a [versions] entry, which is either a
+// plain string or a rich version table; rich versions use the strictest
+// constraint given
+func gradleVersion(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]any:
+		for _, key := range []string{"strictly", "require", "prefer"} {
+			if version, ok := v[key].(string); ok {
+				return version
+			}
+		}
+	}
+	return ""
+}
+
+// ImportGradleCatalog adds the selected libraries (all of them when aliases
+// is empty) to the project's dependencyManagement. Versions referenced
+// through [versions] become properties so modules share a single value.
+func ImportGradleCatalog(catalog *GradleCatalog, project *Project, aliases []string) GradleImport {
+	selected := make(map[string]bool, len(aliases))
+	for _, alias := range aliases {
+		selected[alias] = true
+	}
+
+	var result GradleImport
+	for _, library := range catalog.Libraries {
+		if len(aliases) > 0 && !selected[library.Alias] {
+			continue
+		}
+
+		version := library.Version
+		if library.VersionRef != "" {
+			refVersion, ok := catalog.Versions[library.VersionRef]
+			if !ok || refVersion == "" {
+				result.Skipped = append(result.Skipped, library.Alias)
+				continue
+			}
+			property := CatalogProperty(library.VersionRef)
+			if project.Properties[property] != refVersion {
+				if project.Properties == nil {
+					project.Properties = make(map[string]string)
+				}
+				project.Properties[property] = refVersion
+				result.Properties = append(result.Properties, property)
+			}
+			version = "${" + property + "}"
+		}
+		if version == "" {
+			result.Skipped = append(result.Skipped, library.Alias)
+			continue
+		}
+
+		if setManagedVersion(project, library.GroupID, library.ArtifactID, version) {
+			result.Managed = append(result.Managed, library.GroupID+":"+library.ArtifactID)
+		}
+	}
+
+	return result
+}
+
+// setManagedVersion adds or updates a dependencyManagement entry and reports
+// whether anything changed. Of repeated entries, the last one, which Maven
+// uses, is updated.
+func setManagedVersion(project *Project, groupID, artifactID, version string) bool {
+	for i := len(project.DependencyManagement) - 1; i >= 0; i-- {
+		dep := project.DependencyManagement[i]
+		if dep.GroupID == groupID && dep.ArtifactID == artifactID && !dep.IsBOM() {
+			if dep.Version == version {
+				return false
+			}
+			project.DependencyManagement[i].Version = version
+			return true
+		}
+	}
+
+	project.DependencyManagement = append(project.DependencyManagement, Dependency{
+		GroupID:    groupID,
+		ArtifactID: artifactID,
+		Version:    version,
+	})
+	return true
+}
@@ -0,0 +1,130 @@
+package pom
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const gradleCatalogTest = `[versions]
+junit = "5.11.0"
+guava = { strictly = "33.2.1-jre" }
+
+[libraries]
+junit-api = { module = "org.junit.jupiter:junit-jupiter-api", version.ref = "junit" }
+guava = { group = "com.google.guava", name = "guava", version.ref = "guava" }
+slf4j = "org.slf4j:slf4j-api:2.0.16"
+commons = { module = "org.apache.commons:commons-lang3", version = { prefer = "3.14.0" } }
+bom-managed = "com.example:managed"
+unknown-ref = { module = "com.example:lib", version.ref = "missing" }
+
+[plugins]
+kotlin = { id = "org.jetbrains.kotlin.jvm", version = "2.0.0" }
+`
+
+func TestParseGradleCatalog(t *testing.T) {
+	catalog, err := ParseGradleCatalog([]byte(gradleCatalogTest))
+	if err != nil {
+		t.Fatalf("Expected catalog to parse, got %v", err)
+	}
+
+	wantVersions := map[string]string{"junit": "5.11.0", "guava": "33.2.1-jre"}
+	if !reflect.DeepEqual(catalog.Versions, wantVersions) {
+		t.Errorf("Expected versions %v, got %v", wantVersions, catalog.Versions)
+	}
+	want := []GradleLibrary{
+		{Alias: "bom-managed", GroupID: "com.example", ArtifactID: "managed"},
+		{Alias: "commons", GroupID: "org.apache.commons", ArtifactID: "commons-lang3", Version: "3.14.0"},
+		{Alias: "guava", GroupID: "com.google.guava", ArtifactID: "guava", VersionRef: "guava"},
+		{Alias: "junit-api", GroupID: "org.junit.jupiter", ArtifactID: "junit-jupiter-api", VersionRef: "junit"},
+		{Alias: "slf4j", GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.16"},
+		{Alias: "unknown-ref", GroupID: "com.example", ArtifactID: "lib", VersionRef: "missing"},
+	}
+	if !reflect.DeepEqual(catalog.Libraries, want) {
+		t.Errorf("Expected libraries sorted by alias %+v, got %+v", want, catalog.Libraries)
+	}
+}
+
+func TestParseGradleCatalogErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		toml    string
+		wantErr error
+		message string
+	}{
+		{"invalid TOML", "[libraries\n", ErrInvalidFormat, "parsing Gradle catalog"},
+		{"short notation", `[libraries]
+lib = "com.example"`, ErrInvalidFormat, "expected group:artifact[:version]"},
+		{"module without artifact", `[libraries]
+lib = { module = "com.example" }`, ErrInvalidFormat, "module must be group:artifact"},
+		{"missing name", `[libraries]
+lib = { group = "com.example" }`, ErrMissingRequired, "group and artifact are required"},
+		{"unsupported notation", `[libraries]
+lib = 42`, ErrInvalidFormat, "unsupported notation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseGradleCatalog([]byte(tt.toml))
+			if !errors.Is(err, tt.wantErr) || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected %v containing %q, got %v", tt.wantErr, tt.message, err)
+			}
+		})
+	}
+}
+
+func TestLoadGradleCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "libs.versions.toml")
+	if err := os.WriteFile(path, []byte(gradleCatalogTest), 0644); err != nil {
+		t.Fatalf("Expected catalog to be written, got %v", err)
+	}
+	if catalog, err := LoadGradleCatalog(path); err != nil || len(catalog.Libraries) != 6 {
+		t.Errorf("Expected 6 libraries, got %+v, %v", catalog, err)
+	}
+
+	if _, err := LoadGradleCatalog(filepath.Join(t.TempDir(), "missing.toml")); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("Expected ErrFileNotFound, got %v", err)
+	}
+}
+
+func TestImportGradleCatalog(t *testing.T) {
+	catalog, err := ParseGradleCatalog([]byte(gradleCatalogTest))
+	if err != nil {
+		t.Fatalf("Expected catalog to parse, got %v", err)
+	}
+	project := &Project{DependencyManagement: []Dependency{
+		{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.9"},
+	}}
+
+	result := ImportGradleCatalog(catalog, project, nil)
+	want := GradleImport{
+		Properties: []string{"guava.version", "junit.version"},
+		Managed: []string{
+			"org.apache.commons:commons-lang3",
+			"com.google.guava:guava",
+			"org.junit.jupiter:junit-jupiter-api",
+			"org.slf4j:slf4j-api",
+		},
+		Skipped: []string{"bom-managed", "unknown-ref"},
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("Expected %+v, got %+v", want, result)
+	}
+	if project.Properties["junit.version"] != "5.11.0" {
+		t.Errorf("Expected the referenced version as a property, got %v", project.Properties)
+	}
+	if got := project.DependencyManagement[0].Version; got != "2.0.16" {
+		t.Errorf("Expected the managed slf4j version updated in place, got %s", got)
+	}
+	if len(project.DependencyManagement) != 4 {
+		t.Errorf("Expected 4 managed dependencies, got %+v", project.DependencyManagement)
+	}
+
+	again := ImportGradleCatalog(catalog, project, []string{"junit-api"})
+	if len(again.Properties) != 0 || len(again.Managed) != 0 {
+		t.Errorf("Expected importing again to change nothing, got %+v", again)
+	}
+}
@@ -0,0 +1,106 @@
+package pom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGradleDependencies(t *testing.T) {
+	tests := []struct {
+		name     string
+		gradle   string
+		want     []Dependency
+		warnings []string
+	}{
+		{
+			name:   "Groovy string notation",
+			gradle: `implementation 'org.slf4j:slf4j-api:2.0.16'`,
+			want:   []Dependency{{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.16", Scope: ScopeCompile}},
+		},
+		{
+			name:   "Kotlin string notation",
+			gradle: `testImplementation("org.junit.jupiter:junit-jupiter:5.11.0")`,
+			want:   []Dependency{{GroupID: "org.junit.jupiter", ArtifactID: "junit-jupiter", Version: "5.11.0", Scope: ScopeTest}},
+		},
+		{
+			name:   "classifier and type",
+			gradle: `testImplementation 'com.example:lib:1.0:tests@jar'`,
+			want:   []Dependency{{GroupID: "com.example", ArtifactID: "lib", Version: "1.0", Classifier: "tests", Type: "jar", Scope: ScopeTest}},
+		},
+		{
+			name: "map notation",
+			gradle: `compileOnly group: 'jakarta.servlet', name: 'jakarta.servlet-api', version: '6.0.0'
+runtimeOnly(group = "org.postgresql", name = "postgresql", version = "42.7.3")`,
+			want: []Dependency{
+				{GroupID: "jakarta.servlet", ArtifactID: "jakarta.servlet-api", Version: "6.0.0", Scope: ScopeProvided},
+				{GroupID: "org.postgresql", ArtifactID: "postgresql", Version: "42.7.3", Scope: ScopeRuntime},
+			},
+		},
+		{
+			name:     "version variable",
+			gradle:   `implementation "org.slf4j:slf4j-api:$slf4jVersion"`,
+			want:     []Dependency{{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "${slf4jVersion}", Scope: ScopeCompile}},
+			warnings: []string{"define the property used by version ${slf4jVersion}"},
+		},
+		{
+			name:     "platform",
+			gradle:   `implementation platform('org.springframework.boot:spring-boot-dependencies:3.3.0')`,
+			warnings: []string{"platform() imports a BOM"},
+		},
+		{
+			name:     "configuration without scope",
+			gradle:   `annotationProcessor 'org.projectlombok:lombok:1.18.32'`,
+			warnings: []string{`configuration "annotationProcessor" has no Maven scope`},
+		},
+		{
+			name: "project dependencies",
+			gradle: `implementation project(':core')
+api(project(path = ":api"))`,
+			warnings: []string{`skipped project(":core")`, `skipped project(":api")`},
+		},
+		{
+			name: "version catalog references",
+			gradle: `implementation libs.slf4j.api
+implementation(platform(libs.spring.bom))`,
+			warnings: []string{"skipped libs.slf4j.api", "skipped libs.spring.bom"},
+		},
+		{
+			name: "comments",
+			gradle: `// implementation 'junit:junit:4.12'
+/* testImplementation 'org.old:gone:1.0' */
+implementation 'org.slf4j:slf4j-api:2.0.16' // 'not:a:dependency'
+repositories { maven { url 'https://repo.example.com/maven' } }`,
+			want: []Dependency{{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.16", Scope: ScopeCompile}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseGradleDependencies([]byte(tt.gradle))
+			if err != nil {
+				t.Fatalf("Expected Gradle notation to parse, got %v", err)
+			}
+			if !reflect.DeepEqual(result.Dependencies, tt.want) {
+				t.Errorf("Expected %+v, got %+v", tt.want, result.Dependencies)
+			}
+			assertWarnings(t, result.Warnings, tt.warnings)
+		})
+	}
+}
+
+func TestDependencyNotation(t *testing.T) {
+	tests := []struct {
+		dep  Dependency
+		want string
+	}{
+		{Dependency{GroupID: "com.example", ArtifactID: "lib", Version: "1.0"}, "com.example:lib:1.0"},
+		{Dependency{GroupID: "com.example", ArtifactID: "lib", Version: "1.0", Classifier: "tests", Type: "jar"}, "com.example:lib:1.0:tests@jar"},
+		{Dependency{GroupID: "com.example", ArtifactID: "lib", Type: "pom"}, "com.example:lib@pom"},
+		{Dependency{GroupID: "com.example", ArtifactID: "lib", Classifier: "sources"}, "com.example:lib::sources"},
+	}
+	for _, tt := range tests {
+		if got := tt.dep.Notation(); got != tt.want {
+			t.Errorf("Expected %s, got %s", tt.want, got)
+		}
+	}
+}
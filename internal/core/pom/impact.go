@@ -0,0 +1,161 @@
+package pom
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PluginRemovalImpact lists what is lost along with a plugin: its
+// executions and configuration, described for a confirmation. Nothing is
+// listed for a plugin that only pins a version.
+func PluginRemovalImpact(plugin Plugin) []string {
+	var impact []string
+	for _, exec := range plugin.Executions {
+		id := exec.ID
+		if id == "" {
+			id = "default"
+		}
+		phase := "the default phase"
+		if exec.Phase != "" {
+			phase = "phase " + exec.Phase
+		}
+		impact = append(impact, fmt.Sprintf("execution %s: %s in %s", id, strings.Join(exec.Goals, ", "), phase))
+	}
+	if plugin.Configuration != nil && len(plugin.Configuration.Data) > 0 {
+		impact = append(impact, fmt.Sprintf("configuration (%d parameter(s))", len(plugin.Configuration.Data)))
+	}
+	return impact
+}
+
+// PropertyRemovalImpact lists the places in a project that refer to a
+// property as ${key}, whose interpolation breaks when the property is
+// removed, e.g. "dependency junit:junit". References from the property
+// itself are not listed.
+func PropertyRemovalImpact(project *Project, key string) []string {
+	if project == nil {
+		return nil
+	}
+	ref := "${" + key + "}"
+	refers := func(values ...string) bool {
+		for _, value := range values {
+			if strings.Contains(value, ref) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var impact []string
+	for _, field := range []struct{ name, value string }{
+		{"groupId", project.GroupID},
+		{"artifactId", project.ArtifactID},
+		{"version", project.Version},
+		{"packaging", project.Packaging},
+		{"name", project.Name},
+		{"description", project.Description},
+	} {
+		if refers(field.value) {
+			impact = append(impact, "project "+field.name)
+		}
+	}
+	if project.Parent != nil && refers(project.Parent.Version, project.Parent.RelativePath) {
+		impact = append(impact, "parent "+project.Parent.GroupID+":"+project.Parent.ArtifactID)
+	}
+	impact = append(impact, propertyReferences("", project.Properties, key, refers)...)
+	impact = append(impact, dependencyReferences("dependency", project.Dependencies, refers)...)
+	impact = append(impact, dependencyReferences("managed dependency", project.DependencyManagement, refers)...)
+	impact = append(impact, buildReferences("", project.Build, refers)...)
+	impact = append(impact, moduleReferences("", project.Modules, refers)...)
+
+	for _, profile := range project.Profiles {
+		prefix := "profile " + profile.ID + ": "
+		impact = append(impact, propertyReferences(prefix, profile.Properties, key, refers)...)
+		impact = append(impact, dependencyReferences(prefix+"dependency", profile.Dependencies, refers)...)
+		impact = append(impact, buildReferences(prefix, profile.Build, refers)...)
+		impact = append(impact, moduleReferences(prefix, profile.Modules, refers)...)
+	}
+	return impact
+}
+
+// propertyReferences lists the properties, other than key, whose value
+// refers to key
+func propertyReferences(prefix string, properties map[string]string, key string, refers func(...string) bool) []string {
+	var impact []string
+	for name, value := range properties {
+		if name != key && refers(value) {
+			impact = append(impact, prefix+"property "+name)
+		}
+	}
+	sort.Strings(impact)
+	return impact
+}
+
+// dependencyReferences lists the dependencies with a reference in any field
+func dependencyReferences(kind string, deps []Dependency, refers func(...string) bool) []string {
+	var impact []string
+	for _, dep := range deps {
+		if refers(dep.GroupID, dep.ArtifactID, dep.Version, dep.Type, dep.Classifier, dep.Scope, dep.SystemPath) {
+			impact = append(impact, kind+" "+dep.GroupID+":"+dep.ArtifactID)
+		}
+	}
+	return impact
+}
+
+// buildReferences lists the build directories and plugins with a reference
+func buildReferences(prefix string, build *Build, refers func(...string) bool) []string {
+	if build == nil {
+		return nil
+	}
+
+	var impact []string
+	for _, dir := range []struct{ name, value string }{
+		{"sourceDirectory", build.SourceDirectory},
+		{"testSourceDirectory", build.TestSourceDirectory},
+		{"outputDirectory", build.OutputDirectory},
+	} {
+		if refers(dir.value) {
+			impact = append(impact, prefix+"build "+dir.name)
+		}
+	}
+	for _, plugin := range build.Plugins {
+		name := prefix + "plugin " + plugin.GroupID + ":" + plugin.ArtifactID
+		if refers(plugin.GroupID, plugin.ArtifactID, plugin.Version) {
+			impact = append(impact, name)
+		}
+		if configurationRefers(plugin.Configuration, refers) {
+			impact = append(impact, name+" configuration")
+		}
+		for _, exec := range plugin.Executions {
+			if refers(exec.Phase) || configurationRefers(exec.Configuration, refers) {
+				impact = append(impact, name+" execution "+exec.ID)
+			}
+		}
+	}
+	return impact
+}
+
+// configurationRefers reports whether any configuration value, however
+// deeply nested, contains a reference
+func configurationRefers(config *Configuration, refers func(...string) bool) bool {
+	if config == nil {
+		return false
+	}
+	for _, value := range config.Data {
+		if refers(fmt.Sprint(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// moduleReferences lists the modules whose path has a reference
+func moduleReferences(prefix string, modules []string, refers func(...string) bool) []string {
+	var impact []string
+	for _, module := range modules {
+		if refers(module) {
+			impact = append(impact, prefix+"module "+module)
+		}
+	}
+	return impact
+}
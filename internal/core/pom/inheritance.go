@@ -0,0 +1,335 @@
+package pom
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ResolvedParent is a parent POM located on disk
+type ResolvedParent struct {
+	Project *Project
+	Path    string
+}
+
+// InheritedProperty is a property contributed by an ancestor POM
+type InheritedProperty struct {
+	Name   string
+	Value  string
+	Source string // Coordinates of the declaring parent
+}
+
+// InheritedDependency is a dependency contributed by an ancestor POM
+type InheritedDependency struct {
+	Dependency
+	Source string // Coordinates of the declaring parent
+}
+
+// InheritedPlugin is a build plugin contributed by an ancestor POM
+type InheritedPlugin struct {
+	Plugin
+	Source string // Coordinates of the declaring parent
+}
+
+// Inheritance describes what a project receives from its parent chain
+type Inheritance struct {
+	Parents      []ResolvedParent // Nearest parent first
+	Properties   []InheritedProperty
+	Dependencies []InheritedDependency
+	Plugins      []InheritedPlugin
+	Managed      []InheritedDependency // dependencyManagement entries, including BOMs
+	Warnings     []ValidationError     // Redeclarations of inherited elements
+	Provenance   []Provenance          // Where every effective value is declared
+}
+
+// ParentResolver locates parent POMs via relativePath or the local repository
+type ParentResolver interface {
+	Resolve(childPath string, parent *Parent) (*ResolvedParent, error)
+	ResolveChain(childPath string, project *Project) ([]ResolvedParent, error)
+}
+
+// defaultParentResolver implements ParentResolver on the file system
+type defaultParentResolver struct {
+	parser    Parser
+	localRepo string
+}
+
+// NewParentResolver creates a ParentResolver using the default local repository
+func NewParentResolver(parser Parser) ParentResolver {
+	return &defaultParentResolver{
+		parser:    parser,
+		localRepo: DefaultLocalRepository(),
+	}
+}
+
+// NewParentResolverWithRepo creates a ParentResolver with a custom local repository (for testing)
+func NewParentResolverWithRepo(parser Parser, localRepo string) ParentResolver {
+	return &defaultParentResolver{
+		parser:    parser,
+		localRepo: localRepo,
+	}
+}
+
+// DefaultLocalRepository returns the standard Maven local repository (~/.m2/repository)
+func DefaultLocalRepository() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".m2", "repository")
+}
+
+// Resolve locates the parent POM, trying relativePath first and then the
+// local repository, as Maven does. childPath may be empty for unsaved files.
+func (r *defaultParentResolver) Resolve(childPath string, parent *Parent) (*ResolvedParent, error) {
+	if parent == nil {
+		return nil, fmt.Errorf("%w: no parent declared", ErrParentNotFound)
+	}
+
+	var candidates []string
+	if childPath != "" {
+		relativePath := parent.RelativePath
+		if relativePath == "" {
+			relativePath = DefaultRelativePath
+		}
+		candidate := filepath.Join(filepath.Dir(childPath), filepath.FromSlash(relativePath))
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			candidate = filepath.Join(candidate, "pom.xml")
+		}
+		candidates = append(candidates, candidate)
+	}
+	if r.localRepo != "" {
+		candidates = append(candidates, LocalRepositoryPath(r.localRepo, parent.GroupID, parent.ArtifactID, parent.Version, "pom"))
+	}
+
+	for _, candidate := range candidates {
+		project, err := r.parser.ParseFile(candidate)
+		if err != nil {
+			continue
+		}
+		// A POM at relativePath only counts if it is the referenced parent
+		if project.GroupID == parent.GroupID && project.ArtifactID == parent.ArtifactID && project.Version == parent.Version {
+			absPath, err := filepath.Abs(candidate)
+			if err != nil {
+				absPath = candidate
+			}
+			return &ResolvedParent{Project: project, Path: absPath}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s:%s:%s", ErrParentNotFound, parent.GroupID, parent.ArtifactID, parent.Version)
+}
+
+// ResolveChain resolves all ancestors of the project, nearest first. When an
+// ancestor cannot be found the parents resolved so far are returned with the error.
+func (r *defaultParentResolver) ResolveChain(childPath string, project *Project) ([]ResolvedParent, error) {
+	var chain []ResolvedParent
+	seen := make(map[string]bool)
+
+	current, currentPath := project, childPath
+	for current != nil && current.Parent != nil {
+		if len(chain) >= MaxParentDepth {
+			return chain, fmt.Errorf("%w: more than %d ancestors", ErrParentCycle, MaxParentDepth)
+		}
+
+		resolved, err := r.Resolve(currentPath, current.Parent)
+		if err != nil {
+			return chain, err
+		}
+		if seen[resolved.Path] {
+			return chain, fmt.Errorf("%w: %s", ErrParentCycle, resolved.Path)
+		}
+		seen[resolved.Path] = true
+
+		chain = append(chain, *resolved)
+		current, currentPath = resolved.Project, resolved.Path
+	}
+
+	return chain, nil
+}
+
+// LocalRepositoryPath returns the path of an artifact file in a Maven local repository
+func LocalRepositoryPath(localRepo, groupID, artifactID, version, extension string) string {
+	groupPath := filepath.Join(strings.Split(groupID, ".")...)
+	fileName := fmt.Sprintf("%s-%s.%s", artifactID, version, extension)
+	return filepath.Join(localRepo, groupPath, artifactID, version, fileName)
+}
+
+// ComputeInheritance collects the properties, dependencies, and plugins the
+// project inherits from its parents, and warns about redundant redeclarations.
+// Nearer parents win over more distant ones, and the child wins over all.
+func ComputeInheritance(project *Project, parents []ResolvedParent) *Inheritance {
+	inheritance := &Inheritance{Parents: parents}
+	if project == nil || len(parents) == 0 {
+		return inheritance
+	}
+	inheritance.Provenance = ComputeProvenance(project, parents)
+
+	// Properties
+	seenProps := make(map[string]bool)
+	for name := range project.Properties {
+		seenProps[name] = true
+	}
+	for _, parent := range parents {
+		source := parent.Project.Coordinates.String()
+		for _, name := range sortedKeys(parent.Project.Properties) {
+			value := parent.Project.Properties[name]
+			if childValue, ok := project.Properties[name]; ok && childValue == value {
+				inheritance.Warnings = append(inheritance.Warnings, ValidationError{
+					Field:    "properties." + name,
+					Value:    value,
+					Message:  fmt.Sprintf("redeclares the same value inherited from %s", source),
+					Severity: SeverityWarning,
+				})
+			}
+			if seenProps[name] {
+				continue
+			}
+			seenProps[name] = true
+			inheritance.Properties = append(inheritance.Properties, InheritedProperty{
+				Name:   name,
+				Value:  value,
+				Source: source,
+			})
+		}
+	}
+
+	// Dependencies
+	seenDeps := make(map[string]bool)
+	for _, dep := range project.Dependencies {
+		seenDeps[dep.GroupID+":"+dep.ArtifactID] = true
+	}
+	for _, parent := range parents {
+		source := parent.Project.Coordinates.String()
+		for _, dep := range parent.Project.Dependencies {
+			key := dep.GroupID + ":" + dep.ArtifactID
+			if childDep, ok := findDependency(project.Dependencies, dep.GroupID, dep.ArtifactID); ok {
+				inheritance.Warnings = append(inheritance.Warnings, ValidationError{
+					Field:    "dependencies." + key,
+					Value:    childDep.Version,
+					Message:  fmt.Sprintf("already declared by parent %s (version %s)", source, dep.Version),
+					Severity: SeverityWarning,
+				})
+			}
+			if seenDeps[key] {
+				continue
+			}
+			seenDeps[key] = true
+			inheritance.Dependencies = append(inheritance.Dependencies, InheritedDependency{
+				Dependency: dep,
+				Source:     source,
+			})
+		}
+	}
+
+	// Managed dependencies the child does not manage itself
+	seenManaged := make(map[string]bool)
+	for _, dep := range project.DependencyManagement {
+		seenManaged[dep.GroupID+":"+dep.ArtifactID] = true
+	}
+	for _, parent := range parents {
+		source := parent.Project.Coordinates.String()
+		for _, dep := range parent.Project.DependencyManagement {
+			key := dep.GroupID + ":" + dep.ArtifactID
+			if seenManaged[key] {
+				continue
+			}
+			seenManaged[key] = true
+			inheritance.Managed = append(inheritance.Managed, InheritedDependency{
+				Dependency: dep,
+				Source:     source,
+			})
+		}
+	}
+
+	// Plugins
+	var childPlugins []Plugin
+	if project.Build != nil {
+		childPlugins = project.Build.Plugins
+	}
+	seenPlugins := make(map[string]bool)
+	for _, plugin := range childPlugins {
+		seenPlugins[plugin.GroupID+":"+plugin.ArtifactID] = true
+	}
+	for _, parent := range parents {
+		if parent.Project.Build == nil {
+			continue
+		}
+		source := parent.Project.Coordinates.String()
+		for _, plugin := range parent.Project.Build.Plugins {
+			key := plugin.GroupID + ":" + plugin.ArtifactID
+			// A child plugin that only repeats the parent's version adds nothing
+			if childPlugin, ok := findPlugin(childPlugins, plugin.GroupID, plugin.ArtifactID); ok &&
+				childPlugin.Version == plugin.Version && len(childPlugin.Executions) == 0 {
+				inheritance.Warnings = append(inheritance.Warnings, ValidationError{
+					Field:    "build.plugins." + key,
+					Value:    childPlugin.Version,
+					Message:  fmt.Sprintf("redeclares plugin already configured by parent %s", source),
+					Severity: SeverityWarning,
+				})
+			}
+			if seenPlugins[key] {
+				continue
+			}
+			seenPlugins[key] = true
+			inheritance.Plugins = append(inheritance.Plugins, InheritedPlugin{
+				Plugin: plugin,
+				Source: source,
+			})
+		}
+	}
+
+	// Coordinates duplicated from the direct parent
+	if project.Parent != nil {
+		if !project.InheritsGroupID && project.GroupID == project.Parent.GroupID {
+			inheritance.Warnings = append(inheritance.Warnings, ValidationError{
+				Field:    "groupId",
+				Value:    project.GroupID,
+				Message:  "duplicates the parent groupId and can be omitted",
+				Severity: SeverityWarning,
+			})
+		}
+		if !project.InheritsVersion && project.Version == project.Parent.Version {
+			inheritance.Warnings = append(inheritance.Warnings, ValidationError{
+				Field:    "version",
+				Value:    project.Version,
+				Message:  "duplicates the parent version and can be omitted",
+				Severity: SeverityWarning,
+			})
+		}
+	}
+
+	return inheritance
+}
+
+// findDependency returns the dependency with the given groupId and artifactId
+func findDependency(deps []Dependency, groupID, artifactID string) (Dependency, bool) {
+	for _, dep := range deps {
+		if dep.GroupID == groupID && dep.ArtifactID == artifactID {
+			return dep, true
+		}
+	}
+	return Dependency{}, false
+}
+
+// findPlugin returns the plugin with the given groupId and artifactId
+func findPlugin(plugins []Plugin, groupID, artifactID string) (Plugin, bool) {
+	for _, plugin := range plugins {
+		if plugin.GroupID == groupID && plugin.ArtifactID == artifactID {
+			return plugin, true
+		}
+	}
+	return Plugin{}, false
+}
+
+// sortedKeys returns the keys of a string map in alphabetical order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,124 @@
+package pom
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/beevik/etree"
+)
+
+// scopePriority orders scopes from broadest to narrowest, used when an Ivy
+// dependency is mapped into several configurations
+var scopePriority = []string{ScopeCompile, ScopeProvided, ScopeRuntime, ScopeTest, ScopeSystem}
+
+// ParseIvyDependencies reads the <dependencies> block of an ivy.xml. Either a
+// complete <ivy-module> or the bare <dependencies> block is accepted.
+//
+// The module configurations each dependency is mapped from (the left side of
+// conf="compile->default") become the Maven scope; transitive="false" and
+// <exclude> become exclusions. Each <artifact> of a dependency is imported
+// as a dependency of its own, with its m:classifier and type.
+func ParseIvyDependencies(data []byte) (*DependencyImport, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidXML, err)
+	}
+
+	block := doc.FindElement("//dependencies")
+	if block == nil {
+		return nil, fmt.Errorf("%w: no <dependencies> block found", ErrInvalidXML)
+	}
+	defaultConf := block.SelectAttrValue("defaultconf", "default")
+
+	result := &DependencyImport{}
+	for _, elem := range block.SelectElements("dependency") {
+		org := elem.SelectAttrValue("org", "")
+		name := elem.SelectAttrValue("name", "")
+		rev := elem.SelectAttrValue("rev", "")
+		if org == "" || name == "" {
+			result.Warnings = append(result.Warnings, "skipped dependency without org or name")
+			continue
+		}
+
+		dep := Dependency{GroupID: org, ArtifactID: name, Version: rev}
+		key := org + ":" + name
+
+		if rev == "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s has no revision", key))
+		} else if isDynamicRevision(rev) {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s uses dynamic revision %q; pin a fixed version", key, rev))
+		}
+
+		conf := elem.SelectAttrValue("conf", defaultConf)
+		scope, mapped := ivyScope(conf)
+		if !mapped {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: configuration %q has no Maven scope, using compile", key, conf))
+		}
+		dep.Scope = scope
+
+		if elem.SelectAttrValue("transitive", "true") == "false" {
+			dep.Exclusions = append(dep.Exclusions, Exclusion{GroupID: "*", ArtifactID: "*"})
+		}
+		for _, exclude := range elem.SelectElements("exclude") {
+			dep.Exclusions = append(dep.Exclusions, Exclusion{
+				GroupID:    exclude.SelectAttrValue("org", "*"),
+				ArtifactID: exclude.SelectAttrValue("module", exclude.SelectAttrValue("name", "*")),
+			})
+		}
+
+		result.Dependencies = append(result.Dependencies, ivyArtifacts(dep, elem)...)
+	}
+
+	return result, nil
+}
+
+// ivyArtifacts returns dep once for each <artifact> of its element, with the
+// artifact's classifier and type, or dep alone when it names none
+func ivyArtifacts(dep Dependency, elem *etree.Element) []Dependency {
+	artifacts := elem.SelectElements("artifact")
+	if len(artifacts) == 0 {
+		return []Dependency{dep}
+	}
+	deps := make([]Dependency, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		variant := dep
+		variant.Exclusions = append([]Exclusion(nil), dep.Exclusions...)
+		variant.Classifier = artifact.SelectAttrValue("m:classifier", artifact.SelectAttrValue("classifier", ""))
+		if artifactType := artifact.SelectAttrValue("type", DefaultDependencyType); artifactType != DefaultDependencyType {
+			variant.Type = artifactType
+		}
+		deps = append(deps, variant)
+	}
+	return deps
+}
+
+// ivyScope maps an Ivy conf attribute ("compile->default;test->*") to the
+// broadest Maven scope among its module configurations
+func ivyScope(conf string) (string, bool) {
+	scopes := make(map[string]bool)
+	for _, mapping := range strings.Split(conf, ";") {
+		from, _, _ := strings.Cut(mapping, "->")
+		for _, name := range strings.Split(from, ",") {
+			if strings.TrimSpace(name) == "*" {
+				return ScopeCompile, true
+			}
+			if scope, ok := configurationScope(name); ok {
+				scopes[scope] = true
+			}
+		}
+	}
+
+	for _, scope := range scopePriority {
+		if scopes[scope] {
+			return scope, true
+		}
+	}
+	return ScopeCompile, false
+}
+
+// isDynamicRevision reports whether an Ivy revision is resolved at build time
+// ("latest.release", "1.+") rather than fixed. Version ranges are left alone
+// since Maven understands them.
+func isDynamicRevision(rev string) bool {
+	return strings.HasPrefix(rev, "latest.") || strings.HasSuffix(rev, "+")
+}
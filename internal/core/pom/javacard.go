@@ -0,0 +1,142 @@
+package pom
+
+import (
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"strings"
+	"unicode"
+)
+
+// Properties holding the applet of a JavaCard project; the CAP build
+// configuration refers to them
+const (
+	JavaCardAppletClassProperty = "javacard.applet.class"
+	JavaCardPackageAIDProperty  = "javacard.package.aid"
+	JavaCardAppletAIDProperty   = "javacard.applet.aid"
+)
+
+// GlobalPlatformProfileID is the profile installing the CAP file on a card
+// with GlobalPlatformPro
+const GlobalPlatformProfileID = "gp-deploy"
+
+// JavaCardApplet identifies the applet of a JavaCard project
+type JavaCardApplet struct {
+	Class      string // Fully qualified applet class
+	PackageAID string // Hex, 5 to 16 bytes
+	AppletAID  string // Hex, 5 to 16 bytes, starting with the package's RID
+}
+
+// DefaultJavaCardApplet derives an applet from project coordinates. The
+// AIDs use a proprietary RID (F0...) computed from the groupId, so
+// projects of one organization share it, and a PIX from the artifactId.
+func DefaultJavaCardApplet(coords Coordinates) JavaCardApplet {
+	rid := fmt.Sprintf("F0%08X", crc32.ChecksumIEEE([]byte(coords.GroupID)))
+	pix := fmt.Sprintf("%04X", crc32.ChecksumIEEE([]byte(coords.ArtifactID))&0xFFFF)
+
+	var class strings.Builder
+	upper := true
+	for _, r := range coords.ArtifactID {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		class.WriteRune(r)
+	}
+	name := class.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "Main" + name
+	}
+	if coords.GroupID != "" {
+		name = coords.GroupID + "." + name
+	}
+
+	return JavaCardApplet{
+		Class:      name + "Applet",
+		PackageAID: rid + pix,
+		AppletAID:  rid + pix + "01",
+	}
+}
+
+// ValidateAID checks that an AID is 5 to 16 bytes of hex
+func ValidateAID(aid string) error {
+	data, err := hex.DecodeString(aid)
+	if err != nil {
+		return fmt.Errorf("%w: %s is not hex", ErrInvalidAID, aid)
+	}
+	if len(data) < 5 || len(data) > 16 {
+		return fmt.Errorf("%w: %s has %d bytes, expected 5 to 16", ErrInvalidAID, aid, len(data))
+	}
+	return nil
+}
+
+// Validate checks the applet class and AIDs. The applet AID must share the
+// package's RID (its first 5 bytes) and differ from the package AID.
+func (a JavaCardApplet) Validate() error {
+	if strings.TrimSpace(a.Class) == "" {
+		return fmt.Errorf("%w: applet class", ErrMissingRequired)
+	}
+	if err := ValidateAID(a.PackageAID); err != nil {
+		return fmt.Errorf("package AID: %w", err)
+	}
+	if err := ValidateAID(a.AppletAID); err != nil {
+		return fmt.Errorf("applet AID: %w", err)
+	}
+	if !strings.EqualFold(a.PackageAID[:10], a.AppletAID[:10]) {
+		return fmt.Errorf("%w: applet AID %s must start with the package RID %s", ErrInvalidAID, a.AppletAID, a.PackageAID[:10])
+	}
+	if strings.EqualFold(a.PackageAID, a.AppletAID) {
+		return fmt.Errorf("%w: applet and package AID must differ", ErrInvalidAID)
+	}
+	return nil
+}
+
+// ConfigureJavaCard stores the applet in the project's properties
+func ConfigureJavaCard(project *Project, applet JavaCardApplet) error {
+	if err := applet.Validate(); err != nil {
+		return err
+	}
+	if project.Properties == nil {
+		project.Properties = make(map[string]string)
+	}
+	project.Properties[JavaCardAppletClassProperty] = strings.TrimSpace(applet.Class)
+	project.Properties[JavaCardPackageAIDProperty] = strings.ToUpper(applet.PackageAID)
+	project.Properties[JavaCardAppletAIDProperty] = strings.ToUpper(applet.AppletAID)
+	return nil
+}
+
+// GlobalPlatformProfile returns a profile that installs the CAP file on a
+// card during mvn install -Pgp-deploy. It runs GlobalPlatformPro through
+// exec-maven-plugin, configured by its exec.* properties; gp.jar and
+// gp.key default to a gp.jar in the project and the test key of new cards.
+func GlobalPlatformProfile() Profile {
+	return Profile{
+		ID: GlobalPlatformProfileID,
+		Properties: map[string]string{
+			"gp.jar":          "${project.basedir}/gp.jar",
+			"gp.key":          "404142434445464748494A4B4C4D4E4F",
+			"exec.executable": "java",
+			"exec.args":       "-jar ${gp.jar} --key ${gp.key} --reinstall ${project.build.directory}/${project.build.finalName}.cap",
+		},
+		Build: &Build{
+			Plugins: []Plugin{
+				{
+					GroupID:    "org.codehaus.mojo",
+					ArtifactID: "exec-maven-plugin",
+					Version:    DefaultVersion("org.codehaus.mojo", "exec-maven-plugin"),
+					Executions: []PluginExecution{
+						{
+							ID:    "gp-install",
+							Phase: PhaseInstall,
+							Goals: []string{"exec"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,93 @@
+package pom
+
+import "fmt"
+
+// Well-known bills of materials offered when importing a BOM
+var CommonBOMs = []Coordinates{
+	{GroupID: "org.springframework.boot", ArtifactID: "spring-boot-dependencies", Version: "3.2.0"},
+	{GroupID: "org.springframework.cloud", ArtifactID: "spring-cloud-dependencies", Version: "2023.0.0"},
+	{GroupID: "io.quarkus.platform", ArtifactID: "quarkus-bom", Version: "3.6.0"},
+	{GroupID: "org.junit", ArtifactID: "junit-bom", Version: "5.10.1"},
+	{GroupID: "com.fasterxml.jackson", ArtifactID: "jackson-bom", Version: "2.16.0"},
+	{GroupID: "io.netty", ArtifactID: "netty-bom", Version: "4.1.104.Final"},
+}
+
+// NewBOM returns the dependencyManagement entry importing a bill of materials
+func NewBOM(groupID, artifactID, version string) Dependency {
+	return Dependency{
+		GroupID:    groupID,
+		ArtifactID: artifactID,
+		Version:    version,
+		Type:       PackagingPom,
+		Scope:      ScopeImport,
+	}
+}
+
+// IsBOM reports whether the dependency imports a bill of materials
+func (d Dependency) IsBOM() bool {
+	return d.Scope == ScopeImport && d.Type == PackagingPom
+}
+
+// BOMs returns the bills of materials imported by the project's
+// dependencyManagement
+func (p *Project) BOMs() []Dependency {
+	var boms []Dependency
+	for _, dep := range p.DependencyManagement {
+		if dep.IsBOM() {
+			boms = append(boms, dep)
+		}
+	}
+	return boms
+}
+
+// ManagedVersion returns the version the project's dependencyManagement
+// declares explicitly for groupId:artifactId. Of repeated entries, the last
+// is the one Maven uses.
+func (p *Project) ManagedVersion(groupID, artifactID string) (string, bool) {
+	for i := len(p.DependencyManagement) - 1; i >= 0; i-- {
+		dep := p.DependencyManagement[i]
+		if dep.GroupID == groupID && dep.ArtifactID == artifactID && !dep.IsBOM() {
+			return dep.Version, true
+		}
+	}
+	return "", false
+}
+
+// ManagedVersionSource describes where a dependency declared without a
+// version gets it from: the project's dependencyManagement, a parent's, or an
+// imported BOM. Since BOM contents are not resolved, any imported BOM is
+// assumed to manage the dependency, as is a parent the inheritance could not
+// resolve, such as spring-boot-starter-parent outside the local repository.
+// Without inheritance, parents are not considered. Returns false when
+// nothing manages it.
+func ManagedVersionSource(project *Project, inheritance *Inheritance, groupID, artifactID string) (string, bool) {
+	if version, ok := project.ManagedVersion(groupID, artifactID); ok {
+		return fmt.Sprintf("version %s managed by dependencyManagement", version), true
+	}
+
+	var parentManaged []InheritedDependency
+	if inheritance != nil {
+		parentManaged = inheritance.Managed
+	}
+	for _, managed := range parentManaged {
+		if managed.GroupID == groupID && managed.ArtifactID == artifactID && !managed.IsBOM() {
+			return fmt.Sprintf("version %s managed by parent %s", managed.Version, managed.Source), true
+		}
+	}
+
+	if boms := project.BOMs(); len(boms) > 0 {
+		return fmt.Sprintf("version expected from imported BOM %s:%s", boms[0].GroupID, boms[0].ArtifactID), true
+	}
+	for _, managed := range parentManaged {
+		if managed.IsBOM() {
+			return fmt.Sprintf("version expected from BOM %s:%s imported by parent %s",
+				managed.GroupID, managed.ArtifactID, managed.Source), true
+		}
+	}
+
+	if project.Parent != nil && inheritance != nil && len(inheritance.Parents) == 0 {
+		return fmt.Sprintf("version expected from parent %s:%s, which was not resolved",
+			project.Parent.GroupID, project.Parent.ArtifactID), true
+	}
+	return "", false
+}
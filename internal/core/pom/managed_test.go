@@ -0,0 +1,22 @@
+package pom
+
+import "testing"
+
+func TestManagedVersion(t *testing.T) {
+	project := &Project{DependencyManagement: []Dependency{
+		{GroupID: "g", ArtifactID: "x", Version: "1"},
+		{GroupID: "g", ArtifactID: "x", Version: "2"}, // Maven uses the last
+		{GroupID: "g", ArtifactID: "x", Version: "3", Type: PackagingPom, Scope: ScopeImport},
+	}}
+
+	if version, ok := project.ManagedVersion("g", "x"); !ok || version != "2" {
+		t.Errorf("Expected the last entry that is not a BOM, got %q, %v", version, ok)
+	}
+	if _, ok := project.ManagedVersion("g", "y"); ok {
+		t.Error("Expected nothing managed for an undeclared dependency")
+	}
+
+	if !setManagedVersion(project, "g", "x", "4") || project.DependencyManagement[0].Version != "1" || project.DependencyManagement[1].Version != "4" {
+		t.Errorf("Expected the entry in effect to be updated, got %+v", project.DependencyManagement)
+	}
+}
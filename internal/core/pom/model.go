@@ -1,6 +1,9 @@
 package pom
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+)
 
 // Project represents a complete Maven POM
 type Project struct {
@@ -19,10 +22,24 @@ type Project struct {
 	Properties   map[string]string      `xml:"-"`
 	PropertiesXML *Properties           `xml:"properties,omitempty"`
 	Dependencies []Dependency           `xml:"dependencies>dependency,omitempty"`
+	DependencyManagement []Dependency   `xml:"dependencyManagement>dependencies>dependency,omitempty"`
 	Build        *Build                 `xml:"build,omitempty"`
 	Modules      []string               `xml:"modules>module,omitempty"`
 	Parent       *Parent                `xml:"parent,omitempty"`
 	Profiles     []Profile              `xml:"profiles>profile,omitempty"`
+
+	// InheritsGroupID and InheritsVersion record that the coordinate was
+	// omitted in the XML and taken from <parent>, so it is not written back
+	InheritsGroupID bool `xml:"-"`
+	InheritsVersion bool `xml:"-"`
+
+	// Positions locates elements in the parsed file; nil for projects not
+	// read from XML
+	Positions SourceMap `xml:"-"`
+
+	// Banner is the generated-by comment found before <project>, written
+	// back by the generator; nil when there is none
+	Banner *Banner `xml:"-"`
 }
 
 // Properties represents Maven properties as a map
@@ -47,11 +64,51 @@ type Dependency struct {
 	GroupID    string      `xml:"groupId" validate:"required"`
 	ArtifactID string      `xml:"artifactId" validate:"required"`
 	Version    string      `xml:"version" validate:"required"`
+	Type       string      `xml:"type,omitempty"`
+	Classifier string      `xml:"classifier,omitempty"`
 	Scope      string      `xml:"scope,omitempty"`
 	Optional   bool        `xml:"optional,omitempty"`
+	SystemPath string      `xml:"systemPath,omitempty"` // Only with system scope
 	Exclusions []Exclusion `xml:"exclusions>exclusion,omitempty"`
 }
 
+// Key identifies a dependency the way Maven does when looking for
+// duplicates: groupId:artifactId:type, plus the classifier when there is one
+func (d Dependency) Key() string {
+	depType := d.Type
+	if depType == "" {
+		depType = DefaultDependencyType
+	}
+	key := d.GroupID + ":" + d.ArtifactID + ":" + depType
+	if d.Classifier != "" {
+		key += ":" + d.Classifier
+	}
+	return key
+}
+
+// GAV returns "groupId:artifactId:version", or "groupId:artifactId" when
+// the version is managed
+func (d Dependency) GAV() string {
+	return gav(d.GroupID, d.ArtifactID, d.Version)
+}
+
+// Notation returns the Gradle notation of the dependency,
+// "groupId:artifactId:version[:classifier][@type]", which tells artifacts
+// differing only in classifier or type apart
+func (d Dependency) Notation() string {
+	notation := d.GAV()
+	if d.Classifier != "" {
+		if d.Version == "" {
+			notation += ":"
+		}
+		notation += ":" + d.Classifier
+	}
+	if d.Type != "" {
+		notation += "@" + d.Type
+	}
+	return notation
+}
+
 // Exclusion represents an excluded transitive dependency
 type Exclusion struct {
 	GroupID    string `xml:"groupId" validate:"required"`
@@ -71,10 +128,25 @@ type Plugin struct {
 	GroupID       string            `xml:"groupId" validate:"required"`
 	ArtifactID    string            `xml:"artifactId" validate:"required"`
 	Version       string            `xml:"version,omitempty"`
+	Extensions    bool              `xml:"extensions,omitempty"` // Plugin adds lifecycles or packaging types
 	Configuration *Configuration    `xml:"configuration,omitempty"`
 	Executions    []PluginExecution `xml:"executions>execution,omitempty"`
 }
 
+// GAV returns "groupId:artifactId:version", or "groupId:artifactId" when
+// no version is declared
+func (p Plugin) GAV() string {
+	return gav(p.GroupID, p.ArtifactID, p.Version)
+}
+
+// gav joins coordinates with colons, leaving out an empty version
+func gav(groupID, artifactID, version string) string {
+	if version == "" {
+		return groupID + ":" + artifactID
+	}
+	return groupID + ":" + artifactID + ":" + version
+}
+
 // PluginExecution represents a plugin execution
 type PluginExecution struct {
 	ID            string         `xml:"id,omitempty"`
@@ -83,12 +155,34 @@ type PluginExecution struct {
 	Configuration *Configuration `xml:"configuration,omitempty"`
 }
 
-// Configuration represents generic plugin or execution configuration
-// This is a simplified representation - real Maven configs can be complex nested XML
+// Configuration represents generic plugin or execution configuration.
+// Each child element is a key whose value is its text, a map of its own
+// child elements, or a list when the element is repeated, so that
+// <includes><include>A</include><include>B</include></includes> becomes
+// includes: {include: [A, B]}. Elements with attributes are kept as
+// ConfigurationXML.
 type Configuration struct {
 	Data map[string]interface{}
 }
 
+// ConfigurationXML is a configuration element kept as written, for
+// elements the map form cannot hold, such as the tasks of the
+// antrun plugin with their attributes
+type ConfigurationXML string
+
+// Keys returns the names of the top-level parameters, sorted
+func (c *Configuration) Keys() []string {
+	if c == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(c.Data))
+	for key := range c.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // Parent represents a parent POM reference
 type Parent struct {
 	GroupID      string `xml:"groupId" validate:"required"`
@@ -139,7 +233,7 @@ type ActivationFile struct {
 
 // ValidationResult contains validation errors grouped by category
 type ValidationResult struct {
-	Valid  bool
+	Valid  bool // No findings of SeverityError
 	Errors ValidationErrors
 }
 
@@ -151,11 +245,35 @@ type ValidationErrors struct {
 	General      []ValidationError
 }
 
-// ValidationError represents a single validation failure
+// Severity ranks validation findings; only errors make a POM invalid
+type Severity int
+
+const (
+	SeverityError   Severity = iota // Maven cannot build the POM (the zero value)
+	SeverityWarning                 // Convention or style issue
+	SeverityInfo                    // Informational note
+)
+
+// String returns the lowercase severity name
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	}
+	return "error"
+}
+
+// ValidationError represents a single validation finding
 type ValidationError struct {
-	Field   string
-	Value   string
-	Message string
+	Field    string
+	Value    string
+	Message  string
+	Severity Severity
+	Rule     string   // ID of the rule that reported it, see RuleRegistry
+	Position Position // Where in the file, when known
+	Fix      *Fix     // Automatic correction, nil when there is none
 }
 
 // Error returns formatted error message
@@ -163,12 +281,28 @@ func (v ValidationError) Error() string {
 	return fmt.Sprintf("field '%s' with value '%s': %s", v.Field, v.Value, v.Message)
 }
 
-// HasErrors returns true if there are any validation errors
+// HasErrors returns true if there are any findings of SeverityError
 func (ve ValidationErrors) HasErrors() bool {
-	return len(ve.Coordinates) > 0 || len(ve.Dependencies) > 0 || len(ve.Build) > 0 || len(ve.General) > 0
+	return ve.Count(SeverityError) > 0
+}
+
+// Count returns the number of findings with the given severity
+func (ve ValidationErrors) Count(severity Severity) int {
+	return len(ve.BySeverity(severity))
+}
+
+// BySeverity returns the findings with the given severity as a flat slice
+func (ve ValidationErrors) BySeverity(severity Severity) []ValidationError {
+	var matching []ValidationError
+	for _, err := range ve.AllErrors() {
+		if err.Severity == severity {
+			matching = append(matching, err)
+		}
+	}
+	return matching
 }
 
-// AllErrors returns all validation errors as a flat slice
+// AllErrors returns all validation findings, of any severity, as a flat slice
 func (ve ValidationErrors) AllErrors() []ValidationError {
 	var all []ValidationError
 	all = append(all, ve.Coordinates...)
@@ -182,4 +316,6 @@ func (ve ValidationErrors) AllErrors() []ValidationError {
 type TemplateInfo struct {
 	Name        string
 	Description string
+	Path        string             // Definition file of a custom template, "" for built-in ones
+	Variables   []TemplateVariable // Values asked for when creating a project
 }
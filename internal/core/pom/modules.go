@@ -0,0 +1,110 @@
+package pom
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// HasModule reports whether the project lists the given module path
+func HasModule(project *Project, module string) bool {
+	if project == nil {
+		return false
+	}
+	module = normalizeModulePath(module)
+	for _, existing := range project.Modules {
+		if normalizeModulePath(existing) == module {
+			return true
+		}
+	}
+	return false
+}
+
+// AddModule appends a module path to the project's <modules> section.
+// Aggregator projects must use "pom" packaging, so the packaging is switched
+// when necessary; the returned bool reports whether that happened.
+func AddModule(project *Project, module string) (bool, error) {
+	if project == nil {
+		return false, fmt.Errorf("%w: project is nil", ErrInvalidProject)
+	}
+
+	module = normalizeModulePath(module)
+	if module == "" {
+		return false, fmt.Errorf("%w: module path is empty", ErrInvalidFormat)
+	}
+
+	if HasModule(project, module) {
+		return false, fmt.Errorf("%w: module '%s' already declared", ErrDuplicateModule, module)
+	}
+
+	project.Modules = append(project.Modules, module)
+
+	packagingChanged := false
+	if project.Packaging != PackagingPom {
+		project.Packaging = PackagingPom
+		packagingChanged = true
+	}
+
+	return packagingChanged, nil
+}
+
+// RemoveModule removes a module path from the project's <modules> section
+func RemoveModule(project *Project, module string) error {
+	if project == nil {
+		return fmt.Errorf("%w: project is nil", ErrInvalidProject)
+	}
+
+	module = normalizeModulePath(module)
+	for i, existing := range project.Modules {
+		if normalizeModulePath(existing) == module {
+			project.Modules = append(project.Modules[:i], project.Modules[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrModuleNotFound, module)
+}
+
+// NewChildProject creates a module project from a template whose <parent>
+// points at the given aggregator. The artifactId defaults to the last
+// segment of the module path and groupId/version are inherited.
+func NewChildProject(parent *Project, module string, tm TemplateManager, templateName string) (*Project, error) {
+	if parent == nil {
+		return nil, fmt.Errorf("%w: parent project is nil", ErrInvalidProject)
+	}
+
+	module = normalizeModulePath(module)
+	coords := Coordinates{
+		GroupID:    parent.GroupID,
+		ArtifactID: path.Base(module),
+		Version:    parent.Version,
+	}
+
+	child, err := tm.Create(templateName, coords)
+	if err != nil {
+		return nil, err
+	}
+
+	child.Parent = &Parent{
+		GroupID:    parent.GroupID,
+		ArtifactID: parent.ArtifactID,
+		Version:    parent.Version,
+	}
+	child.InheritsGroupID = true
+	child.InheritsVersion = true
+
+	// Default relativePath is ../pom.xml; only nested modules need it spelled out
+	if depth := strings.Count(module, "/") + 1; depth > 1 {
+		child.Parent.RelativePath = strings.Repeat("../", depth) + "pom.xml"
+	}
+
+	return child, nil
+}
+
+// normalizeModulePath converts a module path to forward slashes without
+// leading "./" or trailing separators, matching how Maven compares them
+func normalizeModulePath(module string) string {
+	module = strings.TrimSpace(strings.ReplaceAll(module, "\\", "/"))
+	module = strings.TrimPrefix(module, "./")
+	return strings.TrimSuffix(module, "/")
+}
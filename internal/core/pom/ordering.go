@@ -0,0 +1,127 @@
+package pom
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// SortDependencies orders dependencies by scope, in the order of
+// ValidDependencyScopes, then by groupId and artifactId. Dependencies that
+// compare equal keep their relative order.
+func SortDependencies(deps []Dependency) {
+	sort.SliceStable(deps, func(i, j int) bool {
+		a, b := deps[i], deps[j]
+		if ra, rb := scopeRank(a.Scope), scopeRank(b.Scope); ra != rb {
+			return ra < rb
+		}
+		if a.GroupID != b.GroupID {
+			return a.GroupID < b.GroupID
+		}
+		return a.ArtifactID < b.ArtifactID
+	})
+}
+
+// Positions at which InsertDependency adds a dependency
+const (
+	InsertAtEnd        = "end"          // After all dependencies
+	InsertAlphabetical = "alphabetical" // Before the first one with a greater groupId:artifactId
+	InsertAfterEntry   = "after"        // After a given dependency
+	InsertByScope      = "scope"        // After the last one with the same scope
+)
+
+// InsertPositions lists the positions understood by InsertDependency
+var InsertPositions = []string{InsertAtEnd, InsertAlphabetical, InsertAfterEntry, InsertByScope}
+
+// InsertDependency returns deps with dep inserted at position. For
+// InsertAfterEntry, after is the index of the dependency to insert after.
+// An index out of range or an unknown position inserts at the end.
+func InsertDependency(deps []Dependency, dep Dependency, position string, after int) []Dependency {
+	index := len(deps)
+	switch position {
+	case InsertAlphabetical:
+		for i, existing := range deps {
+			if existing.GroupID > dep.GroupID || (existing.GroupID == dep.GroupID && existing.ArtifactID > dep.ArtifactID) {
+				index = i
+				break
+			}
+		}
+	case InsertAfterEntry:
+		if after >= 0 && after < len(deps) {
+			index = after + 1
+		}
+	case InsertByScope:
+		// After the same scope, else before the first later scope
+		rank, last, firstLater := scopeRank(dep.Scope), -1, -1
+		for i, existing := range deps {
+			switch r := scopeRank(existing.Scope); {
+			case r == rank:
+				last = i
+			case r > rank && firstLater < 0:
+				firstLater = i
+			}
+		}
+		if last >= 0 {
+			index = last + 1
+		} else if firstLater >= 0 {
+			index = firstLater
+		}
+	}
+	return slices.Insert(deps, index, dep)
+}
+
+// MoveDependency moves the dependency at from to index to, shifting the
+// ones in between
+func MoveDependency(deps []Dependency, from, to int) error {
+	if from < 0 || from >= len(deps) || to < 0 || to >= len(deps) {
+		return fmt.Errorf("%w: cannot move %d to %d in %d dependencies", ErrDependencyNotFound, from, to, len(deps))
+	}
+
+	dep := deps[from]
+	if from < to {
+		copy(deps[from:to], deps[from+1:to+1])
+	} else {
+		copy(deps[to+1:from+1], deps[to:from])
+	}
+	deps[to] = dep
+	return nil
+}
+
+// MoveExecution swaps the execution at position from among those bound to
+// phase with its neighbour at to, which must be from-1 or from+1. Executions
+// of the same plugin trade places within the plugin; otherwise the later
+// plugin is declared before the earlier one, which also changes their order
+// in other phases.
+func MoveExecution(project *Project, phase string, from, to int) error {
+	executions := NewOrganizer().ExecutionsInPhase(project, phase)
+	if from < 0 || from >= len(executions) || to < 0 || to >= len(executions) || (to != from-1 && to != from+1) {
+		return fmt.Errorf("cannot move execution %d to %d of %d in phase %s", from, to, len(executions), phase)
+	}
+
+	first, second := executions[min(from, to)], executions[max(from, to)]
+	if first.PluginIndex == second.PluginIndex {
+		execs := project.Build.Plugins[first.PluginIndex].Executions
+		execs[first.ExecutionIndex], execs[second.ExecutionIndex] = execs[second.ExecutionIndex], execs[first.ExecutionIndex]
+		return nil
+	}
+
+	plugins := project.Build.Plugins
+	plugin := plugins[second.PluginIndex]
+	copy(plugins[first.PluginIndex+1:second.PluginIndex+1], plugins[first.PluginIndex:second.PluginIndex])
+	plugins[first.PluginIndex] = plugin
+	return nil
+}
+
+// scopeRank returns the position of a scope in ValidDependencyScopes, with
+// no scope counting as compile and unknown scopes last
+func scopeRank(scope string) int {
+	if scope == "" {
+		scope = DefaultScope
+	}
+	for i, valid := range ValidDependencyScopes {
+		if scope == valid {
+			return i
+		}
+	}
+	return len(ValidDependencyScopes)
+}
@@ -7,9 +7,19 @@ type Organizer interface {
 	ByPhase(project *Project) map[string][]PluginExecution
 	ByGoal(project *Project) map[string][]PluginExecution
 	ByPlugin(project *Project) map[string][]PluginExecution
+	ExecutionsInPhase(project *Project, phase string) []PhaseExecution
 	GetPhaseOrder() []string
 }
 
+// PhaseExecution is a plugin execution with the plugin declaring it, and
+// their positions in the project
+type PhaseExecution struct {
+	Plugin         Plugin
+	Execution      PluginExecution
+	PluginIndex    int
+	ExecutionIndex int
+}
+
 // defaultOrganizer implements Organizer
 type defaultOrganizer struct{}
 
@@ -75,6 +85,32 @@ func (o *defaultOrganizer) ByPlugin(project *Project) map[string][]PluginExecuti
 	return result
 }
 
+// ExecutionsInPhase returns the executions bound to a phase in the order
+// Maven runs them: plugins in declaration order, then each plugin's
+// executions in declaration order
+func (o *defaultOrganizer) ExecutionsInPhase(project *Project, phase string) []PhaseExecution {
+	var result []PhaseExecution
+
+	if project == nil || project.Build == nil {
+		return result
+	}
+
+	for i, plugin := range project.Build.Plugins {
+		for j, exec := range plugin.Executions {
+			if exec.Phase == phase {
+				result = append(result, PhaseExecution{
+					Plugin:         plugin,
+					Execution:      exec,
+					PluginIndex:    i,
+					ExecutionIndex: j,
+				})
+			}
+		}
+	}
+
+	return result
+}
+
 // GetPhaseOrder returns Maven lifecycle phases in execution order
 func (o *defaultOrganizer) GetPhaseOrder() []string {
 	return MavenLifecyclePhases
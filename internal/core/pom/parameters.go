@@ -0,0 +1,264 @@
+package pom
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Parameter types of plugin configuration
+const (
+	ParamString  = "string"
+	ParamBoolean = "boolean"
+	ParamInteger = "integer"
+	ParamList    = "list" // e.g. <includes><include>...</include></includes>
+	ParamMap     = "map"  // e.g. <systemPropertyVariables> or <archive>
+)
+
+// PluginParameter is a configuration parameter of a plugin's goals, as
+// described by the plugin's descriptor
+type PluginParameter struct {
+	Name        string
+	Type        string
+	Description string
+	Values      []string // Allowed values of string parameters, nil for any
+}
+
+// pluginParameters lists the commonly configured parameters of well-known
+// plugins by groupId:artifactId, merged across their goals
+var pluginParameters = map[string][]PluginParameter{
+	DefaultPluginGroupID + ":maven-compiler-plugin": {
+		{"release", ParamInteger, "Java release to compile for, e.g. 17", nil},
+		{"source", ParamString, "Java language level of the sources", nil},
+		{"target", ParamString, "Java version of the generated class files", nil},
+		{"encoding", ParamString, "Encoding of the source files", nil},
+		{"compilerArgs", ParamList, "Extra arguments passed to the compiler", nil},
+		{"annotationProcessorPaths", ParamList, "Artifacts containing annotation processors", nil},
+		{"parameters", ParamBoolean, "Keep method parameter names for reflection", nil},
+		{"showWarnings", ParamBoolean, "Show compiler warnings", nil},
+		{"showDeprecation", ParamBoolean, "Show where deprecated APIs are used", nil},
+		{"failOnWarning", ParamBoolean, "Fail the build on compiler warnings", nil},
+		{"fork", ParamBoolean, "Run the compiler in a separate process", nil},
+		{"debug", ParamBoolean, "Include debugging information", nil},
+	},
+	DefaultPluginGroupID + ":maven-surefire-plugin": {
+		{"includes", ParamList, "Patterns of test classes to run", nil},
+		{"excludes", ParamList, "Patterns of test classes to skip", nil},
+		{"skipTests", ParamBoolean, "Compile tests but do not run them", nil},
+		{"testFailureIgnore", ParamBoolean, "Continue the build when tests fail", nil},
+		{"forkCount", ParamString, "Number of forked JVMs, e.g. 1 or 1C per core", nil},
+		{"reuseForks", ParamBoolean, "Reuse forked JVMs for several test classes", nil},
+		{"argLine", ParamString, "JVM arguments of the forked test JVMs", nil},
+		{"parallel", ParamString, "What JUnit 4 runs in parallel", []string{
+			"methods", "classes", "both", "suites", "suitesAndClasses", "suitesAndMethods", "classesAndMethods", "all",
+		}},
+		{"threadCount", ParamInteger, "Number of threads for parallel tests", nil},
+		{"groups", ParamString, "Test groups or JUnit 5 tags to run", nil},
+		{"excludedGroups", ParamString, "Test groups or JUnit 5 tags to skip", nil},
+		{"systemPropertyVariables", ParamMap, "System properties of the test JVM", nil},
+		{"environmentVariables", ParamMap, "Environment variables of the test JVM", nil},
+		{"redirectTestOutputToFile", ParamBoolean, "Write test output to files instead of the console", nil},
+		{"trimStackTrace", ParamBoolean, "Shorten stack traces in reports", nil},
+	},
+	DefaultPluginGroupID + ":maven-failsafe-plugin": {
+		{"includes", ParamList, "Patterns of integration test classes to run", nil},
+		{"excludes", ParamList, "Patterns of integration test classes to skip", nil},
+		{"skipITs", ParamBoolean, "Do not run integration tests", nil},
+		{"testFailureIgnore", ParamBoolean, "Continue the build when tests fail", nil},
+		{"forkCount", ParamString, "Number of forked JVMs, e.g. 1 or 1C per core", nil},
+		{"reuseForks", ParamBoolean, "Reuse forked JVMs for several test classes", nil},
+		{"argLine", ParamString, "JVM arguments of the forked test JVMs", nil},
+		{"systemPropertyVariables", ParamMap, "System properties of the test JVM", nil},
+	},
+	DefaultPluginGroupID + ":maven-jar-plugin": {
+		{"archive", ParamMap, "Manifest and archive settings", nil},
+		{"classifier", ParamString, "Classifier of the jar, e.g. tests", nil},
+		{"includes", ParamList, "Patterns of files to include", nil},
+		{"excludes", ParamList, "Patterns of files to exclude", nil},
+		{"forceCreation", ParamBoolean, "Build the jar even when it is up to date", nil},
+		{"skipIfEmpty", ParamBoolean, "Do not build an empty jar", nil},
+	},
+	DefaultPluginGroupID + ":maven-war-plugin": {
+		{"failOnMissingWebXml", ParamBoolean, "Fail when WEB-INF/web.xml is missing", nil},
+		{"warName", ParamString, "File name of the war", nil},
+		{"webXml", ParamString, "Path of the web.xml to use", nil},
+		{"packagingExcludes", ParamString, "Comma-separated patterns to leave out of the war", nil},
+		{"webResources", ParamList, "Extra resources copied into the war", nil},
+		{"archive", ParamMap, "Manifest and archive settings", nil},
+	},
+	DefaultPluginGroupID + ":maven-assembly-plugin": {
+		{"descriptorRefs", ParamList, "Built-in descriptors, e.g. jar-with-dependencies", nil},
+		{"descriptors", ParamList, "Paths of assembly descriptors", nil},
+		{"finalName", ParamString, "File name of the assembly", nil},
+		{"appendAssemblyId", ParamBoolean, "Append the assembly ID to the file name", nil},
+		{"archive", ParamMap, "Manifest and archive settings", nil},
+	},
+	DefaultPluginGroupID + ":maven-javadoc-plugin": {
+		{"doclint", ParamString, "Doclint checks, e.g. none or all,-missing", nil},
+		{"quiet", ParamBoolean, "Only show warnings and errors", nil},
+		{"source", ParamString, "Java language level of the sources", nil},
+		{"failOnError", ParamBoolean, "Fail the build on Javadoc errors", nil},
+		{"additionalOptions", ParamList, "Extra options passed to javadoc", nil},
+	},
+	"org.codehaus.mojo:exec-maven-plugin": {
+		{"mainClass", ParamString, "Class whose main method exec:java runs", nil},
+		{"executable", ParamString, "Program exec:exec runs", nil},
+		{"arguments", ParamList, "Arguments of the program", nil},
+		{"workingDirectory", ParamString, "Directory the program runs in", nil},
+		{"classpathScope", ParamString, "Dependencies on the program's classpath", []string{
+			ScopeCompile, ScopeRuntime, ScopeTest, ScopeSystem,
+		}},
+	},
+}
+
+// PluginParameters returns the known configuration parameters of a plugin
+// sorted by name, or nil when the plugin is not known
+func PluginParameters(plugin Plugin) []PluginParameter {
+	params := append([]PluginParameter(nil), pluginParameters[pluginCatalogKey(plugin)]...)
+	sort.Slice(params, func(i, j int) bool {
+		return params[i].Name < params[j].Name
+	})
+	return params
+}
+
+// LookupParameter returns a configuration parameter of a known plugin
+func LookupParameter(plugin Plugin, name string) (PluginParameter, bool) {
+	for _, param := range pluginParameters[pluginCatalogKey(plugin)] {
+		if param.Name == name {
+			return param, true
+		}
+	}
+	return PluginParameter{}, false
+}
+
+// CompleteParameter returns the parameters of a plugin whose names start
+// with prefix, ignoring case, for completion while typing
+func CompleteParameter(plugin Plugin, prefix string) []PluginParameter {
+	var matches []PluginParameter
+	prefix = strings.ToLower(prefix)
+	for _, param := range PluginParameters(plugin) {
+		if strings.HasPrefix(strings.ToLower(param.Name), prefix) {
+			matches = append(matches, param)
+		}
+	}
+	return matches
+}
+
+// CheckValue reports whether a configuration value has the parameter's
+// type. Values are strings as written in XML, or the booleans, numbers,
+// lists and maps of YAML templates. Property references such as
+// ${java.version} are accepted for every scalar type.
+func (p PluginParameter) CheckValue(value interface{}) error {
+	switch v := value.(type) {
+	case []interface{}:
+		if p.Type == ParamList {
+			return nil
+		}
+		return fmt.Errorf("%s takes a %s, not a list", p.Name, p.Type)
+	case map[string]interface{}, ConfigurationXML:
+		// Maven lists are nested elements, such as <includes><include>
+		if p.Type == ParamList || p.Type == ParamMap {
+			return nil
+		}
+		return fmt.Errorf("%s takes a %s, not nested elements", p.Name, p.Type)
+	case bool:
+		if p.Type == ParamBoolean || p.Type == ParamString {
+			return nil
+		}
+	case int, int64, float64:
+		if p.Type == ParamInteger || p.Type == ParamString {
+			return nil
+		}
+	case string:
+		if strings.Contains(v, "${") {
+			return nil
+		}
+		switch p.Type {
+		case ParamBoolean:
+			if v != "true" && v != "false" {
+				return fmt.Errorf("%s must be true or false, not %q", p.Name, v)
+			}
+			return nil
+		case ParamInteger:
+			if _, err := strconv.Atoi(strings.TrimSpace(v)); err != nil {
+				return fmt.Errorf("%s must be a number, not %q", p.Name, v)
+			}
+			return nil
+		case ParamString:
+			if len(p.Values) > 0 && !containsString(p.Values, v) {
+				return fmt.Errorf("%s must be one of %s, not %q", p.Name, strings.Join(p.Values, ", "), v)
+			}
+			return nil
+		}
+	case nil:
+		return nil
+	}
+	return fmt.Errorf("%s takes a %s, not %v", p.Name, p.Type, value)
+}
+
+// CheckParameter returns an error when a value does not have the type of a
+// known parameter, or when a name not known is close to a known one and
+// likely misspelled. Other names are accepted, since only commonly used
+// parameters are known.
+func CheckParameter(plugin Plugin, name string, value interface{}) error {
+	if param, ok := LookupParameter(plugin, name); ok {
+		return param.CheckValue(value)
+	}
+
+	params := PluginParameters(plugin)
+	names := make([]string, len(params))
+	for i, param := range params {
+		names[i] = param.Name
+	}
+	if suggestion := closestMatch(name, names); suggestion != "" {
+		return fmt.Errorf("%s has no parameter %s; did you mean %s?", plugin.ArtifactID, name, suggestion)
+	}
+	return nil
+}
+
+// pluginConfigurationRule checks the configuration of well-known plugins
+// with CheckParameter
+type pluginConfigurationRule struct{}
+
+func (r *pluginConfigurationRule) Validate(project *Project) []ValidationError {
+	if project.Build == nil {
+		return nil
+	}
+
+	var errors []ValidationError
+	for i, plugin := range project.Build.Plugins {
+		if len(PluginParameters(plugin)) == 0 {
+			continue
+		}
+		path := fmt.Sprintf("build.plugins[%d].configuration", i)
+		errors = append(errors, checkConfiguration(path, plugin, plugin.Configuration)...)
+		for j, exec := range plugin.Executions {
+			path := fmt.Sprintf("build.plugins[%d].executions[%d].configuration", i, j)
+			errors = append(errors, checkConfiguration(path, plugin, exec.Configuration)...)
+		}
+	}
+	return errors
+}
+
+// checkConfiguration checks the top-level parameters of one configuration
+func checkConfiguration(path string, plugin Plugin, config *Configuration) []ValidationError {
+	if config == nil {
+		return nil
+	}
+
+	var errors []ValidationError
+	for _, key := range config.Keys() {
+		value := config.Data[key]
+		if err := CheckParameter(plugin, key, value); err != nil {
+			errors = append(errors, ValidationError{
+				Field:    path + "." + key,
+				Value:    fmt.Sprint(value),
+				Message:  err.Error(),
+				Severity: SeverityWarning,
+				Rule:     RulePluginConfiguration,
+			})
+		}
+	}
+	return errors
+}
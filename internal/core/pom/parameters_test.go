@@ -0,0 +1,194 @@
+package pom
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const configurationTestPOM = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <modelVersion>4.0.0</modelVersion>
+  <groupId>com.example</groupId>
+  <artifactId>demo</artifactId>
+  <version>1.0.0</version>
+  <build>
+    <plugins>
+      <plugin>
+        <groupId>org.apache.maven.plugins</groupId>
+        <artifactId>maven-surefire-plugin</artifactId>
+        <configuration>
+          <skipTests>yes</skipTests>
+          <includes>
+            <include>**/*Test.java</include>
+            <include>**/*Spec.java</include>
+          </includes>
+          <threadCnt>4</threadCnt>
+        </configuration>
+        <executions>
+          <execution>
+            <id>unit</id>
+            <goals><goal>test</goal></goals>
+            <configuration>
+              <parallel>methods</parallel>
+            </configuration>
+          </execution>
+        </executions>
+      </plugin>
+      <plugin>
+        <groupId>org.apache.maven.plugins</groupId>
+        <artifactId>maven-antrun-plugin</artifactId>
+        <configuration>
+          <target><echo message="hello"/></target>
+        </configuration>
+      </plugin>
+    </plugins>
+  </build>
+</project>
+`
+
+func TestParseConfiguration(t *testing.T) {
+	project, err := NewParser().Parse([]byte(configurationTestPOM))
+	if err != nil {
+		t.Fatalf("Expected POM to parse, got %v", err)
+	}
+
+	surefire := project.Build.Plugins[0]
+	if surefire.Configuration == nil {
+		t.Fatal("Expected the plugin configuration to be parsed")
+	}
+	if got := surefire.Configuration.Keys(); !reflect.DeepEqual(got, []string{"includes", "skipTests", "threadCnt"}) {
+		t.Errorf("Expected sorted parameter names, got %v", got)
+	}
+	if got := surefire.Configuration.Data["skipTests"]; got != "yes" {
+		t.Errorf("Expected skipTests text, got %v", got)
+	}
+	includes := map[string]interface{}{"include": []interface{}{"**/*Test.java", "**/*Spec.java"}}
+	if got := surefire.Configuration.Data["includes"]; !reflect.DeepEqual(got, includes) {
+		t.Errorf("Expected repeated elements as a list, got %#v", got)
+	}
+	if got := surefire.Executions[0].Configuration; got == nil || got.Data["parallel"] != "methods" {
+		t.Errorf("Expected the execution configuration to be parsed, got %v", got)
+	}
+
+	antrun := project.Build.Plugins[1]
+	target, ok := antrun.Configuration.Data["target"].(ConfigurationXML)
+	if !ok || !strings.Contains(string(target), `<echo message="hello"/>`) {
+		t.Errorf("Expected an element with attributes to be kept as written, got %#v", antrun.Configuration.Data["target"])
+	}
+}
+
+func TestGenerateConfigurationRoundTrip(t *testing.T) {
+	project, err := NewParser().Parse([]byte(configurationTestPOM))
+	if err != nil {
+		t.Fatalf("Expected POM to parse, got %v", err)
+	}
+
+	xmlData, err := NewGenerator().Generate(project)
+	if err != nil {
+		t.Fatalf("Expected POM to generate, got %v", err)
+	}
+	for _, want := range []string{
+		"<include>**/*Test.java</include>",
+		"<include>**/*Spec.java</include>",
+		"<skipTests>yes</skipTests>",
+		"<parallel>methods</parallel>",
+		`<echo message="hello"/>`,
+	} {
+		if !strings.Contains(string(xmlData), want) {
+			t.Errorf("Expected generated POM to contain %s", want)
+		}
+	}
+
+	again, err := NewParser().Parse(xmlData)
+	if err != nil {
+		t.Fatalf("Expected generated POM to parse, got %v", err)
+	}
+	for i, plugin := range project.Build.Plugins {
+		if !reflect.DeepEqual(again.Build.Plugins[i].Configuration, plugin.Configuration) {
+			t.Errorf("Expected configuration of %s to survive a round trip, got %#v", plugin.ArtifactID, again.Build.Plugins[i].Configuration.Data)
+		}
+	}
+}
+
+func TestCheckParameter(t *testing.T) {
+	compiler := Plugin{GroupID: DefaultPluginGroupID, ArtifactID: "maven-compiler-plugin"}
+	surefire := Plugin{ArtifactID: "maven-surefire-plugin"} // Default groupId
+	unknown := Plugin{GroupID: "com.example", ArtifactID: "custom-plugin"}
+
+	tests := []struct {
+		name    string
+		plugin  Plugin
+		param   string
+		value   interface{}
+		wantErr string
+	}{
+		{"integer", compiler, "release", "17", ""},
+		{"integer not a number", compiler, "release", "seventeen", "release must be a number"},
+		{"property reference", compiler, "release", "${java.version}", ""},
+		{"boolean", surefire, "skipTests", "true", ""},
+		{"boolean misspelled", surefire, "skipTests", "yes", "skipTests must be true or false"},
+		{"YAML boolean", surefire, "skipTests", true, ""},
+		{"allowed value", surefire, "parallel", "classes", ""},
+		{"value not allowed", surefire, "parallel", "threads", "parallel must be one of"},
+		{"list as nested elements", surefire, "includes", map[string]interface{}{"include": "**/*IT.java"}, ""},
+		{"list as text", surefire, "includes", "**/*IT.java", "includes takes a list"},
+		{"nested elements for a boolean", surefire, "skipTests", map[string]interface{}{"a": "b"}, "skipTests takes a boolean, not nested elements"},
+		{"misspelled name", compiler, "relase", "17", "did you mean release?"},
+		{"unknown name", compiler, "verbose", "true", ""},
+		{"unknown plugin", unknown, "relase", "x", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckParameter(tt.plugin, tt.param, tt.value)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCompleteParameter(t *testing.T) {
+	surefire := Plugin{GroupID: DefaultPluginGroupID, ArtifactID: "maven-surefire-plugin"}
+
+	var names []string
+	for _, param := range CompleteParameter(surefire, "SKIP") {
+		names = append(names, param.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"skipTests"}) {
+		t.Errorf("Expected completion ignoring case, got %v", names)
+	}
+
+	if got := CompleteParameter(surefire, ""); len(got) != len(PluginParameters(surefire)) {
+		t.Errorf("Expected every parameter for an empty prefix, got %d", len(got))
+	}
+	if got := CompleteParameter(Plugin{GroupID: "com.example", ArtifactID: "custom-plugin"}, ""); got != nil {
+		t.Errorf("Expected no completion for an unknown plugin, got %v", got)
+	}
+}
+
+func TestPluginConfigurationRule(t *testing.T) {
+	project, err := NewParser().Parse([]byte(configurationTestPOM))
+	if err != nil {
+		t.Fatalf("Expected POM to parse, got %v", err)
+	}
+
+	var fields []string
+	for _, finding := range (&pluginConfigurationRule{}).Validate(project) {
+		if finding.Severity != SeverityWarning || finding.Rule != RulePluginConfiguration {
+			t.Errorf("Expected a plugin-configuration warning, got %+v", finding)
+		}
+		fields = append(fields, finding.Field)
+	}
+	want := []string{"build.plugins[0].configuration.skipTests", "build.plugins[0].configuration.threadCnt"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("Expected findings %v, got %v", want, fields)
+	}
+}
@@ -1,8 +1,11 @@
 package pom
 
 import (
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/beevik/etree"
 )
@@ -42,7 +45,7 @@ func (p *defaultParser) Parse(xmlData []byte) (*Project, error) {
 	// Parse XML
 	doc := etree.NewDocument()
 	if err := doc.ReadFromBytes(xmlData); err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrInvalidXML, err)
+		return nil, fmt.Errorf("%w: %w", ErrInvalidXML, err)
 	}
 
 	// Get root project element
@@ -51,30 +54,64 @@ func (p *defaultParser) Parse(xmlData []byte) (*Project, error) {
 		return nil, fmt.Errorf("%w: missing <project> root element", ErrInvalidXML)
 	}
 
+	positions := BuildSourceMap(xmlData)
 	project := &Project{
 		XMLNS:          MavenXMLNamespace,
 		XSI:            "http://www.w3.org/2001/XMLSchema-instance",
 		SchemaLocation: MavenXMLSchemaLocation,
-		ModelVersion:   DefaultModelVersion,
+		Positions:      positions,
 	}
 
-	// Parse model version
+	// Recognize a banner, so saving replaces it rather than adding another
+	for _, token := range doc.Child {
+		if comment, ok := token.(*etree.Comment); ok {
+			if banner, ok := ParseBanner(comment.Data); ok {
+				project.Banner = &banner
+				break
+			}
+		}
+	}
+
+	// Parse model version; a missing one is reported by the validator and
+	// written as the default by the generator
 	if modelVersion := root.SelectElement("modelVersion"); modelVersion != nil {
 		project.ModelVersion = modelVersion.Text()
 	}
 
+	// Parse parent first so coordinates can be inherited from it
+	if parentElem := root.SelectElement("parent"); parentElem != nil {
+		parent, err := p.parseParent(parentElem)
+		if err != nil {
+			return nil, positions.wrap("parent", fmt.Errorf("parsing parent: %w", err))
+		}
+		project.Parent = parent
+	}
+
 	// Parse coordinates
 	groupID := root.SelectElement("groupId")
 	artifactID := root.SelectElement("artifactId")
 	version := root.SelectElement("version")
 
-	if groupID == nil || artifactID == nil || version == nil {
-		return nil, fmt.Errorf("%w: missing required fields (groupId, artifactId, or version)", ErrMissingRequired)
+	// groupId and version may be omitted when inherited from <parent>
+	if groupID == nil && project.Parent != nil {
+		project.GroupID = project.Parent.GroupID
+		project.InheritsGroupID = true
+	} else if groupID != nil {
+		project.GroupID = groupID.Text()
+	}
+
+	if version == nil && project.Parent != nil {
+		project.Version = project.Parent.Version
+		project.InheritsVersion = true
+	} else if version != nil {
+		project.Version = version.Text()
+	}
+
+	if artifactID == nil || (groupID == nil && !project.InheritsGroupID) || (version == nil && !project.InheritsVersion) {
+		return nil, positions.wrap("", fmt.Errorf("%w: missing required fields (groupId, artifactId, or version)", ErrMissingRequired))
 	}
 
-	project.GroupID = groupID.Text()
 	project.ArtifactID = artifactID.Text()
-	project.Version = version.Text()
 	project.Coordinates = Coordinates{
 		GroupID:    project.GroupID,
 		ArtifactID: project.ArtifactID,
@@ -106,33 +143,35 @@ func (p *defaultParser) Parse(xmlData []byte) (*Project, error) {
 
 	// Parse dependencies
 	if dependencies := root.SelectElement("dependencies"); dependencies != nil {
-		for _, dep := range dependencies.SelectElements("dependency") {
+		for i, dep := range dependencies.SelectElements("dependency") {
 			dependency, err := p.parseDependency(dep)
 			if err != nil {
-				return nil, fmt.Errorf("parsing dependency: %w", err)
+				return nil, positions.wrap(fmt.Sprintf("dependencies[%d]", i), fmt.Errorf("parsing dependency: %w", err))
 			}
 			project.Dependencies = append(project.Dependencies, dependency)
 		}
 	}
 
+	// Parse dependency management
+	if managed := root.FindElement("dependencyManagement/dependencies"); managed != nil {
+		for i, dep := range managed.SelectElements("dependency") {
+			dependency, err := p.parseDependency(dep)
+			if err != nil {
+				return nil, positions.wrap(fmt.Sprintf("dependencyManagement[%d]", i), fmt.Errorf("parsing managed dependency: %w", err))
+			}
+			project.DependencyManagement = append(project.DependencyManagement, dependency)
+		}
+	}
+
 	// Parse build
 	if buildElem := root.SelectElement("build"); buildElem != nil {
 		build, err := p.parseBuild(buildElem)
 		if err != nil {
-			return nil, fmt.Errorf("parsing build: %w", err)
+			return nil, positions.wrap("build", fmt.Errorf("parsing build: %w", err))
 		}
 		project.Build = build
 	}
 
-	// Parse parent
-	if parentElem := root.SelectElement("parent"); parentElem != nil {
-		parent, err := p.parseParent(parentElem)
-		if err != nil {
-			return nil, fmt.Errorf("parsing parent: %w", err)
-		}
-		project.Parent = parent
-	}
-
 	// Parse modules
 	if modulesElem := root.SelectElement("modules"); modulesElem != nil {
 		for _, module := range modulesElem.SelectElements("module") {
@@ -142,10 +181,10 @@ func (p *defaultParser) Parse(xmlData []byte) (*Project, error) {
 
 	// Parse profiles
 	if profilesElem := root.SelectElement("profiles"); profilesElem != nil {
-		for _, profileElem := range profilesElem.SelectElements("profile") {
+		for i, profileElem := range profilesElem.SelectElements("profile") {
 			profile, err := p.parseProfile(profileElem)
 			if err != nil {
-				return nil, fmt.Errorf("parsing profile: %w", err)
+				return nil, positions.wrap(fmt.Sprintf("profiles[%d]", i), fmt.Errorf("parsing profile: %w", err))
 			}
 			project.Profiles = append(project.Profiles, profile)
 		}
@@ -192,18 +231,33 @@ func (p *defaultParser) parseDependency(elem *etree.Element) (Dependency, error)
 	artifactID := elem.SelectElement("artifactId")
 	version := elem.SelectElement("version")
 
-	if groupID == nil || artifactID == nil || version == nil {
+	// The version may be omitted when it is managed by dependencyManagement
+	if groupID == nil || artifactID == nil {
 		return dep, fmt.Errorf("%w: dependency missing required fields", ErrMissingRequired)
 	}
 
 	dep.GroupID = groupID.Text()
 	dep.ArtifactID = artifactID.Text()
-	dep.Version = version.Text()
+	if version != nil {
+		dep.Version = version.Text()
+	}
+
+	if depType := elem.SelectElement("type"); depType != nil {
+		dep.Type = depType.Text()
+	}
+
+	if classifier := elem.SelectElement("classifier"); classifier != nil {
+		dep.Classifier = classifier.Text()
+	}
 
 	if scope := elem.SelectElement("scope"); scope != nil {
 		dep.Scope = scope.Text()
 	}
 
+	if systemPath := elem.SelectElement("systemPath"); systemPath != nil {
+		dep.SystemPath = systemPath.Text()
+	}
+
 	if optional := elem.SelectElement("optional"); optional != nil {
 		dep.Optional = optional.Text() == "true"
 	}
@@ -286,6 +340,13 @@ func (p *defaultParser) parsePlugin(elem *etree.Element) (Plugin, error) {
 	if version := elem.SelectElement("version"); version != nil {
 		plugin.Version = version.Text()
 	}
+	if extensions := elem.SelectElement("extensions"); extensions != nil {
+		plugin.Extensions = extensions.Text() == "true"
+	}
+
+	if config := elem.SelectElement("configuration"); config != nil {
+		plugin.Configuration = parseConfiguration(config)
+	}
 
 	// Parse executions
 	if executions := elem.SelectElement("executions"); executions != nil {
@@ -320,9 +381,69 @@ func (p *defaultParser) parseExecution(elem *etree.Element) (PluginExecution, er
 		}
 	}
 
+	if config := elem.SelectElement("configuration"); config != nil {
+		exec.Configuration = parseConfiguration(config)
+	}
+
 	return exec, nil
 }
 
+// parseConfiguration parses a plugin or execution configuration element
+func parseConfiguration(elem *etree.Element) *Configuration {
+	config := &Configuration{Data: make(map[string]interface{})}
+	for key, value := range parseConfigurationChildren(elem) {
+		config.Data[key] = value
+	}
+	return config
+}
+
+// parseConfigurationChildren returns the child elements of elem by name,
+// with the values of repeated elements collected into a list
+func parseConfigurationChildren(elem *etree.Element) map[string]interface{} {
+	children := make(map[string]interface{})
+	for _, child := range elem.ChildElements() {
+		value := parseConfigurationValue(child)
+		switch existing := children[child.Tag].(type) {
+		case nil:
+			children[child.Tag] = value
+		case []interface{}:
+			children[child.Tag] = append(existing, value)
+		default:
+			children[child.Tag] = []interface{}{existing, value}
+		}
+	}
+	return children
+}
+
+// parseConfigurationValue returns the value of one configuration element:
+// its text, its child elements, or the element as written when it has
+// attributes the map form cannot hold
+func parseConfigurationValue(elem *etree.Element) interface{} {
+	if hasAttributes(elem) {
+		doc := etree.NewDocumentWithRoot(elem.Copy())
+		doc.Unindent()
+		written, _ := doc.WriteToString()
+		return ConfigurationXML(written)
+	}
+	if len(elem.ChildElements()) == 0 {
+		return strings.TrimSpace(elem.Text())
+	}
+	return parseConfigurationChildren(elem)
+}
+
+// hasAttributes reports whether elem or an element inside it has attributes
+func hasAttributes(elem *etree.Element) bool {
+	if len(elem.Attr) > 0 {
+		return true
+	}
+	for _, child := range elem.ChildElements() {
+		if hasAttributes(child) {
+			return true
+		}
+	}
+	return false
+}
+
 // parseParent parses a parent element
 func (p *defaultParser) parseParent(elem *etree.Element) (*Parent, error) {
 	parent := &Parent{}
@@ -420,3 +541,21 @@ func (p *defaultParser) parseProfile(elem *etree.Element) (Profile, error) {
 
 	return profile, nil
 }
+
+// ErrorLine returns the line number of an error returned by Parse, or 0
+// when the error carries no position
+func ErrorLine(err error) int {
+	var syntaxErr *xml.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return syntaxErr.Line
+	}
+	var positionErr *PositionError
+	if errors.As(err, &positionErr) {
+		return positionErr.Line
+	}
+	var limitErr *LimitError
+	if errors.As(err, &limitErr) {
+		return limitErr.Line
+	}
+	return 0
+}
@@ -0,0 +1,202 @@
+package pom
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CheckPaths reports source directories and modules that do not exist
+// relative to dir, the directory containing the POM, and a parent
+// relativePath that does not lead to the declared parent. Output
+// directories are created by the build and only reported when they name a
+// file. Paths with properties other than ${project.basedir} are not checked.
+func CheckPaths(project *Project, dir string) []ValidationError {
+	errors := checkParentPath(project, dir)
+
+	if build := project.Build; build != nil {
+		for _, entry := range []struct {
+			field string
+			path  string
+		}{
+			{"build.sourceDirectory", build.SourceDirectory},
+			{"build.testSourceDirectory", build.TestSourceDirectory},
+		} {
+			target, ok := resolvePath(dir, entry.path)
+			if !ok {
+				continue
+			}
+			if _, err := os.Stat(target); os.IsNotExist(err) {
+				errors = append(errors, ValidationError{
+					Field:    entry.field,
+					Value:    entry.path,
+					Message:  fmt.Sprintf("directory %s does not exist", target),
+					Severity: SeverityWarning,
+					Rule:     RuleBuildDirectory,
+					Fix:      createDirectoryFix(target),
+				})
+			}
+		}
+
+		if target, ok := resolvePath(dir, build.OutputDirectory); ok {
+			if info, err := os.Stat(target); err == nil && !info.IsDir() {
+				errors = append(errors, ValidationError{
+					Field:    "build.outputDirectory",
+					Value:    build.OutputDirectory,
+					Message:  fmt.Sprintf("%s is a file, not a directory", target),
+					Severity: SeverityWarning,
+					Rule:     RuleBuildDirectory,
+				})
+			}
+		}
+	}
+
+	for i, module := range project.Modules {
+		target, ok := resolvePath(dir, module)
+		if !ok {
+			continue
+		}
+		// A module is a directory with a pom.xml, or a POM file itself
+		if info, err := os.Stat(target); err == nil && info.IsDir() {
+			target = filepath.Join(target, "pom.xml")
+		}
+		if _, err := os.Stat(target); os.IsNotExist(err) {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("modules[%d]", i),
+				Value:   module,
+				Message: fmt.Sprintf("module POM %s does not exist", target),
+				Rule:    RuleModulePath,
+				Fix:     createModuleFix(dir, module),
+			})
+		}
+	}
+
+	return errors
+}
+
+// checkParentPath reports a parent relativePath leading nowhere or to a POM
+// other than the declared parent, most often one whose version was bumped
+// without updating the child. A missing POM at the default ../pom.xml is
+// fine since the parent then comes from a repository.
+func checkParentPath(project *Project, dir string) []ValidationError {
+	parent := project.Parent
+	if parent == nil {
+		return nil
+	}
+
+	relativePath := parent.RelativePath
+	if relativePath == "" {
+		relativePath = DefaultRelativePath
+	}
+	target, ok := resolvePath(dir, relativePath)
+	if !ok {
+		return nil
+	}
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		target = filepath.Join(target, "pom.xml")
+	}
+
+	if _, err := os.Stat(target); os.IsNotExist(err) {
+		if parent.RelativePath == "" {
+			return nil
+		}
+		return []ValidationError{{
+			Field:    "parent.relativePath",
+			Value:    parent.RelativePath,
+			Message:  fmt.Sprintf("parent POM %s does not exist", target),
+			Severity: SeverityWarning,
+			Rule:     RuleParentPath,
+		}}
+	}
+
+	found, err := NewParser().ParseFile(target)
+	if err != nil {
+		return []ValidationError{{
+			Field:    "parent.relativePath",
+			Value:    relativePath,
+			Message:  fmt.Sprintf("cannot read parent POM %s: %v", target, err),
+			Severity: SeverityWarning,
+			Rule:     RuleParentPath,
+		}}
+	}
+
+	if found.GroupID != parent.GroupID || found.ArtifactID != parent.ArtifactID {
+		return []ValidationError{{
+			Field:    "parent.relativePath",
+			Value:    relativePath,
+			Message:  fmt.Sprintf("%s is %s:%s, not the declared parent %s:%s", target, found.GroupID, found.ArtifactID, parent.GroupID, parent.ArtifactID),
+			Severity: SeverityWarning,
+			Rule:     RuleParentPath,
+		}}
+	}
+
+	if found.Version != "" && found.Version != parent.Version {
+		return []ValidationError{{
+			Field:    "parent.version",
+			Value:    parent.Version,
+			Message:  fmt.Sprintf("parent POM %s has version %s", target, found.Version),
+			Severity: SeverityWarning,
+			Rule:     RuleParentPath,
+			Fix:      setParentVersionFix(parent.Version, found.Version),
+		}}
+	}
+
+	return nil
+}
+
+// resolvePath resolves a path from the POM against its directory; ok is
+// false for empty paths and paths with unknown properties
+func resolvePath(dir, path string) (string, bool) {
+	for _, basedir := range []string{"${project.basedir}", "${basedir}"} {
+		path = strings.ReplaceAll(path, basedir, dir)
+	}
+	if path == "" || strings.Contains(path, "${") {
+		return "", false
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+	}
+	return filepath.Clean(path), true
+}
+
+// createDirectoryFix creates a missing directory
+func createDirectoryFix(target string) *Fix {
+	return &Fix{
+		Description:  fmt.Sprintf("Create directory %s", target),
+		ChangesFiles: true,
+		Apply: func(project *Project) error {
+			return os.MkdirAll(target, 0755)
+		},
+	}
+}
+
+// createModuleFix writes a module POM inheriting from the project, from the
+// basic-java template
+func createModuleFix(dir, module string) *Fix {
+	return &Fix{
+		Description:  fmt.Sprintf("Create module %s", module),
+		ChangesFiles: true,
+		Apply: func(project *Project) error {
+			target, ok := resolvePath(dir, module)
+			if !ok {
+				return ErrFixNotApplicable
+			}
+			if filepath.Ext(target) != ".xml" {
+				target = filepath.Join(target, "pom.xml")
+			}
+			if _, err := os.Stat(target); err == nil {
+				return ErrFixNotApplicable
+			}
+
+			child, err := NewChildProject(project, module, NewTemplateManager(), "basic-java")
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			return NewGenerator().GenerateToFile(child, target)
+		},
+	}
+}
@@ -0,0 +1,171 @@
+package pom
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Position is a place in a POM file; the zero value means unknown
+type Position struct {
+	Line   int // 1-based
+	Column int // 1-based, in bytes
+}
+
+// IsKnown reports whether the position was found
+func (p Position) IsKnown() bool {
+	return p.Line > 0
+}
+
+// String returns "line:column"
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// PositionError is a parse error at a known place in the file
+type PositionError struct {
+	Position
+	Err error
+}
+
+// Error returns the error prefixed with its line
+func (e *PositionError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *PositionError) Unwrap() error {
+	return e.Err
+}
+
+// listElements maps list containers to the element they repeat
+var listElements = map[string]string{
+	"dependencies":       "dependency",
+	"exclusions":         "exclusion",
+	"plugins":            "plugin",
+	"executions":         "execution",
+	"goals":              "goal",
+	"modules":            "module",
+	"profiles":           "profile",
+	"repositories":       "repository",
+	"pluginRepositories": "pluginRepository",
+	"resources":          "resource",
+	"testResources":      "testResource",
+	"extensions":         "extension",
+}
+
+// SourceMap records where elements start, keyed by the field paths used in
+// ValidationError: "version", "dependencies[0].scope",
+// "dependencyManagement[1]", "build.plugins[0].executions[2].phase". The
+// <project> element itself is recorded under "".
+type SourceMap map[string]Position
+
+// BuildSourceMap scans POM XML for element positions. Malformed XML yields
+// the positions found before the error.
+func BuildSourceMap(data []byte) SourceMap {
+	// Offsets of line starts, to turn byte offsets into line and column
+	lineStarts := []int{0}
+	for i, b := range data {
+		if b == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	position := func(offset int64) Position {
+		line := sort.Search(len(lineStarts), func(i int) bool { return lineStarts[i] > int(offset) })
+		return Position{Line: line, Column: int(offset) - lineStarts[line-1] + 1}
+	}
+
+	type frame struct {
+		tag    string
+		path   string
+		counts map[string]int
+	}
+
+	sourceMap := make(SourceMap)
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	var stack []*frame
+
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err != nil {
+			return sourceMap
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			current := &frame{tag: t.Name.Local, counts: make(map[string]int)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				switch {
+				case listElements[parent.tag] == current.tag:
+					index := parent.counts[current.tag]
+					parent.counts[current.tag]++
+					// Managed dependencies are reported as dependencyManagement[i]
+					base := parent.path
+					if strings.HasSuffix(base, "dependencyManagement.dependencies") {
+						base = strings.TrimSuffix(base, ".dependencies")
+					}
+					current.path = fmt.Sprintf("%s[%d]", base, index)
+				case parent.path == "":
+					current.path = current.tag
+				default:
+					current.path = parent.path + "." + current.tag
+				}
+			}
+			if _, seen := sourceMap[current.path]; !seen {
+				sourceMap[current.path] = position(offset)
+			}
+			stack = append(stack, current)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+}
+
+// Lookup returns the position of a field. Fields of elements that are
+// missing resolve to the closest enclosing element that exists.
+func (m SourceMap) Lookup(field string) (Position, bool) {
+	for {
+		if position, ok := m[field]; ok {
+			return position, true
+		}
+		if field == "" {
+			return Position{}, false
+		}
+		if i := strings.LastIndexAny(field, ".["); i >= 0 {
+			field = field[:i]
+		} else {
+			field = ""
+		}
+	}
+}
+
+// wrap attaches the position of a field to a parse error
+func (m SourceMap) wrap(field string, err error) error {
+	if position, ok := m.Lookup(field); ok {
+		return &PositionError{Position: position, Err: err}
+	}
+	return err
+}
+
+// Locate sets the position of every finding in the result
+func (m SourceMap) Locate(result *ValidationResult) {
+	for _, findings := range [][]ValidationError{
+		result.Errors.Coordinates,
+		result.Errors.Dependencies,
+		result.Errors.Build,
+		result.Errors.General,
+	} {
+		for i := range findings {
+			if position, ok := m.Lookup(findings[i].Field); ok {
+				findings[i].Position = position
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package pom
+
+import "sort"
+
+// Kinds of effective values traced by Provenance
+const (
+	ProvenanceProperty = "property"
+	ProvenancePlugin   = "plugin"
+	ProvenanceManaged  = "managed"
+)
+
+// ProvenanceValue is a value as declared by one POM of the chain
+type ProvenanceValue struct {
+	Value  string
+	Source string // Coordinates of the declaring POM
+	Depth  int    // 0 for the project itself, 1 for its parent, and so on
+}
+
+// Provenance traces an effective value to the POM that declares it, along
+// with the values it overrides further up the parent chain
+type Provenance struct {
+	Kind string
+	Name string // Property name, or groupId:artifactId of plugins and managed entries
+	ProvenanceValue
+	Overrides []ProvenanceValue // Nearest first
+}
+
+// Inherited reports whether the effective value comes from an ancestor
+func (p Provenance) Inherited() bool {
+	return p.Depth > 0
+}
+
+// ComputeProvenance traces every property, plugin version and managed
+// version of the project through its parents, nearest first. Entries are
+// sorted by kind, then name.
+func ComputeProvenance(project *Project, parents []ResolvedParent) []Provenance {
+	if project == nil {
+		return nil
+	}
+
+	chain := []*Project{project}
+	for _, parent := range parents {
+		chain = append(chain, parent.Project)
+	}
+
+	var provenance []Provenance
+	index := make(map[string]int)
+	record := func(kind, name, value, source string, depth int) {
+		declared := ProvenanceValue{Value: value, Source: source, Depth: depth}
+		if i, ok := index[kind+"\x00"+name]; ok {
+			provenance[i].Overrides = append(provenance[i].Overrides, declared)
+			return
+		}
+		index[kind+"\x00"+name] = len(provenance)
+		provenance = append(provenance, Provenance{Kind: kind, Name: name, ProvenanceValue: declared})
+	}
+
+	for depth, declaring := range chain {
+		source := declaring.Coordinates.String()
+		for _, name := range sortedKeys(declaring.Properties) {
+			record(ProvenanceProperty, name, declaring.Properties[name], source, depth)
+		}
+		if declaring.Build != nil {
+			for _, plugin := range declaring.Build.Plugins {
+				groupID := plugin.GroupID
+				if groupID == "" {
+					groupID = DefaultPluginGroupID
+				}
+				record(ProvenancePlugin, groupID+":"+plugin.ArtifactID, plugin.Version, source, depth)
+			}
+		}
+		// Within one POM the last managed entry wins
+		managed := make(map[string]bool)
+		for i := len(declaring.DependencyManagement) - 1; i >= 0; i-- {
+			dep := declaring.DependencyManagement[i]
+			name := dep.GroupID + ":" + dep.ArtifactID
+			if managed[name] {
+				continue
+			}
+			managed[name] = true
+			record(ProvenanceManaged, name, dep.Version, source, depth)
+		}
+	}
+
+	kinds := map[string]int{ProvenanceProperty: 0, ProvenancePlugin: 1, ProvenanceManaged: 2}
+	sort.SliceStable(provenance, func(i, j int) bool {
+		if provenance[i].Kind != provenance[j].Kind {
+			return kinds[provenance[i].Kind] < kinds[provenance[j].Kind]
+		}
+		return provenance[i].Name < provenance[j].Name
+	})
+	return provenance
+}
@@ -0,0 +1,325 @@
+package pom
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// propertyReference matches ${name}
+var propertyReference = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// implicitPropertyPrefixes are the properties Maven provides without a
+// declaration: the model, the environment, settings.xml and Java system
+// properties
+var implicitPropertyPrefixes = []string{
+	"project.", "pom.", "parent.", "env.", "settings.",
+	"java.", "os.", "user.", "file.", "line.", "path.", "sun.",
+}
+
+// implicitProperties are further properties Maven provides
+var implicitProperties = []string{
+	"basedir",
+	"maven.build.timestamp",
+	"maven.home",
+	"maven.version",
+	"maven.repo.local",
+	"maven.multiModuleProjectDirectory",
+}
+
+// isImplicitProperty reports whether Maven provides a property without a
+// declaration in any POM
+func isImplicitProperty(key string) bool {
+	for _, prefix := range implicitPropertyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return containsString(implicitProperties, key)
+}
+
+// CheckPropertyReferences reports ${...} references to properties that
+// neither the project, its parent chain (inheritance, may be nil), nor
+// Maven itself define. external lists properties supplied outside the POM,
+// such as with -D or in settings.xml. A property defined only in profiles
+// is noted rather than warned about, as is any property when part of the
+// parent chain could not be read. Plugin configuration is not checked:
+// plugins commonly read properties set at build time, such as argLine.
+func CheckPropertyReferences(project *Project, inheritance *Inheritance, external []string) []ValidationError {
+	if project == nil {
+		return nil
+	}
+
+	candidates := DeclaredProperties(project, inheritance)
+	declared := make(map[string]bool, len(candidates))
+	for _, key := range candidates {
+		declared[key] = true
+	}
+	parentUnknown := project.Parent != nil
+	if inheritance != nil {
+		if n := len(inheritance.Parents); n > 0 {
+			parentUnknown = inheritance.Parents[n-1].Project.Parent != nil
+		}
+	}
+	inProfiles := make(map[string][]string) // Property -> IDs of the profiles defining it
+	for _, profile := range project.Profiles {
+		for key := range profile.Properties {
+			inProfiles[key] = append(inProfiles[key], profile.ID)
+		}
+	}
+
+	// Fields referring to each missing property, in order of appearance
+	var missing []string
+	fields := make(map[string][]string)
+	walkValues(project, false, func(field, profile, value string) string {
+		for _, match := range propertyReference.FindAllStringSubmatch(value, -1) {
+			key := match[1]
+			if declared[key] || isImplicitProperty(key) || containsString(external, key) ||
+				(profile != "" && containsString(inProfiles[key], profile)) {
+				continue
+			}
+			if _, seen := fields[key]; !seen {
+				missing = append(missing, key)
+			}
+			fields[key] = append(fields[key], field)
+		}
+		return value
+	})
+
+	var findings []ValidationError
+	for _, key := range missing {
+		finding := ValidationError{
+			Field:    fields[key][0],
+			Value:    "${" + key + "}",
+			Severity: SeverityWarning,
+			Rule:     RuleUndefinedProperty,
+		}
+		references := fmt.Sprintf("%d reference(s)", len(fields[key]))
+		switch {
+		case len(inProfiles[key]) > 0:
+			finding.Severity = SeverityInfo
+			finding.Message = fmt.Sprintf("property is only defined in profile(s) %s and undefined when none is active (%s)",
+				strings.Join(inProfiles[key], ", "), references)
+		case parentUnknown:
+			finding.Severity = SeverityInfo
+			finding.Message = fmt.Sprintf("property is not defined here; the parent POM, which could not be read, may define it (%s)", references)
+		default:
+			finding.Message = fmt.Sprintf("property is not defined (%s)", references)
+			if suggestion := SuggestProperty(key, candidates); suggestion != "" {
+				finding.Message = fmt.Sprintf("property is not defined, did you mean ${%s}? (%s)", suggestion, references)
+				finding.Fix = renameReferenceFix(key, suggestion)
+			}
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// DeclaredProperties returns the properties declared by the project, outside
+// profiles, and by its parent chain (inheritance, may be nil), sorted
+func DeclaredProperties(project *Project, inheritance *Inheritance) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	add := func(key string) {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	for key := range project.Properties {
+		add(key)
+	}
+	if inheritance != nil {
+		for _, property := range inheritance.Properties {
+			add(property.Name)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SuggestProperty returns the declared property that key is probably a
+// misspelling of, "" when there is none
+func SuggestProperty(key string, declared []string) string {
+	return closestMatch(key, declared)
+}
+
+// UndefinedPropertyKey returns the property a RuleUndefinedProperty finding
+// is about
+func UndefinedPropertyKey(finding ValidationError) string {
+	return strings.TrimSuffix(strings.TrimPrefix(finding.Value, "${"), "}")
+}
+
+// RenamePropertyReference replaces the references to property from with
+// references to property to, everywhere including plugin configuration.
+// Returns how many were replaced.
+func RenamePropertyReference(project *Project, from, to string) int {
+	old, replacement := "${"+from+"}", "${"+to+"}"
+	count := 0
+	walkValues(project, true, func(field, profile, value string) string {
+		count += strings.Count(value, old)
+		return strings.ReplaceAll(value, old, replacement)
+	})
+	// Coordinates mirror the project's, or the parent's when inherited
+	for _, value := range []*string{&project.Coordinates.GroupID, &project.Coordinates.ArtifactID, &project.Coordinates.Version} {
+		*value = strings.ReplaceAll(*value, old, replacement)
+	}
+	return count
+}
+
+// renameReferenceFix points the references to a missing property at the
+// defined property it is probably a misspelling of
+func renameReferenceFix(from, to string) *Fix {
+	return &Fix{
+		Description: fmt.Sprintf("Change ${%s} to ${%s}", from, to),
+		Apply: func(project *Project) error {
+			if RenamePropertyReference(project, from, to) == 0 {
+				return ErrFixNotApplicable
+			}
+			return nil
+		},
+	}
+}
+
+// walkValues calls visit with every value in the project that may hold a
+// property reference, storing what it returns. field is the value's path
+// as used in ValidationError and profile the ID of the enclosing profile,
+// "" outside profiles. Plugin configuration is only visited with
+// configuration set.
+func walkValues(project *Project, configuration bool, visit func(field, profile, value string) string) {
+	for _, value := range []struct {
+		field string
+		value *string
+	}{
+		{"groupId", &project.GroupID},
+		{"artifactId", &project.ArtifactID},
+		{"version", &project.Version},
+		{"packaging", &project.Packaging},
+		{"name", &project.Name},
+		{"description", &project.Description},
+	} {
+		*value.value = visit(value.field, "", *value.value)
+	}
+	if project.Parent != nil {
+		project.Parent.Version = visit("parent.version", "", project.Parent.Version)
+		project.Parent.RelativePath = visit("parent.relativePath", "", project.Parent.RelativePath)
+	}
+	walkProperties("", "", project.Properties, visit)
+	walkDependencies("dependencies", "", project.Dependencies, visit)
+	walkDependencies("dependencyManagement", "", project.DependencyManagement, visit)
+	walkBuild("build", "", project.Build, configuration, visit)
+	walkModules("", "", project.Modules, visit)
+
+	for i := range project.Profiles {
+		profile := &project.Profiles[i]
+		prefix := fmt.Sprintf("profiles[%d].", i)
+		walkProperties(prefix, profile.ID, profile.Properties, visit)
+		walkDependencies(prefix+"dependencies", profile.ID, profile.Dependencies, visit)
+		walkBuild(prefix+"build", profile.ID, profile.Build, configuration, visit)
+		walkModules(prefix, profile.ID, profile.Modules, visit)
+	}
+}
+
+// walkProperties visits property values in key order
+func walkProperties(prefix, profile string, properties map[string]string, visit func(field, profile, value string) string) {
+	for _, key := range sortedKeys(properties) {
+		properties[key] = visit(prefix+"properties."+key, profile, properties[key])
+	}
+}
+
+// walkDependencies visits the coordinates of dependencies and their
+// exclusions
+func walkDependencies(field, profile string, deps []Dependency, visit func(field, profile, value string) string) {
+	for i := range deps {
+		dep := &deps[i]
+		prefix := fmt.Sprintf("%s[%d].", field, i)
+		for _, value := range []struct {
+			field string
+			value *string
+		}{
+			{"groupId", &dep.GroupID},
+			{"artifactId", &dep.ArtifactID},
+			{"version", &dep.Version},
+			{"type", &dep.Type},
+			{"classifier", &dep.Classifier},
+			{"scope", &dep.Scope},
+			{"systemPath", &dep.SystemPath},
+		} {
+			*value.value = visit(prefix+value.field, profile, *value.value)
+		}
+		for j := range dep.Exclusions {
+			exclusion := &dep.Exclusions[j]
+			exclusionPrefix := fmt.Sprintf("%sexclusions[%d].", prefix, j)
+			exclusion.GroupID = visit(exclusionPrefix+"groupId", profile, exclusion.GroupID)
+			exclusion.ArtifactID = visit(exclusionPrefix+"artifactId", profile, exclusion.ArtifactID)
+		}
+	}
+}
+
+// walkBuild visits build directories, plugin coordinates and execution
+// phases, and plugin configuration with configuration set
+func walkBuild(field, profile string, build *Build, configuration bool, visit func(field, profile, value string) string) {
+	if build == nil {
+		return
+	}
+
+	build.SourceDirectory = visit(field+".sourceDirectory", profile, build.SourceDirectory)
+	build.TestSourceDirectory = visit(field+".testSourceDirectory", profile, build.TestSourceDirectory)
+	build.OutputDirectory = visit(field+".outputDirectory", profile, build.OutputDirectory)
+	for i := range build.Plugins {
+		plugin := &build.Plugins[i]
+		prefix := fmt.Sprintf("%s.plugins[%d].", field, i)
+		plugin.GroupID = visit(prefix+"groupId", profile, plugin.GroupID)
+		plugin.ArtifactID = visit(prefix+"artifactId", profile, plugin.ArtifactID)
+		plugin.Version = visit(prefix+"version", profile, plugin.Version)
+		if configuration && plugin.Configuration != nil {
+			walkConfiguration(prefix+"configuration", profile, plugin.Configuration.Data, visit)
+		}
+		for j := range plugin.Executions {
+			exec := &plugin.Executions[j]
+			execPrefix := fmt.Sprintf("%sexecutions[%d].", prefix, j)
+			exec.Phase = visit(execPrefix+"phase", profile, exec.Phase)
+			if configuration && exec.Configuration != nil {
+				walkConfiguration(execPrefix+"configuration", profile, exec.Configuration.Data, visit)
+			}
+		}
+	}
+}
+
+// walkConfiguration visits the string values of plugin configuration,
+// however deeply nested
+func walkConfiguration(field, profile string, data map[string]interface{}, visit func(field, profile, value string) string) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		data[key] = walkConfigurationValue(field+"."+key, profile, data[key], visit)
+	}
+}
+
+// walkConfigurationValue visits one configuration value and returns it
+// with the visited strings stored
+func walkConfigurationValue(field, profile string, value interface{}, visit func(field, profile, value string) string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return visit(field, profile, v)
+	case map[string]interface{}:
+		walkConfiguration(field, profile, v, visit)
+	case []interface{}:
+		for i := range v {
+			v[i] = walkConfigurationValue(fmt.Sprintf("%s[%d]", field, i), profile, v[i], visit)
+		}
+	}
+	return value
+}
+
+// walkModules visits module paths
+func walkModules(prefix, profile string, modules []string, visit func(field, profile, value string) string) {
+	for i := range modules {
+		modules[i] = visit(fmt.Sprintf("%smodules[%d]", prefix, i), profile, modules[i])
+	}
+}
@@ -11,6 +11,7 @@ type Repository interface {
 	Read(path string) ([]byte, error)
 	Write(path string, data []byte) error
 	Exists(path string) bool
+	IsWritable(path string) bool
 }
 
 // fileRepository implements Repository using the file system
@@ -79,3 +80,13 @@ func (r *fileRepository) Exists(path string) bool {
 	_, err := os.Stat(path)
 	return err == nil
 }
+
+// IsWritable checks if an existing file can be opened for writing
+func (r *fileRepository) IsWritable(path string) bool {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return false
+	}
+	file.Close()
+	return true
+}
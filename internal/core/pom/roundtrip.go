@@ -0,0 +1,72 @@
+package pom
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/beevik/etree"
+)
+
+// UnmodeledElements returns the paths of the elements of POM XML that are
+// lost when it is parsed and generated again, such as <repositories> or
+// <resources>, outermost first. Rewriting a POM from its Project drops
+// them; edits that must keep them go through the XML instead (see
+// SetDependencyVersion).
+func UnmodeledElements(data []byte) ([]string, error) {
+	project, err := NewParser().Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	generated, err := NewGenerator().Generate(project)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := elementPaths(data)
+	if err != nil {
+		return nil, err
+	}
+	kept, err := elementPaths(generated)
+	if err != nil {
+		return nil, err
+	}
+
+	var lost []string
+	for path := range original {
+		if !kept[path] {
+			lost = append(lost, path)
+		}
+	}
+	sort.Strings(lost)
+
+	// A lost element takes its content with it
+	var outermost []string
+	for _, path := range lost {
+		if n := len(outermost); n == 0 || !strings.HasPrefix(path, outermost[n-1]+"/") {
+			outermost = append(outermost, path)
+		}
+	}
+	return outermost, nil
+}
+
+// elementPaths returns the slash-separated tag paths of the elements of
+// XML data
+func elementPaths(data []byte) (map[string]bool, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidXML, err)
+	}
+	paths := make(map[string]bool)
+	var visit func(elem *etree.Element, path string)
+	visit = func(elem *etree.Element, path string) {
+		paths[path] = true
+		for _, child := range elem.ChildElements() {
+			visit(child, path+"/"+child.Tag)
+		}
+	}
+	if root := doc.Root(); root != nil {
+		visit(root, root.Tag)
+	}
+	return paths, nil
+}
@@ -0,0 +1,24 @@
+package pom
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnmodeledElements(t *testing.T) {
+	lost, err := UnmodeledElements([]byte(editTestPOM))
+	if err != nil {
+		t.Fatalf("Expected the POM to round trip, got %v", err)
+	}
+	// An empty relativePath turns off the parent lookup, so it matters too
+	if want := []string{"project/parent/relativePath", "project/repositories"}; !reflect.DeepEqual(lost, want) {
+		t.Errorf("Expected %q, got %q", want, lost)
+	}
+
+	modeled := strings.Replace(editTestPOM, "    <relativePath/>\n", "", 1)
+	modeled = modeled[:strings.Index(modeled, "  <repositories>")] + "</project>\n"
+	if lost, err := UnmodeledElements([]byte(modeled)); err != nil || len(lost) != 0 {
+		t.Errorf("Expected nothing lost, got %q, %v", lost, err)
+	}
+}
@@ -0,0 +1,313 @@
+package pom
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Validation rule IDs, attached to each finding so individual rules can be
+// disabled or tuned
+const (
+	RuleModelVersion             = "model-version"
+	RuleCoordinatesRequired      = "coordinates-required"
+	RuleGroupIDFormat            = "groupid-format"
+	RuleArtifactIDFormat         = "artifactid-format"
+	RuleVersionFormat            = "version-format"
+	RulePackagingType            = "packaging-type"
+	RuleDependencyCoordinates    = "dependency-coordinates"
+	RuleDependencyScope          = "dependency-scope"
+	RuleDependencyDuplicate      = "dependency-duplicate"
+	RuleCoordinateTypo           = "coordinate-typo"
+	RuleDependencyVersion        = "dependency-version"
+	RuleManagedVersion           = "managed-version"
+	RuleManagedDependencyVersion = "managed-dependency-version"
+	RuleImportType               = "import-type"
+	RuleImportScope              = "import-scope"
+	RuleManagedDuplicate         = "managed-duplicate"
+	RuleSystemPath               = "system-path"
+	RulePluginCoordinates        = "plugin-coordinates"
+	RuleExecutionPhase           = "execution-phase"
+	RuleExecutionGoal            = "execution-goal"
+	RulePluginConfiguration      = "plugin-configuration"
+	RuleAggregatorPackaging      = "aggregator-packaging"
+	RuleSelfParent               = "self-parent"
+	RuleBuildDirectory           = "build-directory"
+	RuleModulePath               = "module-path"
+	RuleParentPath               = "parent-path"
+	RuleUndefinedProperty        = "undefined-property"
+)
+
+// RuleOff disables a rule in RuleSettings
+const RuleOff = "off"
+
+// ProjectConfigFile is the per-project configuration file, looked up in the
+// POM's directory and its ancestors
+const ProjectConfigFile = ".pom-manager.yaml"
+
+// RuleInfo describes a validation rule
+type RuleInfo struct {
+	ID          string
+	Description string
+	Default     Severity
+}
+
+// RuleRegistry lists every validation rule with its default severity
+var RuleRegistry = []RuleInfo{
+	{RuleModelVersion, "modelVersion is declared", SeverityWarning},
+	{RuleCoordinatesRequired, "groupId, artifactId and version are present", SeverityError},
+	{RuleGroupIDFormat, "groupId is lowercase with dot separators", SeverityWarning},
+	{RuleArtifactIDFormat, "artifactId is lowercase with hyphens", SeverityWarning},
+	{RuleVersionFormat, "version follows semantic versioning or snapshot conventions", SeverityWarning},
+	{RulePackagingType, "packaging is a known type", SeverityError},
+	{RuleDependencyCoordinates, "dependencies have a groupId and artifactId", SeverityError},
+	{RuleDependencyScope, "dependency scopes are valid", SeverityError},
+	{RuleDependencyDuplicate, "no dependency is declared twice", SeverityError},
+	{RuleCoordinateTypo, "coordinates are not misspellings of well-known artifacts", SeverityWarning},
+	{RuleDependencyVersion, "dependencies have a version or a managed one", SeverityError},
+	{RuleManagedVersion, "notes where a missing version is managed", SeverityInfo},
+	{RuleManagedDependencyVersion, "dependencyManagement entries have a version", SeverityError},
+	{RuleImportType, "import scope is used with type pom", SeverityError},
+	{RuleImportScope, "import scope is only used in dependencyManagement", SeverityError},
+	{RuleManagedDuplicate, "each artifact and BOM is managed once", SeverityWarning},
+	{RuleSystemPath, "systemPath is given with, and only with, system scope", SeverityError},
+	{RulePluginCoordinates, "plugins have a groupId and artifactId", SeverityError},
+	{RuleExecutionPhase, "execution phases are lifecycle phases", SeverityError},
+	{RuleExecutionGoal, "execution goals exist in well-known plugins", SeverityWarning},
+	{RulePluginConfiguration, "well-known plugin parameters are spelled right and have the right type", SeverityWarning},
+	{RuleAggregatorPackaging, "projects with modules use pom packaging", SeverityError},
+	{RuleSelfParent, "a project is not its own parent", SeverityError},
+	{RuleBuildDirectory, "source directories exist next to the POM", SeverityWarning},
+	{RuleModulePath, "module directories contain a POM", SeverityError},
+	{RuleParentPath, "the POM at the parent's relativePath is the declared parent", SeverityWarning},
+	{RuleUndefinedProperty, "${...} references name a defined property", SeverityWarning},
+}
+
+// LookupRule returns the registry entry for a rule ID
+func LookupRule(id string) (RuleInfo, bool) {
+	for _, rule := range RuleRegistry {
+		if rule.ID == id {
+			return rule, true
+		}
+	}
+	return RuleInfo{}, false
+}
+
+// ParseSeverity parses a lowercase severity name
+func ParseSeverity(name string) (Severity, error) {
+	for _, severity := range []Severity{SeverityError, SeverityWarning, SeverityInfo} {
+		if name == severity.String() {
+			return severity, nil
+		}
+	}
+	return SeverityError, fmt.Errorf("%w: unknown severity %q (expected error, warning, info)", ErrInvalidFormat, name)
+}
+
+// RuleSettings maps rule IDs to a severity name or RuleOff; rules not
+// listed keep their default severity
+type RuleSettings map[string]string
+
+// Validate checks that every entry names a known rule and level
+func (s RuleSettings) Validate() error {
+	ids := make([]string, 0, len(s))
+	for id := range s {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		if _, ok := LookupRule(id); !ok {
+			return fmt.Errorf("%w: unknown validation rule %q", ErrInvalidFormat, id)
+		}
+		if level := s[id]; level != RuleOff {
+			if _, err := ParseSeverity(level); err != nil {
+				return fmt.Errorf("rule %s: %w", id, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Merge returns the settings with overrides applied on top
+func (s RuleSettings) Merge(overrides RuleSettings) RuleSettings {
+	merged := make(RuleSettings, len(s)+len(overrides))
+	for id, level := range s {
+		merged[id] = level
+	}
+	for id, level := range overrides {
+		merged[id] = level
+	}
+	return merged
+}
+
+// Apply drops findings of disabled rules and adjusts the severity of tuned
+// ones. Findings without a rule ID are kept as they are.
+func (s RuleSettings) Apply(result ValidationResult) ValidationResult {
+	if len(s) == 0 {
+		return result
+	}
+
+	tuned := ValidationResult{Valid: true}
+	for _, err := range result.Errors.AllErrors() {
+		if level, ok := s[err.Rule]; ok && err.Rule != "" {
+			if level == RuleOff {
+				continue
+			}
+			if severity, parseErr := ParseSeverity(level); parseErr == nil {
+				err.Severity = severity
+			}
+		}
+		tuned.addError(err)
+	}
+	return tuned
+}
+
+// ProjectConfig is the content of a .pom-manager.yaml file
+type ProjectConfig struct {
+	Validation struct {
+		Rules              RuleSettings `yaml:"rules"`
+		ExternalProperties []string     `yaml:"external_properties"` // Supplied outside the POM, such as with -D
+	} `yaml:"validation"`
+	Generator struct {
+		Banner *bool `yaml:"banner"` // Write a generated-by comment; nil leaves it to the tool's setting
+	} `yaml:"generator"`
+}
+
+// LoadProjectConfig reads and checks a project configuration file
+func LoadProjectConfig(path string) (*ProjectConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrFileNotFound, path)
+		}
+		return nil, fmt.Errorf("reading project config %s: %w", path, err)
+	}
+
+	var config ProjectConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("%w: parsing project config %s: %v", ErrInvalidFormat, path, err)
+	}
+	if err := config.Validation.Rules.Validate(); err != nil {
+		return nil, fmt.Errorf("project config %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// FindProjectConfig returns the closest .pom-manager.yaml in the POM's
+// directory or its ancestors
+func FindProjectConfig(pomPath string) (string, bool) {
+	dir, err := filepath.Abs(filepath.Dir(pomPath))
+	if err != nil {
+		return "", false
+	}
+	for {
+		candidate := filepath.Join(dir, ProjectConfigFile)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// ProjectConfigPath returns the path of the project config that applies to
+// a POM, or where AddExternalProperty creates one when there is none
+func ProjectConfigPath(pomPath string) string {
+	if path, ok := FindProjectConfig(pomPath); ok {
+		return path
+	}
+	return filepath.Join(filepath.Dir(pomPath), ProjectConfigFile)
+}
+
+// ProjectConfigFor returns the project config that applies to a POM and
+// its path; an empty config and path when there is none
+func ProjectConfigFor(pomPath string) (*ProjectConfig, string, error) {
+	path, ok := FindProjectConfig(pomPath)
+	if !ok {
+		return &ProjectConfig{}, "", nil
+	}
+	config, err := LoadProjectConfig(path)
+	if err != nil {
+		return &ProjectConfig{}, path, err
+	}
+	return config, path, nil
+}
+
+// AddExternalProperty records in the project config that applies to a POM
+// that a property is supplied outside the POM, so references to it are not
+// reported as undefined. Without a config, one is created next to the POM.
+// Other settings and comments in the file are kept. Returns the config's
+// path.
+func AddExternalProperty(pomPath, key string) (string, error) {
+	path := ProjectConfigPath(pomPath)
+
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return path, fmt.Errorf("reading project config %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return path, fmt.Errorf("%w: parsing project config %s: %v", ErrInvalidFormat, path, err)
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	validation, err := mappingEntry(doc.Content[0], "validation", yaml.MappingNode)
+	if err != nil {
+		return path, fmt.Errorf("project config %s: %w", path, err)
+	}
+	external, err := mappingEntry(validation, "external_properties", yaml.SequenceNode)
+	if err != nil {
+		return path, fmt.Errorf("project config %s: %w", path, err)
+	}
+	for _, item := range external.Content {
+		if item.Value == key {
+			return path, nil
+		}
+	}
+	external.Content = append(external.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key})
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return path, fmt.Errorf("encoding project config: %w", err)
+	}
+	if err := os.WriteFile(path, out.Bytes(), 0644); err != nil {
+		return path, fmt.Errorf("writing project config %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// mappingEntry returns the value of key in a YAML mapping, adding an empty
+// node of kind when the key is missing or has no value
+func mappingEntry(mapping *yaml.Node, key string, kind yaml.Kind) (*yaml.Node, error) {
+	if mapping.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: expected a mapping at line %d", ErrInvalidFormat, mapping.Line)
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != key {
+			continue
+		}
+		value := mapping.Content[i+1]
+		if value.Kind == yaml.ScalarNode && value.Tag == "!!null" {
+			*value = yaml.Node{Kind: kind}
+		}
+		if value.Kind != kind {
+			return nil, fmt.Errorf("%w: unexpected value for %s at line %d", ErrInvalidFormat, key, value.Line)
+		}
+		return value, nil
+	}
+
+	value := &yaml.Node{Kind: kind}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	return value, nil
+}
@@ -0,0 +1,135 @@
+package pom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// sbtModuleID matches "group" % "artifact" % version [% config], where %%
+	// (and Scala.js %%%) append the Scala binary version to the artifact and
+	// version and config are string literals or identifiers
+	sbtModuleID = regexp.MustCompile(`"([^"\s]+)"\s*(%{1,3})\s*"([^"\s]+)"\s*%\s*(?:"([^"]*)"|([A-Za-z_][\w.]*))` +
+		`(?:\s*%\s*(?:"([^"]*)"|([A-Za-z]\w*)))?`)
+
+	// sbtModifier matches a classifier, exclude or intransitive call chained
+	// to a module ID, which may be parenthesized: ("g" % "a" % "v").exclude(...)
+	sbtModifier = regexp.MustCompile(`^\s*\)?\s*\.?\s*(?:classifier\s*\(?\s*"([^"]*)"\s*\)?|` +
+		`exclude\s*\(\s*"([^"]*)"\s*,\s*"([^"]*)"\s*\)|(intransitive)(?:\s*\(\s*\))?)`)
+
+	// sbtVal matches val and lazy val definitions of string literals
+	sbtVal = regexp.MustCompile(`(?m)^\s*(?:lazy\s+)?val\s+(\w+)\s*(?::\s*String\s*)?=\s*"([^"]*)"`)
+
+	// sbtScalaVersion matches the build's scalaVersion setting
+	sbtScalaVersion = regexp.MustCompile(`scalaVersion\s*:=\s*"([^"]+)"`)
+)
+
+// ParseSBTDependencies reads the dependencies of a build.sbt, as declared in
+// libraryDependencies settings. Versions may be string literals or vals
+// defined in the same file; configurations such as Test or "provided" become
+// Maven scopes. Chained classifier, exclude and intransitive calls become the
+// classifier and exclusions.
+func ParseSBTDependencies(data []byte) (*DependencyImport, error) {
+	text := stripComments(string(data), `"`)
+
+	vals := make(map[string]string)
+	for _, match := range sbtVal.FindAllStringSubmatch(text, -1) {
+		vals[match[1]] = match[2]
+	}
+
+	scalaBinary := ""
+	if match := sbtScalaVersion.FindStringSubmatch(text); match != nil {
+		scalaBinary = scalaBinaryVersion(match[1])
+	}
+
+	result := &DependencyImport{}
+	for _, indexes := range sbtModuleID.FindAllStringSubmatchIndex(text, -1) {
+		match := submatches(text, indexes)
+		groupID, operator, artifactID := match[1], match[2], match[3]
+		key := groupID + ":" + artifactID
+
+		// Cross-built Scala artifacts carry the Scala binary version
+		if operator != "%" {
+			if operator == "%%%" {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s is a Scala.js/Native dependency, imported as its JVM artifact", key))
+			}
+			if scalaBinary == "" {
+				artifactID += "_${scala.binary.version}"
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: scalaVersion not found, define the scala.binary.version property", key))
+			} else {
+				artifactID += "_" + scalaBinary
+			}
+			key = groupID + ":" + artifactID
+		}
+
+		version := match[4]
+		if ref := match[5]; ref != "" {
+			// Identifiers such as Versions.akka resolve through their last segment
+			name := ref[strings.LastIndex(ref, ".")+1:]
+			resolved, ok := vals[name]
+			if !ok {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("skipped %s: version %s is not defined in this file", key, ref))
+				continue
+			}
+			version = resolved
+		}
+
+		dep := Dependency{GroupID: groupID, ArtifactID: artifactID, Version: version, Scope: ScopeCompile}
+
+		configuration := match[6] + match[7]
+		if configuration != "" {
+			// "test->default" maps the test configuration
+			configuration, _, _ = strings.Cut(configuration, "->")
+			if strings.EqualFold(configuration, "optional") {
+				dep.Optional = true
+			} else if scope, ok := configurationScope(configuration); ok {
+				dep.Scope = scope
+			} else {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: configuration %q has no Maven scope, using compile", key, configuration))
+			}
+		}
+
+		for rest := text[indexes[1]:]; ; {
+			modifier := sbtModifier.FindStringSubmatch(rest)
+			if modifier == nil {
+				break
+			}
+			rest = rest[len(modifier[0]):]
+			switch {
+			case modifier[1] != "":
+				dep.Classifier = modifier[1]
+			case modifier[4] != "":
+				dep.Exclusions = append(dep.Exclusions, Exclusion{GroupID: "*", ArtifactID: "*"})
+			case modifier[2] != "":
+				dep.Exclusions = append(dep.Exclusions, Exclusion{GroupID: modifier[2], ArtifactID: modifier[3]})
+			}
+		}
+
+		result.Dependencies = append(result.Dependencies, dep)
+	}
+
+	return result, nil
+}
+
+// submatches returns the text of the submatches at indexes, as
+// FindStringSubmatch does
+func submatches(text string, indexes []int) []string {
+	match := make([]string, len(indexes)/2)
+	for i := range match {
+		if start := indexes[2*i]; start >= 0 {
+			match[i] = text[start:indexes[2*i+1]]
+		}
+	}
+	return match
+}
+
+// scalaBinaryVersion returns the binary version artifacts are published for:
+// "2.13" for Scala 2.13.x, "3" for Scala 3
+func scalaBinaryVersion(version string) string {
+	parts := strings.Split(version, ".")
+	if parts[0] == "3" || len(parts) < 2 {
+		return parts[0]
+	}
+	return parts[0] + "." + parts[1]
+}
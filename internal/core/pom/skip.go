@@ -0,0 +1,137 @@
+package pom
+
+// SkipFlag is a property that makes a plugin skip its work when set to
+// true, such as skipTests for Surefire. Plugins read these user properties
+// from the POM's properties as well as from -D on the command line.
+type SkipFlag struct {
+	Property    string
+	Description string
+}
+
+// skipFlags lists the skip properties of well-known plugins by
+// groupId:artifactId
+var skipFlags = map[string][]SkipFlag{
+	DefaultPluginGroupID + ":maven-surefire-plugin": {
+		{"skipTests", "Compile tests but do not run them"},
+		{"maven.test.skip", "Neither compile nor run tests"},
+	},
+	DefaultPluginGroupID + ":maven-failsafe-plugin": {
+		{"skipITs", "Do not run integration tests"},
+		{"skipTests", "Do not run unit or integration tests"},
+	},
+	DefaultPluginGroupID + ":maven-compiler-plugin": {
+		{"maven.test.skip", "Do not compile tests (also skips running them)"},
+		{"maven.main.skip", "Do not compile main sources"},
+	},
+	DefaultPluginGroupID + ":maven-resources-plugin": {
+		{"maven.resources.skip", "Do not copy resources"},
+	},
+	DefaultPluginGroupID + ":maven-javadoc-plugin": {
+		{"maven.javadoc.skip", "Do not generate Javadoc"},
+	},
+	DefaultPluginGroupID + ":maven-source-plugin": {
+		{"maven.source.skip", "Do not package sources"},
+	},
+	DefaultPluginGroupID + ":maven-install-plugin": {
+		{"maven.install.skip", "Do not install to the local repository"},
+	},
+	DefaultPluginGroupID + ":maven-deploy-plugin": {
+		{"maven.deploy.skip", "Do not deploy to the remote repository"},
+	},
+	DefaultPluginGroupID + ":maven-site-plugin": {
+		{"maven.site.skip", "Do not generate the site"},
+		{"maven.site.deploy.skip", "Do not deploy the site"},
+	},
+	DefaultPluginGroupID + ":maven-gpg-plugin": {
+		{"gpg.skip", "Do not sign artifacts"},
+	},
+	DefaultPluginGroupID + ":maven-enforcer-plugin": {
+		{"enforcer.skip", "Do not check enforcer rules"},
+	},
+	DefaultPluginGroupID + ":maven-checkstyle-plugin": {
+		{"checkstyle.skip", "Do not run Checkstyle"},
+	},
+	DefaultPluginGroupID + ":maven-pmd-plugin": {
+		{"pmd.skip", "Do not run PMD"},
+		{"cpd.skip", "Do not run copy-paste detection"},
+	},
+	DefaultPluginGroupID + ":maven-assembly-plugin": {
+		{"assembly.skipAssembly", "Do not build assemblies"},
+	},
+	DefaultPluginGroupID + ":maven-dependency-plugin": {
+		{"mdep.skip", "Do not run dependency goals"},
+	},
+	DefaultPluginGroupID + ":maven-antrun-plugin": {
+		{"maven.antrun.skip", "Do not run Ant tasks"},
+	},
+	"org.jacoco:jacoco-maven-plugin": {
+		{"jacoco.skip", "Do not measure code coverage"},
+	},
+	"com.github.spotbugs:spotbugs-maven-plugin": {
+		{"spotbugs.skip", "Do not run SpotBugs"},
+	},
+	"com.diffplug.spotless:spotless-maven-plugin": {
+		{"spotless.check.skip", "Do not check formatting"},
+		{"spotless.apply.skip", "Do not apply formatting"},
+	},
+	"org.codehaus.mojo:exec-maven-plugin": {
+		{"exec.skip", "Do not run the program"},
+	},
+}
+
+// SkipFlags returns the skip properties understood by a plugin, or nil when
+// the plugin is not known
+func SkipFlags(plugin Plugin) []SkipFlag {
+	groupID := plugin.GroupID
+	if groupID == "" {
+		groupID = DefaultPluginGroupID
+	}
+	return skipFlags[groupID+":"+plugin.ArtifactID]
+}
+
+// SkipEnabled reports whether a skip property is set to true in the
+// project's properties or, when profileID is not empty, in that profile's
+func SkipEnabled(project *Project, profileID, property string) bool {
+	if profileID == "" {
+		return project.Properties[property] == "true"
+	}
+	for _, profile := range project.Profiles {
+		if profile.ID == profileID {
+			return profile.Properties[property] == "true"
+		}
+	}
+	return false
+}
+
+// SetSkip sets a skip property to true in the project's properties or,
+// when profileID is not empty, in that profile's, adding the profile when
+// needed. Clearing the flag removes the property.
+func SetSkip(project *Project, profileID, property string, skip bool) {
+	properties := &project.Properties
+	if profileID != "" {
+		index := -1
+		for i, profile := range project.Profiles {
+			if profile.ID == profileID {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			if !skip {
+				return
+			}
+			project.Profiles = append(project.Profiles, Profile{ID: profileID})
+			index = len(project.Profiles) - 1
+		}
+		properties = &project.Profiles[index].Properties
+	}
+
+	if !skip {
+		delete(*properties, property)
+		return
+	}
+	if *properties == nil {
+		*properties = make(map[string]string)
+	}
+	(*properties)[property] = "true"
+}
@@ -0,0 +1,179 @@
+package pom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/beevik/etree"
+)
+
+// Snippet holds the dependencies and plugins found in a piece of POM XML,
+// such as the <dependency> blocks in a library's documentation
+type Snippet struct {
+	Dependencies []Dependency
+	Plugins      []Plugin
+	Warnings     []string // Elements that were skipped
+}
+
+// Empty reports whether the snippet contains nothing to add
+func (s *Snippet) Empty() bool {
+	return len(s.Dependencies) == 0 && len(s.Plugins) == 0
+}
+
+// xmlDeclaration matches the <?xml ...?> header that may start a snippet
+var xmlDeclaration = regexp.MustCompile(`^\s*<\?xml[^>]*\?>`)
+
+// ParseSnippet reads one or more <dependency> and <plugin> elements from XML
+// text. They may stand alone, one after the other, or be wrapped in
+// <dependencies>, <plugins>, <build> or a whole <project>. Plugins without a
+// groupId get the default org.apache.maven.plugins.
+func ParseSnippet(xml string) (*Snippet, error) {
+	doc := etree.NewDocument()
+	wrapped := "<snippet>" + xmlDeclaration.ReplaceAllString(xml, "") + "</snippet>"
+	if err := doc.ReadFromString(wrapped); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidXML, err)
+	}
+
+	snippet := &Snippet{}
+	parser := &defaultParser{}
+	var walk func(elem *etree.Element)
+	walk = func(elem *etree.Element) {
+		for _, child := range elem.ChildElements() {
+			switch child.Tag {
+			case "dependency":
+				dep, err := parser.parseDependency(child)
+				if err != nil {
+					snippet.Warnings = append(snippet.Warnings, fmt.Sprintf("skipped <dependency>: %v", err))
+					continue
+				}
+				snippet.Dependencies = append(snippet.Dependencies, dep)
+			case "plugin":
+				if child.SelectElement("groupId") == nil {
+					child.CreateElement("groupId").SetText(DefaultPluginGroupID)
+				}
+				plugin, err := parser.parsePlugin(child)
+				if err != nil {
+					snippet.Warnings = append(snippet.Warnings, fmt.Sprintf("skipped <plugin>: %v", err))
+					continue
+				}
+				snippet.Plugins = append(snippet.Plugins, plugin)
+			case "dependencyManagement", "pluginManagement", "profiles":
+				snippet.Warnings = append(snippet.Warnings, fmt.Sprintf("skipped <%s>, only plain dependencies and plugins are added", child.Tag))
+			default:
+				walk(child)
+			}
+		}
+	}
+	walk(doc.Root())
+
+	return snippet, nil
+}
+
+// MergeSnippet adds the snippet's dependencies and plugins to the project,
+// replacing existing dependencies as MergeDependencies does and plugins of
+// the same groupId:artifactId, and returns the keys of the added and
+// updated ones
+func MergeSnippet(project *Project, snippet *Snippet) (added, updated []string) {
+	added, updated = MergeDependencies(project, snippet.Dependencies)
+	if len(snippet.Plugins) == 0 {
+		return added, updated
+	}
+
+	if project.Build == nil {
+		project.Build = &Build{}
+	}
+	for _, plugin := range snippet.Plugins {
+		key := plugin.GroupID + ":" + plugin.ArtifactID
+		replaced := false
+		for i, existing := range project.Build.Plugins {
+			if existing.GroupID == plugin.GroupID && existing.ArtifactID == plugin.ArtifactID {
+				project.Build.Plugins[i] = plugin
+				replaced = true
+				break
+			}
+		}
+		if replaced {
+			updated = append(updated, key)
+			continue
+		}
+		project.Build.Plugins = append(project.Build.Plugins, plugin)
+		added = append(added, key)
+	}
+	return added, updated
+}
+
+// String summarizes the snippet as one line per element
+func (s *Snippet) String() string {
+	var lines []string
+	for _, dep := range s.Dependencies {
+		line := "dependency " + dep.GroupID + ":" + dep.ArtifactID
+		if dep.Version != "" {
+			line += ":" + dep.Version
+		}
+		if dep.Scope != "" && dep.Scope != DefaultScope {
+			line += " [" + dep.Scope + "]"
+		}
+		lines = append(lines, line)
+	}
+	for _, plugin := range s.Plugins {
+		line := "plugin " + plugin.GroupID + ":" + plugin.ArtifactID
+		if plugin.Version != "" {
+			line += ":" + plugin.Version
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// coordinateText matches groupId:artifactId[:version] standing alone,
+// capturing the groupId and the version
+var coordinateText = regexp.MustCompile(`^([\w.\-]+):[A-Za-z][\w.\-]*(?::([\w.\-${}]+))?$`)
+
+// coordinateVersion matches the start of a version in bare coordinates: a
+// digit or a property reference
+var coordinateVersion = regexp.MustCompile(`^(\d|\$\{)`)
+
+// maxDetectLength bounds the text DetectDependency looks at; longer text is
+// a document, not a copied dependency
+const maxDetectLength = 2048
+
+// DetectDependency recognizes a single dependency in copied text: one
+// <dependency> element, one line of Gradle notation such as
+// implementation 'g:a:v', or bare groupId:artifactId[:version]. Bare
+// coordinates need a version or a dotted groupId, so that text such as
+// "Note:Hello" is not taken for one. Anything else, including several
+// dependencies, is not a match.
+func DetectDependency(text string) (Dependency, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" || len(text) > maxDetectLength {
+		return Dependency{}, false
+	}
+
+	if strings.HasPrefix(text, "<") {
+		snippet, err := ParseSnippet(text)
+		if err != nil || len(snippet.Dependencies) != 1 || len(snippet.Plugins) != 0 {
+			return Dependency{}, false
+		}
+		return snippet.Dependencies[0], true
+	}
+	if strings.Contains(text, "\n") {
+		return Dependency{}, false
+	}
+
+	if match := coordinateText.FindStringSubmatch(text); match != nil {
+		if !strings.Contains(match[1], ".") && !coordinateVersion.MatchString(match[2]) {
+			return Dependency{}, false
+		}
+		return parseGradleNotation(text)
+	}
+	result, _ := ParseGradleDependencies([]byte(text))
+	if len(result.Dependencies) != 1 {
+		return Dependency{}, false
+	}
+	dep := result.Dependencies[0]
+	if dep.Scope == ScopeCompile {
+		dep.Scope = "" // The default needs no <scope>
+	}
+	return dep, true
+}
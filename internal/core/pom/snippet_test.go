@@ -0,0 +1,50 @@
+package pom
+
+import "testing"
+
+func TestDetectDependency(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		want   Dependency
+		wantOK bool
+	}{
+		{"dependency element", `
+			<dependency>
+			  <groupId>org.slf4j</groupId>
+			  <artifactId>slf4j-api</artifactId>
+			  <version>2.0.9</version>
+			</dependency>`,
+			Dependency{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.9", Scope: ScopeCompile}, true},
+		{"dependency element with scope", `<dependency><groupId>junit</groupId><artifactId>junit</artifactId><version>4.13.2</version><scope>test</scope></dependency>`,
+			Dependency{GroupID: "junit", ArtifactID: "junit", Version: "4.13.2", Scope: ScopeTest}, true},
+		{"gradle line", `implementation 'org.slf4j:slf4j-api:2.0.9'`,
+			Dependency{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.9"}, true},
+		{"gradle test line", `testImplementation("junit:junit:4.13.2")`,
+			Dependency{GroupID: "junit", ArtifactID: "junit", Version: "4.13.2", Scope: ScopeTest}, true},
+		{"bare coordinates", "com.google.guava:guava:33.0.0-jre",
+			Dependency{GroupID: "com.google.guava", ArtifactID: "guava", Version: "33.0.0-jre"}, true},
+		{"bare coordinates with a property", "junit:junit:${junit.version}",
+			Dependency{GroupID: "junit", ArtifactID: "junit", Version: "${junit.version}"}, true},
+		{"bare coordinates without a version", "org.slf4j:slf4j-api",
+			Dependency{GroupID: "org.slf4j", ArtifactID: "slf4j-api"}, true},
+		{"note", "Note:Hello", Dependency{}, false},
+		{"word version", "Note:Hello:World", Dependency{}, false},
+		{"several dependencies", "<dependency><groupId>a.b</groupId><artifactId>c</artifactId></dependency>\n" +
+			"<dependency><groupId>d.e</groupId><artifactId>f</artifactId></dependency>", Dependency{}, false},
+		{"several lines", "org.slf4j:slf4j-api:2.0.9\njunit:junit:4.13.2", Dependency{}, false},
+		{"plugin", "<plugin><artifactId>maven-jar-plugin</artifactId></plugin>", Dependency{}, false},
+		{"prose", "See https://example.com for details", Dependency{}, false},
+		{"empty", "  \n", Dependency{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DetectDependency(tt.text)
+			if ok != tt.wantOK || got.GroupID != tt.want.GroupID || got.ArtifactID != tt.want.ArtifactID ||
+				got.Version != tt.want.Version || got.Scope != tt.want.Scope {
+				t.Errorf("Expected %+v %v, got %+v %v", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package pom
+
+import "bytes"
+
+// Stats summarizes the size of a POM for a quick assessment. Counts cover
+// the project itself; dependencies and plugins declared inside profiles are
+// counted separately.
+type Stats struct {
+	Dependencies        int
+	DependenciesByScope map[string]int // Scope -> count; no scope counts as compile
+	ManagedDependencies int
+	Plugins             int
+	Properties          int
+	Profiles            int
+	Modules             int
+	ProfileDependencies int
+	ProfilePlugins      int
+	XMLBytes            int
+	XMLLines            int
+}
+
+// ComputeStats counts the elements of a project; xml is its serialized
+// form, used for the size figures and may be nil
+func ComputeStats(project *Project, xml []byte) Stats {
+	stats := Stats{DependenciesByScope: make(map[string]int)}
+	if project == nil {
+		return stats
+	}
+
+	stats.Dependencies = len(project.Dependencies)
+	for _, dep := range project.Dependencies {
+		scope := dep.Scope
+		if scope == "" {
+			scope = ScopeCompile
+		}
+		stats.DependenciesByScope[scope]++
+	}
+	stats.ManagedDependencies = len(project.DependencyManagement)
+	if project.Build != nil {
+		stats.Plugins = len(project.Build.Plugins)
+	}
+	stats.Properties = len(project.Properties)
+	stats.Profiles = len(project.Profiles)
+	stats.Modules = len(project.Modules)
+	for _, profile := range project.Profiles {
+		stats.ProfileDependencies += len(profile.Dependencies)
+		if profile.Build != nil {
+			stats.ProfilePlugins += len(profile.Build.Plugins)
+		}
+	}
+
+	stats.XMLBytes = len(xml)
+	if len(xml) > 0 {
+		stats.XMLLines = bytes.Count(xml, []byte("\n"))
+		if xml[len(xml)-1] != '\n' {
+			stats.XMLLines++
+		}
+	}
+	return stats
+}
@@ -0,0 +1,136 @@
+package pom
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Limits bounds the input ParseBytesStrict accepts, so untrusted POMs
+// cannot exhaust memory or time. Zero fields use DefaultLimits.
+type Limits struct {
+	MaxBytes           int // Size of the document
+	MaxElements        int // Number of elements
+	MaxDepth           int // Nesting depth of elements
+	MaxAttributeLength int // Length of an attribute value, in bytes
+}
+
+// DefaultLimits are generous for hand-written and generated POMs; the
+// largest real-world POMs have a few thousand elements
+var DefaultLimits = Limits{
+	MaxBytes:           2 * 1024 * 1024,
+	MaxElements:        50000,
+	MaxDepth:           64,
+	MaxAttributeLength: 4096,
+}
+
+// Names of the limits in LimitError
+const (
+	LimitBytes           = "size"
+	LimitElements        = "element count"
+	LimitDepth           = "nesting depth"
+	LimitAttributeLength = "attribute length"
+)
+
+// LimitError reports input beyond one of the Limits; it wraps
+// ErrLimitExceeded
+type LimitError struct {
+	Position        // Where the limit was exceeded, unknown for LimitBytes
+	Limit    string // One of the Limit constants
+	Max      int
+}
+
+// Error returns the exceeded limit, prefixed with its line when known
+func (e *LimitError) Error() string {
+	message := fmt.Sprintf("%v: %s exceeds maximum %d", ErrLimitExceeded, e.Limit, e.Max)
+	if e.IsKnown() {
+		return fmt.Sprintf("line %d: %s", e.Line, message)
+	}
+	return message
+}
+
+// Unwrap returns ErrLimitExceeded
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// ParseBytesStrict parses untrusted POM XML, for servers and editor
+// integrations. The input is checked against limits in a streaming pass
+// before the document is built, and every failure is an error wrapping
+// ErrLimitExceeded, ErrInvalidXML or ErrMissingRequired, never a panic.
+func ParseBytesStrict(data []byte, limits Limits) (project *Project, err error) {
+	limits = limits.withDefaults()
+	if len(data) > limits.MaxBytes {
+		return nil, &LimitError{Limit: LimitBytes, Max: limits.MaxBytes}
+	}
+	if err := checkLimits(data, limits); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			project, err = nil, fmt.Errorf("%w: %v", ErrInvalidXML, r)
+		}
+	}()
+	return NewParser().Parse(data)
+}
+
+// withDefaults fills zero limits from DefaultLimits
+func (l Limits) withDefaults() Limits {
+	if l.MaxBytes <= 0 {
+		l.MaxBytes = DefaultLimits.MaxBytes
+	}
+	if l.MaxElements <= 0 {
+		l.MaxElements = DefaultLimits.MaxElements
+	}
+	if l.MaxDepth <= 0 {
+		l.MaxDepth = DefaultLimits.MaxDepth
+	}
+	if l.MaxAttributeLength <= 0 {
+		l.MaxAttributeLength = DefaultLimits.MaxAttributeLength
+	}
+	return l
+}
+
+// checkLimits scans the tokens of data without building a tree, returning
+// a LimitError at the first element beyond limits
+func checkLimits(data []byte, limits Limits) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	elements, depth := 0, 0
+	for {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidXML, err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			if _, ok := token.(xml.EndElement); ok {
+				depth--
+			}
+			continue
+		}
+
+		elements++
+		depth++
+		exceeded := func(limit string, max int) error {
+			line, column := decoder.InputPos()
+			return &LimitError{Position: Position{Line: line, Column: column}, Limit: limit, Max: max}
+		}
+		if elements > limits.MaxElements {
+			return exceeded(LimitElements, limits.MaxElements)
+		}
+		if depth > limits.MaxDepth {
+			return exceeded(LimitDepth, limits.MaxDepth)
+		}
+		for _, attr := range start.Attr {
+			if len(attr.Value) > limits.MaxAttributeLength {
+				return exceeded(LimitAttributeLength, limits.MaxAttributeLength)
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package pom
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const strictTestPOM = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <modelVersion>4.0.0</modelVersion>
+  <groupId>com.example</groupId>
+  <artifactId>demo</artifactId>
+  <version>1.0.0</version>
+  <dependencies>
+    <dependency>
+      <groupId>junit</groupId>
+      <artifactId>junit</artifactId>
+      <version>4.13.2</version>
+      <scope>test</scope>
+    </dependency>
+  </dependencies>
+</project>
+`
+
+// nestedPOM returns a POM with depth elements nested in its description
+func nestedPOM(depth int) string {
+	return strings.Replace(strictTestPOM, "<version>1.0.0</version>",
+		"<version>1.0.0</version><description>"+strings.Repeat("<a>", depth)+strings.Repeat("</a>", depth)+"</description>", 1)
+}
+
+// largePOM returns a POM with n dependencies
+func largePOM(n int) []byte {
+	var deps strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&deps, "<dependency><groupId>com.example</groupId><artifactId>lib-%d</artifactId><version>1.%d</version></dependency>\n", i, i)
+	}
+	return []byte(strings.Replace(strictTestPOM, "<dependencies>", "<dependencies>\n"+deps.String(), 1))
+}
+
+func TestParseBytesStrict(t *testing.T) {
+	project, err := ParseBytesStrict([]byte(strictTestPOM), Limits{})
+	if err != nil {
+		t.Fatalf("Expected valid POM to parse, got %v", err)
+	}
+	if project.Coordinates.String() != "com.example:demo:1.0.0" || len(project.Dependencies) != 1 {
+		t.Errorf("Unexpected project %s with %d dependencies", project.Coordinates.String(), len(project.Dependencies))
+	}
+
+	if _, err := ParseBytesStrict([]byte("<project><groupId>"), Limits{}); !errors.Is(err, ErrInvalidXML) {
+		t.Errorf("Expected ErrInvalidXML for truncated XML, got %v", err)
+	}
+}
+
+func TestParseBytesStrictLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		limits Limits
+		limit  string
+		line   int
+	}{
+		{"size", strictTestPOM, Limits{MaxBytes: 100}, LimitBytes, 0},
+		{"elements", strictTestPOM, Limits{MaxElements: 5}, LimitElements, 7},
+		{"depth", nestedPOM(10), Limits{MaxDepth: 8}, LimitDepth, 6},
+		{"attribute", strings.Replace(strictTestPOM, "<project ", `<project a="`+strings.Repeat("x", 100)+`" `, 1),
+			Limits{MaxAttributeLength: 64}, LimitAttributeLength, 2},
+	}
+	for _, tt := range tests {
+		_, err := ParseBytesStrict([]byte(tt.data), tt.limits)
+		if !errors.Is(err, ErrLimitExceeded) {
+			t.Errorf("%s: Expected ErrLimitExceeded, got %v", tt.name, err)
+			continue
+		}
+		var limitErr *LimitError
+		if !errors.As(err, &limitErr) || limitErr.Limit != tt.limit {
+			t.Errorf("%s: Expected %s limit, got %v", tt.name, tt.limit, err)
+		}
+		if line := ErrorLine(err); line != tt.line {
+			t.Errorf("%s: Expected line %d, got %d", tt.name, tt.line, line)
+		}
+	}
+
+	if _, err := ParseBytesStrict([]byte(nestedPOM(40)), Limits{}); err != nil {
+		t.Errorf("Expected default limits to allow nesting of 40, got %v", err)
+	}
+}
+
+func FuzzParseBytesStrict(f *testing.F) {
+	f.Add([]byte(strictTestPOM))
+	f.Add([]byte(nestedPOM(3)))
+	f.Add([]byte(`<project><parent><groupId>g</groupId><artifactId>p</artifactId><version>1</version></parent><artifactId>a</artifactId></project>`))
+	f.Add([]byte(`<!DOCTYPE project [<!ENTITY a "aaaa">]><project>&a;</project>`))
+	f.Add([]byte(`<project><build><plugins><plugin><artifactId>x</artifactId><executions><execution><goals><goal>g</goal></goals></execution></executions></plugin></plugins></build></project>`))
+
+	limits := Limits{MaxBytes: 64 * 1024, MaxElements: 1000, MaxDepth: 32, MaxAttributeLength: 256}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		project, err := ParseBytesStrict(data, limits)
+		if err == nil {
+			if project == nil {
+				t.Fatal("Expected a project when there is no error")
+			}
+			return
+		}
+		if !errors.Is(err, ErrLimitExceeded) && !errors.Is(err, ErrInvalidXML) && !errors.Is(err, ErrMissingRequired) {
+			t.Fatalf("Expected a typed error, got %v", err)
+		}
+	})
+}
+
+func BenchmarkParseBytesStrict(b *testing.B) {
+	data := largePOM(500)
+	b.SetBytes(int64(len(data)))
+	for b.Loop() {
+		if _, err := ParseBytesStrict(data, Limits{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	data := largePOM(500)
+	parser := NewParser()
+	b.SetBytes(int64(len(data)))
+	for b.Loop() {
+		if _, err := parser.Parse(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,40 +1,198 @@
 package pom
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
 
 // TemplateManager interface for creating Projects from templates
 type TemplateManager interface {
 	Create(templateName string, coords Coordinates) (*Project, error)
+	CreateWithValues(templateName string, coords Coordinates, values map[string]string) (*Project, error)
 	List() []TemplateInfo
 }
 
 // templateManager implements TemplateManager
-type templateManager struct{}
+type templateManager struct {
+	dirs []string // Directories with custom template files
+}
 
-// NewTemplateManager creates a new TemplateManager
-func NewTemplateManager() TemplateManager {
-	return &templateManager{}
+// NewTemplateManager creates a new TemplateManager offering the built-in
+// templates plus the template files (see LoadTemplateDefinition) in dirs.
+// Custom templates cannot replace built-in ones; with the same name in
+// several directories, the first directory wins.
+func NewTemplateManager(dirs ...string) TemplateManager {
+	return &templateManager{dirs: dirs}
 }
 
-// Create creates a new Project from a template
+// Create creates a new Project from a template, with the template's
+// variables set to their defaults
 func (tm *templateManager) Create(templateName string, coords Coordinates) (*Project, error) {
+	return tm.CreateWithValues(templateName, coords, nil)
+}
+
+// CreateWithValues creates a new Project from a template, setting its
+// variables (see TemplateInfo.Variables) from values. Variables left out or
+// empty get their default.
+func (tm *templateManager) CreateWithValues(templateName string, coords Coordinates, values map[string]string) (*Project, error) {
+	var project *Project
 	switch templateName {
 	case "basic-java":
-		return tm.createBasicJava(coords), nil
+		project = tm.createBasicJava(coords)
 	case "java-library":
-		return tm.createJavaLibrary(coords), nil
+		project = tm.createJavaLibrary(coords)
 	case "web-app":
-		return tm.createWebApp(coords), nil
+		project = tm.createWebApp(coords)
 	case "javacard":
-		return tm.createJavaCard(coords), nil
-	default:
-		return nil, fmt.Errorf("%w: unknown template '%s', available templates: basic-java, java-library, web-app, javacard", ErrTemplateNotFound, templateName)
+		project = tm.createJavaCard(coords)
+	case "spring-boot":
+		project = tm.createSpringBoot(coords)
+	case "quarkus":
+		project = tm.createQuarkus(coords)
+	case "kotlin-jvm":
+		project = tm.createKotlinJVM(coords)
+	}
+	if project != nil {
+		for _, variable := range builtinVariables(templateName) {
+			if value := values[variable.Name]; value != "" {
+				variable.apply(project, value)
+			}
+		}
+		return project, nil
+	}
+
+	if path, ok := tm.customFiles()[templateName]; ok {
+		definition, err := LoadTemplateDefinition(path)
+		if err != nil {
+			return nil, err
+		}
+		return definition.Render(coords, values)
+	}
+
+	var names []string
+	for _, info := range tm.List() {
+		names = append(names, info.Name)
 	}
+	return nil, fmt.Errorf("%w: unknown template '%s', available templates: %s", ErrTemplateNotFound, templateName, strings.Join(names, ", "))
 }
 
-// List returns all available templates
+// List returns all available templates, the built-in ones first. Custom
+// template files that fail to load are left out.
 func (tm *templateManager) List() []TemplateInfo {
-	return []TemplateInfo{
+	templates := builtinTemplates()
+
+	files := tm.customFiles()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		definition, err := LoadTemplateDefinition(files[name])
+		if err != nil {
+			continue
+		}
+		templates = append(templates, TemplateInfo{
+			Name:        name,
+			Description: definition.Description,
+			Path:        files[name],
+			Variables:   definition.Inputs(),
+		})
+	}
+	return templates
+}
+
+// customFiles returns the custom template files by name, leaving out
+// names of built-in templates
+func (tm *templateManager) customFiles() map[string]string {
+	files := make(map[string]string)
+	for _, dir := range tm.dirs {
+		if dir == "" {
+			continue
+		}
+		found, err := TemplateFiles(dir)
+		if err != nil {
+			continue
+		}
+		for name, path := range found {
+			if _, seen := files[name]; !seen && !IsBuiltinTemplate(name) {
+				files[name] = path
+			}
+		}
+	}
+	return files
+}
+
+// Versions of the frameworks the built-in templates start from; a catalog
+// refresh may supply newer ones (see DefaultVersion)
+const (
+	springBootParent = "org.springframework.boot:spring-boot-starter-parent"
+	quarkusBOM       = "io.quarkus.platform:quarkus-bom"
+	kotlinStdlib     = "org.jetbrains.kotlin:kotlin-stdlib"
+)
+
+// builtinVariable is a variable of a built-in template and how its value
+// is applied to the created project
+type builtinVariable struct {
+	TemplateVariable
+	apply func(project *Project, value string)
+}
+
+// propertyVariable is a variable whose value is written to properties
+func propertyVariable(name, description, defaultValue string, properties ...string) builtinVariable {
+	return builtinVariable{
+		TemplateVariable: TemplateVariable{Name: name, Description: description, Default: defaultValue},
+		apply: func(project *Project, value string) {
+			for _, property := range properties {
+				project.Properties[property] = value
+			}
+		},
+	}
+}
+
+// builtinVariables returns the variables of a built-in template. The
+// javacard template has none, since the JavaCard SDK needs Java 8.
+func builtinVariables(templateName string) []builtinVariable {
+	const javaDescription = "Java version to compile for, e.g. 17"
+	switch templateName {
+	case "basic-java", "java-library", "web-app":
+		return []builtinVariable{
+			propertyVariable("javaVersion", javaDescription, "11", "maven.compiler.source", "maven.compiler.target"),
+		}
+	case "spring-boot":
+		return []builtinVariable{
+			propertyVariable("javaVersion", javaDescription, "17", "java.version"),
+			{
+				TemplateVariable: TemplateVariable{
+					Name:        "springBootVersion",
+					Description: "Version of the spring-boot-starter-parent POM",
+					Default:     DefaultVersion(SplitVersionKey(springBootParent)),
+				},
+				apply: func(project *Project, value string) {
+					project.Parent.Version = value
+				},
+			},
+		}
+	case "quarkus":
+		return []builtinVariable{
+			propertyVariable("javaVersion", javaDescription, "17", "maven.compiler.release"),
+			propertyVariable("quarkusVersion", "Version of the Quarkus platform BOM",
+				DefaultVersion(SplitVersionKey(quarkusBOM)), "quarkus.platform.version"),
+		}
+	case "kotlin-jvm":
+		return []builtinVariable{
+			propertyVariable("javaVersion", "JVM version the Kotlin compiler targets, e.g. 17", "17", "kotlin.compiler.jvmTarget"),
+			propertyVariable("kotlinVersion", "Version of the Kotlin compiler and standard library",
+				DefaultVersion(SplitVersionKey(kotlinStdlib)), "kotlin.version"),
+		}
+	}
+	return nil
+}
+
+// builtinTemplates describes the templates created in code
+func builtinTemplates() []TemplateInfo {
+	templates := []TemplateInfo{
 		{
 			Name:        "basic-java",
 			Description: "Basic Java JAR project with compiler plugin",
@@ -51,7 +209,25 @@ func (tm *templateManager) List() []TemplateInfo {
 			Name:        "javacard",
 			Description: "JavaCard applet project for smart cards (CAP packaging)",
 		},
+		{
+			Name:        "spring-boot",
+			Description: "Spring Boot web application with the starter parent and Boot plugin",
+		},
+		{
+			Name:        "quarkus",
+			Description: "Quarkus REST service importing the Quarkus platform BOM",
+		},
+		{
+			Name:        "kotlin-jvm",
+			Description: "Kotlin JVM project with the Kotlin compiler plugin and standard library",
+		},
+	}
+	for i, info := range templates {
+		for _, variable := range builtinVariables(info.Name) {
+			templates[i].Variables = append(templates[i].Variables, variable.TemplateVariable)
+		}
 	}
+	return templates
 }
 
 // createBasicJava creates a basic Java project template
@@ -76,7 +252,7 @@ func (tm *templateManager) createBasicJava(coords Coordinates) *Project {
 				{
 					GroupID:    "org.apache.maven.plugins",
 					ArtifactID: "maven-compiler-plugin",
-					Version:    "3.11.0",
+					Version:    DefaultVersion(DefaultPluginGroupID, "maven-compiler-plugin"),
 				},
 			},
 		},
@@ -104,7 +280,7 @@ func (tm *templateManager) createJavaLibrary(coords Coordinates) *Project {
 			{
 				GroupID:    "junit",
 				ArtifactID: "junit",
-				Version:    "4.13.2",
+				Version:    DefaultVersion("junit", "junit"),
 				Scope:      ScopeTest,
 			},
 		},
@@ -113,12 +289,12 @@ func (tm *templateManager) createJavaLibrary(coords Coordinates) *Project {
 				{
 					GroupID:    "org.apache.maven.plugins",
 					ArtifactID: "maven-compiler-plugin",
-					Version:    "3.11.0",
+					Version:    DefaultVersion(DefaultPluginGroupID, "maven-compiler-plugin"),
 				},
 				{
 					GroupID:    "org.apache.maven.plugins",
 					ArtifactID: "maven-jar-plugin",
-					Version:    "3.3.0",
+					Version:    DefaultVersion(DefaultPluginGroupID, "maven-jar-plugin"),
 				},
 			},
 		},
@@ -146,13 +322,13 @@ func (tm *templateManager) createWebApp(coords Coordinates) *Project {
 			{
 				GroupID:    "javax.servlet",
 				ArtifactID: "javax.servlet-api",
-				Version:    "4.0.1",
+				Version:    DefaultVersion("javax.servlet", "javax.servlet-api"),
 				Scope:      ScopeProvided,
 			},
 			{
 				GroupID:    "junit",
 				ArtifactID: "junit",
-				Version:    "4.13.2",
+				Version:    DefaultVersion("junit", "junit"),
 				Scope:      ScopeTest,
 			},
 		},
@@ -161,20 +337,22 @@ func (tm *templateManager) createWebApp(coords Coordinates) *Project {
 				{
 					GroupID:    "org.apache.maven.plugins",
 					ArtifactID: "maven-compiler-plugin",
-					Version:    "3.11.0",
+					Version:    DefaultVersion(DefaultPluginGroupID, "maven-compiler-plugin"),
 				},
 				{
 					GroupID:    "org.apache.maven.plugins",
 					ArtifactID: "maven-war-plugin",
-					Version:    "3.3.2",
+					Version:    DefaultVersion(DefaultPluginGroupID, "maven-war-plugin"),
 				},
 			},
 		},
 	}
 }
 
-// createJavaCard creates a JavaCard applet template
+// createJavaCard creates a JavaCard applet template; the applet defaults
+// to DefaultJavaCardApplet
 func (tm *templateManager) createJavaCard(coords Coordinates) *Project {
+	applet := DefaultJavaCardApplet(coords)
 	return &Project{
 		XMLNS:          MavenXMLNamespace,
 		XSI:            "http://www.w3.org/2001/XMLSchema-instance",
@@ -191,6 +369,9 @@ func (tm *templateManager) createJavaCard(coords Coordinates) *Project {
 			"maven.compiler.target":        "1.8",
 			"javacard.version":             "3.0.5",
 			"globalplatform.version":       "1.7.0",
+			JavaCardAppletClassProperty:    applet.Class,
+			JavaCardPackageAIDProperty:     applet.PackageAID,
+			JavaCardAppletAIDProperty:      applet.AppletAID,
 		},
 		Dependencies: []Dependency{
 			{
@@ -208,7 +389,7 @@ func (tm *templateManager) createJavaCard(coords Coordinates) *Project {
 			{
 				GroupID:    "junit",
 				ArtifactID: "junit",
-				Version:    "4.13.2",
+				Version:    DefaultVersion("junit", "junit"),
 				Scope:      ScopeTest,
 			},
 		},
@@ -217,7 +398,7 @@ func (tm *templateManager) createJavaCard(coords Coordinates) *Project {
 				{
 					GroupID:    "org.apache.maven.plugins",
 					ArtifactID: "maven-compiler-plugin",
-					Version:    "3.11.0",
+					Version:    DefaultVersion(DefaultPluginGroupID, "maven-compiler-plugin"),
 				},
 				{
 					GroupID:    "com.github.martinpaljak",
@@ -229,9 +410,196 @@ func (tm *templateManager) createJavaCard(coords Coordinates) *Project {
 							Phase: PhasePackage,
 							Goals: []string{"cap"},
 						},
+						{
+							ID:    "verify-cap",
+							Phase: PhaseVerify,
+							Goals: []string{"verify"},
+						},
 					},
 				},
 			},
 		},
+		Profiles: []Profile{GlobalPlatformProfile()},
+	}
+}
+
+// createSpringBoot creates a Spring Boot web application template. The
+// starter parent manages the versions of the starters and the Boot plugin.
+func (tm *templateManager) createSpringBoot(coords Coordinates) *Project {
+	groupID, artifactID := SplitVersionKey(springBootParent)
+	return &Project{
+		XMLNS:          MavenXMLNamespace,
+		XSI:            "http://www.w3.org/2001/XMLSchema-instance",
+		SchemaLocation: MavenXMLSchemaLocation,
+		ModelVersion:   DefaultModelVersion,
+		GroupID:        coords.GroupID,
+		ArtifactID:     coords.ArtifactID,
+		Version:        coords.Version,
+		Coordinates:    coords,
+		Packaging:      PackagingJar,
+		Parent: &Parent{
+			GroupID:    groupID,
+			ArtifactID: artifactID,
+			Version:    DefaultVersion(groupID, artifactID),
+		},
+		Properties: map[string]string{
+			"java.version": "17",
+		},
+		Dependencies: []Dependency{
+			{
+				GroupID:    "org.springframework.boot",
+				ArtifactID: "spring-boot-starter-web",
+			},
+			{
+				GroupID:    "org.springframework.boot",
+				ArtifactID: "spring-boot-starter-test",
+				Scope:      ScopeTest,
+			},
+		},
+		Build: &Build{
+			Plugins: []Plugin{
+				{
+					GroupID:    "org.springframework.boot",
+					ArtifactID: "spring-boot-maven-plugin",
+				},
+			},
+		},
+	}
+}
+
+// createQuarkus creates a Quarkus REST service template. The platform BOM
+// is imported through properties, as the Quarkus CLI does, so upgrading
+// means changing quarkus.platform.version.
+func (tm *templateManager) createQuarkus(coords Coordinates) *Project {
+	groupID, artifactID := SplitVersionKey(quarkusBOM)
+	return &Project{
+		XMLNS:          MavenXMLNamespace,
+		XSI:            "http://www.w3.org/2001/XMLSchema-instance",
+		SchemaLocation: MavenXMLSchemaLocation,
+		ModelVersion:   DefaultModelVersion,
+		GroupID:        coords.GroupID,
+		ArtifactID:     coords.ArtifactID,
+		Version:        coords.Version,
+		Coordinates:    coords,
+		Packaging:      PackagingJar,
+		Properties: map[string]string{
+			"project.build.sourceEncoding": "UTF-8",
+			"maven.compiler.release":       "17",
+			"quarkus.platform.group-id":    groupID,
+			"quarkus.platform.artifact-id": artifactID,
+			"quarkus.platform.version":     DefaultVersion(groupID, artifactID),
+			"surefire-plugin.version":      DefaultVersion(DefaultPluginGroupID, "maven-surefire-plugin"),
+			"compiler-plugin.version":      DefaultVersion(DefaultPluginGroupID, "maven-compiler-plugin"),
+		},
+		DependencyManagement: []Dependency{
+			{
+				GroupID:    "${quarkus.platform.group-id}",
+				ArtifactID: "${quarkus.platform.artifact-id}",
+				Version:    "${quarkus.platform.version}",
+				Type:       "pom",
+				Scope:      ScopeImport,
+			},
+		},
+		Dependencies: []Dependency{
+			{
+				GroupID:    "io.quarkus",
+				ArtifactID: "quarkus-rest",
+			},
+			{
+				GroupID:    "io.quarkus",
+				ArtifactID: "quarkus-arc",
+			},
+			{
+				GroupID:    "io.quarkus",
+				ArtifactID: "quarkus-junit5",
+				Scope:      ScopeTest,
+			},
+		},
+		Build: &Build{
+			Plugins: []Plugin{
+				{
+					GroupID:    "${quarkus.platform.group-id}",
+					ArtifactID: "quarkus-maven-plugin",
+					Version:    "${quarkus.platform.version}",
+					Extensions: true,
+					Executions: []PluginExecution{
+						{
+							Goals: []string{"build", "generate-code", "generate-code-tests"},
+						},
+					},
+				},
+				{
+					GroupID:    DefaultPluginGroupID,
+					ArtifactID: "maven-compiler-plugin",
+					Version:    "${compiler-plugin.version}",
+				},
+				{
+					GroupID:    DefaultPluginGroupID,
+					ArtifactID: "maven-surefire-plugin",
+					Version:    "${surefire-plugin.version}",
+				},
+			},
+		},
+	}
+}
+
+// createKotlinJVM creates a Kotlin JVM project template. The compiler
+// plugin and standard library share kotlin.version.
+func (tm *templateManager) createKotlinJVM(coords Coordinates) *Project {
+	groupID, artifactID := SplitVersionKey(kotlinStdlib)
+	return &Project{
+		XMLNS:          MavenXMLNamespace,
+		XSI:            "http://www.w3.org/2001/XMLSchema-instance",
+		SchemaLocation: MavenXMLSchemaLocation,
+		ModelVersion:   DefaultModelVersion,
+		GroupID:        coords.GroupID,
+		ArtifactID:     coords.ArtifactID,
+		Version:        coords.Version,
+		Coordinates:    coords,
+		Packaging:      PackagingJar,
+		Properties: map[string]string{
+			"project.build.sourceEncoding": "UTF-8",
+			"kotlin.version":               DefaultVersion(groupID, artifactID),
+			"kotlin.compiler.jvmTarget":    "17",
+		},
+		Dependencies: []Dependency{
+			{
+				GroupID:    groupID,
+				ArtifactID: artifactID,
+				Version:    "${kotlin.version}",
+			},
+			{
+				GroupID:    groupID,
+				ArtifactID: "kotlin-test-junit5",
+				Version:    "${kotlin.version}",
+				Scope:      ScopeTest,
+			},
+		},
+		Build: &Build{
+			SourceDirectory:     "src/main/kotlin",
+			TestSourceDirectory: "src/test/kotlin",
+			Plugins: []Plugin{
+				{
+					GroupID:    groupID,
+					ArtifactID: "kotlin-maven-plugin",
+					Version:    "${kotlin.version}",
+					Executions: []PluginExecution{
+						{
+							ID:    "compile",
+							Goals: []string{"compile"},
+						},
+						{
+							ID:    "test-compile",
+							Goals: []string{"test-compile"},
+						},
+					},
+				},
+				{
+					GroupID:    DefaultPluginGroupID,
+					ArtifactID: "maven-surefire-plugin",
+					Version:    DefaultVersion(DefaultPluginGroupID, "maven-surefire-plugin"),
+				},
+			},
+		},
 	}
 }
@@ -0,0 +1,521 @@
+package pom
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Built-in template variables, set from the new project's coordinates
+const (
+	TemplateVarGroupID    = "groupId"
+	TemplateVarArtifactID = "artifactId"
+	TemplateVarVersion    = "version"
+)
+
+// TemplateFileExt is the file extension of template definitions
+const TemplateFileExt = ".yaml"
+
+// templateFileExts are the extensions of template files: YAML definitions
+// and POMs with {{name}} placeholders
+var templateFileExts = map[string]bool{".yaml": true, ".yml": true, ".xml": true}
+
+// IsTemplateFile reports whether path names a template file by its extension
+func IsTemplateFile(path string) bool {
+	return templateFileExts[strings.ToLower(filepath.Ext(path))]
+}
+
+// templateVar matches {{name}} placeholders; Maven's own ${...} references
+// are left alone
+var templateVar = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// templateVarName matches the names allowed in placeholders
+var templateVarName = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// TemplateDefinition is a template stored as a YAML file. String values may
+// contain {{name}} placeholders, filled in from the coordinates of the new
+// project ({{groupId}}, {{artifactId}}, {{version}}) and user-set values.
+// Variables declares placeholders with a description and default value.
+type TemplateDefinition struct {
+	Name                 string               `yaml:"name"`
+	Description          string               `yaml:"description,omitempty"`
+	Variables            []TemplateVariable   `yaml:"variables,omitempty"`
+	Packaging            string               `yaml:"packaging,omitempty"`
+	Parent               *TemplateParent      `yaml:"parent,omitempty"`
+	Properties           map[string]string    `yaml:"properties,omitempty"`
+	DependencyManagement []TemplateDependency `yaml:"dependencyManagement,omitempty"`
+	Dependencies         []TemplateDependency `yaml:"dependencies,omitempty"`
+	Plugins              []TemplatePlugin     `yaml:"plugins,omitempty"`
+}
+
+// TemplateVariable is a placeholder of a template that tools ask for when
+// creating a project. Without a default, a value must be given.
+type TemplateVariable struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Default     string `yaml:"default,omitempty"`
+}
+
+// TemplateParent is the parent POM of a template
+type TemplateParent struct {
+	GroupID    string `yaml:"groupId"`
+	ArtifactID string `yaml:"artifactId"`
+	Version    string `yaml:"version"`
+}
+
+// TemplateDependency is a dependency declared by a template
+type TemplateDependency struct {
+	GroupID    string `yaml:"groupId"`
+	ArtifactID string `yaml:"artifactId"`
+	Version    string `yaml:"version,omitempty"`
+	Type       string `yaml:"type,omitempty"`
+	Scope      string `yaml:"scope,omitempty"`
+	Optional   bool   `yaml:"optional,omitempty"`
+}
+
+// TemplatePlugin is a build plugin declared by a template
+type TemplatePlugin struct {
+	GroupID       string                 `yaml:"groupId"`
+	ArtifactID    string                 `yaml:"artifactId"`
+	Version       string                 `yaml:"version,omitempty"`
+	Extensions    bool                   `yaml:"extensions,omitempty"`
+	Configuration map[string]interface{} `yaml:"configuration,omitempty"`
+	Executions    []TemplateExecution    `yaml:"executions,omitempty"`
+}
+
+// TemplateExecution is a plugin execution declared by a template
+type TemplateExecution struct {
+	ID    string   `yaml:"id,omitempty"`
+	Phase string   `yaml:"phase,omitempty"`
+	Goals []string `yaml:"goals,omitempty"`
+}
+
+// LoadTemplateDefinition reads a template file: a YAML definition, or a
+// POM (.xml) whose values may contain {{name}} placeholders. A POM
+// template's description is its <description>; its own coordinates are
+// replaced by those of the new project.
+func LoadTemplateDefinition(path string) (*TemplateDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrFileNotFound, path)
+		}
+		return nil, fmt.Errorf("reading template %s: %w", path, err)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if strings.EqualFold(filepath.Ext(path), ".xml") {
+		project, err := NewParser().Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("template %s: %w", path, err)
+		}
+		return NewTemplateDefinition(name, project.Description, project), nil
+	}
+
+	definition, err := ParseTemplateDefinition(data)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", path, err)
+	}
+	if definition.Name == "" {
+		definition.Name = name
+	}
+	return definition, nil
+}
+
+// TemplateFiles returns the template files in dir by template name, the
+// file name without extension. A missing directory has no templates.
+func TemplateFiles(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading template directory: %w", err)
+	}
+
+	files := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !IsTemplateFile(entry.Name()) {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		files[name] = filepath.Join(dir, entry.Name())
+	}
+	return files, nil
+}
+
+// TemplatePath returns the file in dir a custom template named name is
+// saved to. The name must be a plain file name and, since custom templates
+// cannot replace built-in ones, not the name of a built-in template.
+func TemplatePath(dir, name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("%w: invalid template name %q", ErrInvalidFormat, name)
+	}
+	if IsBuiltinTemplate(name) {
+		return "", fmt.Errorf("%w: %q is a built-in template name", ErrInvalidFormat, name)
+	}
+	return filepath.Join(dir, name+TemplateFileExt), nil
+}
+
+// IsBuiltinTemplate reports whether name is one of the templates created in
+// code
+func IsBuiltinTemplate(name string) bool {
+	for _, info := range builtinTemplates() {
+		if info.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTemplateDefinition parses a template definition from YAML
+func ParseTemplateDefinition(data []byte) (*TemplateDefinition, error) {
+	var definition TemplateDefinition
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&definition); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+
+	declared := make(map[string]bool)
+	for _, variable := range definition.Variables {
+		switch {
+		case !templateVarName.MatchString(variable.Name):
+			return nil, fmt.Errorf("%w: invalid variable name %q", ErrInvalidFormat, variable.Name)
+		case isCoordinateVar(variable.Name):
+			return nil, fmt.Errorf("%w: variable %s is set from the coordinates", ErrInvalidFormat, variable.Name)
+		case declared[variable.Name]:
+			return nil, fmt.Errorf("%w: variable %s declared twice", ErrInvalidFormat, variable.Name)
+		}
+		declared[variable.Name] = true
+	}
+	return &definition, nil
+}
+
+// isCoordinateVar reports whether name is one of the built-in variables set
+// from the coordinates
+func isCoordinateVar(name string) bool {
+	return name == TemplateVarGroupID || name == TemplateVarArtifactID || name == TemplateVarVersion
+}
+
+// Marshal returns the definition as YAML
+func (d *TemplateDefinition) Marshal() ([]byte, error) {
+	return yaml.Marshal(d)
+}
+
+// WriteFile writes the definition as YAML to path, creating its directory
+func (d *TemplateDefinition) WriteFile(path string) error {
+	data, err := d.Marshal()
+	if err != nil {
+		return fmt.Errorf("formatting template: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating template directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// NewTemplateDefinition creates a definition reproducing a project. Its
+// coordinates become the {{groupId}}, {{artifactId}} and {{version}}
+// placeholders, as do dependencies on sibling artifacts released with it.
+func NewTemplateDefinition(name, description string, project *Project) *TemplateDefinition {
+	sibling := func(dep Dependency) TemplateDependency {
+		td := TemplateDependency{
+			GroupID:    dep.GroupID,
+			ArtifactID: dep.ArtifactID,
+			Version:    dep.Version,
+			Type:       dep.Type,
+			Scope:      dep.Scope,
+			Optional:   dep.Optional,
+		}
+		if dep.GroupID == project.GroupID && project.GroupID != "" {
+			td.GroupID = "{{" + TemplateVarGroupID + "}}"
+			if dep.Version == project.Version {
+				td.Version = "{{" + TemplateVarVersion + "}}"
+			}
+		}
+		return td
+	}
+
+	d := &TemplateDefinition{
+		Name:        name,
+		Description: description,
+		Packaging:   project.Packaging,
+	}
+	if project.Parent != nil {
+		d.Parent = &TemplateParent{
+			GroupID:    project.Parent.GroupID,
+			ArtifactID: project.Parent.ArtifactID,
+			Version:    project.Parent.Version,
+		}
+	}
+	if len(project.Properties) > 0 {
+		d.Properties = make(map[string]string, len(project.Properties))
+		for key, value := range project.Properties {
+			d.Properties[key] = value
+		}
+	}
+
+	toTemplate := func(deps []Dependency) []TemplateDependency {
+		var out []TemplateDependency
+		for _, dep := range deps {
+			out = append(out, sibling(dep))
+		}
+		return out
+	}
+	d.DependencyManagement = toTemplate(project.DependencyManagement)
+	d.Dependencies = toTemplate(project.Dependencies)
+
+	if project.Build != nil {
+		for _, plugin := range project.Build.Plugins {
+			tp := TemplatePlugin{
+				GroupID:    plugin.GroupID,
+				ArtifactID: plugin.ArtifactID,
+				Version:    plugin.Version,
+				Extensions: plugin.Extensions,
+			}
+			if plugin.Configuration != nil {
+				tp.Configuration = plugin.Configuration.Data
+			}
+			for _, exec := range plugin.Executions {
+				tp.Executions = append(tp.Executions, TemplateExecution{
+					ID:    exec.ID,
+					Phase: exec.Phase,
+					Goals: exec.Goals,
+				})
+			}
+			d.Plugins = append(d.Plugins, tp)
+		}
+	}
+	return d
+}
+
+// Placeholders returns the names of all placeholders used by the
+// definition, sorted, including the built-in coordinate variables
+func (d *TemplateDefinition) Placeholders() []string {
+	seen := map[string]bool{
+		TemplateVarGroupID:    true,
+		TemplateVarArtifactID: true,
+		TemplateVarVersion:    true,
+	}
+	scan := *d
+	scan.expand(func(s string) string {
+		for _, match := range templateVar.FindAllStringSubmatch(s, -1) {
+			seen[match[1]] = true
+		}
+		return s
+	})
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Inputs returns the variables to ask for when creating a project: the
+// declared ones in order, then the other placeholders apart from the
+// coordinates, which have no default
+func (d *TemplateDefinition) Inputs() []TemplateVariable {
+	inputs := append([]TemplateVariable(nil), d.Variables...)
+	declared := make(map[string]bool)
+	for _, variable := range d.Variables {
+		declared[variable.Name] = true
+	}
+	for _, name := range d.Placeholders() {
+		if !declared[name] && !isCoordinateVar(name) {
+			inputs = append(inputs, TemplateVariable{Name: name})
+		}
+	}
+	return inputs
+}
+
+// Render creates a project from the definition. The coordinates fill in the
+// built-in variables; values supplies the others, falling back to the
+// declared defaults. Fails with ErrTemplateVariable when a placeholder has
+// no value.
+func (d *TemplateDefinition) Render(coords Coordinates, values map[string]string) (*Project, error) {
+	vars := map[string]string{
+		TemplateVarGroupID:    coords.GroupID,
+		TemplateVarArtifactID: coords.ArtifactID,
+		TemplateVarVersion:    coords.Version,
+	}
+	for _, variable := range d.Variables {
+		if variable.Default != "" {
+			vars[variable.Name] = variable.Default
+		}
+	}
+	for name, value := range values {
+		if value != "" || vars[name] == "" {
+			vars[name] = value
+		}
+	}
+
+	missing := make(map[string]bool)
+	rendered := *d
+	rendered.expand(func(s string) string {
+		return templateVar.ReplaceAllStringFunc(s, func(ref string) string {
+			name := templateVar.FindStringSubmatch(ref)[1]
+			if value, ok := vars[name]; ok {
+				return value
+			}
+			missing[name] = true
+			return ref
+		})
+	})
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("%w: %s", ErrTemplateVariable, strings.Join(names, ", "))
+	}
+
+	return rendered.project(coords), nil
+}
+
+// expand replaces every string of the definition by fn's result. The
+// slices and maps are copied first, so expanding a shallow copy leaves the
+// original untouched.
+func (d *TemplateDefinition) expand(fn func(string) string) {
+	d.Packaging = fn(d.Packaging)
+	if d.Parent != nil {
+		parent := *d.Parent
+		parent.GroupID = fn(parent.GroupID)
+		parent.ArtifactID = fn(parent.ArtifactID)
+		parent.Version = fn(parent.Version)
+		d.Parent = &parent
+	}
+
+	if d.Properties != nil {
+		properties := make(map[string]string, len(d.Properties))
+		for key, value := range d.Properties {
+			properties[key] = fn(value)
+		}
+		d.Properties = properties
+	}
+
+	expandDeps := func(deps []TemplateDependency) []TemplateDependency {
+		if deps == nil {
+			return nil
+		}
+		out := make([]TemplateDependency, len(deps))
+		for i, dep := range deps {
+			dep.GroupID = fn(dep.GroupID)
+			dep.ArtifactID = fn(dep.ArtifactID)
+			dep.Version = fn(dep.Version)
+			out[i] = dep
+		}
+		return out
+	}
+	d.DependencyManagement = expandDeps(d.DependencyManagement)
+	d.Dependencies = expandDeps(d.Dependencies)
+
+	if d.Plugins != nil {
+		plugins := make([]TemplatePlugin, len(d.Plugins))
+		for i, plugin := range d.Plugins {
+			plugin.GroupID = fn(plugin.GroupID)
+			plugin.ArtifactID = fn(plugin.ArtifactID)
+			plugin.Version = fn(plugin.Version)
+			if plugin.Configuration != nil {
+				plugin.Configuration = expandConfiguration(plugin.Configuration, fn)
+			}
+			plugins[i] = plugin
+		}
+		d.Plugins = plugins
+	}
+}
+
+// expandConfiguration applies fn to the string values of nested
+// plugin configuration
+func expandConfiguration(config map[string]interface{}, fn func(string) string) map[string]interface{} {
+	out := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		switch v := value.(type) {
+		case string:
+			out[key] = fn(v)
+		case map[string]interface{}:
+			out[key] = expandConfiguration(v, fn)
+		default:
+			out[key] = v
+		}
+	}
+	return out
+}
+
+// project converts an expanded definition to a Project
+func (d *TemplateDefinition) project(coords Coordinates) *Project {
+	project := &Project{
+		XMLNS:          MavenXMLNamespace,
+		XSI:            "http://www.w3.org/2001/XMLSchema-instance",
+		SchemaLocation: MavenXMLSchemaLocation,
+		ModelVersion:   DefaultModelVersion,
+		GroupID:        coords.GroupID,
+		ArtifactID:     coords.ArtifactID,
+		Version:        coords.Version,
+		Coordinates:    coords,
+		Packaging:      d.Packaging,
+		Properties:     make(map[string]string),
+	}
+	if project.Packaging == "" {
+		project.Packaging = DefaultPackaging
+	}
+	if d.Parent != nil {
+		project.Parent = &Parent{
+			GroupID:    d.Parent.GroupID,
+			ArtifactID: d.Parent.ArtifactID,
+			Version:    d.Parent.Version,
+		}
+	}
+	for key, value := range d.Properties {
+		project.Properties[key] = value
+	}
+
+	fromTemplate := func(deps []TemplateDependency) []Dependency {
+		var out []Dependency
+		for _, dep := range deps {
+			out = append(out, Dependency{
+				GroupID:    dep.GroupID,
+				ArtifactID: dep.ArtifactID,
+				Version:    dep.Version,
+				Type:       dep.Type,
+				Scope:      dep.Scope,
+				Optional:   dep.Optional,
+			})
+		}
+		return out
+	}
+	project.DependencyManagement = fromTemplate(d.DependencyManagement)
+	project.Dependencies = fromTemplate(d.Dependencies)
+
+	if len(d.Plugins) > 0 {
+		project.Build = &Build{}
+		for _, tp := range d.Plugins {
+			plugin := Plugin{
+				GroupID:    tp.GroupID,
+				ArtifactID: tp.ArtifactID,
+				Version:    tp.Version,
+				Extensions: tp.Extensions,
+			}
+			if tp.Configuration != nil {
+				plugin.Configuration = &Configuration{Data: tp.Configuration}
+			}
+			for _, exec := range tp.Executions {
+				plugin.Executions = append(plugin.Executions, PluginExecution{
+					ID:    exec.ID,
+					Phase: exec.Phase,
+					Goals: exec.Goals,
+				})
+			}
+			project.Build.Plugins = append(project.Build.Plugins, plugin)
+		}
+	}
+	return project
+}
@@ -0,0 +1,182 @@
+package pom
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// LifecycleStep is a plugin goal Maven runs in a phase
+type LifecycleStep struct {
+	Phase       string
+	Plugin      Plugin // As declared in the POM, or groupId and artifactId only for implied bindings
+	Goal        string
+	ExecutionID string
+	Implied     bool // Bound by the packaging rather than declared in the POM
+}
+
+// lifecycleBinding binds a goal of a Maven plugin to a phase
+type lifecycleBinding struct {
+	phase  string
+	plugin string // artifactId, with DefaultPluginGroupID
+	goal   string
+}
+
+// jarBindings are the default lifecycle bindings shared by the packagings
+// building Java classes
+var jarBindings = []lifecycleBinding{
+	{PhaseProcessResources, "maven-resources-plugin", "resources"},
+	{PhaseCompile, "maven-compiler-plugin", "compile"},
+	{PhaseProcessTestResources, "maven-resources-plugin", "testResources"},
+	{PhaseTestCompile, "maven-compiler-plugin", "testCompile"},
+	{PhaseTest, "maven-surefire-plugin", "test"},
+}
+
+// installBindings are the default lifecycle bindings of every packaging
+// after package
+var installBindings = []lifecycleBinding{
+	{PhaseInstall, "maven-install-plugin", "install"},
+	{PhaseDeploy, "maven-deploy-plugin", "deploy"},
+}
+
+// otherBindings are the bindings of the clean and site lifecycles, which
+// do not depend on the packaging
+var otherBindings = []lifecycleBinding{
+	{PhaseClean, "maven-clean-plugin", "clean"},
+	{PhaseSite, "maven-site-plugin", "site"},
+	{PhaseSiteDeploy, "maven-site-plugin", "deploy"},
+}
+
+// lifecycleMappings lists the default lifecycle bindings of each packaging,
+// as in Maven's lifecycle mappings
+var lifecycleMappings = map[string][]lifecycleBinding{
+	PackagingJar: slices.Concat(jarBindings, []lifecycleBinding{
+		{PhasePackage, "maven-jar-plugin", "jar"},
+	}, installBindings),
+	PackagingWar: slices.Concat(jarBindings, []lifecycleBinding{
+		{PhasePackage, "maven-war-plugin", "war"},
+	}, installBindings),
+	PackagingEar: slices.Concat([]lifecycleBinding{
+		{PhaseGenerateResources, "maven-ear-plugin", "generate-application-xml"},
+		{PhaseProcessResources, "maven-resources-plugin", "resources"},
+		{PhasePackage, "maven-ear-plugin", "ear"},
+	}, installBindings),
+	PackagingPom: installBindings,
+	PackagingMavenPlugin: slices.Concat(jarBindings[:2], []lifecycleBinding{
+		{PhaseProcessClasses, "maven-plugin-plugin", "descriptor"},
+	}, jarBindings[2:], []lifecycleBinding{
+		{PhasePackage, "maven-jar-plugin", "jar"},
+		{PhasePackage, "maven-plugin-plugin", "addPluginArtifactMetadata"},
+	}, installBindings),
+	PackagingRar: slices.Concat(jarBindings, []lifecycleBinding{
+		{PhasePackage, "maven-rar-plugin", "rar"},
+	}, installBindings),
+}
+
+// HasLifecycleMapping reports whether the default lifecycle bindings of a
+// packaging are known. Other packagings are defined by build extensions.
+func HasLifecycleMapping(packaging string) bool {
+	if packaging == "" {
+		packaging = DefaultPackaging
+	}
+	_, ok := lifecycleMappings[packaging]
+	return ok
+}
+
+// PhasesUpTo returns the phases Maven runs for mvn phase: those of its
+// lifecycle up to and including it
+func PhasesUpTo(phase string) ([]string, error) {
+	var phases []string
+	switch LifecycleOf(phase) {
+	case LifecycleClean:
+		phases = CleanLifecyclePhases
+	case LifecycleDefault:
+		phases = DefaultLifecyclePhases
+	case LifecycleSite:
+		phases = SiteLifecyclePhases
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPhase, phase)
+	}
+	return phases[:slices.Index(phases, phase)+1], nil
+}
+
+// Timeline returns the goals Maven runs for mvn phase, in order: phase by
+// phase, the goals the packaging binds first, then the POM's executions in
+// declaration order. An execution named default-<goal> configures the
+// implied binding of that goal rather than adding one. Executions without a
+// phase run in their goal's default phase when the goal is known, and not
+// at all otherwise. Profiles and inherited plugins are not considered.
+func Timeline(project *Project, phase string) ([]LifecycleStep, error) {
+	phases, err := PhasesUpTo(phase)
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []LifecycleStep
+	packaging := DefaultPackaging
+	if project != nil && project.Packaging != "" {
+		packaging = project.Packaging
+	}
+	for _, binding := range slices.Concat(lifecycleMappings[packaging], otherBindings) {
+		steps = append(steps, LifecycleStep{
+			Phase:       binding.phase,
+			Plugin:      Plugin{GroupID: DefaultPluginGroupID, ArtifactID: binding.plugin},
+			Goal:        binding.goal,
+			ExecutionID: "default-" + binding.goal,
+			Implied:     true,
+		})
+	}
+
+	if project != nil && project.Build != nil {
+		for _, plugin := range project.Build.Plugins {
+			for _, exec := range plugin.Executions {
+				steps = addExecutionSteps(steps, plugin, exec)
+			}
+		}
+	}
+
+	var ordered []LifecycleStep
+	for _, p := range phases {
+		for _, step := range steps {
+			if step.Phase == p {
+				ordered = append(ordered, step)
+			}
+		}
+	}
+	return ordered, nil
+}
+
+// addExecutionSteps adds a step per goal of an execution, or updates the
+// implied step the execution configures
+func addExecutionSteps(steps []LifecycleStep, plugin Plugin, exec PluginExecution) []LifecycleStep {
+	goals := exec.Goals
+	if len(goals) == 0 && strings.HasPrefix(exec.ID, "default-") {
+		// Configuring an implied binding needs no goals
+		goals = []string{strings.TrimPrefix(exec.ID, "default-")}
+	}
+
+	for _, goal := range goals {
+		phase := exec.Phase
+		if known, ok := LookupGoal(plugin, goal); ok && phase == "" {
+			phase = known.Phase
+		}
+
+		implied := slices.IndexFunc(steps, func(step LifecycleStep) bool {
+			return step.Implied && step.ExecutionID == exec.ID && step.Goal == goal &&
+				pluginCatalogKey(step.Plugin) == pluginCatalogKey(plugin)
+		})
+		if implied >= 0 {
+			if phase != "" {
+				steps[implied].Phase = phase
+			}
+			steps[implied].Plugin = plugin
+			steps[implied].Implied = false
+			continue
+		}
+
+		if phase != "" && len(exec.Goals) > 0 {
+			steps = append(steps, LifecycleStep{Phase: phase, Plugin: plugin, Goal: goal, ExecutionID: exec.ID})
+		}
+	}
+	return steps
+}
@@ -0,0 +1,108 @@
+package pom
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// timelineSteps describes steps as "phase artifactId:goal", with a
+// trailing " (implied)" for bindings of the packaging
+func timelineSteps(steps []LifecycleStep) []string {
+	var out []string
+	for _, step := range steps {
+		s := step.Phase + " " + step.Plugin.ArtifactID + ":" + step.Goal
+		if step.Implied {
+			s += " (implied)"
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestTimeline(t *testing.T) {
+	project := &Project{
+		Packaging: PackagingJar,
+		Build: &Build{Plugins: []Plugin{
+			{GroupID: DefaultPluginGroupID, ArtifactID: "maven-surefire-plugin", Executions: []PluginExecution{
+				{ID: "default-test"}, // Configures the implied binding
+			}},
+			{GroupID: DefaultPluginGroupID, ArtifactID: "maven-source-plugin", Executions: []PluginExecution{
+				{ID: "attach-sources", Goals: []string{"jar-no-fork"}}, // Default phase
+			}},
+			{GroupID: DefaultPluginGroupID, ArtifactID: "maven-jar-plugin", Executions: []PluginExecution{
+				{ID: "test-jar", Phase: PhasePackage, Goals: []string{"test-jar"}},
+			}},
+			{GroupID: "com.example", ArtifactID: "custom-plugin", Executions: []PluginExecution{
+				{ID: "generate", Goals: []string{"generate"}}, // Unknown goal without a phase never runs
+			}},
+			{GroupID: DefaultPluginGroupID, ArtifactID: "maven-failsafe-plugin", Executions: []PluginExecution{
+				{Goals: []string{"integration-test", "verify"}},
+			}},
+		}},
+	}
+
+	tests := []struct {
+		phase string
+		want  []string
+	}{
+		{PhasePackage, []string{
+			"process-resources maven-resources-plugin:resources (implied)",
+			"compile maven-compiler-plugin:compile (implied)",
+			"process-test-resources maven-resources-plugin:testResources (implied)",
+			"test-compile maven-compiler-plugin:testCompile (implied)",
+			"test maven-surefire-plugin:test",
+			"package maven-jar-plugin:jar (implied)",
+			"package maven-source-plugin:jar-no-fork",
+			"package maven-jar-plugin:test-jar",
+		}},
+		{PhaseCompile, []string{
+			"process-resources maven-resources-plugin:resources (implied)",
+			"compile maven-compiler-plugin:compile (implied)",
+		}},
+		{PhaseClean, []string{"clean maven-clean-plugin:clean (implied)"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phase, func(t *testing.T) {
+			steps, err := Timeline(project, tt.phase)
+			if err != nil {
+				t.Fatalf("Expected a timeline, got %v", err)
+			}
+			if got := timelineSteps(steps); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	steps, err := Timeline(project, PhaseVerify)
+	if err != nil {
+		t.Fatalf("Expected a timeline, got %v", err)
+	}
+	got := timelineSteps(steps)
+	if want := []string{"integration-test maven-failsafe-plugin:integration-test", "verify maven-failsafe-plugin:verify"}; !reflect.DeepEqual(got[len(got)-2:], want) {
+		t.Errorf("Expected executions without a phase in their goal's default phase, got %q", got)
+	}
+}
+
+func TestTimelinePackagings(t *testing.T) {
+	steps, err := Timeline(&Project{Packaging: PackagingPom}, PhaseInstall)
+	if err != nil {
+		t.Fatalf("Expected a timeline, got %v", err)
+	}
+	if got := timelineSteps(steps); !reflect.DeepEqual(got, []string{"install maven-install-plugin:install (implied)"}) {
+		t.Errorf("Expected only install for pom packaging, got %q", got)
+	}
+
+	steps, err = Timeline(nil, PhaseTest)
+	if err != nil || len(steps) != 5 {
+		t.Errorf("Expected the jar bindings up to test without a project, got %q, %v", timelineSteps(steps), err)
+	}
+
+	if _, err := Timeline(&Project{}, "packge"); !errors.Is(err, ErrInvalidPhase) {
+		t.Errorf("Expected ErrInvalidPhase, got %v", err)
+	}
+	if !HasLifecycleMapping("") || HasLifecycleMapping("bundle") {
+		t.Error("Expected a mapping for the default packaging and none for bundle")
+	}
+}
@@ -0,0 +1,231 @@
+package pom
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// wellKnownArtifacts lists popular artifacts by groupId. Coordinates close
+// to, but not exactly, one of these are likely typos.
+var wellKnownArtifacts = map[string][]string{
+	"org.springframework": {
+		"spring-aop", "spring-beans", "spring-context", "spring-core", "spring-jdbc",
+		"spring-orm", "spring-test", "spring-tx", "spring-web", "spring-webflux", "spring-webmvc",
+	},
+	"org.springframework.boot": {
+		"spring-boot-dependencies", "spring-boot-maven-plugin", "spring-boot-starter",
+		"spring-boot-starter-actuator", "spring-boot-starter-data-jpa", "spring-boot-starter-parent",
+		"spring-boot-starter-security", "spring-boot-starter-test", "spring-boot-starter-validation",
+		"spring-boot-starter-web", "spring-boot-starter-webflux",
+	},
+	"junit":                          {"junit"},
+	"org.junit":                      {"junit-bom"},
+	"org.junit.jupiter":              {"junit-jupiter", "junit-jupiter-api", "junit-jupiter-engine", "junit-jupiter-params"},
+	"org.mockito":                    {"mockito-core", "mockito-inline", "mockito-junit-jupiter"},
+	"org.assertj":                    {"assertj-core"},
+	"org.hamcrest":                   {"hamcrest", "hamcrest-core", "hamcrest-library"},
+	"org.testcontainers":             {"junit-jupiter", "postgresql", "testcontainers"},
+	"org.slf4j":                      {"jul-to-slf4j", "slf4j-api", "slf4j-simple"},
+	"ch.qos.logback":                 {"logback-classic", "logback-core"},
+	"org.apache.logging.log4j":       {"log4j-api", "log4j-core", "log4j-slf4j2-impl"},
+	"com.fasterxml.jackson.core":     {"jackson-annotations", "jackson-core", "jackson-databind"},
+	"com.fasterxml.jackson.datatype": {"jackson-datatype-jdk8", "jackson-datatype-jsr310"},
+	"com.google.guava":               {"guava"},
+	"com.google.code.gson":           {"gson"},
+	"org.apache.commons":             {"commons-collections4", "commons-compress", "commons-lang3", "commons-text"},
+	"commons-io":                     {"commons-io"},
+	"commons-codec":                  {"commons-codec"},
+	"org.projectlombok":              {"lombok"},
+	"org.hibernate.orm":              {"hibernate-core"},
+	"jakarta.servlet":                {"jakarta.servlet-api"},
+	"javax.servlet":                  {"javax.servlet-api"},
+	"jakarta.persistence":            {"jakarta.persistence-api"},
+	"org.postgresql":                 {"postgresql"},
+	"com.mysql":                      {"mysql-connector-j"},
+	"com.h2database":                 {"h2"},
+	"org.apache.httpcomponents":      {"httpclient"},
+	"com.squareup.okhttp3":           {"okhttp"},
+	"io.netty":                       {"netty-all"},
+	"io.projectreactor":              {"reactor-core"},
+	"io.quarkus":                     {"quarkus-arc", "quarkus-bom", "quarkus-maven-plugin", "quarkus-resteasy-reactive"},
+	"org.jetbrains.kotlin":           {"kotlin-maven-plugin", "kotlin-stdlib"},
+	DefaultPluginGroupID: {
+		"maven-assembly-plugin", "maven-clean-plugin", "maven-compiler-plugin", "maven-dependency-plugin",
+		"maven-deploy-plugin", "maven-enforcer-plugin", "maven-failsafe-plugin", "maven-install-plugin",
+		"maven-jar-plugin", "maven-javadoc-plugin", "maven-resources-plugin", "maven-shade-plugin",
+		"maven-source-plugin", "maven-surefire-plugin", "maven-war-plugin",
+	},
+}
+
+// SuggestCoordinates returns the well-known groupId and artifactId that the
+// given ones are a likely misspelling of. ok is false when the coordinates
+// are well-known, or too far from any well-known ones to guess.
+func SuggestCoordinates(groupID, artifactID string) (suggestedGroupID, suggestedArtifactID string, ok bool) {
+	if groupID == "" || artifactID == "" {
+		return "", "", false
+	}
+
+	group := groupID
+	if _, known := wellKnownArtifacts[groupID]; !known {
+		group = closestMatch(groupID, wellKnownGroupIDs())
+		if group == "" {
+			return "", "", false
+		}
+	}
+
+	artifacts := wellKnownArtifacts[group]
+	artifact := artifactID
+	if !containsString(artifacts, artifactID) {
+		artifact = closestMatch(artifactID, artifacts)
+		if artifact == "" {
+			// Only the groupId is recognized; an unknown artifact of a
+			// misspelt group is still worth flagging
+			if group == groupID {
+				return "", "", false
+			}
+			artifact = artifactID
+		}
+	}
+
+	if group == groupID && artifact == artifactID {
+		return "", "", false
+	}
+	return group, artifact, true
+}
+
+// typoRule flags dependencies and plugins whose coordinates are likely
+// misspellings of well-known ones
+type typoRule struct{}
+
+func (r *typoRule) Validate(project *Project) []ValidationError {
+	var errors []ValidationError
+
+	for i, dep := range project.Dependencies {
+		groupID, artifactID, ok := SuggestCoordinates(dep.GroupID, dep.ArtifactID)
+		if !ok {
+			continue
+		}
+		errors = append(errors, typoFinding(fmt.Sprintf("dependencies[%d]", i), dep.GroupID, dep.ArtifactID, groupID, artifactID,
+			func(project *Project) error {
+				if !sameDependency(project.Dependencies, i, dep) {
+					return ErrFixNotApplicable
+				}
+				project.Dependencies[i].GroupID = groupID
+				project.Dependencies[i].ArtifactID = artifactID
+				return nil
+			}))
+	}
+
+	if project.Build == nil {
+		return errors
+	}
+	for i, plugin := range project.Build.Plugins {
+		pluginGroupID := plugin.GroupID
+		if pluginGroupID == "" {
+			pluginGroupID = DefaultPluginGroupID
+		}
+		groupID, artifactID, ok := SuggestCoordinates(pluginGroupID, plugin.ArtifactID)
+		if !ok {
+			continue
+		}
+		errors = append(errors, typoFinding(fmt.Sprintf("build.plugins[%d]", i), pluginGroupID, plugin.ArtifactID, groupID, artifactID,
+			func(project *Project) error {
+				plugins := project.Build.Plugins
+				if i >= len(plugins) || plugins[i].GroupID != plugin.GroupID || plugins[i].ArtifactID != plugin.ArtifactID {
+					return ErrFixNotApplicable
+				}
+				if plugin.GroupID != "" || groupID != DefaultPluginGroupID {
+					plugins[i].GroupID = groupID
+				}
+				plugins[i].ArtifactID = artifactID
+				return nil
+			}))
+	}
+
+	return errors
+}
+
+// typoFinding reports misspelt coordinates on the groupId or artifactId
+// field of the element at path
+func typoFinding(path, groupID, artifactID, suggestedGroupID, suggestedArtifactID string, apply func(*Project) error) ValidationError {
+	field := path + ".artifactId"
+	if groupID != suggestedGroupID {
+		field = path + ".groupId"
+	}
+	suggestion := suggestedGroupID + ":" + suggestedArtifactID
+	return ValidationError{
+		Field:    field,
+		Value:    groupID + ":" + artifactID,
+		Message:  fmt.Sprintf("unknown coordinates, did you mean %s?", suggestion),
+		Severity: SeverityWarning,
+		Rule:     RuleCoordinateTypo,
+		Fix: &Fix{
+			Description: "Change to " + suggestion,
+			Apply:       apply,
+		},
+	}
+}
+
+// wellKnownGroupIDs returns the groupIds of wellKnownArtifacts, sorted so
+// ties resolve the same way every time
+func wellKnownGroupIDs() []string {
+	groupIDs := make([]string, 0, len(wellKnownArtifacts))
+	for groupID := range wellKnownArtifacts {
+		groupIDs = append(groupIDs, groupID)
+	}
+	sort.Strings(groupIDs)
+	return groupIDs
+}
+
+// closestMatch returns the candidate within typo distance of s, "" when
+// none is. Differences in case alone always match; otherwise short names
+// allow one edit and longer ones two.
+func closestMatch(s string, candidates []string) string {
+	maxDistance := 1
+	if len(s) > 8 {
+		maxDistance = 2
+	}
+
+	best, bestDistance := "", maxDistance+1
+	for _, candidate := range candidates {
+		if strings.EqualFold(s, candidate) {
+			return candidate
+		}
+		if d := editDistance(s, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,78 @@
+package pom
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSuggestCoordinates(t *testing.T) {
+	tests := []struct {
+		name         string
+		groupID      string
+		artifactID   string
+		wantGroup    string
+		wantArtifact string
+		wantOK       bool
+	}{
+		{"well-known", "org.slf4j", "slf4j-api", "", "", false},
+		{"misspelt artifact", "org.slf4j", "slf4j-apj", "org.slf4j", "slf4j-api", true},
+		{"misspelt group", "org.sfl4j", "slf4j-api", "org.slf4j", "slf4j-api", true},
+		{"case only", "com.google.guava", "Guava", "com.google.guava", "guava", true},
+		{"misspelt group, unknown artifact", "org.projectlombk", "lombok-extra", "org.projectlombok", "lombok-extra", true},
+		{"known group, unknown artifact", "org.slf4j", "slf4j-ext", "", "", false},
+		{"unknown coordinates", "com.example", "demo", "", "", false},
+		{"short names allow one edit", "junit", "jnit", "junit", "junit", true},
+		{"short names reject two edits", "junit", "jnt", "", "", false},
+		{"empty artifact", "org.slf4j", "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group, artifact, ok := SuggestCoordinates(tt.groupID, tt.artifactID)
+			if ok != tt.wantOK || group != tt.wantGroup || artifact != tt.wantArtifact {
+				t.Errorf("Expected %s:%s %v, got %s:%s %v", tt.wantGroup, tt.wantArtifact, tt.wantOK, group, artifact, ok)
+			}
+		})
+	}
+}
+
+func TestTypoRule(t *testing.T) {
+	project := &Project{
+		Dependencies: []Dependency{
+			{GroupID: "org.slf4j", ArtifactID: "slf4j-api"},
+			{GroupID: "org.sfl4j", ArtifactID: "slf4j-simple"},
+		},
+		Build: &Build{Plugins: []Plugin{
+			{ArtifactID: "maven-surefire-plugn"}, // Default groupId
+		}},
+	}
+
+	findings := (&typoRule{}).Validate(project)
+	var fields []string
+	for _, finding := range findings {
+		if finding.Severity != SeverityWarning || finding.Rule != RuleCoordinateTypo || finding.Fix == nil {
+			t.Errorf("Expected a fixable coordinate-typo warning, got %+v", finding)
+		}
+		fields = append(fields, finding.Field)
+	}
+	want := []string{"dependencies[1].groupId", "build.plugins[0].artifactId"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Fatalf("Expected findings %v, got %v", want, fields)
+	}
+
+	for _, finding := range findings {
+		if err := finding.Fix.Apply(project); err != nil {
+			t.Errorf("Expected %s to be fixed, got %v", finding.Field, err)
+		}
+	}
+	if got := project.Dependencies[1].GroupID; got != "org.slf4j" {
+		t.Errorf("Expected the groupId fixed, got %s", got)
+	}
+	if plugin := project.Build.Plugins[0]; plugin.GroupID != "" || plugin.ArtifactID != "maven-surefire-plugin" {
+		t.Errorf("Expected the artifactId fixed and the default groupId left out, got %+v", plugin)
+	}
+	if err := findings[0].Fix.Apply(project); !errors.Is(err, ErrFixNotApplicable) {
+		t.Errorf("Expected ErrFixNotApplicable once fixed, got %v", err)
+	}
+}
@@ -11,6 +11,9 @@ import (
 // Validator interface for validating Project structs
 type Validator interface {
 	Validate(project *Project) ValidationResult
+	// ValidateWithInheritance also takes what the parent chain provides into
+	// account, such as versions managed by a parent's dependencyManagement
+	ValidateWithInheritance(project *Project, inheritance *Inheritance) ValidationResult
 }
 
 // ValidationRule interface for individual validation rules
@@ -30,12 +33,21 @@ func NewValidator() Validator {
 			&coordinatesRule{},
 			&dependenciesRule{},
 			&buildRule{},
+			&modulesRule{},
+			&typoRule{},
+			&pluginConfigurationRule{},
 		},
 	}
 }
 
 // Validate runs all validation rules and returns grouped errors
 func (v *defaultValidator) Validate(project *Project) ValidationResult {
+	return v.ValidateWithInheritance(project, nil)
+}
+
+// ValidateWithInheritance runs all validation rules; inheritance may be nil
+// when the parent chain is unknown
+func (v *defaultValidator) ValidateWithInheritance(project *Project, inheritance *Inheritance) ValidationResult {
 	result := ValidationResult{
 		Valid: true,
 		Errors: ValidationErrors{
@@ -58,43 +70,113 @@ func (v *defaultValidator) Validate(project *Project) ValidationResult {
 
 	// Run all validation rules
 	for _, rule := range v.rules {
-		errors := rule.Validate(project)
-		for _, err := range errors {
-			result.Valid = false
-			// Categorize errors based on field
-			if strings.HasPrefix(err.Field, "groupId") || strings.HasPrefix(err.Field, "artifactId") || strings.HasPrefix(err.Field, "version") || strings.HasPrefix(err.Field, "packaging") {
-				result.Errors.Coordinates = append(result.Errors.Coordinates, err)
-			} else if strings.Contains(err.Field, "dependency") || strings.Contains(err.Field, "scope") {
-				result.Errors.Dependencies = append(result.Errors.Dependencies, err)
-			} else if strings.Contains(err.Field, "plugin") || strings.Contains(err.Field, "phase") || strings.Contains(err.Field, "build") {
-				result.Errors.Build = append(result.Errors.Build, err)
-			} else {
-				result.Errors.General = append(result.Errors.General, err)
-			}
+		for _, err := range rule.Validate(project) {
+			result.addError(err)
 		}
 	}
 
+	// Missing versions depend on what the parent chain manages
+	for _, err := range checkManagedVersions(project, inheritance) {
+		result.addError(err)
+	}
+
+	if project.Positions != nil {
+		project.Positions.Locate(&result)
+	}
+
 	return result
 }
 
+// Add records findings from checks outside the validator, such as
+// CheckPaths
+func (result *ValidationResult) Add(findings ...ValidationError) {
+	for _, finding := range findings {
+		result.addError(finding)
+	}
+}
+
+// addError records a validation finding in the category matching its field;
+// only errors make the result invalid
+func (result *ValidationResult) addError(err ValidationError) {
+	if err.Severity == SeverityError {
+		result.Valid = false
+	}
+	// Categorize errors based on field
+	if strings.HasPrefix(err.Field, "groupId") || strings.HasPrefix(err.Field, "artifactId") || strings.HasPrefix(err.Field, "version") || strings.HasPrefix(err.Field, "packaging") {
+		result.Errors.Coordinates = append(result.Errors.Coordinates, err)
+	} else if strings.Contains(err.Field, "dependency") || strings.Contains(err.Field, "scope") {
+		result.Errors.Dependencies = append(result.Errors.Dependencies, err)
+	} else if strings.Contains(err.Field, "plugin") || strings.Contains(err.Field, "phase") || strings.Contains(err.Field, "build") {
+		result.Errors.Build = append(result.Errors.Build, err)
+	} else {
+		result.Errors.General = append(result.Errors.General, err)
+	}
+}
+
+// checkManagedVersions reports dependencies declared without a version: a
+// note when dependencyManagement (the project's, a parent's, or a BOM's)
+// supplies it, an error otherwise
+func checkManagedVersions(project *Project, inheritance *Inheritance) []ValidationError {
+	var errors []ValidationError
+	for i, dep := range project.Dependencies {
+		if dep.Version != "" {
+			continue
+		}
+		field := fmt.Sprintf("dependencies[%d].version", i)
+		if source, ok := ManagedVersionSource(project, inheritance, dep.GroupID, dep.ArtifactID); ok {
+			errors = append(errors, ValidationError{
+				Field:    field,
+				Value:    dep.GroupID + ":" + dep.ArtifactID,
+				Message:  source,
+				Severity: SeverityInfo,
+				Rule:     RuleManagedVersion,
+			})
+			continue
+		}
+		errors = append(errors, ValidationError{
+			Field:   field,
+			Value:   "",
+			Message: "dependency version is required unless it is managed by dependencyManagement",
+			Rule:    RuleDependencyVersion,
+		})
+	}
+	return errors
+}
+
 // coordinatesRule validates project coordinates
 type coordinatesRule struct{}
 
 func (r *coordinatesRule) Validate(project *Project) []ValidationError {
 	var errors []ValidationError
 
+	// The generator writes the default, but Maven rejects a file without one
+	if project.ModelVersion == "" {
+		errors = append(errors, ValidationError{
+			Field:    "modelVersion",
+			Value:    "",
+			Message:  fmt.Sprintf("modelVersion is missing (Maven requires %s)", DefaultModelVersion),
+			Severity: SeverityWarning,
+			Rule:     RuleModelVersion,
+			Fix:      setModelVersionFix(),
+		})
+	}
+
 	// Validate groupId
 	if project.GroupID == "" {
 		errors = append(errors, ValidationError{
 			Field:   "groupId",
 			Value:   "",
 			Message: "groupId is required",
+			Rule:    RuleCoordinatesRequired,
 		})
 	} else if !isValidGroupID(project.GroupID) {
 		errors = append(errors, ValidationError{
-			Field:   "groupId",
-			Value:   project.GroupID,
-			Message: "groupId must be lowercase with dot separators (e.g., 'com.example')",
+			Field:    "groupId",
+			Value:    project.GroupID,
+			Message:  "groupId should be lowercase with dot separators (e.g., 'com.example')",
+			Severity: SeverityWarning,
+			Rule:     RuleGroupIDFormat,
+			Fix:      suggestFix(conformingGroupID(project.GroupID), func(to string) *Fix { return setGroupIDFix(project.GroupID, to) }),
 		})
 	}
 
@@ -104,12 +186,16 @@ func (r *coordinatesRule) Validate(project *Project) []ValidationError {
 			Field:   "artifactId",
 			Value:   "",
 			Message: "artifactId is required",
+			Rule:    RuleCoordinatesRequired,
 		})
 	} else if !isValidArtifactID(project.ArtifactID) {
 		errors = append(errors, ValidationError{
-			Field:   "artifactId",
-			Value:   project.ArtifactID,
-			Message: "artifactId must be lowercase with hyphens (e.g., 'my-app')",
+			Field:    "artifactId",
+			Value:    project.ArtifactID,
+			Message:  "artifactId should be lowercase with hyphens (e.g., 'my-app')",
+			Severity: SeverityWarning,
+			Rule:     RuleArtifactIDFormat,
+			Fix:      suggestFix(conformingArtifactID(project.ArtifactID), func(to string) *Fix { return setArtifactIDFix(project.ArtifactID, to) }),
 		})
 	}
 
@@ -119,12 +205,15 @@ func (r *coordinatesRule) Validate(project *Project) []ValidationError {
 			Field:   "version",
 			Value:   "",
 			Message: "version is required",
+			Rule:    RuleCoordinatesRequired,
 		})
 	} else if !isValidVersion(project.Version) {
 		errors = append(errors, ValidationError{
-			Field:   "version",
-			Value:   project.Version,
-			Message: "version must follow semantic versioning or Maven snapshot conventions",
+			Field:    "version",
+			Value:    project.Version,
+			Message:  "version should follow semantic versioning or Maven snapshot conventions",
+			Severity: SeverityWarning,
+			Rule:     RuleVersionFormat,
 		})
 	}
 
@@ -134,6 +223,7 @@ func (r *coordinatesRule) Validate(project *Project) []ValidationError {
 			Field:   "packaging",
 			Value:   project.Packaging,
 			Message: fmt.Sprintf("packaging must be one of: %s", strings.Join(ValidPackagingTypes, ", ")),
+			Rule:    RulePackagingType,
 		})
 	}
 
@@ -155,6 +245,7 @@ func (r *dependenciesRule) Validate(project *Project) []ValidationError {
 				Field:   fmt.Sprintf("dependencies[%d].groupId", i),
 				Value:   "",
 				Message: "dependency groupId is required",
+				Rule:    RuleDependencyCoordinates,
 			})
 		}
 		if dep.ArtifactID == "" {
@@ -162,15 +253,10 @@ func (r *dependenciesRule) Validate(project *Project) []ValidationError {
 				Field:   fmt.Sprintf("dependencies[%d].artifactId", i),
 				Value:   "",
 				Message: "dependency artifactId is required",
+				Rule:    RuleDependencyCoordinates,
 			})
 		}
-		if dep.Version == "" {
-			errors = append(errors, ValidationError{
-				Field:   fmt.Sprintf("dependencies[%d].version", i),
-				Value:   "",
-				Message: "dependency version is required",
-			})
-		}
+		// Missing versions are checked against dependencyManagement afterwards
 
 		// Validate scope
 		if dep.Scope != "" && !isValidScope(dep.Scope) {
@@ -178,24 +264,133 @@ func (r *dependenciesRule) Validate(project *Project) []ValidationError {
 				Field:   fmt.Sprintf("dependencies[%d].scope", i),
 				Value:   dep.Scope,
 				Message: fmt.Sprintf("scope must be one of: %s", strings.Join(ValidDependencyScopes, ", ")),
+				Rule:    RuleDependencyScope,
+				Fix:     suggestFix(conformingScope(dep.Scope), func(to string) *Fix { return setScopeFix(i, dep, to) }),
 			})
 		}
 
-		// Check for duplicates (simple circular dependency detection)
-		key := fmt.Sprintf("%s:%s", dep.GroupID, dep.ArtifactID)
+		// BOMs can only be imported from dependencyManagement
+		if dep.Scope == ScopeImport {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("dependencies[%d].scope", i),
+				Value:   dep.Scope,
+				Message: "import scope is only allowed in dependencyManagement",
+				Rule:    RuleImportScope,
+				Fix:     moveToManagementFix(i, dep),
+			})
+		}
+		errors = append(errors, checkSystemPath(fmt.Sprintf("dependencies[%d]", i), dep)...)
+
+		// Check for duplicates; artifacts differing in type or classifier
+		// (such as test-jars) are distinct
+		key := dep.Key()
 		if seen[key] {
 			errors = append(errors, ValidationError{
 				Field:   fmt.Sprintf("dependencies[%d]", i),
-				Value:   key,
+				Value:   fmt.Sprintf("%s:%s", dep.GroupID, dep.ArtifactID),
 				Message: "duplicate dependency detected",
+				Rule:    RuleDependencyDuplicate,
+				Fix:     removeDependencyFix(i, dep),
 			})
 		}
 		seen[key] = true
 	}
 
+	// Managed entries must carry the version they hand out; imports must be BOMs
+	for i, dep := range project.DependencyManagement {
+		if dep.Version == "" {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("dependencyManagement[%d].version", i),
+				Value:   "",
+				Message: "managed dependency version is required",
+				Rule:    RuleManagedDependencyVersion,
+			})
+		}
+		if dep.Scope == ScopeImport && dep.Type != PackagingPom {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("dependencyManagement[%d].type", i),
+				Value:   dep.Type,
+				Message: "import scope requires <type>pom</type>",
+				Rule:    RuleImportType,
+				Fix:     setImportTypeFix(i, dep),
+			})
+		}
+		errors = append(errors, checkSystemPath(fmt.Sprintf("dependencyManagement[%d]", i), dep)...)
+	}
+	errors = append(errors, checkManagedCollisions(project.DependencyManagement)...)
+
+	return errors
+}
+
+// checkManagedCollisions reports artifacts managed more than once and BOMs
+// imported more than once. Maven lets the last managed entry win, but for
+// imports the first BOM wins for every artifact the two have in common.
+func checkManagedCollisions(managed []Dependency) []ValidationError {
+	var errors []ValidationError
+
+	declared := make(map[string]int)
+	for i, dep := range managed {
+		key := dep.Key()
+		if dep.IsBOM() {
+			key = "import:" + key
+		}
+		j, seen := declared[key]
+		if !seen {
+			declared[key] = i
+			continue
+		}
+
+		previous := managed[j]
+		finding := ValidationError{
+			Field:    fmt.Sprintf("dependencyManagement[%d]", i),
+			Value:    fmt.Sprintf("%s:%s:%s", dep.GroupID, dep.ArtifactID, dep.Version),
+			Severity: SeverityWarning,
+			Rule:     RuleManagedDuplicate,
+		}
+		switch {
+		case previous.Version == dep.Version:
+			finding.Message = fmt.Sprintf("already declared as dependencyManagement[%d]", j)
+			finding.Fix = removeManagedFix(i, dep)
+		case dep.IsBOM():
+			finding.Message = fmt.Sprintf("BOM already imported with version %s as dependencyManagement[%d]; "+
+				"Maven uses the versions of that first import and only adds what it lacks from this one", previous.Version, j)
+		default:
+			finding.Message = fmt.Sprintf("also managed with version %s as dependencyManagement[%d]; "+
+				"Maven uses the last declaration, version %s", previous.Version, j, dep.Version)
+		}
+		errors = append(errors, finding)
+
+		// The next repeat is compared with the one in effect
+		if !dep.IsBOM() {
+			declared[key] = i
+		}
+	}
+
 	return errors
 }
 
+// checkSystemPath reports a system-scoped dependency without a systemPath,
+// and a systemPath on any other scope, both of which Maven rejects
+func checkSystemPath(path string, dep Dependency) []ValidationError {
+	switch {
+	case dep.Scope == ScopeSystem && dep.SystemPath == "":
+		return []ValidationError{{
+			Field:   path + ".systemPath",
+			Value:   "",
+			Message: "system scope requires a systemPath",
+			Rule:    RuleSystemPath,
+		}}
+	case dep.Scope != ScopeSystem && dep.SystemPath != "":
+		return []ValidationError{{
+			Field:   path + ".systemPath",
+			Value:   dep.SystemPath,
+			Message: "systemPath is only allowed with system scope",
+			Rule:    RuleSystemPath,
+		}}
+	}
+	return nil
+}
+
 // buildRule validates build configuration
 type buildRule struct{}
 
@@ -213,6 +408,7 @@ func (r *buildRule) Validate(project *Project) []ValidationError {
 				Field:   fmt.Sprintf("build.plugins[%d].groupId", i),
 				Value:   "",
 				Message: "plugin groupId is required",
+				Rule:    RulePluginCoordinates,
 			})
 		}
 		if plugin.ArtifactID == "" {
@@ -220,6 +416,7 @@ func (r *buildRule) Validate(project *Project) []ValidationError {
 				Field:   fmt.Sprintf("build.plugins[%d].artifactId", i),
 				Value:   "",
 				Message: "plugin artifactId is required",
+				Rule:    RulePluginCoordinates,
 			})
 		}
 
@@ -229,15 +426,70 @@ func (r *buildRule) Validate(project *Project) []ValidationError {
 				errors = append(errors, ValidationError{
 					Field:   fmt.Sprintf("build.plugins[%d].executions[%d].phase", i, j),
 					Value:   exec.Phase,
-					Message: "phase must be a valid Maven lifecycle phase",
+					Message: "phase must be a phase of the clean, default or site lifecycle",
+					Rule:    RuleExecutionPhase,
 				})
 			}
+			for k, goal := range exec.Goals {
+				if err := CheckGoal(plugin, goal); err != nil {
+					errors = append(errors, ValidationError{
+						Field:    fmt.Sprintf("build.plugins[%d].executions[%d].goals[%d]", i, j, k),
+						Value:    goal,
+						Message:  err.Error(),
+						Severity: SeverityWarning,
+						Rule:     RuleExecutionGoal,
+					})
+				}
+			}
 		}
 	}
 
 	return errors
 }
 
+// modulesRule validates the aggregator and parent declarations
+type modulesRule struct{}
+
+func (r *modulesRule) Validate(project *Project) []ValidationError {
+	var errors []ValidationError
+
+	// Maven refuses to build an aggregator with jar/war packaging
+	if len(project.Modules) > 0 && project.Packaging != PackagingPom {
+		packaging := project.Packaging
+		if packaging == "" {
+			packaging = DefaultPackaging
+		}
+		errors = append(errors, ValidationError{
+			Field:   "packaging",
+			Value:   packaging,
+			Message: "projects declaring <modules> must use 'pom' packaging",
+			Rule:    RuleAggregatorPackaging,
+			Fix:     setPackagingFix(PackagingPom),
+		})
+	}
+
+	if project.Parent != nil && project.Parent.GroupID == project.GroupID &&
+		project.Parent.ArtifactID == project.ArtifactID {
+		errors = append(errors, ValidationError{
+			Field:   "parent",
+			Value:   fmt.Sprintf("%s:%s", project.Parent.GroupID, project.Parent.ArtifactID),
+			Message: "project cannot be its own parent",
+			Rule:    RuleSelfParent,
+		})
+	}
+
+	return errors
+}
+
+// suggestFix returns the fix for a suggested value, nil when there is no
+// suggestion
+func suggestFix(suggestion string, fix func(string) *Fix) *Fix {
+	if suggestion == "" {
+		return nil
+	}
+	return fix(suggestion)
+}
+
 // isValidGroupID checks if groupId follows Maven conventions
 func isValidGroupID(groupID string) bool {
 	// Allow lowercase letters, numbers, dots, and hyphens
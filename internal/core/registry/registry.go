@@ -0,0 +1,335 @@
+// Package registry shares templates between users. A registry is a Git
+// repository or an HTTPS URL holding template files; updating it copies
+// them into the cache directory, where the template manager offers them
+// after the user's own custom templates, offline too.
+package registry
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/user/pom-manager/internal/core/git"
+	"github.com/user/pom-manager/internal/core/pom"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DirName is the directory in the cache directory holding the templates
+	// of the last update
+	DirName = "template-registry"
+
+	// StateFileName is the file in the cache directory recording the last
+	// update; it lives next to DirName so it is not taken for a template
+	StateFileName = "template-registry.yaml"
+
+	// maxDownloadSize bounds downloads from HTTPS registries
+	maxDownloadSize = 20 << 20
+)
+
+var (
+	// ErrInvalidSource is returned for registry URLs that cannot be fetched
+	ErrInvalidSource = errors.New("invalid template registry")
+
+	// ErrNoTemplates is returned when a registry holds no template files
+	ErrNoTemplates = errors.New("no templates in registry")
+)
+
+// Source is where a registry is fetched from
+type Source struct {
+	URL string `yaml:"url"`           // Git repository, or HTTPS URL of a .zip archive or a template file
+	Ref string `yaml:"ref,omitempty"` // Git branch, tag or commit to pin; "" = the default branch
+}
+
+// String describes the source as url@ref
+func (s Source) String() string {
+	if s.Ref == "" {
+		return s.URL
+	}
+	return s.URL + "@" + s.Ref
+}
+
+// Validate checks that the source can be fetched: a Git repository, or an
+// HTTP(S) URL of a .zip archive or template file, which cannot be pinned
+func (s Source) Validate() error {
+	if s.URL == "" {
+		return fmt.Errorf("%w: no URL", ErrInvalidSource)
+	}
+	if s.IsGit() {
+		return nil
+	}
+	if s.Ref != "" {
+		return fmt.Errorf("%w: versions can only be pinned for Git registries; put the version in the URL instead", ErrInvalidSource)
+	}
+	parsed, err := url.Parse(s.URL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return fmt.Errorf("%w: %s is neither a Git repository nor an HTTPS URL", ErrInvalidSource, s.URL)
+	}
+	name := downloadName(s.URL)
+	if !strings.EqualFold(path.Ext(name), ".zip") && !pom.IsTemplateFile(name) {
+		return fmt.Errorf("%w: %s must end in .zip, .yaml, .yml or .xml", ErrInvalidSource, s.URL)
+	}
+	return nil
+}
+
+// downloadName returns the file name of a download URL
+func downloadName(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return path.Base(parsed.Path)
+}
+
+// IsGit reports whether the source is a Git repository rather than a
+// download: URLs ending in .git, SSH and git:// URLs, file:// URLs and
+// local paths
+func (s Source) IsGit() bool {
+	switch {
+	case strings.HasSuffix(strings.TrimSuffix(s.URL, "/"), ".git"),
+		strings.HasPrefix(s.URL, "git@"),
+		strings.HasPrefix(s.URL, "ssh://"),
+		strings.HasPrefix(s.URL, "git://"),
+		strings.HasPrefix(s.URL, "file://"):
+		return true
+	}
+	return !strings.Contains(s.URL, "://")
+}
+
+// State records the last update of a registry
+type State struct {
+	Source    Source    `yaml:"source"`
+	Revision  string    `yaml:"revision"` // Git commit, or SHA-256 of the download
+	Updated   time.Time `yaml:"updated"`
+	Templates []string  `yaml:"templates"`
+	Skipped   []string  `yaml:"skipped,omitempty"` // Template files that failed to load, with the reason
+}
+
+// ShortRevision abbreviates the revision for display
+func (s *State) ShortRevision() string {
+	if len(s.Revision) > 12 {
+		return s.Revision[:12]
+	}
+	return s.Revision
+}
+
+// Dir returns the directory holding the cached templates of a registry,
+// for the template manager
+func Dir(cacheDir string) string {
+	return filepath.Join(cacheDir, DirName)
+}
+
+// LoadState reads the state of the last update from cacheDir; nil when the
+// registry was never updated
+func LoadState(cacheDir string) (*State, error) {
+	data, err := os.ReadFile(filepath.Join(cacheDir, StateFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading template registry state: %w", err)
+	}
+
+	var state State
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("%w: parsing template registry state: %v", pom.ErrInvalidFormat, err)
+	}
+	return &state, nil
+}
+
+// save writes the state to cacheDir
+func (s *State) save(cacheDir string) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("encoding template registry state: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(cacheDir, StateFileName), data, 0644); err != nil {
+		return fmt.Errorf("writing template registry state: %w", err)
+	}
+	return nil
+}
+
+// Update fetches the templates of a registry into cacheDir, replacing those
+// of the previous update. Git registries are fetched with the git command,
+// others with client. On failure the previous templates are kept.
+func Update(ctx context.Context, cacheDir string, source Source, client *http.Client) (*State, error) {
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating cache directory: %w", err)
+	}
+	work, err := os.MkdirTemp(cacheDir, DirName+"-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating download directory: %w", err)
+	}
+	defer os.RemoveAll(work)
+
+	templates := filepath.Join(work, "templates")
+	if err := os.Mkdir(templates, 0755); err != nil {
+		return nil, fmt.Errorf("creating download directory: %w", err)
+	}
+	var revision string
+	if source.IsGit() {
+		revision, err = fetchGit(ctx, source, work, templates)
+	} else {
+		revision, err = download(ctx, source, client, templates)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := &State{Source: source, Revision: revision, Updated: time.Now()}
+	files, err := pom.TemplateFiles(templates)
+	if err != nil {
+		return nil, err
+	}
+	for name, file := range files {
+		if _, err := pom.LoadTemplateDefinition(file); err != nil {
+			// The download directory is gone after the update
+			reason := strings.ReplaceAll(err.Error(), templates+string(filepath.Separator), "")
+			state.Skipped = append(state.Skipped, fmt.Sprintf("%s: %s", name, reason))
+			os.Remove(file)
+			continue
+		}
+		state.Templates = append(state.Templates, name)
+	}
+	sort.Strings(state.Templates)
+	sort.Strings(state.Skipped)
+	if len(state.Templates) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoTemplates, source)
+	}
+
+	dir := Dir(cacheDir)
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("removing previous templates: %w", err)
+	}
+	if err := os.Rename(templates, dir); err != nil {
+		return nil, fmt.Errorf("storing templates: %w", err)
+	}
+	if err := state.save(cacheDir); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// fetchGit checks out the registry and copies the template files at its
+// top level, or in its templates directory when there are none, into dest
+func fetchGit(ctx context.Context, source Source, work, dest string) (string, error) {
+	checkout := filepath.Join(work, "checkout")
+	revision, err := git.Fetch(ctx, source.URL, source.Ref, checkout)
+	if err != nil {
+		return "", fmt.Errorf("fetching %s: %w", source, err)
+	}
+
+	files, err := pom.TemplateFiles(checkout)
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		if files, err = pom.TemplateFiles(filepath.Join(checkout, "templates")); err != nil {
+			return "", err
+		}
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", filepath.Base(file), err)
+		}
+		if err := os.WriteFile(filepath.Join(dest, filepath.Base(file)), data, 0644); err != nil {
+			return "", fmt.Errorf("storing %s: %w", filepath.Base(file), err)
+		}
+	}
+	return revision, nil
+}
+
+// download fetches a .zip archive of template files or a single template
+// file into dest. Returns the SHA-256 of the download.
+func download(ctx context.Context, source Source, client *http.Client, dest string) (string, error) {
+	name := downloadName(source.URL)
+	isArchive := strings.EqualFold(path.Ext(name), ".zip")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.URL, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidSource, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("downloading %s: %w", source.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("downloading %s: %s", source.URL, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return "", fmt.Errorf("downloading %s: %w", source.URL, err)
+	}
+	if len(data) > maxDownloadSize {
+		return "", fmt.Errorf("downloading %s: %w", source.URL, pom.ErrFileTooBig)
+	}
+	sum := sha256.Sum256(data)
+	revision := hex.EncodeToString(sum[:])
+
+	if !isArchive {
+		if err := os.WriteFile(filepath.Join(dest, name), data, 0644); err != nil {
+			return "", fmt.Errorf("storing %s: %w", name, err)
+		}
+		return revision, nil
+	}
+	if err := extractTemplates(data, dest); err != nil {
+		return "", fmt.Errorf("extracting %s: %w", source.URL, err)
+	}
+	return revision, nil
+}
+
+// extractTemplates writes the template files of a .zip archive into dest,
+// whatever directory they are in; with the same name in several
+// directories, the shallowest wins. Archives of Git hosts wrap the files in
+// a top-level directory, which this skips.
+func extractTemplates(data []byte, dest string) error {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	files := append([]*zip.File(nil), archive.File...)
+	sort.SliceStable(files, func(i, j int) bool {
+		return strings.Count(files[i].Name, "/") < strings.Count(files[j].Name, "/")
+	})
+	seen := make(map[string]bool)
+	for _, file := range files {
+		name := path.Base(file.Name)
+		if file.FileInfo().IsDir() || !pom.IsTemplateFile(name) || strings.HasPrefix(name, ".") || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		reader, err := file.Open()
+		if err != nil {
+			return err
+		}
+		content, err := io.ReadAll(io.LimitReader(reader, maxDownloadSize))
+		reader.Close()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dest, name), content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
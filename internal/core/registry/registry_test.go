@@ -0,0 +1,155 @@
+package registry
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+const stackTemplate = `name: stack
+description: Shared stack
+packaging: jar
+`
+
+func TestUpdateFromArchive(t *testing.T) {
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	for name, content := range map[string]string{
+		"templates-1.0/stack.yaml":        stackTemplate,
+		"templates-1.0/broken.yaml":       "dependencies: [",
+		"templates-1.0/README.md":         "# Templates",
+		"templates-1.0/nested/stack.yaml": "name: nested\n",
+	} {
+		file, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.Write([]byte(content))
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive.Bytes())
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	state, err := Update(context.Background(), cacheDir, Source{URL: server.URL + "/templates-1.0.zip"}, server.Client())
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if len(state.Templates) != 1 || state.Templates[0] != "stack" {
+		t.Errorf("Expected the stack template, got %v", state.Templates)
+	}
+	if len(state.Skipped) != 1 {
+		t.Errorf("Expected the broken template to be skipped, got %v", state.Skipped)
+	}
+	if len(state.Revision) != 64 {
+		t.Errorf("Expected a SHA-256 revision, got %q", state.Revision)
+	}
+	if data, _ := os.ReadFile(filepath.Join(Dir(cacheDir), "stack.yaml")); string(data) != stackTemplate {
+		t.Errorf("Expected the top-level stack.yaml to win, got %q", data)
+	}
+
+	loaded, err := LoadState(cacheDir)
+	if err != nil || loaded == nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if loaded.Source.URL != state.Source.URL || loaded.Revision != state.Revision {
+		t.Errorf("Expected the saved state, got %+v", loaded)
+	}
+}
+
+func TestUpdateKeepsTemplatesOnFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(stackTemplate))
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	if _, err := Update(context.Background(), cacheDir, Source{URL: server.URL + "/stack.yaml"}, server.Client()); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if _, err := Update(context.Background(), cacheDir, Source{URL: server.URL + "/missing.yaml"}, server.Client()); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+	if _, err := os.Stat(filepath.Join(Dir(cacheDir), "stack.yaml")); err != nil {
+		t.Errorf("Expected the previous templates to be kept: %v", err)
+	}
+
+	_, err := Update(context.Background(), cacheDir, Source{URL: server.URL + "/stack.yaml", Ref: "v1"}, server.Client())
+	if !errors.Is(err, ErrInvalidSource) {
+		t.Errorf("Expected ErrInvalidSource for a pinned download, got %v", err)
+	}
+}
+
+func TestUpdateFromGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	remote := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(remote, "templates"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(remote, "templates", "stack.yaml"), []byte(stackTemplate), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"commit", "--quiet", "-m", "Add stack"},
+		{"tag", "v1"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", remote}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v\n%s", args[0], err, out)
+		}
+	}
+
+	cacheDir := t.TempDir()
+	source := Source{URL: remote, Ref: "v1"}
+	if !source.IsGit() {
+		t.Fatal("Expected a local path to be a Git registry")
+	}
+	state, err := Update(context.Background(), cacheDir, source, nil)
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if len(state.Templates) != 1 || state.Templates[0] != "stack" {
+		t.Errorf("Expected the stack template from the templates directory, got %v", state.Templates)
+	}
+	if len(state.Revision) != 40 {
+		t.Errorf("Expected a commit revision, got %q", state.Revision)
+	}
+}
+
+func TestSourceIsGit(t *testing.T) {
+	for url, expected := range map[string]bool{
+		"https://github.com/acme/templates.git":             true,
+		"git@github.com:acme/templates.git":                 true,
+		"ssh://git.example.com/templates":                   true,
+		"/srv/templates":                                    true,
+		"https://example.com/templates-1.0.zip":             false,
+		"https://example.com/templates/raw/main/stack.yaml": false,
+	} {
+		if got := (Source{URL: url}).IsGit(); got != expected {
+			t.Errorf("Expected IsGit(%s) to be %v, got %v", url, expected, got)
+		}
+	}
+}
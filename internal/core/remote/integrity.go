@@ -0,0 +1,160 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrChecksumMismatch indicates a download that does not match the
+	// checksum the repository publishes next to it
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	// ErrNoChecksum indicates a download the repository publishes no
+	// checksum for, so it cannot be verified
+	ErrNoChecksum = errors.New("no checksum published")
+)
+
+// maxSidecarSize limits how much of a checksum or signature file is read
+const maxSidecarSize = 64 << 10
+
+// checksumFiles are the checksums looked for next to a download, strongest
+// first
+var checksumFiles = []struct {
+	extension string
+	name      string
+	hash      func() hash.Hash
+}{
+	{".sha256", "SHA-256", sha256.New},
+	{".sha1", "SHA-1", sha1.New},
+}
+
+// Failure is a download rejected because it failed verification, a
+// possible sign of tampering
+type Failure struct {
+	URL string
+	Err error // Wraps ErrChecksumMismatch, ErrNoChecksum, ErrBadSignature or ErrUnknownKey
+}
+
+// Message describes the failure for display
+func (f Failure) Message() string {
+	return fmt.Sprintf("%s: %v", f.URL, f.Err)
+}
+
+// Verifier checks downloads against the checksums repositories publish next
+// to them and, with a keyring, against their PGP signatures. A download
+// failing either is not used and is reported to OnFailure.
+type Verifier struct {
+	// Keyring holds the keys trusted to sign artifacts; nil skips signatures
+	Keyring *Keyring
+	// Strict rejects downloads without a published checksum, instead of
+	// reporting them to OnWarning
+	Strict bool
+	// OnFailure is called for each download failing verification; it may be
+	// nil, and may be called from any goroutine
+	OnFailure func(failure Failure)
+	// OnWarning is called for each download accepted without a checksum to
+	// verify it against; it may be nil, and may be called from any goroutine
+	OnWarning func(warning Failure)
+}
+
+// Check verifies data downloaded from url with client. The SHA-256 checksum
+// is preferred to the SHA-1 one. A download without either fails with
+// ErrNoChecksum when v is strict, and is otherwise accepted and reported to
+// OnWarning, as some repositories publish none for metadata. When signed
+// and v has a keyring, url.asc must be a valid signature by one of its
+// keys. v may be nil, to check checksums only.
+func (v *Verifier) Check(ctx context.Context, client *http.Client, url string, data []byte, signed bool) error {
+	err := verifyChecksum(ctx, client, url, data)
+	if errors.Is(err, ErrNoChecksum) && (v == nil || !v.Strict) {
+		if v != nil && v.OnWarning != nil {
+			v.OnWarning(Failure{URL: url, Err: err})
+		}
+		err = nil
+	}
+	if err == nil && signed && v != nil && v.Keyring != nil {
+		err = v.verifySignature(ctx, client, url, data)
+	}
+	if err != nil && v != nil && v.OnFailure != nil && isVerificationFailure(err) {
+		v.OnFailure(Failure{URL: url, Err: err})
+	}
+	return err
+}
+
+// verifyChecksum compares data to the strongest checksum published for url,
+// or returns ErrNoChecksum when none is
+func verifyChecksum(ctx context.Context, client *http.Client, url string, data []byte) error {
+	for _, checksum := range checksumFiles {
+		published, err := fetchSidecar(ctx, client, url+checksum.extension)
+		if err != nil {
+			return err
+		}
+		if published == nil {
+			continue
+		}
+
+		// Checksum files may be followed by the file name
+		fields := strings.Fields(string(published))
+		h := checksum.hash()
+		h.Write(data)
+		if actual := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(fields[0], actual) {
+			return fmt.Errorf("%w: %s is %s, repository publishes %s", ErrChecksumMismatch, checksum.name, actual, fields[0])
+		}
+		return nil
+	}
+	return ErrNoChecksum
+}
+
+// verifySignature checks the detached signature published as url.asc
+func (v *Verifier) verifySignature(ctx context.Context, client *http.Client, url string, data []byte) error {
+	signature, err := fetchSidecar(ctx, client, url+".asc")
+	if err != nil {
+		return err
+	}
+	if signature == nil {
+		return fmt.Errorf("%w: no signature published", ErrBadSignature)
+	}
+	_, err = v.Keyring.Verify(data, signature)
+	return err
+}
+
+// fetchSidecar downloads a checksum or signature file; nil without error
+// when the repository has none or it is empty
+func fetchSidecar(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSidecarSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", url, err)
+	}
+	return bytes.TrimSpace(data), nil
+}
+
+// isVerificationFailure reports whether err means a download failed
+// verification, rather than could not be verified for lack of network
+func isVerificationFailure(err error) bool {
+	return errors.Is(err, ErrChecksumMismatch) || errors.Is(err, ErrNoChecksum) ||
+		errors.Is(err, ErrBadSignature) || errors.Is(err, ErrUnknownKey)
+}
@@ -0,0 +1,117 @@
+package remote
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func hexSum(data []byte, sha256sum bool) string {
+	if sha256sum {
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:])
+	}
+	sum := sha1.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestVerifierCheck(t *testing.T) {
+	pom := []byte(testPOM)
+	files := map[string]string{
+		"/sha1.pom":        testPOM,
+		"/sha1.pom.sha1":   hexSum(pom, false) + "  sha1.pom\n",
+		"/both.pom":        testPOM,
+		"/both.pom.sha256": hexSum(pom, true),
+		"/both.pom.sha1":   "0000000000000000000000000000000000000000",
+		"/bad.pom.sha1":    "0000000000000000000000000000000000000000",
+		"/signed.pom.sha1": hexSum(pom, false),
+		"/signed.pom.asc":  testEd25519Signature,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if content, ok := files[r.URL.Path]; ok {
+			w.Write([]byte(content))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(server.Close)
+	keyring, err := ParseKeyring([]byte(testEd25519Key))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var failures, warnings []Failure
+	verifier := &Verifier{
+		Keyring:   keyring,
+		OnFailure: func(failure Failure) { failures = append(failures, failure) },
+		OnWarning: func(warning Failure) { warnings = append(warnings, warning) },
+	}
+	ctx := context.Background()
+	client := server.Client()
+
+	tests := []struct {
+		path   string
+		signed bool
+		err    error
+	}{
+		{"/sha1.pom", false, nil},
+		{"/both.pom", false, nil}, // SHA-256 is preferred
+		{"/none.pom", false, nil}, // No checksum published, only a warning
+		{"/bad.pom", false, ErrChecksumMismatch},
+		{"/signed.pom", true, nil},
+		{"/sha1.pom", true, ErrBadSignature}, // No signature published
+	}
+	for _, tt := range tests {
+		err := verifier.Check(ctx, client, server.URL+tt.path, pom, tt.signed)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: expected %v, got %v", tt.path, tt.err, err)
+		}
+	}
+	if len(failures) != 2 || failures[0].URL != server.URL+"/bad.pom" {
+		t.Errorf("Expected the 2 failures to be reported, got %+v", failures)
+	}
+	if len(warnings) != 1 || warnings[0].URL != server.URL+"/none.pom" || !errors.Is(warnings[0].Err, ErrNoChecksum) {
+		t.Errorf("Expected the missing checksum to be reported as a warning, got %+v", warnings)
+	}
+
+	verifier.Strict = true
+	failures = nil
+	if err := verifier.Check(ctx, client, server.URL+"/none.pom", pom, false); !errors.Is(err, ErrNoChecksum) {
+		t.Errorf("Expected a strict verifier to reject a download without checksum, got %v", err)
+	}
+	if len(failures) != 1 || len(warnings) != 1 {
+		t.Errorf("Expected the strict rejection to be reported as a failure, got %+v", failures)
+	}
+
+	var none *Verifier
+	if err := none.Check(ctx, client, server.URL+"/bad.pom", pom, true); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Expected checksums to be checked without a verifier, got %v", err)
+	}
+}
+
+func TestClientRejectsTamperedMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/org/junit/jupiter/junit-jupiter/maven-metadata.xml":
+			w.Write([]byte(junitMetadata))
+		case "/org/junit/jupiter/junit-jupiter/maven-metadata.xml.sha1":
+			w.Write([]byte("0000000000000000000000000000000000000000"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	reported := false
+	verifier := &Verifier{OnFailure: func(Failure) { reported = true }}
+	_, err := NewClient([]string{server.URL}, &http.Client{Timeout: 5 * time.Second}, nil, verifier).Metadata(context.Background(), "org.junit.jupiter", "junit-jupiter")
+	if !errors.Is(err, ErrChecksumMismatch) || !reported {
+		t.Errorf("Expected the metadata to be rejected and reported, got %v", err)
+	}
+}
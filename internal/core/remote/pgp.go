@@ -0,0 +1,141 @@
+package remote
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	pgperrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+)
+
+var (
+	// ErrBadSignature indicates a PGP signature that does not match the
+	// signed file, or that is not acceptable, such as one made with SHA-1
+	// or by an expired or revoked key
+	ErrBadSignature = errors.New("bad PGP signature")
+	// ErrUnknownKey indicates a PGP signature by a key not in the keyring
+	ErrUnknownKey = errors.New("signed by an untrusted key")
+)
+
+// armorHeader starts each block of an ASCII-armored file
+var armorHeader = []byte("-----BEGIN PGP ")
+
+// rejectedHashes are hashes too weak to trust a signature made with
+var rejectedHashes = map[crypto.Hash]bool{
+	crypto.MD5:       true,
+	crypto.SHA1:      true,
+	crypto.RIPEMD160: true,
+}
+
+// Keyring holds the public keys trusted to sign artifacts, as exported with
+// gpg --export. Subkeys are only used with a valid binding signature by
+// their primary key, and signatures by expired or revoked keys are
+// rejected.
+type Keyring struct {
+	entities openpgp.EntityList
+}
+
+// LoadKeyring reads a keyring file, ASCII-armored or binary
+func LoadKeyring(path string) (*Keyring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading keyring: %w", err)
+	}
+	keyring, err := ParseKeyring(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return keyring, nil
+}
+
+// ParseKeyring reads public keys, ASCII-armored or binary. Keys that are
+// unsupported or not validly self-signed are skipped; having none left is
+// an error.
+func ParseKeyring(data []byte) (*Keyring, error) {
+	keyring := &Keyring{}
+	var skipped error
+	for _, block := range armoredBlocks(data) {
+		var entities openpgp.EntityList
+		var err error
+		if bytes.HasPrefix(block, armorHeader) {
+			entities, err = openpgp.ReadArmoredKeyRing(bytes.NewReader(block))
+		} else {
+			entities, err = openpgp.ReadKeyRing(bytes.NewReader(block))
+		}
+		if err != nil {
+			skipped = err
+			continue
+		}
+		keyring.entities = append(keyring.entities, entities...)
+	}
+	if len(keyring.entities) == 0 {
+		if skipped != nil {
+			return nil, fmt.Errorf("no usable public keys found: %w", skipped)
+		}
+		return nil, fmt.Errorf("no usable public keys found")
+	}
+	return keyring, nil
+}
+
+// Len returns the number of keys, subkeys included
+func (k *Keyring) Len() int {
+	n := 0
+	for _, entity := range k.entities {
+		n += 1 + len(entity.Subkeys)
+	}
+	return n
+}
+
+// Verify checks a detached signature of data, ASCII-armored or binary.
+// Returns the ID of the key that made it, ErrUnknownKey when no key of the
+// keyring did, or ErrBadSignature when the signature does not match or is
+// not acceptable.
+func (k *Keyring) Verify(data, signature []byte) (string, error) {
+	signature = bytes.TrimSpace(signature)
+	var packets io.Reader = bytes.NewReader(signature)
+	if bytes.HasPrefix(signature, armorHeader) {
+		block, err := armor.Decode(packets)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrBadSignature, err)
+		}
+		packets = block.Body
+	}
+
+	sig, _, err := openpgp.VerifyDetachedSignature(k.entities, bytes.NewReader(data), packets, nil)
+	switch {
+	case errors.Is(err, pgperrors.ErrUnknownIssuer):
+		return "", ErrUnknownKey
+	case sig != nil && rejectedHashes[sig.Hash]:
+		return "", fmt.Errorf("%w: made with %s, which is not accepted", ErrBadSignature, sig.Hash)
+	case err != nil:
+		return "", fmt.Errorf("%w: %v", ErrBadSignature, err)
+	}
+	return fmt.Sprintf("%016X", *sig.IssuerKeyId), nil
+}
+
+// armoredBlocks splits data at the start of each ASCII-armored block, or
+// returns it whole when it is binary
+func armoredBlocks(data []byte) [][]byte {
+	data = bytes.TrimSpace(data)
+	if !bytes.HasPrefix(data, armorHeader) {
+		return [][]byte{data}
+	}
+
+	var blocks [][]byte
+	for len(data) > 0 {
+		next := bytes.Index(data[len(armorHeader):], armorHeader)
+		if next < 0 {
+			blocks = append(blocks, data)
+			break
+		}
+		next += len(armorHeader)
+		blocks = append(blocks, data[:next])
+		data = bytes.TrimSpace(data[next:])
+	}
+	return blocks
+}
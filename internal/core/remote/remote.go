@@ -0,0 +1,190 @@
+// Package remote looks up artifacts in Maven repositories over HTTP, using
+// the maven-metadata.xml every repository publishes next to an artifact's
+// versions.
+package remote
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// MavenCentral is the repository used when none are configured
+const MavenCentral = "https://repo.maven.apache.org/maven2"
+
+// ErrNotFound indicates that no repository has the artifact
+var ErrNotFound = errors.New("artifact not found")
+
+// maxMetadataSize limits how much of a metadata response is read
+const maxMetadataSize = 4 << 20
+
+// Metadata lists the published versions of an artifact
+type Metadata struct {
+	GroupID    string   `xml:"groupId"`
+	ArtifactID string   `xml:"artifactId"`
+	Latest     string   `xml:"versioning>latest"`
+	Release    string   `xml:"versioning>release"`
+	Versions   []string `xml:"versioning>versions>version"`
+
+	// Repository is the URL of the repository the metadata came from
+	Repository string `xml:"-"`
+}
+
+// HasVersion reports whether a version is published
+func (m *Metadata) HasVersion(version string) bool {
+	for _, v := range m.Versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// LatestRelease returns the newest release, falling back to the newest
+// version of any kind
+func (m *Metadata) LatestRelease() string {
+	if m.Release != "" {
+		return m.Release
+	}
+	if m.Latest != "" {
+		return m.Latest
+	}
+	if len(m.Versions) > 0 {
+		return m.Versions[len(m.Versions)-1]
+	}
+	return ""
+}
+
+// preRelease matches the qualifiers of alpha, beta, milestone, release
+// candidate, early access and snapshot versions
+var preRelease = regexp.MustCompile(`(?i)(alpha|beta|milestone|preview|snapshot|[.-](rc|cr|m|ea)[.-]?\d*$)`)
+
+// IsPreRelease reports whether a version carries a pre-release qualifier,
+// such as 4.0.0-beta-1, 2.0-M3 or 1.0-SNAPSHOT
+func IsPreRelease(version string) bool {
+	return preRelease.MatchString(version)
+}
+
+// LatestStable returns the newest version without a pre-release qualifier.
+// Repositories set <release> to whatever was deployed last, which can be a
+// beta of the next major version. Falls back to LatestRelease.
+func (m *Metadata) LatestStable() string {
+	for i := len(m.Versions) - 1; i >= 0; i-- {
+		if !IsPreRelease(m.Versions[i]) {
+			return m.Versions[i]
+		}
+	}
+	return m.LatestRelease()
+}
+
+// Client fetches artifact metadata from remote repositories
+type Client interface {
+	// Metadata returns the metadata of the first repository that has the
+	// artifact, or ErrNotFound when none has it
+	Metadata(ctx context.Context, groupID, artifactID string) (*Metadata, error)
+}
+
+// httpClient implements Client over HTTP
+type httpClient struct {
+	repositories []string
+	settings     *Settings
+	verifier     *Verifier
+	http         *http.Client
+}
+
+// NewClient creates a client for the given repository URLs, tried in order.
+// Without repositories, Maven Central is used. Repositories are reached
+// with client, typically from settings.HTTPClient, at the mirrors settings
+// give for them; settings may be nil. Metadata is checked against its
+// checksums by verifier, which may be nil too.
+func NewClient(repositories []string, client *http.Client, settings *Settings, verifier *Verifier) Client {
+	if len(repositories) == 0 {
+		repositories = []string{MavenCentral}
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &httpClient{
+		repositories: repositories,
+		settings:     settings,
+		verifier:     verifier,
+		http:         client,
+	}
+}
+
+// MetadataURL returns the URL of an artifact's maven-metadata.xml
+func MetadataURL(repository, groupID, artifactID string) string {
+	return fmt.Sprintf("%s/%s/%s/maven-metadata.xml",
+		strings.TrimSuffix(repository, "/"), strings.ReplaceAll(groupID, ".", "/"), artifactID)
+}
+
+// Metadata queries each repository in turn. A repository that cannot be
+// reached does not hide the artifact in a later one; its error is only
+// returned when no repository has the artifact.
+func (c *httpClient) Metadata(ctx context.Context, groupID, artifactID string) (*Metadata, error) {
+	if groupID == "" || artifactID == "" {
+		return nil, fmt.Errorf("groupId and artifactId are required")
+	}
+
+	var firstErr error
+	for _, repository := range c.repositories {
+		metadata, err := c.fetch(ctx, repository, groupID, artifactID)
+		if err == nil {
+			return metadata, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if !errors.Is(err, ErrNotFound) && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return nil, fmt.Errorf("%w: %s:%s", ErrNotFound, groupID, artifactID)
+}
+
+// fetch reads the metadata of an artifact from one repository, or from the
+// mirror serving it
+func (c *httpClient) fetch(ctx context.Context, repository, groupID, artifactID string) (*Metadata, error) {
+	target := c.settings.Route(RepositoryID(repository), repository).Target()
+	url := MetadataURL(target, groupID, artifactID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository URL %s: %w", target, err)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("querying %s: %w", target, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("querying %s: %s", target, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading metadata from %s: %w", target, err)
+	}
+	if err := c.verifier.Check(ctx, c.http, url, data, false); err != nil {
+		return nil, fmt.Errorf("verifying metadata from %s: %w", target, err)
+	}
+
+	var metadata Metadata
+	if err := xml.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("parsing metadata from %s: %w", target, err)
+	}
+	metadata.Repository = repository
+	return &metadata, nil
+}
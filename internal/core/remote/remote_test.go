@@ -0,0 +1,116 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const junitMetadata = `<?xml version="1.0" encoding="UTF-8"?>
+<metadata>
+  <groupId>org.junit.jupiter</groupId>
+  <artifactId>junit-jupiter</artifactId>
+  <versioning>
+    <latest>5.10.1</latest>
+    <release>5.10.1</release>
+    <versions>
+      <version>5.9.3</version>
+      <version>5.10.0</version>
+      <version>5.10.1</version>
+    </versions>
+  </versioning>
+</metadata>`
+
+func newTestServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/org/junit/jupiter/junit-jupiter/maven-metadata.xml" {
+			w.Write([]byte(junitMetadata))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMetadata(t *testing.T) {
+	server := newTestServer(t)
+	empty := httptest.NewServer(http.NotFoundHandler())
+	defer empty.Close()
+
+	// The second repository has the artifact
+	client := NewClient([]string{empty.URL, server.URL + "/"}, &http.Client{Timeout: 5 * time.Second}, nil, nil)
+	metadata, err := client.Metadata(context.Background(), "org.junit.jupiter", "junit-jupiter")
+	if err != nil {
+		t.Fatalf("Metadata failed: %v", err)
+	}
+	if len(metadata.Versions) != 3 || metadata.LatestRelease() != "5.10.1" {
+		t.Errorf("Expected 3 versions with latest 5.10.1, got %v (%s)", metadata.Versions, metadata.LatestRelease())
+	}
+	if metadata.Repository != server.URL+"/" {
+		t.Errorf("Expected repository %s, got %s", server.URL+"/", metadata.Repository)
+	}
+
+	_, err = client.Metadata(context.Background(), "org.junit.jupiter", "junit-jupyter")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	client := NewClient([]string{newTestServer(t).URL}, &http.Client{Timeout: 5 * time.Second}, nil, nil)
+
+	tests := []struct {
+		name        string
+		artifactID  string
+		version     string
+		ok          bool
+		suggestions []string
+	}{
+		{"published version", "junit-jupiter", "5.10.0", true, nil},
+		{"no version", "junit-jupiter", "", true, nil},
+		{"property reference", "junit-jupiter", "${junit.version}", true, nil},
+		{"version typo", "junit-jupiter", "5.10.O", false, []string{"5.10.0", "5.10.1"}},
+		{"artifact typo", "junit-jupyter", "5.10.0", false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verification, err := Verify(context.Background(), client, "org.junit.jupiter", tt.artifactID, tt.version)
+			if err != nil {
+				t.Fatalf("Verify failed: %v", err)
+			}
+			if verification.OK() != tt.ok {
+				t.Errorf("Expected OK() = %v, got %v (%s)", tt.ok, verification.OK(), verification.Message())
+			}
+			if strings.Join(verification.Suggestions, ",") != strings.Join(tt.suggestions, ",") {
+				t.Errorf("Expected suggestions %v, got %v", tt.suggestions, verification.Suggestions)
+			}
+		})
+	}
+}
+
+func TestLatestStable(t *testing.T) {
+	metadata := &Metadata{
+		Release:  "4.0.0-beta-1",
+		Versions: []string{"3.11.0", "3.12.1", "4.0.0-M1", "3.13.0", "4.0.0-beta-1"},
+	}
+	if got := metadata.LatestStable(); got != "3.13.0" {
+		t.Errorf("Expected 3.13.0, got %s", got)
+	}
+
+	for _, version := range []string{"1.0-SNAPSHOT", "5.10.0-RC1", "2.0.0.M3", "6.0.0-alpha", "21-ea"} {
+		if !IsPreRelease(version) {
+			t.Errorf("Expected %s to be a pre-release", version)
+		}
+	}
+	for _, version := range []string{"3.11.0", "31.1-jre", "5.6.15.Final", "2.0.9"} {
+		if IsPreRelease(version) {
+			t.Errorf("Expected %s to be a release", version)
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package remote
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/user/pom-manager/internal/core/httpcache"
+)
+
+// CentralID is the ID Maven gives Maven Central, which mirrors refer to
+const CentralID = "central"
+
+// Settings is what lookups use of a Maven settings.xml: the mirrors serving
+// repositories, the proxies reaching them and the credentials of servers
+type Settings struct {
+	LocalRepository string   `xml:"localRepository"`
+	Offline         bool     `xml:"offline"`
+	Mirrors         []Mirror `xml:"mirrors>mirror"`
+	Proxies         []Proxy  `xml:"proxies>proxy"`
+	Servers         []Server `xml:"servers>server"`
+
+	// Path is the file the settings were read from
+	Path string `xml:"-"`
+}
+
+// Mirror serves the repositories its MirrorOf pattern matches in their
+// place, such as "*", "central" or "external:*,!internal"
+type Mirror struct {
+	ID       string `xml:"id"`
+	Name     string `xml:"name"`
+	URL      string `xml:"url"`
+	MirrorOf string `xml:"mirrorOf"`
+}
+
+// Proxy is an HTTP proxy that repositories are reached through
+type Proxy struct {
+	ID            string `xml:"id"`
+	Active        string `xml:"active"` // "false" disables it
+	Protocol      string `xml:"protocol"`
+	Host          string `xml:"host"`
+	Port          int    `xml:"port"`
+	Username      string `xml:"username"`
+	Password      string `xml:"password"`
+	NonProxyHosts string `xml:"nonProxyHosts"` // Hosts reached directly, separated by |, with * wildcards
+}
+
+// Server holds the credentials of the repository or mirror with its ID
+type Server struct {
+	ID       string `xml:"id"`
+	Username string `xml:"username"`
+	Password string `xml:"password"`
+}
+
+// Route is how a repository is reached with the settings
+type Route struct {
+	ID  string // Repository ID, CentralID for Maven Central
+	URL string // Repository URL
+
+	Mirror *Mirror // Mirror serving the repository, nil when it is reached directly
+	Proxy  *Proxy  // Proxy the requests go through, nil for none
+	Server *Server // Credentials of the repository or mirror, nil for none
+}
+
+// DefaultSettingsPath returns the user's settings.xml (~/.m2/settings.xml)
+func DefaultSettingsPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".m2", "settings.xml")
+}
+
+// settingsExpression matches the ${env.NAME} and ${user.home} expressions
+// Maven expands in settings.xml
+var settingsExpression = regexp.MustCompile(`\$\{(env\.[A-Za-z0-9_]+|user\.home)\}`)
+
+// LoadSettings reads a settings.xml. Returns nil without error when the
+// file does not exist, as lookups then go directly to the repositories.
+func LoadSettings(path string) (*Settings, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	// Maven expands expressions before parsing
+	data = settingsExpression.ReplaceAllFunc(data, func(match []byte) []byte {
+		name := string(match[2 : len(match)-1])
+		if name == "user.home" {
+			home, _ := os.UserHomeDir()
+			return []byte(home)
+		}
+		return []byte(os.Getenv(strings.TrimPrefix(name, "env.")))
+	})
+
+	var settings Settings
+	if err := xml.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	settings.Path = path
+	return &settings, nil
+}
+
+// RepositoryID returns the ID of a repository known only by its URL:
+// CentralID for Maven Central, else the URL itself, which only wildcard
+// mirrors match
+func RepositoryID(repository string) string {
+	if strings.TrimSuffix(repository, "/") == MavenCentral {
+		return CentralID
+	}
+	return repository
+}
+
+// Route returns how the repository with id and URL is reached. s may be
+// nil, for no settings.xml.
+func (s *Settings) Route(id, repository string) Route {
+	route := Route{ID: id, URL: repository}
+	if s == nil {
+		return route
+	}
+
+	route.Mirror = s.mirrorFor(id, repository)
+	serverID := id
+	if route.Mirror != nil {
+		serverID = route.Mirror.ID
+	}
+	if parsed, err := url.Parse(route.Target()); err == nil {
+		route.Proxy = s.proxyFor(parsed)
+	}
+	for i := range s.Servers {
+		if s.Servers[i].ID == serverID {
+			route.Server = &s.Servers[i]
+			break
+		}
+	}
+	return route
+}
+
+// Target returns the URL requests go to: the mirror's or the repository's
+func (r Route) Target() string {
+	if r.Mirror != nil {
+		return r.Mirror.URL
+	}
+	return r.URL
+}
+
+// HTTPClient returns a client that sends requests through the proxy and
+// with the credentials the settings give for their URL, answering them from
+// cache when it is not nil. s may be nil.
+func (s *Settings) HTTPClient(timeout time.Duration, cache *httpcache.Cache) *http.Client {
+	var transport http.RoundTripper = http.DefaultTransport
+	if s != nil {
+		proxied := http.DefaultTransport.(*http.Transport).Clone()
+		proxied.Proxy = func(req *http.Request) (*url.URL, error) {
+			proxy := s.proxyFor(req.URL)
+			if proxy == nil {
+				return nil, nil
+			}
+			return proxy.url(), nil
+		}
+		transport = &authTransport{settings: s, next: proxied}
+	}
+	if cache != nil {
+		transport = cache.Transport(transport)
+	}
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// Encrypted reports whether the password is encrypted with Maven's master
+// password, which lookups cannot decrypt and so do not send
+func (s Server) Encrypted() bool {
+	return strings.HasPrefix(s.Password, "{") && strings.HasSuffix(s.Password, "}")
+}
+
+// IsActive reports whether the proxy is used; proxies are active unless
+// they say otherwise
+func (p Proxy) IsActive() bool {
+	return !strings.EqualFold(strings.TrimSpace(p.Active), "false")
+}
+
+// Address returns the proxy's host:port
+func (p Proxy) Address() string {
+	port := p.Port
+	if port == 0 {
+		port = 8080
+	}
+	return net.JoinHostPort(p.Host, strconv.Itoa(port))
+}
+
+// url returns the proxy URL with its credentials
+func (p Proxy) url() *url.URL {
+	proxyURL := &url.URL{Scheme: "http", Host: p.Address()}
+	if p.Username != "" {
+		proxyURL.User = url.UserPassword(p.Username, p.Password)
+	}
+	return proxyURL
+}
+
+// bypasses reports whether host is one of the proxy's nonProxyHosts
+func (p Proxy) bypasses(host string) bool {
+	for _, pattern := range strings.FieldsFunc(p.NonProxyHosts, func(r rune) bool { return r == '|' || r == ',' }) {
+		pattern = strings.TrimSpace(pattern)
+		if matched, _ := filepath.Match(strings.ToLower(pattern), strings.ToLower(host)); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// mirrorFor returns the mirror serving a repository, as Maven picks it: a
+// mirror of exactly the repository's ID first, then the first mirror whose
+// pattern matches
+func (s *Settings) mirrorFor(id, repository string) *Mirror {
+	for i := range s.Mirrors {
+		if s.Mirrors[i].MirrorOf == id {
+			return &s.Mirrors[i]
+		}
+	}
+	for i := range s.Mirrors {
+		if mirrorMatches(s.Mirrors[i].MirrorOf, id, repository) {
+			return &s.Mirrors[i]
+		}
+	}
+	return nil
+}
+
+// mirrorMatches reports whether a mirrorOf pattern matches a repository.
+// The pattern lists IDs, "*", "external:*" (any repository not on this
+// machine) and "external:http:*" (such as over plain HTTP), separated by
+// commas; "!id" excludes a repository.
+func mirrorMatches(mirrorOf, id, repository string) bool {
+	matched := false
+	for _, pattern := range strings.Split(mirrorOf, ",") {
+		pattern = strings.TrimSpace(pattern)
+		switch {
+		case strings.HasPrefix(pattern, "!"):
+			if pattern[1:] == id {
+				return false
+			}
+		case pattern == "*", pattern == id:
+			matched = true
+		case pattern == "external:*":
+			matched = matched || isExternal(repository)
+		case pattern == "external:http:*":
+			matched = matched || (isExternal(repository) && strings.HasPrefix(repository, "http:"))
+		}
+	}
+	return matched
+}
+
+// isExternal reports whether a repository is on another machine
+func isExternal(repository string) bool {
+	parsed, err := url.Parse(repository)
+	if err != nil || parsed.Scheme == "file" {
+		return false
+	}
+	host := parsed.Hostname()
+	return host != "localhost" && host != "127.0.0.1" && host != "::1"
+}
+
+// proxyFor returns the first active proxy for a URL's protocol that does
+// not bypass its host; HTTP proxies serve HTTPS too
+func (s *Settings) proxyFor(target *url.URL) *Proxy {
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return nil
+	}
+	for i := range s.Proxies {
+		proxy := &s.Proxies[i]
+		if !proxy.IsActive() || proxy.Host == "" {
+			continue
+		}
+		if protocol := strings.ToLower(proxy.Protocol); protocol != "" && protocol != "http" && protocol != target.Scheme {
+			continue
+		}
+		if proxy.bypasses(target.Hostname()) {
+			return nil
+		}
+		return proxy
+	}
+	return nil
+}
+
+// authTransport adds the credentials of the server whose mirror serves a
+// request's URL, or of Maven Central
+type authTransport struct {
+	settings *Settings
+	next     http.RoundTripper
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, _, ok := req.BasicAuth(); ok {
+		return t.next.RoundTrip(req)
+	}
+
+	serverID := ""
+	target := req.URL.String()
+	for _, mirror := range t.settings.Mirrors {
+		if mirror.URL != "" && strings.HasPrefix(target, strings.TrimSuffix(mirror.URL, "/")+"/") {
+			serverID = mirror.ID
+			break
+		}
+	}
+	if serverID == "" && strings.HasPrefix(target, MavenCentral+"/") {
+		serverID = CentralID
+	}
+	for _, server := range t.settings.Servers {
+		if server.ID == serverID && server.Username != "" && !server.Encrypted() {
+			// RoundTrippers must not change the caller's request
+			req = req.Clone(req.Context())
+			req.SetBasicAuth(server.Username, server.Password)
+			break
+		}
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,149 @@
+package remote
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeSettings(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "settings.xml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadSettings(t *testing.T) {
+	settings, err := LoadSettings(filepath.Join(t.TempDir(), "settings.xml"))
+	if err != nil || settings != nil {
+		t.Errorf("Expected no settings and no error for a missing file, got %v, %v", settings, err)
+	}
+
+	t.Setenv("NEXUS_PASSWORD", "s3cret")
+	path := writeSettings(t, `<settings>
+  <localRepository>/data/m2</localRepository>
+  <servers>
+    <server><id>nexus</id><username>deploy</username><password>${env.NEXUS_PASSWORD}</password></server>
+  </servers>
+  <mirrors>
+    <mirror><id>nexus</id><url>https://nexus.example.com/maven-public</url><mirrorOf>*</mirrorOf></mirror>
+  </mirrors>
+  <proxies>
+    <proxy><id>corp</id><host>proxy.example.com</host><port>3128</port></proxy>
+  </proxies>
+</settings>`)
+	settings, err = LoadSettings(path)
+	if err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	if settings.Path != path || settings.LocalRepository != "/data/m2" {
+		t.Errorf("Expected path and local repository, got %q, %q", settings.Path, settings.LocalRepository)
+	}
+	if len(settings.Servers) != 1 || settings.Servers[0].Password != "s3cret" {
+		t.Errorf("Expected ${env.NEXUS_PASSWORD} to be expanded, got %+v", settings.Servers)
+	}
+	if !settings.Proxies[0].IsActive() || settings.Proxies[0].Address() != "proxy.example.com:3128" {
+		t.Errorf("Expected an active proxy at proxy.example.com:3128, got %+v", settings.Proxies[0])
+	}
+
+	if _, err := LoadSettings(writeSettings(t, "<settings><mirrors>")); err == nil {
+		t.Errorf("Expected an error for malformed settings")
+	}
+}
+
+func TestSettingsRoute(t *testing.T) {
+	settings := &Settings{
+		Mirrors: []Mirror{
+			{ID: "all", URL: "https://all.example.com", MirrorOf: "external:*,!internal"},
+			{ID: "central-mirror", URL: "https://central.example.com", MirrorOf: "central"},
+		},
+		Proxies: []Proxy{
+			{ID: "off", Active: "false", Host: "off.example.com"},
+			{ID: "corp", Host: "proxy.example.com", NonProxyHosts: "localhost|*.internal.example.com"},
+		},
+		Servers: []Server{{ID: "all", Username: "reader", Password: "{encrypted}"}},
+	}
+
+	tests := []struct {
+		id, url string
+		mirror  string
+		proxy   bool
+	}{
+		// An exact match wins over the wildcard listed first
+		{CentralID, MavenCentral, "central-mirror", true},
+		{"https://repo.example.com", "https://repo.example.com", "all", true},
+		{"internal", "https://maven.internal.example.com", "", false},
+		{"local", "http://localhost:8081/repo", "", false},
+	}
+	for _, tt := range tests {
+		route := settings.Route(tt.id, tt.url)
+		mirror := ""
+		if route.Mirror != nil {
+			mirror = route.Mirror.ID
+		}
+		if mirror != tt.mirror {
+			t.Errorf("%s: expected mirror %q, got %q", tt.id, tt.mirror, mirror)
+		}
+		if (route.Proxy != nil) != tt.proxy || (route.Proxy != nil && route.Proxy.ID != "corp") {
+			t.Errorf("%s: expected proxy %v, got %+v", tt.id, tt.proxy, route.Proxy)
+		}
+	}
+
+	route := settings.Route("https://repo.example.com", "https://repo.example.com")
+	if route.Target() != "https://all.example.com" {
+		t.Errorf("Expected requests to go to the mirror, got %s", route.Target())
+	}
+	if route.Server == nil || !route.Server.Encrypted() {
+		t.Errorf("Expected the mirror's encrypted credentials, got %+v", route.Server)
+	}
+
+	var none *Settings
+	if route := none.Route(CentralID, MavenCentral); route.Target() != MavenCentral || route.Mirror != nil {
+		t.Errorf("Expected direct access without settings, got %+v", route)
+	}
+}
+
+func TestClientUsesMirror(t *testing.T) {
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "reader" || password != "pw" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/public/org/junit/jupiter/junit-jupiter/maven-metadata.xml" {
+			w.Write([]byte(junitMetadata))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(mirror.Close)
+
+	settings := &Settings{
+		Mirrors: []Mirror{{ID: "nexus", URL: mirror.URL + "/public", MirrorOf: "*"}},
+		Servers: []Server{{ID: "nexus", Username: "reader", Password: "pw"}},
+	}
+	metadata, err := NewClient(nil, settings.HTTPClient(5*time.Second, nil), settings, nil).Metadata(context.Background(), "org.junit.jupiter", "junit-jupiter")
+	if err != nil {
+		t.Fatalf("Expected Maven Central to be served by the mirror, got %v", err)
+	}
+	if metadata.Repository != MavenCentral || metadata.LatestRelease() != "5.10.1" {
+		t.Errorf("Unexpected metadata: %+v", metadata)
+	}
+}
+
+func TestHTTPClientProxy(t *testing.T) {
+	settings := &Settings{Proxies: []Proxy{{Host: "proxy.example.com", Port: 3128, Username: "me", Password: "pw"}}}
+	transport := settings.HTTPClient(time.Second, nil).Transport.(*authTransport).next.(*http.Transport)
+
+	target, _ := url.Parse("https://repo.example.com/maven2")
+	proxyURL, err := transport.Proxy(&http.Request{URL: target})
+	if err != nil || proxyURL == nil || proxyURL.Host != "proxy.example.com:3128" || proxyURL.User.Username() != "me" {
+		t.Errorf("Expected the proxy with credentials, got %v (%v)", proxyURL, err)
+	}
+}
@@ -0,0 +1,138 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxSuggestionDistance is the largest edit distance at which a published
+// version is offered as a correction
+const maxSuggestionDistance = 2
+
+// Verification is the outcome of checking a dependency against the
+// repositories
+type Verification struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+
+	// ArtifactFound is false when no repository has the artifact
+	ArtifactFound bool
+	// VersionFound is false when the artifact exists but not in this version;
+	// it is true when no version was given
+	VersionFound bool
+	// Suggestions are published versions close to a version that was not found
+	Suggestions []string
+
+	Metadata *Metadata
+}
+
+// Verify checks that an artifact, and the version when one is given, is
+// published. Versions that are property references or ranges are not
+// checked. Errors other than the artifact missing are returned as is.
+func Verify(ctx context.Context, client Client, groupID, artifactID, version string) (*Verification, error) {
+	verification := &Verification{GroupID: groupID, ArtifactID: artifactID, Version: version}
+
+	metadata, err := client.Metadata(ctx, groupID, artifactID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return verification, nil
+		}
+		return nil, err
+	}
+	verification.ArtifactFound = true
+	verification.Metadata = metadata
+
+	if !Checkable(version) || metadata.HasVersion(version) {
+		verification.VersionFound = true
+		return verification, nil
+	}
+	verification.Suggestions = closestVersions(version, metadata.Versions)
+	return verification, nil
+}
+
+// OK reports whether the dependency resolves
+func (v *Verification) OK() bool {
+	return v.ArtifactFound && v.VersionFound
+}
+
+// Message describes the outcome for display
+func (v *Verification) Message() string {
+	coords := v.GroupID + ":" + v.ArtifactID
+	switch {
+	case !v.ArtifactFound:
+		return fmt.Sprintf("%s was not found in the configured repositories; check the groupId and artifactId for typos", coords)
+	case !v.VersionFound:
+		message := fmt.Sprintf("Version %s of %s was not found", v.Version, coords)
+		if len(v.Suggestions) > 0 {
+			message += fmt.Sprintf("; did you mean %s?", strings.Join(v.Suggestions, " or "))
+		}
+		if latest := v.Metadata.LatestRelease(); latest != "" {
+			message += fmt.Sprintf(" (latest: %s)", latest)
+		}
+		return message
+	case !Checkable(v.Version):
+		if latest := v.Metadata.LatestRelease(); latest != "" {
+			return fmt.Sprintf("%s found (latest: %s)", coords, latest)
+		}
+		return coords + " found"
+	}
+	return fmt.Sprintf("%s:%s found in %s", coords, v.Version, v.Metadata.Repository)
+}
+
+// Checkable reports whether a version is a plain version that can be looked
+// up, rather than empty, a ${property} reference, or a range
+func Checkable(version string) bool {
+	return version != "" && !strings.Contains(version, "${") && !strings.ContainsAny(version, "[](),")
+}
+
+// closestVersions returns the published versions within
+// maxSuggestionDistance edits of version, closest first
+func closestVersions(version string, versions []string) []string {
+	type candidate struct {
+		version  string
+		distance int
+	}
+	var candidates []candidate
+	for _, v := range versions {
+		if d := editDistance(version, v); d <= maxSuggestionDistance {
+			candidates = append(candidates, candidate{v, d})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	suggestions := make([]string, 0, 3)
+	for _, c := range candidates {
+		if len(suggestions) == cap(suggestions) {
+			break
+		}
+		suggestions = append(suggestions, c.version)
+	}
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
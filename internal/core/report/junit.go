@@ -0,0 +1,131 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// junitSuiteName names the test suite, and the class of every test case
+const junitSuiteName = "pom-manager.validate"
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	SystemOut *junitOutput  `xml:"system-out,omitempty"`
+}
+
+// junitOutput is text kept as CDATA, so its lines stay readable
+type junitOutput struct {
+	Text string `xml:",cdata"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+// JUnit returns the findings as JUnit XML, with one test case per POM, so
+// CI servers such as Jenkins and GitLab show failing POMs as failed tests.
+// Findings of passing POMs, such as warnings, go to the case's output.
+func JUnit(files []File, timestamp time.Time) ([]byte, error) {
+	suite := junitTestSuite{
+		Name:      junitSuiteName,
+		Tests:     len(files),
+		Timestamp: timestamp.Format("2006-01-02T15:04:05"),
+	}
+	var total time.Duration
+	for _, file := range files {
+		total += file.Duration
+		testCase := junitTestCase{
+			ClassName: junitSuiteName,
+			Name:      file.Path,
+			Time:      seconds(file.Duration),
+		}
+
+		var lines []string
+		for _, finding := range file.Findings {
+			lines = append(lines, describe(finding))
+		}
+		text := strings.Join(lines, "\n")
+
+		switch {
+		case file.Err != nil && len(file.Findings) == 0:
+			suite.Errors++
+			testCase.Error = &junitProblem{Message: file.Err.Error(), Type: "parse-error", Text: file.Err.Error()}
+		case file.Failed:
+			suite.Failures++
+			testCase.Failure = &junitProblem{
+				Message: fmt.Sprintf("%d finding(s) fail validation", failingCount(file)),
+				Type:    "validation",
+				Text:    text,
+			}
+		case text != "":
+			testCase.SystemOut = &junitOutput{Text: text}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	suite.Time = seconds(total)
+
+	suites := junitTestSuites{
+		Name:     junitSuiteName,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// failingCount counts the findings of a failed file that are not notes;
+// warnings only fail in strict mode, in which case there are no errors
+func failingCount(file File) int {
+	errors, warnings := 0, 0
+	for _, finding := range file.Findings {
+		switch severityName(finding.Severity) {
+		case "error":
+			errors++
+		case "warning":
+			warnings++
+		}
+	}
+	if errors > 0 {
+		return errors
+	}
+	return warnings
+}
+
+// seconds formats a duration as JUnit does, in seconds
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
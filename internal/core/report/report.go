@@ -0,0 +1,55 @@
+// Package report writes validation findings in the formats CI systems read:
+// SARIF for code scanning and JUnit XML for test result views.
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// Report formats
+const (
+	FormatText  = "text"
+	FormatSARIF = "sarif"
+	FormatJUnit = "junit"
+)
+
+// Formats lists the supported report formats
+var Formats = []string{FormatText, FormatSARIF, FormatJUnit}
+
+// ToolName identifies pom-manager in reports
+const ToolName = "pom-manager"
+
+// File is the validation outcome of one POM
+type File struct {
+	Path     string
+	Findings []pom.ValidationError
+	Failed   bool  // Validation failed, e.g. on errors or on warnings in strict mode
+	Err      error // The file could not be read or parsed
+	Duration time.Duration
+}
+
+// severityName returns the lowercase name of a severity
+func severityName(severity pom.Severity) string {
+	switch severity {
+	case pom.SeverityWarning:
+		return "warning"
+	case pom.SeverityInfo:
+		return "info"
+	}
+	return "error"
+}
+
+// describe formats a finding on one line, as the text output does
+func describe(finding pom.ValidationError) string {
+	text := fmt.Sprintf("%s: %s", severityName(finding.Severity), finding.Error())
+	if finding.Position.IsKnown() {
+		text = finding.Position.String() + ": " + text
+	}
+	if finding.Rule != "" {
+		text += " [" + finding.Rule + "]"
+	}
+	return text
+}
@@ -0,0 +1,105 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// testFiles are a failing, a passing and an unreadable POM
+func testFiles() []File {
+	return []File{
+		{
+			Path: "core/pom.xml",
+			Findings: []pom.ValidationError{{
+				Field: "packaging", Value: "war", Message: "projects declaring <modules> must use 'pom' packaging",
+				Rule: pom.RuleAggregatorPackaging, Position: pom.Position{Line: 8, Column: 5},
+			}},
+			Failed:   true,
+			Err:      errors.New("validation failed"),
+			Duration: 12 * time.Millisecond,
+		},
+		{
+			Path: "web/pom.xml",
+			Findings: []pom.ValidationError{{
+				Field: "groupId", Value: "Com.Example", Message: "groupId should be lowercase",
+				Severity: pom.SeverityWarning, Rule: pom.RuleGroupIDFormat,
+			}},
+		},
+		{Path: "broken/pom.xml", Err: errors.New("line 3: unexpected EOF")},
+	}
+}
+
+func TestSARIF(t *testing.T) {
+	data, err := SARIF(testFiles(), "1.2.3")
+	if err != nil {
+		t.Fatalf("SARIF failed: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("Expected valid JSON: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("Expected one SARIF 2.1.0 run, got %+v", log)
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != ToolName || run.Tool.Driver.Version != "1.2.3" {
+		t.Errorf("Expected the tool to be named, got %+v", run.Tool.Driver)
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(run.Results))
+	}
+
+	first := run.Results[0]
+	if first.RuleID != pom.RuleAggregatorPackaging || first.Level != "error" {
+		t.Errorf("Expected an aggregator-packaging error, got %+v", first)
+	}
+	region := first.Locations[0].PhysicalLocation.Region
+	if region == nil || region.StartLine != 8 || region.StartColumn != 5 {
+		t.Errorf("Expected the finding's position, got %+v", region)
+	}
+	if uri := first.Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "core/pom.xml" {
+		t.Errorf("Expected a relative URI, got %q", uri)
+	}
+	if run.Results[1].Level != "warning" {
+		t.Errorf("Expected a warning, got %q", run.Results[1].Level)
+	}
+	if run.Results[2].RuleID != ruleParseError {
+		t.Errorf("Expected a parse error, got %q", run.Results[2].RuleID)
+	}
+}
+
+func TestJUnit(t *testing.T) {
+	data, err := JUnit(testFiles(), time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("JUnit failed: %v", err)
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		t.Fatalf("Expected valid XML: %v", err)
+	}
+	if suites.Tests != 3 || suites.Failures != 1 || suites.Errors != 1 {
+		t.Errorf("Expected 3 tests, 1 failure and 1 error, got %d, %d and %d", suites.Tests, suites.Failures, suites.Errors)
+	}
+
+	cases := suites.Suites[0].Cases
+	if cases[0].Failure == nil || !strings.Contains(cases[0].Failure.Text, "8:5: error:") {
+		t.Errorf("Expected the failing POM's findings, got %+v", cases[0].Failure)
+	}
+	if cases[0].Time != "0.012" {
+		t.Errorf("Expected the time in seconds, got %q", cases[0].Time)
+	}
+	if cases[1].Failure != nil || cases[1].SystemOut == nil || !strings.Contains(cases[1].SystemOut.Text, "warning:") {
+		t.Errorf("Expected the passing POM's warning in its output, got %+v", cases[1])
+	}
+	if cases[2].Error == nil {
+		t.Error("Expected the unreadable POM to be an error")
+	}
+}
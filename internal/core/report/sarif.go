@@ -0,0 +1,145 @@
+package report
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// sarifSchema is the schema of SARIF 2.1.0, the version code scanning
+// services such as GitHub accept
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// ruleParseError reports POMs that could not be read or parsed
+const ruleParseError = "parse-error"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Rules   []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// SARIF returns the findings as a SARIF 2.1.0 log for code scanning upload.
+// Every validation rule is listed, so services can show rule descriptions.
+func SARIF(files []File, toolVersion string) ([]byte, error) {
+	driver := sarifDriver{Name: ToolName, Version: toolVersion}
+	for _, rule := range pom.RuleRegistry {
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   rule.ID,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Default)},
+		})
+	}
+	driver.Rules = append(driver.Rules, sarifRule{
+		ID:                   ruleParseError,
+		ShortDescription:     sarifMessage{Text: "the POM is well-formed XML with a <project> root"},
+		DefaultConfiguration: sarifConfiguration{Level: "error"},
+	})
+
+	results := []sarifResult{}
+	for _, file := range files {
+		location := sarifArtifactLocation{URI: filepath.ToSlash(file.Path)}
+		if filepath.IsAbs(file.Path) {
+			location.URI = "file://" + location.URI
+		} else {
+			location.URIBaseID = "%SRCROOT%"
+		}
+
+		if file.Err != nil && len(file.Findings) == 0 {
+			results = append(results, sarifResult{
+				RuleID:    ruleParseError,
+				Level:     "error",
+				Message:   sarifMessage{Text: file.Err.Error()},
+				Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: location}}},
+			})
+			continue
+		}
+
+		for _, finding := range file.Findings {
+			physical := sarifPhysicalLocation{ArtifactLocation: location}
+			if finding.Position.IsKnown() {
+				physical.Region = &sarifRegion{StartLine: finding.Position.Line, StartColumn: finding.Position.Column}
+			}
+			results = append(results, sarifResult{
+				RuleID:    finding.Rule,
+				Level:     sarifLevel(finding.Severity),
+				Message:   sarifMessage{Text: finding.Error()},
+				Locations: []sarifLocation{{PhysicalLocation: physical}},
+			})
+		}
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+	return json.MarshalIndent(log, "", "  ")
+}
+
+// sarifLevel maps a severity to a SARIF result level
+func sarifLevel(severity pom.Severity) string {
+	switch severity {
+	case pom.SeverityWarning:
+		return "warning"
+	case pom.SeverityInfo:
+		return "note"
+	}
+	return "error"
+}
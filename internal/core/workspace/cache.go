@@ -0,0 +1,127 @@
+package workspace
+
+import (
+	"container/list"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// DefaultCacheSize is how many parsed POMs a workspace keeps in memory.
+// Large monorepos have hundreds of modules, so projects are parsed on first
+// access and the least recently used are dropped.
+const DefaultCacheSize = 64
+
+// cachedProject is a parsed POM together with the file state it was parsed
+// from, so edits on disk are noticed
+type cachedProject struct {
+	path    string
+	project *pom.Project
+	modTime time.Time
+	size    int64
+}
+
+// projectCache is an LRU cache of parsed POMs keyed by path
+type projectCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List               // Most recently used first
+	entries map[string]*list.Element // Path -> element holding a *cachedProject
+	parser  pom.Parser
+}
+
+func newProjectCache(size int) *projectCache {
+	return &projectCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+		parser:  pom.NewParser(),
+	}
+}
+
+// get returns the project parsed from path, parsing it when it is not
+// cached or changed on disk since
+func (c *projectCache) get(path string) (*pom.Project, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	c.mu.Lock()
+	if elem, ok := c.entries[path]; ok {
+		cached := elem.Value.(*cachedProject)
+		if cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+			c.order.MoveToFront(elem)
+			c.mu.Unlock()
+			return cached.project, nil
+		}
+	}
+	c.mu.Unlock()
+
+	// Parse without holding the lock, so lookups of other files go on
+	project, err := c.parser.ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached := &cachedProject{path: path, project: project, modTime: info.ModTime(), size: info.Size()}
+	if elem, ok := c.entries[path]; ok {
+		elem.Value = cached
+		c.order.MoveToFront(elem)
+	} else {
+		c.entries[path] = c.order.PushFront(cached)
+	}
+	c.trim()
+	return project, nil
+}
+
+// resize changes how many projects are kept
+func (c *projectCache) resize(size int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.size = size
+	c.trim()
+}
+
+// trim drops the least recently used projects beyond the cache size
+func (c *projectCache) trim() {
+	for c.order.Len() > max(c.size, 0) {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cachedProject).path)
+	}
+}
+
+// len returns the number of cached projects
+func (c *projectCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Project returns the parsed POM at path, parsing it on first access. At
+// most the cache size of projects stay in memory; others are parsed again
+// when needed, as are files changed on disk. The project is shared, so
+// callers must Clone it before modifying it.
+func (w *Workspace) Project(path string) (*pom.Project, error) {
+	return w.projects().get(path)
+}
+
+// SetCacheSize changes how many parsed POMs are kept in memory (default
+// DefaultCacheSize)
+func (w *Workspace) SetCacheSize(size int) {
+	w.projects().resize(size)
+}
+
+// projects returns the workspace's project cache, creating it on first use
+func (w *Workspace) projects() *projectCache {
+	w.cacheOnce.Do(func() {
+		w.cache = newProjectCache(DefaultCacheSize)
+	})
+	return w.cache
+}
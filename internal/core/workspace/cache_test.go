@@ -0,0 +1,55 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestProjectCache(t *testing.T) {
+	root := t.TempDir()
+	for _, module := range []string{"a", "b", "c"} {
+		writePOM(t, filepath.Join(root, module, POMFileName), module)
+	}
+	ws, err := Open(root)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	ws.SetCacheSize(2)
+
+	first, err := ws.Project(ws.POMs[0])
+	if err != nil {
+		t.Fatalf("Project failed: %v", err)
+	}
+	again, _ := ws.Project(ws.POMs[0])
+	if first != again {
+		t.Error("Expected the cached project on second access")
+	}
+
+	// Only the two most recently used projects stay
+	ws.Project(ws.POMs[1])
+	ws.Project(ws.POMs[2])
+	if got := ws.projects().len(); got != 2 {
+		t.Errorf("Expected 2 cached projects, got %d", got)
+	}
+	if reparsed, _ := ws.Project(ws.POMs[0]); reparsed == first {
+		t.Error("Expected the evicted project to be parsed again")
+	}
+
+	// Files changed on disk are parsed again
+	path := ws.POMs[2]
+	cached, _ := ws.Project(path)
+	writePOM(t, path, "renamed")
+	later := time.Now().Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	changed, err := ws.Project(path)
+	if err != nil {
+		t.Fatalf("Project failed: %v", err)
+	}
+	if changed == cached || changed.ArtifactID != "renamed" {
+		t.Errorf("Expected the changed POM, got %s", changed.ArtifactID)
+	}
+}
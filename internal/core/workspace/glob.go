@@ -0,0 +1,104 @@
+package workspace
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Glob returns the files matching a pattern, sorted. Besides the syntax of
+// filepath.Match, "**" matches any number of directories, so "**/pom.xml"
+// finds every POM below the current directory. Build output and VCS
+// directories are not searched. A pattern without wildcards is returned as
+// is, whether or not the file exists.
+func Glob(pattern string) ([]string, error) {
+	if !hasMeta(pattern) {
+		return []string{pattern}, nil
+	}
+
+	segments := strings.Split(filepath.ToSlash(pattern), "/")
+	for _, segment := range segments {
+		if _, err := path.Match(segment, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	// Walk from the longest directory prefix without wildcards
+	base := 0
+	for base < len(segments)-1 && !hasMeta(segments[base]) {
+		base++
+	}
+	root := strings.Join(segments[:base], "/")
+	switch {
+	case root == "" && base > 0:
+		root = "/" // Absolute pattern
+	case root == "":
+		root = "."
+	}
+	rest := segments[base:]
+
+	var matches []string
+	err := filepath.WalkDir(filepath.FromSlash(root), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if d != nil && d.IsDir() && p != root {
+				return filepath.SkipDir
+			}
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			if skippedDirs[d.Name()] && p != filepath.FromSlash(root) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(filepath.FromSlash(root), p)
+		if err != nil {
+			return nil
+		}
+		if matchSegments(rest, strings.Split(filepath.ToSlash(rel), "/")) {
+			matches = append(matches, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("expanding %q: %w", pattern, err)
+	}
+
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// matchSegments matches path segments against pattern segments, where a
+// "**" segment matches zero or more path segments
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
+
+// hasMeta reports whether a pattern contains wildcards
+func hasMeta(pattern string) bool {
+	return strings.ContainsAny(pattern, `*?[`)
+}
@@ -0,0 +1,48 @@
+package workspace
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGlob(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"", "core", "services/api", "services/api/target", "docs"} {
+		writePOM(t, filepath.Join(root, dir, POMFileName), "app")
+	}
+
+	tests := []struct {
+		pattern  string
+		expected []string
+	}{
+		{"**/pom.xml", []string{"core/pom.xml", "docs/pom.xml", "pom.xml", "services/api/pom.xml"}},
+		{"services/**/pom.xml", []string{"services/api/pom.xml"}},
+		{"*/pom.xml", []string{"core/pom.xml", "docs/pom.xml"}},
+		{"c*/pom.xml", []string{"core/pom.xml"}},
+	}
+	for _, tt := range tests {
+		matches, err := Glob(filepath.Join(root, tt.pattern))
+		if err != nil {
+			t.Fatalf("Glob(%q) failed: %v", tt.pattern, err)
+		}
+		var got []string
+		for _, match := range matches {
+			rel, _ := filepath.Rel(root, match)
+			got = append(got, filepath.ToSlash(rel))
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Glob(%q): expected %v, got %v", tt.pattern, tt.expected, got)
+		}
+	}
+
+	// Plain paths are kept even when missing, so callers report them
+	missing := filepath.Join(root, "missing", POMFileName)
+	if matches, err := Glob(missing); err != nil || len(matches) != 1 || matches[0] != missing {
+		t.Errorf("Expected plain path to be returned as is, got %v, %v", matches, err)
+	}
+
+	if _, err := Glob("[/pom.xml"); err == nil {
+		t.Error("Expected an error for a malformed pattern")
+	}
+}
@@ -0,0 +1,154 @@
+package workspace
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// IndexEntry is a POM file and the coordinates it builds
+type IndexEntry struct {
+	Path        string
+	Coordinates pom.Coordinates // groupId and version may come from the parent
+}
+
+// Index maps coordinates to the workspace POMs building them, for
+// navigation. It fills in the background; lookups return what is indexed
+// so far.
+type Index struct {
+	mu      sync.RWMutex
+	byKey   map[string][]string // groupId:artifactId -> paths
+	byPath  map[string]pom.Coordinates
+	indexed int
+	total   int
+	done    chan struct{}
+}
+
+// Index returns the workspace's coordinate index, starting to build it in
+// the background on first call. Only coordinates are kept, so indexing a
+// large workspace does not hold every parsed POM in memory.
+func (w *Workspace) Index() *Index {
+	w.indexOnce.Do(func() {
+		w.index = &Index{
+			byKey:  make(map[string][]string),
+			byPath: make(map[string]pom.Coordinates),
+			total:  len(w.POMs),
+			done:   make(chan struct{}),
+		}
+		go w.index.build(append([]string(nil), w.POMs...))
+	})
+	return w.index
+}
+
+// build parses the POMs one by one; files that fail to parse are skipped
+func (i *Index) build(paths []string) {
+	defer close(i.done)
+	parser := pom.NewParser()
+	for _, path := range paths {
+		project, err := parser.ParseFile(path)
+		i.mu.Lock()
+		if err == nil {
+			i.add(path, EffectiveCoordinates(project))
+		}
+		i.indexed++
+		i.mu.Unlock()
+	}
+}
+
+// add records a file's coordinates; the caller holds the lock
+func (i *Index) add(path string, coords pom.Coordinates) {
+	i.remove(path)
+	key := coords.GroupID + ":" + coords.ArtifactID
+	i.byKey[key] = append(i.byKey[key], path)
+	sort.Strings(i.byKey[key])
+	i.byPath[path] = coords
+}
+
+// remove forgets a file; the caller holds the lock
+func (i *Index) remove(path string) {
+	old, ok := i.byPath[path]
+	if !ok {
+		return
+	}
+	key := old.GroupID + ":" + old.ArtifactID
+	paths := i.byKey[key]
+	for j, p := range paths {
+		if p == path {
+			paths = append(paths[:j], paths[j+1:]...)
+			break
+		}
+	}
+	if len(paths) == 0 {
+		delete(i.byKey, key)
+	} else {
+		i.byKey[key] = paths
+	}
+	delete(i.byPath, path)
+}
+
+// Wait blocks until the index is complete or ctx is done
+func (i *Index) Wait(ctx context.Context) error {
+	select {
+	case <-i.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Progress returns how many of the workspace's POMs have been indexed
+func (i *Index) Progress() (indexed, total int) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.indexed, i.total
+}
+
+// Find returns the POMs building groupId:artifactId, usually one
+func (i *Index) Find(groupID, artifactID string) []string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return append([]string(nil), i.byKey[groupID+":"+artifactID]...)
+}
+
+// Entries returns every indexed POM, ordered by groupId:artifactId
+func (i *Index) Entries() []IndexEntry {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	entries := make([]IndexEntry, 0, len(i.byPath))
+	for path, coords := range i.byPath {
+		entries = append(entries, IndexEntry{Path: path, Coordinates: coords})
+	}
+	sort.Slice(entries, func(a, b int) bool {
+		ka := entries[a].Coordinates.GroupID + ":" + entries[a].Coordinates.ArtifactID
+		kb := entries[b].Coordinates.GroupID + ":" + entries[b].Coordinates.ArtifactID
+		if ka != kb {
+			return ka < kb
+		}
+		return entries[a].Path < entries[b].Path
+	})
+	return entries
+}
+
+// Update re-indexes a POM after it was saved, e.g. with new coordinates
+func (i *Index) Update(path string, project *pom.Project) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.add(path, EffectiveCoordinates(project))
+}
+
+// EffectiveCoordinates returns a project's coordinates, taking the groupId
+// and version from the parent when the project does not declare them
+func EffectiveCoordinates(project *pom.Project) pom.Coordinates {
+	coords := pom.Coordinates{GroupID: project.GroupID, ArtifactID: project.ArtifactID, Version: project.Version}
+	if project.Parent != nil {
+		if coords.GroupID == "" {
+			coords.GroupID = project.Parent.GroupID
+		}
+		if coords.Version == "" {
+			coords.Version = project.Parent.Version
+		}
+	}
+	return coords
+}
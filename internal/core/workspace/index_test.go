@@ -0,0 +1,60 @@
+package workspace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIndex(t *testing.T) {
+	root := t.TempDir()
+	writePOM(t, filepath.Join(root, "core", POMFileName), "core")
+	writePOM(t, filepath.Join(root, "web", POMFileName), "web")
+	// A module inheriting its groupId and version
+	child := filepath.Join(root, "child", POMFileName)
+	if err := os.MkdirAll(filepath.Dir(child), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(child, []byte(`<project><modelVersion>4.0.0</modelVersion>
+<parent><groupId>com.example</groupId><artifactId>core</artifactId><version>1.0.0</version></parent>
+<artifactId>child</artifactId></project>`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Broken POMs are skipped
+	if err := os.WriteFile(filepath.Join(root, POMFileName), []byte("<project"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := Open(root)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	index := ws.Index()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := index.Wait(ctx); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+
+	if indexed, total := index.Progress(); indexed != 4 || total != 4 {
+		t.Errorf("Expected 4 of 4 POMs indexed, got %d of %d", indexed, total)
+	}
+	if paths := index.Find("com.example", "child"); len(paths) != 1 || paths[0] != child {
+		t.Errorf("Expected %s for the inheriting module, got %v", child, paths)
+	}
+	entries := index.Entries()
+	if len(entries) != 3 || entries[0].Coordinates.ArtifactID != "child" || entries[0].Coordinates.Version != "1.0.0" {
+		t.Errorf("Expected 3 entries starting with child 1.0.0, got %v", entries)
+	}
+
+	// Saving new coordinates moves the file in the index
+	project, _ := ws.Project(child)
+	renamed := project.Clone()
+	renamed.ArtifactID = "renamed"
+	index.Update(child, renamed)
+	if len(index.Find("com.example", "child")) != 0 || len(index.Find("com.example", "renamed")) != 1 {
+		t.Error("Expected the update to replace the old coordinates")
+	}
+}
@@ -0,0 +1,110 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// ModuleOptions describes a new child module of an aggregator POM
+type ModuleOptions struct {
+	AggregatorPath string   // Path of the aggregator pom.xml
+	Module         string   // Module path relative to the aggregator directory
+	ArtifactID     string   // Optional; defaults to the last segment of Module
+	Template       string   // Template for the module POM
+	Dependents     []string // POM paths of sibling modules that should depend on the new module
+}
+
+// ModuleResult reports the files written by CreateModule
+type ModuleResult struct {
+	ModulePath       string // Path of the new module's pom.xml
+	PackagingChanged bool   // Aggregator packaging was switched to "pom"
+	Dependents       []string
+}
+
+// CreateModule creates a module directory with a POM whose <parent> points at
+// the aggregator, registers it in the aggregator's <modules>, and adds it as
+// a dependency of the selected sibling modules.
+func CreateModule(opts ModuleOptions) (*ModuleResult, error) {
+	parser := pom.NewParser()
+	generator := pom.NewGenerator()
+
+	aggregator, err := parser.ParseFile(opts.AggregatorPath)
+	if err != nil {
+		return nil, fmt.Errorf("parsing aggregator POM: %w", err)
+	}
+
+	packagingChanged, err := pom.AddModule(aggregator, opts.Module)
+	if err != nil {
+		return nil, err
+	}
+
+	modulePath := filepath.Join(filepath.Dir(opts.AggregatorPath), filepath.FromSlash(opts.Module), POMFileName)
+	if _, err := os.Stat(modulePath); err == nil {
+		return nil, fmt.Errorf("module POM already exists: %s", modulePath)
+	}
+
+	child, err := pom.NewChildProject(aggregator, opts.Module, pom.NewTemplateManager(), opts.Template)
+	if err != nil {
+		return nil, fmt.Errorf("creating module POM: %w", err)
+	}
+	if opts.ArtifactID != "" {
+		child.ArtifactID = opts.ArtifactID
+		child.Coordinates.ArtifactID = opts.ArtifactID
+	}
+
+	// Parse all dependents up front so a bad sibling aborts before anything is written
+	dependents := make([]*pom.Project, len(opts.Dependents))
+	for i, path := range opts.Dependents {
+		dependents[i], err = parser.ParseFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(modulePath), 0755); err != nil {
+		return nil, fmt.Errorf("creating module directory: %w", err)
+	}
+	if err := generator.GenerateToFile(child, modulePath); err != nil {
+		return nil, fmt.Errorf("writing module POM: %w", err)
+	}
+	if err := generator.GenerateToFile(aggregator, opts.AggregatorPath); err != nil {
+		return nil, fmt.Errorf("writing aggregator POM: %w", err)
+	}
+
+	result := &ModuleResult{
+		ModulePath:       modulePath,
+		PackagingChanged: packagingChanged,
+	}
+
+	// Siblings share the aggregator's version, so reference it via ${project.version}
+	for i, dependent := range dependents {
+		if dependsOn(dependent, child.GroupID, child.ArtifactID) {
+			continue
+		}
+		dependent.Dependencies = append(dependent.Dependencies, pom.Dependency{
+			GroupID:    child.GroupID,
+			ArtifactID: child.ArtifactID,
+			Version:    "${project.version}",
+			Scope:      pom.DefaultScope,
+		})
+		if err := generator.GenerateToFile(dependent, opts.Dependents[i]); err != nil {
+			return result, fmt.Errorf("writing %s: %w", opts.Dependents[i], err)
+		}
+		result.Dependents = append(result.Dependents, opts.Dependents[i])
+	}
+
+	return result, nil
+}
+
+// dependsOn reports whether the project already declares the dependency
+func dependsOn(project *pom.Project, groupID, artifactID string) bool {
+	for _, dep := range project.Dependencies {
+		if dep.GroupID == groupID && dep.ArtifactID == artifactID {
+			return true
+		}
+	}
+	return false
+}
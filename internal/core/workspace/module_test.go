@@ -0,0 +1,73 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+func TestCreateModule(t *testing.T) {
+	root := t.TempDir()
+	aggregatorPath := filepath.Join(root, "pom.xml")
+	corePath := filepath.Join(root, "core", "pom.xml")
+	writePOM(t, aggregatorPath, "parent")
+	writePOM(t, corePath, "core")
+
+	result, err := CreateModule(ModuleOptions{
+		AggregatorPath: aggregatorPath,
+		Module:         "api",
+		Template:       "basic-java",
+		Dependents:     []string{corePath},
+	})
+	if err != nil {
+		t.Fatalf("CreateModule failed: %v", err)
+	}
+	if !result.PackagingChanged {
+		t.Errorf("Expected aggregator packaging to change to pom")
+	}
+
+	parser := pom.NewParser()
+
+	aggregator, err := parser.ParseFile(aggregatorPath)
+	if err != nil {
+		t.Fatalf("Failed to parse aggregator: %v", err)
+	}
+	if !pom.HasModule(aggregator, "api") {
+		t.Errorf("Expected aggregator to declare module 'api', got %v", aggregator.Modules)
+	}
+
+	module, err := parser.ParseFile(filepath.Join(root, "api", "pom.xml"))
+	if err != nil {
+		t.Fatalf("Failed to parse module POM: %v", err)
+	}
+	if module.Parent == nil || module.Parent.ArtifactID != "parent" {
+		t.Errorf("Expected module parent to be 'parent', got %+v", module.Parent)
+	}
+	if module.ArtifactID != "api" {
+		t.Errorf("Expected artifactId 'api', got '%s'", module.ArtifactID)
+	}
+
+	core, err := parser.ParseFile(corePath)
+	if err != nil {
+		t.Fatalf("Failed to parse dependent: %v", err)
+	}
+	found := false
+	for _, dep := range core.Dependencies {
+		if dep.ArtifactID == "api" && dep.Version == "${project.version}" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected core to depend on api, got %+v", core.Dependencies)
+	}
+
+	// The same module cannot be created twice
+	if _, err := CreateModule(ModuleOptions{AggregatorPath: aggregatorPath, Module: "api", Template: "basic-java"}); err == nil {
+		t.Errorf("Expected error when creating an existing module")
+	}
+	if _, err := os.Stat(filepath.Join(root, "api", "pom.xml")); err != nil {
+		t.Errorf("Expected module POM to remain: %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// FileChange is the new content of one POM written by a refactoring
+type FileChange struct {
+	Path    string
+	Before  []byte // Content on disk when the plan was made
+	After   []byte
+	Summary string // One-line description of the change
+}
+
+// RefactorPlan is a set of POM rewrites applied together
+type RefactorPlan struct {
+	Title   string
+	Changes []FileChange
+}
+
+// Apply writes every change of the plan. If any write fails, files already
+// written are restored to their previous content.
+func (p *RefactorPlan) Apply() error {
+	for i, change := range p.Changes {
+		if err := writeFileAtomic(change.Path, change.After); err != nil {
+			// Roll back what was written so far
+			for _, written := range p.Changes[:i] {
+				_ = writeFileAtomic(written.Path, written.Before)
+			}
+			return fmt.Errorf("writing %s: %w", change.Path, err)
+		}
+	}
+	return nil
+}
+
+// PlanMoveToParent prepares moving a dependency declared by several modules
+// into the parent's dependencyManagement: the parent gets a managed entry
+// and the modules inheriting from it drop their explicit versions.
+//
+// The managed version is the one most modules use (the highest on a tie).
+func PlanMoveToParent(parentPath, groupID, artifactID string) (*RefactorPlan, error) {
+	parser := pom.NewParser()
+	generator := pom.NewGenerator()
+
+	parentData, err := os.ReadFile(parentPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading parent POM: %w", err)
+	}
+	parent, err := parser.Parse(parentData)
+	if err != nil {
+		return nil, fmt.Errorf("parsing parent POM: %w", err)
+	}
+
+	// Collect the modules that inherit from the parent and declare the dependency
+	type usage struct {
+		path    string
+		data    []byte
+		project *pom.Project
+		index   int
+	}
+	var usages []usage
+	for _, module := range parent.Modules {
+		modulePath := filepath.Join(filepath.Dir(parentPath), filepath.FromSlash(module), POMFileName)
+		data, err := os.ReadFile(modulePath)
+		if err != nil {
+			continue
+		}
+		child, err := parser.Parse(data)
+		if err != nil || !isParentOf(parent, child) {
+			continue
+		}
+		for i, dep := range child.Dependencies {
+			if dep.GroupID == groupID && dep.ArtifactID == artifactID {
+				usages = append(usages, usage{path: modulePath, data: data, project: child, index: i})
+				break
+			}
+		}
+	}
+	if len(usages) == 0 {
+		return nil, fmt.Errorf("%w: no module inheriting from %s declares %s:%s",
+			pom.ErrDependencyNotFound, parent.ArtifactID, groupID, artifactID)
+	}
+
+	// Managed version: existing entry in the parent, else the most common one
+	managedIndex := -1
+	for i, dep := range parent.DependencyManagement {
+		if dep.GroupID == groupID && dep.ArtifactID == artifactID {
+			managedIndex = i
+		}
+	}
+	versions := make([]string, 0, len(usages))
+	for _, u := range usages {
+		if v := u.project.Dependencies[u.index].Version; v != "" {
+			versions = append(versions, v)
+		}
+	}
+	version := preferredVersion(versions)
+	if managedIndex >= 0 && parent.DependencyManagement[managedIndex].Version != "" {
+		version = parent.DependencyManagement[managedIndex].Version
+	}
+	if version == "" {
+		return nil, fmt.Errorf("%w: no module declares a version for %s:%s", pom.ErrMissingRequired, groupID, artifactID)
+	}
+
+	plan := &RefactorPlan{Title: fmt.Sprintf("Move %s:%s to parent dependencyManagement", groupID, artifactID)}
+
+	// Parent (unchanged when it already manages the dependency)
+	if managedIndex < 0 {
+		parent.DependencyManagement = append(parent.DependencyManagement, pom.Dependency{
+			GroupID:    groupID,
+			ArtifactID: artifactID,
+			Version:    version,
+		})
+		after, err := generator.Generate(pom.StampBanner(parent, parentPath))
+		if err != nil {
+			return nil, err
+		}
+		plan.Changes = append(plan.Changes, FileChange{
+			Path:    parentPath,
+			Before:  parentData,
+			After:   after,
+			Summary: fmt.Sprintf("manage %s:%s:%s", groupID, artifactID, version),
+		})
+	}
+
+	// Modules
+	for _, u := range usages {
+		dep := &u.project.Dependencies[u.index]
+		if dep.Version == "" {
+			continue
+		}
+		summary := fmt.Sprintf("remove version %s", dep.Version)
+		if dep.Version != version {
+			summary = fmt.Sprintf("remove version %s (now %s)", dep.Version, version)
+		}
+		dep.Version = ""
+
+		after, err := generator.Generate(pom.StampBanner(u.project, u.path))
+		if err != nil {
+			return nil, err
+		}
+		plan.Changes = append(plan.Changes, FileChange{Path: u.path, Before: u.data, After: after, Summary: summary})
+	}
+
+	if len(plan.Changes) == 0 {
+		return nil, fmt.Errorf("%s:%s is already managed by the parent", groupID, artifactID)
+	}
+
+	return plan, nil
+}
+
+// preferredVersion returns the most common version, choosing the highest
+// on a tie
+func preferredVersion(versions []string) string {
+	counts := make(map[string]int)
+	for _, v := range versions {
+		counts[v]++
+	}
+
+	candidates := make([]string, 0, len(counts))
+	for v := range counts {
+		candidates = append(candidates, v)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if counts[candidates[i]] != counts[candidates[j]] {
+			return counts[candidates[i]] > counts[candidates[j]]
+		}
+		return compareVersions(candidates[i], candidates[j]) > 0
+	})
+
+	if len(candidates) == 0 {
+		return ""
+	}
+	return candidates[0]
+}
+
+// compareVersions compares two versions semantically, falling back to
+// string order for versions semver cannot parse (such as property references)
+func compareVersions(a, b string) int {
+	va, errA := semver.NewVersion(a)
+	vb, errB := semver.NewVersion(b)
+	if errA != nil || errB != nil {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	}
+	return va.Compare(vb)
+}
+
+// writeFileAtomic writes data to a temporary file and renames it into place
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,62 @@
+package workspace
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+func TestPlanMoveToParent(t *testing.T) {
+	root := t.TempDir()
+	parentPath := filepath.Join(root, "pom.xml")
+	writePOM(t, parentPath, "parent")
+	writePOM(t, filepath.Join(root, "core", "pom.xml"), "core")
+	writePOM(t, filepath.Join(root, "api", "pom.xml"), "api")
+
+	parser := pom.NewParser()
+	parent, err := parser.ParseFile(parentPath)
+	if err != nil {
+		t.Fatalf("Failed to parse parent: %v", err)
+	}
+	parent.Modules = []string{"core", "api"}
+	parent.Packaging = pom.PackagingPom
+	if err := pom.NewGenerator().GenerateToFile(parent, parentPath); err != nil {
+		t.Fatalf("Failed to write parent: %v", err)
+	}
+	if _, err := ConvertToParent(parentPath); err != nil {
+		t.Fatalf("ConvertToParent failed: %v", err)
+	}
+
+	plan, err := PlanMoveToParent(parentPath, "junit", "junit")
+	if err != nil {
+		t.Fatalf("PlanMoveToParent failed: %v", err)
+	}
+	if len(plan.Changes) != 3 {
+		t.Fatalf("Expected 3 file changes, got %d", len(plan.Changes))
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	parent, err = parser.ParseFile(parentPath)
+	if err != nil {
+		t.Fatalf("Failed to parse parent: %v", err)
+	}
+	if len(parent.DependencyManagement) != 1 || parent.DependencyManagement[0].Version != "${junit.version}" {
+		t.Errorf("Expected managed junit ${junit.version}, got %+v", parent.DependencyManagement)
+	}
+
+	core, err := parser.ParseFile(filepath.Join(root, "core", "pom.xml"))
+	if err != nil {
+		t.Fatalf("Failed to parse module: %v", err)
+	}
+	if len(core.Dependencies) != 1 || core.Dependencies[0].Version != "" {
+		t.Errorf("Expected version-less junit in module, got %+v", core.Dependencies)
+	}
+
+	// Nothing left to move
+	if _, err := PlanMoveToParent(parentPath, "junit", "junit"); err == nil {
+		t.Error("Expected error when the dependency is already managed")
+	}
+}
@@ -0,0 +1,371 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// Test frameworks a scaffolded test class is written for
+const (
+	TestFrameworkJUnit5     = "JUnit 5"
+	TestFrameworkJUnit4     = "JUnit 4"
+	TestFrameworkKotlinTest = "kotlin.test"
+)
+
+// GitIgnoreFileName is the name of the Git ignore file of a scaffolded
+// project
+const GitIgnoreFileName = ".gitignore"
+
+// ScaffoldFile is a file of a new project's source tree
+type ScaffoldFile struct {
+	Path    string
+	Content string
+}
+
+// ScaffoldResult reports what Scaffold wrote
+type ScaffoldResult struct {
+	Created  []string // Paths of the files written
+	Existing []string // Paths of files left alone because they already exist
+
+	// TestFramework is the framework the test class is written for, "" when
+	// the project has no test dependency and so no test class
+	TestFramework string
+}
+
+// Scaffold creates the source tree of a new project in dir, the directory of
+// its POM, as the Maven quickstart archetype does: the source and test
+// directories, a sample main class and test in the package of the groupId,
+// and a .gitignore. Existing files are never overwritten.
+func Scaffold(dir string, project *pom.Project) (*ScaffoldResult, error) {
+	result := &ScaffoldResult{TestFramework: testFramework(project)}
+
+	if project.Packaging != pom.PackagingPom {
+		main, test := sourceRoots(dir, project)
+		for _, root := range []string{main, test} {
+			if err := os.MkdirAll(root, 0755); err != nil {
+				return result, fmt.Errorf("creating %s: %w", root, err)
+			}
+		}
+	}
+
+	for _, file := range ScaffoldFiles(dir, project) {
+		if err := os.MkdirAll(filepath.Dir(file.Path), 0755); err != nil {
+			return result, fmt.Errorf("creating %s: %w", filepath.Dir(file.Path), err)
+		}
+		f, err := os.OpenFile(file.Path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if errors.Is(err, os.ErrExist) {
+			result.Existing = append(result.Existing, file.Path)
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("writing %s: %w", file.Path, err)
+		}
+		_, err = f.WriteString(file.Content)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return result, fmt.Errorf("writing %s: %w", file.Path, err)
+		}
+		result.Created = append(result.Created, file.Path)
+	}
+	return result, nil
+}
+
+// ScaffoldFiles returns the files Scaffold writes for project in dir. An
+// aggregator (pom packaging) only gets the .gitignore; a JavaCard project
+// gets its applet class instead of App, and the test class is left out
+// when the project has no test framework to run it.
+func ScaffoldFiles(dir string, project *pom.Project) []ScaffoldFile {
+	files := []ScaffoldFile{{Path: filepath.Join(dir, GitIgnoreFileName), Content: gitIgnore}}
+	if project.Packaging == pom.PackagingPom {
+		return files
+	}
+
+	pkg, class := JavaPackage(project.GroupID), "App"
+	applet := project.Properties[pom.JavaCardAppletClassProperty]
+	javaCard := applet != "" && !strings.Contains(applet, "${")
+	if javaCard {
+		pkg, class = "", applet
+		if i := strings.LastIndex(applet, "."); i >= 0 {
+			pkg, class = applet[:i], applet[i+1:]
+		}
+	}
+	kotlin := hasPlugin(project, "org.jetbrains.kotlin", "kotlin-maven-plugin")
+	mainRoot, testRoot := sourceRoots(dir, project)
+	packageDir := filepath.FromSlash(strings.ReplaceAll(pkg, ".", "/"))
+
+	extension := ".java"
+	switch {
+	case kotlin:
+		extension = ".kt"
+		files = append(files, ScaffoldFile{
+			Path:    filepath.Join(mainRoot, packageDir, class+extension),
+			Content: packageLine(pkg, "") + kotlinMain,
+		})
+	case javaCard:
+		files = append(files, ScaffoldFile{
+			Path:    filepath.Join(mainRoot, packageDir, class+extension),
+			Content: packageLine(pkg, ";") + fmt.Sprintf(javaCardApplet, class, class),
+		})
+	default:
+		files = append(files, ScaffoldFile{
+			Path:    filepath.Join(mainRoot, packageDir, class+extension),
+			Content: packageLine(pkg, ";") + fmt.Sprintf(javaMain, class),
+		})
+	}
+
+	var test string
+	switch testFramework(project) {
+	case TestFrameworkKotlinTest:
+		if kotlin {
+			test = packageLine(pkg, "") + fmt.Sprintf(kotlinTest, class)
+		}
+	case TestFrameworkJUnit5:
+		if kotlin {
+			test = packageLine(pkg, "") + fmt.Sprintf(kotlinJUnit5Test, class)
+		} else {
+			test = packageLine(pkg, ";") + fmt.Sprintf(junit5Test, class)
+		}
+	case TestFrameworkJUnit4:
+		if kotlin {
+			test = packageLine(pkg, "") + fmt.Sprintf(kotlinJUnit4Test, class)
+		} else {
+			test = packageLine(pkg, ";") + fmt.Sprintf(junit4Test, class)
+		}
+	}
+	if test != "" {
+		files = append(files, ScaffoldFile{
+			Path:    filepath.Join(testRoot, packageDir, class+"Test"+extension),
+			Content: test,
+		})
+	}
+	return files
+}
+
+// JavaPackage returns the Java package for a groupId, which it follows as
+// closely as Java allows: characters that may not appear in identifiers
+// become underscores, and segments that start with a digit or are
+// keywords get one too
+func JavaPackage(groupID string) string {
+	var segments []string
+	for _, segment := range strings.Split(groupID, ".") {
+		if segment == "" {
+			continue
+		}
+		segment = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '$' {
+				return r
+			}
+			return '_'
+		}, segment)
+		if unicode.IsDigit(rune(segment[0])) {
+			segment = "_" + segment
+		}
+		if javaKeywords[segment] {
+			segment += "_"
+		}
+		segments = append(segments, segment)
+	}
+	return strings.Join(segments, ".")
+}
+
+// sourceRoots returns the main and test source directories of project in
+// dir, the configured ones when they can be resolved, else Maven's defaults
+func sourceRoots(dir string, project *pom.Project) (string, string) {
+	main, test := "src/main/java", "src/test/java"
+	if build := project.Build; build != nil {
+		if build.SourceDirectory != "" {
+			main = build.SourceDirectory
+		}
+		if build.TestSourceDirectory != "" {
+			test = build.TestSourceDirectory
+		}
+	}
+	return sourceRoot(dir, main, "src/main/java"), sourceRoot(dir, test, "src/test/java")
+}
+
+// sourceRoot resolves a source directory from the POM against dir, falling
+// back to fallback when it refers to properties other than the basedir
+func sourceRoot(dir, path, fallback string) string {
+	for _, basedir := range []string{"${project.basedir}", "${basedir}"} {
+		path = strings.ReplaceAll(path, basedir, dir)
+	}
+	if strings.Contains(path, "${") {
+		path = fallback
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+	}
+	return filepath.Clean(path)
+}
+
+// testFramework returns the test framework the project's dependencies
+// provide, "" when there is none
+func testFramework(project *pom.Project) string {
+	framework := ""
+	for _, dep := range project.Dependencies {
+		switch {
+		case dep.GroupID == "org.jetbrains.kotlin" && strings.HasPrefix(dep.ArtifactID, "kotlin-test"):
+			return TestFrameworkKotlinTest
+		case dep.GroupID == "org.junit.jupiter",
+			dep.ArtifactID == "spring-boot-starter-test",
+			dep.ArtifactID == "quarkus-junit5":
+			framework = TestFrameworkJUnit5
+		case dep.GroupID == "junit" && dep.ArtifactID == "junit":
+			if framework == "" {
+				framework = TestFrameworkJUnit4
+			}
+		}
+	}
+	return framework
+}
+
+// hasPlugin reports whether the project's build declares a plugin
+func hasPlugin(project *pom.Project, groupID, artifactID string) bool {
+	if project.Build == nil {
+		return false
+	}
+	for _, plugin := range project.Build.Plugins {
+		if plugin.GroupID == groupID && plugin.ArtifactID == artifactID {
+			return true
+		}
+	}
+	return false
+}
+
+// packageLine returns the package declaration of a source file, ended by
+// terminator, or "" for the default package
+func packageLine(pkg, terminator string) string {
+	if pkg == "" {
+		return ""
+	}
+	return "package " + pkg + terminator + "\n\n"
+}
+
+// javaKeywords are the reserved words that cannot be package names
+var javaKeywords = map[string]bool{
+	"abstract": true, "assert": true, "boolean": true, "break": true, "byte": true,
+	"case": true, "catch": true, "char": true, "class": true, "const": true,
+	"continue": true, "default": true, "do": true, "double": true, "else": true,
+	"enum": true, "extends": true, "false": true, "final": true, "finally": true,
+	"float": true, "for": true, "goto": true, "if": true, "implements": true,
+	"import": true, "instanceof": true, "int": true, "interface": true, "long": true,
+	"native": true, "new": true, "null": true, "package": true, "private": true,
+	"protected": true, "public": true, "return": true, "short": true, "static": true,
+	"strictfp": true, "super": true, "switch": true, "synchronized": true, "this": true,
+	"throw": true, "throws": true, "transient": true, "true": true, "try": true,
+	"void": true, "volatile": true, "while": true,
+}
+
+const gitIgnore = `# Maven
+target/
+pom.xml.tag
+pom.xml.releaseBackup
+pom.xml.versionsBackup
+release.properties
+dependency-reduced-pom.xml
+
+# IDEs
+.idea/
+*.iml
+.vscode/
+.classpath
+.project
+.settings/
+`
+
+const javaMain = `public class %s {
+    public static void main(String[] args) {
+        System.out.println("Hello World!");
+    }
+}
+`
+
+const javaCardApplet = `import javacard.framework.APDU;
+import javacard.framework.Applet;
+import javacard.framework.ISO7816;
+import javacard.framework.ISOException;
+
+public class %s extends Applet {
+
+    public static void install(byte[] bArray, short bOffset, byte bLength) {
+        new %s().register(bArray, (short) (bOffset + 1), bArray[bOffset]);
+    }
+
+    public void process(APDU apdu) {
+        if (selectingApplet()) {
+            return;
+        }
+        ISOException.throwIt(ISO7816.SW_INS_NOT_SUPPORTED);
+    }
+}
+`
+
+const junit5Test = `import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import org.junit.jupiter.api.Test;
+
+class %sTest {
+    @Test
+    void shouldAnswerWithTrue() {
+        assertTrue(true);
+    }
+}
+`
+
+const junit4Test = `import static org.junit.Assert.assertTrue;
+
+import org.junit.Test;
+
+public class %sTest {
+    @Test
+    public void shouldAnswerWithTrue() {
+        assertTrue(true);
+    }
+}
+`
+
+const kotlinMain = `fun main() {
+    println("Hello World!")
+}
+`
+
+const kotlinTest = `import kotlin.test.Test
+import kotlin.test.assertTrue
+
+class %sTest {
+    @Test
+    fun shouldAnswerWithTrue() {
+        assertTrue(true)
+    }
+}
+`
+
+const kotlinJUnit5Test = `import org.junit.jupiter.api.Assertions.assertTrue
+import org.junit.jupiter.api.Test
+
+class %sTest {
+    @Test
+    fun shouldAnswerWithTrue() {
+        assertTrue(true)
+    }
+}
+`
+
+const kotlinJUnit4Test = `import org.junit.Assert.assertTrue
+import org.junit.Test
+
+class %sTest {
+    @Test
+    fun shouldAnswerWithTrue() {
+        assertTrue(true)
+    }
+}
+`
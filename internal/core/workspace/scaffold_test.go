@@ -0,0 +1,124 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+func TestScaffold(t *testing.T) {
+	dir := t.TempDir()
+	project, err := pom.NewTemplateManager().Create("java-library", pom.Coordinates{
+		GroupID: "com.example.my-lib", ArtifactID: "lib", Version: "1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	result, err := Scaffold(dir, project)
+	if err != nil {
+		t.Fatalf("Scaffold failed: %v", err)
+	}
+	if result.TestFramework != TestFrameworkJUnit4 {
+		t.Errorf("Expected JUnit 4 for java-library, got %q", result.TestFramework)
+	}
+	if len(result.Created) != 3 {
+		t.Errorf("Expected .gitignore, App and AppTest, got %v", result.Created)
+	}
+
+	app, err := os.ReadFile(filepath.Join(dir, "src", "main", "java", "com", "example", "my_lib", "App.java"))
+	if err != nil {
+		t.Fatalf("Expected App.java in the groupId's package: %v", err)
+	}
+	if !strings.HasPrefix(string(app), "package com.example.my_lib;\n") {
+		t.Errorf("Expected package declaration, got:\n%s", app)
+	}
+	test, err := os.ReadFile(filepath.Join(dir, "src", "test", "java", "com", "example", "my_lib", "AppTest.java"))
+	if err != nil {
+		t.Fatalf("Expected AppTest.java: %v", err)
+	}
+	if !strings.Contains(string(test), "import org.junit.Test;") {
+		t.Errorf("Expected a JUnit 4 test, got:\n%s", test)
+	}
+	if _, err := os.Stat(filepath.Join(dir, GitIgnoreFileName)); err != nil {
+		t.Errorf("Expected .gitignore: %v", err)
+	}
+
+	// Existing files are left alone
+	gitIgnorePath := filepath.Join(dir, GitIgnoreFileName)
+	if err := os.WriteFile(gitIgnorePath, []byte("custom\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, err = Scaffold(dir, project)
+	if err != nil {
+		t.Fatalf("Second Scaffold failed: %v", err)
+	}
+	if len(result.Created) != 0 || len(result.Existing) != 3 {
+		t.Errorf("Expected all 3 files to exist already, got created %v, existing %v", result.Created, result.Existing)
+	}
+	if data, _ := os.ReadFile(gitIgnorePath); string(data) != "custom\n" {
+		t.Errorf("Expected .gitignore to be kept, got %q", data)
+	}
+}
+
+func TestScaffoldFiles(t *testing.T) {
+	tm := pom.NewTemplateManager()
+	coords := pom.Coordinates{GroupID: "org.acme", ArtifactID: "card", Version: "1.0.0"}
+
+	names := func(files []ScaffoldFile) []string {
+		var names []string
+		for _, file := range files {
+			rel, _ := filepath.Rel("/p", file.Path)
+			names = append(names, filepath.ToSlash(rel))
+		}
+		return names
+	}
+
+	tests := []struct {
+		template string
+		expected []string
+	}{
+		{"basic-java", []string{".gitignore", "src/main/java/org/acme/App.java"}},
+		{"spring-boot", []string{".gitignore", "src/main/java/org/acme/App.java", "src/test/java/org/acme/AppTest.java"}},
+		{"kotlin-jvm", []string{".gitignore", "src/main/kotlin/org/acme/App.kt", "src/test/kotlin/org/acme/AppTest.kt"}},
+		{"javacard", []string{".gitignore", "src/main/java/org/acme/CardApplet.java", "src/test/java/org/acme/CardAppletTest.java"}},
+	}
+	for _, tt := range tests {
+		project, err := tm.Create(tt.template, coords)
+		if err != nil {
+			t.Fatalf("Create %s failed: %v", tt.template, err)
+		}
+		if tt.template == "javacard" {
+			if err := pom.ConfigureJavaCard(project, pom.DefaultJavaCardApplet(coords)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got := names(ScaffoldFiles("/p", project))
+		if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("%s: expected %v, got %v", tt.template, tt.expected, got)
+		}
+	}
+
+	aggregator := &pom.Project{GroupID: "org.acme", Packaging: pom.PackagingPom}
+	if got := names(ScaffoldFiles("/p", aggregator)); len(got) != 1 || got[0] != ".gitignore" {
+		t.Errorf("Expected only .gitignore for an aggregator, got %v", got)
+	}
+}
+
+func TestJavaPackage(t *testing.T) {
+	tests := map[string]string{
+		"com.example":         "com.example",
+		"com.my-company.apps": "com.my_company.apps",
+		"io.3scale":           "io._3scale",
+		"org.example.int":     "org.example.int_",
+		"":                    "",
+	}
+	for groupID, expected := range tests {
+		if got := JavaPackage(groupID); got != expected {
+			t.Errorf("JavaPackage(%q): expected %q, got %q", groupID, expected, got)
+		}
+	}
+}
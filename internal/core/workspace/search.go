@@ -0,0 +1,179 @@
+package workspace
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// SearchKind selects what a workspace search matches against
+type SearchKind int
+
+const (
+	SearchAll         SearchKind = iota // Coordinates, properties, and raw text
+	SearchCoordinates                   // Project, parent, dependency, and plugin coordinates
+	SearchProperties                    // Property names
+	SearchText                          // Raw XML text, line by line
+)
+
+// String returns a human-readable name for the search kind
+func (k SearchKind) String() string {
+	switch k {
+	case SearchCoordinates:
+		return "Coordinates"
+	case SearchProperties:
+		return "Properties"
+	case SearchText:
+		return "Text"
+	default:
+		return "All"
+	}
+}
+
+// Sections of a POM a structured match can point at
+const (
+	SectionProject      = "project"
+	SectionParent       = "parent"
+	SectionDependencies = "dependencies"
+	SectionPlugins      = "plugins"
+	SectionProperties   = "properties"
+)
+
+// Match is a single search hit inside a workspace POM
+type Match struct {
+	Path    string     // Absolute path of the POM
+	Kind    SearchKind // SearchCoordinates, SearchProperties, or SearchText
+	Section string     // POM section of a structured match, empty for text matches
+	Key     string     // groupId:artifactId or property name of a structured match
+	Line    int        // 1-based line of the match, 0 if unknown
+	Text    string     // Display text
+}
+
+// Search finds query (case-insensitive) in every POM of the workspace.
+// Results are ordered by file, then by line. POMs that fail to parse are
+// still searched as raw text.
+func (w *Workspace) Search(query string, kind SearchKind) ([]Match, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("search query is empty")
+	}
+	needle := strings.ToLower(query)
+
+	var matches []Match
+	for _, path := range w.POMs {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		var fileMatches []Match
+		if kind == SearchAll || kind == SearchCoordinates || kind == SearchProperties {
+			if project, err := w.Project(path); err == nil {
+				if kind != SearchProperties {
+					fileMatches = append(fileMatches, searchCoordinates(project, data, needle)...)
+				}
+				if kind != SearchCoordinates {
+					fileMatches = append(fileMatches, searchProperties(project, data, needle)...)
+				}
+			}
+		}
+		if kind == SearchAll || kind == SearchText {
+			fileMatches = append(fileMatches, searchText(data, needle)...)
+		}
+
+		sort.SliceStable(fileMatches, func(i, j int) bool {
+			return fileMatches[i].Line < fileMatches[j].Line
+		})
+		for i := range fileMatches {
+			fileMatches[i].Path = path
+		}
+		matches = append(matches, fileMatches...)
+	}
+
+	return matches, nil
+}
+
+// searchCoordinates matches against project, parent, dependency, and plugin GAVs
+func searchCoordinates(project *pom.Project, data []byte, needle string) []Match {
+	var matches []Match
+	add := func(section, groupID, artifactID, version string) {
+		gav := groupID + ":" + artifactID
+		if version != "" {
+			gav += ":" + version
+		}
+		if !strings.Contains(strings.ToLower(gav), needle) {
+			return
+		}
+		matches = append(matches, Match{
+			Kind:    SearchCoordinates,
+			Section: section,
+			Key:     groupID + ":" + artifactID,
+			Line:    findLine(data, "<artifactId>"+artifactID+"</artifactId>"),
+			Text:    fmt.Sprintf("%s %s", section, gav),
+		})
+	}
+
+	add(SectionProject, project.GroupID, project.ArtifactID, project.Version)
+	if project.Parent != nil {
+		add(SectionParent, project.Parent.GroupID, project.Parent.ArtifactID, project.Parent.Version)
+	}
+	for _, dep := range project.Dependencies {
+		add(SectionDependencies, dep.GroupID, dep.ArtifactID, dep.Version)
+	}
+	if project.Build != nil {
+		for _, plugin := range project.Build.Plugins {
+			add(SectionPlugins, plugin.GroupID, plugin.ArtifactID, plugin.Version)
+		}
+	}
+
+	return matches
+}
+
+// searchProperties matches against property names
+func searchProperties(project *pom.Project, data []byte, needle string) []Match {
+	var matches []Match
+	for name, value := range project.Properties {
+		if !strings.Contains(strings.ToLower(name), needle) {
+			continue
+		}
+		matches = append(matches, Match{
+			Kind:    SearchProperties,
+			Section: SectionProperties,
+			Key:     name,
+			Line:    findLine(data, "<"+name+">"),
+			Text:    fmt.Sprintf("%s = %s", name, value),
+		})
+	}
+	return matches
+}
+
+// searchText matches raw lines of the file
+func searchText(data []byte, needle string) []Match {
+	var matches []Match
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.Contains(strings.ToLower(text), needle) {
+			matches = append(matches, Match{
+				Kind: SearchText,
+				Line: line,
+				Text: strings.TrimSpace(text),
+			})
+		}
+	}
+	return matches
+}
+
+// findLine returns the 1-based line of the first occurrence of s, or 0
+func findLine(data []byte, s string) int {
+	idx := bytes.Index(data, []byte(s))
+	if idx < 0 {
+		return 0
+	}
+	return bytes.Count(data[:idx], []byte("\n")) + 1
+}
@@ -0,0 +1,82 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testPOM = `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>com.example</groupId>
+    <artifactId>%s</artifactId>
+    <version>1.0.0</version>
+    <properties>
+        <junit.version>4.13.2</junit.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>${junit.version}</version>
+        </dependency>
+    </dependencies>
+</project>`
+
+func writePOM(t *testing.T, path, artifactID string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte(fmt.Sprintf(testPOM, artifactID))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWorkspaceSearch(t *testing.T) {
+	root := t.TempDir()
+	writePOM(t, filepath.Join(root, "pom.xml"), "parent")
+	writePOM(t, filepath.Join(root, "core", "pom.xml"), "core")
+	writePOM(t, filepath.Join(root, "core", "target", "pom.xml"), "ignored")
+
+	ws, err := Open(root)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	// Build output directories are not scanned
+	if len(ws.POMs) != 2 {
+		t.Fatalf("Expected 2 POMs, got %d: %v", len(ws.POMs), ws.POMs)
+	}
+
+	// Coordinates
+	matches, err := ws.Search("JUNIT:junit", SearchCoordinates)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("Expected 2 coordinate matches, got %d", len(matches))
+	}
+	if matches[0].Section != SectionDependencies || matches[0].Key != "junit:junit" || matches[0].Line != 13 {
+		t.Errorf("Unexpected coordinate match: %+v", matches[0])
+	}
+
+	// Property names
+	matches, _ = ws.Search("junit.version", SearchProperties)
+	if len(matches) != 2 || matches[0].Key != "junit.version" {
+		t.Errorf("Expected 2 property matches, got %v", matches)
+	}
+
+	// Raw text finds the property reference as well as its declaration
+	matches, _ = ws.Search("junit.version", SearchText)
+	if len(matches) != 4 {
+		t.Errorf("Expected 4 text matches, got %d", len(matches))
+	}
+
+	if _, err := ws.Search("  ", SearchAll); err == nil {
+		t.Error("Expected error for empty query")
+	}
+}
@@ -0,0 +1,269 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// Role describes how a POM organizes other modules
+type Role int
+
+const (
+	// RoleStandalone neither lists modules nor is inherited from
+	RoleStandalone Role = iota
+	// RoleAggregator lists <modules> that are built together
+	RoleAggregator
+	// RoleParent is referenced as <parent> by other POMs
+	RoleParent
+	// RoleAggregatorParent both lists modules and is inherited from
+	RoleAggregatorParent
+)
+
+// String returns the display name of the role
+func (r Role) String() string {
+	switch r {
+	case RoleAggregator:
+		return "Aggregator"
+	case RoleParent:
+		return "Parent"
+	case RoleAggregatorParent:
+		return "Aggregator + Parent"
+	default:
+		return "Standalone"
+	}
+}
+
+// ModuleStatus describes one entry of an aggregator's <modules>
+type ModuleStatus struct {
+	Module     string // Module path as declared
+	Path       string // Path of the module's pom.xml
+	Exists     bool   // The module POM was found and parsed
+	ArtifactID string
+	Inherits   bool // The module declares the aggregator as its <parent>
+}
+
+// Structure is the aggregator/parent analysis of a POM
+type Structure struct {
+	Path     string
+	Role     Role
+	Modules  []ModuleStatus
+	Children []string // POMs under the project directory that inherit from it
+	Warnings []pom.ValidationError
+}
+
+// Analyze determines whether the POM at path acts as aggregator, parent, or
+// both, and reports common mistakes in how the two are combined.
+func Analyze(path string, project *pom.Project) (*Structure, error) {
+	if project == nil {
+		return nil, fmt.Errorf("%w: project is nil", pom.ErrInvalidProject)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolving POM path: %w", err)
+	}
+
+	parser := pom.NewParser()
+	structure := &Structure{Path: absPath}
+	dir := filepath.Dir(absPath)
+
+	// Modules
+	for _, module := range project.Modules {
+		status := ModuleStatus{
+			Module: module,
+			Path:   filepath.Join(dir, filepath.FromSlash(module), POMFileName),
+		}
+		child, err := parser.ParseFile(status.Path)
+		if err != nil {
+			structure.Warnings = append(structure.Warnings, pom.ValidationError{
+				Field:    "modules." + module,
+				Value:    status.Path,
+				Message:  "module POM not found or unreadable",
+				Severity: pom.SeverityWarning,
+			})
+		} else {
+			status.Exists = true
+			status.ArtifactID = child.ArtifactID
+			status.Inherits = isParentOf(project, child)
+		}
+		structure.Modules = append(structure.Modules, status)
+	}
+
+	// Children: any POM below this one that names it as parent
+	ws, err := Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, pomPath := range ws.POMs {
+		if pomPath == absPath {
+			continue
+		}
+		child, err := parser.ParseFile(pomPath)
+		if err != nil || !isParentOf(project, child) {
+			continue
+		}
+		structure.Children = append(structure.Children, pomPath)
+
+		if len(project.Modules) > 0 && !declaresModule(project, dir, pomPath) {
+			structure.Warnings = append(structure.Warnings, pom.ValidationError{
+				Field:    "modules",
+				Value:    ws.RelPath(pomPath),
+				Message:  "inherits from this POM but is not listed in <modules>, so it is not built with it",
+				Severity: pom.SeverityWarning,
+			})
+		}
+	}
+
+	switch {
+	case len(project.Modules) > 0 && len(structure.Children) > 0:
+		structure.Role = RoleAggregatorParent
+	case len(project.Modules) > 0:
+		structure.Role = RoleAggregator
+	case len(structure.Children) > 0:
+		structure.Role = RoleParent
+	}
+
+	// The validator already reports aggregators without 'pom' packaging
+	if structure.Role == RoleParent && project.Packaging != pom.PackagingPom {
+		structure.Warnings = append(structure.Warnings, pom.ValidationError{
+			Field:    "packaging",
+			Value:    project.Packaging,
+			Message:  "a parent POM must use 'pom' packaging",
+			Severity: pom.SeverityWarning,
+		})
+	}
+
+	// Parent cycles make the whole chain unbuildable
+	if project.Parent != nil {
+		resolver := pom.NewParentResolverWithRepo(parser, "")
+		chain, err := resolver.ResolveChain(absPath, project)
+		cycle := errors.Is(err, pom.ErrParentCycle)
+		for _, parent := range chain {
+			if parent.Path == absPath {
+				cycle = true
+			}
+		}
+		if cycle {
+			structure.Warnings = append(structure.Warnings, pom.ValidationError{
+				Field:    "parent",
+				Value:    fmt.Sprintf("%s:%s", project.Parent.GroupID, project.Parent.ArtifactID),
+				Message:  "parent chain contains a cycle",
+				Severity: pom.SeverityWarning,
+			})
+		}
+	}
+
+	return structure, nil
+}
+
+// ConvertToParent makes every module without a <parent> inherit from the
+// aggregator, turning a pure aggregator into an aggregator + parent. It
+// returns the module POMs that were rewritten.
+func ConvertToParent(aggregatorPath string) ([]string, error) {
+	parser := pom.NewParser()
+	generator := pom.NewGenerator()
+
+	aggregator, err := parser.ParseFile(aggregatorPath)
+	if err != nil {
+		return nil, fmt.Errorf("parsing aggregator POM: %w", err)
+	}
+
+	var changed []string
+	for _, module := range aggregator.Modules {
+		modulePath := filepath.Join(filepath.Dir(aggregatorPath), filepath.FromSlash(module), POMFileName)
+		child, err := parser.ParseFile(modulePath)
+		if err != nil {
+			return changed, fmt.Errorf("parsing %s: %w", modulePath, err)
+		}
+		if child.Parent != nil {
+			continue
+		}
+
+		child.Parent = &pom.Parent{
+			GroupID:    aggregator.GroupID,
+			ArtifactID: aggregator.ArtifactID,
+			Version:    aggregator.Version,
+		}
+		if rel, err := filepath.Rel(filepath.Dir(modulePath), aggregatorPath); err == nil {
+			if rel = filepath.ToSlash(rel); rel != pom.DefaultRelativePath {
+				child.Parent.RelativePath = rel
+			}
+		}
+		// Coordinates equal to the parent's no longer need to be spelled out
+		child.InheritsGroupID = child.GroupID == aggregator.GroupID
+		child.InheritsVersion = child.Version == aggregator.Version
+
+		if err := generator.GenerateToFile(child, modulePath); err != nil {
+			return changed, fmt.Errorf("writing %s: %w", modulePath, err)
+		}
+		changed = append(changed, modulePath)
+	}
+
+	return changed, nil
+}
+
+// ConvertToAggregator removes the <parent> reference to the aggregator from
+// its modules, turning an aggregator + parent into a pure aggregator.
+// Inherited groupId and version are written out explicitly. It returns the
+// module POMs that were rewritten.
+func ConvertToAggregator(aggregatorPath string) ([]string, error) {
+	parser := pom.NewParser()
+	generator := pom.NewGenerator()
+
+	aggregator, err := parser.ParseFile(aggregatorPath)
+	if err != nil {
+		return nil, fmt.Errorf("parsing aggregator POM: %w", err)
+	}
+
+	var changed []string
+	for _, module := range aggregator.Modules {
+		modulePath := filepath.Join(filepath.Dir(aggregatorPath), filepath.FromSlash(module), POMFileName)
+		child, err := parser.ParseFile(modulePath)
+		if err != nil {
+			return changed, fmt.Errorf("parsing %s: %w", modulePath, err)
+		}
+		if !isParentOf(aggregator, child) {
+			continue
+		}
+
+		child.Parent = nil
+		child.InheritsGroupID = false
+		child.InheritsVersion = false
+
+		if err := generator.GenerateToFile(child, modulePath); err != nil {
+			return changed, fmt.Errorf("writing %s: %w", modulePath, err)
+		}
+		changed = append(changed, modulePath)
+	}
+
+	return changed, nil
+}
+
+// isParentOf reports whether child declares project as its <parent>
+func isParentOf(project, child *pom.Project) bool {
+	return child.Parent != nil &&
+		child.Parent.GroupID == project.GroupID &&
+		child.Parent.ArtifactID == project.ArtifactID
+}
+
+// declaresModule reports whether the POM at pomPath is one of the project's
+// modules or lies below one (and is built by a nested aggregator), given the
+// directory of the project
+func declaresModule(project *pom.Project, dir, pomPath string) bool {
+	rel, err := filepath.Rel(dir, filepath.Dir(pomPath))
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	for _, module := range project.Modules {
+		module = strings.TrimSuffix(strings.TrimPrefix(filepath.ToSlash(module), "./"), "/")
+		if rel == module || strings.HasPrefix(rel, module+"/") {
+			return true
+		}
+	}
+	return false
+}
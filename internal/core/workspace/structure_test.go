@@ -0,0 +1,71 @@
+package workspace
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+func TestAnalyzeAndConvert(t *testing.T) {
+	root := t.TempDir()
+	aggregatorPath := filepath.Join(root, "pom.xml")
+	writePOM(t, aggregatorPath, "parent")
+	writePOM(t, filepath.Join(root, "core", "pom.xml"), "core")
+
+	parser := pom.NewParser()
+	aggregator, err := parser.ParseFile(aggregatorPath)
+	if err != nil {
+		t.Fatalf("Failed to parse aggregator: %v", err)
+	}
+	if _, err := pom.AddModule(aggregator, "core"); err != nil {
+		t.Fatalf("AddModule failed: %v", err)
+	}
+	if err := pom.NewGenerator().GenerateToFile(aggregator, aggregatorPath); err != nil {
+		t.Fatalf("Failed to write aggregator: %v", err)
+	}
+
+	structure, err := Analyze(aggregatorPath, aggregator)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if structure.Role != RoleAggregator {
+		t.Errorf("Expected role Aggregator, got %s", structure.Role)
+	}
+
+	changed, err := ConvertToParent(aggregatorPath)
+	if err != nil {
+		t.Fatalf("ConvertToParent failed: %v", err)
+	}
+	if len(changed) != 1 {
+		t.Errorf("Expected 1 module to change, got %d", len(changed))
+	}
+
+	structure, err = Analyze(aggregatorPath, aggregator)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if structure.Role != RoleAggregatorParent {
+		t.Errorf("Expected role Aggregator + Parent, got %s", structure.Role)
+	}
+	if len(structure.Modules) != 1 || !structure.Modules[0].Inherits {
+		t.Errorf("Expected module to inherit, got %+v", structure.Modules)
+	}
+
+	changed, err = ConvertToAggregator(aggregatorPath)
+	if err != nil {
+		t.Fatalf("ConvertToAggregator failed: %v", err)
+	}
+	if len(changed) != 1 {
+		t.Errorf("Expected 1 module to change, got %d", len(changed))
+	}
+
+	core, err := parser.ParseFile(filepath.Join(root, "core", "pom.xml"))
+	if err != nil {
+		t.Fatalf("Failed to parse module: %v", err)
+	}
+	if core.Parent != nil || core.GroupID != "com.example" || core.Version != "1.0.0" {
+		t.Errorf("Expected detached module with explicit coordinates, got parent=%+v %s:%s",
+			core.Parent, core.GroupID, core.Version)
+	}
+}
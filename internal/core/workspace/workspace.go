@@ -0,0 +1,97 @@
+// Package workspace discovers and searches the POM files of a project tree.
+//
+// A workspace is a directory (typically the root of a multi-module build)
+// whose pom.xml files are edited together.
+package workspace
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// POMFileName is the file name Maven looks for in each module directory
+const POMFileName = "pom.xml"
+
+// skippedDirs are directories never scanned for POMs (build output, VCS, IDE)
+var skippedDirs = map[string]bool{
+	".git":         true,
+	".svn":         true,
+	".idea":        true,
+	"target":       true,
+	"node_modules": true,
+}
+
+// Workspace is a directory tree containing POM files. Only the file list is
+// read when it is opened; POMs are parsed when first needed.
+type Workspace struct {
+	Root string   // Absolute workspace root directory
+	POMs []string // Absolute paths of discovered pom.xml files, sorted
+
+	cache     *projectCache
+	cacheOnce sync.Once
+	index     *Index
+	indexOnce sync.Once
+}
+
+// Open scans root recursively for pom.xml files
+func Open(root string) (*Workspace, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("resolving workspace root: %w", err)
+	}
+
+	info, err := os.Stat(absRoot)
+	if err != nil {
+		return nil, fmt.Errorf("opening workspace: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("workspace root is not a directory: %s", absRoot)
+	}
+
+	ws := &Workspace{Root: absRoot}
+	err = filepath.WalkDir(absRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable subdirectories are skipped rather than aborting the scan
+			if d != nil && d.IsDir() && path != absRoot {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			if path != absRoot && skippedDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == POMFileName {
+			ws.POMs = append(ws.POMs, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning workspace: %w", err)
+	}
+
+	sort.Strings(ws.POMs)
+	return ws, nil
+}
+
+// RelPath returns path relative to the workspace root, for display
+func (w *Workspace) RelPath(path string) string {
+	rel, err := filepath.Rel(w.Root, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// Contains reports whether path lies inside the workspace
+func (w *Workspace) Contains(path string) bool {
+	rel, err := filepath.Rel(w.Root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
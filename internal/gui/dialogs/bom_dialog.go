@@ -0,0 +1,93 @@
+package dialogs
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// customBOM is the preset entry for typing coordinates by hand
+const customBOM = "Custom..."
+
+// BOMDialog is a modal dialog for importing a bill of materials into
+// dependencyManagement
+type BOMDialog struct {
+	window fyne.Window
+
+	// Form fields
+	presetSelect    *widget.Select
+	groupIDEntry    *widget.Entry
+	artifactIDEntry *widget.Entry
+	versionEntry    *widget.Entry
+}
+
+// NewBOMDialog creates a new BOM dialog
+func NewBOMDialog(window fyne.Window) *BOMDialog {
+	return &BOMDialog{
+		window: window,
+	}
+}
+
+// Show displays the dialog; callback receives the BOM coordinates
+func (d *BOMDialog) Show(callback func(pom.Coordinates)) {
+	d.groupIDEntry = widget.NewEntry()
+	d.groupIDEntry.SetPlaceHolder("org.springframework.boot")
+
+	d.artifactIDEntry = widget.NewEntry()
+	d.artifactIDEntry.SetPlaceHolder("spring-boot-dependencies")
+
+	d.versionEntry = widget.NewEntry()
+	d.versionEntry.SetPlaceHolder("3.2.0")
+
+	presets := []string{customBOM}
+	for _, bom := range pom.CommonBOMs {
+		presets = append(presets, bom.ArtifactID)
+	}
+	d.presetSelect = widget.NewSelect(presets, func(selected string) {
+		for _, bom := range pom.CommonBOMs {
+			if bom.ArtifactID == selected {
+				d.groupIDEntry.SetText(bom.GroupID)
+				d.artifactIDEntry.SetText(bom.ArtifactID)
+				d.versionEntry.SetText(bom.Version)
+				return
+			}
+		}
+	})
+	d.presetSelect.SetSelected(customBOM)
+
+	hint := widget.NewLabel("The BOM is added to dependencyManagement with scope import.\n" +
+		"Dependencies it manages can then be added without a version.")
+	hint.Wrapping = fyne.TextWrapWord
+
+	form := &widget.Form{
+		Items: []*widget.FormItem{
+			{Text: "Preset", Widget: d.presetSelect},
+			{Text: "Group ID", Widget: d.groupIDEntry},
+			{Text: "Artifact ID", Widget: d.artifactIDEntry},
+			{Text: "Version", Widget: d.versionEntry},
+		},
+	}
+
+	customDialog := dialog.NewCustomConfirm(
+		"Add BOM",
+		"Add",
+		"Cancel",
+		container.NewVBox(form, hint),
+		func(add bool) {
+			if add && callback != nil {
+				callback(pom.Coordinates{
+					GroupID:    d.groupIDEntry.Text,
+					ArtifactID: d.artifactIDEntry.Text,
+					Version:    d.versionEntry.Text,
+				})
+			}
+		},
+		d.window,
+	)
+
+	customDialog.Resize(fyne.NewSize(450, 300))
+	customDialog.Show()
+}
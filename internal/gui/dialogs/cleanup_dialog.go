@@ -0,0 +1,137 @@
+package dialogs
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/cleanup"
+	"github.com/user/pom-manager/internal/core/diff"
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// CleanupDialog lists redundant dependency declarations and previews the
+// POM without the ticked ones before removing them in one step
+type CleanupDialog struct {
+	window  fyne.Window
+	project *pom.Project
+	report  *cleanup.Report
+
+	// UI components
+	checks        []*widget.Check
+	previewScroll *container.Scroll
+	summaryLabel  *widget.Label
+}
+
+// NewCleanupDialog creates a dialog offering to clean up the findings of
+// report in project
+func NewCleanupDialog(window fyne.Window, project *pom.Project, report *cleanup.Report) *CleanupDialog {
+	return &CleanupDialog{
+		window:  window,
+		project: project,
+		report:  report,
+	}
+}
+
+// Show displays the dialog; onApply is called with a copy of the project
+// cleaned up by the ticked findings
+func (d *CleanupDialog) Show(onApply func(cleaned *pom.Project) error) {
+	if len(d.report.Findings) == 0 {
+		message := "No duplicate or redundant dependencies found."
+		if len(d.report.Warnings) > 0 {
+			message += "\n\n" + d.warningText()
+		}
+		dialog.ShowInformation("Clean Up Dependencies", message, d.window)
+		return
+	}
+
+	findings := container.NewVBox()
+	for _, finding := range d.report.Findings {
+		check := widget.NewCheck(finding.Description(), func(bool) {
+			d.updatePreview()
+		})
+		check.SetChecked(true)
+		d.checks = append(d.checks, check)
+		findings.Add(check)
+	}
+	findingsScroll := container.NewVScroll(findings)
+	rows := min(len(d.checks), maxChangeRows)
+	findingsScroll.SetMinSize(fyne.NewSize(0, float32(rows)*widget.NewCheck("", nil).MinSize().Height))
+
+	d.summaryLabel = widget.NewLabel("")
+	header := container.NewVBox(widget.NewCard("", fmt.Sprintf("%d redundant declaration(s)", len(d.checks)), findingsScroll))
+	if len(d.report.Warnings) > 0 {
+		warning := widget.NewLabel(d.warningText())
+		warning.Wrapping = fyne.TextWrapWord
+		warning.Importance = widget.WarningImportance
+		header.Add(warning)
+	}
+	header.Add(d.summaryLabel)
+	header.Add(widget.NewSeparator())
+
+	d.previewScroll = container.NewScroll(widget.NewTextGrid())
+	d.updatePreview()
+
+	content := container.NewBorder(header, nil, nil, nil, d.previewScroll)
+	cleanupDialog := dialog.NewCustomConfirm("Clean Up Dependencies", "Remove", "Cancel", content, func(remove bool) {
+		if !remove {
+			return
+		}
+		if err := onApply(d.cleaned()); err != nil {
+			dialog.ShowError(err, d.window)
+		}
+	}, d.window)
+	cleanupDialog.Resize(fyne.NewSize(900, 650))
+	cleanupDialog.Show()
+}
+
+// cleaned returns a copy of the project without the ticked findings
+func (d *CleanupDialog) cleaned() *pom.Project {
+	var selected []cleanup.Finding
+	for i, check := range d.checks {
+		if check.Checked {
+			selected = append(selected, d.report.Findings[i])
+		}
+	}
+	cleaned := d.project.Clone()
+	cleanup.Apply(cleaned, selected)
+	return cleaned
+}
+
+// updatePreview shows the diff the ticked findings make to the POM
+func (d *CleanupDialog) updatePreview() {
+	if d.previewScroll == nil {
+		return
+	}
+	generator := pom.NewGenerator()
+	before, err := generator.Generate(d.project)
+	if err == nil {
+		var after []byte
+		if after, err = generator.Generate(d.cleaned()); err == nil {
+			lines := diff.Lines(diff.SplitLines(string(before)), diff.SplitLines(string(after)))
+			removed := 0
+			for _, line := range lines {
+				if line.Op == diff.Delete {
+					removed++
+				}
+			}
+			d.summaryLabel.SetText(fmt.Sprintf("%d line(s) removed from pom.xml", removed))
+			d.previewScroll.Content = newUnifiedGrid(diff.Unified("pom.xml", "pom.xml (cleaned up)", string(before), string(after), diffContext))
+			d.previewScroll.Refresh()
+			return
+		}
+	}
+	d.summaryLabel.SetText(err.Error())
+}
+
+// warningText explains that transitive dependencies may have been missed
+func (d *CleanupDialog) warningText() string {
+	text := fmt.Sprintf("Some POMs are missing from the local repository, so the dependencies they bring in were not considered: %s", d.report.Warnings[0])
+	if more := len(d.report.Warnings) - 1; more > 0 {
+		text += fmt.Sprintf(" (and %d more)", more)
+	}
+	return text
+}
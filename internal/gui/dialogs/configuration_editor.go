@@ -0,0 +1,253 @@
+package dialogs
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/gui/widgets"
+)
+
+// configurationEditor edits the top-level parameters of a plugin's
+// configuration. Names of known parameters are completed while typing and
+// values are checked against the parameter's type. Nested values, such as
+// <includes>, are shown and kept but edited in the XML source.
+type configurationEditor struct {
+	window fyne.Window
+	plugin func() pom.Plugin // The plugin as currently entered
+
+	rows        []*configurationRow
+	typing      *configurationRow // Row whose name is being completed
+	rowsBox     *fyne.Container
+	completions *fyne.Container
+	status      *widget.Label
+	container   *fyne.Container
+}
+
+// configurationRow is one parameter of the configuration
+type configurationRow struct {
+	name   *widget.Entry
+	value  *widget.Entry
+	nested interface{} // Value kept as is when it is not plain text
+	box    *fyne.Container
+}
+
+// newConfigurationEditor creates an editor for config, which may be nil;
+// plugin returns the coordinates the parameters are looked up for
+func newConfigurationEditor(window fyne.Window, plugin func() pom.Plugin, config *pom.Configuration) *configurationEditor {
+	e := &configurationEditor{
+		window: window,
+		plugin: plugin,
+	}
+
+	e.rowsBox = container.NewVBox()
+	e.completions = container.NewHBox()
+	e.status = widget.NewLabel("")
+	e.status.Wrapping = fyne.TextWrapWord
+
+	if config != nil {
+		for _, key := range config.Keys() {
+			e.addRow(key, config.Data[key])
+		}
+	}
+
+	addButton := widgets.NewButtonWithTooltip("Add Parameter", "Add a configuration parameter of the plugin", func() {
+		row := e.addRow("", "")
+		e.typing = row
+		e.check()
+		e.window.Canvas().Focus(row.name)
+	})
+	addButton.Icon = theme.ContentAddIcon()
+
+	e.container = container.NewVBox(
+		widget.NewLabelWithStyle("Configuration", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		e.rowsBox,
+		container.NewHScroll(e.completions),
+		addButton,
+		e.status,
+	)
+	e.check()
+
+	return e
+}
+
+// addRow adds a parameter row showing value
+func (e *configurationEditor) addRow(name string, value interface{}) *configurationRow {
+	row := &configurationRow{
+		name:  widget.NewEntry(),
+		value: widget.NewEntry(),
+	}
+	row.name.SetPlaceHolder("parameter")
+	row.name.SetText(name)
+	row.value.SetPlaceHolder("value")
+
+	switch v := value.(type) {
+	case string:
+		row.value.SetText(v)
+	case bool, int, int64, float64:
+		row.value.SetText(fmt.Sprint(v))
+	default:
+		row.nested = v
+		row.value.SetText(describeNested(v))
+		row.value.Disable()
+	}
+
+	row.name.OnChanged = func(string) {
+		e.typing = row
+		e.check()
+	}
+	row.value.OnChanged = func(string) {
+		e.check()
+	}
+
+	removeButton := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+		e.removeRow(row)
+	})
+	row.box = container.NewBorder(nil, nil, nil, removeButton, container.NewGridWithColumns(2, row.name, row.value))
+	e.rows = append(e.rows, row)
+	e.rowsBox.Add(row.box)
+	return row
+}
+
+// removeRow removes a parameter row
+func (e *configurationEditor) removeRow(row *configurationRow) {
+	for i, r := range e.rows {
+		if r == row {
+			e.rows = append(e.rows[:i], e.rows[i+1:]...)
+			break
+		}
+	}
+	e.rowsBox.Remove(row.box)
+	if e.typing == row {
+		e.typing = nil
+	}
+	e.check()
+}
+
+// check offers the known parameters completing the name being typed and
+// shows the problems of the parameters entered
+func (e *configurationEditor) check() {
+	plugin := e.plugin()
+	used := make(map[string]bool)
+	var problems []string
+	for _, row := range e.rows {
+		name := strings.TrimSpace(row.name.Text)
+		if name == "" {
+			continue
+		}
+		if used[name] {
+			problems = append(problems, "⚠ "+name+" is set more than once")
+			continue
+		}
+		used[name] = true
+		if err := pom.CheckParameter(plugin, name, row.get()); err != nil {
+			problems = append(problems, "⚠ "+err.Error())
+		}
+	}
+
+	e.completions.RemoveAll()
+	if e.typing != nil {
+		typing := strings.TrimSpace(e.typing.name.Text)
+		for _, param := range pom.CompleteParameter(plugin, typing) {
+			if used[param.Name] {
+				continue
+			}
+			row := e.typing
+			e.completions.Add(widgets.NewButtonWithTooltip(param.Name, describeParameter(param), func() {
+				row.name.SetText(param.Name)
+				e.window.Canvas().Focus(row.value)
+			}))
+		}
+	}
+	e.completions.Refresh()
+
+	switch {
+	case len(problems) > 0:
+		e.setStatus(strings.Join(problems, "\n"), widget.WarningImportance)
+	case len(pom.PluginParameters(plugin)) == 0:
+		e.setStatus("The parameters of this plugin are not known, so they are not checked.", widget.MediumImportance)
+	case e.typing != nil:
+		param, ok := pom.LookupParameter(plugin, strings.TrimSpace(e.typing.name.Text))
+		if !ok {
+			e.setStatus("", widget.MediumImportance)
+			return
+		}
+		e.setStatus(param.Name+": "+describeParameter(param), widget.MediumImportance)
+	default:
+		e.setStatus("", widget.MediumImportance)
+	}
+}
+
+// setStatus shows the outcome of checking the parameters
+func (e *configurationEditor) setStatus(text string, importance widget.Importance) {
+	e.status.Importance = importance
+	e.status.SetText(text)
+}
+
+// configuration returns the configuration entered, or nil when no
+// parameter is
+func (e *configurationEditor) configuration() *pom.Configuration {
+	config := &pom.Configuration{Data: make(map[string]interface{})}
+	for _, row := range e.rows {
+		if name := strings.TrimSpace(row.name.Text); name != "" {
+			config.Data[name] = row.get()
+		}
+	}
+	if len(config.Data) == 0 {
+		return nil
+	}
+	return config
+}
+
+// get returns the value of the row
+func (r *configurationRow) get() interface{} {
+	if r.nested != nil {
+		return r.nested
+	}
+	return strings.TrimSpace(r.value.Text)
+}
+
+// describeParameter returns the description of a parameter with its type
+// and allowed values
+func describeParameter(param pom.PluginParameter) string {
+	text := param.Description + " (" + param.Type + ")"
+	if len(param.Values) > 0 {
+		text += ": " + strings.Join(param.Values, ", ")
+	}
+	return text
+}
+
+// describeNested summarizes a nested value for display
+func describeNested(value interface{}) string {
+	if _, ok := value.(pom.ConfigurationXML); ok {
+		return "XML element (edit in XML Source)"
+	}
+	return formatNested(value) + " (edit in XML Source)"
+}
+
+// formatNested formats the maps and lists of a nested value
+func formatNested(value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		parts := make([]string, 0, len(v))
+		for _, key := range (&pom.Configuration{Data: v}).Keys() {
+			parts = append(parts, key+": "+formatNested(v[key]))
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = formatNested(item)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case pom.ConfigurationXML:
+		return "<XML>"
+	default:
+		return fmt.Sprint(v)
+	}
+}
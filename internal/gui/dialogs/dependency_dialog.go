@@ -1,14 +1,53 @@
 package dialogs
 
 import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/core/remote"
 )
 
+// verifyDelay is how long typing must pause before the coordinates are
+// checked against the repositories
+const verifyDelay = 600 * time.Millisecond
+
+// insertPositionLabels names the positions of pom.InsertPositions
+var insertPositionLabels = map[string]string{
+	pom.InsertAtEnd:        "At the end",
+	pom.InsertAlphabetical: "In alphabetical order",
+	pom.InsertAfterEntry:   "After the selected dependency",
+	pom.InsertByScope:      "After dependencies with the same scope",
+}
+
+// insertPositionOptions returns the labels of positions in order
+func insertPositionOptions(positions []string) []string {
+	options := make([]string, len(positions))
+	for i, position := range positions {
+		options[i] = insertPositionLabels[position]
+	}
+	return options
+}
+
+// insertPositionFor returns the position with the given label, InsertAtEnd
+// when there is none
+func insertPositionFor(label string) string {
+	for position, l := range insertPositionLabels {
+		if l == label {
+			return position
+		}
+	}
+	return pom.InsertAtEnd
+}
+
 // DependencyDialog is a modal dialog for adding or editing dependencies
 type DependencyDialog struct {
 	window fyne.Window
@@ -18,6 +57,28 @@ type DependencyDialog struct {
 	artifactIDEntry *widget.Entry
 	versionEntry    *widget.Entry
 	scopeSelect     *widget.Select
+	systemPathEntry *widget.Entry
+	typeEntry       *widget.SelectEntry
+	classifierEntry *widget.Entry
+	optionalCheck   *widget.Check
+	positionSelect  *widget.Select
+	statusLabel     *widget.Label
+	typoHint        *typoHint
+
+	// Project whose dependencyManagement may supply the version
+	managedBy *pom.Project
+
+	// Repository client for the existence check; nil disables it
+	verifier remote.Client
+
+	// Where a new dependency goes; "" hides the choice
+	insertPosition string
+	insertAfter    *pom.Dependency
+
+	// The pending or running check; a newer check cancels it
+	verifyMu     sync.Mutex
+	verifyTimer  *time.Timer
+	verifyCancel context.CancelFunc
 
 	// Callbacks
 	onSave   func(pom.Dependency)
@@ -31,12 +92,49 @@ func NewDependencyDialog(window fyne.Window) *DependencyDialog {
 	}
 }
 
+// SetManagedBy lets the version be left empty for dependencies the project's
+// dependencyManagement (or an imported BOM) manages
+func (d *DependencyDialog) SetManagedBy(project *pom.Project) {
+	d.managedBy = project
+}
+
+// SetVerifier enables checking, while the dialog is open, that the entered
+// artifact and version exist in the client's repositories
+func (d *DependencyDialog) SetVerifier(client remote.Client) {
+	d.verifier = client
+}
+
+// SetPosition lets the user choose where the new dependency is inserted,
+// starting at position. after is the selected dependency; without one the
+// dependency cannot be inserted after it.
+func (d *DependencyDialog) SetPosition(position string, after *pom.Dependency) {
+	d.insertPosition = position
+	d.insertAfter = after
+}
+
+// Position returns the position chosen for the new dependency
+func (d *DependencyDialog) Position() string {
+	if d.positionSelect == nil {
+		return pom.InsertAtEnd
+	}
+	return insertPositionFor(d.positionSelect.Selected)
+}
+
 // ShowAdd displays the dialog for adding a new dependency
 func (d *DependencyDialog) ShowAdd(callback func(pom.Dependency)) {
 	d.onSave = callback
 	d.show("Add Dependency", nil)
 }
 
+// ShowAddPrefilled displays the dialog for adding a new dependency with
+// the coordinates of dep filled in, such as those found in a JAR
+func (d *DependencyDialog) ShowAddPrefilled(dep pom.Dependency, callback func(pom.Dependency)) {
+	d.ShowAdd(callback)
+	d.groupIDEntry.SetText(dep.GroupID)
+	d.artifactIDEntry.SetText(dep.ArtifactID)
+	d.versionEntry.SetText(dep.Version)
+}
+
 // ShowEdit displays the dialog for editing an existing dependency
 func (d *DependencyDialog) ShowEdit(dep pom.Dependency, callback func(pom.Dependency)) {
 	d.onSave = callback
@@ -61,11 +159,35 @@ func (d *DependencyDialog) show(title string, existingDep *pom.Dependency) {
 	)
 	d.scopeSelect.SetSelected("compile")
 
+	// Only system-scoped dependencies have a path
+	d.systemPathEntry = widget.NewEntry()
+	d.systemPathEntry.SetPlaceHolder("${project.basedir}/lib/library.jar")
+	d.systemPathEntry.Disable()
+	d.scopeSelect.OnChanged = func(scope string) {
+		if scope == pom.ScopeSystem {
+			d.systemPathEntry.Enable()
+		} else {
+			d.systemPathEntry.Disable()
+		}
+	}
+
+	d.typeEntry = widget.NewSelectEntry([]string{pom.DefaultDependencyType, "pom", "test-jar", "war", "zip"})
+	d.typeEntry.SetPlaceHolder(pom.DefaultDependencyType)
+
+	d.classifierEntry = widget.NewEntry()
+	d.classifierEntry.SetPlaceHolder("e.g. tests, sources, jdk8")
+
+	d.optionalCheck = widget.NewCheck("Not passed on to projects depending on this one", nil)
+
 	// Populate fields if editing
 	if existingDep != nil {
 		d.groupIDEntry.SetText(existingDep.GroupID)
 		d.artifactIDEntry.SetText(existingDep.ArtifactID)
 		d.versionEntry.SetText(existingDep.Version)
+		d.systemPathEntry.SetText(existingDep.SystemPath)
+		d.typeEntry.SetText(existingDep.Type)
+		d.classifierEntry.SetText(existingDep.Classifier)
+		d.optionalCheck.SetChecked(existingDep.Optional)
 		if existingDep.Scope != "" {
 			d.scopeSelect.SetSelected(existingDep.Scope)
 		}
@@ -78,11 +200,75 @@ func (d *DependencyDialog) show(title string, existingDep *pom.Dependency) {
 			{Text: "Artifact ID", Widget: d.artifactIDEntry},
 			{Text: "Version", Widget: d.versionEntry},
 			{Text: "Scope", Widget: d.scopeSelect},
+			{Text: "Type", Widget: d.typeEntry},
+			{Text: "Classifier", Widget: d.classifierEntry},
+			{Text: "Optional", Widget: d.optionalCheck},
+			{Text: "System Path", Widget: d.systemPathEntry},
 		},
 	}
 
+	// New dependencies may go elsewhere than the end of the list
+	d.positionSelect = nil
+	if existingDep == nil && d.insertPosition != "" {
+		positions := pom.InsertPositions
+		if d.insertAfter == nil {
+			positions = slices.DeleteFunc(slices.Clone(positions), func(position string) bool {
+				return position == pom.InsertAfterEntry
+			})
+		}
+		d.positionSelect = widget.NewSelect(insertPositionOptions(positions), nil)
+		position := d.insertPosition
+		if !slices.Contains(positions, position) {
+			position = pom.InsertAtEnd
+		}
+		d.positionSelect.SetSelected(insertPositionLabels[position])
+		item := &widget.FormItem{Text: "Insert", Widget: d.positionSelect}
+		if d.insertAfter != nil {
+			item.HintText = "Selected: " + d.insertAfter.GroupID + ":" + d.insertAfter.ArtifactID
+		}
+		form.AppendItem(item)
+	}
+
+	d.statusLabel = widget.NewLabel("")
+	d.statusLabel.Wrapping = fyne.TextWrapWord
+	d.typoHint = newTypoHint(d.groupIDEntry, d.artifactIDEntry, "")
+
 	// Create dialog
-	content := container.NewVBox(form)
+	content := container.NewVBox(form, d.typoHint.container, d.statusLabel)
+	if d.managedBy != nil && len(d.managedBy.DependencyManagement) > 0 {
+		d.versionEntry.SetPlaceHolder("managed")
+		hint := widget.NewLabel("Leave the version empty to use the one from dependencyManagement.")
+		hint.Wrapping = fyne.TextWrapWord
+		content.Add(hint)
+
+		// Show the managed version as soon as the coordinates match an entry
+		showManaged := func(string) {
+			version, ok := d.managedBy.ManagedVersion(d.groupIDEntry.Text, d.artifactIDEntry.Text)
+			switch {
+			case ok:
+				d.versionEntry.SetPlaceHolder("managed: " + version)
+			case len(d.managedBy.BOMs()) > 0:
+				d.versionEntry.SetPlaceHolder("managed by BOM")
+			default:
+				d.versionEntry.SetPlaceHolder("1.0.0")
+			}
+		}
+		d.groupIDEntry.OnChanged = showManaged
+		d.artifactIDEntry.OnChanged = showManaged
+		showManaged("")
+	}
+
+	// Check the coordinates whenever they change
+	for _, entry := range []*widget.Entry{d.groupIDEntry, d.artifactIDEntry, d.versionEntry} {
+		previous := entry.OnChanged
+		entry.OnChanged = func(text string) {
+			if previous != nil {
+				previous(text)
+			}
+			d.scheduleVerify()
+		}
+	}
+	d.scheduleVerify()
 
 	customDialog := dialog.NewCustomConfirm(
 		title,
@@ -90,12 +276,26 @@ func (d *DependencyDialog) show(title string, existingDep *pom.Dependency) {
 		"Cancel",
 		content,
 		func(save bool) {
+			d.stopVerify()
 			if save && d.onSave != nil {
 				dep := pom.Dependency{
 					GroupID:    d.groupIDEntry.Text,
 					ArtifactID: d.artifactIDEntry.Text,
 					Version:    d.versionEntry.Text,
+					Type:       strings.TrimSpace(d.typeEntry.Text),
+					Classifier: strings.TrimSpace(d.classifierEntry.Text),
 					Scope:      d.scopeSelect.Selected,
+					Optional:   d.optionalCheck.Checked,
+				}
+				if dep.Type == pom.DefaultDependencyType {
+					dep.Type = ""
+				}
+				// Exclusions are not edited here and carry over
+				if existingDep != nil {
+					dep.Exclusions = existingDep.Exclusions
+				}
+				if dep.Scope == pom.ScopeSystem {
+					dep.SystemPath = d.systemPathEntry.Text
 				}
 				d.onSave(dep)
 			}
@@ -103,6 +303,87 @@ func (d *DependencyDialog) show(title string, existingDep *pom.Dependency) {
 		d.window,
 	)
 
-	customDialog.Resize(fyne.NewSize(400, 250))
+	customDialog.Resize(fyne.NewSize(420, 420))
 	customDialog.Show()
 }
+
+// scheduleVerify shows problems with the entered version right away and
+// checks the coordinates against the repositories once typing pauses
+func (d *DependencyDialog) scheduleVerify() {
+	d.stopVerify()
+
+	groupID := strings.TrimSpace(d.groupIDEntry.Text)
+	artifactID := strings.TrimSpace(d.artifactIDEntry.Text)
+	version := strings.TrimSpace(d.versionEntry.Text)
+
+	// Likely typos need no repository lookup to be flagged
+	typo := d.typoHint.check()
+
+	if problem := versionProblem(version); problem != "" {
+		d.setStatus(problem, widget.WarningImportance)
+		return
+	}
+	if typo || d.verifier == nil || groupID == "" || artifactID == "" {
+		d.setStatus("", widget.MediumImportance)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.verifyMu.Lock()
+	d.verifyCancel = cancel
+	d.verifyTimer = time.AfterFunc(verifyDelay, func() {
+		fyne.Do(func() { d.setStatus("Checking repositories...", widget.LowImportance) })
+
+		verification, err := remote.Verify(ctx, d.verifier, groupID, artifactID, version)
+		if ctx.Err() != nil {
+			return // Superseded by a newer check or the dialog closed
+		}
+
+		fyne.Do(func() {
+			switch {
+			case err != nil:
+				d.setStatus("Could not check repositories: "+err.Error(), widget.LowImportance)
+			case verification.OK():
+				d.setStatus("✓ "+verification.Message(), widget.SuccessImportance)
+			default:
+				d.setStatus("⚠ "+verification.Message(), widget.WarningImportance)
+			}
+		})
+	})
+	d.verifyMu.Unlock()
+}
+
+// stopVerify cancels the pending or running repository check
+func (d *DependencyDialog) stopVerify() {
+	d.verifyMu.Lock()
+	defer d.verifyMu.Unlock()
+
+	if d.verifyTimer != nil {
+		d.verifyTimer.Stop()
+		d.verifyTimer = nil
+	}
+	if d.verifyCancel != nil {
+		d.verifyCancel()
+		d.verifyCancel = nil
+	}
+}
+
+// setStatus shows the outcome of the latest check
+func (d *DependencyDialog) setStatus(text string, importance widget.Importance) {
+	d.statusLabel.Importance = importance
+	d.statusLabel.SetText(text)
+}
+
+// versionProblem returns a warning for versions Maven would reject or
+// resolve unpredictably, "" when the version looks fine
+func versionProblem(version string) string {
+	switch {
+	case strings.ContainsAny(version, " \t"):
+		return "⚠ Versions cannot contain spaces"
+	case version == "LATEST" || version == "RELEASE":
+		return "⚠ " + version + " is deprecated and makes builds unreproducible; use a fixed version"
+	case strings.Count(version, "${") != strings.Count(version, "}"):
+		return "⚠ Unterminated property reference in version"
+	}
+	return ""
+}
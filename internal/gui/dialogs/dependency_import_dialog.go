@@ -0,0 +1,99 @@
+package dialogs
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// DependencyImportDialog lets the user pick which dependencies read from
+// another build tool's file are added to the POM
+type DependencyImportDialog struct {
+	window   fyne.Window
+	source   string
+	imported *pom.DependencyImport
+}
+
+// NewDependencyImportDialog creates a dialog listing the imported dependencies
+func NewDependencyImportDialog(window fyne.Window, source string, imported *pom.DependencyImport) *DependencyImportDialog {
+	return &DependencyImportDialog{
+		window:   window,
+		source:   source,
+		imported: imported,
+	}
+}
+
+// Show displays the dialog; callback receives the selected dependencies
+func (d *DependencyImportDialog) Show(callback func([]pom.Dependency)) {
+	if len(d.imported.Dependencies) == 0 {
+		message := fmt.Sprintf("No dependencies found in %s.", d.source)
+		if len(d.imported.Warnings) > 0 {
+			message += "\n\n" + strings.Join(d.imported.Warnings, "\n")
+		}
+		dialog.ShowInformation("Import Dependencies", message, d.window)
+		return
+	}
+
+	// Check group labels map back to dependencies
+	labels := make([]string, 0, len(d.imported.Dependencies))
+	deps := make(map[string]pom.Dependency, len(d.imported.Dependencies))
+	for _, dep := range d.imported.Dependencies {
+		label := fmt.Sprintf("%s [%s]", dep.Notation(), dep.Scope)
+		labels = append(labels, label)
+		deps[label] = dep
+	}
+
+	checks := widget.NewCheckGroup(labels, nil)
+	checks.SetSelected(labels)
+
+	selectAll := widget.NewButton("Select All", func() {
+		checks.SetSelected(labels)
+	})
+	selectNone := widget.NewButton("Select None", func() {
+		checks.SetSelected(nil)
+	})
+
+	top := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf("Dependencies found in %s:", d.source)),
+		container.NewHBox(selectAll, selectNone),
+	)
+
+	var bottom fyne.CanvasObject
+	if len(d.imported.Warnings) > 0 {
+		warnings := widget.NewLabel(strings.Join(d.imported.Warnings, "\n"))
+		warnings.Wrapping = fyne.TextWrapWord
+		warnings.Importance = widget.WarningImportance
+		bottom = container.NewVBox(widget.NewSeparator(), warnings)
+	}
+
+	content := container.NewBorder(top, bottom, nil, nil, container.NewVScroll(checks))
+
+	customDialog := dialog.NewCustomConfirm(
+		"Import Dependencies",
+		"Import",
+		"Cancel",
+		content,
+		func(ok bool) {
+			if !ok || callback == nil {
+				return
+			}
+			selected := make([]pom.Dependency, 0, len(checks.Selected))
+			for _, label := range checks.Selected {
+				selected = append(selected, deps[label])
+			}
+			if len(selected) > 0 {
+				callback(selected)
+			}
+		},
+		d.window,
+	)
+
+	customDialog.Resize(fyne.NewSize(600, 450))
+	customDialog.Show()
+}
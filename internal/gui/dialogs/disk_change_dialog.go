@@ -0,0 +1,65 @@
+package dialogs
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/diff"
+)
+
+// DiskChangeDialog asks whether to reload a POM another program changed or
+// to keep the version being edited. With unsaved edits as well, it
+// summarizes both sides and what they both changed.
+type DiskChangeDialog struct {
+	window fyne.Window
+	name   string
+	merge  diff.Merge
+}
+
+// NewDiskChangeDialog creates a dialog for the changes to the file name
+func NewDiskChangeDialog(window fyne.Window, name string, merge diff.Merge) *DiskChangeDialog {
+	return &DiskChangeDialog{
+		window: window,
+		name:   name,
+		merge:  merge,
+	}
+}
+
+// Show displays the dialog; onReload or onKeep is called with the choice.
+// Dismissing it keeps the local version, which loses nothing.
+func (d *DiskChangeDialog) Show(onReload, onKeep func()) {
+	message := fmt.Sprintf("%s was changed by another program, such as an IDE or git.", d.name)
+	if len(d.merge.Ours) == 0 {
+		message += "\nReload it to see the changes?"
+	} else {
+		message += "\nYou have unsaved changes as well. Reloading discards them;" +
+			"\nkeeping yours overwrites the changes on disk when you save."
+	}
+	content := container.NewVBox(widget.NewLabel(message))
+
+	if d.merge.HasConflicts() {
+		conflicts := widget.NewLabel("Changed on both sides: " + strings.Join(d.merge.Conflicts, ", "))
+		conflicts.Importance = widget.DangerImportance
+		conflicts.Wrapping = fyne.TextWrapWord
+		content.Add(conflicts)
+	}
+	content.Add(newChangesCard("On disk", d.merge.Theirs))
+	if len(d.merge.Ours) > 0 {
+		content.Add(newChangesCard("Your unsaved changes", d.merge.Ours))
+	}
+
+	changeDialog := dialog.NewCustomConfirm("File Changed on Disk", "Reload", "Keep Mine", container.NewVScroll(content), func(reload bool) {
+		if reload {
+			onReload()
+		} else {
+			onKeep()
+		}
+	}, d.window)
+	changeDialog.Resize(fyne.NewSize(640, 480))
+	changeDialog.Show()
+}
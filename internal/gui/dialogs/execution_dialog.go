@@ -1,6 +1,7 @@
 package dialogs
 
 import (
+	"slices"
 	"strings"
 
 	"fyne.io/fyne/v2"
@@ -9,6 +10,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/gui/widgets"
 )
 
 // ExecutionDialog manages plugin execution creation and editing
@@ -21,6 +23,9 @@ type ExecutionDialog struct {
 	executionID  *widget.Entry
 	phaseSelect  *widget.Select
 	goalsEntry   *widget.Entry
+	goalButtons  *fyne.Container // Goals of the selected plugin completing the one being typed
+	goalStatus   *widget.Label
+	configEditor *configurationEditor
 }
 
 // NewExecutionDialog creates a new ExecutionDialog
@@ -43,7 +48,11 @@ func (d *ExecutionDialog) ShowAdd(callback func(pluginIndex int, execution pom.P
 // ShowEdit displays the dialog for editing an existing execution
 func (d *ExecutionDialog) ShowEdit(pluginIndex int, execution pom.PluginExecution, callback func(pluginIndex int, execution pom.PluginExecution)) {
 	pluginKey := d.getPluginKey(pluginIndex)
-	d.show("Edit Plugin Execution", pluginKey, execution, callback)
+	d.show("Edit Plugin Execution", pluginKey, execution, func(pluginIndex int, exec pom.PluginExecution) {
+		if callback != nil {
+			callback(pluginIndex, exec)
+		}
+	})
 }
 
 // show displays the execution dialog
@@ -55,7 +64,12 @@ func (d *ExecutionDialog) show(title string, preselectedPlugin string, existing
 		return
 	}
 
-	d.pluginSelect = widget.NewSelect(pluginOptions, nil)
+	d.pluginSelect = widget.NewSelect(pluginOptions, func(string) {
+		d.updateGoals()
+		if d.configEditor != nil {
+			d.configEditor.check()
+		}
+	})
 	if preselectedPlugin != "" {
 		d.pluginSelect.SetSelected(preselectedPlugin)
 	} else {
@@ -69,34 +83,8 @@ func (d *ExecutionDialog) show(title string, preselectedPlugin string, existing
 		d.executionID.SetText(existing.ID)
 	}
 
-	// Phase selection
-	phases := []string{
-		pom.PhaseValidate,
-		pom.PhaseInitialize,
-		pom.PhaseGenerateSources,
-		pom.PhaseProcessSources,
-		pom.PhaseGenerateResources,
-		pom.PhaseProcessResources,
-		pom.PhaseCompile,
-		pom.PhaseProcessClasses,
-		pom.PhaseGenerateTestSources,
-		pom.PhaseProcessTestSources,
-		pom.PhaseGenerateTestResources,
-		pom.PhaseProcessTestResources,
-		pom.PhaseTestCompile,
-		pom.PhaseProcessTestClasses,
-		pom.PhaseTest,
-		pom.PhasePreparePackage,
-		pom.PhasePackage,
-		pom.PhasePreIntegrationTest,
-		pom.PhaseIntegrationTest,
-		pom.PhasePostIntegrationTest,
-		pom.PhaseVerify,
-		pom.PhaseInstall,
-		pom.PhaseDeploy,
-	}
-
-	d.phaseSelect = widget.NewSelect(phases, nil)
+	// Phase selection, from the clean, default and site lifecycles
+	d.phaseSelect = widget.NewSelect(pom.MavenLifecyclePhases, nil)
 	if existing.Phase != "" {
 		d.phaseSelect.SetSelected(existing.Phase)
 	} else {
@@ -109,6 +97,16 @@ func (d *ExecutionDialog) show(title string, preselectedPlugin string, existing
 	if len(existing.Goals) > 0 {
 		d.goalsEntry.SetText(strings.Join(existing.Goals, ", "))
 	}
+	d.goalsEntry.OnChanged = func(string) {
+		d.updateGoals()
+	}
+	d.goalButtons = container.NewHBox()
+	d.goalStatus = widget.NewLabel("")
+	d.goalStatus.Wrapping = fyne.TextWrapWord
+	d.updateGoals()
+
+	// Configuration parameters, completed and checked for the plugin
+	d.configEditor = newConfigurationEditor(d.window, d.selectedPlugin, existing.Configuration)
 
 	// Create form
 	form := &widget.Form{
@@ -125,11 +123,15 @@ func (d *ExecutionDialog) show(title string, preselectedPlugin string, existing
 	infoLabel.Wrapping = fyne.TextWrapWord
 	infoLabel.TextStyle = fyne.TextStyle{Italic: true}
 
-	content := container.NewVBox(
+	content := container.NewVScroll(container.NewVBox(
 		infoLabel,
 		widget.NewSeparator(),
 		form,
-	)
+		container.NewHScroll(d.goalButtons),
+		d.goalStatus,
+		widget.NewSeparator(),
+		d.configEditor.container,
+	))
 
 	// Create dialog
 	customDialog := dialog.NewCustomConfirm(
@@ -146,9 +148,10 @@ func (d *ExecutionDialog) show(title string, preselectedPlugin string, existing
 				}
 
 				exec := pom.PluginExecution{
-					ID:    d.executionID.Text,
-					Phase: d.phaseSelect.Selected,
-					Goals: d.parseGoals(d.goalsEntry.Text),
+					ID:            d.executionID.Text,
+					Phase:         d.phaseSelect.Selected,
+					Goals:         d.parseGoals(d.goalsEntry.Text),
+					Configuration: d.configEditor.configuration(),
 				}
 
 				// Default execution ID if empty
@@ -164,10 +167,81 @@ func (d *ExecutionDialog) show(title string, preselectedPlugin string, existing
 		d.window,
 	)
 
-	customDialog.Resize(fyne.NewSize(500, 400))
+	customDialog.Resize(fyne.NewSize(560, 560))
 	customDialog.Show()
 }
 
+// updateGoals offers the goals of the selected plugin that complete the one
+// being typed, and checks the goals entered against the plugin's goals
+func (d *ExecutionDialog) updateGoals() {
+	if d.goalButtons == nil {
+		return
+	}
+	pluginIndex := d.getSelectedPluginIndex()
+	if pluginIndex < 0 {
+		return
+	}
+	plugin := d.plugins[pluginIndex]
+	entered := d.parseGoals(d.goalsEntry.Text)
+
+	// The goal being typed is the text after the last comma
+	typing := d.goalsEntry.Text
+	if i := strings.LastIndex(typing, ","); i >= 0 {
+		typing = typing[i+1:]
+	}
+	d.goalButtons.RemoveAll()
+	for _, goal := range pom.CompleteGoal(plugin, strings.TrimSpace(typing)) {
+		if slices.Contains(entered, goal.Name) {
+			continue
+		}
+		tooltip := goal.Description
+		if goal.Phase != "" {
+			tooltip += " (binds to " + goal.Phase + " by default)"
+		}
+		d.goalButtons.Add(widgets.NewButtonWithTooltip(goal.Name, tooltip, func() {
+			d.completeGoal(goal)
+		}))
+	}
+	d.goalButtons.Refresh()
+
+	if pom.PluginGoals(plugin) == nil {
+		d.setGoalStatus("The goals of this plugin are not known, so they are not checked.", widget.MediumImportance)
+		return
+	}
+	var described []string
+	for _, name := range entered {
+		if err := pom.CheckGoal(plugin, name); err != nil {
+			d.setGoalStatus("⚠ "+err.Error(), widget.WarningImportance)
+			return
+		}
+		if goal, ok := pom.LookupGoal(plugin, name); ok {
+			described = append(described, goal.Name+": "+goal.Description)
+		}
+	}
+	d.setGoalStatus(strings.Join(described, "\n"), widget.MediumImportance)
+}
+
+// completeGoal replaces the goal being typed with goal. The first goal
+// also selects the phase it binds to by default.
+func (d *ExecutionDialog) completeGoal(goal pom.PluginGoal) {
+	text := d.goalsEntry.Text
+	head := ""
+	if i := strings.LastIndex(text, ","); i >= 0 {
+		head = text[:i+1] + " "
+	}
+	d.goalsEntry.SetText(head + goal.Name)
+	if head == "" && goal.Phase != "" {
+		d.phaseSelect.SetSelected(goal.Phase)
+	}
+	d.window.Canvas().Focus(d.goalsEntry)
+}
+
+// setGoalStatus shows the outcome of checking the goals
+func (d *ExecutionDialog) setGoalStatus(text string, importance widget.Importance) {
+	d.goalStatus.Importance = importance
+	d.goalStatus.SetText(text)
+}
+
 // getPluginOptions returns a list of plugin display strings
 func (d *ExecutionDialog) getPluginOptions() []string {
 	var options []string
@@ -193,6 +267,14 @@ func (d *ExecutionDialog) getPluginKey(index int) string {
 	return ""
 }
 
+// selectedPlugin returns the selected plugin, or an empty one when none is
+func (d *ExecutionDialog) selectedPlugin() pom.Plugin {
+	if i := d.getSelectedPluginIndex(); i >= 0 {
+		return d.plugins[i]
+	}
+	return pom.Plugin{}
+}
+
 // getSelectedPluginIndex returns the index of the selected plugin
 func (d *ExecutionDialog) getSelectedPluginIndex() int {
 	selected := d.pluginSelect.Selected
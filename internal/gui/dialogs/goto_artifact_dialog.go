@@ -0,0 +1,137 @@
+package dialogs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/workspace"
+)
+
+// indexPollInterval is how often the list picks up newly indexed POMs
+const indexPollInterval = 250 * time.Millisecond
+
+// GotoArtifactDialog opens a workspace POM by its coordinates, using the
+// workspace's background index
+type GotoArtifactDialog struct {
+	window    fyne.Window
+	workspace *workspace.Workspace
+	dialog    dialog.Dialog
+
+	// UI components
+	queryEntry  *widget.Entry
+	resultsList *widget.List
+	statusLabel *widget.Label
+
+	// State
+	entries []workspace.IndexEntry // Entries matching the query
+
+	// Callbacks
+	onOpen func(path string)
+}
+
+// NewGotoArtifactDialog creates a new go to artifact dialog
+func NewGotoArtifactDialog(window fyne.Window, ws *workspace.Workspace) *GotoArtifactDialog {
+	return &GotoArtifactDialog{
+		window:    window,
+		workspace: ws,
+	}
+}
+
+// Show displays the dialog; onOpen is called with the POM picked
+func (d *GotoArtifactDialog) Show(onOpen func(path string)) {
+	d.onOpen = onOpen
+
+	d.queryEntry = widget.NewEntry()
+	d.queryEntry.SetPlaceHolder("groupId or artifactId...")
+	d.queryEntry.OnChanged = func(string) {
+		d.update()
+	}
+	d.queryEntry.OnSubmitted = func(string) {
+		if len(d.entries) > 0 {
+			d.open(d.entries[0].Path)
+		}
+	}
+
+	d.statusLabel = widget.NewLabel("")
+
+	d.resultsList = widget.NewList(
+		func() int {
+			return len(d.entries)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("template")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			entry := d.entries[id]
+			obj.(*widget.Label).SetText(fmt.Sprintf("%s  (%s)", entry.Coordinates, d.workspace.RelPath(entry.Path)))
+		},
+	)
+	d.resultsList.OnSelected = func(id widget.ListItemID) {
+		d.open(d.entries[id].Path)
+	}
+
+	content := container.NewBorder(d.queryEntry, d.statusLabel, nil, nil, d.resultsList)
+	d.dialog = dialog.NewCustom("Go to Artifact", "Close", content, d.window)
+	d.dialog.Resize(fyne.NewSize(650, 450))
+
+	// List POMs as the index fills, until it is complete or the dialog closes
+	closed := make(chan struct{})
+	d.dialog.SetOnClosed(func() { close(closed) })
+	index := d.workspace.Index()
+	go func() {
+		ticker := time.NewTicker(indexPollInterval)
+		defer ticker.Stop()
+		for {
+			if indexed, total := index.Progress(); indexed == total {
+				fyne.Do(d.update)
+				return
+			}
+			select {
+			case <-ticker.C:
+				fyne.Do(d.update)
+			case <-closed:
+				return
+			}
+		}
+	}()
+
+	d.update()
+	d.dialog.Show()
+	d.window.Canvas().Focus(d.queryEntry)
+}
+
+// update lists the indexed POMs matching the query
+func (d *GotoArtifactDialog) update() {
+	index := d.workspace.Index()
+	query := strings.ToLower(strings.TrimSpace(d.queryEntry.Text))
+
+	d.entries = d.entries[:0]
+	for _, entry := range index.Entries() {
+		key := strings.ToLower(entry.Coordinates.GroupID + ":" + entry.Coordinates.ArtifactID)
+		if query == "" || strings.Contains(key, query) {
+			d.entries = append(d.entries, entry)
+		}
+	}
+
+	status := fmt.Sprintf("%d of %d POMs", len(d.entries), len(d.workspace.POMs))
+	if indexed, total := index.Progress(); indexed < total {
+		status = fmt.Sprintf("Indexing %d of %d POMs...", indexed, total)
+	}
+	d.statusLabel.SetText(status)
+	d.resultsList.UnselectAll()
+	d.resultsList.Refresh()
+}
+
+// open closes the dialog and opens a POM
+func (d *GotoArtifactDialog) open(path string) {
+	d.dialog.Hide()
+	if d.onOpen != nil {
+		d.onOpen(path)
+	}
+}
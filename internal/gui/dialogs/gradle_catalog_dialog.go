@@ -0,0 +1,102 @@
+package dialogs
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// GradleCatalogDialog lets the user pick which libraries of a Gradle version
+// catalog to import into dependencyManagement
+type GradleCatalogDialog struct {
+	window  fyne.Window
+	catalog *pom.GradleCatalog
+}
+
+// NewGradleCatalogDialog creates a dialog listing the catalog's libraries
+func NewGradleCatalogDialog(window fyne.Window, catalog *pom.GradleCatalog) *GradleCatalogDialog {
+	return &GradleCatalogDialog{
+		window:  window,
+		catalog: catalog,
+	}
+}
+
+// Show displays the dialog; callback receives the selected aliases
+func (d *GradleCatalogDialog) Show(callback func(aliases []string)) {
+	if len(d.catalog.Libraries) == 0 {
+		dialog.ShowInformation("Import Gradle Catalog", "The catalog has no [libraries] entries.", d.window)
+		return
+	}
+
+	// Check group labels map back to aliases
+	labels := make([]string, 0, len(d.catalog.Libraries))
+	aliases := make(map[string]string, len(d.catalog.Libraries))
+	for _, library := range d.catalog.Libraries {
+		label := fmt.Sprintf("%s  (%s:%s:%s)", library.Alias, library.GroupID, library.ArtifactID, d.versionLabel(library))
+		labels = append(labels, label)
+		aliases[label] = library.Alias
+	}
+
+	checks := widget.NewCheckGroup(labels, nil)
+	checks.SetSelected(labels)
+
+	selectAll := widget.NewButton("Select All", func() {
+		checks.SetSelected(labels)
+	})
+	selectNone := widget.NewButton("Select None", func() {
+		checks.SetSelected(nil)
+	})
+
+	hint := widget.NewLabel("Selected libraries are added to dependencyManagement. " +
+		"Versions from [versions] become properties named <key>.version.")
+	hint.Wrapping = fyne.TextWrapWord
+
+	content := container.NewBorder(
+		container.NewVBox(hint, container.NewHBox(selectAll, selectNone)),
+		nil, nil, nil,
+		container.NewVScroll(checks),
+	)
+
+	customDialog := dialog.NewCustomConfirm(
+		"Import Gradle Catalog",
+		"Import",
+		"Cancel",
+		content,
+		func(ok bool) {
+			if !ok || callback == nil {
+				return
+			}
+			selected := make([]string, 0, len(checks.Selected))
+			for _, label := range checks.Selected {
+				selected = append(selected, aliases[label])
+			}
+			if len(selected) > 0 {
+				callback(selected)
+			}
+		},
+		d.window,
+	)
+
+	customDialog.Resize(fyne.NewSize(600, 450))
+	customDialog.Show()
+}
+
+// versionLabel describes a library's version, naming the [versions] key it
+// refers to
+func (d *GradleCatalogDialog) versionLabel(library pom.GradleLibrary) string {
+	if library.VersionRef == "" {
+		if library.Version == "" {
+			return "no version"
+		}
+		return library.Version
+	}
+	if version, ok := d.catalog.Versions[library.VersionRef]; ok {
+		return fmt.Sprintf("%s = %s", library.VersionRef, version)
+	}
+	return library.VersionRef + " (undefined)"
+}
@@ -82,7 +82,11 @@ Default Lifecycle Phases:
 
 Running Phases:
 Execute: mvn <phase>
-Example: mvn clean install`,
+Example: mvn clean install
+
+Running a phase runs every phase before it. The Timeline tab shows the
+goals a command such as mvn package runs, including those the packaging
+binds without being declared in the POM.`,
 		},
 		{
 			title: "Properties",
@@ -161,7 +165,9 @@ Keyboard Shortcuts:
 • Ctrl+O - Open existing POM file
 • Ctrl+S - Save current file
 • Ctrl+Shift+S - Save as new file
-• Ctrl+W / Ctrl+Q - Quit application
+• Ctrl+W - Close the current POM's tab
+• Ctrl+Tab - Switch to the next open POM
+• Ctrl+Q - Quit application
 • F1 - Show this help
 • F5 - Refresh and validate
 
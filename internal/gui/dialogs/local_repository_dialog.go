@@ -0,0 +1,186 @@
+package dialogs
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/localrepo"
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// LocalRepositoryDialog browses the artifacts installed in the local Maven
+// repository, shows their POMs and adds them as dependencies, all without
+// network access
+type LocalRepositoryDialog struct {
+	window fyne.Window
+	dir    string
+	dialog dialog.Dialog
+
+	// UI components
+	queryEntry    *widget.Entry
+	resultsList   *widget.List
+	versionSelect *widget.Select
+	addButton     *widget.Button
+	pomView       *widget.TextGrid
+	statusLabel   *widget.Label
+
+	// State
+	artifacts []localrepo.Artifact // Everything installed
+	shown     []localrepo.Artifact // Artifacts matching the query
+	selected  *localrepo.Artifact
+
+	// Callbacks
+	onAdd func(dep pom.Dependency) error
+}
+
+// NewLocalRepositoryDialog creates a browser of the local repository dir
+func NewLocalRepositoryDialog(window fyne.Window, dir string) *LocalRepositoryDialog {
+	return &LocalRepositoryDialog{
+		window: window,
+		dir:    dir,
+	}
+}
+
+// Show displays the dialog; onAdd is called with each dependency to add,
+// and the dialog stays open to add more
+func (d *LocalRepositoryDialog) Show(onAdd func(dep pom.Dependency) error) {
+	d.onAdd = onAdd
+
+	d.queryEntry = widget.NewEntry()
+	d.queryEntry.SetPlaceHolder("groupId or artifactId...")
+	d.queryEntry.OnChanged = func(string) {
+		d.update()
+	}
+
+	d.statusLabel = widget.NewLabel("Scanning " + d.dir + "...")
+	d.statusLabel.Truncation = fyne.TextTruncateEllipsis
+
+	// Each row adds the newest installed version in one click
+	d.resultsList = widget.NewList(
+		func() int {
+			return len(d.shown)
+		},
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("template")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return container.NewBorder(nil, nil, nil, widget.NewButtonWithIcon("", theme.ContentAddIcon(), nil), label)
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			artifact := d.shown[id]
+			row := obj.(*fyne.Container)
+			row.Objects[0].(*widget.Label).SetText(fmt.Sprintf("%s  (%s)", artifact.Key(), artifact.Latest()))
+			row.Objects[1].(*widget.Button).OnTapped = func() {
+				d.add(artifact, artifact.Latest())
+			}
+		},
+	)
+	d.resultsList.OnSelected = func(id widget.ListItemID) {
+		d.selectArtifact(d.shown[id])
+	}
+
+	d.versionSelect = widget.NewSelect(nil, func(string) {
+		d.showPOM()
+	})
+	d.addButton = widget.NewButtonWithIcon("Add Dependency", theme.ContentAddIcon(), func() {
+		if d.selected != nil && d.versionSelect.Selected != "" {
+			d.add(*d.selected, d.versionSelect.Selected)
+		}
+	})
+	d.addButton.Importance = widget.HighImportance
+	d.addButton.Disable()
+
+	d.pomView = widget.NewTextGrid()
+	d.pomView.SetText("Select an artifact to see its POM.")
+
+	details := container.NewBorder(
+		container.NewBorder(nil, nil, widget.NewLabel("Version:"), d.addButton, d.versionSelect),
+		nil, nil, nil,
+		d.pomView,
+	)
+	split := container.NewHSplit(d.resultsList, details)
+	split.SetOffset(0.45)
+
+	content := container.NewBorder(d.queryEntry, d.statusLabel, nil, nil, split)
+	d.dialog = dialog.NewCustom("Local Repository", "Close", content, d.window)
+	d.dialog.Resize(fyne.NewSize(950, 600))
+
+	// Large repositories take a while to scan; stop when the dialog closes
+	ctx, cancel := context.WithCancel(context.Background())
+	d.dialog.SetOnClosed(cancel)
+	go func() {
+		artifacts, err := localrepo.Scan(ctx, d.dir)
+		if ctx.Err() != nil {
+			return
+		}
+		fyne.Do(func() {
+			if err != nil {
+				d.statusLabel.SetText(err.Error())
+				return
+			}
+			d.artifacts = artifacts
+			d.update()
+		})
+	}()
+
+	d.dialog.Show()
+	d.window.Canvas().Focus(d.queryEntry)
+}
+
+// update lists the artifacts matching the query
+func (d *LocalRepositoryDialog) update() {
+	if d.artifacts == nil {
+		return
+	}
+	d.shown = localrepo.Filter(d.artifacts, d.queryEntry.Text)
+	d.statusLabel.SetText(fmt.Sprintf("%d of %d artifacts in %s", len(d.shown), len(d.artifacts), d.dir))
+	d.resultsList.UnselectAll()
+	d.resultsList.Refresh()
+}
+
+// selectArtifact shows the newest version of an artifact
+func (d *LocalRepositoryDialog) selectArtifact(artifact localrepo.Artifact) {
+	d.selected = &artifact
+
+	// Newest first
+	versions := make([]string, len(artifact.Versions))
+	for i, version := range artifact.Versions {
+		versions[len(versions)-1-i] = version
+	}
+	d.versionSelect.Options = versions
+	d.versionSelect.SetSelected(artifact.Latest())
+	d.addButton.Enable()
+}
+
+// showPOM shows the POM of the selected version
+func (d *LocalRepositoryDialog) showPOM() {
+	if d.selected == nil || d.versionSelect.Selected == "" {
+		return
+	}
+	data, err := os.ReadFile(localrepo.POMPath(d.dir, d.selected.GroupID, d.selected.ArtifactID, d.versionSelect.Selected))
+	if err != nil {
+		d.pomView.SetText(err.Error())
+		return
+	}
+	d.pomView.SetText(string(data))
+	d.pomView.ScrollToTop()
+}
+
+// add adds a version of an artifact as a dependency
+func (d *LocalRepositoryDialog) add(artifact localrepo.Artifact, version string) {
+	if d.onAdd == nil {
+		return
+	}
+	dep := pom.Dependency{GroupID: artifact.GroupID, ArtifactID: artifact.ArtifactID, Version: version}
+	if err := d.onAdd(dep); err != nil {
+		dialog.ShowError(err, d.window)
+		return
+	}
+	d.statusLabel.SetText(fmt.Sprintf("Added %s:%s", artifact.Key(), version))
+}
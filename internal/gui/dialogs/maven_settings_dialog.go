@@ -0,0 +1,142 @@
+package dialogs
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/remote"
+)
+
+// MavenSettingsDialog shows, read-only, what the user's settings.xml
+// changes for remote lookups: which mirror serves each repository, through
+// which proxy and with which credentials. Passwords are never shown.
+type MavenSettingsDialog struct {
+	window fyne.Window
+}
+
+// NewMavenSettingsDialog creates a new settings.xml inspector
+func NewMavenSettingsDialog(window fyne.Window) *MavenSettingsDialog {
+	return &MavenSettingsDialog{
+		window: window,
+	}
+}
+
+// Show displays the settings read from path (nil when there is no such
+// file) or the error reading them, applied to the repositories lookups use
+// (Maven Central when empty)
+func (d *MavenSettingsDialog) Show(path string, settings *remote.Settings, loadErr error, repositories []string) {
+	content := container.NewVBox()
+	add := func(text string) {
+		label := widget.NewLabel(text)
+		label.Wrapping = fyne.TextWrapWord
+		content.Add(label)
+	}
+	heading := func(text string) {
+		content.Add(widget.NewSeparator())
+		content.Add(widget.NewLabelWithStyle(text, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	}
+
+	switch {
+	case loadErr != nil:
+		add(fmt.Sprintf("%v\n\nLookups ignore the file and reach the repositories directly until it is fixed.", loadErr))
+	case settings == nil:
+		add(fmt.Sprintf("There is no %s, so lookups reach the repositories directly.", path))
+	default:
+		add("Read from " + path + ". Lookups of versions and dependencies use its mirrors, proxies and credentials.")
+		if settings.LocalRepository != "" {
+			add("Local repository: " + settings.LocalRepository)
+		}
+	}
+
+	if len(repositories) == 0 {
+		repositories = []string{remote.MavenCentral}
+	}
+	heading("Repositories")
+	for _, repository := range repositories {
+		route := settings.Route(remote.RepositoryID(repository), repository)
+		content.Add(widget.NewLabelWithStyle(repository, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}))
+		add(strings.Join(describeRoute(route), "\n"))
+	}
+
+	if settings != nil {
+		if len(settings.Mirrors) > 0 {
+			heading("Mirrors")
+			for _, mirror := range settings.Mirrors {
+				add(fmt.Sprintf("%s: %s\n  mirror of %s", mirror.ID, mirror.URL, mirror.MirrorOf))
+			}
+		}
+		if len(settings.Proxies) > 0 {
+			heading("Proxies")
+			for _, proxy := range settings.Proxies {
+				add(describeProxy(proxy))
+			}
+		}
+		if len(settings.Servers) > 0 {
+			heading("Servers")
+			for _, server := range settings.Servers {
+				add(fmt.Sprintf("%s: %s", server.ID, describeServer(server)))
+			}
+		}
+	}
+
+	customDialog := dialog.NewCustom("Maven settings.xml", "Close", container.NewVScroll(content), d.window)
+	customDialog.Resize(fyne.NewSize(650, 500))
+	customDialog.Show()
+}
+
+// describeRoute says how a repository is reached, one line per aspect
+func describeRoute(route remote.Route) []string {
+	var lines []string
+	if route.Mirror != nil {
+		lines = append(lines, fmt.Sprintf("Served by mirror %s: %s", route.Mirror.ID, route.Mirror.URL))
+	} else {
+		lines = append(lines, "Served directly, no mirror matches")
+	}
+	if route.Proxy != nil {
+		lines = append(lines, fmt.Sprintf("Through proxy %s at %s", route.Proxy.ID, route.Proxy.Address()))
+	} else {
+		lines = append(lines, "No proxy")
+	}
+	if route.Server != nil {
+		lines = append(lines, fmt.Sprintf("Credentials of server %s: %s", route.Server.ID, describeServer(*route.Server)))
+	} else {
+		lines = append(lines, "No credentials")
+	}
+	return lines
+}
+
+// describeProxy summarizes a proxy without its password
+func describeProxy(proxy remote.Proxy) string {
+	text := fmt.Sprintf("%s: %s", proxy.ID, proxy.Address())
+	if proxy.Protocol != "" {
+		text += " (" + proxy.Protocol + ")"
+	}
+	if !proxy.IsActive() {
+		text += ", inactive"
+	}
+	if proxy.Username != "" {
+		text += ", user " + proxy.Username
+	}
+	if proxy.NonProxyHosts != "" {
+		text += "\n  not for " + proxy.NonProxyHosts
+	}
+	return text
+}
+
+// describeServer summarizes a server's credentials without the password
+func describeServer(server remote.Server) string {
+	switch {
+	case server.Username == "":
+		return "no user name, not used"
+	case server.Encrypted():
+		return "user " + server.Username + ", encrypted password: not sent, as lookups cannot decrypt it"
+	case server.Password == "":
+		return "user " + server.Username + ", no password"
+	}
+	return "user " + server.Username + ", password set"
+}
@@ -0,0 +1,199 @@
+package dialogs
+
+import (
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/maven"
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// MvnCommandDialog builds an mvn command line for the current POM, with an
+// explanation of every flag, for copying into a terminal or running
+type MvnCommandDialog struct {
+	window fyne.Window
+
+	// Form fields
+	taskSelect       *widget.Select
+	goalsEntry       *widget.Entry
+	profileChecks    *widget.CheckGroup
+	propertiesEntry  *widget.Entry
+	moduleChecks     *widget.CheckGroup
+	alsoMakeCheck    *widget.Check
+	dependentsCheck  *widget.Check
+	offlineCheck     *widget.Check
+	commandLabel     *widget.Label
+	explanationLabel *widget.Label
+}
+
+// NewMvnCommandDialog creates a new mvn command dialog
+func NewMvnCommandDialog(window fyne.Window) *MvnCommandDialog {
+	return &MvnCommandDialog{
+		window: window,
+	}
+}
+
+// Show displays the dialog for a project loaded from path ("" when never
+// saved); callback receives the command line to copy
+func (d *MvnCommandDialog) Show(project *pom.Project, path string, callback func(command string)) {
+	content, command := d.createForm(project, path)
+
+	customDialog := dialog.NewCustomConfirm(
+		"mvn Command",
+		"Copy",
+		"Close",
+		container.NewVScroll(content),
+		func(copyCommand bool) {
+			if copyCommand && callback != nil {
+				callback(command().String())
+			}
+		},
+		d.window,
+	)
+
+	customDialog.Resize(fyne.NewSize(600, 560))
+	customDialog.Show()
+}
+
+// ShowRun displays the dialog for running a build of the project saved at
+// path with launcher, the mvn or Maven wrapper found for it; callback
+// receives the command to run
+func (d *MvnCommandDialog) ShowRun(project *pom.Project, path, launcher string, callback func(command maven.Command)) {
+	content, command := d.createForm(project, path)
+	launcherLabel := widget.NewLabel("Runs with " + launcher + " in " + filepath.Dir(path))
+	launcherLabel.Wrapping = fyne.TextWrapBreak
+
+	customDialog := dialog.NewCustomConfirm(
+		"Run Build",
+		"Run",
+		"Cancel",
+		container.NewBorder(nil, launcherLabel, nil, nil, container.NewVScroll(content)),
+		func(run bool) {
+			if run && callback != nil {
+				callback(command())
+			}
+		},
+		d.window,
+	)
+
+	customDialog.Resize(fyne.NewSize(600, 580))
+	customDialog.Show()
+}
+
+// createForm creates the form for the project loaded from path; command
+// builds the command from it
+func (d *MvnCommandDialog) createForm(project *pom.Project, path string) (fyne.CanvasObject, func() maven.Command) {
+	var profileIDs []string
+	for _, profile := range project.Profiles {
+		profileIDs = append(profileIDs, profile.ID)
+	}
+
+	// command builds the command from the form
+	command := func() maven.Command {
+		c := maven.Command{
+			Goals:              strings.Fields(d.goalsEntry.Text),
+			Profiles:           d.profileChecks.Selected,
+			Properties:         parseProperties(d.propertiesEntry.Text),
+			Projects:           d.moduleChecks.Selected,
+			AlsoMake:           d.alsoMakeCheck.Checked,
+			AlsoMakeDependents: d.dependentsCheck.Checked,
+			Offline:            d.offlineCheck.Checked,
+		}
+		if name := filepath.Base(path); path != "" && name != "pom.xml" {
+			c.File = name
+		}
+		return c
+	}
+	update := func() {
+		c := command()
+		d.commandLabel.SetText(c.String())
+		d.explanationLabel.SetText(strings.Join(c.Explain(), "\n"))
+	}
+
+	d.commandLabel = widget.NewLabel("")
+	d.commandLabel.TextStyle = fyne.TextStyle{Monospace: true}
+	d.commandLabel.Wrapping = fyne.TextWrapBreak
+	d.explanationLabel = widget.NewLabel("")
+	d.explanationLabel.Wrapping = fyne.TextWrapWord
+
+	d.goalsEntry = widget.NewEntry()
+	d.goalsEntry.SetPlaceHolder("clean install")
+	d.goalsEntry.OnChanged = func(string) { update() }
+
+	d.propertiesEntry = widget.NewMultiLineEntry()
+	d.propertiesEntry.SetPlaceHolder("skipTests=true")
+	d.propertiesEntry.SetMinRowsVisible(2)
+	d.propertiesEntry.OnChanged = func(string) { update() }
+
+	d.profileChecks = widget.NewCheckGroup(profileIDs, func([]string) { update() })
+	d.profileChecks.Horizontal = true
+	d.moduleChecks = widget.NewCheckGroup(project.Modules, func([]string) { update() })
+	d.moduleChecks.Horizontal = true
+	d.alsoMakeCheck = widget.NewCheck("Also build the modules they need (-am)", func(bool) { update() })
+	d.dependentsCheck = widget.NewCheck("Also build the modules needing them (-amd)", func(bool) { update() })
+	d.offlineCheck = widget.NewCheck("Offline (-o)", func(bool) { update() })
+
+	taskDescription := widget.NewLabel("")
+	taskDescription.Wrapping = fyne.TextWrapWord
+	var taskNames []string
+	for _, task := range maven.Tasks {
+		taskNames = append(taskNames, task.Name)
+	}
+	d.taskSelect = widget.NewSelect(taskNames, func(selected string) {
+		for _, task := range maven.Tasks {
+			if task.Name != selected {
+				continue
+			}
+			taskDescription.SetText(task.Description)
+			var properties []string
+			for name, value := range task.Properties {
+				properties = append(properties, name+"="+value)
+			}
+			d.propertiesEntry.SetText(strings.Join(properties, "\n"))
+			d.goalsEntry.SetText(strings.Join(task.Goals, " "))
+			return
+		}
+	})
+	d.taskSelect.SetSelected(taskNames[0])
+
+	items := []*widget.FormItem{
+		{Text: "Task", Widget: d.taskSelect},
+		{Text: "", Widget: taskDescription},
+		{Text: "Goals", Widget: d.goalsEntry},
+		{Text: "Properties", Widget: d.propertiesEntry, HintText: "One name=value per line"},
+	}
+	if len(profileIDs) > 0 {
+		items = append(items, &widget.FormItem{Text: "Profiles", Widget: d.profileChecks})
+	}
+	if len(project.Modules) > 0 {
+		items = append(items, &widget.FormItem{Text: "Modules", Widget: container.NewVBox(d.moduleChecks, d.alsoMakeCheck, d.dependentsCheck)})
+	}
+	items = append(items, &widget.FormItem{Text: "", Widget: d.offlineCheck})
+	update()
+
+	content := container.NewVBox(
+		&widget.Form{Items: items},
+		widget.NewSeparator(),
+		d.commandLabel,
+		d.explanationLabel,
+	)
+	return content, command
+}
+
+// parseProperties reads name=value lines; a line with just a name sets
+// the property without a value, like -DskipTests
+func parseProperties(text string) map[string]string {
+	properties := make(map[string]string)
+	for _, line := range strings.Split(text, "\n") {
+		name, value, _ := strings.Cut(strings.TrimSpace(line), "=")
+		if name = strings.TrimSpace(name); name != "" {
+			properties[name] = strings.TrimSpace(value)
+		}
+	}
+	return properties
+}
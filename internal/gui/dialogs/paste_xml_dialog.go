@@ -0,0 +1,93 @@
+package dialogs
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// PasteXMLDialog adds dependencies and plugins from pasted POM XML, such as
+// the <dependency> blocks copied from a library's documentation
+type PasteXMLDialog struct {
+	window fyne.Window
+}
+
+// NewPasteXMLDialog creates a new paste dialog
+func NewPasteXMLDialog(window fyne.Window) *PasteXMLDialog {
+	return &PasteXMLDialog{window: window}
+}
+
+// Show displays the dialog with the given text, usually the clipboard's;
+// callback receives the parsed snippet when the user confirms
+func (d *PasteXMLDialog) Show(text string, callback func(*pom.Snippet)) {
+	xmlEntry := widget.NewMultiLineEntry()
+	xmlEntry.SetPlaceHolder("<dependency>\n    <groupId>...</groupId>\n    <artifactId>...</artifactId>\n    <version>...</version>\n</dependency>")
+	xmlEntry.TextStyle = fyne.TextStyle{Monospace: true}
+	xmlEntry.SetMinRowsVisible(10)
+
+	preview := widget.NewLabel("")
+	preview.TextStyle = fyne.TextStyle{Monospace: true}
+	warnings := widget.NewLabel("")
+	warnings.Wrapping = fyne.TextWrapWord
+	warnings.Importance = widget.WarningImportance
+
+	var snippet *pom.Snippet
+	update := func(text string) {
+		parsed, err := pom.ParseSnippet(text)
+		switch {
+		case strings.TrimSpace(text) == "":
+			snippet = nil
+			preview.SetText("Paste <dependency> or <plugin> elements above.")
+			warnings.SetText("")
+		case err != nil:
+			snippet = nil
+			preview.SetText("")
+			warnings.SetText(err.Error())
+		default:
+			snippet = parsed
+			if parsed.Empty() {
+				preview.SetText("No <dependency> or <plugin> elements found.")
+			} else {
+				preview.SetText(parsed.String())
+			}
+			warnings.SetText(strings.Join(parsed.Warnings, "\n"))
+		}
+	}
+	xmlEntry.OnChanged = update
+	xmlEntry.SetText(text)
+	update(text)
+
+	content := container.NewBorder(
+		widget.NewLabel("XML to add:"),
+		container.NewVBox(
+			widget.NewSeparator(),
+			widget.NewLabel("Will be added:"),
+			preview,
+			warnings,
+		),
+		nil, nil,
+		xmlEntry,
+	)
+
+	customDialog := dialog.NewCustomConfirm(
+		"Paste XML",
+		"Add",
+		"Cancel",
+		container.NewVScroll(content),
+		func(ok bool) {
+			if !ok || callback == nil || snippet == nil || snippet.Empty() {
+				return
+			}
+			callback(snippet)
+		},
+		d.window,
+	)
+
+	customDialog.Resize(fyne.NewSize(650, 550))
+	customDialog.Show()
+}
@@ -18,22 +18,24 @@ type PluginDialog struct {
 	groupIDEntry       *widget.Entry
 	artifactIDEntry    *widget.Entry
 	versionEntry       *widget.Entry
+	typoHint           *typoHint
+	configEditor       *configurationEditor
 
 	// Callbacks
 	onSave func(pom.Plugin)
 }
 
-// Common Maven plugins
+// Common Maven plugins; their versions come from pom.DefaultVersion so they
+// follow catalog refreshes
 var commonPlugins = map[string]struct {
 	GroupID    string
 	ArtifactID string
-	Version    string
 }{
-	"Maven Compiler Plugin": {"org.apache.maven.plugins", "maven-compiler-plugin", "3.11.0"},
-	"Maven JAR Plugin":      {"org.apache.maven.plugins", "maven-jar-plugin", "3.3.0"},
-	"Maven WAR Plugin":      {"org.apache.maven.plugins", "maven-war-plugin", "3.4.0"},
-	"Maven Surefire Plugin": {"org.apache.maven.plugins", "maven-surefire-plugin", "3.1.2"},
-	"Maven Assembly Plugin": {"org.apache.maven.plugins", "maven-assembly-plugin", "3.6.0"},
+	"Maven Compiler Plugin": {"org.apache.maven.plugins", "maven-compiler-plugin"},
+	"Maven JAR Plugin":      {"org.apache.maven.plugins", "maven-jar-plugin"},
+	"Maven WAR Plugin":      {"org.apache.maven.plugins", "maven-war-plugin"},
+	"Maven Surefire Plugin": {"org.apache.maven.plugins", "maven-surefire-plugin"},
+	"Maven Assembly Plugin": {"org.apache.maven.plugins", "maven-assembly-plugin"},
 }
 
 // NewPluginDialog creates a new plugin dialog
@@ -49,7 +51,8 @@ func (d *PluginDialog) ShowAdd(callback func(pom.Plugin)) {
 	d.show("Add Plugin", nil)
 }
 
-// ShowEdit displays the dialog for editing an existing plugin
+// ShowEdit displays the dialog for editing an existing plugin; its
+// executions, which the dialog does not show, are kept
 func (d *PluginDialog) ShowEdit(plugin pom.Plugin, callback func(pom.Plugin)) {
 	d.onSave = callback
 	d.show("Edit Plugin", &plugin)
@@ -64,7 +67,7 @@ func (d *PluginDialog) show(title string, existingPlugin *pom.Plugin) {
 			if plugin, ok := commonPlugins[selected]; ok {
 				d.groupIDEntry.SetText(plugin.GroupID)
 				d.artifactIDEntry.SetText(plugin.ArtifactID)
-				d.versionEntry.SetText(plugin.Version)
+				d.versionEntry.SetText(pom.DefaultVersion(plugin.GroupID, plugin.ArtifactID))
 			}
 		}
 	})
@@ -78,7 +81,7 @@ func (d *PluginDialog) show(title string, existingPlugin *pom.Plugin) {
 	d.artifactIDEntry.SetPlaceHolder("maven-compiler-plugin")
 
 	d.versionEntry = widget.NewEntry()
-	d.versionEntry.SetPlaceHolder("3.11.0")
+	d.versionEntry.SetPlaceHolder(pom.DefaultVersion(pom.DefaultPluginGroupID, "maven-compiler-plugin"))
 
 	// Populate fields if editing
 	if existingPlugin != nil {
@@ -97,8 +100,27 @@ func (d *PluginDialog) show(title string, existingPlugin *pom.Plugin) {
 		},
 	}
 
+	// Configuration parameters, completed and checked for the plugin entered
+	var config *pom.Configuration
+	if existingPlugin != nil {
+		config = existingPlugin.Configuration
+	}
+	d.configEditor = newConfigurationEditor(d.window, d.enteredPlugin, config)
+
+	// Flag likely typos as the coordinates are entered
+	d.typoHint = newTypoHint(d.groupIDEntry, d.artifactIDEntry, pom.DefaultPluginGroupID)
+	d.groupIDEntry.OnChanged = func(string) {
+		d.typoHint.check()
+		d.configEditor.check()
+	}
+	d.artifactIDEntry.OnChanged = func(string) {
+		d.typoHint.check()
+		d.configEditor.check()
+	}
+	d.typoHint.check()
+
 	// Create dialog
-	content := container.NewVBox(form)
+	content := container.NewVScroll(container.NewVBox(form, d.typoHint.container, widget.NewSeparator(), d.configEditor.container))
 
 	customDialog := dialog.NewCustomConfirm(
 		title,
@@ -107,17 +129,30 @@ func (d *PluginDialog) show(title string, existingPlugin *pom.Plugin) {
 		content,
 		func(save bool) {
 			if save && d.onSave != nil {
-				plugin := pom.Plugin{
-					GroupID:    d.groupIDEntry.Text,
-					ArtifactID: d.artifactIDEntry.Text,
-					Version:    d.versionEntry.Text,
+				plugin := pom.Plugin{}
+				if existingPlugin != nil {
+					plugin = *existingPlugin
 				}
+				plugin.GroupID = d.groupIDEntry.Text
+				plugin.ArtifactID = d.artifactIDEntry.Text
+				plugin.Version = d.versionEntry.Text
+				plugin.Configuration = d.configEditor.configuration()
 				d.onSave(plugin)
 			}
 		},
 		d.window,
 	)
 
-	customDialog.Resize(fyne.NewSize(450, 280))
+	customDialog.Resize(fyne.NewSize(560, 520))
 	customDialog.Show()
 }
+
+// enteredPlugin returns the coordinates entered, with Maven's default
+// groupId when none is
+func (d *PluginDialog) enteredPlugin() pom.Plugin {
+	groupID := d.groupIDEntry.Text
+	if groupID == "" {
+		groupID = pom.DefaultPluginGroupID
+	}
+	return pom.Plugin{GroupID: groupID, ArtifactID: d.artifactIDEntry.Text}
+}
@@ -0,0 +1,232 @@
+package dialogs
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/diff"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// ReviewChangesDialog shows the difference between the POM on disk and the
+// output that would be written, so reformatting or dropped elements can be
+// spotted before saving
+type ReviewChangesDialog struct {
+	window  fyne.Window
+	name    string
+	onDisk  string
+	pending string
+	changes []diff.Change
+}
+
+// NewReviewChangesDialog creates a dialog comparing the file on disk with the
+// generated XML
+func NewReviewChangesDialog(window fyne.Window, name string, onDisk, pending []byte) *ReviewChangesDialog {
+	return &ReviewChangesDialog{
+		window:  window,
+		name:    name,
+		onDisk:  string(onDisk),
+		pending: string(pending),
+	}
+}
+
+// SetChanges sets the semantic changes listed above the line diff
+func (d *ReviewChangesDialog) SetChanges(changes []diff.Change) {
+	d.changes = changes
+}
+
+// Show displays the dialog; onSave is called when the user confirms. A nil
+// onSave shows the review without a Save button.
+func (d *ReviewChangesDialog) Show(onSave func()) {
+	lines := diff.Lines(diff.SplitLines(d.onDisk), diff.SplitLines(d.pending))
+
+	if !diff.HasChanges(lines) {
+		if onSave == nil {
+			dialog.ShowInformation("Review Changes", "The generated POM is identical to the file on disk.", d.window)
+		} else {
+			onSave()
+		}
+		return
+	}
+
+	added, removed := 0, 0
+	for _, line := range lines {
+		switch line.Op {
+		case diff.Insert:
+			added++
+		case diff.Delete:
+			removed++
+		}
+	}
+	summary := widget.NewLabel(fmt.Sprintf("%s: %d line(s) added, %d line(s) removed", d.name, added, removed))
+
+	unified := d.unifiedView()
+	sideBySide := d.sideBySideView(lines)
+	sideBySide.Hide()
+
+	viewSelect := widget.NewRadioGroup([]string{"Unified", "Side by Side"}, func(value string) {
+		if value == "Side by Side" {
+			unified.Hide()
+			sideBySide.Show()
+		} else {
+			sideBySide.Hide()
+			unified.Show()
+		}
+	})
+	viewSelect.Horizontal = true
+	viewSelect.SetSelected("Unified")
+
+	header := container.NewVBox(summary)
+	if len(d.changes) > 0 {
+		header.Add(d.changesView())
+	}
+	header.Add(viewSelect)
+	header.Add(widget.NewSeparator())
+
+	content := container.NewBorder(
+		header,
+		nil, nil, nil,
+		container.NewStack(unified, sideBySide),
+	)
+
+	var reviewDialog dialog.Dialog
+	if onSave == nil {
+		reviewDialog = dialog.NewCustom("Review Changes", "Close", content, d.window)
+	} else {
+		reviewDialog = dialog.NewCustomConfirm("Review Changes", "Save", "Cancel", content, func(save bool) {
+			if save {
+				onSave()
+			}
+		}, d.window)
+	}
+	reviewDialog.Resize(fyne.NewSize(900, 600))
+	reviewDialog.Show()
+}
+
+// maxChangeRows is the height of the change list before it scrolls
+const maxChangeRows = 6
+
+// changesView lists the semantic changes, such as "junit:junit upgraded
+// 4.13.2 → 5.10.2", marked as additions, removals or modifications
+func (d *ReviewChangesDialog) changesView() fyne.CanvasObject {
+	return newChangesCard("", d.changes)
+}
+
+// newChangesCard lists changes in a card titled title, marked as
+// additions, removals or modifications
+func newChangesCard(title string, changes []diff.Change) fyne.CanvasObject {
+	list := container.NewVBox()
+	for _, change := range changes {
+		label := widget.NewLabel("")
+		switch change.Kind {
+		case diff.Added:
+			label.SetText("+ " + change.Description)
+			label.Importance = widget.SuccessImportance
+		case diff.Removed:
+			label.SetText("− " + change.Description)
+			label.Importance = widget.DangerImportance
+		default:
+			label.SetText("~ " + change.Description)
+		}
+		list.Add(label)
+	}
+
+	scroll := container.NewVScroll(list)
+	rows := min(len(changes), maxChangeRows)
+	scroll.SetMinSize(fyne.NewSize(0, float32(rows)*widget.NewLabel("").MinSize().Height))
+	return widget.NewCard(title, fmt.Sprintf("%d change(s)", len(changes)), scroll)
+}
+
+// unifiedView renders the changes in unified diff format
+func (d *ReviewChangesDialog) unifiedView() fyne.CanvasObject {
+	text := diff.Unified(d.name+" (on disk)", d.name+" (to be saved)", d.onDisk, d.pending, diffContext)
+	return container.NewScroll(newUnifiedGrid(text))
+}
+
+// newUnifiedGrid shows a unified diff with added lines in green, removed
+// lines in red and hunk headers in the primary color
+func newUnifiedGrid(text string) *widget.TextGrid {
+	grid := widget.NewTextGrid()
+	grid.SetText(strings.TrimSuffix(text, "\n"))
+	for row := range grid.Rows {
+		switch {
+		case strings.HasPrefix(grid.RowText(row), "+"):
+			grid.SetRowStyle(row, &widget.CustomTextGridStyle{FGColor: theme.Color(theme.ColorNameSuccess)})
+		case strings.HasPrefix(grid.RowText(row), "-"):
+			grid.SetRowStyle(row, &widget.CustomTextGridStyle{FGColor: theme.Color(theme.ColorNameError)})
+		case strings.HasPrefix(grid.RowText(row), "@@"):
+			grid.SetRowStyle(row, &widget.CustomTextGridStyle{FGColor: theme.Color(theme.ColorNamePrimary)})
+		}
+	}
+	return grid
+}
+
+// sideBySideView renders the file on disk and the pending output in two
+// aligned columns; runs of deleted and inserted lines are paired up
+func (d *ReviewChangesDialog) sideBySideView(lines []diff.Line) fyne.CanvasObject {
+	left := widget.NewTextGrid()
+	right := widget.NewTextGrid()
+
+	var leftRows, rightRows []string
+	var leftOps, rightOps []diff.Op
+	for i := 0; i < len(lines); {
+		if lines[i].Op == diff.Equal {
+			leftRows = append(leftRows, lines[i].Text)
+			rightRows = append(rightRows, lines[i].Text)
+			leftOps = append(leftOps, diff.Equal)
+			rightOps = append(rightOps, diff.Equal)
+			i++
+			continue
+		}
+
+		var deleted, inserted []string
+		for ; i < len(lines) && lines[i].Op != diff.Equal; i++ {
+			if lines[i].Op == diff.Delete {
+				deleted = append(deleted, lines[i].Text)
+			} else {
+				inserted = append(inserted, lines[i].Text)
+			}
+		}
+		for j := 0; j < max(len(deleted), len(inserted)); j++ {
+			leftText, leftOp := "", diff.Equal
+			if j < len(deleted) {
+				leftText, leftOp = deleted[j], diff.Delete
+			}
+			rightText, rightOp := "", diff.Equal
+			if j < len(inserted) {
+				rightText, rightOp = inserted[j], diff.Insert
+			}
+			leftRows = append(leftRows, leftText)
+			rightRows = append(rightRows, rightText)
+			leftOps = append(leftOps, leftOp)
+			rightOps = append(rightOps, rightOp)
+		}
+	}
+
+	left.SetText(strings.Join(leftRows, "\n"))
+	right.SetText(strings.Join(rightRows, "\n"))
+	for row, op := range leftOps {
+		if op == diff.Delete {
+			left.SetRowStyle(row, &widget.CustomTextGridStyle{FGColor: theme.Color(theme.ColorNameError)})
+		}
+	}
+	for row, op := range rightOps {
+		if op == diff.Insert {
+			right.SetRowStyle(row, &widget.CustomTextGridStyle{FGColor: theme.Color(theme.ColorNameSuccess)})
+		}
+	}
+
+	split := container.NewHSplit(
+		container.NewBorder(widget.NewLabel("On disk"), nil, nil, nil, left),
+		container.NewBorder(widget.NewLabel("To be saved"), nil, nil, nil, right),
+	)
+	return container.NewScroll(split)
+}
@@ -0,0 +1,76 @@
+package dialogs
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// SaveTemplateDialog asks for the name and description of a custom template
+// made from the current project
+type SaveTemplateDialog struct {
+	window fyne.Window
+
+	// Form fields
+	nameEntry        *widget.Entry
+	descriptionEntry *widget.Entry
+}
+
+// NewSaveTemplateDialog creates a new save template dialog
+func NewSaveTemplateDialog(window fyne.Window) *SaveTemplateDialog {
+	return &SaveTemplateDialog{
+		window: window,
+	}
+}
+
+// Show asks for a template name, which must be valid for a template file in
+// dir, and a description starting from the project's; callback receives
+// both and the path the template is saved to
+func (d *SaveTemplateDialog) Show(project *pom.Project, dir string, callback func(name, description, path string)) {
+	d.nameEntry = widget.NewEntry()
+	d.nameEntry.SetPlaceHolder("my-stack")
+	d.nameEntry.Validator = func(name string) error {
+		_, err := pom.TemplatePath(dir, strings.TrimSpace(name))
+		return err
+	}
+
+	d.descriptionEntry = widget.NewEntry()
+	d.descriptionEntry.SetText(project.Description)
+	d.descriptionEntry.SetPlaceHolder("Service with logging")
+
+	hint := widget.NewLabel("The project's coordinates become the {{groupId}}, {{artifactId}} " +
+		"and {{version}} placeholders; packaging, parent, properties, dependencies and plugins are kept. " +
+		"Templates are saved to " + dir + ".")
+	hint.Wrapping = fyne.TextWrapWord
+
+	formDialog := dialog.NewForm(
+		"Save as Template",
+		"Save",
+		"Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("Name", d.nameEntry),
+			widget.NewFormItem("Description", d.descriptionEntry),
+			widget.NewFormItem("", hint),
+		},
+		func(save bool) {
+			if !save || callback == nil {
+				return
+			}
+			name := strings.TrimSpace(d.nameEntry.Text)
+			path, err := pom.TemplatePath(dir, name)
+			if err != nil {
+				dialog.ShowError(err, d.window)
+				return
+			}
+			callback(name, strings.TrimSpace(d.descriptionEntry.Text), path)
+		},
+		d.window,
+	)
+
+	formDialog.Resize(fyne.NewSize(500, 300))
+	formDialog.Show()
+}
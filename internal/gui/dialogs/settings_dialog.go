@@ -3,6 +3,7 @@ package dialogs
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -11,6 +12,11 @@ import (
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
+	"github.com/user/pom-manager/internal/core/appdir"
+	"github.com/user/pom-manager/internal/core/httpcache"
+	"github.com/user/pom-manager/internal/core/maven"
+	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/core/remote"
 	"github.com/user/pom-manager/internal/gui/state"
 	"github.com/user/pom-manager/internal/gui/widgets"
 )
@@ -33,15 +39,30 @@ type SettingsDialog struct {
 	livePreviewCheck   *widget.Check
 	validationDelayEntry *widget.Entry
 	syntaxHighlightCheck *widget.Check
+	reviewBeforeSaveCheck *widget.Check
+	commitOnSaveCheck     *widget.Check
+	bannerCheck           *widget.Check
+	insertPositionSelect  *widget.Select
 
 	// Templates tab widgets
 	defaultTemplateSelect *widget.Select
 	customTemplateDirEntry *widget.Entry
+	registryEntry          *widget.Entry
+	registryRefEntry       *widget.Entry
+
+	// Validation tab widgets, keyed by rule ID
+	ruleSelects map[string]*widget.Select
 
 	// Advanced tab widgets
 	mavenTimeoutEntry   *widget.Entry
 	debugLogCheck       *widget.Check
 	cacheDirEntry       *widget.Entry
+	offlineCheck        *widget.Check
+	repositoriesEntry   *widget.Entry
+	catalogRefreshEntry *widget.Entry
+	httpCacheEntry      *widget.Entry
+	httpCacheLabel      *widget.Label
+	mavenPathEntry      *widget.Entry
 
 	// Callbacks
 	onSave func(*state.Settings)
@@ -52,6 +73,7 @@ func NewSettingsDialog(window fyne.Window, currentSettings *state.Settings) *Set
 	// Create a copy of current settings for editing
 	tempSettings := &state.Settings{}
 	*tempSettings = *currentSettings
+	tempSettings.ValidationRules = currentSettings.ValidationRules.Merge(nil)
 
 	return &SettingsDialog{
 		window:       window,
@@ -67,6 +89,7 @@ func (d *SettingsDialog) Show(onSave func(*state.Settings)) {
 	generalTab := d.createGeneralTab()
 	editorTab := d.createEditorTab()
 	templatesTab := d.createTemplatesTab()
+	validationTab := d.createValidationTab()
 	advancedTab := d.createAdvancedTab()
 
 	// Create tabbed container
@@ -74,6 +97,7 @@ func (d *SettingsDialog) Show(onSave func(*state.Settings)) {
 		container.NewTabItem("General", generalTab),
 		container.NewTabItem("Editor", editorTab),
 		container.NewTabItem("Templates", templatesTab),
+		container.NewTabItem("Validation", validationTab),
 		container.NewTabItem("Advanced", advancedTab),
 	)
 
@@ -156,7 +180,7 @@ func (d *SettingsDialog) createGeneralTab() fyne.CanvasObject {
 	d.autoSaveEntry.SetPlaceHolder("Minutes (0 = disabled)")
 
 	// Restore session checkbox
-	d.restoreSessionCheck = widget.NewCheck("Restore last opened file on startup", func(checked bool) {
+	d.restoreSessionCheck = widget.NewCheck("Reopen last session's files on startup", func(checked bool) {
 		d.tempSettings.RestoreSession = checked
 	})
 	d.restoreSessionCheck.SetChecked(d.tempSettings.RestoreSession)
@@ -213,12 +237,43 @@ func (d *SettingsDialog) createEditorTab() fyne.CanvasObject {
 	})
 	d.syntaxHighlightCheck.SetChecked(d.tempSettings.SyntaxHighlight)
 
+	// Review changes checkbox
+	d.reviewBeforeSaveCheck = widget.NewCheck("Review changes against the file on disk before saving", func(checked bool) {
+		d.tempSettings.ReviewBeforeSave = checked
+	})
+	d.reviewBeforeSaveCheck.SetChecked(d.tempSettings.ReviewBeforeSave)
+
+	// Commit on save checkbox; saves outside a Git repository skip the commit
+	d.commitOnSaveCheck = widget.NewCheck("Commit the POM to its Git repository after saving", func(checked bool) {
+		d.tempSettings.CommitOnSave = checked
+	})
+	d.commitOnSaveCheck.SetChecked(d.tempSettings.CommitOnSave)
+
+	// Banner checkbox; generator.banner in a project's .pom-manager.yaml wins
+	d.bannerCheck = widget.NewCheck("Start saved POMs with a generated-by comment", func(checked bool) {
+		d.tempSettings.GeneratorBanner = checked
+	})
+	d.bannerCheck.SetChecked(d.tempSettings.GeneratorBanner)
+
+	// Where added dependencies go; the Add Dependency dialog can override it
+	d.insertPositionSelect = widget.NewSelect(insertPositionOptions(pom.InsertPositions), func(label string) {
+		d.tempSettings.DependencyInsertion = insertPositionFor(label)
+	})
+	d.insertPositionSelect.SetSelected(insertPositionLabels[d.tempSettings.DependencyInsertion])
+	if d.insertPositionSelect.Selected == "" {
+		d.insertPositionSelect.SetSelected(insertPositionLabels[pom.InsertAtEnd])
+	}
+
 	form := &widget.Form{
 		Items: []*widget.FormItem{
 			{Text: "Font Size", Widget: fontSizeContainer},
 			{Text: "Live Preview", Widget: d.livePreviewCheck},
 			{Text: "Validation Delay (ms)", Widget: d.validationDelayEntry},
 			{Text: "Syntax Highlighting", Widget: d.syntaxHighlightCheck},
+			{Text: "Review Before Save", Widget: d.reviewBeforeSaveCheck},
+			{Text: "Commit on Save", Widget: d.commitOnSaveCheck},
+			{Text: "Generator Banner", Widget: d.bannerCheck},
+			{Text: "New Dependencies", Widget: d.insertPositionSelect},
 		},
 	}
 
@@ -233,7 +288,7 @@ func (d *SettingsDialog) createEditorTab() fyne.CanvasObject {
 func (d *SettingsDialog) createTemplatesTab() fyne.CanvasObject {
 	// Default template selection
 	d.defaultTemplateSelect = widget.NewSelect(
-		[]string{"basic-java", "java-library", "web-app"},
+		[]string{"basic-java", "java-library", "web-app", "spring-boot", "quarkus", "kotlin-jvm"},
 		func(value string) {
 			d.tempSettings.DefaultTemplate = value
 		},
@@ -259,10 +314,20 @@ func (d *SettingsDialog) createTemplatesTab() fyne.CanvasObject {
 		d.customTemplateDirEntry,
 	)
 
+	// Shared templates fetched into the cache directory
+	d.registryEntry = widget.NewEntry()
+	d.registryEntry.SetText(d.tempSettings.TemplateRegistry)
+	d.registryEntry.SetPlaceHolder("https://git.example.com/templates.git or https://.../templates.zip")
+	d.registryRefEntry = widget.NewEntry()
+	d.registryRefEntry.SetText(d.tempSettings.TemplateRegistryRef)
+	d.registryRefEntry.SetPlaceHolder("Git tag, branch or commit (empty for the default branch)")
+
 	form := &widget.Form{
 		Items: []*widget.FormItem{
 			{Text: "Default Template", Widget: d.defaultTemplateSelect},
 			{Text: "Custom Templates Dir", Widget: customDirContainer},
+			{Text: "Template Registry", Widget: d.registryEntry},
+			{Text: "Registry Version", Widget: d.registryRefEntry},
 		},
 	}
 
@@ -271,6 +336,50 @@ func (d *SettingsDialog) createTemplatesTab() fyne.CanvasObject {
 		widget.NewSeparator(),
 		form,
 		widget.NewLabel("Custom templates must follow the template structure."),
+		widget.NewLabel("Registry templates are fetched when the registry changes and with Edit > Update Template Registry."),
+	)
+}
+
+// ruleDefault is the rule level that leaves a rule at its default severity
+const ruleDefault = "default"
+
+// createValidationTab creates the Validation settings tab with a level per rule
+func (d *SettingsDialog) createValidationTab() fyne.CanvasObject {
+	d.ruleSelects = make(map[string]*widget.Select)
+	levels := []string{ruleDefault, pom.SeverityError.String(), pom.SeverityWarning.String(), pom.SeverityInfo.String(), pom.RuleOff}
+
+	form := &widget.Form{}
+	for _, rule := range pom.RuleRegistry {
+		id := rule.ID
+		ruleSelect := widget.NewSelect(levels, func(value string) {
+			if value == ruleDefault {
+				delete(d.tempSettings.ValidationRules, id)
+				return
+			}
+			d.tempSettings.ValidationRules[id] = value
+		})
+		if level, ok := d.tempSettings.ValidationRules[id]; ok {
+			ruleSelect.SetSelected(level)
+		} else {
+			ruleSelect.SetSelected(ruleDefault)
+		}
+		d.ruleSelects[id] = ruleSelect
+
+		form.Items = append(form.Items, &widget.FormItem{
+			Text:     id,
+			Widget:   ruleSelect,
+			HintText: fmt.Sprintf("%s (default: %s)", rule.Description, rule.Default),
+		})
+	}
+
+	return container.NewBorder(
+		container.NewVBox(
+			widget.NewLabel("Validation Rules"),
+			widget.NewSeparator(),
+		),
+		widget.NewLabel("A project's "+pom.ProjectConfigFile+" overrides these levels."),
+		nil, nil,
+		container.NewVScroll(form),
 	)
 }
 
@@ -290,7 +399,9 @@ func (d *SettingsDialog) createAdvancedTab() fyne.CanvasObject {
 	// Cache directory
 	d.cacheDirEntry = widget.NewEntry()
 	d.cacheDirEntry.SetText(d.tempSettings.CacheDir)
-	d.cacheDirEntry.SetPlaceHolder("Default: ~/.pom-manager/cache")
+	if cacheDir, err := appdir.CacheDir(); err == nil {
+		d.cacheDirEntry.SetPlaceHolder("Default: " + cacheDir)
+	}
 
 	browseCacheButton := widget.NewButton("Browse...", func() {
 		dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
@@ -306,11 +417,63 @@ func (d *SettingsDialog) createAdvancedTab() fyne.CanvasObject {
 		d.cacheDirEntry,
 	)
 
+	// Remote repositories, checked when adding dependencies
+	d.offlineCheck = widget.NewCheck("Don't check dependencies against remote repositories", func(checked bool) {
+		d.tempSettings.Offline = checked
+	})
+	d.offlineCheck.SetChecked(d.tempSettings.Offline)
+
+	d.repositoriesEntry = widget.NewMultiLineEntry()
+	d.repositoriesEntry.SetText(strings.Join(d.tempSettings.Repositories, "\n"))
+	d.repositoriesEntry.SetPlaceHolder(remote.MavenCentral)
+	d.repositoriesEntry.SetMinRowsVisible(3)
+
+	// Default version catalog refresh
+	d.catalogRefreshEntry = widget.NewEntry()
+	d.catalogRefreshEntry.SetText(fmt.Sprintf("%d", d.tempSettings.CatalogRefreshDays))
+	d.catalogRefreshEntry.SetPlaceHolder("Days (0 = manual only)")
+
+	// Cache of remote lookups
+	d.httpCacheEntry = widget.NewEntry()
+	d.httpCacheEntry.SetText(fmt.Sprintf("%d", d.tempSettings.HTTPCacheHours))
+	d.httpCacheEntry.SetPlaceHolder("Hours (0 = always revalidate)")
+
+	d.httpCacheLabel = widget.NewLabel("")
+	d.updateHTTPCacheSize()
+	clearCacheButton := widget.NewButtonWithIcon("Clear Cache", theme.DeleteIcon(), func() {
+		if cache := d.tempSettings.GetHTTPCache(); cache != nil {
+			if err := cache.Clear(); err != nil {
+				dialog.ShowError(err, d.window)
+			}
+		}
+		d.updateHTTPCacheSize()
+	})
+
+	// Maven that runs builds
+	d.mavenPathEntry = widget.NewEntry()
+	d.mavenPathEntry.SetText(d.tempSettings.MavenPath)
+	d.mavenPathEntry.SetPlaceHolder("Default: the project's mvnw, MAVEN_HOME or PATH")
+
+	browseMavenButton := widget.NewButton("Browse...", func() {
+		dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err == nil && reader != nil {
+				reader.Close()
+				d.mavenPathEntry.SetText(reader.URI().Path())
+			}
+		}, d.window)
+	})
+
 	form := &widget.Form{
 		Items: []*widget.FormItem{
 			{Text: "Maven Central Timeout (s)", Widget: d.mavenTimeoutEntry},
 			{Text: "Debug Logging", Widget: d.debugLogCheck},
 			{Text: "Cache Directory", Widget: cacheDirContainer},
+			{Text: "Offline", Widget: d.offlineCheck},
+			{Text: "Repositories", Widget: d.repositoriesEntry, HintText: "One URL per line"},
+			{Text: "Refresh Versions (days)", Widget: d.catalogRefreshEntry, HintText: "How often default plugin and dependency versions are looked up"},
+			{Text: "HTTP Cache (hours)", Widget: d.httpCacheEntry, HintText: "How long remote lookups are answered without asking the repository"},
+			{Text: "", Widget: container.NewBorder(nil, nil, nil, clearCacheButton, d.httpCacheLabel)},
+			{Text: "Maven Executable", Widget: container.NewBorder(nil, nil, nil, browseMavenButton, d.mavenPathEntry), HintText: "Runs the builds of Build > Run Build"},
 		},
 	}
 
@@ -322,6 +485,27 @@ func (d *SettingsDialog) createAdvancedTab() fyne.CanvasObject {
 	)
 }
 
+// updateHTTPCacheSize shows how much the cache of remote lookups holds,
+// measured in the background as the cache may be large
+func (d *SettingsDialog) updateHTTPCacheSize() {
+	cache := d.tempSettings.GetHTTPCache()
+	if cache == nil {
+		d.httpCacheLabel.SetText("No cache directory")
+		return
+	}
+	d.httpCacheLabel.SetText("Measuring cache...")
+	go func() {
+		count, size, err := cache.Size()
+		fyne.Do(func() {
+			if err != nil {
+				d.httpCacheLabel.SetText(err.Error())
+				return
+			}
+			d.httpCacheLabel.SetText(fmt.Sprintf("%d responses, %s", count, httpcache.FormatSize(size)))
+		})
+	}()
+}
+
 // validateSettings validates all settings before saving
 func (d *SettingsDialog) validateSettings() bool {
 	// Validate auto-save interval
@@ -348,6 +532,56 @@ func (d *SettingsDialog) validateSettings() bool {
 	}
 	d.tempSettings.MavenCentralTimeout = mavenTimeout
 
+	// Validate catalog refresh interval
+	catalogRefresh, err := strconv.Atoi(d.catalogRefreshEntry.Text)
+	if err != nil || catalogRefresh < 0 || catalogRefresh > 365 {
+		dialog.ShowError(fmt.Errorf("version refresh interval must be between 0 and 365 days"), d.window)
+		return false
+	}
+	d.tempSettings.CatalogRefreshDays = catalogRefresh
+
+	// Validate HTTP cache lifetime
+	httpCache, err := strconv.Atoi(d.httpCacheEntry.Text)
+	if err != nil || httpCache < 0 || httpCache > 720 {
+		dialog.ShowError(fmt.Errorf("HTTP cache lifetime must be between 0 and 720 hours"), d.window)
+		return false
+	}
+	d.tempSettings.HTTPCacheHours = httpCache
+
+	// Validate repository URLs
+	var repositories []string
+	for _, line := range strings.Split(d.repositoriesEntry.Text, "\n") {
+		repository := strings.TrimSpace(line)
+		if repository == "" {
+			continue
+		}
+		if !strings.HasPrefix(repository, "https://") && !strings.HasPrefix(repository, "http://") {
+			dialog.ShowError(fmt.Errorf("repository URL %q must start with http:// or https://", repository), d.window)
+			return false
+		}
+		repositories = append(repositories, repository)
+	}
+	d.tempSettings.Repositories = repositories
+
+	// Validate the Maven executable
+	d.tempSettings.MavenPath = strings.TrimSpace(d.mavenPathEntry.Text)
+	if d.tempSettings.MavenPath != "" {
+		if _, err := maven.Find(d.tempSettings.MavenPath); err != nil {
+			dialog.ShowError(err, d.window)
+			return false
+		}
+	}
+
+	// Validate the template registry
+	d.tempSettings.TemplateRegistry = strings.TrimSpace(d.registryEntry.Text)
+	d.tempSettings.TemplateRegistryRef = strings.TrimSpace(d.registryRefEntry.Text)
+	if d.tempSettings.TemplateRegistry != "" {
+		if err := d.tempSettings.RegistrySource().Validate(); err != nil {
+			dialog.ShowError(err, d.window)
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -366,13 +600,29 @@ func (d *SettingsDialog) resetToDefaults() {
 	d.livePreviewCheck.SetChecked(defaults.LivePreview)
 	d.validationDelayEntry.SetText(fmt.Sprintf("%d", defaults.ValidationDelay))
 	d.syntaxHighlightCheck.SetChecked(defaults.SyntaxHighlight)
+	d.reviewBeforeSaveCheck.SetChecked(defaults.ReviewBeforeSave)
+	d.commitOnSaveCheck.SetChecked(defaults.CommitOnSave)
+	d.bannerCheck.SetChecked(defaults.GeneratorBanner)
+	d.insertPositionSelect.SetSelected(insertPositionLabels[defaults.DependencyInsertion])
 
 	d.defaultTemplateSelect.SetSelected(defaults.DefaultTemplate)
 	d.customTemplateDirEntry.SetText(defaults.CustomTemplateDir)
+	d.registryEntry.SetText(defaults.TemplateRegistry)
+	d.registryRefEntry.SetText(defaults.TemplateRegistryRef)
+
+	defaults.ValidationRules = pom.RuleSettings{}
+	for _, ruleSelect := range d.ruleSelects {
+		ruleSelect.SetSelected(ruleDefault)
+	}
 
 	d.mavenTimeoutEntry.SetText(fmt.Sprintf("%d", defaults.MavenCentralTimeout))
 	d.debugLogCheck.SetChecked(defaults.EnableDebugLog)
 	d.cacheDirEntry.SetText(defaults.CacheDir)
+	d.offlineCheck.SetChecked(defaults.Offline)
+	d.repositoriesEntry.SetText("")
+	d.catalogRefreshEntry.SetText(fmt.Sprintf("%d", defaults.CatalogRefreshDays))
+	d.httpCacheEntry.SetText(fmt.Sprintf("%d", defaults.HTTPCacheHours))
+	d.mavenPathEntry.SetText(defaults.MavenPath)
 
 	// Apply default theme
 	d.applyThemePreview(defaults.Theme)
@@ -0,0 +1,127 @@
+package dialogs
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// Skip flag targets besides existing profiles
+const (
+	skipTargetAlways     = "Always (project properties)"
+	skipTargetNewProfile = "New profile..."
+)
+
+// SkipDialog toggles the skip properties of a plugin, such as skipTests or
+// maven.javadoc.skip, so users don't have to look up the flag names
+type SkipDialog struct {
+	window fyne.Window
+
+	// Form fields
+	targetSelect *widget.Select
+	profileEntry *widget.Entry
+	checks       map[string]*widget.Check
+}
+
+// NewSkipDialog creates a new skip dialog
+func NewSkipDialog(window fyne.Window) *SkipDialog {
+	return &SkipDialog{
+		window: window,
+	}
+}
+
+// Show displays the skip flags of a plugin as they are set in the project;
+// callback receives the target profile ("" for the project's properties)
+// and the state of every flag
+func (d *SkipDialog) Show(project *pom.Project, plugin pom.Plugin, callback func(profileID string, flags map[string]bool)) {
+	flags := pom.SkipFlags(plugin)
+
+	d.checks = make(map[string]*widget.Check)
+	checkList := container.NewVBox()
+	for _, flag := range flags {
+		check := widget.NewCheck(fmt.Sprintf("%s: %s", flag.Property, flag.Description), nil)
+		d.checks[flag.Property] = check
+		checkList.Add(check)
+	}
+
+	d.profileEntry = widget.NewEntry()
+	d.profileEntry.SetPlaceHolder("fast")
+	d.profileEntry.Disable()
+
+	// profileID returns the profile the flags go to, "" for the properties
+	profileID := func() string {
+		switch d.targetSelect.Selected {
+		case skipTargetAlways:
+			return ""
+		case skipTargetNewProfile:
+			return strings.TrimSpace(d.profileEntry.Text)
+		}
+		return d.targetSelect.Selected
+	}
+	// load shows the flags as set in the selected target
+	load := func() {
+		for property, check := range d.checks {
+			check.SetChecked(pom.SkipEnabled(project, profileID(), property))
+		}
+	}
+
+	targets := []string{skipTargetAlways}
+	for _, profile := range project.Profiles {
+		targets = append(targets, profile.ID)
+	}
+	targets = append(targets, skipTargetNewProfile)
+	d.targetSelect = widget.NewSelect(targets, func(selected string) {
+		if selected == skipTargetNewProfile {
+			d.profileEntry.Enable()
+		} else {
+			d.profileEntry.Disable()
+		}
+		load()
+	})
+	d.profileEntry.OnChanged = func(string) {
+		load()
+	}
+	d.targetSelect.SetSelected(skipTargetAlways)
+
+	hint := widget.NewLabel("Flags set always skip on every build. Flags set in a profile " +
+		"only skip when it is active, e.g. mvn -Pfast install.")
+	hint.Wrapping = fyne.TextWrapWord
+
+	form := &widget.Form{
+		Items: []*widget.FormItem{
+			{Text: "Set in", Widget: d.targetSelect},
+			{Text: "New profile ID", Widget: d.profileEntry},
+		},
+	}
+
+	customDialog := dialog.NewCustomConfirm(
+		"Skip "+plugin.ArtifactID,
+		"Apply",
+		"Cancel",
+		container.NewVBox(form, widget.NewSeparator(), checkList, hint),
+		func(apply bool) {
+			if !apply || callback == nil {
+				return
+			}
+			if d.targetSelect.Selected == skipTargetNewProfile && profileID() == "" {
+				dialog.ShowError(fmt.Errorf("enter an ID for the new profile"), d.window)
+				return
+			}
+			result := make(map[string]bool)
+			for property, check := range d.checks {
+				result[property] = check.Checked
+			}
+			callback(profileID(), result)
+		},
+		d.window,
+	)
+
+	customDialog.Resize(fyne.NewSize(500, 350))
+	customDialog.Show()
+}
@@ -0,0 +1,145 @@
+package dialogs
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/workspace"
+	"github.com/user/pom-manager/internal/gui/widgets"
+)
+
+// roleGuidance explains each role and when to switch patterns
+var roleGuidance = map[workspace.Role]string{
+	workspace.RoleStandalone: "This POM neither lists modules nor is used as a parent.",
+	workspace.RoleAggregator: "This POM lists modules so they are built together, but the modules do not " +
+		"inherit from it. Convert to Aggregator + Parent to share properties, dependencies, and plugins.",
+	workspace.RoleParent: "Other POMs inherit configuration from this one, but it does not list them as " +
+		"modules. Add modules if they should be built together.",
+	workspace.RoleAggregatorParent: "This POM builds its modules and they inherit from it. Convert to a pure " +
+		"aggregator if the modules should be configured independently.",
+}
+
+// StructureDialog shows whether a POM acts as aggregator, parent, or both,
+// and offers conversions between the two patterns
+type StructureDialog struct {
+	window    fyne.Window
+	structure *workspace.Structure
+	dialog    dialog.Dialog
+
+	// Callbacks
+	onConvertToParent     func()
+	onConvertToAggregator func()
+}
+
+// NewStructureDialog creates a dialog for the analyzed structure
+func NewStructureDialog(window fyne.Window, structure *workspace.Structure) *StructureDialog {
+	return &StructureDialog{
+		window:    window,
+		structure: structure,
+	}
+}
+
+// OnConvertToParent sets the callback for making modules inherit from the aggregator
+func (d *StructureDialog) OnConvertToParent(callback func()) {
+	d.onConvertToParent = callback
+}
+
+// OnConvertToAggregator sets the callback for detaching modules from the aggregator
+func (d *StructureDialog) OnConvertToAggregator(callback func()) {
+	d.onConvertToAggregator = callback
+}
+
+// Show displays the dialog
+func (d *StructureDialog) Show() {
+	s := d.structure
+
+	roleLabel := widget.NewLabelWithStyle(fmt.Sprintf("Role: %s", s.Role), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	guidance := widget.NewLabel(roleGuidance[s.Role])
+	guidance.Wrapping = fyne.TextWrapWord
+
+	var details strings.Builder
+	if len(s.Modules) > 0 {
+		details.WriteString(fmt.Sprintf("Modules (%d):\n", len(s.Modules)))
+		for _, module := range s.Modules {
+			switch {
+			case !module.Exists:
+				details.WriteString(fmt.Sprintf("  ✗ %s (missing)\n", module.Module))
+			case module.Inherits:
+				details.WriteString(fmt.Sprintf("  ● %s — %s, inherits\n", module.Module, module.ArtifactID))
+			default:
+				details.WriteString(fmt.Sprintf("  ○ %s — %s, own parent or none\n", module.Module, module.ArtifactID))
+			}
+		}
+	}
+	if len(s.Children) > 0 {
+		details.WriteString(fmt.Sprintf("\nInheriting POMs (%d):\n", len(s.Children)))
+		for _, child := range s.Children {
+			rel, err := filepath.Rel(filepath.Dir(s.Path), child)
+			if err != nil {
+				rel = child
+			}
+			details.WriteString(fmt.Sprintf("  %s\n", filepath.ToSlash(rel)))
+		}
+	}
+	if len(s.Warnings) > 0 {
+		details.WriteString(fmt.Sprintf("\nWarnings (%d):\n", len(s.Warnings)))
+		for _, warning := range s.Warnings {
+			details.WriteString(fmt.Sprintf("  ⚠ %s: %s (%s)\n", warning.Field, warning.Message, warning.Value))
+		}
+	}
+
+	detailsLabel := widget.NewLabel(details.String())
+	detailsLabel.Wrapping = fyne.TextWrapWord
+
+	toParentButton := widgets.NewButtonWithTooltip("Make Modules Inherit",
+		"Add a <parent> pointing at this POM to every module that has none",
+		func() {
+			d.dialog.Hide()
+			if d.onConvertToParent != nil {
+				d.onConvertToParent()
+			}
+		})
+	toAggregatorButton := widgets.NewButtonWithTooltip("Detach Modules",
+		"Remove the <parent> reference to this POM from its modules",
+		func() {
+			d.dialog.Hide()
+			if d.onConvertToAggregator != nil {
+				d.onConvertToAggregator()
+			}
+		})
+
+	// Only offer conversions that would change something
+	inheriting := 0
+	for _, module := range s.Modules {
+		if module.Inherits {
+			inheriting++
+		}
+	}
+	if len(s.Modules) == 0 || inheriting == len(s.Modules) {
+		toParentButton.Disable()
+	}
+	if inheriting == 0 {
+		toAggregatorButton.Disable()
+	}
+
+	content := container.NewBorder(
+		container.NewVBox(roleLabel, guidance, widget.NewSeparator()),
+		container.NewVBox(
+			widget.NewSeparator(),
+			widget.NewLabel("Conversion assistant:"),
+			container.NewHBox(toParentButton, toAggregatorButton),
+		),
+		nil, nil,
+		container.NewVScroll(detailsLabel),
+	)
+
+	d.dialog = dialog.NewCustom("Project Structure", "Close", content, d.window)
+	d.dialog.Resize(fyne.NewSize(600, 480))
+	d.dialog.Show()
+}
@@ -1,54 +1,76 @@
 package dialogs
 
 import (
+	"strings"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/gui/widgets"
 )
 
-// TemplateGallery displays available templates in a gallery view
+// TemplateGallery displays the built-in and custom templates in a
+// searchable list with a live preview of the POM each one generates
 type TemplateGallery struct {
 	window fyne.Window
 
 	// UI components
+	searchEntry  *widget.Entry
 	templateList *widget.List
-	previewText  *widget.Entry
+	descLabel    *widget.Label
+	preview      *widgets.XMLViewer
+	content      fyne.CanvasObject
 
 	// State
 	templates       []pom.TemplateInfo
-	selectedIndex   int
+	shown           []pom.TemplateInfo // templates matching the search
+	selected        string
+	coords          pom.Coordinates // Coordinates the preview is generated with
 	templateManager pom.TemplateManager
 
 	// Callbacks
-	onSelect func(templateName string)
+	onSelected func(info pom.TemplateInfo)
 }
 
 // NewTemplateGallery creates a new template gallery
 func NewTemplateGallery(window fyne.Window, templateManager pom.TemplateManager) *TemplateGallery {
-	return &TemplateGallery{
+	g := &TemplateGallery{
 		window:          window,
 		templateManager: templateManager,
-		selectedIndex:   -1,
+		templates:       templateManager.List(),
+		coords: pom.Coordinates{
+			GroupID:    "com.example",
+			ArtifactID: "sample-project",
+			Version:    "1.0.0",
+		},
 	}
+	g.shown = g.templates
+
+	g.createUI()
+	return g
 }
 
-// Show displays the template gallery
-func (g *TemplateGallery) Show(callback func(templateName string)) {
-	g.onSelect = callback
-	g.templates = g.templateManager.List()
+// createUI creates the gallery layout
+func (g *TemplateGallery) createUI() {
+	g.searchEntry = widget.NewEntry()
+	g.searchEntry.SetPlaceHolder("Search templates...")
+	g.searchEntry.OnChanged = func(string) {
+		g.applySearch()
+	}
 
-	// Create template list
 	g.templateList = widget.NewList(
 		func() int {
-			return len(g.templates)
+			return len(g.shown)
 		},
 		func() fyne.CanvasObject {
+			descLabel := widget.NewLabel("Description")
+			descLabel.Truncation = fyne.TextTruncateEllipsis
 			return container.NewVBox(
-				widget.NewLabel("Template Name"),
-				widget.NewLabel("Description"),
+				widget.NewLabelWithStyle("Template Name", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				descLabel,
 			)
 		},
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
@@ -56,71 +78,143 @@ func (g *TemplateGallery) Show(callback func(templateName string)) {
 			nameLabel := box.Objects[0].(*widget.Label)
 			descLabel := box.Objects[1].(*widget.Label)
 
-			template := g.templates[id]
-			nameLabel.SetText("📄 " + template.Name)
-			nameLabel.TextStyle = fyne.TextStyle{Bold: true}
+			template := g.shown[id]
+			if template.Path != "" {
+				nameLabel.SetText(template.Name + " (custom)")
+			} else {
+				nameLabel.SetText(template.Name)
+			}
 			descLabel.SetText(template.Description)
 		},
 	)
 
 	g.templateList.OnSelected = func(id widget.ListItemID) {
-		g.selectedIndex = int(id)
+		if int(id) >= len(g.shown) {
+			return
+		}
+		info := g.shown[id]
+		g.selected = info.Name
 		g.updatePreview()
+		if g.onSelected != nil {
+			g.onSelected(info)
+		}
 	}
 
-	// Create preview pane
-	g.previewText = widget.NewMultiLineEntry()
-	g.previewText.Disable()
-	g.previewText.SetPlaceHolder("Select a template to preview...")
-
-	// Create layout
-	content := container.NewHSplit(
-		g.templateList,
-		container.NewBorder(
-			widget.NewLabel("Preview:"),
-			nil, nil, nil,
-			container.NewScroll(g.previewText),
-		),
+	g.descLabel = widget.NewLabel("Select a template to preview it.")
+	g.descLabel.Wrapping = fyne.TextWrapWord
+	g.preview = widgets.NewXMLViewer()
+
+	split := container.NewHSplit(
+		container.NewBorder(g.searchEntry, nil, nil, nil, g.templateList),
+		container.NewBorder(g.descLabel, nil, nil, nil, g.preview),
 	)
-	content.SetOffset(0.4) // 40% for list, 60% for preview
+	split.SetOffset(0.35)
+	g.content = split
+}
+
+// applySearch lists the templates whose name or description contains the
+// search text. The selection stays, even while the search hides it.
+func (g *TemplateGallery) applySearch() {
+	query := strings.ToLower(strings.TrimSpace(g.searchEntry.Text))
+	g.shown = make([]pom.TemplateInfo, 0, len(g.templates))
+	for _, info := range g.templates {
+		if query == "" || strings.Contains(strings.ToLower(info.Name), query) ||
+			strings.Contains(strings.ToLower(info.Description), query) {
+			g.shown = append(g.shown, info)
+		}
+	}
+
+	g.templateList.UnselectAll()
+	g.templateList.Refresh()
+	for i, info := range g.shown {
+		if info.Name == g.selected {
+			g.templateList.Select(i)
+			return
+		}
+	}
+}
+
+// Content returns the gallery for embedding, such as in the New Project
+// wizard
+func (g *TemplateGallery) Content() fyne.CanvasObject {
+	return g.content
+}
+
+// Select selects a template by name; unknown names are ignored
+func (g *TemplateGallery) Select(name string) {
+	// Clear the search if it hides the template
+	for i, info := range g.shown {
+		if info.Name == name {
+			g.templateList.Select(i)
+			return
+		}
+	}
+	for _, info := range g.templates {
+		if info.Name == name {
+			g.selected = name
+			g.searchEntry.SetText("")
+			return
+		}
+	}
+}
+
+// Selected returns the name of the selected template, "" when there is none
+func (g *TemplateGallery) Selected() string {
+	return g.selected
+}
 
-	// Create dialog
+// SetCoordinates sets the coordinates the preview is generated with
+func (g *TemplateGallery) SetCoordinates(coords pom.Coordinates) {
+	g.coords = coords
+	g.updatePreview()
+}
+
+// OnSelected sets the callback for selecting a template
+func (g *TemplateGallery) OnSelected(callback func(info pom.TemplateInfo)) {
+	g.onSelected = callback
+}
+
+// Show displays the template gallery in a dialog
+func (g *TemplateGallery) Show(callback func(templateName string)) {
 	customDialog := dialog.NewCustomConfirm(
 		"Template Gallery",
 		"Use Template",
 		"Cancel",
-		content,
+		g.content,
 		func(useTemplate bool) {
-			if useTemplate && g.selectedIndex >= 0 && g.onSelect != nil {
-				template := g.templates[g.selectedIndex]
-				g.onSelect(template.Name)
+			if useTemplate && g.selected != "" && callback != nil {
+				callback(g.selected)
 			}
 		},
 		g.window,
 	)
 
-	customDialog.Resize(fyne.NewSize(700, 500))
+	customDialog.Resize(fyne.NewSize(800, 550))
 	customDialog.Show()
 }
 
-// updatePreview updates the preview pane with selected template
+// updatePreview shows the description of the selected template and the POM
+// it generates, with its variables at their defaults
 func (g *TemplateGallery) updatePreview() {
-	if g.selectedIndex < 0 || g.selectedIndex >= len(g.templates) {
+	var template *pom.TemplateInfo
+	for i := range g.templates {
+		if g.templates[i].Name == g.selected {
+			template = &g.templates[i]
+		}
+	}
+	if template == nil {
 		return
 	}
 
-	template := g.templates[g.selectedIndex]
-
-	// Generate a sample POM from the template
-	sampleCoords := pom.Coordinates{
-		GroupID:    "com.example",
-		ArtifactID: "sample-project",
-		Version:    "1.0.0",
+	description := template.Description
+	if template.Path != "" {
+		description = strings.TrimSpace(description + "\nCustom template: " + template.Path)
 	}
+	g.descLabel.SetText(description)
 
-	project, err := g.templateManager.Create(template.Name, sampleCoords)
+	project, err := g.templateManager.Create(template.Name, g.coords)
 	if err != nil {
-		g.previewText.SetText("Error generating preview: " + err.Error())
+		g.preview.SetXML("<!-- Error generating preview: " + err.Error() + " -->")
 		return
 	}
 
@@ -128,9 +222,9 @@ func (g *TemplateGallery) updatePreview() {
 	generator := pom.NewGenerator()
 	xmlData, err := generator.Generate(project)
 	if err != nil {
-		g.previewText.SetText("Error generating XML: " + err.Error())
+		g.preview.SetXML("<!-- Error generating XML: " + err.Error() + " -->")
 		return
 	}
 
-	g.previewText.SetText(string(xmlData))
+	g.preview.SetXML(string(xmlData))
 }
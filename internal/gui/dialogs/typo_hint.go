@@ -0,0 +1,68 @@
+package dialogs
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// typoHint suggests well-known coordinates when the entered ones look like a
+// misspelling, with a button to take the suggestion
+type typoHint struct {
+	groupIDEntry    *widget.Entry
+	artifactIDEntry *widget.Entry
+	defaultGroupID  string // Assumed when the groupId entry is empty
+
+	label     *widget.Label
+	button    *widget.Button
+	container *fyne.Container
+}
+
+// newTypoHint creates a hint for a pair of coordinate entries; call check
+// whenever they change
+func newTypoHint(groupIDEntry, artifactIDEntry *widget.Entry, defaultGroupID string) *typoHint {
+	h := &typoHint{
+		groupIDEntry:    groupIDEntry,
+		artifactIDEntry: artifactIDEntry,
+		defaultGroupID:  defaultGroupID,
+	}
+
+	h.label = widget.NewLabel("")
+	h.label.Importance = widget.WarningImportance
+	h.label.Wrapping = fyne.TextWrapWord
+	h.button = widget.NewButton("Use suggestion", nil)
+	h.container = container.NewBorder(nil, nil, nil, h.button, h.label)
+	h.container.Hide()
+
+	return h
+}
+
+// check updates the hint for the current entries and reports whether a
+// suggestion is shown
+func (h *typoHint) check() bool {
+	groupID := strings.TrimSpace(h.groupIDEntry.Text)
+	if groupID == "" {
+		groupID = h.defaultGroupID
+	}
+	artifactID := strings.TrimSpace(h.artifactIDEntry.Text)
+
+	suggestedGroupID, suggestedArtifactID, ok := pom.SuggestCoordinates(groupID, artifactID)
+	if !ok {
+		h.container.Hide()
+		return false
+	}
+
+	h.label.SetText("⚠ Did you mean " + suggestedGroupID + ":" + suggestedArtifactID + "?")
+	h.button.OnTapped = func() {
+		if h.groupIDEntry.Text != "" || suggestedGroupID != h.defaultGroupID {
+			h.groupIDEntry.SetText(suggestedGroupID)
+		}
+		h.artifactIDEntry.SetText(suggestedArtifactID)
+	}
+	h.container.Show()
+	return true
+}
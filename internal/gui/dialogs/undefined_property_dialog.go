@@ -0,0 +1,124 @@
+package dialogs
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Ways to resolve a reference to an undefined property
+const (
+	ResolveDefine   = "Define the property"
+	ResolveRename   = "Refer to another property instead"
+	ResolveExternal = "It is supplied outside the POM (-D, settings.xml)"
+)
+
+// PropertyResolution is how the user chose to resolve an undefined property
+type PropertyResolution struct {
+	Action   string // ResolveDefine, ResolveRename or ResolveExternal
+	Value    string // Value of the new property, for ResolveDefine
+	RenameTo string // Property to refer to, for ResolveRename
+}
+
+// UndefinedPropertyDialog offers the ways to resolve ${...} references to a
+// property that is not defined: define it, point the references at an
+// existing property, or record that it is supplied outside the POM
+type UndefinedPropertyDialog struct {
+	window fyne.Window
+
+	// Form fields
+	actionRadio  *widget.RadioGroup
+	valueEntry   *widget.Entry
+	renameSelect *widget.Select
+}
+
+// NewUndefinedPropertyDialog creates a new undefined property dialog
+func NewUndefinedPropertyDialog(window fyne.Window) *UndefinedPropertyDialog {
+	return &UndefinedPropertyDialog{
+		window: window,
+	}
+}
+
+// Show displays the choices for property key; detail says where it is
+// referenced, candidates are the defined properties the references may be
+// pointed at, with suggestion preselected when it is not ""
+func (d *UndefinedPropertyDialog) Show(key, detail string, candidates []string, suggestion string, callback func(resolution PropertyResolution)) {
+	d.valueEntry = widget.NewEntry()
+	d.valueEntry.SetPlaceHolder("Value of " + key)
+	d.renameSelect = widget.NewSelect(candidates, nil)
+	d.renameSelect.PlaceHolder = "Select a property"
+
+	actions := []string{ResolveDefine}
+	if len(candidates) > 0 {
+		actions = append(actions, ResolveRename)
+	}
+	actions = append(actions, ResolveExternal)
+	d.actionRadio = widget.NewRadioGroup(actions, func(selected string) {
+		if selected == ResolveDefine {
+			d.valueEntry.Enable()
+		} else {
+			d.valueEntry.Disable()
+		}
+		if selected == ResolveRename {
+			d.renameSelect.Enable()
+		} else {
+			d.renameSelect.Disable()
+		}
+	})
+	d.actionRadio.Required = true
+	if suggestion != "" {
+		d.renameSelect.SetSelected(suggestion)
+		d.actionRadio.SetSelected(ResolveRename)
+	} else {
+		d.actionRadio.SetSelected(ResolveDefine)
+	}
+
+	detailLabel := widget.NewLabel(detail)
+	detailLabel.Wrapping = fyne.TextWrapWord
+	hint := widget.NewLabel("Properties supplied outside the POM are listed under " +
+		"validation.external_properties in .pom-manager.yaml and no longer reported.")
+	hint.Wrapping = fyne.TextWrapWord
+
+	form := &widget.Form{
+		Items: []*widget.FormItem{
+			{Text: "Value", Widget: d.valueEntry},
+			{Text: "Refer to", Widget: d.renameSelect},
+		},
+	}
+
+	customDialog := dialog.NewCustomConfirm(
+		fmt.Sprintf("Undefined Property ${%s}", key),
+		"Apply",
+		"Cancel",
+		container.NewVBox(detailLabel, d.actionRadio, form, widget.NewSeparator(), hint),
+		func(apply bool) {
+			if !apply || callback == nil {
+				return
+			}
+			resolution := PropertyResolution{Action: d.actionRadio.Selected}
+			switch resolution.Action {
+			case ResolveDefine:
+				resolution.Value = strings.TrimSpace(d.valueEntry.Text)
+				if resolution.Value == "" {
+					dialog.ShowError(fmt.Errorf("enter a value for %s", key), d.window)
+					return
+				}
+			case ResolveRename:
+				resolution.RenameTo = d.renameSelect.Selected
+				if resolution.RenameTo == "" {
+					dialog.ShowError(fmt.Errorf("select the property to refer to"), d.window)
+					return
+				}
+			}
+			callback(resolution)
+		},
+		d.window,
+	)
+
+	customDialog.Resize(fyne.NewSize(500, 350))
+	customDialog.Show()
+}
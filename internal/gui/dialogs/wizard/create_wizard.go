@@ -2,6 +2,7 @@ package wizard
 
 import (
 	"fmt"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -9,12 +10,15 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/gui/dialogs"
 	"github.com/user/pom-manager/internal/gui/widgets"
 )
 
 // CreateWizard is a multi-step wizard for creating new POM files
 type CreateWizard struct {
-	window fyne.Window
+	window          fyne.Window
+	templateManager pom.TemplateManager
+	templates       []pom.TemplateInfo
 
 	// Step 1: Coordinates
 	groupIDEntry    *widget.Entry
@@ -23,8 +27,17 @@ type CreateWizard struct {
 	packagingSelect *widget.Select
 
 	// Step 2: Template selection
-	templateSelect *widget.RadioGroup
-	templateDesc   *widget.Label
+	gallery       *dialogs.TemplateGallery
+	template      string // Selected template
+	scaffoldCheck *widget.Check
+
+	// Step 3: JavaCard applet, for the javacard template only
+	appletClassEntry *widget.Entry
+	packageAIDEntry  *widget.Entry
+	appletAIDEntry   *widget.Entry
+
+	// Step 3: Template variables, for templates that declare them
+	variableEntries map[string]*widget.Entry
 
 	// Wizard state
 	currentStep int
@@ -35,15 +48,25 @@ type CreateWizard struct {
 	onCancel   func()
 }
 
-// NewCreateWizard creates a new project creation wizard
-func NewCreateWizard(window fyne.Window) *CreateWizard {
+// NewCreateWizard creates a new project creation wizard offering the
+// templates of templateManager, built-in and custom
+func NewCreateWizard(window fyne.Window, templateManager pom.TemplateManager) *CreateWizard {
 	return &CreateWizard{
-		window:      window,
-		currentStep: 1,
-		maxSteps:    2,
+		window:          window,
+		templateManager: templateManager,
+		templates:       templateManager.List(),
+		template:        "basic-java",
+		currentStep:     1,
+		maxSteps:        2,
 	}
 }
 
+// SetTemplate selects a template in advance, such as one picked on the
+// start page
+func (w *CreateWizard) SetTemplate(template string) {
+	w.template = template
+}
+
 // Show displays the wizard
 func (w *CreateWizard) Show(onComplete func(pom.Coordinates, string)) {
 	w.onComplete = onComplete
@@ -108,41 +131,37 @@ func (w *CreateWizard) showStep1() {
 	customDialog.Show()
 }
 
-// showStep2 displays Step 2: Template Selection
+// showStep2 displays Step 2: Template Selection, a gallery previewing the
+// POM each template generates for the entered coordinates
 func (w *CreateWizard) showStep2() {
-	// Template options
-	templates := []string{
-		"basic-java",
-		"java-library",
-		"web-app",
-		"javacard",
-	}
-
-	descriptions := map[string]string{
-		"basic-java":   "Basic Java JAR project with compiler plugin",
-		"java-library": "Java library project with compiler and JAR plugins",
-		"web-app":      "Java web application (WAR) project",
-		"javacard":     "JavaCard applet project for smart cards (CAP packaging)",
-	}
-
-	w.templateSelect = widget.NewRadioGroup(templates, func(selected string) {
-		if desc, ok := descriptions[selected]; ok {
-			w.templateDesc.SetText(desc)
+	// The javacard template asks for the applet in one more step
+	var finishButton *widgets.ButtonWithTooltip
+	w.gallery = dialogs.NewTemplateGallery(w.window, w.templateManager)
+	w.gallery.SetCoordinates(w.coordinates())
+	w.gallery.OnSelected(func(info pom.TemplateInfo) {
+		w.template = info.Name
+		if finishButton == nil {
+			return
+		}
+		if info.Name == "javacard" || len(info.Variables) > 0 {
+			finishButton.SetText("Next")
+		} else {
+			finishButton.SetText("Finish")
 		}
 	})
-	w.templateSelect.SetSelected("basic-java")
 
-	w.templateDesc = widget.NewLabel(descriptions["basic-java"])
-	w.templateDesc.Wrapping = fyne.TextWrapWord
+	// Keep the choice when going back
+	if w.scaffoldCheck == nil {
+		w.scaffoldCheck = widget.NewCheck("Also create the source tree (src/main, src/test, sample classes, .gitignore)", nil)
+	}
 
-	content := container.NewVBox(
-		widget.NewLabel("Step 2 of 2: Choose Template"),
-		widget.NewSeparator(),
-		widget.NewLabel("Select a project template:"),
-		w.templateSelect,
-		widget.NewSeparator(),
-		widget.NewLabel("Description:"),
-		w.templateDesc,
+	content := container.NewBorder(
+		container.NewVBox(
+			widget.NewLabel("Step 2 of 2: Choose Template"),
+			widget.NewSeparator(),
+		),
+		w.scaffoldCheck, nil, nil,
+		w.gallery.Content(),
 	)
 
 	// Create dialog variable to reference in button callbacks
@@ -158,18 +177,25 @@ func (w *CreateWizard) showStep2() {
 			}
 		})
 
-	finishButton := widgets.NewButtonWithTooltip("Finish",
+	finishButton = widgets.NewButtonWithTooltip("Finish",
 		"Create the project with the selected template",
 		func() {
+			if w.gallery.Selected() == "" {
+				dialog.ShowError(fmt.Errorf("select a template"), w.window)
+				return
+			}
 			if customDialog != nil {
 				customDialog.Hide()
+				if w.template == "javacard" {
+					w.showJavaCardStep()
+					return
+				}
+				if len(w.variables(w.template)) > 0 {
+					w.showVariablesStep()
+					return
+				}
 				if w.onComplete != nil {
-					coords := pom.Coordinates{
-						GroupID:    w.groupIDEntry.Text,
-						ArtifactID: w.artifactIDEntry.Text,
-						Version:    w.versionEntry.Text,
-					}
-					w.onComplete(coords, w.templateSelect.Selected)
+					w.onComplete(w.coordinates(), w.template)
 				}
 			}
 		})
@@ -178,6 +204,7 @@ func (w *CreateWizard) showStep2() {
 		backButton,
 		finishButton,
 	)
+	w.gallery.Select(w.template)
 
 	// Build the complete content with buttons BEFORE creating dialog
 	finalContent := container.NewBorder(
@@ -193,6 +220,211 @@ func (w *CreateWizard) showStep2() {
 		w.window,
 	)
 
-	customDialog.Resize(fyne.NewSize(450, 350))
+	customDialog.Resize(fyne.NewSize(800, 550))
+	customDialog.Show()
+}
+
+// coordinates returns the coordinates entered in Step 1
+func (w *CreateWizard) coordinates() pom.Coordinates {
+	return pom.Coordinates{
+		GroupID:    w.groupIDEntry.Text,
+		ArtifactID: w.artifactIDEntry.Text,
+		Version:    w.versionEntry.Text,
+	}
+}
+
+// showJavaCardStep displays Step 3: JavaCard applet class and AIDs
+func (w *CreateWizard) showJavaCardStep() {
+	coords := pom.Coordinates{
+		GroupID:    w.groupIDEntry.Text,
+		ArtifactID: w.artifactIDEntry.Text,
+		Version:    w.versionEntry.Text,
+	}
+	defaults := pom.DefaultJavaCardApplet(coords)
+
+	// Keep values entered before going back
+	if w.appletClassEntry == nil {
+		w.appletClassEntry = widget.NewEntry()
+		w.packageAIDEntry = widget.NewEntry()
+		w.appletAIDEntry = widget.NewEntry()
+	}
+	if w.appletClassEntry.Text == "" {
+		w.appletClassEntry.SetText(defaults.Class)
+	}
+	if w.packageAIDEntry.Text == "" {
+		w.packageAIDEntry.SetText(defaults.PackageAID)
+	}
+	if w.appletAIDEntry.Text == "" {
+		w.appletAIDEntry.SetText(defaults.AppletAID)
+	}
+
+	form := &widget.Form{
+		Items: []*widget.FormItem{
+			{Text: "Applet class *", Widget: w.appletClassEntry},
+			{Text: "Package AID *", Widget: w.packageAIDEntry, HintText: "5 to 16 bytes in hex"},
+			{Text: "Applet AID *", Widget: w.appletAIDEntry, HintText: "Starts with the package's 5-byte RID"},
+		},
+	}
+
+	hint := widget.NewLabel("The defaults use a proprietary RID (F0...) derived from the group ID. " +
+		"Use your registered RID for applets that ship. The project also gets a gp-deploy profile " +
+		"that installs the CAP file with GlobalPlatformPro on mvn install -Pgp-deploy.")
+	hint.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(
+		widget.NewLabel("Step 3 of 3: JavaCard Applet"),
+		widget.NewSeparator(),
+		form,
+		hint,
+	)
+
+	var customDialog dialog.Dialog
+
+	backButton := widgets.NewButtonWithTooltip("Back",
+		"Go back to template selection",
+		func() {
+			customDialog.Hide()
+			w.showStep2()
+		})
+
+	finishButton := widgets.NewButtonWithTooltip("Finish",
+		"Create the JavaCard project with this applet",
+		func() {
+			applet := w.JavaCardApplet()
+			if err := applet.Validate(); err != nil {
+				dialog.ShowError(err, w.window)
+				return
+			}
+			customDialog.Hide()
+			if w.onComplete != nil {
+				w.onComplete(coords, "javacard")
+			}
+		})
+
+	customDialog = dialog.NewCustom(
+		"New POM Project",
+		"Cancel",
+		container.NewBorder(nil, container.NewHBox(backButton, finishButton), nil, nil, content),
+		w.window,
+	)
+
+	customDialog.Resize(fyne.NewSize(500, 380))
 	customDialog.Show()
 }
+
+// variables returns the variables declared by a template
+func (w *CreateWizard) variables(template string) []pom.TemplateVariable {
+	for _, info := range w.templates {
+		if info.Name == template {
+			return info.Variables
+		}
+	}
+	return nil
+}
+
+// showVariablesStep displays Step 3: values for the selected template's
+// variables, starting from their defaults
+func (w *CreateWizard) showVariablesStep() {
+	template := w.template
+	variables := w.variables(template)
+
+	// Keep values entered before going back
+	if w.variableEntries == nil {
+		w.variableEntries = make(map[string]*widget.Entry)
+	}
+	form := &widget.Form{}
+	for _, variable := range variables {
+		entry, ok := w.variableEntries[variable.Name]
+		if !ok {
+			entry = widget.NewEntry()
+			entry.SetText(variable.Default)
+			w.variableEntries[variable.Name] = entry
+		}
+		label := variable.Name
+		if variable.Default == "" {
+			label += " *"
+		}
+		form.AppendItem(&widget.FormItem{Text: label, Widget: entry, HintText: variable.Description})
+	}
+
+	content := container.NewVBox(
+		widget.NewLabel("Step 3 of 3: Template Variables"),
+		widget.NewSeparator(),
+		widget.NewLabel(fmt.Sprintf("Values for the %s template:", template)),
+		form,
+	)
+
+	var customDialog dialog.Dialog
+
+	backButton := widgets.NewButtonWithTooltip("Back",
+		"Go back to template selection",
+		func() {
+			customDialog.Hide()
+			w.showStep2()
+		})
+
+	finishButton := widgets.NewButtonWithTooltip("Finish",
+		"Create the project with these values",
+		func() {
+			for _, variable := range variables {
+				if variable.Default == "" && strings.TrimSpace(w.variableEntries[variable.Name].Text) == "" {
+					dialog.ShowError(fmt.Errorf("enter a value for %s", variable.Name), w.window)
+					return
+				}
+			}
+			customDialog.Hide()
+			if w.onComplete != nil {
+				w.onComplete(pom.Coordinates{
+					GroupID:    w.groupIDEntry.Text,
+					ArtifactID: w.artifactIDEntry.Text,
+					Version:    w.versionEntry.Text,
+				}, template)
+			}
+		})
+
+	customDialog = dialog.NewCustom(
+		"New POM Project",
+		"Cancel",
+		container.NewBorder(nil, container.NewHBox(backButton, finishButton), nil, nil, container.NewVScroll(content)),
+		w.window,
+	)
+
+	customDialog.Resize(fyne.NewSize(500, 380))
+	customDialog.Show()
+}
+
+// Values returns the values entered for the selected template's variables;
+// variables not shown in the variables step are left out and get their
+// default
+func (w *CreateWizard) Values() map[string]string {
+	values := make(map[string]string)
+	for _, variable := range w.variables(w.template) {
+		if entry, ok := w.variableEntries[variable.Name]; ok {
+			values[variable.Name] = strings.TrimSpace(entry.Text)
+		}
+	}
+	return values
+}
+
+// Scaffold reports whether the source tree should be created next to the
+// POM once it is saved
+func (w *CreateWizard) Scaffold() bool {
+	return w.scaffoldCheck != nil && w.scaffoldCheck.Checked
+}
+
+// JavaCardApplet returns the applet entered in the JavaCard step, or the
+// defaults for the coordinates when the step was not shown
+func (w *CreateWizard) JavaCardApplet() pom.JavaCardApplet {
+	if w.appletClassEntry == nil {
+		return pom.DefaultJavaCardApplet(pom.Coordinates{
+			GroupID:    w.groupIDEntry.Text,
+			ArtifactID: w.artifactIDEntry.Text,
+			Version:    w.versionEntry.Text,
+		})
+	}
+	return pom.JavaCardApplet{
+		Class:      w.appletClassEntry.Text,
+		PackageAID: w.packageAIDEntry.Text,
+		AppletAID:  w.appletAIDEntry.Text,
+	}
+}
@@ -0,0 +1,216 @@
+package wizard
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/gui/widgets"
+)
+
+// SiblingModule is an existing module the new module can be added to as a dependency
+type SiblingModule struct {
+	Label string // Display name (path relative to the workspace)
+	Path  string // Path of the module's pom.xml
+}
+
+// ModuleSpec is the result of the module wizard
+type ModuleSpec struct {
+	Module     string   // Module path relative to the aggregator
+	ArtifactID string   // Module artifactId
+	Template   string   // Template name
+	Dependents []string // POM paths of siblings that should depend on the module
+}
+
+// ModuleWizard is a two-step wizard for adding a module to an aggregator POM
+type ModuleWizard struct {
+	window     fyne.Window
+	aggregator *pom.Project
+	templates  []pom.TemplateInfo
+	siblings   []SiblingModule
+
+	// Step 1: Module
+	modulePathEntry *widget.Entry
+	artifactIDEntry *widget.Entry
+	templateSelect  *widget.Select
+
+	// Step 2: Dependents
+	dependentsCheck *widget.CheckGroup
+
+	// Callbacks
+	onComplete func(spec ModuleSpec)
+}
+
+// NewModuleWizard creates a wizard adding a module to the given aggregator
+func NewModuleWizard(window fyne.Window, aggregator *pom.Project, templates []pom.TemplateInfo, siblings []SiblingModule) *ModuleWizard {
+	return &ModuleWizard{
+		window:     window,
+		aggregator: aggregator,
+		templates:  templates,
+		siblings:   siblings,
+	}
+}
+
+// Show displays the wizard
+func (w *ModuleWizard) Show(onComplete func(ModuleSpec)) {
+	w.onComplete = onComplete
+
+	w.modulePathEntry = widget.NewEntry()
+	w.modulePathEntry.SetPlaceHolder("core or services/api")
+
+	w.artifactIDEntry = widget.NewEntry()
+	w.artifactIDEntry.SetPlaceHolder("defaults to the last path segment")
+
+	// Keep the artifactId in sync with the path until the user edits it
+	autoArtifactID := ""
+	w.modulePathEntry.OnChanged = func(text string) {
+		if w.artifactIDEntry.Text == autoArtifactID {
+			autoArtifactID = path.Base(strings.Trim(strings.ReplaceAll(text, "\\", "/"), "/"))
+			if autoArtifactID == "." {
+				autoArtifactID = ""
+			}
+			w.artifactIDEntry.SetText(autoArtifactID)
+		}
+	}
+
+	names := make([]string, len(w.templates))
+	for i, template := range w.templates {
+		names[i] = template.Name
+	}
+	w.templateSelect = widget.NewSelect(names, nil)
+	w.templateSelect.SetSelected("basic-java")
+
+	labels := make([]string, len(w.siblings))
+	for i, sibling := range w.siblings {
+		labels[i] = sibling.Label
+	}
+	w.dependentsCheck = widget.NewCheckGroup(labels, nil)
+
+	w.showStep1()
+}
+
+// showStep1 displays Step 1: Module path, artifactId, and template
+func (w *ModuleWizard) showStep1() {
+	form := &widget.Form{
+		Items: []*widget.FormItem{
+			{Text: "Module Path *", Widget: w.modulePathEntry},
+			{Text: "Artifact ID", Widget: w.artifactIDEntry},
+			{Text: "Template", Widget: w.templateSelect},
+		},
+	}
+
+	inherited := widget.NewLabel(fmt.Sprintf("Parent: %s:%s:%s (groupId and version are inherited)",
+		w.aggregator.GroupID, w.aggregator.ArtifactID, w.aggregator.Version))
+	inherited.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(
+		widget.NewLabel("Step 1 of 2: Module"),
+		widget.NewSeparator(),
+		form,
+		inherited,
+	)
+
+	customDialog := dialog.NewCustomConfirm(
+		"New Module",
+		"Next",
+		"Cancel",
+		content,
+		func(next bool) {
+			if next {
+				if strings.TrimSpace(w.modulePathEntry.Text) == "" {
+					dialog.ShowError(fmt.Errorf("module path is required"), w.window)
+					w.showStep1() // Show again
+					return
+				}
+				if pom.HasModule(w.aggregator, w.modulePathEntry.Text) {
+					dialog.ShowError(fmt.Errorf("module '%s' is already declared", w.modulePathEntry.Text), w.window)
+					w.showStep1()
+					return
+				}
+				w.showStep2()
+			}
+		},
+		w.window,
+	)
+
+	customDialog.Resize(fyne.NewSize(500, 320))
+	customDialog.Show()
+}
+
+// showStep2 displays Step 2: Sibling modules that should depend on the new one
+func (w *ModuleWizard) showStep2() {
+	var selection fyne.CanvasObject = w.dependentsCheck
+	if len(w.siblings) == 0 {
+		selection = widget.NewLabel("No sibling modules found in the workspace.")
+	}
+
+	content := container.NewBorder(
+		container.NewVBox(
+			widget.NewLabel("Step 2 of 2: Dependents (optional)"),
+			widget.NewSeparator(),
+			widget.NewLabel("Add the new module as a dependency of:"),
+		),
+		nil, nil, nil,
+		container.NewVScroll(selection),
+	)
+
+	var customDialog dialog.Dialog
+
+	backButton := widgets.NewButtonWithTooltip("Back",
+		"Go back to the module step",
+		func() {
+			customDialog.Hide()
+			w.showStep1()
+		})
+
+	finishButton := widgets.NewButtonWithTooltip("Finish",
+		"Create the module and update the aggregator",
+		func() {
+			customDialog.Hide()
+			if w.onComplete != nil {
+				w.onComplete(w.spec())
+			}
+		})
+
+	finalContent := container.NewBorder(
+		nil, container.NewHBox(backButton, finishButton), nil, nil,
+		content,
+	)
+
+	customDialog = dialog.NewCustom(
+		"New Module",
+		"Cancel",
+		finalContent,
+		w.window,
+	)
+
+	customDialog.Resize(fyne.NewSize(500, 400))
+	customDialog.Show()
+}
+
+// spec collects the wizard input
+func (w *ModuleWizard) spec() ModuleSpec {
+	spec := ModuleSpec{
+		Module:     strings.TrimSpace(w.modulePathEntry.Text),
+		ArtifactID: strings.TrimSpace(w.artifactIDEntry.Text),
+		Template:   w.templateSelect.Selected,
+	}
+
+	selected := make(map[string]bool)
+	for _, label := range w.dependentsCheck.Selected {
+		selected[label] = true
+	}
+	for _, sibling := range w.siblings {
+		if selected[sibling.Label] {
+			spec.Dependents = append(spec.Dependents, sibling.Path)
+		}
+	}
+
+	return spec
+}
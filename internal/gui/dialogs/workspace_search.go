@@ -0,0 +1,161 @@
+package dialogs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/workspace"
+)
+
+// searchKinds maps the scope selector options to search kinds
+var searchKinds = map[string]workspace.SearchKind{
+	"All":         workspace.SearchAll,
+	"Coordinates": workspace.SearchCoordinates,
+	"Properties":  workspace.SearchProperties,
+	"Raw Text":    workspace.SearchText,
+}
+
+// WorkspaceSearchDialog searches all POMs of a workspace and lists the
+// results grouped by file
+type WorkspaceSearchDialog struct {
+	window    fyne.Window
+	workspace *workspace.Workspace
+	dialog    dialog.Dialog
+
+	// UI components
+	queryEntry  *widget.Entry
+	kindSelect  *widget.Select
+	resultsTree *widget.Tree
+	statusLabel *widget.Label
+
+	// State
+	files   []string                     // Files with matches, in workspace order
+	matches map[string][]workspace.Match // Matches grouped by file
+
+	// Callbacks
+	onOpen func(match workspace.Match)
+}
+
+// NewWorkspaceSearchDialog creates a new workspace search dialog
+func NewWorkspaceSearchDialog(window fyne.Window, ws *workspace.Workspace) *WorkspaceSearchDialog {
+	return &WorkspaceSearchDialog{
+		window:    window,
+		workspace: ws,
+		matches:   make(map[string][]workspace.Match),
+	}
+}
+
+// Show displays the dialog; onOpen is called when a result is clicked
+func (d *WorkspaceSearchDialog) Show(onOpen func(match workspace.Match)) {
+	d.onOpen = onOpen
+
+	d.queryEntry = widget.NewEntry()
+	d.queryEntry.SetPlaceHolder("groupId, artifactId, property name, or text...")
+	d.queryEntry.OnSubmitted = func(string) {
+		d.runSearch()
+	}
+
+	d.kindSelect = widget.NewSelect([]string{"All", "Coordinates", "Properties", "Raw Text"}, nil)
+	d.kindSelect.SetSelected("All")
+
+	searchButton := widget.NewButton("Search", d.runSearch)
+
+	d.statusLabel = widget.NewLabel(fmt.Sprintf("%d POM files in %s", len(d.workspace.POMs), d.workspace.Root))
+
+	d.resultsTree = widget.NewTree(
+		func(id widget.TreeNodeID) []widget.TreeNodeID {
+			if id == "" {
+				return d.files
+			}
+			children := make([]widget.TreeNodeID, len(d.matches[id]))
+			for i := range d.matches[id] {
+				children[i] = id + "\x00" + strconv.Itoa(i)
+			}
+			return children
+		},
+		func(id widget.TreeNodeID) bool {
+			_, isFile := d.matches[id]
+			return id == "" || isFile
+		},
+		func(branch bool) fyne.CanvasObject {
+			return widget.NewLabel("template")
+		},
+		func(id widget.TreeNodeID, branch bool, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			if branch {
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				label.SetText(fmt.Sprintf("%s (%d)", d.workspace.RelPath(id), len(d.matches[id])))
+				return
+			}
+			label.TextStyle = fyne.TextStyle{}
+			if match, ok := d.matchFor(id); ok {
+				label.SetText(fmt.Sprintf("%d: [%s] %s", match.Line, match.Kind, match.Text))
+			}
+		},
+	)
+
+	d.resultsTree.OnSelected = func(id widget.TreeNodeID) {
+		match, ok := d.matchFor(id)
+		if !ok {
+			return
+		}
+		d.dialog.Hide()
+		if d.onOpen != nil {
+			d.onOpen(match)
+		}
+	}
+
+	header := container.NewBorder(nil, nil, nil,
+		container.NewHBox(d.kindSelect, searchButton),
+		d.queryEntry,
+	)
+	content := container.NewBorder(header, d.statusLabel, nil, nil, d.resultsTree)
+
+	d.dialog = dialog.NewCustom("Find in Workspace", "Close", content, d.window)
+	d.dialog.Resize(fyne.NewSize(750, 500))
+	d.dialog.Show()
+	d.window.Canvas().Focus(d.queryEntry)
+}
+
+// runSearch executes the query and refreshes the results tree
+func (d *WorkspaceSearchDialog) runSearch() {
+	results, err := d.workspace.Search(d.queryEntry.Text, searchKinds[d.kindSelect.Selected])
+	if err != nil {
+		d.statusLabel.SetText(err.Error())
+		return
+	}
+
+	d.files = nil
+	d.matches = make(map[string][]workspace.Match)
+	for _, match := range results {
+		if _, seen := d.matches[match.Path]; !seen {
+			d.files = append(d.files, match.Path)
+		}
+		d.matches[match.Path] = append(d.matches[match.Path], match)
+	}
+
+	d.statusLabel.SetText(fmt.Sprintf("%d matches in %d files", len(results), len(d.files)))
+	d.resultsTree.Refresh()
+	for _, file := range d.files {
+		d.resultsTree.OpenBranch(file)
+	}
+}
+
+// matchFor returns the match behind a leaf node ID ("path\x00index")
+func (d *WorkspaceSearchDialog) matchFor(id widget.TreeNodeID) (workspace.Match, bool) {
+	path, index, found := strings.Cut(id, "\x00")
+	if !found {
+		return workspace.Match{}, false
+	}
+	i, err := strconv.Atoi(index)
+	if err != nil || i < 0 || i >= len(d.matches[path]) {
+		return workspace.Match{}, false
+	}
+	return d.matches[path][i], true
+}
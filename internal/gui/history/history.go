@@ -0,0 +1,148 @@
+// Package history provides a bounded undo/redo stack of POM snapshots.
+//
+// Each recorded operation stores the project as it was before the change,
+// so undo and redo work regardless of whether edits mutate the project in
+// place or replace it.
+package history
+
+import (
+	"sync"
+	"time"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// DefaultLimit is the default number of undoable operations kept
+const DefaultLimit = 100
+
+// coalesceWindow merges repeated operations of the same name (such as typing
+// into a coordinate field) into a single undo step
+const coalesceWindow = time.Second
+
+// entry is a named snapshot of the project
+type entry struct {
+	name     string
+	snapshot *pom.Project
+	recorded time.Time
+}
+
+// History is a thread-safe undo/redo stack
+type History struct {
+	baseline *pom.Project // Snapshot of the latest recorded state
+	undo     []entry
+	redo     []entry
+	limit    int
+	mutex    sync.Mutex
+}
+
+// New creates a History keeping at most limit undoable operations
+func New(limit int) *History {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	return &History{limit: limit}
+}
+
+// Reset clears the history and starts tracking from the given project
+func (h *History) Reset(project *pom.Project) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.baseline = project.Clone()
+	h.undo = nil
+	h.redo = nil
+}
+
+// Record registers a completed operation; project is the state after it.
+// Recording a new operation discards the redo stack.
+func (h *History) Record(name string, project *pom.Project) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.push(name, project)
+}
+
+// RecordContinuous is like Record, but merges the operation into the previous
+// step when that step has the same name and was recorded within the coalesce
+// window. Used for edits that fire on every keystroke.
+func (h *History) RecordContinuous(name string, project *pom.Project) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if n := len(h.undo); n > 0 && len(h.redo) == 0 && h.undo[n-1].name == name &&
+		time.Since(h.undo[n-1].recorded) < coalesceWindow {
+		h.undo[n-1].recorded = time.Now()
+		h.baseline = project.Clone()
+		return
+	}
+
+	h.push(name, project)
+}
+
+// push appends an undo step and advances the baseline (caller holds the lock)
+func (h *History) push(name string, project *pom.Project) {
+	h.undo = append(h.undo, entry{name: name, snapshot: h.baseline, recorded: time.Now()})
+	if len(h.undo) > h.limit {
+		h.undo = h.undo[len(h.undo)-h.limit:]
+	}
+	h.redo = nil
+	h.baseline = project.Clone()
+}
+
+// Undo reverts the most recent operation, returning the project to restore
+// and the operation name. ok is false when there is nothing to undo.
+func (h *History) Undo() (project *pom.Project, name string, ok bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if len(h.undo) == 0 {
+		return nil, "", false
+	}
+
+	last := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, entry{name: last.name, snapshot: h.baseline})
+	h.baseline = last.snapshot
+
+	return h.baseline.Clone(), last.name, true
+}
+
+// Redo reapplies the most recently undone operation, returning the project
+// to restore and the operation name. ok is false when there is nothing to redo.
+func (h *History) Redo() (project *pom.Project, name string, ok bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if len(h.redo) == 0 {
+		return nil, "", false
+	}
+
+	next := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, entry{name: next.name, snapshot: h.baseline})
+	h.baseline = next.snapshot
+
+	return h.baseline.Clone(), next.name, true
+}
+
+// UndoName returns the name of the operation Undo would revert, or ""
+func (h *History) UndoName() string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if len(h.undo) == 0 {
+		return ""
+	}
+	return h.undo[len(h.undo)-1].name
+}
+
+// RedoName returns the name of the operation Redo would reapply, or ""
+func (h *History) RedoName() string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if len(h.redo) == 0 {
+		return ""
+	}
+	return h.redo[len(h.redo)-1].name
+}
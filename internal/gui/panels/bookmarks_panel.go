@@ -0,0 +1,170 @@
+package panels
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/gui/state"
+	"github.com/user/pom-manager/internal/gui/widgets"
+)
+
+// BookmarksPanel lists bookmarked dependencies and plugins of the workspace
+type BookmarksPanel struct {
+	// UI components
+	bookmarksList *widget.List
+	openButton    *widgets.ButtonWithTooltip
+	noteButton    *widgets.ButtonWithTooltip
+	removeButton  *widgets.ButtonWithTooltip
+	mainContainer *fyne.Container
+
+	// State
+	bookmarks     []state.Bookmark
+	selectedIndex int
+
+	// Callbacks
+	onOpen     func(state.Bookmark)
+	onEditNote func(state.Bookmark)
+	onRemove   func(state.Bookmark)
+}
+
+// NewBookmarksPanel creates a new BookmarksPanel
+func NewBookmarksPanel() *BookmarksPanel {
+	panel := &BookmarksPanel{
+		bookmarks:     make([]state.Bookmark, 0),
+		selectedIndex: -1,
+	}
+
+	panel.createUI()
+	return panel
+}
+
+// createUI creates the panel layout
+func (p *BookmarksPanel) createUI() {
+	p.bookmarksList = widget.NewList(
+		func() int {
+			return len(p.bookmarks)
+		},
+		func() fyne.CanvasObject {
+			note := widget.NewLabel("note")
+			note.Importance = widget.LowImportance
+			return container.NewVBox(widget.NewLabel("bookmark"), note)
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			box := obj.(*fyne.Container)
+			titleLabel := box.Objects[0].(*widget.Label)
+			noteLabel := box.Objects[1].(*widget.Label)
+
+			bookmark := p.bookmarks[id]
+			titleLabel.SetText(fmt.Sprintf("★ %s %s  (%s)",
+				bookmark.Kind, bookmark.Key(), filepath.Base(filepath.Dir(bookmark.Path))))
+			if bookmark.Note != "" {
+				noteLabel.SetText(bookmark.Note)
+			} else {
+				noteLabel.SetText("(no note)")
+			}
+		},
+	)
+
+	p.bookmarksList.OnSelected = func(id widget.ListItemID) {
+		p.selectedIndex = int(id)
+		p.updateButtonStates()
+	}
+
+	p.bookmarksList.OnUnselected = func(id widget.ListItemID) {
+		p.selectedIndex = -1
+		p.updateButtonStates()
+	}
+
+	p.openButton = widgets.NewButtonWithTooltip("Go To",
+		"Open the POM and select the bookmarked element",
+		func() {
+			if p.selectedIndex >= 0 && p.selectedIndex < len(p.bookmarks) && p.onOpen != nil {
+				p.onOpen(p.bookmarks[p.selectedIndex])
+			}
+		})
+	p.openButton.Disable()
+
+	p.noteButton = widgets.NewButtonWithTooltip("Edit Note",
+		"Edit the note attached to the selected bookmark",
+		func() {
+			if p.selectedIndex >= 0 && p.selectedIndex < len(p.bookmarks) && p.onEditNote != nil {
+				p.onEditNote(p.bookmarks[p.selectedIndex])
+			}
+		})
+	p.noteButton.Disable()
+
+	p.removeButton = widgets.NewButtonWithTooltip("Remove",
+		"Remove the selected bookmark (the POM is not changed)",
+		func() {
+			if p.selectedIndex >= 0 && p.selectedIndex < len(p.bookmarks) && p.onRemove != nil {
+				p.onRemove(p.bookmarks[p.selectedIndex])
+			}
+		})
+	p.removeButton.Disable()
+
+	buttonBar := container.NewHBox(
+		p.openButton,
+		p.noteButton,
+		p.removeButton,
+	)
+
+	p.mainContainer = container.NewBorder(
+		container.NewVBox(
+			widget.NewLabel("Bookmarks"),
+			widget.NewSeparator(),
+		),
+		buttonBar,
+		nil, nil,
+		p.bookmarksList,
+	)
+}
+
+// LoadBookmarks updates the list with bookmarks
+func (p *BookmarksPanel) LoadBookmarks(bookmarks []state.Bookmark) {
+	p.bookmarks = bookmarks
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.bookmarksList.UnselectAll()
+		p.bookmarksList.Refresh()
+		p.selectedIndex = -1
+		p.updateButtonStates()
+	})
+}
+
+// updateButtonStates enables/disables buttons based on selection
+func (p *BookmarksPanel) updateButtonStates() {
+	hasSelection := p.selectedIndex >= 0 && p.selectedIndex < len(p.bookmarks)
+	if hasSelection {
+		p.openButton.Enable()
+		p.noteButton.Enable()
+		p.removeButton.Enable()
+	} else {
+		p.openButton.Disable()
+		p.noteButton.Disable()
+		p.removeButton.Disable()
+	}
+}
+
+// OnOpen sets the callback for navigating to a bookmark
+func (p *BookmarksPanel) OnOpen(callback func(state.Bookmark)) {
+	p.onOpen = callback
+}
+
+// OnEditNote sets the callback for editing a bookmark note
+func (p *BookmarksPanel) OnEditNote(callback func(state.Bookmark)) {
+	p.onEditNote = callback
+}
+
+// OnRemove sets the callback for removing a bookmark
+func (p *BookmarksPanel) OnRemove(callback func(state.Bookmark)) {
+	p.onRemove = callback
+}
+
+// GetContainer returns the main container for embedding
+func (p *BookmarksPanel) GetContainer() *fyne.Container {
+	return p.mainContainer
+}
@@ -0,0 +1,161 @@
+package panels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/maven"
+)
+
+// maxBuildOutputLines is how many lines of output the panel keeps; older
+// lines are dropped
+const maxBuildOutputLines = 5000
+
+// BuildOutputPanel shows the output of a Maven build run from the GUI as it
+// is printed, colored by what each line reports, and the build's exit status
+type BuildOutputPanel struct {
+	// UI components
+	statusLabel   *widget.Label
+	runButton     *widget.Button
+	stopButton    *widget.Button
+	clearButton   *widget.Button
+	output        *widget.TextGrid
+	scroll        *container.Scroll
+	mainContainer *fyne.Container
+
+	// State
+	running bool
+	started time.Time
+
+	// Callbacks
+	onRun  func()
+	onStop func()
+}
+
+// NewBuildOutputPanel creates a new BuildOutputPanel
+func NewBuildOutputPanel() *BuildOutputPanel {
+	panel := &BuildOutputPanel{}
+
+	panel.createUI()
+	return panel
+}
+
+// createUI creates the panel layout
+func (p *BuildOutputPanel) createUI() {
+	p.statusLabel = widget.NewLabel("No build has run yet. Use Build > Run Build... to run one.")
+	p.statusLabel.Truncation = fyne.TextTruncateEllipsis
+
+	p.runButton = widget.NewButtonWithIcon("Run Build...", theme.MediaPlayIcon(), func() {
+		if p.onRun != nil {
+			p.onRun()
+		}
+	})
+	p.stopButton = widget.NewButtonWithIcon("Stop", theme.MediaStopIcon(), func() {
+		if p.onStop != nil {
+			p.onStop()
+		}
+	})
+	p.stopButton.Disable()
+	p.clearButton = widget.NewButtonWithIcon("Clear", theme.ContentClearIcon(), func() {
+		p.output.SetText("")
+	})
+
+	// The panel scrolls the grid itself, to follow the output
+	p.output = widget.NewTextGrid()
+	p.output.Scroll = fyne.ScrollNone
+	p.scroll = container.NewScroll(p.output)
+
+	p.mainContainer = container.NewBorder(
+		container.NewBorder(nil, nil, nil,
+			container.NewHBox(p.runButton, p.stopButton, p.clearButton),
+			p.statusLabel),
+		nil, nil, nil,
+		p.scroll,
+	)
+}
+
+// Start clears the output for a build of command in dir
+func (p *BuildOutputPanel) Start(command, dir string) {
+	p.running = true
+	p.started = time.Now()
+	p.output.SetText("")
+	p.statusLabel.SetText(fmt.Sprintf("Running %s in %s", command, dir))
+	p.runButton.Disable()
+	p.stopButton.Enable()
+}
+
+// AppendLine adds a line of output, colored by what it reports
+func (p *BuildOutputPanel) AppendLine(line string) {
+	if len(p.output.Rows) >= maxBuildOutputLines {
+		p.output.Rows = p.output.Rows[len(p.output.Rows)-maxBuildOutputLines+1:]
+	}
+	p.output.Append(line)
+	if style := buildLineStyle(maven.ClassifyLine(line)); style != nil {
+		p.output.SetRowStyle(len(p.output.Rows)-1, style)
+	}
+	p.scroll.ScrollToBottom()
+}
+
+// Finish shows the outcome of the build, err being what maven.Stream
+// returned
+func (p *BuildOutputPanel) Finish(err error) {
+	p.running = false
+	p.runButton.Enable()
+	p.stopButton.Disable()
+
+	elapsed := time.Since(p.started).Round(100 * time.Millisecond)
+	code := maven.ExitCode(err)
+	switch {
+	case err == nil:
+		p.statusLabel.SetText(fmt.Sprintf("✓ Build succeeded in %s (exit code 0)", elapsed))
+	case errors.Is(err, context.Canceled):
+		p.statusLabel.SetText(fmt.Sprintf("Build stopped after %s", elapsed))
+	case code > 0:
+		p.statusLabel.SetText(fmt.Sprintf("✗ Build failed after %s (exit code %d)", elapsed, code))
+	default:
+		p.statusLabel.SetText(fmt.Sprintf("✗ Build did not run: %v", err))
+	}
+}
+
+// Running reports whether a build is running
+func (p *BuildOutputPanel) Running() bool {
+	return p.running
+}
+
+// OnRun sets the callback for the Run Build button
+func (p *BuildOutputPanel) OnRun(callback func()) {
+	p.onRun = callback
+}
+
+// OnStop sets the callback for stopping the running build
+func (p *BuildOutputPanel) OnStop(callback func()) {
+	p.onStop = callback
+}
+
+// GetContainer returns the panel container
+func (p *BuildOutputPanel) GetContainer() *fyne.Container {
+	return p.mainContainer
+}
+
+// buildLineStyle returns the style of a kind of output line, nil for plain
+// lines
+func buildLineStyle(kind maven.LineKind) widget.TextGridStyle {
+	switch kind {
+	case maven.LineError:
+		return &widget.CustomTextGridStyle{FGColor: theme.Color(theme.ColorNameError)}
+	case maven.LineWarning:
+		return &widget.CustomTextGridStyle{FGColor: theme.Color(theme.ColorNameWarning)}
+	case maven.LineSuccess:
+		return &widget.CustomTextGridStyle{FGColor: theme.Color(theme.ColorNameSuccess), TextStyle: fyne.TextStyle{Bold: true}}
+	case maven.LineHeading:
+		return &widget.CustomTextGridStyle{FGColor: theme.Color(theme.ColorNamePrimary), TextStyle: fyne.TextStyle{Bold: true}}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/gui/widgets"
 )
 
 // CoordinatesPanel provides a form for editing project coordinates
@@ -17,12 +18,14 @@ type CoordinatesPanel struct {
 	packagingSelect *widget.Select
 	nameEntry       *widget.Entry
 	descriptionEntry *widget.Entry
+	copyGAVButton    *widgets.ButtonWithTooltip
 
 	// Main container
 	mainContainer *fyne.Container
 
 	// Callbacks
-	onChange func(pom.Coordinates)
+	onChange  func(pom.Coordinates)
+	onCopyGAV func(pom.Coordinates)
 
 	// State
 	loading bool // Flag to prevent onChange during programmatic updates
@@ -73,8 +76,16 @@ func (p *CoordinatesPanel) createUI() {
 		},
 	}
 
+	p.copyGAVButton = widgets.NewButtonWithTooltip("Copy GAV",
+		"Copy groupId:artifactId:version to the clipboard",
+		func() {
+			if p.onCopyGAV != nil {
+				p.onCopyGAV(p.GetCoordinates())
+			}
+		})
+
 	p.mainContainer = container.NewVBox(
-		widget.NewLabel("Project Coordinates"),
+		container.NewBorder(nil, nil, widget.NewLabel("Project Coordinates"), p.copyGAVButton),
 		widget.NewSeparator(),
 		form,
 	)
@@ -176,11 +187,66 @@ func (p *CoordinatesPanel) GetDescription() string {
 	return p.descriptionEntry.Text
 }
 
+// SetReadOnly disables all form fields while in view-only mode
+func (p *CoordinatesPanel) SetReadOnly(readOnly bool) {
+	entries := []*widget.Entry{p.groupIDEntry, p.artifactIDEntry, p.versionEntry, p.nameEntry, p.descriptionEntry}
+
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		for _, entry := range entries {
+			if readOnly {
+				entry.Disable()
+			} else {
+				entry.Enable()
+			}
+		}
+		if readOnly {
+			p.packagingSelect.Disable()
+		} else {
+			p.packagingSelect.Enable()
+		}
+	})
+}
+
+// FocusField moves keyboard focus to the form field for a POM element name
+// ("groupId", "version", ...). Unknown names are ignored.
+func (p *CoordinatesPanel) FocusField(field string) {
+	var target fyne.Focusable
+	switch field {
+	case "groupId":
+		target = p.groupIDEntry
+	case "artifactId":
+		target = p.artifactIDEntry
+	case "version":
+		target = p.versionEntry
+	case "packaging":
+		target = p.packagingSelect
+	case "name":
+		target = p.nameEntry
+	case "description":
+		target = p.descriptionEntry
+	default:
+		return
+	}
+
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		if canvas := fyne.CurrentApp().Driver().CanvasForObject(target.(fyne.CanvasObject)); canvas != nil {
+			canvas.Focus(target)
+		}
+	})
+}
+
 // OnChange sets the callback for when coordinates change
 func (p *CoordinatesPanel) OnChange(callback func(pom.Coordinates)) {
 	p.onChange = callback
 }
 
+// OnCopyGAV sets the callback for copying the project's coordinates
+func (p *CoordinatesPanel) OnCopyGAV(callback func(pom.Coordinates)) {
+	p.onCopyGAV = callback
+}
+
 // GetContainer returns the main container for embedding
 func (p *CoordinatesPanel) GetContainer() *fyne.Container {
 	return p.mainContainer
@@ -2,9 +2,14 @@ package panels
 
 import (
 	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/user/pom-manager/internal/core/pom"
@@ -15,19 +20,54 @@ import (
 type DependenciesPanel struct {
 	// UI components
 	dependenciesList *widget.List
+	filterEntry      *widget.Entry
 	addButton        *widgets.ButtonWithTooltip
 	editButton       *widgets.ButtonWithTooltip
 	removeButton     *widgets.ButtonWithTooltip
+	bookmarkButton   *widgets.ButtonWithTooltip
+	moveButton       *widgets.ButtonWithTooltip
+	bomButton        *widgets.ButtonWithTooltip
+	upButton         *widgets.ButtonWithTooltip
+	downButton       *widgets.ButtonWithTooltip
+	sortButton       *widgets.ButtonWithTooltip
+	cleanUpButton    *widgets.ButtonWithTooltip
+	selectAllCheck   *widget.Check
+	bulkLabel        *widget.Label
+	bulkRemoveButton *widgets.ButtonWithTooltip
+	bulkScopeSelect  *widget.Select
+	bulkCopyButton   *widgets.ButtonWithTooltip
+	bulkBar          *fyne.Container
+	managedList      *widget.List
+	managedSection   *fyne.Container
+	inheritedList    *widget.List
+	inheritedSection *fyne.Container
 	mainContainer    *fyne.Container
 
 	// State
 	dependencies     []pom.Dependency
+	visible          []int // Indices of the dependencies matching the filter
+	inherited        []pom.InheritedDependency
+	managed          []pom.Dependency
 	selectedIndex    int
+	checked          map[string]bool // Keys of the dependencies ticked for bulk actions
+	readOnly         bool
 
 	// Callbacks
-	onAdd    func()
-	onEdit   func(pom.Dependency)
-	onRemove func(pom.Dependency)
+	onAdd      func()
+	onEdit     func(pom.Dependency)
+	onRemove   func(pom.Dependency)
+	onBookmark func(pom.Dependency)
+	onMove     func(pom.Dependency)
+	onAddBOM   func()
+	onReorder  func(from, to int)
+	onSort     func()
+	onCleanUp  func()
+
+	onBulkRemove func([]pom.Dependency)
+	onBulkScope  func([]pom.Dependency, string)
+	onBulkCopy   func([]pom.Dependency)
+
+	onContextMenu ContextMenuFunc
 }
 
 // NewDependenciesPanel creates a new DependenciesPanel
@@ -35,6 +75,7 @@ func NewDependenciesPanel() *DependenciesPanel {
 	panel := &DependenciesPanel{
 		dependencies:  make([]pom.Dependency, 0),
 		selectedIndex: -1,
+		checked:       make(map[string]bool),
 	}
 
 	panel.createUI()
@@ -43,28 +84,50 @@ func NewDependenciesPanel() *DependenciesPanel {
 
 // createUI creates the panel layout
 func (p *DependenciesPanel) createUI() {
+	// Narrow the list down while typing
+	p.filterEntry = widget.NewEntry()
+	p.filterEntry.SetPlaceHolder("Filter dependencies...")
+	p.filterEntry.OnChanged = func(string) {
+		p.applyFilter()
+		p.dependenciesList.UnselectAll()
+		p.dependenciesList.Refresh()
+	}
+
 	// Create list
 	p.dependenciesList = widget.NewList(
 		func() int {
-			return len(p.dependencies)
+			return len(p.visible)
 		},
 		func() fyne.CanvasObject {
-			return widget.NewLabel("template")
+			check := widget.NewCheck("", nil)
+			return container.NewBorder(nil, nil, check, nil, newDependencyRow(p))
 		},
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
-			label := obj.(*widget.Label)
-			dep := p.dependencies[id]
-			scope := dep.Scope
-			if scope == "" {
-				scope = "compile"
+			row := obj.(*fyne.Container)
+			label := row.Objects[0].(*dependencyRow)
+			label.index = p.visible[id]
+			dep := p.dependencies[label.index]
+
+			// Set the state before the handler so reused rows don't
+			// report a change
+			check := row.Objects[1].(*widget.Check)
+			check.OnChanged = nil
+			check.SetChecked(p.checked[dep.Key()])
+			check.OnChanged = func(checked bool) {
+				p.setChecked(dep, checked)
+			}
+
+			version := dep.Version
+			if version == "" {
+				version = "(managed)"
 			}
 			label.SetText(fmt.Sprintf("%s:%s:%s [%s]",
-				dep.GroupID, dep.ArtifactID, dep.Version, scope))
+				dep.GroupID, dep.ArtifactID, version, strings.Join(dependencyTags(dep), ", ")))
 		},
 	)
 
 	p.dependenciesList.OnSelected = func(id widget.ListItemID) {
-		p.selectedIndex = int(id)
+		p.selectedIndex = p.visible[id]
 		p.updateButtonStates()
 	}
 
@@ -73,6 +136,59 @@ func (p *DependenciesPanel) createUI() {
 		p.updateButtonStates()
 	}
 
+	// Entries of dependencyManagement, including imported BOMs
+	p.managedList = widget.NewList(
+		func() int {
+			return len(p.managed)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("template")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			dep := p.managed[id]
+			if dep.IsBOM() {
+				label.SetText(fmt.Sprintf("%s:%s:%s [BOM]", dep.GroupID, dep.ArtifactID, dep.Version))
+				return
+			}
+			label.SetText(fmt.Sprintf("%s:%s:%s", dep.GroupID, dep.ArtifactID, dep.Version))
+		},
+	)
+	p.managedSection = container.NewBorder(
+		widget.NewLabel("Managed (dependencyManagement)"),
+		nil, nil, nil,
+		p.managedList,
+	)
+	p.managedSection.Hide()
+
+	// Inherited dependencies are shown dimmed and cannot be edited here
+	p.inheritedList = widget.NewList(
+		func() int {
+			return len(p.inherited)
+		},
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("template")
+			label.Importance = widget.LowImportance
+			return label
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			dep := p.inherited[id]
+			scope := dep.Scope
+			if scope == "" {
+				scope = "compile"
+			}
+			label.SetText(fmt.Sprintf("%s:%s:%s [%s] (from %s)",
+				dep.GroupID, dep.ArtifactID, dep.Version, scope, dep.Source))
+		},
+	)
+	p.inheritedSection = container.NewBorder(
+		widget.NewLabel("Inherited from parent"),
+		nil, nil, nil,
+		p.inheritedList,
+	)
+	p.inheritedSection.Hide()
+
 	// Create buttons with tooltips
 	p.addButton = widgets.NewButtonWithTooltip("Add Dependency",
 		"Add a new Maven dependency to the project",
@@ -100,47 +216,355 @@ func (p *DependenciesPanel) createUI() {
 		})
 	p.removeButton.Disable()
 
+	p.bookmarkButton = widgets.NewButtonWithTooltip("Bookmark",
+		"Bookmark the selected dependency with a note (stored locally, not in the POM)",
+		func() {
+			if p.selectedIndex >= 0 && p.selectedIndex < len(p.dependencies) && p.onBookmark != nil {
+				p.onBookmark(p.dependencies[p.selectedIndex])
+			}
+		})
+	p.bookmarkButton.Disable()
+
+	p.moveButton = widgets.NewButtonWithTooltip("Move to Parent",
+		"Manage the selected dependency in the parent's dependencyManagement and drop the version from all modules",
+		func() {
+			if p.selectedIndex >= 0 && p.selectedIndex < len(p.dependencies) && p.onMove != nil {
+				p.onMove(p.dependencies[p.selectedIndex])
+			}
+		})
+	p.moveButton.Disable()
+
+	p.bomButton = widgets.NewButtonWithTooltip("Add BOM",
+		"Import a bill of materials (e.g. spring-boot-dependencies) so dependencies can be added without versions",
+		func() {
+			if p.onAddBOM != nil {
+				p.onAddBOM()
+			}
+		})
+
+	p.upButton = widgets.NewButtonWithTooltip("↑",
+		"Move the selected dependency up (or drag it in the list)",
+		func() {
+			p.reorder(p.selectedIndex, p.selectedIndex-1)
+		})
+	p.upButton.Disable()
+
+	p.downButton = widgets.NewButtonWithTooltip("↓",
+		"Move the selected dependency down (or drag it in the list)",
+		func() {
+			p.reorder(p.selectedIndex, p.selectedIndex+1)
+		})
+	p.downButton.Disable()
+
+	p.sortButton = widgets.NewButtonWithTooltip("Sort",
+		"Sort dependencies by scope, then groupId and artifactId",
+		func() {
+			if p.onSort != nil {
+				p.onSort()
+			}
+		})
+
+	p.cleanUpButton = widgets.NewButtonWithTooltip("Clean Up",
+		"Find duplicate dependencies, dependencies already brought in by others and versions dependencyManagement already sets",
+		func() {
+			if p.onCleanUp != nil {
+				p.onCleanUp()
+			}
+		})
+
+	// Bulk actions on the ticked dependencies
+	p.selectAllCheck = widget.NewCheck("Select all", nil)
+	p.selectAllCheck.OnChanged = p.selectAll
+
+	p.bulkLabel = widget.NewLabel("")
+	p.bulkRemoveButton = widgets.NewButtonWithTooltip("Remove Selected",
+		"Remove all ticked dependencies",
+		func() {
+			if p.onBulkRemove != nil {
+				p.onBulkRemove(p.CheckedDependencies())
+			}
+		})
+	p.bulkScopeSelect = widget.NewSelect(pom.ValidDependencyScopes, func(scope string) {
+		if scope == "" {
+			return
+		}
+		if p.onBulkScope != nil {
+			p.onBulkScope(p.CheckedDependencies(), scope)
+		}
+		p.bulkScopeSelect.ClearSelected()
+	})
+	p.bulkScopeSelect.PlaceHolder = "Change scope..."
+	p.bulkCopyButton = widgets.NewButtonWithTooltip("Copy as XML",
+		"Copy the ticked dependencies to the clipboard as <dependency> elements",
+		func() {
+			if p.onBulkCopy != nil {
+				p.onBulkCopy(p.CheckedDependencies())
+			}
+		})
+	p.bulkBar = container.NewHBox(p.bulkLabel, p.bulkRemoveButton, p.bulkScopeSelect, p.bulkCopyButton)
+	p.bulkBar.Hide()
+
 	// Create layout
 	buttonBar := container.NewHBox(
 		p.addButton,
 		p.editButton,
 		p.removeButton,
+		p.bookmarkButton,
+		p.moveButton,
+		p.bomButton,
+		widget.NewSeparator(),
+		p.upButton,
+		p.downButton,
+		p.sortButton,
+		p.cleanUpButton,
 	)
 
 	p.mainContainer = container.NewBorder(
 		container.NewVBox(
-			widget.NewLabel("Dependencies"),
+			container.NewBorder(nil, nil, widget.NewLabel("Dependencies"), p.selectAllCheck, p.filterEntry),
 			widget.NewSeparator(),
 		),
-		buttonBar,
+		container.NewVBox(p.bulkBar, buttonBar),
 		nil, nil,
-		p.dependenciesList,
+		container.NewVSplit(p.dependenciesList, container.NewGridWithColumns(1, p.managedSection, p.inheritedSection)),
 	)
 }
 
 // LoadDependencies updates the list with dependencies
 func (p *DependenciesPanel) LoadDependencies(deps []pom.Dependency) {
 	p.dependencies = deps
+	p.applyFilter()
 	// UI updates must be called on UI thread
 	fyne.Do(func() {
+		p.checked = make(map[string]bool)
+		p.selectAllCheck.OnChanged = nil
+		p.selectAllCheck.SetChecked(false)
+		p.selectAllCheck.OnChanged = p.selectAll
 		p.dependenciesList.Refresh()
 		p.selectedIndex = -1
 		p.updateButtonStates()
+		p.updateBulkBar()
+	})
+}
+
+// applyFilter lists the dependencies whose coordinates or tags contain the
+// filter text, ignoring case
+func (p *DependenciesPanel) applyFilter() {
+	p.visible = p.visible[:0]
+	for i, dep := range p.dependencies {
+		if matchesFilter(dependencyFilterText(dep), p.filterEntry.Text) {
+			p.visible = append(p.visible, i)
+		}
+	}
+}
+
+// CountMatches returns how many dependencies filter would list
+func (p *DependenciesPanel) CountMatches(filter string) int {
+	count := 0
+	for _, dep := range p.dependencies {
+		if matchesFilter(dependencyFilterText(dep), filter) {
+			count++
+		}
+	}
+	return count
+}
+
+// dependencyFilterText is what the filter looks for in a dependency: its
+// coordinates and tags
+func dependencyFilterText(dep pom.Dependency) string {
+	return fmt.Sprintf("%s:%s:%s %s", dep.GroupID, dep.ArtifactID, dep.Version, strings.Join(dependencyTags(dep), " "))
+}
+
+// Filter returns the filter text
+func (p *DependenciesPanel) Filter() string {
+	return p.filterEntry.Text
+}
+
+// SetFilter shows only the dependencies matching text
+func (p *DependenciesPanel) SetFilter(text string) {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.filterEntry.SetText(text)
+	})
+}
+
+// filtering reports whether the filter hides dependencies; reordering is
+// off then, since moves past hidden rows would be surprising
+func (p *DependenciesPanel) filtering() bool {
+	return len(p.visible) != len(p.dependencies)
+}
+
+// CheckedDependencies returns the dependencies ticked for bulk actions, in
+// list order
+func (p *DependenciesPanel) CheckedDependencies() []pom.Dependency {
+	var deps []pom.Dependency
+	for _, dep := range p.dependencies {
+		if p.checked[dep.Key()] {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// setChecked ticks or unticks a dependency for bulk actions
+func (p *DependenciesPanel) setChecked(dep pom.Dependency, checked bool) {
+	if checked {
+		p.checked[dep.Key()] = true
+	} else {
+		delete(p.checked, dep.Key())
+	}
+	p.updateBulkBar()
+}
+
+// selectAll ticks or unticks every dependency the filter shows
+func (p *DependenciesPanel) selectAll(checked bool) {
+	p.checked = make(map[string]bool)
+	if checked {
+		for _, i := range p.visible {
+			p.checked[p.dependencies[i].Key()] = true
+		}
+	}
+	p.dependenciesList.Refresh()
+	p.updateBulkBar()
+}
+
+// updateBulkBar shows the bulk actions while dependencies are ticked
+func (p *DependenciesPanel) updateBulkBar() {
+	count := len(p.CheckedDependencies())
+	if count == 0 {
+		p.bulkBar.Hide()
+		return
+	}
+
+	p.bulkLabel.SetText(fmt.Sprintf("%d selected", count))
+	if p.readOnly {
+		p.bulkRemoveButton.Disable()
+		p.bulkScopeSelect.Disable()
+	} else {
+		p.bulkRemoveButton.Enable()
+		p.bulkScopeSelect.Enable()
+	}
+	p.bulkBar.Show()
+}
+
+// Selected returns the dependency selected in the list, if any
+func (p *DependenciesPanel) Selected() (pom.Dependency, bool) {
+	if p.selectedIndex < 0 || p.selectedIndex >= len(p.dependencies) {
+		return pom.Dependency{}, false
+	}
+	return p.dependencies[p.selectedIndex], true
+}
+
+// SelectDependency highlights the dependency with the given groupId and artifactId
+func (p *DependenciesPanel) SelectDependency(groupID, artifactID string) {
+	for i, dep := range p.dependencies {
+		if dep.GroupID == groupID && dep.ArtifactID == artifactID {
+			p.SelectDependencyAt(i)
+			return
+		}
+	}
+}
+
+// SelectDependencyAt highlights the dependency at index in the project's
+// dependencies
+func (p *DependenciesPanel) SelectDependencyAt(index int) {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		if index < 0 || index >= len(p.dependencies) {
+			return
+		}
+		// Clear a filter hiding it
+		if !slices.Contains(p.visible, index) {
+			p.filterEntry.SetText("")
+		}
+		row := slices.Index(p.visible, index)
+		p.dependenciesList.Select(row)
+		p.dependenciesList.ScrollTo(row)
+	})
+}
+
+// EditSelected opens the selected dependency for editing, as the Edit
+// button does
+func (p *DependenciesPanel) EditSelected() {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		if !p.editButton.Disabled() {
+			p.editButton.OnTapped()
+		}
+	})
+}
+
+// LoadManaged updates the list of dependencyManagement entries
+func (p *DependenciesPanel) LoadManaged(deps []pom.Dependency) {
+	p.managed = deps
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.managedList.Refresh()
+		if len(p.managed) > 0 {
+			p.managedSection.Show()
+		} else {
+			p.managedSection.Hide()
+		}
+	})
+}
+
+// LoadInherited updates the dimmed list of dependencies inherited from parents
+func (p *DependenciesPanel) LoadInherited(deps []pom.InheritedDependency) {
+	p.inherited = deps
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.inheritedList.Refresh()
+		if len(p.inherited) > 0 {
+			p.inheritedSection.Show()
+		} else {
+			p.inheritedSection.Hide()
+		}
 	})
 }
 
 // updateButtonStates enables/disables buttons based on selection
 func (p *DependenciesPanel) updateButtonStates() {
 	hasSelection := p.selectedIndex >= 0 && p.selectedIndex < len(p.dependencies)
+
+	// Bookmarks live outside the POM, so they stay available when read-only
 	if hasSelection {
+		p.bookmarkButton.Enable()
+	} else {
+		p.bookmarkButton.Disable()
+	}
+
+	if hasSelection && !p.readOnly {
 		p.editButton.Enable()
 		p.removeButton.Enable()
+		p.moveButton.Enable()
 	} else {
 		p.editButton.Disable()
 		p.removeButton.Disable()
+		p.moveButton.Disable()
+	}
+
+	if hasSelection && !p.readOnly && !p.filtering() && p.selectedIndex > 0 {
+		p.upButton.Enable()
+	} else {
+		p.upButton.Disable()
+	}
+	if hasSelection && !p.readOnly && !p.filtering() && p.selectedIndex < len(p.dependencies)-1 {
+		p.downButton.Enable()
+	} else {
+		p.downButton.Disable()
 	}
 }
 
+// reorder asks for the dependency at from to be moved to to, ignoring moves
+// out of range and while read-only
+func (p *DependenciesPanel) reorder(from, to int) {
+	if p.readOnly || p.filtering() || p.onReorder == nil || from == to ||
+		from < 0 || from >= len(p.dependencies) || to < 0 || to >= len(p.dependencies) {
+		return
+	}
+	p.onReorder(from, to)
+}
+
 // OnAdd sets the callback for adding a dependency
 func (p *DependenciesPanel) OnAdd(callback func()) {
 	p.onAdd = callback
@@ -156,7 +580,148 @@ func (p *DependenciesPanel) OnRemove(callback func(pom.Dependency)) {
 	p.onRemove = callback
 }
 
+// OnBookmark sets the callback for bookmarking a dependency
+func (p *DependenciesPanel) OnBookmark(callback func(pom.Dependency)) {
+	p.onBookmark = callback
+}
+
+// OnMoveToParent sets the callback for moving a dependency to the parent's
+// dependencyManagement
+func (p *DependenciesPanel) OnMoveToParent(callback func(pom.Dependency)) {
+	p.onMove = callback
+}
+
+// OnAddBOM sets the callback for importing a BOM
+func (p *DependenciesPanel) OnAddBOM(callback func()) {
+	p.onAddBOM = callback
+}
+
+// OnReorder sets the callback for moving the dependency at index from to
+// index to
+func (p *DependenciesPanel) OnReorder(callback func(from, to int)) {
+	p.onReorder = callback
+}
+
+// OnSort sets the callback for sorting the dependencies
+func (p *DependenciesPanel) OnSort(callback func()) {
+	p.onSort = callback
+}
+
+// OnCleanUp sets the callback for cleaning up redundant dependencies
+func (p *DependenciesPanel) OnCleanUp(callback func()) {
+	p.onCleanUp = callback
+}
+
+// OnRemoveSelected sets the callback for removing the ticked dependencies
+func (p *DependenciesPanel) OnRemoveSelected(callback func([]pom.Dependency)) {
+	p.onBulkRemove = callback
+}
+
+// OnScopeSelected sets the callback for changing the scope of the ticked
+// dependencies
+func (p *DependenciesPanel) OnScopeSelected(callback func([]pom.Dependency, string)) {
+	p.onBulkScope = callback
+}
+
+// OnCopySelected sets the callback for copying the ticked dependencies as XML
+func (p *DependenciesPanel) OnCopySelected(callback func([]pom.Dependency)) {
+	p.onBulkCopy = callback
+}
+
+// OnContextMenu sets the callback returning the menu shown when a
+// dependency is right-clicked
+func (p *DependenciesPanel) OnContextMenu(callback ContextMenuFunc) {
+	p.onContextMenu = callback
+}
+
+// SetReadOnly disables all mutating buttons while in view-only mode
+func (p *DependenciesPanel) SetReadOnly(readOnly bool) {
+	p.readOnly = readOnly
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		if readOnly {
+			p.addButton.Disable()
+			p.bomButton.Disable()
+			p.sortButton.Disable()
+			p.cleanUpButton.Disable()
+		} else {
+			p.addButton.Enable()
+			p.bomButton.Enable()
+			p.sortButton.Enable()
+			p.cleanUpButton.Enable()
+		}
+		p.updateButtonStates()
+		p.updateBulkBar()
+	})
+}
+
 // GetContainer returns the main container for embedding
 func (p *DependenciesPanel) GetContainer() *fyne.Container {
 	return p.mainContainer
 }
+
+// dependencyTags describes a dependency's scope and whatever sets it apart
+// from a plain jar: its type, classifier, and whether it is optional
+func dependencyTags(dep pom.Dependency) []string {
+	scope := dep.Scope
+	if scope == "" {
+		scope = pom.DefaultScope
+	}
+	tags := []string{scope}
+	if dep.Type != "" && dep.Type != pom.DefaultDependencyType {
+		tags = append(tags, "type: "+dep.Type)
+	}
+	if dep.Classifier != "" {
+		tags = append(tags, "classifier: "+dep.Classifier)
+	}
+	if dep.Optional {
+		tags = append(tags, "optional")
+	}
+	return tags
+}
+
+// dependencyRow is a dependency list label that can be dragged up or down to
+// reorder the dependencies, or right-clicked for its context menu
+type dependencyRow struct {
+	widget.Label
+	panel   *DependenciesPanel
+	index   int
+	dragged float32 // Vertical distance dragged so far
+}
+
+func newDependencyRow(panel *DependenciesPanel) *dependencyRow {
+	row := &dependencyRow{panel: panel}
+	row.Text = "template"
+	row.ExtendBaseWidget(row)
+	return row
+}
+
+// Tapped implements fyne.Tappable, selecting the row as the list would
+func (r *dependencyRow) Tapped(*fyne.PointEvent) {
+	r.panel.dependenciesList.Select(slices.Index(r.panel.visible, r.index))
+}
+
+// TappedSecondary implements fyne.SecondaryTappable
+func (r *dependencyRow) TappedSecondary(event *fyne.PointEvent) {
+	r.Tapped(event)
+	showContextMenu(r, r.panel.onContextMenu, "dep", strconv.Itoa(r.index), event)
+}
+
+// Dragged implements fyne.Draggable
+func (r *dependencyRow) Dragged(event *fyne.DragEvent) {
+	r.dragged += event.Dragged.DY
+}
+
+// DragEnd implements fyne.Draggable, moving the dependency by the number of
+// rows it was dropped away from
+func (r *dependencyRow) DragEnd() {
+	rowHeight := r.Size().Height + theme.Padding()
+	rows := int(math.Round(float64(r.dragged / rowHeight)))
+	r.dragged = 0
+	if rows == 0 || rowHeight <= 0 {
+		return
+	}
+
+	to := max(0, min(r.index+rows, len(r.panel.dependencies)-1))
+	r.panel.reorder(r.index, to)
+}
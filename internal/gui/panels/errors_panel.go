@@ -15,21 +15,36 @@ import (
 type ErrorsPanel struct {
 	// UI components
 	errorsList    *widget.List
+	severityCheck *widget.CheckGroup
+	fixAllButton  *widget.Button
 	mainContainer *fyne.Container
 
 	// State
 	errors        []errorItem
+	warnings      []errorItem
+	shown         []errorItem // errors and warnings passing the severity filter
+	filter        map[pom.Severity]bool
 	visible       bool
 
 	// Callbacks
-	onErrorClick func(errorType string, index int)
+	onErrorClick func(finding pom.ValidationError)
+	onFix        func(finding pom.ValidationError)
+	onFixAll     func()
+	onResolve    func(finding pom.ValidationError)
 }
 
 // errorItem represents a single error with category
 type errorItem struct {
 	category string
 	message  string
-	index    int
+	finding  pom.ValidationError
+}
+
+// severityLabels are the filter options, in severity order
+var severityLabels = map[pom.Severity]string{
+	pom.SeverityError:   "Errors",
+	pom.SeverityWarning: "Warnings",
+	pom.SeverityInfo:    "Info",
 }
 
 // NewErrorsPanel creates a new ErrorsPanel
@@ -37,6 +52,11 @@ func NewErrorsPanel() *ErrorsPanel {
 	panel := &ErrorsPanel{
 		errors:  make([]errorItem, 0),
 		visible: false,
+		filter: map[pom.Severity]bool{
+			pom.SeverityError:   true,
+			pom.SeverityWarning: true,
+			pom.SeverityInfo:    true,
+		},
 	}
 
 	panel.createUI()
@@ -48,36 +68,110 @@ func (p *ErrorsPanel) createUI() {
 	// Create error list
 	p.errorsList = widget.NewList(
 		func() int {
-			return len(p.errors)
+			return len(p.shown)
 		},
 		func() fyne.CanvasObject {
-			return container.NewHBox(
-				widget.NewIcon(theme.ErrorIcon()),
-				widget.NewLabel("template"),
-			)
+			icon := widget.NewIcon(theme.ErrorIcon())
+			fixButton := widget.NewButtonWithIcon("Fix", theme.ConfirmIcon(), nil)
+			resolveButton := widget.NewButtonWithIcon("Resolve...", theme.SettingsIcon(), nil)
+			return container.NewBorder(nil, nil, icon, container.NewHBox(fixButton, resolveButton), widget.NewLabel("template"))
 		},
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
 			box := obj.(*fyne.Container)
-			label := box.Objects[1].(*widget.Label)
-			err := p.errors[id]
-			label.SetText(fmt.Sprintf("[%s] %s", err.category, err.message))
+			label := box.Objects[0].(*widget.Label)
+			icon := box.Objects[1].(*widget.Icon)
+			actions := box.Objects[2].(*fyne.Container)
+			fixButton := actions.Objects[0].(*widget.Button)
+			resolveButton := actions.Objects[1].(*widget.Button)
+			err := p.shown[id]
+			switch err.finding.Severity {
+			case pom.SeverityInfo:
+				icon.SetResource(theme.InfoIcon())
+			case pom.SeverityWarning:
+				icon.SetResource(theme.WarningIcon())
+			default:
+				icon.SetResource(theme.ErrorIcon())
+			}
+			if err.finding.Position.IsKnown() {
+				label.SetText(fmt.Sprintf("[%s] Line %d: %s", err.category, err.finding.Position.Line, err.message))
+			} else {
+				label.SetText(fmt.Sprintf("[%s] %s", err.category, err.message))
+			}
+
+			// Offer the quick-fix, if the finding has one
+			if fix := err.finding.Fix; fix != nil {
+				finding := err.finding
+				fixButton.OnTapped = func() {
+					if p.onFix != nil {
+						p.onFix(finding)
+					}
+				}
+				fixButton.SetText("Fix: " + fix.Description)
+				fixButton.Show()
+			} else {
+				fixButton.OnTapped = nil
+				fixButton.Hide()
+			}
+
+			// Undefined properties can be resolved in more ways than the fix
+			if err.finding.Rule == pom.RuleUndefinedProperty {
+				finding := err.finding
+				resolveButton.OnTapped = func() {
+					if p.onResolve != nil {
+						p.onResolve(finding)
+					}
+				}
+				resolveButton.Show()
+			} else {
+				resolveButton.OnTapped = nil
+				resolveButton.Hide()
+			}
 		},
 	)
 
 	p.errorsList.OnSelected = func(id widget.ListItemID) {
-		if p.onErrorClick != nil && int(id) < len(p.errors) {
-			err := p.errors[id]
-			p.onErrorClick(err.category, err.index)
+		if p.onErrorClick != nil && int(id) < len(p.shown) {
+			p.onErrorClick(p.shown[id].finding)
 		}
+		// Allow clicking the same finding again
+		p.errorsList.Unselect(id)
 	}
 
+	// Severity filter
+	options := []string{
+		severityLabels[pom.SeverityError],
+		severityLabels[pom.SeverityWarning],
+		severityLabels[pom.SeverityInfo],
+	}
+	p.severityCheck = widget.NewCheckGroup(options, func(selected []string) {
+		for severity, label := range severityLabels {
+			p.filter[severity] = false
+			for _, s := range selected {
+				if s == label {
+					p.filter[severity] = true
+				}
+			}
+		}
+		p.applyFilter()
+		p.errorsList.Refresh()
+	})
+	p.severityCheck.Horizontal = true
+	p.severityCheck.SetSelected(options)
+
 	// Wrap errors list in a container with minimum height
 	scrolledList := container.NewScroll(p.errorsList)
 	scrolledList.SetMinSize(fyne.NewSize(0, 150)) // Minimum 150px height
 
+	p.fixAllButton = widget.NewButtonWithIcon("Fix All", theme.ConfirmIcon(), func() {
+		if p.onFixAll != nil {
+			p.onFixAll()
+		}
+	})
+	p.fixAllButton.Hide()
+
 	p.mainContainer = container.NewBorder(
 		container.NewVBox(
-			widget.NewLabel("Validation Errors"),
+			container.NewBorder(nil, nil, widget.NewLabel("Validation Errors"), container.NewHBox(p.fixAllButton, p.severityCheck)),
 			widget.NewSeparator(),
 		),
 		nil, nil, nil,
@@ -85,67 +179,103 @@ func (p *ErrorsPanel) createUI() {
 	)
 }
 
-// SetErrors updates the panel with validation errors
+// SetErrors updates the panel with validation findings of every severity
 func (p *ErrorsPanel) SetErrors(result pom.ValidationResult) {
 	p.errors = make([]errorItem, 0)
 
-	if result.Valid {
-		p.visible = false
-		// UI updates must be called on UI thread
-		fyne.Do(func() {
-			p.errorsList.Refresh()
-		})
-		return
+	groups := []struct {
+		category string
+		findings []pom.ValidationError
+	}{
+		{"Coordinates", result.Errors.Coordinates},
+		{"Dependencies", result.Errors.Dependencies},
+		{"Build", result.Errors.Build},
+		{"General", result.Errors.General},
 	}
-
-	// Add coordinate errors
-	for i, err := range result.Errors.Coordinates {
-		p.errors = append(p.errors, errorItem{
-			category: "Coordinates",
-			message:  err.Error(),
-			index:    i,
-		})
+	for _, group := range groups {
+		for _, err := range group.findings {
+			p.errors = append(p.errors, newErrorItem(group.category, err))
+		}
 	}
 
-	// Add dependency errors
-	for i, err := range result.Errors.Dependencies {
-		p.errors = append(p.errors, errorItem{
-			category: "Dependencies",
-			message:  err.Error(),
-			index:    i,
-		})
+	p.update()
+}
+
+// SetWarnings updates the non-blocking findings listed after validation
+// errors, such as redundant redeclarations of inherited elements
+func (p *ErrorsPanel) SetWarnings(category string, warnings []pom.ValidationError) {
+	p.warnings = make([]errorItem, 0, len(warnings))
+	for _, w := range warnings {
+		p.warnings = append(p.warnings, newErrorItem(category, w))
 	}
 
-	// Add build errors
-	for i, err := range result.Errors.Build {
-		p.errors = append(p.errors, errorItem{
-			category: "Build",
-			message:  err.Error(),
-			index:    i,
-		})
+	p.update()
+}
+
+// newErrorItem creates the list entry for a finding
+func newErrorItem(category string, err pom.ValidationError) errorItem {
+	message := err.Error()
+	if err.Severity == pom.SeverityInfo {
+		message = fmt.Sprintf("%s: %s", err.Value, err.Message)
+	}
+	if err.Rule != "" {
+		message = fmt.Sprintf("%s [%s]", message, err.Rule)
+	}
+	return errorItem{
+		category: category,
+		message:  message,
+		finding:  err,
 	}
+}
 
-	// Add general errors
-	for i, err := range result.Errors.General {
-		p.errors = append(p.errors, errorItem{
-			category: "General",
-			message:  err.Error(),
-			index:    i,
-		})
+// update refreshes the list after the findings changed. Info findings alone
+// do not make the panel visible.
+func (p *ErrorsPanel) update() {
+	p.visible = false
+	fixable := false
+	for _, items := range [][]errorItem{p.errors, p.warnings} {
+		for _, item := range items {
+			if item.finding.Severity != pom.SeverityInfo {
+				p.visible = true
+			}
+			if item.finding.Fix != nil {
+				fixable = true
+			}
+		}
 	}
 
-	p.visible = len(p.errors) > 0
 	// UI updates must be called on UI thread
 	fyne.Do(func() {
+		p.applyFilter()
 		p.errorsList.Refresh()
+		if fixable {
+			p.fixAllButton.Show()
+		} else {
+			p.fixAllButton.Hide()
+		}
 	})
 }
 
+// applyFilter recomputes the entries shown for the selected severities
+func (p *ErrorsPanel) applyFilter() {
+	p.shown = make([]errorItem, 0, len(p.errors)+len(p.warnings))
+	for _, items := range [][]errorItem{p.errors, p.warnings} {
+		for _, item := range items {
+			if p.filter[item.finding.Severity] {
+				p.shown = append(p.shown, item)
+			}
+		}
+	}
+}
+
 // Clear clears all errors
 func (p *ErrorsPanel) Clear() {
 	p.errors = make([]errorItem, 0)
+	p.warnings = make([]errorItem, 0)
+	p.shown = make([]errorItem, 0)
 	p.visible = false
 	p.errorsList.Refresh()
+	p.fixAllButton.Hide()
 }
 
 // IsVisible returns whether the panel should be visible
@@ -153,11 +283,26 @@ func (p *ErrorsPanel) IsVisible() bool {
 	return p.visible
 }
 
-// OnErrorClick sets the callback for error clicks
-func (p *ErrorsPanel) OnErrorClick(callback func(errorType string, index int)) {
+// OnErrorClick sets the callback for clicks on a finding
+func (p *ErrorsPanel) OnErrorClick(callback func(finding pom.ValidationError)) {
 	p.onErrorClick = callback
 }
 
+// OnFix sets the callback for applying the quick-fix of a finding
+func (p *ErrorsPanel) OnFix(callback func(finding pom.ValidationError)) {
+	p.onFix = callback
+}
+
+// OnFixAll sets the callback for applying every available quick-fix
+func (p *ErrorsPanel) OnFixAll(callback func()) {
+	p.onFixAll = callback
+}
+
+// OnResolve sets the callback for resolving an undefined property finding
+func (p *ErrorsPanel) OnResolve(callback func(finding pom.ValidationError)) {
+	p.onResolve = callback
+}
+
 // GetContainer returns the main container for embedding
 func (p *ErrorsPanel) GetContainer() *fyne.Container {
 	return p.mainContainer
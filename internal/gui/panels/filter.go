@@ -0,0 +1,10 @@
+package panels
+
+import "strings"
+
+// matchesFilter reports whether text contains filter, ignoring case and the
+// spaces around filter; an empty filter matches everything
+func matchesFilter(text, filter string) bool {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	return filter == "" || strings.Contains(strings.ToLower(text), filter)
+}
@@ -0,0 +1,171 @@
+package panels
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// InheritancePanel shows which POM of the parent chain declares each
+// effective property, plugin version and managed version, and what it
+// overrides
+type InheritancePanel struct {
+	// UI components
+	tree          *widget.Tree
+	chainLabel    *widget.Label
+	filterEntry   *widget.Entry
+	mainContainer *fyne.Container
+
+	// State
+	inheritance *pom.Inheritance
+	treeData    map[string][]string // Parent UID -> Child UIDs
+	labels      map[string]string   // UID -> Display Label
+}
+
+// provenanceSections are the tree's top-level nodes, by provenance kind
+var provenanceSections = []struct {
+	kind  string
+	title string
+}{
+	{pom.ProvenanceProperty, "⚙️ Properties"},
+	{pom.ProvenancePlugin, "🔧 Plugins"},
+	{pom.ProvenanceManaged, "📦 Managed Versions"},
+}
+
+// NewInheritancePanel creates a new InheritancePanel
+func NewInheritancePanel() *InheritancePanel {
+	panel := &InheritancePanel{
+		treeData: make(map[string][]string),
+		labels:   make(map[string]string),
+	}
+
+	panel.createUI()
+	return panel
+}
+
+// createUI creates the panel layout
+func (p *InheritancePanel) createUI() {
+	p.tree = widget.NewTree(
+		func(uid string) []string {
+			return p.treeData[uid]
+		},
+		func(uid string) bool {
+			return len(p.treeData[uid]) > 0
+		},
+		func(branch bool) fyne.CanvasObject {
+			return widget.NewLabel("Template")
+		},
+		func(uid string, branch bool, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(p.labels[uid])
+		},
+	)
+
+	p.chainLabel = widget.NewLabel("")
+	p.chainLabel.Wrapping = fyne.TextWrapWord
+
+	p.filterEntry = widget.NewEntry()
+	p.filterEntry.SetPlaceHolder("Filter by name (e.g. junit, java.version)")
+	p.filterEntry.OnChanged = func(string) {
+		p.rebuild()
+	}
+
+	p.mainContainer = container.NewBorder(
+		container.NewVBox(
+			widget.NewLabel("Where do effective values come from?"),
+			p.chainLabel,
+			p.filterEntry,
+			widget.NewSeparator(),
+		),
+		nil, nil, nil,
+		p.tree,
+	)
+	p.rebuild()
+}
+
+// LoadInheritance updates the tree with the provenance of a project
+func (p *InheritancePanel) LoadInheritance(inheritance *pom.Inheritance) {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.inheritance = inheritance
+		p.rebuild()
+	})
+}
+
+// rebuild recomputes the tree nodes for the current filter
+func (p *InheritancePanel) rebuild() {
+	p.treeData = make(map[string][]string)
+	p.labels = make(map[string]string)
+
+	if p.inheritance == nil || len(p.inheritance.Parents) == 0 {
+		p.chainLabel.SetText("This POM has no parent that could be resolved, so every value is its own.")
+		p.tree.Refresh()
+		return
+	}
+
+	chain := make([]string, 0, len(p.inheritance.Parents))
+	for _, parent := range p.inheritance.Parents {
+		chain = append(chain, parent.Project.Coordinates.String())
+	}
+	p.chainLabel.SetText("This POM → " + strings.Join(chain, " → "))
+
+	filter := strings.ToLower(strings.TrimSpace(p.filterEntry.Text))
+	for _, section := range provenanceSections {
+		p.treeData[""] = append(p.treeData[""], section.kind)
+	}
+	for i, entry := range p.inheritance.Provenance {
+		if filter != "" && !strings.Contains(strings.ToLower(entry.Name), filter) {
+			continue
+		}
+
+		uid := fmt.Sprintf("entry:%d", i)
+		p.treeData[entry.Kind] = append(p.treeData[entry.Kind], uid)
+		p.labels[uid] = fmt.Sprintf("%s = %s   ← %s", entry.Name, provenanceValue(entry.Value), provenanceSource(entry.ProvenanceValue))
+
+		for j, overridden := range entry.Overrides {
+			overrideUID := fmt.Sprintf("%s:%d", uid, j)
+			p.treeData[uid] = append(p.treeData[uid], overrideUID)
+			p.labels[overrideUID] = fmt.Sprintf("overrides %s from %s", provenanceValue(overridden.Value), provenanceSource(overridden))
+		}
+	}
+	for _, section := range provenanceSections {
+		p.labels[section.kind] = fmt.Sprintf("%s (%d)", section.title, len(p.treeData[section.kind]))
+	}
+
+	p.tree.Refresh()
+	if filter != "" {
+		for _, section := range provenanceSections {
+			p.tree.OpenBranch(section.kind)
+		}
+	}
+}
+
+// GetContainer returns the main container for embedding
+func (p *InheritancePanel) GetContainer() *fyne.Container {
+	return p.mainContainer
+}
+
+// provenanceValue displays a declared value, which is empty for plugins
+// without a version
+func provenanceValue(value string) string {
+	if value == "" {
+		return "(no version)"
+	}
+	return value
+}
+
+// provenanceSource names the POM declaring a value by its place in the chain
+func provenanceSource(value pom.ProvenanceValue) string {
+	switch value.Depth {
+	case 0:
+		return "this POM"
+	case 1:
+		return "parent " + value.Source
+	default:
+		return fmt.Sprintf("ancestor %s (%d levels up)", value.Source, value.Depth)
+	}
+}
@@ -2,7 +2,8 @@ package panels
 
 import (
 	"fmt"
-	"sort"
+	"slices"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -23,12 +24,20 @@ type LifecyclePanel struct {
 	organizer pom.Organizer
 	project   *pom.Project
 	phaseMap  map[string][]pom.PluginExecution
+	readOnly  bool
 
 	// Callbacks
 	onAddExecution    func(pluginIndex int, execution pom.PluginExecution)
-	onRemoveExecution func(pluginIndex int, executionID string)
+	onEditExecution   func(pluginIndex, executionIndex int)
+	onRemoveExecution func(pluginIndex, executionIndex int)
+	onMoveExecution   func(phase string, from, to int)
 }
 
+// executionOrderHelp explains how Maven orders executions within a phase
+const executionOrderHelp = "Executions bound to the same phase run top to bottom, in POM order: " +
+	"plugins in declaration order, then each plugin's executions in order. " +
+	"Moving an execution past another plugin's moves the whole plugin, which also changes its order in other phases."
+
 // NewLifecyclePanel creates a new LifecyclePanel
 func NewLifecyclePanel() *LifecyclePanel {
 	panel := &LifecyclePanel{
@@ -50,7 +59,7 @@ func (p *LifecyclePanel) createUI() {
 		"Add a new plugin execution and bind it to a lifecycle phase",
 		func() {
 			if p.onAddExecution != nil && p.project != nil && p.project.Build != nil && len(p.project.Build.Plugins) > 0 {
-				// Callback will be triggered from parent
+				p.onAddExecution(-1, pom.PluginExecution{}) // -1 means show add dialog
 			}
 		})
 
@@ -102,7 +111,7 @@ func (p *LifecyclePanel) refresh() {
 		}
 
 		// Create content for this phase
-		phaseContent := p.createPhaseContent(phase, executions)
+		phaseContent := p.createPhaseContent(phase)
 
 		// Create accordion item
 		title := fmt.Sprintf("%s (%d)", phase, len(executions))
@@ -130,6 +139,12 @@ func (p *LifecyclePanel) refresh() {
 	// UI updates must be called on UI thread
 	// Completely recreate accordion to ensure proper expansion behavior
 	fyne.Do(func() {
+		// Remember open phases so reordering executions keeps them expanded
+		open := make(map[string]bool)
+		for _, item := range p.accordion.Items {
+			open[strings.SplitN(item.Title, " (", 2)[0]] = item.Open
+		}
+
 		// Clear all existing items
 		for len(p.accordion.Items) > 0 {
 			p.accordion.Remove(p.accordion.Items[0])
@@ -137,6 +152,7 @@ func (p *LifecyclePanel) refresh() {
 
 		// Add new items
 		for _, item := range items {
+			item.Open = open[strings.SplitN(item.Title, " (", 2)[0]]
 			p.accordion.Append(item)
 		}
 
@@ -144,14 +160,32 @@ func (p *LifecyclePanel) refresh() {
 	})
 }
 
-// createPhaseContent creates the content for a single phase section
-func (p *LifecyclePanel) createPhaseContent(phase string, executions []pom.PluginExecution) fyne.CanvasObject {
-	// Sort executions by plugin name for consistent display
-	sortedExecs := make([]pom.PluginExecution, len(executions))
-	copy(sortedExecs, executions)
-	sort.Slice(sortedExecs, func(i, j int) bool {
-		return sortedExecs[i].ID < sortedExecs[j].ID
+// OpenPhases returns the phases whose section is expanded
+func (p *LifecyclePanel) OpenPhases() []string {
+	var phases []string
+	for _, item := range p.accordion.Items {
+		if item.Open {
+			phases = append(phases, strings.SplitN(item.Title, " (", 2)[0])
+		}
+	}
+	return phases
+}
+
+// SetOpenPhases expands exactly the sections of the given phases
+func (p *LifecyclePanel) SetOpenPhases(phases []string) {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		for _, item := range p.accordion.Items {
+			item.Open = slices.Contains(phases, strings.SplitN(item.Title, " (", 2)[0])
+		}
+		p.accordion.Refresh()
 	})
+}
+
+// createPhaseContent creates the content for a single phase section
+func (p *LifecyclePanel) createPhaseContent(phase string) fyne.CanvasObject {
+	// Executions in the order Maven runs them
+	executions := p.organizer.ExecutionsInPhase(p.project, phase)
 
 	var widgets []fyne.CanvasObject
 
@@ -159,25 +193,33 @@ func (p *LifecyclePanel) createPhaseContent(phase string, executions []pom.Plugi
 	phaseDesc := widget.NewLabel(getPhaseDescription(phase))
 	phaseDesc.Wrapping = fyne.TextWrapWord
 	phaseDesc.TextStyle = fyne.TextStyle{Italic: true}
-	widgets = append(widgets, phaseDesc, widget.NewSeparator())
+	widgets = append(widgets, phaseDesc)
+	if len(executions) > 1 {
+		orderHelp := widget.NewLabel("ℹ " + executionOrderHelp)
+		orderHelp.Wrapping = fyne.TextWrapWord
+		orderHelp.Importance = widget.LowImportance
+		widgets = append(widgets, orderHelp)
+	}
+	widgets = append(widgets, widget.NewSeparator())
 
 	// List each execution
-	for i, exec := range sortedExecs {
-		execCard := p.createExecutionCard(i+1, exec)
+	for i, exec := range executions {
+		execCard := p.createExecutionCard(phase, i, len(executions), exec)
 		widgets = append(widgets, execCard)
 	}
 
 	return container.NewVBox(widgets...)
 }
 
-// createExecutionCard creates a card displaying a single plugin execution
-func (p *LifecyclePanel) createExecutionCard(index int, exec pom.PluginExecution) fyne.CanvasObject {
+// createExecutionCard creates a card displaying a single plugin execution,
+// at position index of count in its phase
+func (p *LifecyclePanel) createExecutionCard(phase string, index, count int, exec pom.PhaseExecution) fyne.CanvasObject {
 	// Execution ID
-	idLabel := widget.NewLabel(fmt.Sprintf("%d. Execution ID: %s", index, exec.ID))
+	idLabel := widget.NewLabel(fmt.Sprintf("%d. %s: %s", index+1, exec.Plugin.ArtifactID, exec.Execution.ID))
 	idLabel.TextStyle = fyne.TextStyle{Bold: true}
 
 	// Goals
-	goalsText := "Goals: " + formatGoals(exec.Goals)
+	goalsText := "Goals: " + formatGoals(exec.Execution.Goals)
 	goalsLabel := widget.NewLabel(goalsText)
 
 	// Build card content
@@ -187,14 +229,53 @@ func (p *LifecyclePanel) createExecutionCard(index int, exec pom.PluginExecution
 	)
 
 	// Configuration info (if present)
-	if exec.Configuration != nil {
+	if exec.Execution.Configuration != nil {
 		configLabel := widget.NewLabel("✓ Has configuration")
 		configLabel.TextStyle = fyne.TextStyle{Italic: true}
 		cardContent.Add(configLabel)
 	}
 
+	// Edit and remove buttons, then reorder buttons when the phase has more
+	// than one execution
+	editButton := widgets.NewButtonWithTooltip("Edit", "Change the plugin, ID, phase or goals of this execution", func() {
+		if p.onEditExecution != nil {
+			p.onEditExecution(exec.PluginIndex, exec.ExecutionIndex)
+		}
+	})
+	removeButton := widgets.NewButtonWithTooltip("Remove", "Remove this execution from the plugin", func() {
+		if p.onRemoveExecution != nil {
+			p.onRemoveExecution(exec.PluginIndex, exec.ExecutionIndex)
+		}
+	})
+	if p.readOnly {
+		editButton.Disable()
+		removeButton.Disable()
+	}
+	buttons := container.NewHBox(editButton, removeButton)
+
+	if count > 1 {
+		upButton := widgets.NewButtonWithTooltip("↑", "Run earlier in this phase. "+executionOrderHelp, func() {
+			if p.onMoveExecution != nil {
+				p.onMoveExecution(phase, index, index-1)
+			}
+		})
+		downButton := widgets.NewButtonWithTooltip("↓", "Run later in this phase. "+executionOrderHelp, func() {
+			if p.onMoveExecution != nil {
+				p.onMoveExecution(phase, index, index+1)
+			}
+		})
+		if p.readOnly || index == 0 {
+			upButton.Disable()
+		}
+		if p.readOnly || index == count-1 {
+			downButton.Disable()
+		}
+		buttons.Add(upButton)
+		buttons.Add(downButton)
+	}
+
 	// Create card with padding
-	card := container.NewPadded(cardContent)
+	card := container.NewPadded(container.NewBorder(nil, nil, nil, buttons, cardContent))
 
 	return card
 }
@@ -214,9 +295,13 @@ func formatGoals(goals []string) string {
 	return result
 }
 
-// getPhaseDescription returns a description for each Maven lifecycle phase
+// getPhaseDescription returns a description for each phase of the clean,
+// default and site lifecycles
 func getPhaseDescription(phase string) string {
 	descriptions := map[string]string{
+		pom.PhasePreClean:              "Clean lifecycle: prepare for cleaning",
+		pom.PhaseClean:                 "Clean lifecycle: remove files generated by previous builds",
+		pom.PhasePostClean:             "Clean lifecycle: finish cleaning",
 		pom.PhaseValidate:              "Validate project structure and configuration",
 		pom.PhaseInitialize:            "Initialize build state",
 		pom.PhaseGenerateSources:       "Generate source code",
@@ -240,6 +325,10 @@ func getPhaseDescription(phase string) string {
 		pom.PhaseVerify:                "Verify package is valid",
 		pom.PhaseInstall:               "Install package to local repository",
 		pom.PhaseDeploy:                "Deploy package to remote repository",
+		pom.PhasePreSite:               "Site lifecycle: prepare for generating the site",
+		pom.PhaseSite:                  "Site lifecycle: generate the project's site documentation",
+		pom.PhasePostSite:              "Site lifecycle: finish generating the site",
+		pom.PhaseSiteDeploy:            "Site lifecycle: deploy the site to the web server",
 	}
 
 	if desc, found := descriptions[phase]; found {
@@ -261,22 +350,46 @@ func (p *LifecyclePanel) Clear() {
 	p.accordion.Refresh()
 }
 
+// SetReadOnly disables adding, editing, removing and reordering executions
+// while in view-only mode
+func (p *LifecyclePanel) SetReadOnly(readOnly bool) {
+	if p.readOnly != readOnly {
+		p.readOnly = readOnly
+		p.refresh()
+	}
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		if readOnly {
+			p.addButton.Disable()
+		} else {
+			p.addButton.Enable()
+		}
+	})
+}
+
 // OnAddExecution sets the callback for adding an execution
 func (p *LifecyclePanel) OnAddExecution(callback func(pluginIndex int, execution pom.PluginExecution)) {
 	p.onAddExecution = callback
-	// Update button callback
-	p.addButton.OnTapped = func() {
-		if callback != nil && p.project != nil && p.project.Build != nil && len(p.project.Build.Plugins) > 0 {
-			callback(-1, pom.PluginExecution{}) // -1 means show add dialog
-		}
-	}
 }
 
-// OnRemoveExecution sets the callback for removing an execution
-func (p *LifecyclePanel) OnRemoveExecution(callback func(pluginIndex int, executionID string)) {
+// OnEditExecution sets the callback for editing the execution at
+// executionIndex of the plugin at pluginIndex
+func (p *LifecyclePanel) OnEditExecution(callback func(pluginIndex, executionIndex int)) {
+	p.onEditExecution = callback
+}
+
+// OnRemoveExecution sets the callback for removing the execution at
+// executionIndex of the plugin at pluginIndex
+func (p *LifecyclePanel) OnRemoveExecution(callback func(pluginIndex, executionIndex int)) {
 	p.onRemoveExecution = callback
 }
 
+// OnMoveExecution sets the callback for moving the execution at position
+// from among those of a phase to position to
+func (p *LifecyclePanel) OnMoveExecution(callback func(phase string, from, to int)) {
+	p.onMoveExecution = callback
+}
+
 // GetProject returns the current project
 func (p *LifecyclePanel) GetProject() *pom.Project {
 	return p.project
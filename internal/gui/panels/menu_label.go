@@ -0,0 +1,56 @@
+package panels
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ContextMenuFunc returns the context menu of an item, identified as tree
+// nodes are: nodeType "dep", "plugin", "prop" or "profile", and the index of
+// the dependency, plugin or profile in the project or the property name as
+// id, or "coordinates" for the project. It returns nil when the item has no
+// menu.
+type ContextMenuFunc func(nodeType string, id string) *fyne.Menu
+
+// menuLabel is a list row label which can be right-clicked. Being tappable,
+// it takes left clicks from its list, so onTapped selects the row instead.
+type menuLabel struct {
+	widget.Label
+	onTapped          func()
+	onTappedSecondary func(*fyne.PointEvent)
+}
+
+func newMenuLabel() *menuLabel {
+	label := &menuLabel{}
+	label.Text = "template"
+	label.ExtendBaseWidget(label)
+	return label
+}
+
+// Tapped implements fyne.Tappable
+func (l *menuLabel) Tapped(*fyne.PointEvent) {
+	if l.onTapped != nil {
+		l.onTapped()
+	}
+}
+
+// TappedSecondary implements fyne.SecondaryTappable
+func (l *menuLabel) TappedSecondary(event *fyne.PointEvent) {
+	if l.onTappedSecondary != nil {
+		l.onTappedSecondary(event)
+	}
+}
+
+// showContextMenu shows the context menu of an item where obj was
+// right-clicked, if callback returns one
+func showContextMenu(obj fyne.CanvasObject, callback ContextMenuFunc, nodeType, id string, event *fyne.PointEvent) {
+	if callback == nil {
+		return
+	}
+	menu := callback(nodeType, id)
+	canvas := fyne.CurrentApp().Driver().CanvasForObject(obj)
+	if menu == nil || canvas == nil {
+		return
+	}
+	widget.ShowPopUpMenuAtPosition(menu, canvas, event.AbsolutePosition)
+}
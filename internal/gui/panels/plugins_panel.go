@@ -2,6 +2,8 @@ package panels
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -14,20 +16,32 @@ import (
 // PluginsPanel provides interface for managing build plugins
 type PluginsPanel struct {
 	// UI components
-	pluginsList   *widget.List
-	addButton     *widgets.ButtonWithTooltip
-	editButton    *widgets.ButtonWithTooltip
-	removeButton  *widgets.ButtonWithTooltip
-	mainContainer *fyne.Container
+	pluginsList      *widget.List
+	addButton        *widgets.ButtonWithTooltip
+	editButton       *widgets.ButtonWithTooltip
+	removeButton     *widgets.ButtonWithTooltip
+	skipButton       *widgets.ButtonWithTooltip
+	bookmarkButton   *widgets.ButtonWithTooltip
+	inheritedList    *widget.List
+	inheritedSection *fyne.Container
+	mainContainer    *fyne.Container
 
 	// State
 	plugins       []pom.Plugin
+	shown         []pom.Plugin // Plugins matching the filter
+	filter        string
+	inherited     []pom.InheritedPlugin
 	selectedIndex int
+	readOnly      bool
 
 	// Callbacks
-	onAdd    func()
-	onEdit   func(pom.Plugin)
-	onRemove func(pom.Plugin)
+	onAdd      func()
+	onEdit     func(pom.Plugin)
+	onRemove   func(pom.Plugin)
+	onSkip     func(pom.Plugin)
+	onBookmark func(pom.Plugin)
+
+	onContextMenu ContextMenuFunc
 }
 
 // NewPluginsPanel creates a new PluginsPanel
@@ -46,14 +60,22 @@ func (p *PluginsPanel) createUI() {
 	// Create list
 	p.pluginsList = widget.NewList(
 		func() int {
-			return len(p.plugins)
+			return len(p.shown)
 		},
 		func() fyne.CanvasObject {
-			return widget.NewLabel("template")
+			return newMenuLabel()
 		},
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
-			label := obj.(*widget.Label)
-			plugin := p.plugins[id]
+			label := obj.(*menuLabel)
+			plugin := p.shown[id]
+			index := slices.IndexFunc(p.plugins, func(other pom.Plugin) bool {
+				return other.GroupID == plugin.GroupID && other.ArtifactID == plugin.ArtifactID
+			})
+			label.onTapped = func() { p.pluginsList.Select(id) }
+			label.onTappedSecondary = func(event *fyne.PointEvent) {
+				p.pluginsList.Select(id)
+				showContextMenu(label, p.onContextMenu, "plugin", strconv.Itoa(index), event)
+			}
 			if plugin.Version != "" {
 				label.SetText(fmt.Sprintf("%s:%s:%s",
 					plugin.GroupID, plugin.ArtifactID, plugin.Version))
@@ -74,6 +96,33 @@ func (p *PluginsPanel) createUI() {
 		p.updateButtonStates()
 	}
 
+	// Inherited plugins are shown dimmed and cannot be edited here
+	p.inheritedList = widget.NewList(
+		func() int {
+			return len(p.inherited)
+		},
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("template")
+			label.Importance = widget.LowImportance
+			return label
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			plugin := p.inherited[id]
+			coords := fmt.Sprintf("%s:%s", plugin.GroupID, plugin.ArtifactID)
+			if plugin.Version != "" {
+				coords += ":" + plugin.Version
+			}
+			label.SetText(fmt.Sprintf("%s (from %s)", coords, plugin.Source))
+		},
+	)
+	p.inheritedSection = container.NewBorder(
+		widget.NewLabel("Inherited from parent"),
+		nil, nil, nil,
+		p.inheritedList,
+	)
+	p.inheritedSection.Hide()
+
 	// Create buttons with tooltips
 	p.addButton = widgets.NewButtonWithTooltip("Add Plugin",
 		"Add a new Maven build plugin to the project",
@@ -86,8 +135,8 @@ func (p *PluginsPanel) createUI() {
 	p.editButton = widgets.NewButtonWithTooltip("Edit",
 		"Edit the selected build plugin",
 		func() {
-			if p.selectedIndex >= 0 && p.selectedIndex < len(p.plugins) && p.onEdit != nil {
-				p.onEdit(p.plugins[p.selectedIndex])
+			if p.selectedIndex >= 0 && p.selectedIndex < len(p.shown) && p.onEdit != nil {
+				p.onEdit(p.shown[p.selectedIndex])
 			}
 		})
 	p.editButton.Disable()
@@ -95,17 +144,37 @@ func (p *PluginsPanel) createUI() {
 	p.removeButton = widgets.NewButtonWithTooltip("Remove",
 		"Remove the selected build plugin from the project",
 		func() {
-			if p.selectedIndex >= 0 && p.selectedIndex < len(p.plugins) && p.onRemove != nil {
-				p.onRemove(p.plugins[p.selectedIndex])
+			if p.selectedIndex >= 0 && p.selectedIndex < len(p.shown) && p.onRemove != nil {
+				p.onRemove(p.shown[p.selectedIndex])
 			}
 		})
 	p.removeButton.Disable()
 
+	p.skipButton = widgets.NewButtonWithTooltip("Skip...",
+		"Turn off the selected plugin's work (e.g. skipTests, maven.javadoc.skip) always or in a profile",
+		func() {
+			if p.selectedIndex >= 0 && p.selectedIndex < len(p.shown) && p.onSkip != nil {
+				p.onSkip(p.shown[p.selectedIndex])
+			}
+		})
+	p.skipButton.Disable()
+
+	p.bookmarkButton = widgets.NewButtonWithTooltip("Bookmark",
+		"Bookmark the selected plugin with a note (stored locally, not in the POM)",
+		func() {
+			if p.selectedIndex >= 0 && p.selectedIndex < len(p.shown) && p.onBookmark != nil {
+				p.onBookmark(p.shown[p.selectedIndex])
+			}
+		})
+	p.bookmarkButton.Disable()
+
 	// Create layout
 	buttonBar := container.NewHBox(
 		p.addButton,
 		p.editButton,
 		p.removeButton,
+		p.skipButton,
+		p.bookmarkButton,
 	)
 
 	p.mainContainer = container.NewBorder(
@@ -115,31 +184,124 @@ func (p *PluginsPanel) createUI() {
 		),
 		buttonBar,
 		nil, nil,
-		p.pluginsList,
+		container.NewVSplit(p.pluginsList, p.inheritedSection),
 	)
 }
 
 // LoadPlugins updates the list with plugins
 func (p *PluginsPanel) LoadPlugins(plugins []pom.Plugin) {
 	p.plugins = plugins
+	p.applyFilter()
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.pluginsList.Refresh()
+		p.selectedIndex = -1
+		p.updateButtonStates()
+	})
+}
+
+// SelectPlugin highlights the plugin with the given groupId and artifactId
+func (p *PluginsPanel) SelectPlugin(groupID, artifactID string) {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		if !slices.ContainsFunc(p.shown, func(plugin pom.Plugin) bool {
+			return plugin.GroupID == groupID && plugin.ArtifactID == artifactID
+		}) {
+			// Clear a filter hiding it
+			p.filter = ""
+			p.applyFilter()
+			p.pluginsList.Refresh()
+		}
+		for i, plugin := range p.shown {
+			if plugin.GroupID == groupID && plugin.ArtifactID == artifactID {
+				p.pluginsList.Select(i)
+				p.pluginsList.ScrollTo(i)
+				return
+			}
+		}
+	})
+}
+
+// SetFilter shows only the plugins whose coordinates contain text, ignoring
+// case
+func (p *PluginsPanel) SetFilter(text string) {
+	p.filter = text
+	p.applyFilter()
 	// UI updates must be called on UI thread
 	fyne.Do(func() {
+		p.pluginsList.UnselectAll()
 		p.pluginsList.Refresh()
 		p.selectedIndex = -1
 		p.updateButtonStates()
 	})
 }
 
+// CountMatches returns how many plugins filter would list
+func (p *PluginsPanel) CountMatches(filter string) int {
+	count := 0
+	for _, plugin := range p.plugins {
+		if matchesFilter(pluginFilterText(plugin), filter) {
+			count++
+		}
+	}
+	return count
+}
+
+// applyFilter lists the plugins matching the filter
+func (p *PluginsPanel) applyFilter() {
+	p.shown = nil
+	for _, plugin := range p.plugins {
+		if matchesFilter(pluginFilterText(plugin), p.filter) {
+			p.shown = append(p.shown, plugin)
+		}
+	}
+}
+
+// pluginFilterText is what the filter looks for in a plugin: its
+// coordinates
+func pluginFilterText(plugin pom.Plugin) string {
+	return fmt.Sprintf("%s:%s:%s", plugin.GroupID, plugin.ArtifactID, plugin.Version)
+}
+
+// LoadInherited updates the dimmed list of plugins inherited from parents
+func (p *PluginsPanel) LoadInherited(plugins []pom.InheritedPlugin) {
+	p.inherited = plugins
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.inheritedList.Refresh()
+		if len(p.inherited) > 0 {
+			p.inheritedSection.Show()
+		} else {
+			p.inheritedSection.Hide()
+		}
+	})
+}
+
 // updateButtonStates enables/disables buttons based on selection
 func (p *PluginsPanel) updateButtonStates() {
-	hasSelection := p.selectedIndex >= 0 && p.selectedIndex < len(p.plugins)
+	hasSelection := p.selectedIndex >= 0 && p.selectedIndex < len(p.shown)
+
+	// Bookmarks live outside the POM, so they stay available when read-only
 	if hasSelection {
+		p.bookmarkButton.Enable()
+	} else {
+		p.bookmarkButton.Disable()
+	}
+
+	if hasSelection && !p.readOnly {
 		p.editButton.Enable()
 		p.removeButton.Enable()
 	} else {
 		p.editButton.Disable()
 		p.removeButton.Disable()
 	}
+
+	// Only plugins with known skip properties can be skipped here
+	if hasSelection && !p.readOnly && len(pom.SkipFlags(p.shown[p.selectedIndex])) > 0 {
+		p.skipButton.Enable()
+	} else {
+		p.skipButton.Disable()
+	}
 }
 
 // OnAdd sets the callback for adding a plugin
@@ -157,6 +319,47 @@ func (p *PluginsPanel) OnRemove(callback func(pom.Plugin)) {
 	p.onRemove = callback
 }
 
+// OnSkip sets the callback for changing a plugin's skip flags
+func (p *PluginsPanel) OnSkip(callback func(pom.Plugin)) {
+	p.onSkip = callback
+}
+
+// OnBookmark sets the callback for bookmarking a plugin
+func (p *PluginsPanel) OnBookmark(callback func(pom.Plugin)) {
+	p.onBookmark = callback
+}
+
+// OnContextMenu sets the callback returning the menu shown when a plugin is
+// right-clicked
+func (p *PluginsPanel) OnContextMenu(callback ContextMenuFunc) {
+	p.onContextMenu = callback
+}
+
+// SetReadOnly disables all mutating buttons while in view-only mode
+func (p *PluginsPanel) SetReadOnly(readOnly bool) {
+	p.readOnly = readOnly
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		if readOnly {
+			p.addButton.Disable()
+		} else {
+			p.addButton.Enable()
+		}
+		p.updateButtonStates()
+	})
+}
+
+// EditSelected opens the selected plugin for editing, as the Edit button
+// does
+func (p *PluginsPanel) EditSelected() {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		if !p.editButton.Disabled() {
+			p.editButton.OnTapped()
+		}
+	})
+}
+
 // GetContainer returns the main container for embedding
 func (p *PluginsPanel) GetContainer() *fyne.Container {
 	return p.mainContainer
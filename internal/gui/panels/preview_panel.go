@@ -4,6 +4,7 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 
+	"github.com/user/pom-manager/internal/core/pom"
 	"github.com/user/pom-manager/internal/gui/widgets"
 )
 
@@ -96,6 +97,19 @@ func (p *PreviewPane) CopyToClipboard() {
 	}
 }
 
+// ShowElement scrolls to the element of a field, such as "dependencies[2]"
+// or "properties.java.version", as recorded by pom.BuildSourceMap
+func (p *PreviewPane) ShowElement(field string) {
+	position, ok := pom.BuildSourceMap([]byte(p.currentXML)).Lookup(field)
+	if !ok {
+		return
+	}
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.xmlViewer.ScrollToLine(position.Line)
+	})
+}
+
 // SetLivePreview enables or disables live preview mode
 func (p *PreviewPane) SetLivePreview(enabled bool) {
 	p.livePreview = enabled
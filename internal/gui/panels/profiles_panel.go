@@ -2,6 +2,8 @@ package panels
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -20,7 +22,12 @@ type ProfilesPanel struct {
 
 	// State
 	profiles      []pom.Profile
+	shown         []pom.Profile // Profiles matching the filter
+	filter        string
 	selectedIndex int
+
+	// Callbacks
+	onContextMenu ContextMenuFunc
 }
 
 // NewProfilesPanel creates a new ProfilesPanel
@@ -38,15 +45,21 @@ func (p *ProfilesPanel) createUI() {
 	// Create profiles list
 	p.profilesList = widget.NewList(
 		func() int {
-			return len(p.profiles)
+			return len(p.shown)
 		},
 		func() fyne.CanvasObject {
-			return widget.NewLabel("template")
+			return newMenuLabel()
 		},
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
-			label := obj.(*widget.Label)
-			if int(id) < len(p.profiles) {
-				profile := p.profiles[id]
+			label := obj.(*menuLabel)
+			if int(id) < len(p.shown) {
+				profile := p.shown[id]
+				index := slices.IndexFunc(p.profiles, func(other pom.Profile) bool { return other.ID == profile.ID })
+				label.onTapped = func() { p.profilesList.Select(id) }
+				label.onTappedSecondary = func(event *fyne.PointEvent) {
+					p.profilesList.Select(id)
+					showContextMenu(label, p.onContextMenu, "profile", strconv.Itoa(index), event)
+				}
 				activationStatus := ""
 				if profile.Activation != nil && profile.Activation.ActiveByDefault {
 					activationStatus = " ✓"
@@ -85,22 +98,79 @@ func (p *ProfilesPanel) createUI() {
 // LoadProfiles updates the panel with profiles
 func (p *ProfilesPanel) LoadProfiles(profiles []pom.Profile) {
 	p.profiles = profiles
+	p.applyFilter()
+	p.selectedIndex = -1
+
+	fyne.Do(func() {
+		p.profilesList.Refresh()
+		p.detailsText.ParseMarkdown("*Select a profile to view details*")
+		p.detailsCard.SetSubTitle("")
+	})
+}
+
+// SetFilter shows only the profiles whose ID contains text, ignoring case
+func (p *ProfilesPanel) SetFilter(text string) {
+	p.filter = text
+	p.applyFilter()
 	p.selectedIndex = -1
 
 	fyne.Do(func() {
+		p.profilesList.UnselectAll()
 		p.profilesList.Refresh()
 		p.detailsText.ParseMarkdown("*Select a profile to view details*")
 		p.detailsCard.SetSubTitle("")
 	})
 }
 
+// CountMatches returns how many profiles filter would list
+func (p *ProfilesPanel) CountMatches(filter string) int {
+	count := 0
+	for _, profile := range p.profiles {
+		if matchesFilter(profile.ID, filter) {
+			count++
+		}
+	}
+	return count
+}
+
+// applyFilter lists the profiles matching the filter
+func (p *ProfilesPanel) applyFilter() {
+	p.shown = nil
+	for _, profile := range p.profiles {
+		if matchesFilter(profile.ID, p.filter) {
+			p.shown = append(p.shown, profile)
+		}
+	}
+}
+
+// SelectProfile highlights the profile with the given ID and shows its
+// details
+func (p *ProfilesPanel) SelectProfile(id string) {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		if !slices.ContainsFunc(p.shown, func(profile pom.Profile) bool { return profile.ID == id }) {
+			// Clear a filter hiding it
+			p.filter = ""
+			p.applyFilter()
+			p.profilesList.Refresh()
+		}
+		for i, profile := range p.shown {
+			if profile.ID == id {
+				p.profilesList.Select(i)
+				p.profilesList.ScrollTo(i)
+				return
+			}
+		}
+	})
+}
+
 // showProfileDetails displays details for the selected profile
 func (p *ProfilesPanel) showProfileDetails(index int) {
-	if index < 0 || index >= len(p.profiles) {
+	if index < 0 || index >= len(p.shown) {
 		return
 	}
 
-	profile := p.profiles[index]
+	profile := p.shown[index]
 
 	// Build details markdown
 	details := fmt.Sprintf("# Profile: %s\n\n", profile.ID)
@@ -197,6 +267,12 @@ func (p *ProfilesPanel) showProfileDetails(index int) {
 	})
 }
 
+// OnContextMenu sets the callback returning the menu shown when a profile
+// is right-clicked
+func (p *ProfilesPanel) OnContextMenu(callback ContextMenuFunc) {
+	p.onContextMenu = callback
+}
+
 // GetContainer returns the main container for embedding
 func (p *ProfilesPanel) GetContainer() *fyne.Container {
 	return p.mainContainer
@@ -2,6 +2,7 @@ package panels
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 
 	"fyne.io/fyne/v2"
@@ -9,28 +10,36 @@ import (
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
 
+	"github.com/user/pom-manager/internal/core/pom"
 	"github.com/user/pom-manager/internal/gui/widgets"
 )
 
 // PropertiesPanel provides interface for managing Maven properties
 type PropertiesPanel struct {
 	// UI components
-	propertiesList *widget.List
-	addButton      *widgets.ButtonWithTooltip
-	editButton     *widgets.ButtonWithTooltip
-	removeButton   *widgets.ButtonWithTooltip
-	mainContainer  *fyne.Container
+	propertiesList   *widget.List
+	addButton        *widgets.ButtonWithTooltip
+	editButton       *widgets.ButtonWithTooltip
+	removeButton     *widgets.ButtonWithTooltip
+	inheritedList    *widget.List
+	inheritedSection *fyne.Container
+	mainContainer    *fyne.Container
 
 	// State
 	properties    map[string]string
-	propertyKeys  []string
+	propertyKeys  []string // Keys of the properties matching the filter
+	filter        string
+	inherited     []pom.InheritedProperty
 	selectedIndex int
+	readOnly      bool
 
 	// Parent window for dialogs
 	window fyne.Window
 
 	// Callbacks
-	onChange func(map[string]string)
+	onChange        func(map[string]string)
+	onConfirmRemove func(key string, remove func())
+	onContextMenu   ContextMenuFunc
 }
 
 // NewPropertiesPanel creates a new PropertiesPanel
@@ -54,12 +63,17 @@ func (p *PropertiesPanel) createUI() {
 			return len(p.propertyKeys)
 		},
 		func() fyne.CanvasObject {
-			return widget.NewLabel("template")
+			return newMenuLabel()
 		},
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
-			label := obj.(*widget.Label)
+			label := obj.(*menuLabel)
 			key := p.propertyKeys[id]
 			value := p.properties[key]
+			label.onTapped = func() { p.propertiesList.Select(id) }
+			label.onTappedSecondary = func(event *fyne.PointEvent) {
+				p.propertiesList.Select(id)
+				showContextMenu(label, p.onContextMenu, "prop", key, event)
+			}
 			label.SetText(fmt.Sprintf("%s = %s", key, value))
 		},
 	)
@@ -74,6 +88,29 @@ func (p *PropertiesPanel) createUI() {
 		p.updateButtonStates()
 	}
 
+	// Inherited properties are shown dimmed and cannot be edited here
+	p.inheritedList = widget.NewList(
+		func() int {
+			return len(p.inherited)
+		},
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("template")
+			label.Importance = widget.LowImportance
+			return label
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			prop := p.inherited[id]
+			label.SetText(fmt.Sprintf("%s = %s (from %s)", prop.Name, prop.Value, prop.Source))
+		},
+	)
+	p.inheritedSection = container.NewBorder(
+		widget.NewLabel("Inherited from parent"),
+		nil, nil, nil,
+		p.inheritedList,
+	)
+	p.inheritedSection.Hide()
+
 	// Create buttons with tooltips
 	p.addButton = widgets.NewButtonWithTooltip("Add Property",
 		"Add a new Maven property (key-value pair)",
@@ -97,12 +134,11 @@ func (p *PropertiesPanel) createUI() {
 		func() {
 			if p.selectedIndex >= 0 && p.selectedIndex < len(p.propertyKeys) {
 				key := p.propertyKeys[p.selectedIndex]
-				delete(p.properties, key)
-				p.rebuildKeys()
-				p.propertiesList.Refresh()
-				p.selectedIndex = -1
-				p.updateButtonStates()
-				p.notifyChange()
+				if p.onConfirmRemove != nil {
+					p.onConfirmRemove(key, func() { p.removeProperty(key) })
+				} else {
+					p.removeProperty(key)
+				}
 			}
 		})
 	p.removeButton.Disable()
@@ -121,10 +157,20 @@ func (p *PropertiesPanel) createUI() {
 		),
 		buttonBar,
 		nil, nil,
-		p.propertiesList,
+		container.NewVSplit(p.propertiesList, p.inheritedSection),
 	)
 }
 
+// removeProperty removes a property and reports the change
+func (p *PropertiesPanel) removeProperty(key string) {
+	delete(p.properties, key)
+	p.rebuildKeys()
+	p.propertiesList.Refresh()
+	p.selectedIndex = -1
+	p.updateButtonStates()
+	p.notifyChange()
+}
+
 // showPropertyDialog shows a dialog for adding or editing a property
 func (p *PropertiesPanel) showPropertyDialog(existingKey, existingValue string) {
 	keyEntry := widget.NewEntry()
@@ -195,6 +241,40 @@ func (p *PropertiesPanel) LoadProperties(props map[string]string) {
 	})
 }
 
+// SelectProperty highlights the property with the given name
+func (p *PropertiesPanel) SelectProperty(name string) {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		if _, ok := p.properties[name]; ok && !slices.Contains(p.propertyKeys, name) {
+			// Clear a filter hiding it
+			p.filter = ""
+			p.rebuildKeys()
+			p.propertiesList.Refresh()
+		}
+		for i, key := range p.propertyKeys {
+			if key == name {
+				p.propertiesList.Select(i)
+				p.propertiesList.ScrollTo(i)
+				return
+			}
+		}
+	})
+}
+
+// LoadInherited updates the dimmed list of properties inherited from parents
+func (p *PropertiesPanel) LoadInherited(props []pom.InheritedProperty) {
+	p.inherited = props
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.inheritedList.Refresh()
+		if len(p.inherited) > 0 {
+			p.inheritedSection.Show()
+		} else {
+			p.inheritedSection.Hide()
+		}
+	})
+}
+
 // GetProperties returns the current properties
 func (p *PropertiesPanel) GetProperties() map[string]string {
 	result := make(map[string]string)
@@ -204,12 +284,46 @@ func (p *PropertiesPanel) GetProperties() map[string]string {
 	return result
 }
 
-// rebuildKeys rebuilds the propertyKeys slice from the map
+// SetFilter shows only the properties whose name or value contains text,
+// ignoring case
+func (p *PropertiesPanel) SetFilter(text string) {
+	p.filter = text
+	p.rebuildKeys()
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.propertiesList.UnselectAll()
+		p.propertiesList.Refresh()
+		p.selectedIndex = -1
+		p.updateButtonStates()
+	})
+}
+
+// CountMatches returns how many properties filter would list
+func (p *PropertiesPanel) CountMatches(filter string) int {
+	count := 0
+	for k, v := range p.properties {
+		if matchesFilter(propertyFilterText(k, v), filter) {
+			count++
+		}
+	}
+	return count
+}
+
+// propertyFilterText is what the filter looks for in a property: its name
+// and value
+func propertyFilterText(key, value string) string {
+	return fmt.Sprintf("%s = %s", key, value)
+}
+
+// rebuildKeys rebuilds the propertyKeys slice from the map, leaving out
+// properties not matching the filter
 // Keys are sorted alphabetically to maintain consistent display order
 func (p *PropertiesPanel) rebuildKeys() {
 	p.propertyKeys = make([]string, 0, len(p.properties))
-	for k := range p.properties {
-		p.propertyKeys = append(p.propertyKeys, k)
+	for k, v := range p.properties {
+		if matchesFilter(propertyFilterText(k, v), p.filter) {
+			p.propertyKeys = append(p.propertyKeys, k)
+		}
 	}
 	// Sort keys alphabetically for consistent order
 	sort.Strings(p.propertyKeys)
@@ -217,7 +331,7 @@ func (p *PropertiesPanel) rebuildKeys() {
 
 // updateButtonStates enables/disables buttons based on selection
 func (p *PropertiesPanel) updateButtonStates() {
-	hasSelection := p.selectedIndex >= 0 && p.selectedIndex < len(p.propertyKeys)
+	hasSelection := p.selectedIndex >= 0 && p.selectedIndex < len(p.propertyKeys) && !p.readOnly
 	if hasSelection {
 		p.editButton.Enable()
 		p.removeButton.Enable()
@@ -232,6 +346,12 @@ func (p *PropertiesPanel) OnChange(callback func(map[string]string)) {
 	p.onChange = callback
 }
 
+// OnConfirmRemove sets the callback asked before removing a property; it
+// calls remove to go ahead. Without it, properties are removed at once.
+func (p *PropertiesPanel) OnConfirmRemove(callback func(key string, remove func())) {
+	p.onConfirmRemove = callback
+}
+
 // notifyChange triggers the onChange callback
 func (p *PropertiesPanel) notifyChange() {
 	if p.onChange != nil {
@@ -239,6 +359,47 @@ func (p *PropertiesPanel) notifyChange() {
 	}
 }
 
+// SetReadOnly disables all mutating buttons while in view-only mode
+func (p *PropertiesPanel) SetReadOnly(readOnly bool) {
+	p.readOnly = readOnly
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		if readOnly {
+			p.addButton.Disable()
+		} else {
+			p.addButton.Enable()
+		}
+		p.updateButtonStates()
+	})
+}
+
+// EditSelected opens the selected property for editing, as the Edit button
+// does
+func (p *PropertiesPanel) EditSelected() {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		if !p.editButton.Disabled() {
+			p.editButton.OnTapped()
+		}
+	})
+}
+
+// RemoveSelected removes the selected property, as the Remove button does
+func (p *PropertiesPanel) RemoveSelected() {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		if !p.removeButton.Disabled() {
+			p.removeButton.OnTapped()
+		}
+	})
+}
+
+// OnContextMenu sets the callback returning the menu shown when a property
+// is right-clicked
+func (p *PropertiesPanel) OnContextMenu(callback ContextMenuFunc) {
+	p.onContextMenu = callback
+}
+
 // GetContainer returns the main container for embedding
 func (p *PropertiesPanel) GetContainer() *fyne.Container {
 	return p.mainContainer
@@ -0,0 +1,305 @@
+package panels
+
+import (
+	"image/color"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/gui/state"
+	"github.com/user/pom-manager/internal/gui/widgets"
+)
+
+// StartPage is the welcome screen shown in place of the editor while no
+// POM is loaded. It offers creating a project, from a template or not,
+// the recent and pinned files, the scratch buffers and the recent
+// workspaces to open, a target to drop files on, and links to help.
+type StartPage struct {
+	// UI components
+	templatesBox  *fyne.Container
+	recentBox     *fyne.Container
+	pinnedBox     *fyne.Container
+	scratchBox    *fyne.Container
+	workspacesBox *fyne.Container
+	mainContainer *fyne.Container
+
+	// Callbacks
+	onNew             func()
+	onNewFromTemplate func(string)
+	onOpen            func()
+	onBrowseWorkspace func()
+	onOpenFile        func(string)
+	onOpenWorkspace   func(string)
+	onPin             func(string)
+	onUnpin           func(string)
+	onQuickHelp       func()
+	onMavenBasics     func()
+}
+
+// NewStartPage creates a new StartPage
+func NewStartPage() *StartPage {
+	page := &StartPage{}
+	page.createUI()
+	return page
+}
+
+// createUI creates the page layout: getting started on the left, files to
+// open on the right
+func (p *StartPage) createUI() {
+	p.templatesBox = container.NewGridWrap(fyne.NewSize(140, 36))
+	p.recentBox = container.NewVBox()
+	p.pinnedBox = container.NewVBox()
+	p.scratchBox = container.NewVBox()
+	p.workspacesBox = container.NewVBox()
+
+	newButton := widget.NewButtonWithIcon("New Project...", theme.DocumentCreateIcon(), func() {
+		if p.onNew != nil {
+			p.onNew()
+		}
+	})
+	newButton.Importance = widget.HighImportance
+	openButton := widget.NewButtonWithIcon("Open...", theme.FolderOpenIcon(), func() {
+		if p.onOpen != nil {
+			p.onOpen()
+		}
+	})
+	workspaceButton := widget.NewButtonWithIcon("Open Workspace...", theme.FolderIcon(), func() {
+		if p.onBrowseWorkspace != nil {
+			p.onBrowseWorkspace()
+		}
+	})
+
+	quickHelpLink := widget.NewHyperlink("Quick Help and Shortcuts (F1)", nil)
+	quickHelpLink.OnTapped = func() {
+		if p.onQuickHelp != nil {
+			p.onQuickHelp()
+		}
+	}
+	mavenBasicsLink := widget.NewHyperlink("Maven Basics", nil)
+	mavenBasicsLink.OnTapped = func() {
+		if p.onMavenBasics != nil {
+			p.onMavenBasics()
+		}
+	}
+
+	// Dropping works anywhere on the window; the frame shows it can be done
+	dropFrame := canvas.NewRectangle(color.Transparent)
+	dropFrame.StrokeColor = theme.Color(theme.ColorNameDisabled)
+	dropFrame.StrokeWidth = 2
+	dropFrame.CornerRadius = theme.Size(theme.SizeNameInputRadius)
+	dropFrame.SetMinSize(fyne.NewSize(0, 80))
+	dropLabel := widget.NewLabelWithStyle("Drop a pom.xml or its folder here to open it", fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	dropLabel.Importance = widget.LowImportance
+
+	gettingStarted := container.NewVBox(
+		sectionLabel("Start"),
+		container.NewHBox(newButton, openButton, workspaceButton),
+		sectionLabel("New from Template"),
+		p.templatesBox,
+		container.NewStack(dropFrame, container.NewCenter(dropLabel)),
+		sectionLabel("Help"),
+		quickHelpLink,
+		mavenBasicsLink,
+	)
+
+	files := container.NewVBox(
+		sectionLabel("Pinned Files"),
+		p.pinnedBox,
+		sectionLabel("Recent Files"),
+		p.recentBox,
+		sectionLabel("Scratch Buffers"),
+		p.scratchBox,
+		sectionLabel("Recent Workspaces"),
+		p.workspacesBox,
+	)
+
+	title := widget.NewLabelWithStyle("Welcome to POM Manager", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	subtitle := hintLabel("Create a Maven POM or open one to get started.")
+
+	p.mainContainer = container.NewPadded(container.NewVScroll(container.NewVBox(
+		title,
+		subtitle,
+		widget.NewSeparator(),
+		container.NewGridWithColumns(2, gettingStarted, files),
+	)))
+}
+
+// Load lists the recent files, the pinned files and the recent workspace
+// directories
+func (p *StartPage) Load(recent, pinned, workspaces []string) {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.recentBox.RemoveAll()
+		for _, path := range recent {
+			p.recentBox.Add(container.NewHBox(
+				p.fileEntry(path),
+				p.pinButton("Pin", "Keep the file on the start page", path, p.onPin),
+			))
+		}
+		if len(recent) == 0 {
+			p.recentBox.Add(hintLabel("No recent files."))
+		}
+
+		p.pinnedBox.RemoveAll()
+		for _, path := range pinned {
+			p.pinnedBox.Add(container.NewHBox(
+				p.fileEntry(path),
+				p.pinButton("Unpin", "Remove the file from the start page (the file is not changed)", path, p.onUnpin),
+			))
+		}
+		if len(pinned) == 0 {
+			p.pinnedBox.Add(hintLabel("No pinned files. Use File → Pin to Start Page to keep a POM here."))
+		}
+
+		p.workspacesBox.RemoveAll()
+		for _, dir := range workspaces {
+			p.workspacesBox.Add(startEntry(filepath.Base(dir), dir, func() {
+				if p.onOpenWorkspace != nil {
+					p.onOpenWorkspace(dir)
+				}
+			}))
+		}
+		if len(workspaces) == 0 {
+			p.workspacesBox.Add(hintLabel("No recent workspaces. Use File → Open Workspace... to open a multi-module project."))
+		}
+	})
+}
+
+// LoadTemplates offers a shortcut for creating a project from each
+// template
+func (p *StartPage) LoadTemplates(templates []pom.TemplateInfo) {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.templatesBox.RemoveAll()
+		for _, info := range templates {
+			p.templatesBox.Add(widgets.NewButtonWithTooltip(info.Name, info.Description, func() {
+				if p.onNewFromTemplate != nil {
+					p.onNewFromTemplate(info.Name)
+				}
+			}))
+		}
+	})
+}
+
+// LoadScratchBuffers lists the scratch buffers, most recently modified
+// first as state.ListScratchBuffers returns them
+func (p *StartPage) LoadScratchBuffers(buffers []state.ScratchBuffer) {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.scratchBox.RemoveAll()
+		for _, buffer := range buffers {
+			p.scratchBox.Add(startEntry(buffer.Name, buffer.Modified.Format("2006-01-02 15:04"), func() {
+				if p.onOpenFile != nil {
+					p.onOpenFile(buffer.Path)
+				}
+			}))
+		}
+		if len(buffers) == 0 {
+			p.scratchBox.Add(hintLabel("No scratch buffers. Use File → New Scratch POM to try out a POM without saving it anywhere."))
+		}
+	})
+}
+
+// fileEntry returns the entry opening the POM at path, named after its
+// directory
+func (p *StartPage) fileEntry(path string) *fyne.Container {
+	return startEntry(filepath.Base(filepath.Dir(path)), path, func() {
+		if p.onOpenFile != nil {
+			p.onOpenFile(path)
+		}
+	})
+}
+
+// pinButton returns a low importance button passing path to callback
+func (p *StartPage) pinButton(label, tooltip, path string, callback func(string)) *widgets.ButtonWithTooltip {
+	button := widgets.NewButtonWithTooltip(label, tooltip, func() {
+		if callback != nil {
+			callback(path)
+		}
+	})
+	button.Importance = widget.LowImportance
+	return button
+}
+
+// startEntry returns a link-like button named name, followed by where it
+// leads in low importance
+func startEntry(name, location string, open func()) *fyne.Container {
+	button := widget.NewButton(name, open)
+	button.Importance = widget.LowImportance
+	button.Alignment = widget.ButtonAlignLeading
+	return container.NewHBox(button, hintLabel(location))
+}
+
+// sectionLabel returns the bold heading of a start page section
+func sectionLabel(text string) *widget.Label {
+	return widget.NewLabelWithStyle(text, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+}
+
+// hintLabel returns a label of low importance
+func hintLabel(text string) *widget.Label {
+	label := widget.NewLabel(text)
+	label.Importance = widget.LowImportance
+	return label
+}
+
+// OnNew sets the callback for creating a project with the wizard
+func (p *StartPage) OnNew(callback func()) {
+	p.onNew = callback
+}
+
+// OnNewFromTemplate sets the callback for creating a project from a
+// template
+func (p *StartPage) OnNewFromTemplate(callback func(string)) {
+	p.onNewFromTemplate = callback
+}
+
+// OnOpen sets the callback for choosing a POM to open
+func (p *StartPage) OnOpen(callback func()) {
+	p.onOpen = callback
+}
+
+// OnBrowseWorkspace sets the callback for choosing a workspace folder
+func (p *StartPage) OnBrowseWorkspace(callback func()) {
+	p.onBrowseWorkspace = callback
+}
+
+// OnOpenFile sets the callback for opening a recent or pinned file or a
+// scratch buffer
+func (p *StartPage) OnOpenFile(callback func(string)) {
+	p.onOpenFile = callback
+}
+
+// OnOpenWorkspace sets the callback for opening a recent workspace
+func (p *StartPage) OnOpenWorkspace(callback func(string)) {
+	p.onOpenWorkspace = callback
+}
+
+// OnPin sets the callback for pinning a recent file
+func (p *StartPage) OnPin(callback func(string)) {
+	p.onPin = callback
+}
+
+// OnUnpin sets the callback for unpinning a file
+func (p *StartPage) OnUnpin(callback func(string)) {
+	p.onUnpin = callback
+}
+
+// OnQuickHelp sets the callback for the Quick Help link
+func (p *StartPage) OnQuickHelp(callback func()) {
+	p.onQuickHelp = callback
+}
+
+// OnMavenBasics sets the callback for the Maven Basics link
+func (p *StartPage) OnMavenBasics(callback func()) {
+	p.onMavenBasics = callback
+}
+
+// GetContainer returns the main container for embedding
+func (p *StartPage) GetContainer() *fyne.Container {
+	return p.mainContainer
+}
@@ -0,0 +1,297 @@
+package panels
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/classpath"
+	"github.com/user/pom-manager/internal/core/git"
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// statsTimeFormat is how the panel shows file and commit dates
+const statsTimeFormat = "2006-01-02 15:04"
+
+// StatsPanel shows read-only figures about the open POM for a quick
+// assessment: element counts, size, transitive depth and file history
+type StatsPanel struct {
+	// UI components
+	contentForm    *fyne.Container
+	scopeForm      *fyne.Container
+	transitiveForm *fyne.Container
+	fileForm       *fyne.Container
+	resolveButton  *widget.Button
+	mainContainer  *fyne.Container
+
+	// State
+	stats         pom.Stats
+	dependencyKey string // Inputs of the dependency tree of the shown project
+	resolvedKey   string // dependencyKey when resolution started, to spot stale results
+	transitive    *classpath.Result
+	transitiveErr error
+	resolving     bool
+	modified      time.Time
+	commit        *git.LastCommit
+	commitNote    string // Shown instead of a commit, such as "Not in a Git repository"
+
+	// Callbacks
+	onResolve func()
+}
+
+// NewStatsPanel creates a new StatsPanel
+func NewStatsPanel() *StatsPanel {
+	panel := &StatsPanel{
+		stats: pom.ComputeStats(nil, nil),
+	}
+
+	panel.createUI()
+	return panel
+}
+
+// createUI creates the panel layout
+func (p *StatsPanel) createUI() {
+	p.contentForm = container.New(layout.NewFormLayout())
+	p.scopeForm = container.New(layout.NewFormLayout())
+	p.transitiveForm = container.New(layout.NewFormLayout())
+	p.fileForm = container.New(layout.NewFormLayout())
+
+	p.resolveButton = widget.NewButton("Resolve Dependency Tree", func() {
+		if p.onResolve != nil {
+			p.onResolve()
+		}
+	})
+
+	p.mainContainer = container.NewBorder(
+		widget.NewLabel("Figures for the open POM; read-only."),
+		nil, nil, nil,
+		container.NewVScroll(container.NewVBox(
+			widget.NewLabelWithStyle("Content", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			p.contentForm,
+			widget.NewSeparator(),
+			widget.NewLabelWithStyle("Dependencies by Scope", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			p.scopeForm,
+			widget.NewSeparator(),
+			widget.NewLabelWithStyle("Transitive Dependencies", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			p.transitiveForm,
+			container.NewHBox(p.resolveButton),
+			widget.NewSeparator(),
+			widget.NewLabelWithStyle("File", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			p.fileForm,
+		)),
+	)
+	p.rebuild()
+}
+
+// LoadProject updates the figures for a project; xml is its serialized form
+func (p *StatsPanel) LoadProject(project *pom.Project, xml []byte) {
+	stats := pom.ComputeStats(project, xml)
+	key := dependencyKey(project)
+
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.stats = stats
+		p.dependencyKey = key
+		p.rebuild()
+	})
+}
+
+// SetResolving shows that the dependency tree is being resolved
+func (p *StatsPanel) SetResolving() {
+	fyne.Do(func() {
+		p.resolving = true
+		p.resolvedKey = p.dependencyKey
+		p.rebuild()
+	})
+}
+
+// SetTransitive shows the resolved dependency tree, or why it could not be
+// resolved. The result belongs to the dependencies shown when resolution
+// started; later edits mark it stale.
+func (p *StatsPanel) SetTransitive(result *classpath.Result, err error) {
+	fyne.Do(func() {
+		p.resolving = false
+		p.transitive = result
+		p.transitiveErr = err
+		p.rebuild()
+	})
+}
+
+// ClearTransitive forgets the resolved dependency tree, such as when
+// another file is opened
+func (p *StatsPanel) ClearTransitive() {
+	fyne.Do(func() {
+		p.transitive = nil
+		p.transitiveErr = nil
+		p.resolvedKey = ""
+		p.rebuild()
+	})
+}
+
+// SetFileInfo shows when the file was last modified on disk and the last
+// commit that touched it; commit is nil with note explaining why there is
+// none. A zero modified time means the file is not saved yet.
+func (p *StatsPanel) SetFileInfo(modified time.Time, commit *git.LastCommit, note string) {
+	fyne.Do(func() {
+		p.modified = modified
+		p.commit = commit
+		p.commitNote = note
+		p.rebuild()
+	})
+}
+
+// rebuild fills the forms from the current state
+func (p *StatsPanel) rebuild() {
+	s := p.stats
+	fillForm(p.contentForm, [][2]string{
+		{"Dependencies", countWithExtra(s.Dependencies, s.ProfileDependencies, "in profiles")},
+		{"Managed dependencies", fmt.Sprint(s.ManagedDependencies)},
+		{"Plugins", countWithExtra(s.Plugins, s.ProfilePlugins, "in profiles")},
+		{"Properties", fmt.Sprint(s.Properties)},
+		{"Profiles", fmt.Sprint(s.Profiles)},
+		{"Modules", fmt.Sprint(s.Modules)},
+		{"XML size", fmt.Sprintf("%s, %d lines", formatBytes(s.XMLBytes), s.XMLLines)},
+	})
+
+	var scopes [][2]string
+	for _, scope := range pom.ValidDependencyScopes {
+		if count := s.DependenciesByScope[scope]; count > 0 {
+			scopes = append(scopes, [2]string{scope, fmt.Sprint(count)})
+		}
+	}
+	// Unknown scopes are still counted, after the standard ones
+	var unknown []string
+	for scope := range s.DependenciesByScope {
+		if !slices.Contains(pom.ValidDependencyScopes, scope) {
+			unknown = append(unknown, scope)
+		}
+	}
+	sort.Strings(unknown)
+	for _, scope := range unknown {
+		scopes = append(scopes, [2]string{scope + " (unknown)", fmt.Sprint(s.DependenciesByScope[scope])})
+	}
+	if len(scopes) == 0 {
+		scopes = append(scopes, [2]string{"None", "No direct dependencies"})
+	}
+	fillForm(p.scopeForm, scopes)
+
+	fillForm(p.transitiveForm, p.transitiveRows())
+	if p.resolving {
+		p.resolveButton.Disable()
+	} else {
+		p.resolveButton.Enable()
+	}
+
+	fillForm(p.fileForm, p.fileRows())
+}
+
+// transitiveRows describes the resolved dependency tree
+func (p *StatsPanel) transitiveRows() [][2]string {
+	switch {
+	case p.resolving:
+		return [][2]string{{"Status", "Resolving from the local repository..."}}
+	case p.transitiveErr != nil:
+		return [][2]string{{"Status", "Could not resolve: " + p.transitiveErr.Error()}}
+	case p.transitive == nil:
+		return [][2]string{{"Status", "Not resolved; resolving reads dependency POMs from the local repository"}}
+	}
+
+	total := len(p.transitive.Artifacts)
+	var direct int
+	var deepest classpath.Artifact
+	for _, artifact := range p.transitive.Artifacts {
+		if artifact.Depth == 1 {
+			direct++
+		}
+		if artifact.Depth > deepest.Depth {
+			deepest = artifact
+		}
+	}
+
+	rows := [][2]string{
+		{"Artifacts", fmt.Sprintf("%d (%d direct, %d transitive)", total, direct, total-direct)},
+	}
+	if deepest.Depth > 0 {
+		rows = append(rows, [2]string{"Deepest depth", fmt.Sprintf("%d (%s)", deepest.Depth, deepest)})
+	}
+	if len(p.transitive.Warnings) > 0 {
+		rows = append(rows, [2]string{"Incomplete", fmt.Sprintf("%d POM(s) not found locally, so their dependencies are missing", len(p.transitive.Warnings))})
+	}
+	if p.resolvedKey != p.dependencyKey {
+		rows = append(rows, [2]string{"Status", "Dependencies changed since; resolve again"})
+	}
+	return rows
+}
+
+// fileRows describes the file on disk and its Git history
+func (p *StatsPanel) fileRows() [][2]string {
+	if p.modified.IsZero() {
+		return [][2]string{{"Last modified", "Not saved yet"}}
+	}
+
+	rows := [][2]string{{"Last modified", p.modified.Format(statsTimeFormat)}}
+	if p.commit != nil {
+		rows = append(rows, [2]string{"Last commit", fmt.Sprintf("%s %s\n%s, %s",
+			p.commit.Hash, p.commit.Subject, p.commit.Author, p.commit.Date.Local().Format(statsTimeFormat))})
+	} else if p.commitNote != "" {
+		rows = append(rows, [2]string{"Last commit", p.commitNote})
+	}
+	return rows
+}
+
+// OnResolve sets the callback for resolving the dependency tree
+func (p *StatsPanel) OnResolve(callback func()) {
+	p.onResolve = callback
+}
+
+// GetContainer returns the main container for embedding
+func (p *StatsPanel) GetContainer() *fyne.Container {
+	return p.mainContainer
+}
+
+// fillForm replaces the rows of a form layout container
+func fillForm(form *fyne.Container, rows [][2]string) {
+	objects := make([]fyne.CanvasObject, 0, len(rows)*2)
+	for _, row := range rows {
+		value := widget.NewLabel(row[1])
+		value.Wrapping = fyne.TextWrapWord
+		objects = append(objects, widget.NewLabelWithStyle(row[0], fyne.TextAlignTrailing, fyne.TextStyle{Bold: true}), value)
+	}
+	form.Objects = objects
+	form.Refresh()
+}
+
+// countWithExtra formats a count, adding a second count when there is one
+func countWithExtra(count, extra int, what string) string {
+	if extra == 0 {
+		return fmt.Sprint(count)
+	}
+	return fmt.Sprintf("%d (+%d %s)", count, extra, what)
+}
+
+// formatBytes formats a size in bytes or KiB
+func formatBytes(size int) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d bytes", size)
+	}
+	return fmt.Sprintf("%.1f KiB", float64(size)/1024)
+}
+
+// dependencyKey summarizes what the resolved dependency tree depends on:
+// dependencies, managed versions, properties and the parent
+func dependencyKey(project *pom.Project) string {
+	if project == nil {
+		return ""
+	}
+	var parent pom.Parent
+	if project.Parent != nil {
+		parent = *project.Parent
+	}
+	return fmt.Sprintf("%v|%v|%v|%v", project.Dependencies, project.DependencyManagement, project.Properties, parent)
+}
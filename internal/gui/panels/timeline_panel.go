@@ -0,0 +1,175 @@
+package panels
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// TimelinePanel shows what a Maven command runs: the phases of its
+// lifecycle top to bottom, with a swimlane per plugin holding the goals the
+// plugin runs in each phase, numbered in run order
+type TimelinePanel struct {
+	// UI components
+	phaseSelect   *widget.Select
+	summaryLabel  *widget.Label
+	noteLabel     *widget.Label
+	gridScroll    *container.Scroll
+	mainContainer *fyne.Container
+
+	// State
+	project *pom.Project
+}
+
+// timelineLane is the goals one plugin runs, by phase
+type timelineLane struct {
+	plugin pom.Plugin
+	steps  map[string][]timelineStep
+}
+
+// timelineStep is a goal with its position in the run
+type timelineStep struct {
+	number int
+	step   pom.LifecycleStep
+}
+
+// NewTimelinePanel creates a new TimelinePanel
+func NewTimelinePanel() *TimelinePanel {
+	panel := &TimelinePanel{}
+	panel.createUI()
+	return panel
+}
+
+// createUI creates the panel layout
+func (p *TimelinePanel) createUI() {
+	p.phaseSelect = widget.NewSelect(pom.MavenLifecyclePhases, func(string) {
+		p.refresh()
+	})
+	p.summaryLabel = widget.NewLabel("")
+	p.noteLabel = widget.NewLabel("")
+	p.noteLabel.Wrapping = fyne.TextWrapWord
+	p.noteLabel.Importance = widget.WarningImportance
+	p.gridScroll = container.NewScroll(widget.NewLabel(""))
+
+	legend := widget.NewLabel("Goals in gray are bound by the packaging; the others are declared in the POM. " +
+		"Profiles and plugins inherited from a parent are not shown.")
+	legend.Wrapping = fyne.TextWrapWord
+	legend.Importance = widget.LowImportance
+
+	p.mainContainer = container.NewBorder(
+		container.NewVBox(
+			container.NewHBox(widget.NewLabel("What runs during mvn"), p.phaseSelect, p.summaryLabel),
+			legend,
+			p.noteLabel,
+			widget.NewSeparator(),
+		),
+		nil, nil, nil,
+		p.gridScroll,
+	)
+
+	// Selecting refreshes, which needs the components above
+	p.phaseSelect.SetSelected(pom.PhasePackage)
+}
+
+// LoadProject updates the panel with project data
+func (p *TimelinePanel) LoadProject(project *pom.Project) {
+	p.project = project
+	p.refresh()
+}
+
+// refresh rebuilds the timeline for the selected phase
+func (p *TimelinePanel) refresh() {
+	phase := p.phaseSelect.Selected
+	phases, err := pom.PhasesUpTo(phase)
+	if err != nil || p.project == nil {
+		// UI updates must be called on UI thread
+		fyne.Do(func() {
+			p.summaryLabel.SetText("")
+			p.noteLabel.Hide()
+			p.gridScroll.Content = widget.NewLabel("Open a POM to see what runs during a build.")
+			p.gridScroll.Refresh()
+		})
+		return
+	}
+
+	steps, err := pom.Timeline(p.project, phase)
+	if err != nil {
+		return
+	}
+
+	// One lane per plugin, in the order the plugins first run
+	var lanes []*timelineLane
+	laneOf := make(map[string]*timelineLane)
+	for i, step := range steps {
+		key := step.Plugin.GroupID + ":" + step.Plugin.ArtifactID
+		lane, ok := laneOf[key]
+		if !ok {
+			lane = &timelineLane{plugin: step.Plugin, steps: make(map[string][]timelineStep)}
+			laneOf[key] = lane
+			lanes = append(lanes, lane)
+		}
+		lane.steps[step.Phase] = append(lane.steps[step.Phase], timelineStep{number: i + 1, step: step})
+	}
+
+	grid := container.NewGridWithColumns(len(lanes) + 1)
+	grid.Add(widget.NewLabelWithStyle("Phase", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	for _, lane := range lanes {
+		grid.Add(widget.NewLabelWithStyle(lane.plugin.ArtifactID, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	}
+	for _, ph := range phases {
+		grid.Add(widget.NewLabel(ph))
+		for _, lane := range lanes {
+			cell := container.NewVBox()
+			for _, s := range lane.steps[ph] {
+				cell.Add(newTimelineStepLabel(s))
+			}
+			grid.Add(cell)
+		}
+	}
+
+	summary := fmt.Sprintf("runs %d goal(s) of %d plugin(s)", len(steps), len(lanes))
+	packaging := p.project.Packaging
+	if packaging == "" {
+		packaging = pom.DefaultPackaging
+	}
+	note := ""
+	if !pom.HasLifecycleMapping(packaging) {
+		note = fmt.Sprintf("The %s packaging is defined by a build extension, so the goals it binds are not shown.", packaging)
+	}
+
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.summaryLabel.SetText(summary)
+		p.noteLabel.SetText(note)
+		if note == "" {
+			p.noteLabel.Hide()
+		} else {
+			p.noteLabel.Show()
+		}
+		p.gridScroll.Content = grid
+		p.gridScroll.Refresh()
+	})
+}
+
+// newTimelineStepLabel labels a goal with its position in the run, and its
+// execution ID unless it is the default one; implied goals are grayed out
+func newTimelineStepLabel(s timelineStep) *widget.Label {
+	text := fmt.Sprintf("%d. %s", s.number, s.step.Goal)
+	if s.step.ExecutionID != "default-"+s.step.Goal {
+		text += " (" + s.step.ExecutionID + ")"
+	}
+	label := widget.NewLabel(text)
+	if s.step.Implied {
+		label.Importance = widget.LowImportance
+	}
+	return label
+}
+
+// GetContainer returns the main container for embedding
+func (p *TimelinePanel) GetContainer() *fyne.Container {
+	return p.mainContainer
+}
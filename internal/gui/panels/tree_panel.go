@@ -3,6 +3,7 @@ package panels
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
 
 	"fyne.io/fyne/v2"
@@ -23,9 +24,14 @@ type TreePanel struct {
 	treeData      map[string][]string // Parent UID -> Child UIDs
 	labelCache    map[string]string   // UID -> Display Label
 	labelCacheMux sync.RWMutex        // Protects labelCache from concurrent access
+	gitBadge      string              // Git status badge of the POM file, "" when clean
+	moduleBadges  map[string]string   // Module name -> Git status badge of its POM
+	highlight     string              // Filter whose matching nodes stand out
 
 	// Callbacks
-	onNodeSelected func(nodeType string, id string)
+	onNodeSelected  func(nodeType string, id string)
+	onNodeActivated func(nodeType string, id string)
+	onContextMenu   ContextMenuFunc
 }
 
 // NewTreePanel creates a new TreePanel
@@ -50,10 +56,19 @@ func (p *TreePanel) createUI() {
 			return ok && len(children) > 0
 		},
 		func(branch bool) fyne.CanvasObject {
-			return widget.NewLabel("Template")
+			if branch {
+				return widget.NewLabel("Template")
+			}
+			return newTreeLeaf(p)
 		},
 		func(uid string, branch bool, obj fyne.CanvasObject) {
-			label := obj.(*widget.Label)
+			var label *widget.Label
+			if leaf, ok := obj.(*treeLeaf); ok {
+				leaf.uid = uid
+				label = &leaf.Label
+			} else {
+				label = obj.(*widget.Label)
+			}
 			// Use cached label instead of computing dynamically
 			// Thread-safe read with RLock
 			p.labelCacheMux.RLock()
@@ -65,6 +80,16 @@ func (p *TreePanel) createUI() {
 			} else {
 				label.SetText(uid) // Fallback
 			}
+
+			// Leaves matching the filter stand out
+			if !branch && p.highlight != "" && matchesFilter(label.Text, p.highlight) {
+				label.Importance = widget.HighImportance
+				label.TextStyle = fyne.TextStyle{Bold: true}
+			} else {
+				label.Importance = widget.MediumImportance
+				label.TextStyle = fyne.TextStyle{}
+			}
+			label.Refresh()
 		},
 	)
 
@@ -105,10 +130,13 @@ func (p *TreePanel) LoadProject(project *pom.Project) {
 	// Root node
 	root := fmt.Sprintf("%s:%s:%s", project.GroupID, project.ArtifactID, project.Version)
 	p.treeData[""] = []string{root}
-	p.labelCache[root] = root
+	p.labelCache[root] = withBadge(root, p.gitBadge)
 
 	// Add main sections
 	sections := []string{"coordinates", "properties", "dependencies", "plugins", "profiles"}
+	if len(project.Modules) > 0 {
+		sections = append(sections, "modules")
+	}
 	p.treeData[root] = sections
 
 	// Cache section labels
@@ -121,6 +149,7 @@ func (p *TreePanel) LoadProject(project *pom.Project) {
 	}
 	p.labelCache["plugins"] = fmt.Sprintf("🔧 Plugins (%d)", pluginCount)
 	p.labelCache["profiles"] = fmt.Sprintf("👤 Profiles (%d)", len(project.Profiles))
+	p.labelCache["modules"] = fmt.Sprintf("🗂️ Modules (%d)", len(project.Modules))
 
 	// Add dependencies
 	if len(project.Dependencies) > 0 {
@@ -184,6 +213,17 @@ func (p *TreePanel) LoadProject(project *pom.Project) {
 		p.treeData["profiles"] = profileChildren
 	}
 
+	// Add modules with the Git status of their POMs
+	if len(project.Modules) > 0 {
+		moduleChildren := make([]string, 0, len(project.Modules))
+		for _, module := range project.Modules {
+			uid := fmt.Sprintf("module:%s", module)
+			moduleChildren = append(moduleChildren, uid)
+			p.labelCache[uid] = withBadge(module, p.moduleBadges[module])
+		}
+		p.treeData["modules"] = moduleChildren
+	}
+
 	// Unlock the label cache before refreshing
 	// This ensures all cache writes are complete before any reads
 	p.labelCacheMux.Unlock()
@@ -194,6 +234,83 @@ func (p *TreePanel) LoadProject(project *pom.Project) {
 	})
 }
 
+// rootNode stands for the project node, whose UID holds the coordinates, in
+// ExpandedNodes
+const rootNode = "project"
+
+// ExpandedNodes returns the UIDs of the open branches, sorted
+func (p *TreePanel) ExpandedNodes() []string {
+	var expanded []string
+	for uid, children := range p.treeData {
+		if uid == "" || len(children) == 0 || !p.tree.IsBranchOpen(uid) {
+			continue
+		}
+		if roots := p.treeData[""]; len(roots) > 0 && uid == roots[0] {
+			uid = rootNode
+		}
+		expanded = append(expanded, uid)
+	}
+	sort.Strings(expanded)
+	return expanded
+}
+
+// SetExpandedNodes opens exactly the given branches, as returned by
+// ExpandedNodes
+func (p *TreePanel) SetExpandedNodes(uids []string) {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.tree.CloseAllBranches()
+		for _, uid := range uids {
+			if roots := p.treeData[""]; uid == rootNode && len(roots) > 0 {
+				uid = roots[0]
+			}
+			p.tree.OpenBranch(uid)
+		}
+	})
+}
+
+// SetHighlight makes the properties, dependencies, plugins, profiles and
+// modules whose labels contain filter stand out, opening their sections;
+// an empty filter highlights nothing
+func (p *TreePanel) SetHighlight(filter string) {
+	p.highlight = strings.TrimSpace(filter)
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		if p.highlight != "" {
+			p.labelCacheMux.RLock()
+			for _, section := range []string{"properties", "dependencies", "plugins", "profiles", "modules"} {
+				for _, uid := range p.treeData[section] {
+					if matchesFilter(p.labelCache[uid], p.highlight) {
+						if roots := p.treeData[""]; len(roots) > 0 {
+							p.tree.OpenBranch(roots[0])
+						}
+						p.tree.OpenBranch(section)
+						break
+					}
+				}
+			}
+			p.labelCacheMux.RUnlock()
+		}
+		p.tree.Refresh()
+	})
+}
+
+// SetGitStatus shows Git status badges, such as "M" for modified, next to
+// the project and its modules; empty badges mean clean or not in a repository
+func (p *TreePanel) SetGitStatus(pomBadge string, moduleBadges map[string]string) {
+	p.gitBadge = pomBadge
+	p.moduleBadges = moduleBadges
+	p.LoadProject(p.project)
+}
+
+// withBadge appends a Git status badge to a label
+func withBadge(label, badge string) string {
+	if badge == "" {
+		return label
+	}
+	return fmt.Sprintf("%s  [%s]", label, badge)
+}
+
 // getNodeLabel returns the display label for a node
 func (p *TreePanel) getNodeLabel(uid string) string {
 	if p.project == nil {
@@ -215,6 +332,8 @@ func (p *TreePanel) getNodeLabel(uid string) string {
 		return fmt.Sprintf("🔧 Plugins (%d)", pluginCount)
 	case "profiles":
 		return fmt.Sprintf("👤 Profiles (%d)", len(p.project.Profiles))
+	case "modules":
+		return fmt.Sprintf("🗂️ Modules (%d)", len(p.project.Modules))
 	}
 
 	// Parse specific nodes
@@ -253,6 +372,9 @@ func (p *TreePanel) getNodeLabel(uid string) string {
 			}
 			return fmt.Sprintf("%s%s", profile.ID, activationStatus)
 		}
+
+	case "module":
+		return withBadge(id, p.moduleBadges[id])
 	}
 
 	return uid
@@ -260,7 +382,7 @@ func (p *TreePanel) getNodeLabel(uid string) string {
 
 // parseUID extracts node type and ID from UID
 func (p *TreePanel) parseUID(uid string) (nodeType string, id string) {
-	if uid == "coordinates" || uid == "properties" || uid == "dependencies" || uid == "plugins" || uid == "profiles" {
+	if uid == "coordinates" || uid == "properties" || uid == "dependencies" || uid == "plugins" || uid == "profiles" || uid == "modules" {
 		return uid, ""
 	}
 
@@ -279,6 +401,56 @@ func (p *TreePanel) OnNodeSelected(callback func(nodeType string, id string)) {
 	p.onNodeSelected = callback
 }
 
+// OnNodeActivated sets the callback for double-clicking a leaf node, such
+// as a dependency or property
+func (p *TreePanel) OnNodeActivated(callback func(nodeType string, id string)) {
+	p.onNodeActivated = callback
+}
+
+// OnContextMenu sets the callback returning the menu shown when a leaf node
+// is right-clicked
+func (p *TreePanel) OnContextMenu(callback ContextMenuFunc) {
+	p.onContextMenu = callback
+}
+
+// treeLeaf is the label of a leaf node, which can be double-clicked or
+// right-clicked
+type treeLeaf struct {
+	widget.Label
+	panel *TreePanel
+	uid   string
+}
+
+func newTreeLeaf(panel *TreePanel) *treeLeaf {
+	leaf := &treeLeaf{panel: panel}
+	leaf.Text = "Template"
+	leaf.ExtendBaseWidget(leaf)
+	return leaf
+}
+
+// Tapped implements fyne.Tappable, selecting the node as the tree would
+func (l *treeLeaf) Tapped(*fyne.PointEvent) {
+	l.panel.tree.Select(l.uid)
+}
+
+// DoubleTapped implements fyne.DoubleTappable, selecting the node and
+// reporting it as activated
+func (l *treeLeaf) DoubleTapped(*fyne.PointEvent) {
+	l.panel.tree.Select(l.uid)
+	if l.panel.onNodeActivated != nil {
+		nodeType, id := l.panel.parseUID(l.uid)
+		l.panel.onNodeActivated(nodeType, id)
+	}
+}
+
+// TappedSecondary implements fyne.SecondaryTappable, selecting the node and
+// showing its context menu
+func (l *treeLeaf) TappedSecondary(event *fyne.PointEvent) {
+	l.panel.tree.Select(l.uid)
+	nodeType, id := l.panel.parseUID(l.uid)
+	showContextMenu(l, l.panel.onContextMenu, nodeType, id, event)
+}
+
 // GetContainer returns the main container for embedding
 func (p *TreePanel) GetContainer() *fyne.Container {
 	return p.mainContainer
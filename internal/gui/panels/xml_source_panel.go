@@ -0,0 +1,184 @@
+package panels
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/gui/widgets"
+)
+
+// XMLSourcePanel lets users edit the raw POM XML. Edits are parsed back into
+// the model when the editor loses focus or Apply is clicked.
+type XMLSourcePanel struct {
+	// UI components
+	sourceEntry   *widgets.SourceEntry
+	applyButton   *widgets.ButtonWithTooltip
+	revertButton  *widgets.ButtonWithTooltip
+	errorLabel    *widget.Label
+	mainContainer *fyne.Container
+
+	// State
+	document string // Path of the document shown, to drop edits when it changes
+	source   string // XML generated from the model
+	modified bool   // Text differs from the model and has not been applied
+	loading  bool   // Flag to prevent change tracking during programmatic updates
+	readOnly bool
+
+	// Callbacks
+	onApply func(xml string) error
+}
+
+// NewXMLSourcePanel creates a new XMLSourcePanel
+func NewXMLSourcePanel() *XMLSourcePanel {
+	panel := &XMLSourcePanel{}
+	panel.createUI()
+	return panel
+}
+
+// createUI creates the panel layout
+func (p *XMLSourcePanel) createUI() {
+	p.sourceEntry = widgets.NewSourceEntry()
+	p.sourceEntry.OnChanged = func(text string) {
+		if p.loading {
+			return
+		}
+		p.modified = text != p.source
+		p.updateButtonStates()
+	}
+	p.sourceEntry.OnFocusLost = func() {
+		if p.modified {
+			p.apply()
+		}
+	}
+
+	p.errorLabel = widget.NewLabel("")
+	p.errorLabel.Importance = widget.DangerImportance
+	p.errorLabel.Wrapping = fyne.TextWrapWord
+	p.errorLabel.Hide()
+
+	p.applyButton = widgets.NewButtonWithTooltip("Apply",
+		"Parse the edited XML and update all panels (also applied when the editor loses focus)",
+		p.apply)
+	p.applyButton.Disable()
+
+	p.revertButton = widgets.NewButtonWithTooltip("Revert",
+		"Discard XML edits that have not been applied",
+		func() {
+			p.setText(p.source)
+			p.modified = false
+			p.errorLabel.Hide()
+			p.updateButtonStates()
+		})
+	p.revertButton.Disable()
+
+	p.mainContainer = container.NewBorder(
+		container.NewVBox(
+			widget.NewLabel("XML Source"),
+			widget.NewSeparator(),
+		),
+		container.NewVBox(
+			p.errorLabel,
+			container.NewHBox(p.applyButton, p.revertButton),
+		),
+		nil, nil,
+		p.sourceEntry,
+	)
+}
+
+// SetXML shows the XML generated from the model of the given document.
+// Unapplied edits are kept so a background refresh does not overwrite what
+// the user is typing, unless a different document was loaded.
+func (p *XMLSourcePanel) SetXML(document, xml string) {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.source = xml
+		if p.modified && document == p.document {
+			return
+		}
+		p.document = document
+		p.modified = false
+		p.errorLabel.Hide()
+		p.setText(xml)
+		p.updateButtonStates()
+	})
+}
+
+// setText replaces the editor content without marking it modified
+func (p *XMLSourcePanel) setText(text string) {
+	p.loading = true
+	p.sourceEntry.SetText(text)
+	p.loading = false
+}
+
+// apply parses the edited XML via the apply callback and reports errors inline
+func (p *XMLSourcePanel) apply() {
+	if p.onApply == nil || p.readOnly {
+		return
+	}
+
+	if err := p.onApply(p.sourceEntry.Text); err != nil {
+		if line := pom.ErrorLine(err); line > 0 {
+			p.errorLabel.SetText(fmt.Sprintf("Line %d: %v", line, err))
+			p.sourceEntry.CursorRow = line - 1
+			p.sourceEntry.CursorColumn = 0
+			p.sourceEntry.Refresh()
+		} else {
+			p.errorLabel.SetText(err.Error())
+		}
+		p.errorLabel.Show()
+		return
+	}
+
+	p.modified = false
+	p.errorLabel.Hide()
+	p.updateButtonStates()
+}
+
+// ShowPosition moves the cursor to a line and column of the XML
+func (p *XMLSourcePanel) ShowPosition(position pom.Position) {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		p.sourceEntry.CursorRow = position.Line - 1
+		p.sourceEntry.CursorColumn = max(position.Column-1, 0)
+		p.sourceEntry.Refresh()
+	})
+}
+
+// updateButtonStates enables Apply/Revert while there are unapplied edits
+func (p *XMLSourcePanel) updateButtonStates() {
+	if p.modified && !p.readOnly {
+		p.applyButton.Enable()
+		p.revertButton.Enable()
+	} else {
+		p.applyButton.Disable()
+		p.revertButton.Disable()
+	}
+}
+
+// OnApply sets the callback that parses the edited XML into the model
+func (p *XMLSourcePanel) OnApply(callback func(xml string) error) {
+	p.onApply = callback
+}
+
+// SetReadOnly disables editing while in view-only mode
+func (p *XMLSourcePanel) SetReadOnly(readOnly bool) {
+	p.readOnly = readOnly
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		if readOnly {
+			p.sourceEntry.Disable()
+		} else {
+			p.sourceEntry.Enable()
+		}
+		p.updateButtonStates()
+	})
+}
+
+// GetContainer returns the main container for embedding
+func (p *XMLSourcePanel) GetContainer() *fyne.Container {
+	return p.mainContainer
+}
@@ -1,12 +1,22 @@
 package presenters
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
+	"slices"
 
+	"github.com/user/pom-manager/internal/core/audit"
+	"github.com/user/pom-manager/internal/core/diff"
 	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/gui/history"
 	"github.com/user/pom-manager/internal/gui/state"
 )
 
+// ErrReadOnly is returned by mutating operations while the current document
+// is opened in view-only mode
+var ErrReadOnly = errors.New("document is read-only")
+
 // MainPresenter orchestrates the main window logic and coordinates
 // between UI components and the core POM engine
 type MainPresenter interface {
@@ -14,16 +24,74 @@ type MainPresenter interface {
 	LoadPOM(path string) error
 	SavePOM(path string) error
 	CreateNewPOM(coords pom.Coordinates, template string) error
+	CreateNewPOMWithValues(coords pom.Coordinates, template string, values map[string]string) error
+	CreateJavaCardPOM(coords pom.Coordinates, applet pom.JavaCardApplet) error
+	CreateScratchPOM(template string) (string, error)
+
+	// Templates
+	Templates() []pom.TemplateInfo
+	TemplateManager() pom.TemplateManager
+	SetTemplateManager(templateManager pom.TemplateManager)
 
 	// POM operations
 	ValidateCurrent() (pom.ValidationResult, error)
+	AddExternalProperty(key string) (string, error)
+	ApplyFix(finding pom.ValidationError) error
+	ApplyAllFixes() ([]string, error)
 	UpdateCoordinates(coords pom.Coordinates) error
 	AddDependency(dep pom.Dependency) error
+	AddDependencyAt(dep pom.Dependency, position string, after *pom.Dependency) error
+	UpdateDependency(old, updated pom.Dependency) error
 	RemoveDependency(groupID, artifactID string) error
+	RemoveDependencies(deps []pom.Dependency) error
+	SetDependenciesScope(deps []pom.Dependency, scope string) error
+	MoveDependency(from, to int) error
+	SortDependencies() error
+	AddBOM(bom pom.Coordinates) error
 	AddPlugin(plugin pom.Plugin) error
 	RemovePlugin(groupID, artifactID string) error
+	AddExecution(pluginIndex int, execution pom.PluginExecution) error
+	UpdateExecution(pluginIndex, executionIndex, targetPluginIndex int, execution pom.PluginExecution) error
+	RemoveExecution(pluginIndex, executionIndex int) error
+	MoveExecution(phase string, from, to int) error
+	SetSkipFlags(profileID string, flags map[string]bool) error
 	UpdateProperties(props map[string]string) error
-	UpdateProject(project *pom.Project) error
+	UpdateProject(operation string, project *pom.Project) error
+	ApplyXML(xml string) error
+
+	// Undo/redo
+	Undo() error
+	Redo() error
+	UndoName() string
+	RedoName() string
+
+	// Inheritance
+	GetInheritance() *pom.Inheritance
+
+	// Auto-save and crash recovery
+	AutoSave() error
+	RestoreRecovery(recovery *state.Recovery) error
+
+	// Session changelog
+	SessionChangelog() string
+	MarkCommitted()
+
+	// Changes other programs save to the open file
+	CheckDiskChanges() (*DiskChange, error)
+	KeepLocalChanges(change *DiskChange)
+
+	// Documents open in tabs; the other operations apply to the active one
+	NewDocument()
+	SwitchDocument(index int) error
+	CloseDocument(index int) error
+	SaveScratchBuffers() error
+
+	// Read-only mode
+	IsReadOnly() bool
+	SetForceReadOnly(readOnly bool)
+
+	// Audit log of files written besides saves
+	SetRecorder(recorder *audit.Recorder)
 
 	// State access
 	GetCurrentProject() *pom.Project
@@ -38,6 +106,55 @@ type mainPresenter struct {
 	repository      pom.Repository
 	templateManager pom.TemplateManager
 	appState        *state.AppState
+	parentResolver  pom.ParentResolver
+	history         *history.History
+	forceReadOnly   bool // Open every document view-only (--read-only)
+
+	// Records the files written besides saves, such as the project config,
+	// in the audit log; nil when there is none
+	recorder *audit.Recorder
+
+	// Project as last opened or committed, the base of the session changelog;
+	// nil for projects that have never been on disk
+	committed *pom.Project
+
+	// Project as last read from or written to its file, the base for telling
+	// apart the changes other programs save to it; nil for projects that have
+	// never been on disk
+	saved *pom.Project
+
+	// Edit histories of the documents not active, which take turns in
+	// history, committed and saved
+	sessions map[*state.Document]documentSession
+
+	// Cached parent chain, keyed by file path and <parent> reference
+	parentKey string
+	parents   []pom.ResolvedParent
+	parentErr error
+
+	// Cached validation settings of the project config, keyed by file path
+	rulesKey    string
+	rules       pom.RuleSettings
+	external    []string // Properties supplied outside the POM
+	rulesConfig string
+	rulesErr    error
+}
+
+// documentSession is the edit history of a document while it is not active
+type documentSession struct {
+	history   *history.History
+	committed *pom.Project
+	saved     *pom.Project
+}
+
+// DiskChange is a change another program, such as an IDE or git pull, saved
+// to the file of the active document
+type DiskChange struct {
+	Path    string
+	Project *pom.Project // The file as it is now
+	// Unsaved edits (Ours) and the changes on disk (Theirs) since the file
+	// was last read or saved
+	Merge diff.Merge
 }
 
 // NewMainPresenter creates a new MainPresenter with injected dependencies
@@ -56,6 +173,9 @@ func NewMainPresenter(
 		repository:      repository,
 		templateManager: templateManager,
 		appState:        appState,
+		parentResolver:  pom.NewParentResolver(parser),
+		history:         history.New(history.DefaultLimit),
+		sessions:        make(map[*state.Document]documentSession),
 	}
 }
 
@@ -67,7 +187,19 @@ func (p *mainPresenter) LoadPOM(path string) error {
 		return fmt.Errorf("failed to load POM: %w", err)
 	}
 
+	// Parents and the project config may have changed on disk since they
+	// were last read
+	p.parentKey = ""
+	p.rulesKey = ""
+
+	// Files we cannot write to are opened view-only instead of failing on save
+	readOnly := p.forceReadOnly || !p.repository.IsWritable(path)
+
 	// Update app state
+	p.history.Reset(project)
+	p.committed = project.Clone()
+	p.saved = project.Clone()
+	p.appState.SetReadOnly(readOnly)
 	p.appState.SetCurrentProject(project)
 	p.appState.SetFilePath(path)
 	p.appState.SetDirty(false)
@@ -82,8 +214,14 @@ func (p *mainPresenter) SavePOM(path string) error {
 		return fmt.Errorf("no project loaded")
 	}
 
-	// Generate XML
-	xmlData, err := p.generator.Generate(project)
+	// Read-only documents may only be saved as a copy elsewhere
+	if p.appState.IsReadOnly() && path == p.appState.GetFilePath() {
+		return ErrReadOnly
+	}
+
+	// Generate XML, with a fresh banner when they are enabled
+	stamped := pom.StampBanner(project, path)
+	xmlData, err := p.generator.Generate(stamped)
 	if err != nil {
 		return fmt.Errorf("failed to generate POM XML: %w", err)
 	}
@@ -92,8 +230,12 @@ func (p *mainPresenter) SavePOM(path string) error {
 	if err := p.repository.Write(path, xmlData); err != nil {
 		return fmt.Errorf("failed to save POM: %w", err)
 	}
+	// The source view shows the banner as saved
+	project.Banner = stamped.Banner
+	p.saved = project.Clone()
 
 	// Update app state
+	p.appState.SetReadOnly(p.forceReadOnly)
 	p.appState.SetFilePath(path)
 	p.appState.SetDirty(false)
 
@@ -102,13 +244,46 @@ func (p *mainPresenter) SavePOM(path string) error {
 
 // CreateNewPOM creates a new POM from a template with the given coordinates
 func (p *mainPresenter) CreateNewPOM(coords pom.Coordinates, template string) error {
+	return p.CreateNewPOMWithValues(coords, template, nil)
+}
+
+// CreateNewPOMWithValues creates a new POM from a template with the given
+// coordinates and template variables; variables left out get their default
+func (p *mainPresenter) CreateNewPOMWithValues(coords pom.Coordinates, template string, values map[string]string) error {
 	// Create project from template
-	project, err := p.templateManager.Create(template, coords)
+	project, err := p.templateManager.CreateWithValues(template, coords, values)
+	if err != nil {
+		return fmt.Errorf("failed to create POM from template: %w", err)
+	}
+
+	// Update app state
+	p.history.Reset(project)
+	p.committed = nil
+	p.saved = nil
+	p.appState.SetReadOnly(p.forceReadOnly)
+	p.appState.SetCurrentProject(project)
+	p.appState.SetFilePath("") // New file, not saved yet
+	p.appState.SetDirty(true)
+
+	return nil
+}
+
+// CreateJavaCardPOM creates a new POM from the javacard template with the
+// given applet class and AIDs
+func (p *mainPresenter) CreateJavaCardPOM(coords pom.Coordinates, applet pom.JavaCardApplet) error {
+	project, err := p.templateManager.Create("javacard", coords)
 	if err != nil {
 		return fmt.Errorf("failed to create POM from template: %w", err)
 	}
+	if err := pom.ConfigureJavaCard(project, applet); err != nil {
+		return err
+	}
 
 	// Update app state
+	p.history.Reset(project)
+	p.committed = nil
+	p.saved = nil
+	p.appState.SetReadOnly(p.forceReadOnly)
 	p.appState.SetCurrentProject(project)
 	p.appState.SetFilePath("") // New file, not saved yet
 	p.appState.SetDirty(true)
@@ -116,6 +291,37 @@ func (p *mainPresenter) CreateNewPOM(coords pom.Coordinates, template string) er
 	return nil
 }
 
+// CreateScratchPOM creates an untitled POM from a template and persists it
+// in the scratch area so it survives restarts. Returns the scratch file path.
+func (p *mainPresenter) CreateScratchPOM(template string) (string, error) {
+	coords := pom.Coordinates{
+		GroupID:    "com.example",
+		ArtifactID: "scratch",
+		Version:    "1.0.0-SNAPSHOT",
+	}
+
+	project, err := p.templateManager.Create(template, coords)
+	if err != nil {
+		return "", fmt.Errorf("failed to create POM from template: %w", err)
+	}
+
+	path, err := state.NewScratchPath()
+	if err != nil {
+		return "", fmt.Errorf("failed to allocate scratch buffer: %w", err)
+	}
+
+	p.history.Reset(project)
+	p.committed = nil
+	p.saved = nil
+	p.appState.SetReadOnly(p.forceReadOnly)
+	p.appState.SetCurrentProject(project)
+	if err := p.SavePOM(path); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
 // ValidateCurrent validates the current project
 func (p *mainPresenter) ValidateCurrent() (pom.ValidationResult, error) {
 	project := p.appState.GetCurrentProject()
@@ -123,10 +329,149 @@ func (p *mainPresenter) ValidateCurrent() (pom.ValidationResult, error) {
 		return pom.ValidationResult{}, fmt.Errorf("no project loaded")
 	}
 
-	result := p.validator.Validate(project)
+	result := p.validate(project, p.GetInheritance(), p.ruleSettings())
+
+	// Positions refer to the generated XML shown in the source view, which
+	// differs from the file once the model is edited
+	if xmlData, err := p.generator.Generate(project); err == nil {
+		pom.BuildSourceMap(xmlData).Locate(&result)
+	}
+	if p.rulesErr != nil {
+		result.Errors.General = append(result.Errors.General, pom.ValidationError{
+			Field:    "config",
+			Value:    p.rulesConfig,
+			Message:  fmt.Sprintf("validation rules not applied: %v", p.rulesErr),
+			Severity: pom.SeverityWarning,
+		})
+	}
 	return result, nil
 }
 
+// validate checks a project against the validator, the files next to the
+// current POM, the properties it refers to, and the rule settings. Versions
+// and properties may come from the parent chain in inheritance.
+func (p *mainPresenter) validate(project *pom.Project, inheritance *pom.Inheritance, rules pom.RuleSettings) pom.ValidationResult {
+	result := p.validator.ValidateWithInheritance(project, inheritance)
+	if path := p.appState.GetFilePath(); path != "" {
+		result.Add(pom.CheckPaths(project, filepath.Dir(path))...)
+	}
+	result.Add(pom.CheckPropertyReferences(project, inheritance, p.external)...)
+	return rules.Apply(result)
+}
+
+// AddExternalProperty records in the project's .pom-manager.yaml that a
+// property is supplied outside the POM, so references to it are no longer
+// reported, and revalidates. Returns the config's path.
+func (p *mainPresenter) AddExternalProperty(key string) (string, error) {
+	project := p.appState.GetCurrentProject()
+	if project == nil {
+		return "", fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return "", ErrReadOnly
+	}
+
+	path := p.appState.GetFilePath()
+	if path == "" {
+		return "", fmt.Errorf("save the POM first: the project config is kept next to it")
+	}
+	configPath := pom.ProjectConfigPath(path)
+	err := p.track(configPath, "external property "+key, func() error {
+		_, err := pom.AddExternalProperty(path, key)
+		return err
+	})
+	if err != nil {
+		return configPath, err
+	}
+
+	// Read the config again and revalidate
+	p.rulesKey = ""
+	p.appState.SetCurrentProject(project)
+	return configPath, nil
+}
+
+// ApplyFix applies the quick-fix of a validation finding as one undoable
+// edit; fixes that create files only trigger revalidation
+func (p *mainPresenter) ApplyFix(finding pom.ValidationError) error {
+	project := p.appState.GetCurrentProject()
+	if project == nil {
+		return fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	if finding.Fix == nil {
+		return fmt.Errorf("no fix available for %s", finding.Field)
+	}
+	if err := finding.Fix.Apply(project); err != nil {
+		return err
+	}
+
+	if !finding.Fix.ChangesFiles {
+		p.history.Record(finding.Fix.Description, project)
+		p.appState.SetDirty(true)
+	}
+	p.appState.SetCurrentProject(project)
+
+	return nil
+}
+
+// ApplyAllFixes applies every available quick-fix as one undoable edit and
+// returns their descriptions
+func (p *mainPresenter) ApplyAllFixes() ([]string, error) {
+	project := p.appState.GetCurrentProject()
+	if project == nil {
+		return nil, fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return nil, ErrReadOnly
+	}
+
+	inheritance := p.GetInheritance()
+	rules := p.ruleSettings()
+	applied, err := pom.FixAll(project, func(project *pom.Project) pom.ValidationResult {
+		return p.validate(project, inheritance, rules)
+	})
+
+	var descriptions []string
+	edited := false
+	for _, fix := range applied {
+		descriptions = append(descriptions, fix.Description)
+		edited = edited || !fix.ChangesFiles
+	}
+	if edited {
+		p.history.Record("Fix All", project)
+		p.appState.SetDirty(true)
+	}
+	if len(applied) > 0 {
+		p.appState.SetCurrentProject(project)
+	}
+
+	return descriptions, err
+}
+
+// ruleSettings returns the user's rule settings overridden by the project's
+// .pom-manager.yaml, and loads the config's external properties; an invalid
+// config is ignored and reported by ValidateCurrent
+func (p *mainPresenter) ruleSettings() pom.RuleSettings {
+	path := p.appState.GetFilePath()
+	if path != p.rulesKey {
+		p.rules, p.external, p.rulesConfig, p.rulesErr = nil, nil, "", nil
+		if path != "" {
+			var config *pom.ProjectConfig
+			config, p.rulesConfig, p.rulesErr = pom.ProjectConfigFor(path)
+			p.rules, p.external = config.Validation.Rules, config.Validation.ExternalProperties
+		}
+		p.rulesKey = path
+	}
+
+	return p.appState.GetSettings().ValidationRules.Merge(p.rules)
+}
+
 // UpdateCoordinates updates the project coordinates
 func (p *mainPresenter) UpdateCoordinates(coords pom.Coordinates) error {
 	project := p.appState.GetCurrentProject()
@@ -134,6 +479,10 @@ func (p *mainPresenter) UpdateCoordinates(coords pom.Coordinates) error {
 		return fmt.Errorf("no project loaded")
 	}
 
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
 	// Update coordinates
 	project.GroupID = coords.GroupID
 	project.ArtifactID = coords.ArtifactID
@@ -141,24 +490,39 @@ func (p *mainPresenter) UpdateCoordinates(coords pom.Coordinates) error {
 	project.Coordinates = coords
 
 	// Mark as dirty and notify
+	p.history.RecordContinuous("Change Coordinates", project)
 	p.appState.SetDirty(true)
 	p.appState.SetCurrentProject(project) // This triggers notification
 
 	return nil
 }
 
-// AddDependency adds a new dependency to the project
+// AddDependency adds a new dependency to the end of the project's list
 func (p *mainPresenter) AddDependency(dep pom.Dependency) error {
+	return p.AddDependencyAt(dep, pom.InsertAtEnd, nil)
+}
+
+// AddDependencyAt adds a new dependency at position (see
+// pom.InsertDependency); for pom.InsertAfterEntry it goes after the
+// dependency after, or at the end when after is nil or missing. An existing
+// declaration is updated in place.
+func (p *mainPresenter) AddDependencyAt(dep pom.Dependency, position string, after *pom.Dependency) error {
 	project := p.appState.GetCurrentProject()
 	if project == nil {
 		return fmt.Errorf("no project loaded")
 	}
 
-	// Check for duplicates
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	// Check for duplicates; a different type or classifier is a different
+	// artifact
 	for i, existing := range project.Dependencies {
-		if existing.GroupID == dep.GroupID && existing.ArtifactID == dep.ArtifactID {
+		if existing.Key() == dep.Key() {
 			// Update existing dependency
 			project.Dependencies[i] = dep
+			p.history.Record("Update Dependency", project)
 			p.appState.SetDirty(true)
 			p.appState.SetCurrentProject(project)
 			return nil
@@ -166,13 +530,48 @@ func (p *mainPresenter) AddDependency(dep pom.Dependency) error {
 	}
 
 	// Add new dependency
-	project.Dependencies = append(project.Dependencies, dep)
+	index := -1
+	if after != nil {
+		for i, existing := range project.Dependencies {
+			if existing.Key() == after.Key() {
+				index = i
+				break
+			}
+		}
+	}
+	project.Dependencies = pom.InsertDependency(project.Dependencies, dep, position, index)
+	p.history.Record("Add Dependency", project)
 	p.appState.SetDirty(true)
 	p.appState.SetCurrentProject(project)
 
 	return nil
 }
 
+// UpdateDependency replaces a dependency, which may change its type or
+// classifier, in place
+func (p *mainPresenter) UpdateDependency(old, updated pom.Dependency) error {
+	project := p.appState.GetCurrentProject()
+	if project == nil {
+		return fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	for i, existing := range project.Dependencies {
+		if existing.Key() == old.Key() {
+			project.Dependencies[i] = updated
+			p.history.Record("Update Dependency", project)
+			p.appState.SetDirty(true)
+			p.appState.SetCurrentProject(project)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s:%s", pom.ErrDependencyNotFound, old.GroupID, old.ArtifactID)
+}
+
 // RemoveDependency removes a dependency from the project
 func (p *mainPresenter) RemoveDependency(groupID, artifactID string) error {
 	project := p.appState.GetCurrentProject()
@@ -180,10 +579,15 @@ func (p *mainPresenter) RemoveDependency(groupID, artifactID string) error {
 		return fmt.Errorf("no project loaded")
 	}
 
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
 	// Find and remove dependency
 	for i, dep := range project.Dependencies {
 		if dep.GroupID == groupID && dep.ArtifactID == artifactID {
 			project.Dependencies = append(project.Dependencies[:i], project.Dependencies[i+1:]...)
+			p.history.Record("Remove Dependency", project)
 			p.appState.SetDirty(true)
 			p.appState.SetCurrentProject(project)
 			return nil
@@ -193,6 +597,167 @@ func (p *mainPresenter) RemoveDependency(groupID, artifactID string) error {
 	return fmt.Errorf("dependency not found: %s:%s", groupID, artifactID)
 }
 
+// RemoveDependencies removes several dependencies as one edit with a
+// single change notification
+func (p *mainPresenter) RemoveDependencies(deps []pom.Dependency) error {
+	project := p.appState.GetCurrentProject()
+	if project == nil {
+		return fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	remove := dependencyKeys(deps)
+	kept := make([]pom.Dependency, 0, len(project.Dependencies))
+	for _, dep := range project.Dependencies {
+		if !remove[dep.Key()] {
+			kept = append(kept, dep)
+		}
+	}
+	if len(kept) == len(project.Dependencies) {
+		return fmt.Errorf("%w: none of the selected dependencies are declared", pom.ErrDependencyNotFound)
+	}
+
+	removed := len(project.Dependencies) - len(kept)
+	project.Dependencies = kept
+	p.history.Record(fmt.Sprintf("Remove %d Dependencies", removed), project)
+	p.appState.SetDirty(true)
+	p.appState.SetCurrentProject(project)
+
+	return nil
+}
+
+// SetDependenciesScope changes the scope of several dependencies as one
+// edit with a single change notification
+func (p *mainPresenter) SetDependenciesScope(deps []pom.Dependency, scope string) error {
+	project := p.appState.GetCurrentProject()
+	if project == nil {
+		return fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	valid := false
+	for _, s := range pom.ValidDependencyScopes {
+		valid = valid || s == scope
+	}
+	if !valid {
+		return fmt.Errorf("%w: %s", pom.ErrInvalidScope, scope)
+	}
+
+	change := dependencyKeys(deps)
+	changed := 0
+	for i, dep := range project.Dependencies {
+		if change[dep.Key()] {
+			project.Dependencies[i].Scope = scope
+			changed++
+		}
+	}
+	if changed == 0 {
+		return fmt.Errorf("%w: none of the selected dependencies are declared", pom.ErrDependencyNotFound)
+	}
+
+	p.history.Record(fmt.Sprintf("Change Scope of %d Dependencies", changed), project)
+	p.appState.SetDirty(true)
+	p.appState.SetCurrentProject(project)
+
+	return nil
+}
+
+// dependencyKeys returns the set of keys of deps
+func dependencyKeys(deps []pom.Dependency) map[string]bool {
+	keys := make(map[string]bool, len(deps))
+	for _, dep := range deps {
+		keys[dep.Key()] = true
+	}
+	return keys
+}
+
+// MoveDependency moves the dependency at index from to index to; the
+// generator writes dependencies in this order
+func (p *mainPresenter) MoveDependency(from, to int) error {
+	project := p.appState.GetCurrentProject()
+	if project == nil {
+		return fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	if from == to {
+		return nil
+	}
+	if err := pom.MoveDependency(project.Dependencies, from, to); err != nil {
+		return err
+	}
+
+	p.history.Record("Move Dependency", project)
+	p.appState.SetDirty(true)
+	p.appState.SetCurrentProject(project)
+
+	return nil
+}
+
+// SortDependencies orders the dependencies by scope, then groupId and
+// artifactId
+func (p *mainPresenter) SortDependencies() error {
+	project := p.appState.GetCurrentProject()
+	if project == nil {
+		return fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	pom.SortDependencies(project.Dependencies)
+	p.history.Record("Sort Dependencies", project)
+	p.appState.SetDirty(true)
+	p.appState.SetCurrentProject(project)
+
+	return nil
+}
+
+// AddBOM imports a bill of materials into the project's dependencyManagement
+// so dependencies it manages can be declared without a version
+func (p *mainPresenter) AddBOM(bom pom.Coordinates) error {
+	project := p.appState.GetCurrentProject()
+	if project == nil {
+		return fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	if bom.GroupID == "" || bom.ArtifactID == "" || bom.Version == "" {
+		return fmt.Errorf("%w: BOM groupId, artifactId and version are required", pom.ErrMissingRequired)
+	}
+
+	entry := pom.NewBOM(bom.GroupID, bom.ArtifactID, bom.Version)
+	for i, existing := range project.DependencyManagement {
+		if existing.GroupID == bom.GroupID && existing.ArtifactID == bom.ArtifactID {
+			project.DependencyManagement[i] = entry
+			p.history.Record("Update BOM", project)
+			p.appState.SetDirty(true)
+			p.appState.SetCurrentProject(project)
+			return nil
+		}
+	}
+
+	project.DependencyManagement = append(project.DependencyManagement, entry)
+	p.history.Record("Add BOM", project)
+	p.appState.SetDirty(true)
+	p.appState.SetCurrentProject(project)
+
+	return nil
+}
+
 // AddPlugin adds a new plugin to the project's build configuration
 func (p *mainPresenter) AddPlugin(plugin pom.Plugin) error {
 	project := p.appState.GetCurrentProject()
@@ -200,6 +765,10 @@ func (p *mainPresenter) AddPlugin(plugin pom.Plugin) error {
 		return fmt.Errorf("no project loaded")
 	}
 
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
 	// Ensure Build section exists
 	if project.Build == nil {
 		project.Build = &pom.Build{
@@ -212,6 +781,7 @@ func (p *mainPresenter) AddPlugin(plugin pom.Plugin) error {
 		if existing.GroupID == plugin.GroupID && existing.ArtifactID == plugin.ArtifactID {
 			// Update existing plugin
 			project.Build.Plugins[i] = plugin
+			p.history.Record("Update Plugin", project)
 			p.appState.SetDirty(true)
 			p.appState.SetCurrentProject(project)
 			return nil
@@ -220,6 +790,7 @@ func (p *mainPresenter) AddPlugin(plugin pom.Plugin) error {
 
 	// Add new plugin
 	project.Build.Plugins = append(project.Build.Plugins, plugin)
+	p.history.Record("Add Plugin", project)
 	p.appState.SetDirty(true)
 	p.appState.SetCurrentProject(project)
 
@@ -233,6 +804,10 @@ func (p *mainPresenter) RemovePlugin(groupID, artifactID string) error {
 		return fmt.Errorf("no project loaded")
 	}
 
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
 	if project.Build == nil {
 		return fmt.Errorf("no build configuration")
 	}
@@ -241,13 +816,185 @@ func (p *mainPresenter) RemovePlugin(groupID, artifactID string) error {
 	for i, plugin := range project.Build.Plugins {
 		if plugin.GroupID == groupID && plugin.ArtifactID == artifactID {
 			project.Build.Plugins = append(project.Build.Plugins[:i], project.Build.Plugins[i+1:]...)
+			p.history.Record("Remove Plugin", project)
 			p.appState.SetDirty(true)
 			p.appState.SetCurrentProject(project)
 			return nil
 		}
 	}
 
-	return fmt.Errorf("plugin not found: %s:%s", groupID, artifactID)
+	return fmt.Errorf("%w: %s:%s", pom.ErrPluginNotFound, groupID, artifactID)
+}
+
+// AddExecution adds an execution to the plugin at pluginIndex
+func (p *mainPresenter) AddExecution(pluginIndex int, execution pom.PluginExecution) error {
+	project := p.appState.GetCurrentProject()
+	if project == nil {
+		return fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	if project.Build == nil || pluginIndex < 0 || pluginIndex >= len(project.Build.Plugins) {
+		return fmt.Errorf("%w: index %d", pom.ErrPluginNotFound, pluginIndex)
+	}
+	plugin := &project.Build.Plugins[pluginIndex]
+	if err := checkExecutionID(*plugin, -1, execution.ID); err != nil {
+		return err
+	}
+
+	plugin.Executions = append(plugin.Executions, execution)
+	p.history.Record("Add Execution", project)
+	p.appState.SetDirty(true)
+	p.appState.SetCurrentProject(project)
+
+	return nil
+}
+
+// UpdateExecution replaces the execution at executionIndex of the plugin at
+// pluginIndex. When targetPluginIndex is another plugin, the execution is
+// moved to the end of that plugin's executions.
+func (p *mainPresenter) UpdateExecution(pluginIndex, executionIndex, targetPluginIndex int, execution pom.PluginExecution) error {
+	project := p.appState.GetCurrentProject()
+	if project == nil {
+		return fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	if err := checkExecutionIndex(project, pluginIndex, executionIndex); err != nil {
+		return err
+	}
+	if targetPluginIndex < 0 || targetPluginIndex >= len(project.Build.Plugins) {
+		return fmt.Errorf("%w: index %d", pom.ErrPluginNotFound, targetPluginIndex)
+	}
+
+	plugins := project.Build.Plugins
+	if targetPluginIndex == pluginIndex {
+		if err := checkExecutionID(plugins[pluginIndex], executionIndex, execution.ID); err != nil {
+			return err
+		}
+		plugins[pluginIndex].Executions[executionIndex] = execution
+	} else {
+		if err := checkExecutionID(plugins[targetPluginIndex], -1, execution.ID); err != nil {
+			return err
+		}
+		plugins[pluginIndex].Executions = slices.Delete(plugins[pluginIndex].Executions, executionIndex, executionIndex+1)
+		plugins[targetPluginIndex].Executions = append(plugins[targetPluginIndex].Executions, execution)
+	}
+
+	p.history.Record("Edit Execution", project)
+	p.appState.SetDirty(true)
+	p.appState.SetCurrentProject(project)
+
+	return nil
+}
+
+// RemoveExecution removes the execution at executionIndex of the plugin at
+// pluginIndex
+func (p *mainPresenter) RemoveExecution(pluginIndex, executionIndex int) error {
+	project := p.appState.GetCurrentProject()
+	if project == nil {
+		return fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	if err := checkExecutionIndex(project, pluginIndex, executionIndex); err != nil {
+		return err
+	}
+
+	plugin := &project.Build.Plugins[pluginIndex]
+	plugin.Executions = slices.Delete(plugin.Executions, executionIndex, executionIndex+1)
+	p.history.Record("Remove Execution", project)
+	p.appState.SetDirty(true)
+	p.appState.SetCurrentProject(project)
+
+	return nil
+}
+
+// checkExecutionIndex returns an error when the project has no execution at
+// executionIndex of the plugin at pluginIndex
+func checkExecutionIndex(project *pom.Project, pluginIndex, executionIndex int) error {
+	if project.Build == nil || pluginIndex < 0 || pluginIndex >= len(project.Build.Plugins) {
+		return fmt.Errorf("%w: index %d", pom.ErrPluginNotFound, pluginIndex)
+	}
+	plugin := project.Build.Plugins[pluginIndex]
+	if executionIndex < 0 || executionIndex >= len(plugin.Executions) {
+		return fmt.Errorf("%s has no execution %d", plugin.ArtifactID, executionIndex)
+	}
+	return nil
+}
+
+// checkExecutionID returns an error when another execution of the plugin
+// than the one at skip already has id, which Maven rejects
+func checkExecutionID(plugin pom.Plugin, skip int, id string) error {
+	for i, exec := range plugin.Executions {
+		if i != skip && exec.ID == id {
+			return fmt.Errorf("%s already has an execution %s", plugin.ArtifactID, id)
+		}
+	}
+	return nil
+}
+
+// MoveExecution swaps two neighbouring executions bound to the same phase,
+// changing the order Maven runs them in
+func (p *mainPresenter) MoveExecution(phase string, from, to int) error {
+	project := p.appState.GetCurrentProject()
+	if project == nil {
+		return fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	if err := pom.MoveExecution(project, phase, from, to); err != nil {
+		return err
+	}
+
+	p.history.Record("Reorder Executions", project)
+	p.appState.SetDirty(true)
+	p.appState.SetCurrentProject(project)
+
+	return nil
+}
+
+// SetSkipFlags sets or clears plugin skip properties, such as skipTests,
+// in the project's properties or, when profileID is not empty, in that
+// profile's
+func (p *mainPresenter) SetSkipFlags(profileID string, flags map[string]bool) error {
+	project := p.appState.GetCurrentProject()
+	if project == nil {
+		return fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	changed := false
+	for property, skip := range flags {
+		if pom.SkipEnabled(project, profileID, property) != skip {
+			pom.SetSkip(project, profileID, property, skip)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	p.history.Record("Change Skip Flags", project)
+	p.appState.SetDirty(true)
+	p.appState.SetCurrentProject(project)
+
+	return nil
 }
 
 // UpdateProperties updates the project properties
@@ -257,26 +1004,374 @@ func (p *mainPresenter) UpdateProperties(props map[string]string) error {
 		return fmt.Errorf("no project loaded")
 	}
 
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
 	// Update properties
 	project.Properties = props
+	p.history.Record("Edit Properties", project)
 	p.appState.SetDirty(true)
 	p.appState.SetCurrentProject(project)
 
 	return nil
 }
 
-// UpdateProject updates the entire project
-func (p *mainPresenter) UpdateProject(project *pom.Project) error {
+// UpdateProject updates the entire project; operation names the change in
+// the undo history
+func (p *mainPresenter) UpdateProject(operation string, project *pom.Project) error {
 	if project == nil {
 		return fmt.Errorf("project cannot be nil")
 	}
 
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	p.history.Record(operation, project)
 	p.appState.SetDirty(true)
 	p.appState.SetCurrentProject(project)
 
 	return nil
 }
 
+// ApplyXML replaces the current project with one parsed from edited XML
+func (p *mainPresenter) ApplyXML(xml string) error {
+	if p.appState.GetCurrentProject() == nil {
+		return fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	project, err := p.parser.Parse([]byte(xml))
+	if err != nil {
+		return err
+	}
+
+	return p.UpdateProject("Edit XML", project)
+}
+
+// Undo reverts the most recent edit
+func (p *mainPresenter) Undo() error {
+	if p.appState.GetCurrentProject() == nil {
+		return fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	project, _, ok := p.history.Undo()
+	if !ok {
+		return fmt.Errorf("nothing to undo")
+	}
+
+	p.appState.SetDirty(true)
+	p.appState.SetCurrentProject(project)
+
+	return nil
+}
+
+// Redo reapplies the most recently undone edit
+func (p *mainPresenter) Redo() error {
+	if p.appState.GetCurrentProject() == nil {
+		return fmt.Errorf("no project loaded")
+	}
+
+	if p.appState.IsReadOnly() {
+		return ErrReadOnly
+	}
+
+	project, _, ok := p.history.Redo()
+	if !ok {
+		return fmt.Errorf("nothing to redo")
+	}
+
+	p.appState.SetDirty(true)
+	p.appState.SetCurrentProject(project)
+
+	return nil
+}
+
+// UndoName returns the name of the edit Undo would revert, or ""
+func (p *mainPresenter) UndoName() string {
+	return p.history.UndoName()
+}
+
+// RedoName returns the name of the edit Redo would reapply, or ""
+func (p *mainPresenter) RedoName() string {
+	return p.history.RedoName()
+}
+
+// GetInheritance returns what the current project inherits from its parent
+// chain. Parents are resolved lazily and re-resolved when <parent> changes.
+func (p *mainPresenter) GetInheritance() *pom.Inheritance {
+	project := p.appState.GetCurrentProject()
+	if project == nil || project.Parent == nil {
+		return &pom.Inheritance{}
+	}
+
+	path := p.appState.GetFilePath()
+	key := fmt.Sprintf("%s|%s:%s:%s|%s", path, project.Parent.GroupID, project.Parent.ArtifactID,
+		project.Parent.Version, project.Parent.RelativePath)
+	if key != p.parentKey {
+		p.parents, p.parentErr = p.parentResolver.ResolveChain(path, project)
+		p.parentKey = key
+	}
+
+	inheritance := pom.ComputeInheritance(project, p.parents)
+	if p.parentErr != nil {
+		inheritance.Warnings = append(inheritance.Warnings, pom.ValidationError{
+			Field:    "parent",
+			Value:    fmt.Sprintf("%s:%s:%s", project.Parent.GroupID, project.Parent.ArtifactID, project.Parent.Version),
+			Message:  fmt.Sprintf("inherited elements may be incomplete: %v", p.parentErr),
+			Severity: pom.SeverityWarning,
+		})
+	}
+
+	return inheritance
+}
+
+// AutoSave persists unsaved changes so they survive a crash. Scratch buffers
+// are saved in place; other documents are written to the recovery file, which
+// is cleared again once there is nothing left to recover.
+func (p *mainPresenter) AutoSave() error {
+	project := p.appState.GetCurrentProject()
+	if project == nil || !p.appState.IsDirty() {
+		return state.ClearRecovery()
+	}
+
+	path := p.appState.GetFilePath()
+	if state.IsScratchPath(path) {
+		return p.SavePOM(path)
+	}
+
+	xmlData, err := p.generator.Generate(project)
+	if err != nil {
+		return fmt.Errorf("failed to generate POM XML: %w", err)
+	}
+
+	return state.WriteRecovery(path, xmlData)
+}
+
+// RestoreRecovery opens an auto-saved document as an unsaved edit of its
+// original file
+func (p *mainPresenter) RestoreRecovery(recovery *state.Recovery) error {
+	project, err := p.parser.Parse(recovery.Data)
+	if err != nil {
+		return fmt.Errorf("failed to restore recovered POM: %w", err)
+	}
+
+	p.parentKey = ""
+	readOnly := p.forceReadOnly
+	p.committed = nil
+	p.saved = nil
+	if recovery.FilePath != "" {
+		readOnly = readOnly || !p.repository.IsWritable(recovery.FilePath)
+		// The recovered edits are changes to the file still on disk
+		if original, err := p.parser.ParseFile(recovery.FilePath); err == nil {
+			p.committed = original
+			p.saved = original.Clone()
+		}
+	}
+
+	p.history.Reset(project)
+	p.appState.SetReadOnly(readOnly)
+	p.appState.SetCurrentProject(project)
+	p.appState.SetFilePath(recovery.FilePath)
+	p.appState.SetDirty(true)
+
+	return nil
+}
+
+// SessionChangelog describes the changes since the project was opened or
+// last committed as a commit message, e.g. "Add prod profile; bump junit to
+// 5.10.2". New projects are described as created; "" means no changes.
+func (p *mainPresenter) SessionChangelog() string {
+	project := p.appState.GetCurrentProject()
+	if project == nil {
+		return ""
+	}
+	if p.committed == nil {
+		return fmt.Sprintf("Create %s:%s", project.GroupID, project.ArtifactID)
+	}
+	return diff.Changelog(diff.Projects(p.committed, project))
+}
+
+// MarkCommitted starts the session changelog afresh from the current project
+func (p *mainPresenter) MarkCommitted() {
+	if project := p.appState.GetCurrentProject(); project != nil {
+		p.committed = project.Clone()
+	}
+}
+
+// CheckDiskChanges compares the file of the active document with the
+// version last read or saved, returning nil when another program changed
+// nothing in it, such as after only reformatting it. A file removed, even
+// while being replaced, fails with pom.ErrFileNotFound.
+func (p *mainPresenter) CheckDiskChanges() (*DiskChange, error) {
+	project := p.appState.GetCurrentProject()
+	path := p.appState.GetFilePath()
+	if project == nil || p.saved == nil || path == "" {
+		return nil, nil
+	}
+
+	onDisk, err := p.parser.ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	merge := diff.ThreeWay(p.saved, project, onDisk)
+	if len(merge.Theirs) == 0 {
+		return nil, nil
+	}
+	return &DiskChange{Path: path, Project: onDisk, Merge: merge}, nil
+}
+
+// KeepLocalChanges keeps the edits of the active document over a change
+// made on disk, which is only reported again once the file changes anew.
+// The document is left unsaved, as saving it overwrites the change.
+func (p *mainPresenter) KeepLocalChanges(change *DiskChange) {
+	if change == nil || change.Path != p.appState.GetFilePath() {
+		return
+	}
+	p.saved = change.Project
+	p.appState.SetDirty(true)
+}
+
+// NewDocument opens an empty document in a new tab and makes it active,
+// keeping the edit history of the current one
+func (p *mainPresenter) NewDocument() {
+	p.suspendDocument()
+	p.appState.AddDocument()
+	p.resumeDocument()
+}
+
+// SwitchDocument makes the document at index active, with its own edit
+// history
+func (p *mainPresenter) SwitchDocument(index int) error {
+	if index == p.appState.ActiveDocument() {
+		return nil
+	}
+	if p.appState.Document(index) == nil {
+		return fmt.Errorf("%w: %d", state.ErrNoDocument, index)
+	}
+	p.suspendDocument()
+	if err := p.appState.ActivateDocument(index); err != nil {
+		return err
+	}
+	p.resumeDocument()
+	return nil
+}
+
+// CloseDocument closes the document at index, discarding unsaved changes
+func (p *mainPresenter) CloseDocument(index int) error {
+	active := p.appState.Document(p.appState.ActiveDocument())
+	closed := p.appState.Document(index)
+	if err := p.appState.CloseDocument(index); err != nil {
+		return err
+	}
+	delete(p.sessions, closed)
+	if closed == active {
+		p.resumeDocument()
+	}
+	return nil
+}
+
+// SaveScratchBuffers saves the scratch buffers with unsaved changes in
+// place, whichever document is active, so closing the window loses none
+// of them. The active document stays active.
+func (p *mainPresenter) SaveScratchBuffers() error {
+	active := p.appState.ActiveDocument()
+	var errs []error
+	for i, doc := range p.appState.Documents() {
+		if !doc.Dirty || !state.IsScratchPath(doc.FilePath) {
+			continue
+		}
+		if err := p.SwitchDocument(i); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := p.SavePOM(doc.FilePath); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := p.SwitchDocument(active); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// suspendDocument keeps the edit history of the active document while
+// another one is
+func (p *mainPresenter) suspendDocument() {
+	doc := p.appState.Document(p.appState.ActiveDocument())
+	p.sessions[doc] = documentSession{history: p.history, committed: p.committed, saved: p.saved}
+}
+
+// resumeDocument restores the edit history of the active document, starting
+// one for a document that has none
+func (p *mainPresenter) resumeDocument() {
+	doc := p.appState.Document(p.appState.ActiveDocument())
+	session, ok := p.sessions[doc]
+	if !ok {
+		session = documentSession{history: history.New(history.DefaultLimit)}
+		if project := p.appState.GetCurrentProject(); project != nil {
+			session.history.Reset(project)
+		}
+	}
+	delete(p.sessions, doc)
+	p.history = session.history
+	p.committed = session.committed
+	p.saved = session.saved
+}
+
+// IsReadOnly reports whether the current document is in view-only mode
+func (p *mainPresenter) IsReadOnly() bool {
+	return p.appState.IsReadOnly()
+}
+
+// Templates lists the templates new projects can be created from
+func (p *mainPresenter) Templates() []pom.TemplateInfo {
+	return p.templateManager.List()
+}
+
+// TemplateManager returns the template manager new projects are created
+// with, such as for previewing templates
+func (p *mainPresenter) TemplateManager() pom.TemplateManager {
+	return p.templateManager
+}
+
+// SetTemplateManager replaces the template manager, e.g. when the custom
+// template directory changes
+func (p *mainPresenter) SetTemplateManager(templateManager pom.TemplateManager) {
+	p.templateManager = templateManager
+}
+
+// SetRecorder records the files the presenter writes besides saves in the
+// audit log
+func (p *mainPresenter) SetRecorder(recorder *audit.Recorder) {
+	p.recorder = recorder
+}
+
+// track runs write, which changes the file at path, recording it when an
+// audit recorder is set
+func (p *mainPresenter) track(path, operation string, write func() error) error {
+	if p.recorder == nil {
+		return write()
+	}
+	return p.recorder.Track(path, operation, write)
+}
+
+// SetForceReadOnly opens all subsequently loaded documents view-only
+func (p *mainPresenter) SetForceReadOnly(readOnly bool) {
+	p.forceReadOnly = readOnly
+	if readOnly {
+		p.appState.SetReadOnly(true)
+	}
+}
+
 // GetCurrentProject returns the current project from app state
 func (p *mainPresenter) GetCurrentProject() *pom.Project {
 	return p.appState.GetCurrentProject()
@@ -1,8 +1,13 @@
 package presenters
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/user/pom-manager/internal/core/audit"
 	"github.com/user/pom-manager/internal/core/pom"
 	"github.com/user/pom-manager/internal/gui/state"
 )
@@ -78,6 +83,135 @@ func TestCreateNewPOM(t *testing.T) {
 	}
 }
 
+func TestCustomTemplates(t *testing.T) {
+	appState := state.NewAppState()
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		appState,
+	)
+	builtin := len(presenter.Templates())
+
+	dir := t.TempDir()
+	definition := "description: Service\ndependencies:\n  - groupId: org.slf4j\n    artifactId: slf4j-api\n    version: 2.0.9\n"
+	if err := os.WriteFile(filepath.Join(dir, "service.yaml"), []byte(definition), 0644); err != nil {
+		t.Fatal(err)
+	}
+	presenter.SetTemplateManager(pom.NewTemplateManager(dir))
+
+	templates := presenter.Templates()
+	if len(templates) != builtin+1 {
+		t.Fatalf("Expected the custom template after the %d built-in ones, got %+v", builtin, templates)
+	}
+	if custom := templates[builtin]; custom.Name != "service" || custom.Description != "Service" || custom.Path == "" {
+		t.Errorf("Expected the custom template with its description and path, got %+v", custom)
+	}
+
+	coords := pom.Coordinates{GroupID: "com.example", ArtifactID: "svc", Version: "1.0.0"}
+	if err := presenter.CreateNewPOM(coords, "service"); err != nil {
+		t.Fatalf("Failed to create a POM from the custom template: %v", err)
+	}
+	project := presenter.GetCurrentProject()
+	if project.ArtifactID != "svc" || len(project.Dependencies) != 1 {
+		t.Errorf("Expected the custom template's dependency in svc, got %+v", project)
+	}
+}
+
+func TestCreateNewPOMWithValues(t *testing.T) {
+	appState := state.NewAppState()
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		appState,
+	)
+
+	dir := t.TempDir()
+	definition := "description: Service\nvariables:\n  - name: slf4jVersion\n    default: 2.0.9\n  - name: team\n" +
+		"properties:\n  owner: \"{{team}}\"\ndependencies:\n  - groupId: org.slf4j\n    artifactId: slf4j-api\n    version: \"{{slf4jVersion}}\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "service.yaml"), []byte(definition), 0644); err != nil {
+		t.Fatal(err)
+	}
+	presenter.SetTemplateManager(pom.NewTemplateManager(dir))
+
+	var variables []pom.TemplateVariable
+	for _, info := range presenter.Templates() {
+		if info.Name == "service" {
+			variables = info.Variables
+		}
+	}
+	if len(variables) != 2 || variables[0].Default != "2.0.9" || variables[1].Name != "team" {
+		t.Errorf("Expected the declared variables of the custom template, got %+v", variables)
+	}
+
+	coords := pom.Coordinates{GroupID: "com.example", ArtifactID: "svc", Version: "1.0.0"}
+	if err := presenter.CreateNewPOM(coords, "service"); err == nil {
+		t.Error("Expected an error without a value for team")
+	}
+	if err := presenter.CreateNewPOMWithValues(coords, "service", map[string]string{"team": "core"}); err != nil {
+		t.Fatalf("CreateNewPOMWithValues failed: %v", err)
+	}
+	project := presenter.GetCurrentProject()
+	if project.Properties["owner"] != "core" || project.Dependencies[0].Version != "2.0.9" {
+		t.Errorf("Expected the value and the default filled in, got %+v", project)
+	}
+
+	if err := presenter.CreateNewPOMWithValues(coords, "basic-java", map[string]string{"javaVersion": "21"}); err != nil {
+		t.Fatalf("CreateNewPOMWithValues failed: %v", err)
+	}
+	if got := presenter.GetCurrentProject().Properties["maven.compiler.source"]; got != "21" {
+		t.Errorf("Expected maven.compiler.source 21, got %q", got)
+	}
+}
+
+func TestCreateFrameworkTemplates(t *testing.T) {
+	appState := state.NewAppState()
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		appState,
+	)
+	coords := pom.Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "1.0.0"}
+
+	if err := presenter.CreateNewPOMWithValues(coords, "spring-boot", map[string]string{"springBootVersion": "3.4.0"}); err != nil {
+		t.Fatalf("Failed to create a Spring Boot POM: %v", err)
+	}
+	project := presenter.GetCurrentProject()
+	if project.Parent == nil || project.Parent.ArtifactID != "spring-boot-starter-parent" || project.Parent.Version != "3.4.0" {
+		t.Errorf("Expected the Spring Boot parent at 3.4.0, got %+v", project.Parent)
+	}
+	if result, _ := presenter.ValidateCurrent(); !result.Valid {
+		t.Errorf("Expected starters managed by the unresolved parent to be valid, got %v", result.Errors.AllErrors())
+	}
+
+	if err := presenter.CreateNewPOMWithValues(coords, "quarkus", map[string]string{"javaVersion": "21"}); err != nil {
+		t.Fatalf("Failed to create a Quarkus POM: %v", err)
+	}
+	project = presenter.GetCurrentProject()
+	if boms := project.BOMs(); len(boms) != 1 || project.Properties["maven.compiler.release"] != "21" {
+		t.Errorf("Expected the Quarkus BOM and Java 21, got %+v", project)
+	}
+	if plugin := project.Build.Plugins[0]; plugin.ArtifactID != "quarkus-maven-plugin" || !plugin.Extensions {
+		t.Errorf("Expected quarkus-maven-plugin with extensions, got %+v", plugin)
+	}
+
+	if err := presenter.CreateNewPOM(coords, "kotlin-jvm"); err != nil {
+		t.Fatalf("Failed to create a Kotlin POM: %v", err)
+	}
+	project = presenter.GetCurrentProject()
+	if project.Properties["kotlin.version"] == "" || project.Build.SourceDirectory != "src/main/kotlin" {
+		t.Errorf("Expected kotlin.version and Kotlin sources, got %+v", project)
+	}
+}
+
 func TestUpdateCoordinates(t *testing.T) {
 	parser := pom.NewParser()
 	generator := pom.NewGenerator()
@@ -185,6 +319,51 @@ func TestAddDependency(t *testing.T) {
 	}
 }
 
+func TestAddDependencyAt(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	_ = presenter.CreateNewPOM(pom.Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "1.0.0"}, "basic-java")
+
+	slf4j := pom.Dependency{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.9"}
+	junit := pom.Dependency{GroupID: "junit", ArtifactID: "junit", Version: "4.13.2", Scope: "test"}
+	_ = presenter.AddDependency(slf4j)
+	_ = presenter.AddDependency(junit)
+
+	steps := []struct {
+		dep      pom.Dependency
+		position string
+		after    *pom.Dependency
+		expected string
+	}{
+		{pom.Dependency{GroupID: "com.google.guava", ArtifactID: "guava", Version: "33.0.0-jre"}, pom.InsertAlphabetical, nil,
+			"com.google.guava:guava org.slf4j:slf4j-api junit:junit"},
+		{pom.Dependency{GroupID: "org.mockito", ArtifactID: "mockito-core", Version: "5.11.0", Scope: "test"}, pom.InsertAfterEntry, &slf4j,
+			"com.google.guava:guava org.slf4j:slf4j-api org.mockito:mockito-core junit:junit"},
+		{pom.Dependency{GroupID: "org.apache.commons", ArtifactID: "commons-lang3", Version: "3.14.0"}, pom.InsertByScope, nil,
+			"com.google.guava:guava org.slf4j:slf4j-api org.apache.commons:commons-lang3 org.mockito:mockito-core junit:junit"},
+		{pom.Dependency{GroupID: "org.assertj", ArtifactID: "assertj-core", Version: "3.25.3", Scope: "test"}, pom.InsertAfterEntry, nil,
+			"com.google.guava:guava org.slf4j:slf4j-api org.apache.commons:commons-lang3 org.mockito:mockito-core junit:junit org.assertj:assertj-core"},
+	}
+	for _, step := range steps {
+		if err := presenter.AddDependencyAt(step.dep, step.position, step.after); err != nil {
+			t.Fatalf("AddDependencyAt failed: %v", err)
+		}
+		var keys []string
+		for _, dep := range presenter.GetCurrentProject().Dependencies {
+			keys = append(keys, dep.GroupID+":"+dep.ArtifactID)
+		}
+		if got := strings.Join(keys, " "); got != step.expected {
+			t.Errorf("Expected %s inserted %s: %s, got %s", step.dep.ArtifactID, step.position, step.expected, got)
+		}
+	}
+}
+
 func TestRemoveDependency(t *testing.T) {
 	parser := pom.NewParser()
 	generator := pom.NewGenerator()
@@ -314,3 +493,1673 @@ func TestSubscribeToChanges(t *testing.T) {
 		t.Error("Expected callback to be called after changes")
 	}
 }
+
+func TestReadOnlyMode(t *testing.T) {
+	parser := pom.NewParser()
+	generator := pom.NewGenerator()
+	validator := pom.NewValidator()
+	repository := pom.NewRepository()
+	templateManager := pom.NewTemplateManager()
+	appState := state.NewAppState()
+
+	presenter := NewMainPresenter(
+		parser,
+		generator,
+		validator,
+		repository,
+		templateManager,
+		appState,
+	)
+
+	// Write a POM to disk
+	coords := pom.Coordinates{
+		GroupID:    "com.example",
+		ArtifactID: "test-app",
+		Version:    "1.0.0",
+	}
+	_ = presenter.CreateNewPOM(coords, "basic-java")
+	path := filepath.Join(t.TempDir(), "pom.xml")
+	if err := presenter.SavePOM(path); err != nil {
+		t.Fatalf("Failed to save POM: %v", err)
+	}
+
+	// Reopen in forced read-only mode
+	presenter.SetForceReadOnly(true)
+	if err := presenter.LoadPOM(path); err != nil {
+		t.Fatalf("Failed to load POM: %v", err)
+	}
+
+	if !presenter.IsReadOnly() {
+		t.Fatal("Expected document to be read-only")
+	}
+
+	dep := pom.Dependency{GroupID: "junit", ArtifactID: "junit", Version: "4.13.2"}
+	if err := presenter.AddDependency(dep); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from AddDependency, got %v", err)
+	}
+
+	if err := presenter.SavePOM(path); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly saving over read-only file, got %v", err)
+	}
+
+	// Saving a copy elsewhere is always allowed
+	copyPath := filepath.Join(t.TempDir(), "copy.xml")
+	if err := presenter.SavePOM(copyPath); err != nil {
+		t.Errorf("Expected Save As copy to succeed, got %v", err)
+	}
+}
+
+func TestGetInheritance(t *testing.T) {
+	dir := t.TempDir()
+	parentXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0.0</version>
+    <packaging>pom</packaging>
+    <properties>
+        <java.version>17</java.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>4.13.2</version>
+            <scope>test</scope>
+        </dependency>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>2.0.9</version>
+        </dependency>
+    </dependencies>
+</project>`
+	childXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0.0</version>
+    </parent>
+    <artifactId>core</artifactId>
+    <dependencies>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>4.13.2</version>
+            <scope>test</scope>
+        </dependency>
+    </dependencies>
+</project>`
+
+	childPath := filepath.Join(dir, "core", "pom.xml")
+	if err := os.MkdirAll(filepath.Dir(childPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pom.xml"), []byte(parentXML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(childPath, []byte(childXML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+
+	if err := presenter.LoadPOM(childPath); err != nil {
+		t.Fatalf("Failed to load child POM: %v", err)
+	}
+
+	// groupId and version come from <parent>
+	project := presenter.GetCurrentProject()
+	if project.GroupID != "com.example" || project.Version != "1.0.0" {
+		t.Errorf("Expected inherited coordinates, got %s", project.Coordinates.String())
+	}
+
+	inheritance := presenter.GetInheritance()
+	if len(inheritance.Parents) != 1 {
+		t.Fatalf("Expected 1 resolved parent, got %d", len(inheritance.Parents))
+	}
+
+	if len(inheritance.Properties) != 1 || inheritance.Properties[0].Name != "java.version" {
+		t.Errorf("Expected inherited java.version property, got %v", inheritance.Properties)
+	}
+
+	// junit is redeclared by the child, so only slf4j is inherited
+	if len(inheritance.Dependencies) != 1 || inheritance.Dependencies[0].ArtifactID != "slf4j-api" {
+		t.Errorf("Expected inherited slf4j-api dependency, got %v", inheritance.Dependencies)
+	}
+
+	if len(inheritance.Warnings) != 1 || inheritance.Warnings[0].Field != "dependencies.junit:junit" {
+		t.Errorf("Expected a redeclaration warning for junit, got %v", inheritance.Warnings)
+	}
+}
+
+func TestUndoRedo(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+
+	coords := pom.Coordinates{
+		GroupID:    "com.example",
+		ArtifactID: "test-app",
+		Version:    "1.0.0",
+	}
+	_ = presenter.CreateNewPOM(coords, "basic-java")
+	initialDeps := len(presenter.GetCurrentProject().Dependencies)
+
+	if presenter.UndoName() != "" {
+		t.Errorf("Expected empty history for a new POM, got '%s'", presenter.UndoName())
+	}
+
+	dep := pom.Dependency{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.9"}
+	if err := presenter.AddDependency(dep); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := presenter.RemoveDependency(dep.GroupID, dep.ArtifactID); err != nil {
+		t.Fatalf("RemoveDependency failed: %v", err)
+	}
+
+	if presenter.UndoName() != "Remove Dependency" {
+		t.Errorf("Expected 'Remove Dependency' to undo, got '%s'", presenter.UndoName())
+	}
+
+	// Undo the removal: dependency is back
+	if err := presenter.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if got := len(presenter.GetCurrentProject().Dependencies); got != initialDeps+1 {
+		t.Errorf("Expected %d dependencies after undo, got %d", initialDeps+1, got)
+	}
+
+	// Undo the addition: back to the template
+	if err := presenter.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if got := len(presenter.GetCurrentProject().Dependencies); got != initialDeps {
+		t.Errorf("Expected %d dependencies after second undo, got %d", initialDeps, got)
+	}
+	if err := presenter.Undo(); err == nil {
+		t.Error("Expected error when nothing is left to undo")
+	}
+
+	if presenter.RedoName() != "Add Dependency" {
+		t.Errorf("Expected 'Add Dependency' to redo, got '%s'", presenter.RedoName())
+	}
+	if err := presenter.Redo(); err != nil {
+		t.Fatalf("Redo failed: %v", err)
+	}
+	if got := len(presenter.GetCurrentProject().Dependencies); got != initialDeps+1 {
+		t.Errorf("Expected %d dependencies after redo, got %d", initialDeps+1, got)
+	}
+
+	// A new edit discards the redo stack
+	_ = presenter.UpdateProperties(map[string]string{"java.version": "17"})
+	if presenter.RedoName() != "" {
+		t.Errorf("Expected redo stack to be cleared, got '%s'", presenter.RedoName())
+	}
+}
+
+func TestApplyXML(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+
+	coords := pom.Coordinates{
+		GroupID:    "com.example",
+		ArtifactID: "test-app",
+		Version:    "1.0.0",
+	}
+	_ = presenter.CreateNewPOM(coords, "basic-java")
+
+	xml := `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>com.example</groupId>
+    <artifactId>edited-app</artifactId>
+    <version>2.0.0</version>
+</project>`
+	if err := presenter.ApplyXML(xml); err != nil {
+		t.Fatalf("ApplyXML failed: %v", err)
+	}
+	if got := presenter.GetCurrentProject().ArtifactID; got != "edited-app" {
+		t.Errorf("Expected artifactId 'edited-app', got '%s'", got)
+	}
+	if presenter.UndoName() != "Edit XML" {
+		t.Errorf("Expected 'Edit XML' to undo, got '%s'", presenter.UndoName())
+	}
+
+	// Malformed XML leaves the project untouched and reports the line
+	err := presenter.ApplyXML("<project>\n<groupId>broken</groupId\n</project>")
+	if err == nil {
+		t.Fatal("Expected error for malformed XML")
+	}
+	if line := pom.ErrorLine(err); line != 3 {
+		t.Errorf("Expected error on line 3, got %d (%v)", line, err)
+	}
+	if got := presenter.GetCurrentProject().ArtifactID; got != "edited-app" {
+		t.Errorf("Expected project to be unchanged, got artifactId '%s'", got)
+	}
+}
+
+func TestAddBOM(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+
+	coords := pom.Coordinates{
+		GroupID:    "com.example",
+		ArtifactID: "test-app",
+		Version:    "1.0.0",
+	}
+	_ = presenter.CreateNewPOM(coords, "basic-java")
+
+	// A version-less dependency is invalid until something manages it
+	dep := pom.Dependency{GroupID: "org.springframework.boot", ArtifactID: "spring-boot-starter-web"}
+	if err := presenter.AddDependency(dep); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	result, _ := presenter.ValidateCurrent()
+	if result.Valid {
+		t.Error("Expected unmanaged version-less dependency to be invalid")
+	}
+
+	bom := pom.Coordinates{GroupID: "org.springframework.boot", ArtifactID: "spring-boot-dependencies", Version: "3.2.0"}
+	if err := presenter.AddBOM(bom); err != nil {
+		t.Fatalf("AddBOM failed: %v", err)
+	}
+	if presenter.UndoName() != "Add BOM" {
+		t.Errorf("Expected 'Add BOM' to undo, got '%s'", presenter.UndoName())
+	}
+
+	project := presenter.GetCurrentProject()
+	if len(project.DependencyManagement) != 1 || !project.DependencyManagement[0].IsBOM() {
+		t.Fatalf("Expected one imported BOM, got %+v", project.DependencyManagement)
+	}
+	result, _ = presenter.ValidateCurrent()
+	if !result.Valid {
+		t.Errorf("Expected dependency managed by the BOM to be valid, got %+v", result.Errors)
+	}
+
+	// The BOM survives a round trip through XML
+	data, err := pom.NewGenerator().Generate(project)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	reparsed, err := pom.NewParser().Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if boms := reparsed.BOMs(); len(boms) != 1 || boms[0].ArtifactID != "spring-boot-dependencies" {
+		t.Errorf("Expected BOM after round trip, got %+v", reparsed.DependencyManagement)
+	}
+
+	if err := presenter.AddBOM(pom.Coordinates{GroupID: "org.example"}); err == nil {
+		t.Error("Expected error for incomplete BOM coordinates")
+	}
+}
+
+func TestValidateManagedByParent(t *testing.T) {
+	dir := t.TempDir()
+	parentXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0.0</version>
+    <packaging>pom</packaging>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>org.slf4j</groupId>
+                <artifactId>slf4j-api</artifactId>
+                <version>2.0.9</version>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+</project>`
+	childXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0.0</version>
+    </parent>
+    <artifactId>core</artifactId>
+    <dependencies>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+        </dependency>
+    </dependencies>
+</project>`
+
+	childPath := filepath.Join(dir, "core", "pom.xml")
+	if err := os.MkdirAll(filepath.Dir(childPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pom.xml"), []byte(parentXML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(childPath, []byte(childXML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+
+	if err := presenter.LoadPOM(childPath); err != nil {
+		t.Fatalf("Failed to load child POM: %v", err)
+	}
+
+	// The parent manages the version, so the missing version is only a note
+	result, err := presenter.ValidateCurrent()
+	if err != nil {
+		t.Fatalf("ValidateCurrent failed: %v", err)
+	}
+	if !result.Valid {
+		t.Errorf("Expected child to be valid, got %v", result.Errors.AllErrors())
+	}
+	notes := result.Errors.BySeverity(pom.SeverityInfo)
+	if len(notes) != 1 || !strings.Contains(notes[0].Message, "2.0.9") {
+		t.Errorf("Expected a note naming the managed version, got %v", notes)
+	}
+
+	// Without the parent chain the version is missing
+	if pom.NewValidator().Validate(presenter.GetCurrentProject()).Valid {
+		t.Error("Expected missing version to be an error without the parent")
+	}
+}
+
+func TestValidateSeverity(t *testing.T) {
+	project := &pom.Project{
+		ModelVersion: "4.0.0",
+		GroupID:      "com.example",
+		ArtifactID:   "MyApp",
+		Version:      "1.0.0",
+		Packaging:    "jar",
+	}
+
+	result := pom.NewValidator().Validate(project)
+	if !result.Valid {
+		t.Errorf("Expected stylistic findings not to invalidate the POM, got %v", result.Errors.AllErrors())
+	}
+	if result.Errors.Count(pom.SeverityWarning) != 1 {
+		t.Errorf("Expected 1 warning for the uppercase artifactId, got %v", result.Errors.AllErrors())
+	}
+	if result.Errors.HasErrors() {
+		t.Error("Expected no error-severity findings")
+	}
+}
+
+func TestValidateRuleSettings(t *testing.T) {
+	dir := t.TempDir()
+	pomXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>com.Example</groupId>
+    <artifactId>MyApp</artifactId>
+    <version>1.0.0</version>
+</project>`
+	config := `validation:
+  rules:
+    groupid-format: off
+    artifactid-format: error
+`
+	path := filepath.Join(dir, "pom.xml")
+	if err := os.WriteFile(path, []byte(pomXML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, pom.ProjectConfigFile), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	if err := presenter.LoadPOM(path); err != nil {
+		t.Fatalf("Failed to load POM: %v", err)
+	}
+
+	result, err := presenter.ValidateCurrent()
+	if err != nil {
+		t.Fatalf("ValidateCurrent failed: %v", err)
+	}
+
+	// The groupId warning is disabled and the artifactId warning is an error
+	findings := result.Errors.AllErrors()
+	if len(findings) != 1 || findings[0].Rule != pom.RuleArtifactIDFormat {
+		t.Fatalf("Expected only the artifactId finding, got %v", findings)
+	}
+	if result.Valid {
+		t.Error("Expected the promoted artifactId rule to invalidate the POM")
+	}
+}
+
+func TestValidateUndefinedProperties(t *testing.T) {
+	dir := t.TempDir()
+	pomXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>com.example</groupId>
+    <artifactId>app</artifactId>
+    <version>1.0.0</version>
+    <properties>
+        <junit.version>4.13.2</junit.version>
+    </properties>
+    <dependencies>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>${junit.verison}</version>
+            <scope>test</scope>
+        </dependency>
+        <dependency>
+            <groupId>com.example</groupId>
+            <artifactId>client</artifactId>
+            <version>${deploy.version}</version>
+        </dependency>
+    </dependencies>
+</project>`
+	path := filepath.Join(dir, "pom.xml")
+	if err := os.WriteFile(path, []byte(pomXML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	if err := presenter.LoadPOM(path); err != nil {
+		t.Fatalf("Failed to load POM: %v", err)
+	}
+	auditLog := audit.NewLog(filepath.Join(t.TempDir(), audit.FileName))
+	presenter.SetRecorder(audit.NewRecorder(auditLog, audit.ToolGUI))
+
+	undefined := func() []pom.ValidationError {
+		result, err := presenter.ValidateCurrent()
+		if err != nil {
+			t.Fatalf("ValidateCurrent failed: %v", err)
+		}
+		var findings []pom.ValidationError
+		for _, finding := range result.Errors.AllErrors() {
+			if finding.Rule == pom.RuleUndefinedProperty {
+				findings = append(findings, finding)
+			}
+		}
+		return findings
+	}
+
+	findings := undefined()
+	if len(findings) != 2 {
+		t.Fatalf("Expected 2 undefined properties, got %v", findings)
+	}
+	if findings[0].Value != "${junit.verison}" || findings[0].Fix == nil || !findings[0].Position.IsKnown() {
+		t.Errorf("Expected a located, fixable finding for the misspelt reference, got %+v", findings[0])
+	}
+	if findings[1].Fix != nil {
+		t.Errorf("Expected no fix without a similar property, got %s", findings[1].Fix.Description)
+	}
+
+	// The fix points the reference at the defined property
+	if err := presenter.ApplyFix(findings[0]); err != nil {
+		t.Fatalf("ApplyFix failed: %v", err)
+	}
+	if got := presenter.GetCurrentProject().Dependencies[0].Version; got != "${junit.version}" {
+		t.Errorf("Expected the reference to be renamed, got %s", got)
+	}
+
+	// Properties supplied outside the POM are recorded in the project config
+	configPath, err := presenter.AddExternalProperty("deploy.version")
+	if err != nil {
+		t.Fatalf("AddExternalProperty failed: %v", err)
+	}
+	if configPath != filepath.Join(dir, pom.ProjectConfigFile) {
+		t.Errorf("Expected the config next to the POM, got %s", configPath)
+	}
+	if findings := undefined(); len(findings) != 0 {
+		t.Errorf("Expected no undefined properties left, got %v", findings)
+	}
+	config, err := pom.LoadProjectConfig(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := config.Validation.ExternalProperties; len(got) != 1 || got[0] != "deploy.version" {
+		t.Errorf("Expected deploy.version to be external, got %v", got)
+	}
+	entries, err := auditLog.Entries()
+	if err != nil || len(entries) != 1 || entries[0].Path != configPath || entries[0].Operation != "external property deploy.version" {
+		t.Errorf("Expected the config write in the audit log, got %+v, %v", entries, err)
+	}
+
+	presenter.SetForceReadOnly(true)
+	if _, err := presenter.AddExternalProperty("other.version"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly for a read-only document, got %v", err)
+	}
+}
+
+func TestValidatePositions(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	if err := presenter.CreateNewPOM(pom.Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "1.0.0"}, "basic-java"); err != nil {
+		t.Fatalf("CreateNewPOM failed: %v", err)
+	}
+	if err := presenter.AddDependency(pom.Dependency{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.9", Scope: "bogus"}); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	result, err := presenter.ValidateCurrent()
+	if err != nil {
+		t.Fatalf("ValidateCurrent failed: %v", err)
+	}
+	errors := result.Errors.BySeverity(pom.SeverityError)
+	if len(errors) != 1 || !errors[0].Position.IsKnown() {
+		t.Fatalf("Expected one located error, got %v", errors)
+	}
+
+	// The position refers to the generated XML shown in the source view
+	xmlData, err := pom.NewGenerator().Generate(presenter.GetCurrentProject())
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(xmlData), "\n")
+	if line := lines[errors[0].Position.Line-1]; !strings.Contains(line, "<scope>bogus</scope>") {
+		t.Errorf("Expected position to point at the scope element, got line %q", line)
+	}
+}
+
+func TestApplyFixes(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	if err := presenter.CreateNewPOM(pom.Coordinates{GroupID: "Com.Example", ArtifactID: "app", Version: "1.0.0"}, "basic-java"); err != nil {
+		t.Fatalf("CreateNewPOM failed: %v", err)
+	}
+	junit := pom.Dependency{GroupID: "junit", ArtifactID: "junit", Version: "4.13.2", Scope: "test"}
+	if err := presenter.AddDependency(junit); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	project := presenter.GetCurrentProject()
+	project.Dependencies = append(project.Dependencies, junit)
+
+	result, err := presenter.ValidateCurrent()
+	if err != nil {
+		t.Fatalf("ValidateCurrent failed: %v", err)
+	}
+	warnings := result.Errors.BySeverity(pom.SeverityWarning)
+	if len(warnings) != 1 || warnings[0].Fix == nil {
+		t.Fatalf("Expected one fixable warning, got %v", warnings)
+	}
+
+	// A single fix is one undoable edit
+	if err := presenter.ApplyFix(warnings[0]); err != nil {
+		t.Fatalf("ApplyFix failed: %v", err)
+	}
+	if got := presenter.GetCurrentProject().GroupID; got != "com.example" {
+		t.Errorf("Expected groupId 'com.example', got '%s'", got)
+	}
+	if err := presenter.ApplyFix(warnings[0]); !errors.Is(err, pom.ErrFixNotApplicable) {
+		t.Errorf("Expected ErrFixNotApplicable for a stale fix, got %v", err)
+	}
+
+	applied, err := presenter.ApplyAllFixes()
+	if err != nil {
+		t.Fatalf("ApplyAllFixes failed: %v", err)
+	}
+	if len(applied) != 1 {
+		t.Errorf("Expected the duplicate to be removed, got %v", applied)
+	}
+	if result, _ := presenter.ValidateCurrent(); !result.Valid {
+		t.Errorf("Expected a valid POM after fixing, got %v", result.Errors.AllErrors())
+	}
+
+	if err := presenter.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if got := len(presenter.GetCurrentProject().Dependencies); got != 2 {
+		t.Errorf("Expected undo to restore the duplicate, got %d dependencies", got)
+	}
+}
+
+func TestValidateImportAndSystemScope(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	if err := presenter.CreateNewPOM(pom.Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "1.0.0"}, "basic-java"); err != nil {
+		t.Fatalf("CreateNewPOM failed: %v", err)
+	}
+	bom := pom.Dependency{GroupID: "org.junit", ArtifactID: "junit-bom", Version: "5.10.1", Type: "pom", Scope: "import"}
+	tools := pom.Dependency{GroupID: "com.sun", ArtifactID: "tools", Version: "1.8", Scope: "system"}
+	for _, dep := range []pom.Dependency{bom, tools} {
+		if err := presenter.AddDependency(dep); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	result, err := presenter.ValidateCurrent()
+	if err != nil {
+		t.Fatalf("ValidateCurrent failed: %v", err)
+	}
+	rules := make(map[string]pom.ValidationError)
+	for _, finding := range result.Errors.BySeverity(pom.SeverityError) {
+		rules[finding.Rule] = finding
+	}
+	if len(rules) != 2 {
+		t.Fatalf("Expected import-scope and system-path errors, got %v", result.Errors.AllErrors())
+	}
+	if _, ok := rules[pom.RuleSystemPath]; !ok {
+		t.Errorf("Expected a system-path error for a system dependency without systemPath")
+	}
+
+	// The import moves to dependencyManagement
+	if err := presenter.ApplyFix(rules[pom.RuleImportScope]); err != nil {
+		t.Fatalf("ApplyFix failed: %v", err)
+	}
+	project := presenter.GetCurrentProject()
+	if len(project.Dependencies) != 1 || len(project.DependencyManagement) != 1 {
+		t.Errorf("Expected the BOM in dependencyManagement, got %d dependencies and %d managed",
+			len(project.Dependencies), len(project.DependencyManagement))
+	}
+}
+
+func TestDependencyClassifiers(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	if err := presenter.CreateNewPOM(pom.Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "1.0.0"}, "basic-java"); err != nil {
+		t.Fatalf("CreateNewPOM failed: %v", err)
+	}
+
+	// The test-jar of a module is a separate artifact from its main jar
+	core := pom.Dependency{GroupID: "com.example", ArtifactID: "core", Version: "1.0.0"}
+	coreTests := pom.Dependency{GroupID: "com.example", ArtifactID: "core", Version: "1.0.0", Type: "test-jar", Classifier: "tests", Scope: "test"}
+	for _, dep := range []pom.Dependency{core, coreTests} {
+		if err := presenter.AddDependency(dep); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	if got := len(presenter.GetCurrentProject().Dependencies); got != 2 {
+		t.Fatalf("Expected 2 dependencies, got %d", got)
+	}
+	if result, _ := presenter.ValidateCurrent(); !result.Valid {
+		t.Errorf("Expected no duplicate error, got %v", result.Errors.AllErrors())
+	}
+
+	optional := core
+	optional.Optional = true
+	optional.Classifier = "jdk8"
+	if err := presenter.UpdateDependency(core, optional); err != nil {
+		t.Fatalf("UpdateDependency failed: %v", err)
+	}
+	deps := presenter.GetCurrentProject().Dependencies
+	if len(deps) != 2 || !deps[0].Optional || deps[0].Classifier != "jdk8" {
+		t.Errorf("Expected the first dependency updated in place, got %+v", deps)
+	}
+
+	xmlData, err := pom.NewGenerator().Generate(presenter.GetCurrentProject())
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := pom.NewParser().Parse(xmlData)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if parsed.Dependencies[1].Classifier != "tests" || !parsed.Dependencies[0].Optional {
+		t.Errorf("Expected classifier and optional to round-trip, got %+v", parsed.Dependencies)
+	}
+}
+
+func TestReorderDependencies(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	if err := presenter.CreateNewPOM(pom.Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "1.0.0"}, "basic-java"); err != nil {
+		t.Fatalf("CreateNewPOM failed: %v", err)
+	}
+
+	for _, dep := range []pom.Dependency{
+		{GroupID: "org.junit.jupiter", ArtifactID: "junit-jupiter", Version: "5.10.1", Scope: "test"},
+		{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.9"},
+		{GroupID: "jakarta.servlet", ArtifactID: "jakarta.servlet-api", Version: "6.0.0", Scope: "provided"},
+		{GroupID: "com.google.guava", ArtifactID: "guava", Version: "32.1.3-jre", Scope: "compile"},
+	} {
+		if err := presenter.AddDependency(dep); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	artifactIDs := func() string {
+		var ids []string
+		for _, dep := range presenter.GetCurrentProject().Dependencies {
+			ids = append(ids, dep.ArtifactID)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	if err := presenter.MoveDependency(3, 0); err != nil {
+		t.Fatalf("MoveDependency failed: %v", err)
+	}
+	if got := artifactIDs(); got != "guava,junit-jupiter,slf4j-api,jakarta.servlet-api" {
+		t.Errorf("Expected guava moved to the top, got %s", got)
+	}
+	if err := presenter.MoveDependency(0, 4); err == nil {
+		t.Error("Expected error moving past the end")
+	}
+
+	if err := presenter.SortDependencies(); err != nil {
+		t.Fatalf("SortDependencies failed: %v", err)
+	}
+	want := "guava,slf4j-api,jakarta.servlet-api,junit-jupiter"
+	if got := artifactIDs(); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	// The generator keeps the order
+	xmlData, err := pom.NewGenerator().Generate(presenter.GetCurrentProject())
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	xml := string(xmlData)
+	if strings.Index(xml, "slf4j-api") > strings.Index(xml, "jakarta.servlet-api") {
+		t.Error("Expected dependencies written in sorted order")
+	}
+
+	if err := presenter.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if got := artifactIDs(); got != "guava,junit-jupiter,slf4j-api,jakarta.servlet-api" {
+		t.Errorf("Expected undo to restore the previous order, got %s", got)
+	}
+}
+
+func TestValidateParentPath(t *testing.T) {
+	dir := t.TempDir()
+	parentXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.1.0</version>
+    <packaging>pom</packaging>
+</project>`
+	// The parent was bumped to 1.1.0 without updating the child
+	childXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0.0</version>
+    </parent>
+    <artifactId>core</artifactId>
+</project>`
+
+	childPath := filepath.Join(dir, "core", "pom.xml")
+	if err := os.MkdirAll(filepath.Dir(childPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pom.xml"), []byte(parentXML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(childPath, []byte(childXML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	if err := presenter.LoadPOM(childPath); err != nil {
+		t.Fatalf("Failed to load child POM: %v", err)
+	}
+
+	findParentVersion := func() *pom.ValidationError {
+		result, err := presenter.ValidateCurrent()
+		if err != nil {
+			t.Fatalf("ValidateCurrent failed: %v", err)
+		}
+		for _, finding := range result.Errors.AllErrors() {
+			if finding.Rule == pom.RuleParentPath {
+				return &finding
+			}
+		}
+		return nil
+	}
+
+	finding := findParentVersion()
+	if finding == nil || finding.Field != "parent.version" || finding.Fix == nil {
+		t.Fatalf("Expected a parent version finding with a fix, got %+v", finding)
+	}
+	if err := presenter.ApplyFix(*finding); err != nil {
+		t.Fatalf("ApplyFix failed: %v", err)
+	}
+	project := presenter.GetCurrentProject()
+	if project.Parent.Version != "1.1.0" || project.Version != "1.1.0" {
+		t.Errorf("Expected parent and inherited version 1.1.0, got %s and %s", project.Parent.Version, project.Version)
+	}
+	if finding := findParentVersion(); finding != nil {
+		t.Errorf("Expected no parent finding after the fix, got %+v", finding)
+	}
+}
+
+func TestBulkDependencyOperations(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	if err := presenter.CreateNewPOM(pom.Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "1.0.0"}, "basic-java"); err != nil {
+		t.Fatalf("CreateNewPOM failed: %v", err)
+	}
+
+	junit := pom.Dependency{GroupID: "org.junit.jupiter", ArtifactID: "junit-jupiter", Version: "5.10.1"}
+	mockito := pom.Dependency{GroupID: "org.mockito", ArtifactID: "mockito-core", Version: "5.7.0"}
+	guava := pom.Dependency{GroupID: "com.google.guava", ArtifactID: "guava", Version: "32.1.3-jre"}
+	for _, dep := range []pom.Dependency{junit, mockito, guava} {
+		if err := presenter.AddDependency(dep); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	notifications := 0
+	presenter.SubscribeToChanges(func() {
+		notifications++
+	})
+
+	if err := presenter.SetDependenciesScope([]pom.Dependency{junit, mockito}, "test"); err != nil {
+		t.Fatalf("SetDependenciesScope failed: %v", err)
+	}
+	deps := presenter.GetCurrentProject().Dependencies
+	if deps[0].Scope != "test" || deps[1].Scope != "test" || deps[2].Scope != "" {
+		t.Errorf("Expected junit and mockito in test scope, got %+v", deps)
+	}
+	batched := notifications
+
+	// A batch notifies as often as a single edit
+	notifications = 0
+	if err := presenter.AddDependency(pom.Dependency{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.9"}); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if batched != notifications {
+		t.Errorf("Expected %d notifications for the batch, got %d", notifications, batched)
+	}
+
+	if err := presenter.SetDependenciesScope([]pom.Dependency{guava}, "bogus"); !errors.Is(err, pom.ErrInvalidScope) {
+		t.Errorf("Expected ErrInvalidScope, got %v", err)
+	}
+
+	if err := presenter.RemoveDependencies([]pom.Dependency{junit, mockito}); err != nil {
+		t.Fatalf("RemoveDependencies failed: %v", err)
+	}
+	deps = presenter.GetCurrentProject().Dependencies
+	if len(deps) != 2 || deps[0].ArtifactID != "guava" {
+		t.Errorf("Expected guava and slf4j-api left, got %+v", deps)
+	}
+	if err := presenter.RemoveDependencies([]pom.Dependency{junit}); !errors.Is(err, pom.ErrDependencyNotFound) {
+		t.Errorf("Expected ErrDependencyNotFound, got %v", err)
+	}
+
+	snippet, err := pom.DependencySnippet([]pom.Dependency{guava, {GroupID: "junit", ArtifactID: "junit", Version: "4.13.2", Scope: "test"}})
+	if err != nil {
+		t.Fatalf("DependencySnippet failed: %v", err)
+	}
+	if strings.Count(snippet, "<dependency>") != 2 || !strings.Contains(snippet, "<scope>test</scope>") {
+		t.Errorf("Expected two dependency elements, got %s", snippet)
+	}
+}
+
+func TestValidateManagedCollisions(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	if err := presenter.CreateNewPOM(pom.Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "1.0.0"}, "basic-java"); err != nil {
+		t.Fatalf("CreateNewPOM failed: %v", err)
+	}
+
+	project := presenter.GetCurrentProject()
+	project.DependencyManagement = []pom.Dependency{
+		{GroupID: "com.google.guava", ArtifactID: "guava", Version: "31.1-jre"},
+		pom.NewBOM("org.junit", "junit-bom", "5.9.3"),
+		{GroupID: "com.google.guava", ArtifactID: "guava", Version: "32.1.3-jre"},
+		pom.NewBOM("org.junit", "junit-bom", "5.10.1"),
+		pom.NewBOM("org.junit", "junit-bom", "5.9.3"),
+	}
+	if err := presenter.UpdateProject("Edit dependencyManagement", project); err != nil {
+		t.Fatalf("UpdateProject failed: %v", err)
+	}
+
+	result, err := presenter.ValidateCurrent()
+	if err != nil {
+		t.Fatalf("ValidateCurrent failed: %v", err)
+	}
+	findings := make(map[string]pom.ValidationError)
+	for _, finding := range result.Errors.AllErrors() {
+		if finding.Rule == pom.RuleManagedDuplicate {
+			findings[finding.Field] = finding
+		}
+	}
+	if len(findings) != 3 {
+		t.Fatalf("Expected 3 collisions, got %v", findings)
+	}
+	if msg := findings["dependencyManagement[2]"].Message; !strings.Contains(msg, "last declaration, version 32.1.3-jre") {
+		t.Errorf("Expected the last guava version to win, got %q", msg)
+	}
+	if msg := findings["dependencyManagement[3]"].Message; !strings.Contains(msg, "5.9.3") {
+		t.Errorf("Expected the first junit-bom import to win, got %q", msg)
+	}
+	if findings["dependencyManagement[4]"].Fix == nil {
+		t.Error("Expected a fix removing the repeated import")
+	}
+
+	if _, err := presenter.ApplyAllFixes(); err != nil {
+		t.Fatalf("ApplyAllFixes failed: %v", err)
+	}
+	if got := len(presenter.GetCurrentProject().DependencyManagement); got != 4 {
+		t.Errorf("Expected the identical import removed, got %d entries", got)
+	}
+}
+
+func TestInheritanceProvenance(t *testing.T) {
+	dir := t.TempDir()
+	rootXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>com.example</groupId>
+    <artifactId>root</artifactId>
+    <version>1.0.0</version>
+    <packaging>pom</packaging>
+    <properties>
+        <java.version>11</java.version>
+        <encoding>UTF-8</encoding>
+    </properties>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>junit</groupId>
+                <artifactId>junit</artifactId>
+                <version>4.12</version>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+</project>`
+	parentXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>root</artifactId>
+        <version>1.0.0</version>
+    </parent>
+    <artifactId>parent</artifactId>
+    <packaging>pom</packaging>
+    <properties>
+        <java.version>17</java.version>
+    </properties>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>junit</groupId>
+                <artifactId>junit</artifactId>
+                <version>4.13.2</version>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+</project>`
+	childXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+    <modelVersion>4.0.0</modelVersion>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>1.0.0</version>
+    </parent>
+    <artifactId>core</artifactId>
+    <properties>
+        <java.version>21</java.version>
+    </properties>
+</project>`
+
+	files := map[string]string{
+		"pom.xml":             rootXML,
+		"parent/pom.xml":      parentXML,
+		"parent/core/pom.xml": childXML,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	if err := presenter.LoadPOM(filepath.Join(dir, "parent", "core", "pom.xml")); err != nil {
+		t.Fatalf("Failed to load child POM: %v", err)
+	}
+
+	provenance := make(map[string]pom.Provenance)
+	for _, entry := range presenter.GetInheritance().Provenance {
+		provenance[entry.Kind+" "+entry.Name] = entry
+	}
+
+	javaVersion := provenance["property java.version"]
+	if javaVersion.Value != "21" || javaVersion.Inherited() || len(javaVersion.Overrides) != 2 {
+		t.Errorf("Expected java.version 21 from the child overriding 2 ancestors, got %+v", javaVersion)
+	}
+	if encoding := provenance["property encoding"]; encoding.Depth != 2 || encoding.Source != "com.example:root:1.0.0" {
+		t.Errorf("Expected encoding from the root POM, got %+v", encoding)
+	}
+	junit := provenance["managed junit:junit"]
+	if junit.Value != "4.13.2" || junit.Depth != 1 || len(junit.Overrides) != 1 || junit.Overrides[0].Value != "4.12" {
+		t.Errorf("Expected junit 4.13.2 from the parent overriding 4.12, got %+v", junit)
+	}
+}
+
+func TestPasteSnippet(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	if err := presenter.CreateNewPOM(pom.Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "1.0.0"}, "basic-java"); err != nil {
+		t.Fatalf("CreateNewPOM failed: %v", err)
+	}
+
+	// Two blocks as copied from library docs, plus a plugin without groupId
+	snippet, err := pom.ParseSnippet(`<?xml version="1.0"?>
+<dependency>
+    <groupId>com.google.guava</groupId>
+    <artifactId>guava</artifactId>
+    <version>32.1.3-jre</version>
+</dependency>
+<dependency>
+    <groupId>org.assertj</groupId>
+    <artifactId>assertj-core</artifactId>
+    <version>3.24.2</version>
+    <scope>test</scope>
+</dependency>
+<plugin>
+    <artifactId>maven-shade-plugin</artifactId>
+    <version>3.5.1</version>
+</plugin>
+<dependency>
+    <groupId>broken</groupId>
+</dependency>`)
+	if err != nil {
+		t.Fatalf("ParseSnippet failed: %v", err)
+	}
+	if len(snippet.Dependencies) != 2 || len(snippet.Plugins) != 1 || len(snippet.Warnings) != 1 {
+		t.Fatalf("Expected 2 dependencies, 1 plugin and 1 warning, got %+v", snippet)
+	}
+	if snippet.Plugins[0].GroupID != pom.DefaultPluginGroupID {
+		t.Errorf("Expected default plugin groupId, got %s", snippet.Plugins[0].GroupID)
+	}
+
+	project := presenter.GetCurrentProject().Clone()
+	added, updated := pom.MergeSnippet(project, snippet)
+	if len(added) != 3 || len(updated) != 0 {
+		t.Errorf("Expected 3 added, got %v added and %v updated", added, updated)
+	}
+	if err := presenter.UpdateProject("Paste XML", project); err != nil {
+		t.Fatalf("UpdateProject failed: %v", err)
+	}
+	if got := presenter.GetCurrentProject().Dependencies; len(got) != 2 || got[1].Scope != "test" {
+		t.Errorf("Expected the pasted dependencies, got %+v", got)
+	}
+
+	if _, err := pom.ParseSnippet("<dependency><groupId>"); !errors.Is(err, pom.ErrInvalidXML) {
+		t.Errorf("Expected ErrInvalidXML, got %v", err)
+	}
+}
+
+func TestMoveExecution(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	if err := presenter.CreateNewPOM(pom.Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "1.0.0"}, "basic-java"); err != nil {
+		t.Fatalf("CreateNewPOM failed: %v", err)
+	}
+
+	for _, plugin := range []pom.Plugin{
+		{GroupID: "org.example.plugins", ArtifactID: "first-maven-plugin", Version: "1.0", Executions: []pom.PluginExecution{
+			{ID: "a", Phase: "generate-sources", Goals: []string{"run"}},
+			{ID: "b", Phase: "generate-sources", Goals: []string{"run"}},
+		}},
+		{GroupID: "org.example.plugins", ArtifactID: "second-maven-plugin", Version: "1.0", Executions: []pom.PluginExecution{
+			{ID: "c", Phase: "generate-sources", Goals: []string{"run"}},
+		}},
+	} {
+		if err := presenter.AddPlugin(plugin); err != nil {
+			t.Fatalf("AddPlugin failed: %v", err)
+		}
+	}
+	executionIDs := func() string {
+		var ids []string
+		for _, exec := range pom.NewOrganizer().ExecutionsInPhase(presenter.GetCurrentProject(), "generate-sources") {
+			ids = append(ids, exec.Execution.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+	if got := executionIDs(); got != "a,b,c" {
+		t.Fatalf("Expected executions in declaration order, got %s", got)
+	}
+
+	// Within one plugin the executions swap
+	if err := presenter.MoveExecution("generate-sources", 1, 0); err != nil {
+		t.Fatalf("MoveExecution failed: %v", err)
+	}
+	if got := executionIDs(); got != "b,a,c" {
+		t.Errorf("Expected b,a,c, got %s", got)
+	}
+
+	// Across plugins the plugins are reordered
+	if err := presenter.MoveExecution("generate-sources", 2, 1); err != nil {
+		t.Fatalf("MoveExecution failed: %v", err)
+	}
+	if got := executionIDs(); got != "c,b,a" {
+		t.Errorf("Expected c,b,a, got %s", got)
+	}
+
+	xmlData, err := pom.NewGenerator().Generate(presenter.GetCurrentProject())
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	xml := string(xmlData)
+	if strings.Index(xml, "second-maven-plugin") > strings.Index(xml, "first-maven-plugin") {
+		t.Error("Expected the moved plugin written first")
+	}
+	if strings.Index(xml, "<id>b</id>") > strings.Index(xml, "<id>a</id>") {
+		t.Error("Expected execution b written before a")
+	}
+
+	if err := presenter.MoveExecution("generate-sources", 0, 2); err == nil {
+		t.Error("Expected error moving an execution more than one position")
+	}
+	if err := presenter.MoveExecution("generate-sources", 2, 3); err == nil {
+		t.Error("Expected error moving past the last execution")
+	}
+}
+
+func TestEditExecutions(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	if err := presenter.CreateNewPOM(pom.Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "1.0.0"}, "basic-java"); err != nil {
+		t.Fatalf("CreateNewPOM failed: %v", err)
+	}
+	for _, artifactID := range []string{"first-maven-plugin", "second-maven-plugin"} {
+		if err := presenter.AddPlugin(pom.Plugin{GroupID: "org.example.plugins", ArtifactID: artifactID, Version: "1.0"}); err != nil {
+			t.Fatalf("AddPlugin failed: %v", err)
+		}
+	}
+	first := len(presenter.GetCurrentProject().Build.Plugins) - 2
+	executions := func(pluginIndex int) string {
+		var ids []string
+		for _, exec := range presenter.GetCurrentProject().Build.Plugins[pluginIndex].Executions {
+			ids = append(ids, exec.ID+"@"+exec.Phase)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	for _, id := range []string{"a", "b"} {
+		if err := presenter.AddExecution(first, pom.PluginExecution{ID: id, Phase: "compile", Goals: []string{"run"}}); err != nil {
+			t.Fatalf("AddExecution failed: %v", err)
+		}
+	}
+	if err := presenter.AddExecution(first, pom.PluginExecution{ID: "a", Phase: "test"}); err == nil {
+		t.Error("Expected error adding a second execution a")
+	}
+	if presenter.UndoName() != "Add Execution" {
+		t.Errorf("Expected undo of Add Execution, got %q", presenter.UndoName())
+	}
+
+	// Editing in place keeps the position
+	if err := presenter.UpdateExecution(first, 0, first, pom.PluginExecution{ID: "a", Phase: "test", Goals: []string{"run"}}); err != nil {
+		t.Fatalf("UpdateExecution failed: %v", err)
+	}
+	if got := executions(first); got != "a@test,b@compile" {
+		t.Errorf("Expected a@test,b@compile, got %s", got)
+	}
+	if err := presenter.UpdateExecution(first, 0, first, pom.PluginExecution{ID: "b"}); err == nil {
+		t.Error("Expected error renaming a to an existing execution")
+	}
+
+	// Editing the plugin moves the execution
+	if err := presenter.UpdateExecution(first, 1, first+1, pom.PluginExecution{ID: "b", Phase: "compile"}); err != nil {
+		t.Fatalf("UpdateExecution failed: %v", err)
+	}
+	if got := executions(first) + " " + executions(first+1); got != "a@test b@compile" {
+		t.Errorf("Expected b moved to the second plugin, got %s", got)
+	}
+
+	if err := presenter.RemoveExecution(first, 0); err != nil {
+		t.Fatalf("RemoveExecution failed: %v", err)
+	}
+	if got := executions(first); got != "" {
+		t.Errorf("Expected no executions left, got %s", got)
+	}
+	if presenter.UndoName() != "Remove Execution" {
+		t.Errorf("Expected undo of Remove Execution, got %q", presenter.UndoName())
+	}
+	if err := presenter.RemoveExecution(first, 0); err == nil {
+		t.Error("Expected error removing a missing execution")
+	}
+
+	if err := presenter.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if got := executions(first); got != "a@test" {
+		t.Errorf("Expected undo to restore a@test, got %s", got)
+	}
+}
+
+func TestSkipFlags(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	if err := presenter.CreateNewPOM(pom.Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "1.0.0"}, "basic-java"); err != nil {
+		t.Fatalf("CreateNewPOM failed: %v", err)
+	}
+
+	flags := pom.SkipFlags(pom.Plugin{GroupID: "org.apache.maven.plugins", ArtifactID: "maven-surefire-plugin"})
+	if len(flags) != 2 || flags[0].Property != "skipTests" || flags[1].Property != "maven.test.skip" {
+		t.Errorf("Expected skipTests and maven.test.skip for Surefire, got %v", flags)
+	}
+	if pom.SkipFlags(pom.Plugin{GroupID: "com.example", ArtifactID: "custom-maven-plugin"}) != nil {
+		t.Error("Expected no skip flags for an unknown plugin")
+	}
+
+	if err := presenter.SetSkipFlags("", map[string]bool{"maven.javadoc.skip": true}); err != nil {
+		t.Fatalf("SetSkipFlags failed: %v", err)
+	}
+	if got := presenter.GetCurrentProject().Properties["maven.javadoc.skip"]; got != "true" {
+		t.Errorf("Expected maven.javadoc.skip=true in the properties, got %q", got)
+	}
+
+	if err := presenter.SetSkipFlags("fast", map[string]bool{"skipTests": true, "maven.test.skip": false}); err != nil {
+		t.Fatalf("SetSkipFlags failed: %v", err)
+	}
+	project := presenter.GetCurrentProject()
+	if len(project.Profiles) != 1 || project.Profiles[0].ID != "fast" {
+		t.Fatalf("Expected profile fast to be added, got %v", project.Profiles)
+	}
+	if _, ok := project.Profiles[0].Properties["maven.test.skip"]; ok {
+		t.Error("Expected cleared flag not to be written")
+	}
+
+	// The profile survives a save and reload
+	xmlData, err := pom.NewGenerator().Generate(project)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	reparsed, err := pom.NewParser().Parse(xmlData)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if !pom.SkipEnabled(reparsed, "fast", "skipTests") || pom.SkipEnabled(reparsed, "", "skipTests") {
+		t.Errorf("Expected skipTests only in profile fast:\n%s", xmlData)
+	}
+
+	if err := presenter.SetSkipFlags("fast", map[string]bool{"skipTests": false}); err != nil {
+		t.Fatalf("SetSkipFlags failed: %v", err)
+	}
+	if pom.SkipEnabled(presenter.GetCurrentProject(), "fast", "skipTests") {
+		t.Error("Expected skipTests to be cleared")
+	}
+}
+
+func TestCreateJavaCardPOM(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	coords := pom.Coordinates{GroupID: "com.example.card", ArtifactID: "wallet-applet", Version: "1.0.0"}
+
+	defaults := pom.DefaultJavaCardApplet(coords)
+	if err := defaults.Validate(); err != nil {
+		t.Errorf("Expected default applet to be valid, got %v", err)
+	}
+	if defaults.Class != "com.example.card.WalletAppletApplet" {
+		t.Errorf("Expected class derived from the coordinates, got %s", defaults.Class)
+	}
+
+	applet := pom.JavaCardApplet{Class: "com.example.card.Wallet", PackageAID: "a000000062030108", AppletAID: "A00000006203010801"}
+	if err := presenter.CreateJavaCardPOM(coords, applet); err != nil {
+		t.Fatalf("CreateJavaCardPOM failed: %v", err)
+	}
+	project := presenter.GetCurrentProject()
+	if got := project.Properties[pom.JavaCardPackageAIDProperty]; got != "A000000062030108" {
+		t.Errorf("Expected upper-case package AID, got %s", got)
+	}
+	if got := project.Properties[pom.JavaCardAppletClassProperty]; got != "com.example.card.Wallet" {
+		t.Errorf("Expected applet class property, got %s", got)
+	}
+
+	xmlData, err := pom.NewGenerator().Generate(project)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	for _, expected := range []string{"<id>verify-cap</id>", "<id>gp-deploy</id>", "<artifactId>exec-maven-plugin</artifactId>"} {
+		if !strings.Contains(string(xmlData), expected) {
+			t.Errorf("Expected POM to contain %s", expected)
+		}
+	}
+
+	for _, invalid := range []pom.JavaCardApplet{
+		{Class: "Wallet", PackageAID: "A0000000", AppletAID: "A000000062030108"},
+		{Class: "Wallet", PackageAID: "A000000062030108", AppletAID: "B00000006203010801"},
+		{Class: "Wallet", PackageAID: "A000000062030108", AppletAID: "A000000062030108"},
+	} {
+		if err := presenter.CreateJavaCardPOM(coords, invalid); !errors.Is(err, pom.ErrInvalidAID) {
+			t.Errorf("Expected ErrInvalidAID for %+v, got %v", invalid, err)
+		}
+	}
+}
+
+func TestSessionChangelog(t *testing.T) {
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		state.NewAppState(),
+	)
+	if err := presenter.CreateNewPOM(pom.Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "1.0.0"}, "basic-java"); err != nil {
+		t.Fatalf("CreateNewPOM failed: %v", err)
+	}
+	if got := presenter.SessionChangelog(); got != "Create com.example:app" {
+		t.Errorf("Expected a new project to be described as created, got %q", got)
+	}
+
+	path := filepath.Join(t.TempDir(), "pom.xml")
+	if err := presenter.SavePOM(path); err != nil {
+		t.Fatalf("SavePOM failed: %v", err)
+	}
+	if err := presenter.LoadPOM(path); err != nil {
+		t.Fatalf("LoadPOM failed: %v", err)
+	}
+	if got := presenter.SessionChangelog(); got != "" {
+		t.Errorf("Expected no changes right after loading, got %q", got)
+	}
+
+	if err := presenter.AddDependency(pom.Dependency{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.9"}); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if got := presenter.SessionChangelog(); got != "Add slf4j-api 2.0.9" {
+		t.Errorf("Expected the added dependency, got %q", got)
+	}
+
+	presenter.MarkCommitted()
+	if got := presenter.SessionChangelog(); got != "" {
+		t.Errorf("Expected no changes after committing, got %q", got)
+	}
+}
+
+func TestDocuments(t *testing.T) {
+	appState := state.NewAppState()
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		appState,
+	)
+	if err := presenter.CreateNewPOM(pom.Coordinates{GroupID: "com.example", ArtifactID: "first", Version: "1.0.0"}, "basic-java"); err != nil {
+		t.Fatalf("CreateNewPOM failed: %v", err)
+	}
+	if err := presenter.AddDependency(pom.Dependency{GroupID: "org.example", ArtifactID: "lib", Version: "1.0"}); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	presenter.NewDocument()
+	if presenter.GetCurrentProject() != nil || presenter.UndoName() != "" {
+		t.Fatalf("Expected an empty document without history, got undo %q", presenter.UndoName())
+	}
+	if err := presenter.CreateNewPOM(pom.Coordinates{GroupID: "com.example", ArtifactID: "second", Version: "1.0.0"}, "basic-java"); err != nil {
+		t.Fatalf("CreateNewPOM failed: %v", err)
+	}
+
+	// Each document keeps its own history
+	if err := presenter.SwitchDocument(0); err != nil {
+		t.Fatalf("SwitchDocument failed: %v", err)
+	}
+	if presenter.GetCurrentProject().ArtifactID != "first" || presenter.UndoName() != "Add Dependency" {
+		t.Errorf("Expected first with undo of Add Dependency, got %s and %q", presenter.GetCurrentProject().ArtifactID, presenter.UndoName())
+	}
+	if err := presenter.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if err := presenter.SwitchDocument(5); !errors.Is(err, state.ErrNoDocument) {
+		t.Errorf("Expected ErrNoDocument, got %v", err)
+	}
+
+	// Closing the active document activates the next one with its history
+	if err := presenter.CloseDocument(0); err != nil {
+		t.Fatalf("CloseDocument failed: %v", err)
+	}
+	if presenter.GetCurrentProject().ArtifactID != "second" || presenter.RedoName() != "" {
+		t.Errorf("Expected second without redo, got %s and %q", presenter.GetCurrentProject().ArtifactID, presenter.RedoName())
+	}
+	if len(appState.Documents()) != 1 {
+		t.Errorf("Expected 1 document left, got %d", len(appState.Documents()))
+	}
+}
+
+func TestSaveScratchBuffers(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	appState := state.NewAppState()
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		appState,
+	)
+	scratchPath, err := presenter.CreateScratchPOM("basic-java")
+	if err != nil {
+		t.Fatalf("CreateScratchPOM failed: %v", err)
+	}
+	if err := presenter.AddDependency(pom.Dependency{GroupID: "org.example", ArtifactID: "scratch-lib", Version: "1.0"}); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	presenter.NewDocument()
+	if err := presenter.CreateNewPOM(pom.Coordinates{GroupID: "com.example", ArtifactID: "untitled", Version: "1.0.0"}, "basic-java"); err != nil {
+		t.Fatalf("CreateNewPOM failed: %v", err)
+	}
+
+	// The scratch buffer in the other tab is saved; the untitled POM is not
+	if err := presenter.SaveScratchBuffers(); err != nil {
+		t.Fatalf("SaveScratchBuffers failed: %v", err)
+	}
+	docs := appState.Documents()
+	if docs[0].Dirty || !docs[1].Dirty {
+		t.Errorf("Expected only the scratch buffer saved, got dirty %v and %v", docs[0].Dirty, docs[1].Dirty)
+	}
+	if appState.ActiveDocument() != 1 || presenter.GetCurrentProject().ArtifactID != "untitled" {
+		t.Errorf("Expected the untitled POM to stay active, got document %d", appState.ActiveDocument())
+	}
+	data, err := os.ReadFile(scratchPath)
+	if err != nil {
+		t.Fatalf("Failed to read scratch buffer: %v", err)
+	}
+	if !strings.Contains(string(data), "scratch-lib") {
+		t.Error("Expected the scratch buffer's edits on disk")
+	}
+}
+
+func TestDiskChanges(t *testing.T) {
+	appState := state.NewAppState()
+	presenter := NewMainPresenter(
+		pom.NewParser(),
+		pom.NewGenerator(),
+		pom.NewValidator(),
+		pom.NewRepository(),
+		pom.NewTemplateManager(),
+		appState,
+	)
+	if err := presenter.CreateNewPOM(pom.Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "1.0.0"}, "basic-java"); err != nil {
+		t.Fatalf("CreateNewPOM failed: %v", err)
+	}
+	if change, err := presenter.CheckDiskChanges(); change != nil || err != nil {
+		t.Errorf("Expected no disk changes for a new POM, got %v, %v", change, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "pom.xml")
+	if err := presenter.SavePOM(path); err != nil {
+		t.Fatalf("SavePOM failed: %v", err)
+	}
+	// Our own save is not a change made by another program
+	if change, err := presenter.CheckDiskChanges(); change != nil || err != nil {
+		t.Errorf("Expected no disk changes after saving, got %v, %v", change, err)
+	}
+
+	// Another program sets the version while the same one is edited here
+	if err := presenter.UpdateCoordinates(pom.Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "1.1.0"}); err != nil {
+		t.Fatalf("UpdateCoordinates failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	external := strings.Replace(string(data), "<version>1.0.0</version>", "<version>2.0.0</version>", 1)
+	if err := os.WriteFile(path, []byte(external), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	change, err := presenter.CheckDiskChanges()
+	if err != nil || change == nil {
+		t.Fatalf("Expected a disk change, got %v, %v", change, err)
+	}
+	if len(change.Merge.Theirs) != 1 || len(change.Merge.Conflicts) != 1 || change.Merge.Conflicts[0] != "version" {
+		t.Errorf("Expected the version to conflict, got %+v", change.Merge)
+	}
+
+	// Keeping the local edits leaves them unsaved and settles the change
+	presenter.KeepLocalChanges(change)
+	if !appState.IsDirty() || presenter.GetCurrentProject().Version != "1.1.0" {
+		t.Errorf("Expected the unsaved local version, got %s", presenter.GetCurrentProject().Version)
+	}
+	if change, err := presenter.CheckDiskChanges(); change != nil || err != nil {
+		t.Errorf("Expected the kept change not to be reported again, got %v, %v", change, err)
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := presenter.CheckDiskChanges(); !errors.Is(err, pom.ErrFileNotFound) {
+		t.Errorf("Expected ErrFileNotFound for a removed file, got %v", err)
+	}
+}
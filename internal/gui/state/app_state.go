@@ -7,11 +7,12 @@ import (
 )
 
 // AppState manages the application's global state with thread-safe access
-// and observer pattern for reactive UI updates
+// and observer pattern for reactive UI updates. The project, file path,
+// dirty and read-only flags are those of the active document.
 type AppState struct {
-	currentProject *pom.Project  // Current loaded/edited POM
-	filePath       string         // Path to current file
-	isDirty        bool           // Unsaved changes flag
+	documents      []*Document    // Open documents, in tab order
+	active         int            // Index of the active document
+	workspaceRoot  string         // Root directory of the open workspace
 	settings       *Settings      // User preferences
 	observers      []func()       // Observer callbacks
 	mutex          sync.RWMutex   // Thread-safe access
@@ -20,9 +21,7 @@ type AppState struct {
 // NewAppState creates a new AppState with default settings
 func NewAppState() *AppState {
 	return &AppState{
-		currentProject: nil,
-		filePath:       "",
-		isDirty:        false,
+		documents:      []*Document{{}},
 		settings:       NewSettings(),
 		observers:      make([]func(), 0),
 	}
@@ -32,13 +31,13 @@ func NewAppState() *AppState {
 func (s *AppState) GetCurrentProject() *pom.Project {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
-	return s.currentProject
+	return s.documents[s.active].project
 }
 
 // SetCurrentProject sets the current project and notifies observers
 func (s *AppState) SetCurrentProject(project *pom.Project) {
 	s.mutex.Lock()
-	s.currentProject = project
+	s.documents[s.active].project = project
 	s.mutex.Unlock()
 	s.Notify()
 }
@@ -47,13 +46,13 @@ func (s *AppState) SetCurrentProject(project *pom.Project) {
 func (s *AppState) GetFilePath() string {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
-	return s.filePath
+	return s.documents[s.active].filePath
 }
 
 // SetFilePath sets the file path and notifies observers
 func (s *AppState) SetFilePath(path string) {
 	s.mutex.Lock()
-	s.filePath = path
+	s.documents[s.active].filePath = path
 	s.mutex.Unlock()
 	s.Notify()
 }
@@ -62,13 +61,43 @@ func (s *AppState) SetFilePath(path string) {
 func (s *AppState) IsDirty() bool {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
-	return s.isDirty
+	return s.documents[s.active].isDirty
 }
 
 // SetDirty sets the dirty flag and notifies observers
 func (s *AppState) SetDirty(dirty bool) {
 	s.mutex.Lock()
-	s.isDirty = dirty
+	s.documents[s.active].isDirty = dirty
+	s.mutex.Unlock()
+	s.Notify()
+}
+
+// IsReadOnly returns the read-only flag (thread-safe read)
+func (s *AppState) IsReadOnly() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.documents[s.active].readOnly
+}
+
+// SetReadOnly sets the read-only flag and notifies observers
+func (s *AppState) SetReadOnly(readOnly bool) {
+	s.mutex.Lock()
+	s.documents[s.active].readOnly = readOnly
+	s.mutex.Unlock()
+	s.Notify()
+}
+
+// GetWorkspaceRoot returns the workspace root directory (thread-safe read)
+func (s *AppState) GetWorkspaceRoot() string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.workspaceRoot
+}
+
+// SetWorkspaceRoot sets the workspace root directory and notifies observers
+func (s *AppState) SetWorkspaceRoot(root string) {
+	s.mutex.Lock()
+	s.workspaceRoot = root
 	s.mutex.Unlock()
 	s.Notify()
 }
@@ -134,8 +163,8 @@ func (s *AppState) Notify() {
 // Useful for batching multiple changes before notifying observers
 func (s *AppState) UpdateProject(updater func(*pom.Project)) {
 	s.mutex.Lock()
-	if s.currentProject != nil {
-		updater(s.currentProject)
+	if project := s.documents[s.active].project; project != nil {
+		updater(project)
 	}
 	s.mutex.Unlock()
 }
@@ -0,0 +1,120 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// autosaveDirName is the subdirectory of the config dir that holds the
+// recovery copy of the document being edited
+const autosaveDirName = "autosave"
+
+const (
+	recoveryDataFile = "recovery.xml"
+	recoveryInfoFile = "recovery.yaml"
+)
+
+// Recovery is an auto-saved copy of a document with unsaved changes. It is
+// removed on a clean exit, so finding one on startup means the last session
+// ended unexpectedly.
+type Recovery struct {
+	FilePath string    `yaml:"file_path"` // Original file path ("" for an untitled POM)
+	Saved    time.Time `yaml:"saved"`     // Time of the last auto-save
+	Data     []byte    `yaml:"-"`         // Generated POM XML
+}
+
+// GetAutosaveDir returns the auto-save directory (autosave in the config directory)
+func GetAutosaveDir() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, autosaveDirName), nil
+}
+
+// WriteRecovery stores the document's XML as the recovery copy
+func WriteRecovery(filePath string, data []byte) error {
+	autosaveDir, err := GetAutosaveDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(autosaveDir, 0755); err != nil {
+		return fmt.Errorf("failed to create autosave directory: %w", err)
+	}
+
+	info, err := yaml.Marshal(&Recovery{FilePath: filePath, Saved: time.Now()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal recovery info: %w", err)
+	}
+
+	// Write the data before the info file, so an info file always has data
+	if err := writeFileAtomic(filepath.Join(autosaveDir, recoveryDataFile), data); err != nil {
+		return fmt.Errorf("failed to write recovery file: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(autosaveDir, recoveryInfoFile), info); err != nil {
+		return fmt.Errorf("failed to write recovery file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadRecovery returns the recovery copy left by a previous session, or nil
+// when there is none
+func LoadRecovery() (*Recovery, error) {
+	autosaveDir, err := GetAutosaveDir()
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.ReadFile(filepath.Join(autosaveDir, recoveryInfoFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read recovery info: %w", err)
+	}
+
+	var recovery Recovery
+	if err := yaml.Unmarshal(info, &recovery); err != nil {
+		return nil, fmt.Errorf("failed to parse recovery info: %w", err)
+	}
+
+	recovery.Data, err = os.ReadFile(filepath.Join(autosaveDir, recoveryDataFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recovery file: %w", err)
+	}
+
+	return &recovery, nil
+}
+
+// ClearRecovery removes the recovery copy
+func ClearRecovery() error {
+	autosaveDir, err := GetAutosaveDir()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{recoveryInfoFile, recoveryDataFile} {
+		if err := os.Remove(filepath.Join(autosaveDir, name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove recovery file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file and renames it into place,
+// so a crash mid-write never leaves a truncated file behind
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,56 @@
+package state
+
+import (
+	"testing"
+)
+
+func TestRecovery(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	// Nothing to recover initially
+	recovery, err := LoadRecovery()
+	if err != nil {
+		t.Fatalf("LoadRecovery failed: %v", err)
+	}
+	if recovery != nil {
+		t.Fatalf("Expected no recovery, got %+v", recovery)
+	}
+
+	if err := WriteRecovery("/tmp/project/pom.xml", []byte("<project/>")); err != nil {
+		t.Fatalf("WriteRecovery failed: %v", err)
+	}
+
+	recovery, err = LoadRecovery()
+	if err != nil {
+		t.Fatalf("LoadRecovery failed: %v", err)
+	}
+	if recovery == nil {
+		t.Fatal("Expected a recovery after WriteRecovery")
+	}
+	if recovery.FilePath != "/tmp/project/pom.xml" {
+		t.Errorf("Expected file path '/tmp/project/pom.xml', got '%s'", recovery.FilePath)
+	}
+	if string(recovery.Data) != "<project/>" {
+		t.Errorf("Expected recovered data '<project/>', got '%s'", recovery.Data)
+	}
+	if recovery.Saved.IsZero() {
+		t.Error("Expected saved time to be set")
+	}
+
+	if err := ClearRecovery(); err != nil {
+		t.Fatalf("ClearRecovery failed: %v", err)
+	}
+	recovery, err = LoadRecovery()
+	if err != nil {
+		t.Fatalf("LoadRecovery failed: %v", err)
+	}
+	if recovery != nil {
+		t.Errorf("Expected no recovery after ClearRecovery, got %+v", recovery)
+	}
+
+	// Clearing twice is not an error
+	if err := ClearRecovery(); err != nil {
+		t.Errorf("Expected ClearRecovery to be idempotent, got %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Bookmark kinds
+const (
+	BookmarkDependency = "dependency"
+	BookmarkPlugin     = "plugin"
+)
+
+// Bookmark marks a dependency or plugin of a POM for later review.
+// Bookmarks are stored in the user's config dir, never in the POM itself.
+type Bookmark struct {
+	Path       string    `yaml:"path"`        // Absolute path of the POM file
+	Kind       string    `yaml:"kind"`        // "dependency" | "plugin"
+	GroupID    string    `yaml:"group_id"`    // Element groupId
+	ArtifactID string    `yaml:"artifact_id"` // Element artifactId
+	Note       string    `yaml:"note"`        // Free-form annotation
+	Created    time.Time `yaml:"created"`     // When the bookmark was added
+}
+
+// Key returns the groupId:artifactId of the bookmarked element
+func (b Bookmark) Key() string {
+	return b.GroupID + ":" + b.ArtifactID
+}
+
+// Bookmarks is the persisted list of bookmarks across all workspaces
+type Bookmarks struct {
+	Items []Bookmark `yaml:"bookmarks"`
+}
+
+// GetBookmarksFilePath returns the full path to the bookmarks file
+func GetBookmarksFilePath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "bookmarks.yaml"), nil
+}
+
+// LoadBookmarks loads bookmarks from the bookmarks file
+// If the file doesn't exist, returns an empty list
+func LoadBookmarks() (*Bookmarks, error) {
+	bookmarksPath, err := GetBookmarksFilePath()
+	if err != nil {
+		return &Bookmarks{}, fmt.Errorf("failed to get bookmarks path: %w", err)
+	}
+
+	data, err := os.ReadFile(bookmarksPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Bookmarks{}, nil
+		}
+		return &Bookmarks{}, fmt.Errorf("failed to read bookmarks file: %w", err)
+	}
+
+	var bookmarks Bookmarks
+	if err := yaml.Unmarshal(data, &bookmarks); err != nil {
+		return &Bookmarks{}, fmt.Errorf("failed to parse bookmarks file: %w", err)
+	}
+
+	return &bookmarks, nil
+}
+
+// SaveBookmarks saves bookmarks to the bookmarks file
+func SaveBookmarks(bookmarks *Bookmarks) error {
+	bookmarksPath, err := GetBookmarksFilePath()
+	if err != nil {
+		return fmt.Errorf("failed to get bookmarks path: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(bookmarksPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(bookmarks)
+	if err != nil {
+		return fmt.Errorf("failed to marshal bookmarks: %w", err)
+	}
+
+	if err := os.WriteFile(bookmarksPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write bookmarks file: %w", err)
+	}
+
+	return nil
+}
+
+// Find returns the index of the bookmark for an element, or -1
+func (b *Bookmarks) Find(path, kind, groupID, artifactID string) int {
+	for i, item := range b.Items {
+		if item.Path == path && item.Kind == kind && item.GroupID == groupID && item.ArtifactID == artifactID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Set adds a bookmark, or updates the note of an existing one
+func (b *Bookmarks) Set(bookmark Bookmark) {
+	if i := b.Find(bookmark.Path, bookmark.Kind, bookmark.GroupID, bookmark.ArtifactID); i >= 0 {
+		b.Items[i].Note = bookmark.Note
+		return
+	}
+
+	if bookmark.Created.IsZero() {
+		bookmark.Created = time.Now()
+	}
+	b.Items = append(b.Items, bookmark)
+}
+
+// Remove deletes the bookmark for an element, reporting whether one existed
+func (b *Bookmarks) Remove(path, kind, groupID, artifactID string) bool {
+	i := b.Find(path, kind, groupID, artifactID)
+	if i < 0 {
+		return false
+	}
+	b.Items = append(b.Items[:i], b.Items[i+1:]...)
+	return true
+}
+
+// InDirectory returns the bookmarks whose POM lies under root (all when root is empty)
+func (b *Bookmarks) InDirectory(root string) []Bookmark {
+	if root == "" {
+		return append([]Bookmark(nil), b.Items...)
+	}
+
+	var result []Bookmark
+	for _, item := range b.Items {
+		rel, err := filepath.Rel(root, item.Path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
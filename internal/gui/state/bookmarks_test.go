@@ -0,0 +1,61 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBookmarks(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	// No bookmarks file yet
+	bookmarks, err := LoadBookmarks()
+	if err != nil {
+		t.Fatalf("LoadBookmarks failed: %v", err)
+	}
+	if len(bookmarks.Items) != 0 {
+		t.Errorf("Expected no bookmarks, got %d", len(bookmarks.Items))
+	}
+
+	root := t.TempDir()
+	corePOM := filepath.Join(root, "core", "pom.xml")
+	otherPOM := filepath.Join(t.TempDir(), "pom.xml")
+
+	bookmarks.Set(Bookmark{Path: corePOM, Kind: BookmarkDependency, GroupID: "junit", ArtifactID: "junit", Note: "upgrade to 5"})
+	bookmarks.Set(Bookmark{Path: otherPOM, Kind: BookmarkPlugin, GroupID: "org.apache.maven.plugins", ArtifactID: "maven-jar-plugin"})
+
+	// Setting an existing bookmark only updates its note
+	bookmarks.Set(Bookmark{Path: corePOM, Kind: BookmarkDependency, GroupID: "junit", ArtifactID: "junit", Note: "migrate to jupiter"})
+	if len(bookmarks.Items) != 2 {
+		t.Fatalf("Expected 2 bookmarks, got %d", len(bookmarks.Items))
+	}
+
+	if err := SaveBookmarks(bookmarks); err != nil {
+		t.Fatalf("SaveBookmarks failed: %v", err)
+	}
+
+	loaded, err := LoadBookmarks()
+	if err != nil {
+		t.Fatalf("LoadBookmarks failed: %v", err)
+	}
+	if len(loaded.Items) != 2 {
+		t.Fatalf("Expected 2 loaded bookmarks, got %d", len(loaded.Items))
+	}
+	if loaded.Items[0].Note != "migrate to jupiter" {
+		t.Errorf("Expected updated note, got '%s'", loaded.Items[0].Note)
+	}
+
+	// Only bookmarks inside the workspace are listed for it
+	inWorkspace := loaded.InDirectory(root)
+	if len(inWorkspace) != 1 || inWorkspace[0].Key() != "junit:junit" {
+		t.Errorf("Expected only the junit bookmark in workspace, got %v", inWorkspace)
+	}
+
+	if !loaded.Remove(corePOM, BookmarkDependency, "junit", "junit") {
+		t.Error("Expected Remove to find the bookmark")
+	}
+	if loaded.Remove(corePOM, BookmarkDependency, "junit", "junit") {
+		t.Error("Expected second Remove to report nothing removed")
+	}
+}
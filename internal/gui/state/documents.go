@@ -0,0 +1,131 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+// ErrNoDocument is returned for a document index out of range
+var ErrNoDocument = errors.New("no such document")
+
+// Document is a POM open in its own tab. Its fields are read and changed
+// through AppState while it is the active document.
+type Document struct {
+	project    *pom.Project
+	filePath   string
+	isDirty    bool
+	readOnly   bool
+	errorCount int // Validation errors when last checked
+}
+
+// DocumentInfo describes an open document, for listing it in tabs
+type DocumentInfo struct {
+	FilePath   string
+	Dirty      bool
+	ReadOnly   bool
+	ErrorCount int
+	Empty      bool // Nothing loaded yet
+}
+
+// Documents describes the open documents, in tab order
+func (s *AppState) Documents() []DocumentInfo {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	infos := make([]DocumentInfo, len(s.documents))
+	for i, doc := range s.documents {
+		infos[i] = DocumentInfo{
+			FilePath:   doc.filePath,
+			Dirty:      doc.isDirty,
+			ReadOnly:   doc.readOnly,
+			ErrorCount: doc.errorCount,
+			Empty:      doc.project == nil,
+		}
+	}
+	return infos
+}
+
+// ActiveDocument returns the index of the active document
+func (s *AppState) ActiveDocument() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.active
+}
+
+// Document returns the document at index, which identifies it across
+// changes to the tab order, or nil when there is none
+func (s *AppState) Document(index int) *Document {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if index < 0 || index >= len(s.documents) {
+		return nil
+	}
+	return s.documents[index]
+}
+
+// FindDocument returns the index of the document open from path, or -1
+func (s *AppState) FindDocument(path string) int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for i, doc := range s.documents {
+		if path != "" && doc.filePath == path {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddDocument opens an empty document after the others, makes it active,
+// and notifies observers
+func (s *AppState) AddDocument() {
+	s.mutex.Lock()
+	s.documents = append(s.documents, &Document{})
+	s.active = len(s.documents) - 1
+	s.mutex.Unlock()
+	s.Notify()
+}
+
+// ActivateDocument makes the document at index active and notifies
+// observers
+func (s *AppState) ActivateDocument(index int) error {
+	s.mutex.Lock()
+	if index < 0 || index >= len(s.documents) {
+		s.mutex.Unlock()
+		return fmt.Errorf("%w: %d", ErrNoDocument, index)
+	}
+	s.active = index
+	s.mutex.Unlock()
+	s.Notify()
+	return nil
+}
+
+// CloseDocument removes the document at index and notifies observers. The
+// document after it becomes active when it was, or the one before for the
+// last tab. Closing the only document leaves an empty one.
+func (s *AppState) CloseDocument(index int) error {
+	s.mutex.Lock()
+	if index < 0 || index >= len(s.documents) {
+		s.mutex.Unlock()
+		return fmt.Errorf("%w: %d", ErrNoDocument, index)
+	}
+	s.documents = append(s.documents[:index], s.documents[index+1:]...)
+	if len(s.documents) == 0 {
+		s.documents = []*Document{{}}
+	}
+	if s.active > index || s.active == len(s.documents) {
+		s.active--
+	}
+	s.mutex.Unlock()
+	s.Notify()
+	return nil
+}
+
+// SetErrorCount records how many validation errors the active document has.
+// Observers are not notified, as this is set while refreshing for a change.
+func (s *AppState) SetErrorCount(count int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.documents[s.active].errorCount = count
+}
@@ -0,0 +1,60 @@
+package state
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/user/pom-manager/internal/core/pom"
+)
+
+func TestDocuments(t *testing.T) {
+	state := NewAppState()
+	state.SetCurrentProject(&pom.Project{ArtifactID: "first"})
+	state.SetFilePath("/first/pom.xml")
+
+	state.AddDocument()
+	if state.ActiveDocument() != 1 || state.GetCurrentProject() != nil {
+		t.Fatalf("Expected an empty second document to be active, got %d", state.ActiveDocument())
+	}
+	state.SetCurrentProject(&pom.Project{ArtifactID: "second"})
+	state.SetFilePath("/second/pom.xml")
+	state.SetDirty(true)
+	state.SetErrorCount(2)
+
+	docs := state.Documents()
+	if len(docs) != 2 || docs[0].Dirty || !docs[1].Dirty || docs[1].ErrorCount != 2 {
+		t.Errorf("Expected a clean and a dirty document, got %+v", docs)
+	}
+	if index := state.FindDocument("/first/pom.xml"); index != 0 {
+		t.Errorf("Expected /first/pom.xml at 0, got %d", index)
+	}
+	if index := state.FindDocument(""); index != -1 {
+		t.Errorf("Expected untitled documents not to be found, got %d", index)
+	}
+
+	if err := state.ActivateDocument(0); err != nil {
+		t.Fatalf("ActivateDocument failed: %v", err)
+	}
+	if state.GetCurrentProject().ArtifactID != "first" || state.IsDirty() {
+		t.Errorf("Expected the first document's state, got %s", state.GetCurrentProject().ArtifactID)
+	}
+	if err := state.ActivateDocument(2); !errors.Is(err, ErrNoDocument) {
+		t.Errorf("Expected ErrNoDocument, got %v", err)
+	}
+
+	// Closing the active document activates the one after it
+	if err := state.CloseDocument(0); err != nil {
+		t.Fatalf("CloseDocument failed: %v", err)
+	}
+	if state.GetFilePath() != "/second/pom.xml" || len(state.Documents()) != 1 {
+		t.Errorf("Expected /second/pom.xml to be left, got %s", state.GetFilePath())
+	}
+
+	// Closing the only document leaves an empty one
+	if err := state.CloseDocument(0); err != nil {
+		t.Fatalf("CloseDocument failed: %v", err)
+	}
+	if docs := state.Documents(); len(docs) != 1 || !docs[0].Empty || state.GetFilePath() != "" {
+		t.Errorf("Expected an empty document, got %+v", docs)
+	}
+}
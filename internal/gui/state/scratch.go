@@ -0,0 +1,129 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// scratchDirName is the subdirectory of the config dir that holds scratch POMs
+const scratchDirName = "scratch"
+
+// ScratchBuffer describes an untitled POM persisted in the scratch area.
+// Scratch buffers are not tied to a session: they are kept across restarts
+// until deleted with DeleteScratchBuffer.
+type ScratchBuffer struct {
+	Name     string    // File name without directory (e.g. scratch-20240101-120000.xml)
+	Path     string    // Absolute path to the scratch file
+	Modified time.Time // Last modification time
+}
+
+// GetScratchDir returns the scratch area directory (scratch in the config directory)
+func GetScratchDir() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, scratchDirName), nil
+}
+
+// NewScratchPath returns an unused file path inside the scratch area,
+// creating the directory if needed
+func NewScratchPath() (string, error) {
+	scratchDir, err := GetScratchDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(scratchDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create scratch directory: %w", err)
+	}
+
+	base := "scratch-" + time.Now().Format("20060102-150405")
+	path := filepath.Join(scratchDir, base+".xml")
+	for i := 2; fileExists(path); i++ {
+		path = filepath.Join(scratchDir, fmt.Sprintf("%s-%d.xml", base, i))
+	}
+
+	return path, nil
+}
+
+// ListScratchBuffers returns all scratch POMs, most recently modified first
+func ListScratchBuffers() ([]ScratchBuffer, error) {
+	scratchDir, err := GetScratchDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(scratchDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []ScratchBuffer{}, nil
+		}
+		return nil, fmt.Errorf("failed to read scratch directory: %w", err)
+	}
+
+	buffers := make([]ScratchBuffer, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".xml") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		buffers = append(buffers, ScratchBuffer{
+			Name:     entry.Name(),
+			Path:     filepath.Join(scratchDir, entry.Name()),
+			Modified: info.ModTime(),
+		})
+	}
+
+	sort.Slice(buffers, func(i, j int) bool {
+		return buffers[i].Modified.After(buffers[j].Modified)
+	})
+
+	return buffers, nil
+}
+
+// DeleteScratchBuffer removes a scratch POM from the scratch area
+func DeleteScratchBuffer(path string) error {
+	if !IsScratchPath(path) {
+		return fmt.Errorf("not a scratch buffer: %s", path)
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete scratch buffer: %w", err)
+	}
+
+	return nil
+}
+
+// IsScratchPath reports whether the path points into the scratch area
+func IsScratchPath(path string) bool {
+	if path == "" {
+		return false
+	}
+
+	scratchDir, err := GetScratchDir()
+	if err != nil {
+		return false
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+
+	return filepath.Dir(absPath) == scratchDir
+}
+
+// fileExists reports whether a file exists at path
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,67 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScratchBuffers(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	// Empty scratch area
+	buffers, err := ListScratchBuffers()
+	if err != nil {
+		t.Fatalf("ListScratchBuffers failed: %v", err)
+	}
+	if len(buffers) != 0 {
+		t.Errorf("Expected no scratch buffers, got %d", len(buffers))
+	}
+
+	// Allocate and write a scratch buffer
+	path, err := NewScratchPath()
+	if err != nil {
+		t.Fatalf("NewScratchPath failed: %v", err)
+	}
+	if !IsScratchPath(path) {
+		t.Errorf("Expected '%s' to be a scratch path", path)
+	}
+	if err := os.WriteFile(path, []byte("<project/>"), 0644); err != nil {
+		t.Fatalf("Failed to write scratch buffer: %v", err)
+	}
+
+	// A second allocation must not collide with the first
+	second, err := NewScratchPath()
+	if err != nil {
+		t.Fatalf("NewScratchPath failed: %v", err)
+	}
+	if second == path {
+		t.Error("Expected a unique scratch path")
+	}
+
+	buffers, err = ListScratchBuffers()
+	if err != nil {
+		t.Fatalf("ListScratchBuffers failed: %v", err)
+	}
+	if len(buffers) != 1 || buffers[0].Path != path {
+		t.Fatalf("Expected one scratch buffer at '%s', got %v", path, buffers)
+	}
+
+	// Files outside the scratch area are rejected
+	outside := filepath.Join(t.TempDir(), "pom.xml")
+	if IsScratchPath(outside) {
+		t.Errorf("Expected '%s' not to be a scratch path", outside)
+	}
+	if err := DeleteScratchBuffer(outside); err == nil {
+		t.Error("Expected error deleting a non-scratch file")
+	}
+
+	if err := DeleteScratchBuffer(path); err != nil {
+		t.Fatalf("DeleteScratchBuffer failed: %v", err)
+	}
+	buffers, _ = ListScratchBuffers()
+	if len(buffers) != 0 {
+		t.Errorf("Expected scratch buffer to be deleted, got %d", len(buffers))
+	}
+}
@@ -4,8 +4,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/user/pom-manager/internal/core/appdir"
+	"github.com/user/pom-manager/internal/core/httpcache"
+	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/core/registry"
 )
 
 // Settings holds user preferences for the GUI application
@@ -13,22 +21,38 @@ type Settings struct {
 	// General settings
 	Theme            string `yaml:"theme"`             // "light" | "dark"
 	AutoSaveInterval int    `yaml:"auto_save_interval"` // Minutes (0 = disabled)
-	RestoreSession   bool   `yaml:"restore_session"`   // Restore last file on startup
+	RestoreSession   bool   `yaml:"restore_session"`   // Reopen the last session's files on startup
 
 	// Editor settings
 	FontSize         int  `yaml:"font_size"`         // 10-18 pt
 	LivePreview      bool `yaml:"live_preview"`      // Enable real-time preview
 	ValidationDelay  int  `yaml:"validation_delay"`  // Milliseconds
 	SyntaxHighlight  bool `yaml:"syntax_highlight"`  // Enable XML syntax highlighting
+	ReviewBeforeSave bool `yaml:"review_before_save"` // Show a diff against the file on disk before saving
+	CommitOnSave     bool `yaml:"commit_on_save"`     // Commit the POM to its Git repository after saving
+	GeneratorBanner  bool `yaml:"generator_banner"`   // Start saved POMs with a generated-by comment; a project's .pom-manager.yaml takes precedence
+
+	// Where added dependencies go, one of pom.InsertPositions ("" = end)
+	DependencyInsertion string `yaml:"dependency_insertion"`
+
+	// Validation settings; a project's .pom-manager.yaml takes precedence
+	ValidationRules pom.RuleSettings `yaml:"validation_rules,omitempty"` // Rule ID -> severity or "off"
 
 	// Templates settings
-	DefaultTemplate   string `yaml:"default_template"`    // Default template name
-	CustomTemplateDir string `yaml:"custom_template_dir"` // Path to custom templates
+	DefaultTemplate     string `yaml:"default_template"`      // Default template name
+	CustomTemplateDir   string `yaml:"custom_template_dir"`   // Path to custom templates
+	TemplateRegistry    string `yaml:"template_registry"`     // Git repository or HTTPS URL of shared templates ("" = none)
+	TemplateRegistryRef string `yaml:"template_registry_ref"` // Git branch, tag or commit the registry is pinned to ("" = default branch)
 
 	// Advanced settings
-	MavenCentralTimeout int    `yaml:"maven_central_timeout"` // Seconds
-	EnableDebugLog      bool   `yaml:"enable_debug_log"`      // Debug logging
-	CacheDir            string `yaml:"cache_dir"`             // Cache directory path
+	MavenCentralTimeout int      `yaml:"maven_central_timeout"`  // Seconds
+	EnableDebugLog      bool     `yaml:"enable_debug_log"`       // Debug logging
+	CacheDir            string   `yaml:"cache_dir"`              // Cache directory path
+	Offline             bool     `yaml:"offline"`                // Don't check dependencies against remote repositories
+	Repositories        []string `yaml:"repositories,omitempty"` // Remote repository URLs (empty = Maven Central)
+	CatalogRefreshDays  int      `yaml:"catalog_refresh_days"`   // Days between default version refreshes (0 = manual only)
+	HTTPCacheHours      int      `yaml:"http_cache_hours"`       // Hours remote lookups are answered from the cache (0 = always revalidate)
+	MavenPath           string   `yaml:"maven_path"`             // mvn that runs builds ("" = the project's mvnw, MAVEN_HOME or PATH)
 
 	// Window settings
 	WindowWidth  int `yaml:"window_width"`  // Last window width
@@ -37,8 +61,23 @@ type Settings struct {
 	WindowY      int `yaml:"window_y"`      // Last window Y position
 
 	// Session restore
-	LastOpenedFile string   `yaml:"last_opened_file"` // Last opened file path
-	RecentFiles    []string `yaml:"recent_files"`     // List of recently opened files
+	LastOpenedFile string        `yaml:"last_opened_file"`        // Last opened file path
+	OpenFiles      []SessionFile `yaml:"open_files,omitempty"`    // Files open in tabs, in tab order
+	ActiveFile     int           `yaml:"active_file"`             // Index in OpenFiles of the file shown
+	SplitOffsets   []float64     `yaml:"split_offsets,omitempty"` // Positions of the tree and preview dividers
+	RecentFiles    []string      `yaml:"recent_files"`            // List of recently opened files
+
+	// Start page
+	PinnedFiles      []string `yaml:"pinned_files,omitempty"`      // Favorite files, kept until unpinned
+	RecentWorkspaces []string `yaml:"recent_workspaces,omitempty"` // Recently opened workspace directories
+}
+
+// SessionFile is a file open when the application was last closed, with
+// how its editor was left
+type SessionFile struct {
+	Path          string   `yaml:"path"`
+	Tab           string   `yaml:"tab,omitempty"`            // Title of the selected editor tab
+	ExpandedNodes []string `yaml:"expanded_nodes,omitempty"` // Open branches of the structure tree
 }
 
 // NewSettings creates Settings with default values
@@ -54,6 +93,11 @@ func NewSettings() *Settings {
 		LivePreview:      true,
 		ValidationDelay:  100, // 100ms
 		SyntaxHighlight:  true,
+		ReviewBeforeSave: false,
+		CommitOnSave:     false,
+		GeneratorBanner:  false,
+
+		DependencyInsertion: pom.InsertAtEnd,
 
 		// Templates defaults
 		DefaultTemplate:   "basic-java",
@@ -62,7 +106,9 @@ func NewSettings() *Settings {
 		// Advanced defaults
 		MavenCentralTimeout: 10, // 10 seconds
 		EnableDebugLog:      false,
-		CacheDir:            "", // Will use default ~/.pom-manager/cache
+		CacheDir:            "", // Will use the platform cache directory
+		CatalogRefreshDays:  7,
+		HTTPCacheHours:      24,
 
 		// Window defaults
 		WindowWidth:  1024,
@@ -95,6 +141,63 @@ func (s *Settings) AddRecentFile(filePath string) {
 	}
 }
 
+// AddRecentWorkspace adds a workspace directory to the recent workspaces
+// list (max 10)
+func (s *Settings) AddRecentWorkspace(dir string) {
+	s.RecentWorkspaces = slices.DeleteFunc(s.RecentWorkspaces, func(path string) bool {
+		return path == dir
+	})
+	s.RecentWorkspaces = append([]string{dir}, s.RecentWorkspaces...)
+	if len(s.RecentWorkspaces) > 10 {
+		s.RecentWorkspaces = s.RecentWorkspaces[:10]
+	}
+}
+
+// GetRecentWorkspaces returns the recent workspaces whose directories still
+// exist
+func (s *Settings) GetRecentWorkspaces() []string {
+	s.RecentWorkspaces = slices.DeleteFunc(s.RecentWorkspaces, func(path string) bool {
+		info, err := os.Stat(path)
+		return err != nil || !info.IsDir()
+	})
+	return s.RecentWorkspaces
+}
+
+// IsPinned reports whether a file is pinned to the start page
+func (s *Settings) IsPinned(filePath string) bool {
+	return slices.Contains(s.PinnedFiles, filePath)
+}
+
+// PinFile pins a file to the start page, after the ones pinned before
+func (s *Settings) PinFile(filePath string) {
+	if !s.IsPinned(filePath) {
+		s.PinnedFiles = append(s.PinnedFiles, filePath)
+	}
+}
+
+// UnpinFile removes a file from the start page's pinned files
+func (s *Settings) UnpinFile(filePath string) {
+	s.PinnedFiles = slices.DeleteFunc(s.PinnedFiles, func(path string) bool {
+		return path == filePath
+	})
+}
+
+// SessionFiles returns the files to reopen on startup and the index of the
+// one to show. Settings saved before whole sessions were kept give the last
+// opened file.
+func (s *Settings) SessionFiles() ([]SessionFile, int) {
+	if len(s.OpenFiles) == 0 {
+		if s.LastOpenedFile == "" {
+			return nil, 0
+		}
+		return []SessionFile{{Path: s.LastOpenedFile}}, 0
+	}
+	if s.ActiveFile < 0 || s.ActiveFile >= len(s.OpenFiles) {
+		return s.OpenFiles, 0
+	}
+	return s.OpenFiles, s.ActiveFile
+}
+
 // GetRecentFiles returns the list of recent files
 func (s *Settings) GetRecentFiles() []string {
 	// Filter out files that don't exist anymore
@@ -108,15 +211,62 @@ func (s *Settings) GetRecentFiles() []string {
 	return validFiles
 }
 
-// GetConfigDir returns the config directory path (~/.pom-manager)
+// GetConfigDir returns the config directory path, e.g.
+// ~/.config/pom-manager (see appdir.ConfigDir)
 func GetConfigDir() (string, error) {
-	homeDir, err := os.UserHomeDir()
+	return appdir.ConfigDir()
+}
+
+// GetCacheDir returns the configured cache directory, or the platform cache
+// directory when none is set
+func (s *Settings) GetCacheDir() (string, error) {
+	if s.CacheDir != "" {
+		return s.CacheDir, nil
+	}
+	return appdir.CacheDir()
+}
+
+// GetHTTPCache returns the cache of remote lookups in the cache directory,
+// or nil when there is no cache directory
+func (s *Settings) GetHTTPCache() *httpcache.Cache {
+	cacheDir, err := s.GetCacheDir()
 	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
+		return nil
 	}
+	return httpcache.New(filepath.Join(cacheDir, httpcache.DirName), time.Duration(s.HTTPCacheHours)*time.Hour)
+}
+
+// GetTemplateDirs returns the directories searched for custom templates:
+// the configured one, then the templates directory in the config directory,
+// which the command line tool uses as well
+func (s *Settings) GetTemplateDirs() []string {
+	var dirs []string
+	if s.CustomTemplateDir != "" {
+		dirs = append(dirs, s.CustomTemplateDir)
+	}
+	if configDir, err := GetConfigDir(); err == nil {
+		dirs = append(dirs, filepath.Join(configDir, "templates"))
+	}
+	return dirs
+}
+
+// RegistrySource returns where the template registry is fetched from; its
+// URL is empty when none is configured
+func (s *Settings) RegistrySource() registry.Source {
+	return registry.Source{URL: s.TemplateRegistry, Ref: s.TemplateRegistryRef}
+}
 
-	configDir := filepath.Join(homeDir, ".pom-manager")
-	return configDir, nil
+// NewTemplateManager returns a template manager offering the built-in
+// templates, the custom ones in GetTemplateDirs and the cached ones of the
+// template registry, in that order of precedence
+func (s *Settings) NewTemplateManager() pom.TemplateManager {
+	dirs := s.GetTemplateDirs()
+	if s.TemplateRegistry != "" {
+		if cacheDir, err := s.GetCacheDir(); err == nil {
+			dirs = append(dirs, registry.Dir(cacheDir))
+		}
+	}
+	return pom.NewTemplateManager(dirs...)
 }
 
 // GetConfigFilePath returns the full path to the GUI config file
@@ -208,8 +358,30 @@ func validateSettings(s *Settings) error {
 	if s.MavenCentralTimeout < 1 || s.MavenCentralTimeout > 300 {
 		return fmt.Errorf("Maven Central timeout must be between 1 and 300 seconds")
 	}
+	if s.CatalogRefreshDays < 0 || s.CatalogRefreshDays > 365 {
+		return fmt.Errorf("catalog refresh interval must be between 0 and 365 days")
+	}
+	if s.HTTPCacheHours < 0 || s.HTTPCacheHours > 720 {
+		return fmt.Errorf("HTTP cache lifetime must be between 0 and 720 hours")
+	}
 	if s.Theme != "light" && s.Theme != "dark" {
 		return fmt.Errorf("theme must be 'light' or 'dark'")
 	}
+	if s.DependencyInsertion != "" && !slices.Contains(pom.InsertPositions, s.DependencyInsertion) {
+		return fmt.Errorf("dependency insertion must be one of %s", strings.Join(pom.InsertPositions, ", "))
+	}
+	if err := s.ValidationRules.Validate(); err != nil {
+		return err
+	}
+	for _, repository := range s.Repositories {
+		if !strings.HasPrefix(repository, "https://") && !strings.HasPrefix(repository, "http://") {
+			return fmt.Errorf("repository URL %q must start with http:// or https://", repository)
+		}
+	}
+	if s.TemplateRegistry != "" {
+		if err := s.RegistrySource().Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
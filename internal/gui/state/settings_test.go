@@ -3,7 +3,10 @@ package state
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestNewSettings(t *testing.T) {
@@ -140,6 +143,43 @@ func TestValidateSettings(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "Invalid HTTP cache lifetime",
+			settings: &Settings{
+				Theme:               "light",
+				FontSize:            12,
+				AutoSaveInterval:    5,
+				ValidationDelay:     100,
+				MavenCentralTimeout: 10,
+				HTTPCacheHours:      -1,
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid dependency insertion",
+			settings: &Settings{
+				Theme:               "light",
+				FontSize:            12,
+				AutoSaveInterval:    5,
+				ValidationDelay:     100,
+				MavenCentralTimeout: 10,
+				DependencyInsertion: "middle",
+			},
+			expectError: true,
+		},
+		{
+			name: "Pinned download as template registry",
+			settings: &Settings{
+				Theme:               "light",
+				FontSize:            12,
+				AutoSaveInterval:    5,
+				ValidationDelay:     100,
+				MavenCentralTimeout: 10,
+				TemplateRegistry:    "https://example.com/templates.zip",
+				TemplateRegistryRef: "v1",
+			},
+			expectError: true,
+		},
 		{
 			name: "Invalid theme",
 			settings: &Settings{
@@ -166,6 +206,95 @@ func TestValidateSettings(t *testing.T) {
 	}
 }
 
+func TestSessionFiles(t *testing.T) {
+	settings := NewSettings()
+	if files, _ := settings.SessionFiles(); len(files) != 0 {
+		t.Errorf("Expected no session files by default, got %v", files)
+	}
+
+	// Settings from before whole sessions were kept reopen the last file
+	settings.LastOpenedFile = "/last/pom.xml"
+	files, active := settings.SessionFiles()
+	if len(files) != 1 || files[0].Path != "/last/pom.xml" || active != 0 {
+		t.Errorf("Expected the last opened file, got %v (%d)", files, active)
+	}
+
+	settings.OpenFiles = []SessionFile{
+		{Path: "/first/pom.xml", Tab: "Plugins"},
+		{Path: "/second/pom.xml", ExpandedNodes: []string{"dependencies"}},
+	}
+	settings.ActiveFile = 1
+	files, active = settings.SessionFiles()
+	if len(files) != 2 || active != 1 {
+		t.Errorf("Expected the second of two files, got %v (%d)", files, active)
+	}
+
+	// Out of range indexes show the first file
+	settings.ActiveFile = 5
+	if _, active := settings.SessionFiles(); active != 0 {
+		t.Errorf("Expected the first file for an invalid index, got %d", active)
+	}
+
+	// The session survives a round trip through the config file format
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		t.Fatalf("Failed to marshal settings: %v", err)
+	}
+	var loaded Settings
+	if err := yaml.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Failed to unmarshal settings: %v", err)
+	}
+	if len(loaded.OpenFiles) != 2 || loaded.OpenFiles[0].Tab != "Plugins" ||
+		len(loaded.OpenFiles[1].ExpandedNodes) != 1 {
+		t.Errorf("Expected the open files to be kept, got %+v", loaded.OpenFiles)
+	}
+}
+
+func TestPinnedFiles(t *testing.T) {
+	settings := NewSettings()
+	settings.PinFile("/a/pom.xml")
+	settings.PinFile("/b/pom.xml")
+	settings.PinFile("/a/pom.xml")
+
+	if len(settings.PinnedFiles) != 2 || settings.PinnedFiles[0] != "/a/pom.xml" {
+		t.Errorf("Expected two pinned files in pin order, got %v", settings.PinnedFiles)
+	}
+	if !settings.IsPinned("/b/pom.xml") {
+		t.Error("Expected /b/pom.xml to be pinned")
+	}
+
+	settings.UnpinFile("/a/pom.xml")
+	if settings.IsPinned("/a/pom.xml") || len(settings.PinnedFiles) != 1 {
+		t.Errorf("Expected /a/pom.xml to be unpinned, got %v", settings.PinnedFiles)
+	}
+}
+
+func TestRecentWorkspaces(t *testing.T) {
+	settings := NewSettings()
+	dirs := make([]string, 12)
+	for i := range dirs {
+		dirs[i] = t.TempDir()
+		settings.AddRecentWorkspace(dirs[i])
+	}
+	settings.AddRecentWorkspace(dirs[5])
+
+	if len(settings.RecentWorkspaces) != 10 {
+		t.Fatalf("Expected 10 recent workspaces, got %d", len(settings.RecentWorkspaces))
+	}
+	if settings.RecentWorkspaces[0] != dirs[5] || settings.RecentWorkspaces[1] != dirs[11] {
+		t.Errorf("Expected the reopened workspace first, got %v", settings.RecentWorkspaces[:2])
+	}
+
+	// Directories that no longer exist are dropped
+	if err := os.Remove(dirs[11]); err != nil {
+		t.Fatalf("Failed to remove directory: %v", err)
+	}
+	workspaces := settings.GetRecentWorkspaces()
+	if len(workspaces) != 9 || slices.Contains(workspaces, dirs[11]) {
+		t.Errorf("Expected the removed directory to be dropped, got %v", workspaces)
+	}
+}
+
 // Helper function to marshal settings (exported for testing)
 func marshalSettings(s *Settings) ([]byte, error) {
 	// This would use yaml.Marshal in actual implementation
@@ -0,0 +1,119 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MaxFileUIStates limits how many files' UI states are remembered; the
+// least recently used are forgotten first
+const MaxFileUIStates = 100
+
+// FileUIState is how the editor was left for one POM, restored when the
+// file is opened again
+type FileUIState struct {
+	Tab              string    `yaml:"tab,omitempty"`               // Title of the selected editor tab
+	ExpandedNodes    []string  `yaml:"expanded_nodes,omitempty"`    // Open branches of the structure tree
+	OpenPhases       []string  `yaml:"open_phases,omitempty"`       // Expanded lifecycle phases; the others stay collapsed
+	DependencyFilter string    `yaml:"dependency_filter,omitempty"` // Text in the dependency filter
+	Updated          time.Time `yaml:"updated"`                     // When the state was last saved
+}
+
+// UIStates is the persisted UI state of every file, keyed by absolute path
+type UIStates struct {
+	Files map[string]FileUIState `yaml:"files"`
+}
+
+// GetUIStatesFilePath returns the full path to the UI state file
+func GetUIStatesFilePath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "ui_state.yaml"), nil
+}
+
+// LoadUIStates loads the UI states from the UI state file
+// If the file doesn't exist, returns no states
+func LoadUIStates() (*UIStates, error) {
+	statesPath, err := GetUIStatesFilePath()
+	if err != nil {
+		return &UIStates{}, fmt.Errorf("failed to get UI state path: %w", err)
+	}
+
+	data, err := os.ReadFile(statesPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &UIStates{}, nil
+		}
+		return &UIStates{}, fmt.Errorf("failed to read UI state file: %w", err)
+	}
+
+	var states UIStates
+	if err := yaml.Unmarshal(data, &states); err != nil {
+		return &UIStates{}, fmt.Errorf("failed to parse UI state file: %w", err)
+	}
+
+	return &states, nil
+}
+
+// SaveUIStates saves the UI states to the UI state file
+func SaveUIStates(states *UIStates) error {
+	statesPath, err := GetUIStatesFilePath()
+	if err != nil {
+		return fmt.Errorf("failed to get UI state path: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(statesPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(states)
+	if err != nil {
+		return fmt.Errorf("failed to marshal UI state: %w", err)
+	}
+
+	if err := os.WriteFile(statesPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write UI state file: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the UI state of a file, reporting whether one was saved
+func (u *UIStates) Get(path string) (FileUIState, bool) {
+	state, ok := u.Files[path]
+	return state, ok
+}
+
+// Set remembers the UI state of a file, forgetting the least recently
+// used files beyond MaxFileUIStates
+func (u *UIStates) Set(path string, state FileUIState) {
+	if u.Files == nil {
+		u.Files = make(map[string]FileUIState)
+	}
+	if state.Updated.IsZero() {
+		state.Updated = time.Now()
+	}
+	u.Files[path] = state
+
+	if len(u.Files) <= MaxFileUIStates {
+		return
+	}
+	paths := make([]string, 0, len(u.Files))
+	for p := range u.Files {
+		paths = append(paths, p)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return u.Files[paths[i]].Updated.Before(u.Files[paths[j]].Updated)
+	})
+	for _, p := range paths[:len(paths)-MaxFileUIStates] {
+		delete(u.Files, p)
+	}
+}
@@ -0,0 +1,58 @@
+package state
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestUIStates(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	// No UI state file yet
+	states, err := LoadUIStates()
+	if err != nil {
+		t.Fatalf("LoadUIStates failed: %v", err)
+	}
+	if _, ok := states.Get("/work/pom.xml"); ok {
+		t.Error("Expected no state for an unknown file")
+	}
+
+	states.Set("/work/pom.xml", FileUIState{
+		Tab:              "Plugins",
+		ExpandedNodes:    []string{"dependencies", "project"},
+		OpenPhases:       []string{"package"},
+		DependencyFilter: "junit",
+	})
+	if err := SaveUIStates(states); err != nil {
+		t.Fatalf("SaveUIStates failed: %v", err)
+	}
+
+	loaded, err := LoadUIStates()
+	if err != nil {
+		t.Fatalf("LoadUIStates failed: %v", err)
+	}
+	state, ok := loaded.Get("/work/pom.xml")
+	if !ok {
+		t.Fatal("Expected the saved state")
+	}
+	if state.Tab != "Plugins" || len(state.ExpandedNodes) != 2 || state.OpenPhases[0] != "package" || state.DependencyFilter != "junit" {
+		t.Errorf("Expected the saved state back, got %+v", state)
+	}
+}
+
+func TestUIStatesForgetOldest(t *testing.T) {
+	states := &UIStates{}
+	start := time.Now()
+	for i := 0; i <= MaxFileUIStates; i++ {
+		states.Set(fmt.Sprintf("/work/%d/pom.xml", i), FileUIState{Updated: start.Add(time.Duration(i) * time.Second)})
+	}
+
+	if len(states.Files) != MaxFileUIStates {
+		t.Errorf("Expected %d states, got %d", MaxFileUIStates, len(states.Files))
+	}
+	if _, ok := states.Get("/work/0/pom.xml"); ok {
+		t.Error("Expected the least recently used state to be forgotten")
+	}
+}
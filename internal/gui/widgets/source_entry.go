@@ -0,0 +1,33 @@
+package widgets
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// SourceEntry is a monospaced multi-line entry for editing source text that
+// reports when it loses focus
+type SourceEntry struct {
+	widget.Entry
+
+	// OnFocusLost is called after the entry loses keyboard focus
+	OnFocusLost func()
+}
+
+// NewSourceEntry creates a new source entry
+func NewSourceEntry() *SourceEntry {
+	entry := &SourceEntry{}
+	entry.MultiLine = true
+	entry.Wrapping = fyne.TextWrapOff
+	entry.TextStyle = fyne.TextStyle{Monospace: true}
+	entry.ExtendBaseWidget(entry)
+	return entry
+}
+
+// FocusLost is called by the canvas when the entry loses focus (implements fyne.Focusable)
+func (e *SourceEntry) FocusLost() {
+	e.Entry.FocusLost()
+	if e.OnFocusLost != nil {
+		e.OnFocusLost()
+	}
+}
@@ -255,6 +255,18 @@ func (x *XMLViewer) CreateRenderer() fyne.WidgetRenderer {
 	return widget.NewSimpleRenderer(x.scroll)
 }
 
+// ScrollToLine scrolls so that a line, counted from 1, is near the top
+func (x *XMLViewer) ScrollToLine(line int) {
+	lines := strings.Count(x.GetText(), "\n") + 1
+	if line < 1 || line > lines {
+		return
+	}
+	lineHeight := x.richText.MinSize().Height / float32(lines)
+	// Keep a line of context above
+	x.scroll.Offset.Y = max(0, float32(line-2)*lineHeight)
+	x.scroll.Refresh()
+}
+
 // GetText returns the current text content
 func (x *XMLViewer) GetText() string {
 	var text strings.Builder
@@ -1,27 +1,64 @@
 package windows
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
 
+	"github.com/user/pom-manager/internal/core/audit"
+	"github.com/user/pom-manager/internal/core/catalog"
+	"github.com/user/pom-manager/internal/core/classpath"
+	"github.com/user/pom-manager/internal/core/cleanup"
+	"github.com/user/pom-manager/internal/core/convert"
+	"github.com/user/pom-manager/internal/core/diff"
+	"github.com/user/pom-manager/internal/core/filewatch"
+	"github.com/user/pom-manager/internal/core/git"
+	"github.com/user/pom-manager/internal/core/jar"
+	"github.com/user/pom-manager/internal/core/localrepo"
+	"github.com/user/pom-manager/internal/core/maven"
 	"github.com/user/pom-manager/internal/core/pom"
+	"github.com/user/pom-manager/internal/core/registry"
+	"github.com/user/pom-manager/internal/core/remote"
+	"github.com/user/pom-manager/internal/core/workspace"
 	"github.com/user/pom-manager/internal/gui/dialogs"
 	"github.com/user/pom-manager/internal/gui/dialogs/wizard"
 	"github.com/user/pom-manager/internal/gui/panels"
 	"github.com/user/pom-manager/internal/gui/presenters"
 	"github.com/user/pom-manager/internal/gui/state"
+	"github.com/user/pom-manager/internal/gui/widgets"
 )
 
+// statsResolveTimeout bounds resolving the dependency tree for the
+// statistics; reading the local repository is usually much faster
+const statsResolveTimeout = time.Minute
+
+// maxImpactLines limits how many affected places a removal confirmation
+// lists, keeping the dialog on screen
+const maxImpactLines = 15
+
+// registryUpdateTimeout bounds fetching the template registry
+const registryUpdateTimeout = 2 * time.Minute
+
 // MainWindow is the main application window
 type MainWindow struct {
 	window    fyne.Window
+	baseTitle string // Window title without file name or modified marker
 	presenter presenters.MainPresenter
 	appState  *state.AppState
 
@@ -33,13 +70,74 @@ type MainWindow struct {
 	propsPanel        *panels.PropertiesPanel
 	profilesPanel     *panels.ProfilesPanel
 	lifecyclePanel    *panels.LifecyclePanel
+	timelinePanel     *panels.TimelinePanel
 	previewPane       *panels.PreviewPane
 	errorsPanel       *panels.ErrorsPanel
+	bookmarksPanel    *panels.BookmarksPanel
+	xmlSourcePanel    *panels.XMLSourcePanel
+	inheritancePanel  *panels.InheritancePanel
+	statsPanel        *panels.StatsPanel
+	buildOutputPanel  *panels.BuildOutputPanel
+	startPage         *panels.StartPage
 
 	// UI components
-	tabContainer *container.AppTabs
-	statusLabel  *widget.Label
-	mainContent  *fyne.Container
+	undoItem       *fyne.MenuItem
+	pinItem        *fyne.MenuItem
+	redoItem       *fyne.MenuItem
+	documentTabs   *container.DocTabs
+	syncingTabs    bool // Tabs are being rebuilt, so selections are not the user's
+	tabContainer   *container.AppTabs
+	statusLabel    *widget.Label
+	readOnlyBanner *fyne.Container
+	mainContent    *fyne.Container
+	treeSplit      *container.Split // Structure tree | editor
+	previewSplit   *container.Split // Tree and editor | preview
+
+	// Global filter narrowing down the lists of several tabs (hidden until
+	// Ctrl+F)
+	filterBar   *fyne.Container
+	filterEntry *widget.Entry
+
+	// Suggestion to add a dependency found on the clipboard
+	clipboardChip  *fyne.Container
+	clipboardLabel *widget.Label
+	clipboardDep   pom.Dependency
+	lastClipboard  string // Clipboard text already offered
+
+	// File whose Git status the tree shows
+	gitStatusPath string
+
+	// Bookmarks across all workspaces (persisted in the config dir)
+	bookmarks *state.Bookmarks
+
+	// Per-file UI state (persisted in the config dir) and the file shown
+	uiStates    *state.UIStates
+	uiStatePath string
+
+	// Workspace opened by workspace-wide features; its parsed POMs and
+	// coordinate index are kept until another root is used
+	workspace *workspace.Workspace
+
+	// Records the files written outside of saves in the audit log; nil
+	// disables recording
+	recorder *audit.Recorder
+
+	// Stops the build running in the Build Output panel, nil when none runs
+	buildCancel context.CancelFunc
+
+	// Search result to reveal once the target POM is displayed
+	pendingMatch *workspace.Match
+
+	// Background auto-save; closing the channel stops the ticker
+	autoSaveStop chan struct{}
+
+	// Reports changes other programs make to the open files; nil when files
+	// cannot be watched
+	fileWatcher *filewatch.Watcher
+	// Open files changed on disk while another document was shown
+	changedOnDisk map[string]bool
+	// The File Changed on Disk dialog is showing
+	diskPrompt bool
 
 	// Debouncing for preview updates
 	refreshTimer    *time.Timer
@@ -55,6 +153,7 @@ func NewMainWindow(
 ) *MainWindow {
 	mw := &MainWindow{
 		window:    window,
+		baseTitle: window.Title(),
 		presenter: presenter,
 		appState:  appState,
 	}
@@ -63,11 +162,39 @@ func NewMainWindow(
 	settings := appState.GetSettings()
 	mw.refreshDebounce = time.Duration(settings.ValidationDelay) * time.Millisecond
 
+	// Load bookmarks (falls back to an empty list on error)
+	mw.bookmarks, _ = state.LoadBookmarks()
+	mw.uiStates, _ = state.LoadUIStates()
+
 	mw.createPanels()
 	mw.createMenu()
 	mw.createLayout()
 	mw.setupCallbacks()
 
+	// Ask before discarding unsaved changes when the window is closed
+	window.SetCloseIntercept(mw.handleClose)
+
+	// Open POMs dropped anywhere on the window, such as the start page
+	window.SetOnDropped(mw.handleDrop)
+
+	// Notice when other programs, such as an IDE or git, change open files
+	mw.changedOnDisk = make(map[string]bool)
+	if watcher, err := filewatch.New(mw.handleDiskChange); err == nil {
+		mw.fileWatcher = watcher
+	}
+
+	// Offer dependencies copied in other applications, such as a browser, and
+	// pick up commits or checkouts made outside the application
+	fyne.CurrentApp().Lifecycle().SetOnEnteredForeground(func() {
+		mw.checkClipboard()
+		mw.refreshGitStatus()
+	})
+
+	mw.bookmarksPanel.LoadBookmarks(mw.bookmarks.InDirectory(mw.workspaceRoot()))
+
+	mw.loadCatalog()
+	mw.updateTemplateRegistry(false)
+
 	return mw
 }
 
@@ -80,8 +207,15 @@ func (mw *MainWindow) createPanels() {
 	mw.propsPanel = panels.NewPropertiesPanel(mw.window)
 	mw.profilesPanel = panels.NewProfilesPanel()
 	mw.lifecyclePanel = panels.NewLifecyclePanel()
+	mw.timelinePanel = panels.NewTimelinePanel()
 	mw.previewPane = panels.NewPreviewPane()
 	mw.errorsPanel = panels.NewErrorsPanel()
+	mw.bookmarksPanel = panels.NewBookmarksPanel()
+	mw.xmlSourcePanel = panels.NewXMLSourcePanel()
+	mw.inheritancePanel = panels.NewInheritancePanel()
+	mw.statsPanel = panels.NewStatsPanel()
+	mw.buildOutputPanel = panels.NewBuildOutputPanel()
+	mw.startPage = panels.NewStartPage()
 }
 
 // createMenu creates the menu bar
@@ -95,18 +229,65 @@ func (mw *MainWindow) createMenu() {
 	mw.updateRecentFilesMenu(recentMenu)
 	recentItem := fyne.NewMenuItem("Open Recent", nil)
 	recentItem.ChildMenu = recentMenu
+	openWorkspaceItem := fyne.NewMenuItem("Open Workspace...", mw.handleOpenWorkspace)
+	mw.pinItem = fyne.NewMenuItem("Pin to Start Page", mw.handleTogglePin)
+	mw.pinItem.Checked = mw.appState.GetSettings().IsPinned(mw.appState.GetFilePath())
+	newModuleItem := fyne.NewMenuItem("New Module...", mw.handleNewModule)
+	structureItem := fyne.NewMenuItem("Project Structure...", mw.handleProjectStructure)
+
+	// Scratch buffers submenu
+	newScratchItem := fyne.NewMenuItem("New Scratch POM", mw.handleNewScratch)
+	scratchMenu := fyne.NewMenu("Scratch Buffers")
+	mw.updateScratchMenu(scratchMenu)
+	scratchItem := fyne.NewMenuItem("Scratch Buffers", nil)
+	scratchItem.ChildMenu = scratchMenu
 
 	saveItem := fyne.NewMenuItem("Save", mw.handleSave)
 	saveAsItem := fyne.NewMenuItem("Save As...", mw.handleSaveAs)
+	saveTemplateItem := fyne.NewMenuItem("Save as Template...", mw.handleSaveAsTemplate)
+	reviewItem := fyne.NewMenuItem("Review Changes...", mw.handleReviewChanges)
+	changelogItem := fyne.NewMenuItem("Changelog...", mw.handleChangelog)
+	importItem := fyne.NewMenuItem("Import", nil)
+	importItem.ChildMenu = fyne.NewMenu("",
+		fyne.NewMenuItem("Gradle Version Catalog...", mw.handleImportGradleCatalog),
+		fyne.NewMenuItem("Ivy Dependencies (ivy.xml)...", func() { mw.handleImportDependencies(pom.ImportFormatIvy) }),
+		fyne.NewMenuItem("SBT Dependencies (build.sbt)...", func() { mw.handleImportDependencies(pom.ImportFormatSBT) }),
+		fyne.NewMenuItem("Gradle Dependencies (build.gradle)...", func() { mw.handleImportDependencies(pom.ImportFormatGradle) }),
+		fyne.NewMenuItem("Gradle Notation (Paste)...", mw.handlePasteGradle),
+	)
+	exportItem := fyne.NewMenuItem("Export", nil)
+	exportItem.ChildMenu = fyne.NewMenu("",
+		fyne.NewMenuItem("Gradle Build Script (build.gradle)...", func() { mw.handleExport(convert.FormatGradle) }),
+		fyne.NewMenuItem("Gradle Kotlin Script (build.gradle.kts)...", func() { mw.handleExport(convert.FormatGradleKts) }),
+		fyne.NewMenuItem("Bazel Artifacts (maven_artifacts.bzl)...", func() { mw.handleExport(convert.FormatBazel) }),
+	)
+	closeTabItem := fyne.NewMenuItem("Close Tab", mw.handleCloseDocument)
 	exitItem := fyne.NewMenuItem("Exit", func() {
-		mw.window.Close()
+		mw.handleClose()
 	})
 
-	fileMenu := fyne.NewMenu("File", newItem, openItem, recentItem, fyne.NewMenuItemSeparator(), saveItem, saveAsItem, fyne.NewMenuItemSeparator(), exitItem)
+	fileMenu := fyne.NewMenu("File", newItem, openItem, recentItem, mw.pinItem, openWorkspaceItem, newModuleItem, structureItem, fyne.NewMenuItemSeparator(), newScratchItem, scratchItem, fyne.NewMenuItemSeparator(), saveItem, saveAsItem, saveTemplateItem, reviewItem, changelogItem, fyne.NewMenuItemSeparator(), importItem, exportItem, fyne.NewMenuItemSeparator(), closeTabItem, exitItem)
 
 	// Edit menu
+	mw.undoItem = fyne.NewMenuItem("Undo", mw.handleUndo)
+	mw.redoItem = fyne.NewMenuItem("Redo", mw.handleRedo)
+	mw.updateUndoMenu()
+	pasteXMLItem := fyne.NewMenuItem("Paste XML...", mw.handlePasteXML)
+	filterItem := fyne.NewMenuItem("Filter...", mw.handleFilter)
+	findInWorkspaceItem := fyne.NewMenuItem("Find in Workspace...", mw.handleWorkspaceSearch)
+	gotoArtifactItem := fyne.NewMenuItem("Go to Artifact...", mw.handleGotoArtifact)
+	localRepositoryItem := fyne.NewMenuItem("Browse Local Repository...", mw.handleLocalRepository)
+	mvnCommandItem := fyne.NewMenuItem("Copy mvn Command...", mw.handleMvnCommand)
 	settingsItem := fyne.NewMenuItem("Settings...", mw.handleSettings)
-	editMenu := fyne.NewMenu("Edit", settingsItem)
+	mavenSettingsItem := fyne.NewMenuItem("Maven settings.xml...", mw.handleMavenSettings)
+	refreshCatalogItem := fyne.NewMenuItem("Refresh Default Versions", mw.handleRefreshCatalog)
+	updateRegistryItem := fyne.NewMenuItem("Update Template Registry", func() { mw.updateTemplateRegistry(true) })
+	editMenu := fyne.NewMenu("Edit", mw.undoItem, mw.redoItem, fyne.NewMenuItemSeparator(), pasteXMLItem, filterItem, findInWorkspaceItem, gotoArtifactItem, localRepositoryItem, mvnCommandItem, fyne.NewMenuItemSeparator(), settingsItem, mavenSettingsItem, refreshCatalogItem, updateRegistryItem)
+
+	// Build menu
+	runBuildItem := fyne.NewMenuItem("Run Build...", mw.handleRunBuild)
+	stopBuildItem := fyne.NewMenuItem("Stop Build", mw.handleStopBuild)
+	buildMenu := fyne.NewMenu("Build", runBuildItem, stopBuildItem)
 
 	// Help menu
 	quickHelpItem := fyne.NewMenuItem("Quick Help", mw.handleQuickHelp)
@@ -114,7 +295,7 @@ func (mw *MainWindow) createMenu() {
 	aboutItem := fyne.NewMenuItem("About", mw.handleAbout)
 	helpMenu := fyne.NewMenu("Help", quickHelpItem, mavenBasicsItem, fyne.NewMenuItemSeparator(), aboutItem)
 
-	mainMenu := fyne.NewMainMenu(fileMenu, editMenu, helpMenu)
+	mainMenu := fyne.NewMainMenu(fileMenu, editMenu, buildMenu, helpMenu)
 	mw.window.SetMainMenu(mainMenu)
 }
 
@@ -128,40 +309,95 @@ func (mw *MainWindow) createLayout() {
 		container.NewTabItem("Properties", mw.propsPanel.GetContainer()),
 		container.NewTabItem("Profiles", mw.profilesPanel.GetContainer()),
 		container.NewTabItem("Lifecycle Phases", mw.lifecyclePanel.GetContainer()),
+		container.NewTabItem("Timeline", mw.timelinePanel.GetContainer()),
+		container.NewTabItem("Bookmarks", mw.bookmarksPanel.GetContainer()),
+		container.NewTabItem("XML Source", mw.xmlSourcePanel.GetContainer()),
+		container.NewTabItem("Inheritance", mw.inheritancePanel.GetContainer()),
+		container.NewTabItem("Statistics", mw.statsPanel.GetContainer()),
+		container.NewTabItem("Build Output", mw.buildOutputPanel.GetContainer()),
 	)
 
-	// Create center panel with tabs and errors
+	// Global filter bar above the tabs
+	mw.filterEntry = widget.NewEntry()
+	mw.filterEntry.SetPlaceHolder("Filter dependencies, plugins, properties and profiles...")
+	mw.filterEntry.OnChanged = mw.applyGlobalFilter
+	closeFilterButton := widget.NewButton("✕", func() {
+		mw.filterEntry.SetText("")
+		mw.filterBar.Hide()
+	})
+	closeFilterButton.Importance = widget.LowImportance
+	mw.filterBar = container.NewBorder(nil, nil, widget.NewLabel("Filter:"), closeFilterButton, mw.filterEntry)
+	mw.filterBar.Hide()
+
+	// Create center panel with filter, tabs and errors
 	centerPanel := container.NewBorder(
-		nil,
+		mw.filterBar,
 		mw.errorsPanel.GetContainer(),
 		nil, nil,
 		mw.tabContainer,
 	)
 
 	// Create three-panel layout
-	splitLeft := container.NewHSplit(
+	mw.treeSplit = container.NewHSplit(
 		mw.treePanel.GetContainer(),
 		centerPanel,
 	)
-	splitLeft.SetOffset(0.2) // 20% for tree
+	mw.treeSplit.SetOffset(0.2) // 20% for tree
 
-	splitMain := container.NewHSplit(
-		splitLeft,
+	mw.previewSplit = container.NewHSplit(
+		mw.treeSplit,
 		mw.previewPane.GetContainer(),
 	)
-	splitMain.SetOffset(0.65) // 65% for left (tree + editor), 35% for preview
+	mw.previewSplit.SetOffset(0.65) // 65% for left (tree + editor), 35% for preview
 
 	// Status bar
 	mw.statusLabel = widget.NewLabel("Ready")
-	statusBar := container.NewHBox(mw.statusLabel)
+
+	// Clipboard suggestion (hidden until the clipboard holds a dependency)
+	mw.clipboardLabel = widget.NewLabel("")
+	addClipboardButton := widget.NewButton("Add", mw.handleAddClipboardDependency)
+	addClipboardButton.Importance = widget.HighImportance
+	dismissClipboardButton := widget.NewButton("✕", func() { mw.clipboardChip.Hide() })
+	dismissClipboardButton.Importance = widget.LowImportance
+	mw.clipboardChip = container.NewHBox(mw.clipboardLabel, addClipboardButton, dismissClipboardButton)
+	mw.clipboardChip.Hide()
+
+	statusBar := container.NewHBox(mw.statusLabel, layout.NewSpacer(), mw.clipboardChip)
+
+	// Tabs of the open documents (hidden while only one is open); the
+	// panels below show the selected one
+	mw.documentTabs = container.NewDocTabs()
+	mw.documentTabs.OnSelected = func(*container.TabItem) {
+		if mw.syncingTabs {
+			return
+		}
+		if err := mw.presenter.SwitchDocument(mw.documentTabs.SelectedIndex()); err != nil {
+			dialog.ShowError(err, mw.window)
+		}
+	}
+	mw.documentTabs.CloseIntercept = func(item *container.TabItem) {
+		mw.closeDocument(slices.Index(mw.documentTabs.Items, item))
+	}
+	mw.documentTabs.Hide()
+
+	// Read-only banner (hidden until a view-only document is loaded)
+	readOnlyLabel := widget.NewLabel("🔒 This file is read-only. Edits are disabled.")
+	saveCopyButton := widgets.NewButtonWithTooltip("Save As a Copy",
+		"Save this POM to a writable location and continue editing there",
+		mw.handleSaveAs)
+	mw.readOnlyBanner = container.NewBorder(nil, widget.NewSeparator(), nil, saveCopyButton, readOnlyLabel)
+	mw.readOnlyBanner.Hide()
 
 	// Main content
 	mw.mainContent = container.NewBorder(
-		nil,        // Top (menu is separate)
+		container.NewVBox(mw.documentTabs, mw.readOnlyBanner), // Top (menu is separate)
 		statusBar,  // Bottom
 		nil, nil,   // Left, Right
-		splitMain,  // Center
+		container.NewStack(mw.previewSplit, mw.startPage.GetContainer()), // Center
 	)
+	// Nothing is loaded yet
+	mw.previewSplit.Hide()
+	mw.loadStartPage()
 
 	mw.window.SetContent(mw.mainContent)
 }
@@ -178,18 +414,23 @@ func (mw *MainWindow) setupCallbacks() {
 		mw.presenter.UpdateCoordinates(coords)
 	})
 
+	mw.coordsPanel.OnCopyGAV(func(coords pom.Coordinates) {
+		mw.copyGAV(coords.String())
+	})
+
 	// Dependencies panel
 	mw.depsPanel.OnAdd(func() {
-		depDialog := dialogs.NewDependencyDialog(mw.window)
-		depDialog.ShowAdd(func(dep pom.Dependency) {
-			mw.presenter.AddDependency(dep)
-		})
+		mw.showAddDependency(nil)
 	})
 
 	mw.depsPanel.OnEdit(func(dep pom.Dependency) {
 		depDialog := dialogs.NewDependencyDialog(mw.window)
+		depDialog.SetManagedBy(mw.presenter.GetCurrentProject())
+		depDialog.SetVerifier(mw.remoteClient())
 		depDialog.ShowEdit(dep, func(updated pom.Dependency) {
-			mw.presenter.AddDependency(updated) // Add/update logic
+			if err := mw.presenter.UpdateDependency(dep, updated); err != nil {
+				dialog.ShowError(err, mw.window)
+			}
 		})
 	})
 
@@ -197,6 +438,68 @@ func (mw *MainWindow) setupCallbacks() {
 		mw.presenter.RemoveDependency(dep.GroupID, dep.ArtifactID)
 	})
 
+	mw.depsPanel.OnBookmark(func(dep pom.Dependency) {
+		mw.handleBookmark(state.BookmarkDependency, dep.GroupID, dep.ArtifactID)
+	})
+
+	mw.depsPanel.OnMoveToParent(func(dep pom.Dependency) {
+		mw.handleMoveToParent(dep.GroupID, dep.ArtifactID)
+	})
+
+	mw.depsPanel.OnRemoveSelected(func(deps []pom.Dependency) {
+		message := fmt.Sprintf("Remove %d dependencies?", len(deps))
+		dialog.ShowConfirm("Remove Dependencies", message, func(ok bool) {
+			if !ok {
+				return
+			}
+			if err := mw.presenter.RemoveDependencies(deps); err != nil {
+				dialog.ShowError(err, mw.window)
+			}
+		}, mw.window)
+	})
+
+	mw.depsPanel.OnScopeSelected(func(deps []pom.Dependency, scope string) {
+		if err := mw.presenter.SetDependenciesScope(deps, scope); err != nil {
+			dialog.ShowError(err, mw.window)
+		}
+	})
+
+	mw.depsPanel.OnCopySelected(func(deps []pom.Dependency) {
+		snippet, err := pom.DependencySnippet(deps)
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		fyne.CurrentApp().Clipboard().SetContent(snippet)
+		mw.statusLabel.SetText(fmt.Sprintf("Copied %d dependencies as XML", len(deps)))
+	})
+
+	mw.depsPanel.OnReorder(func(from, to int) {
+		dep := mw.presenter.GetCurrentProject().Dependencies[from]
+		if err := mw.presenter.MoveDependency(from, to); err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		mw.depsPanel.SelectDependency(dep.GroupID, dep.ArtifactID)
+	})
+
+	mw.depsPanel.OnSort(func() {
+		if err := mw.presenter.SortDependencies(); err != nil {
+			dialog.ShowError(err, mw.window)
+		}
+	})
+
+	mw.depsPanel.OnCleanUp(mw.handleCleanUp)
+
+	mw.depsPanel.OnAddBOM(func() {
+		bomDialog := dialogs.NewBOMDialog(mw.window)
+		bomDialog.Show(func(bom pom.Coordinates) {
+			if err := mw.presenter.AddBOM(bom); err != nil {
+				dialog.ShowError(err, mw.window)
+			}
+		})
+	})
+
 	// Plugins panel
 	mw.pluginsPanel.OnAdd(func() {
 		pluginDialog := dialogs.NewPluginDialog(mw.window)
@@ -205,15 +508,104 @@ func (mw *MainWindow) setupCallbacks() {
 		})
 	})
 
-	mw.pluginsPanel.OnEdit(func(plugin pom.Plugin) {
-		pluginDialog := dialogs.NewPluginDialog(mw.window)
-		pluginDialog.ShowEdit(plugin, func(updated pom.Plugin) {
-			mw.presenter.AddPlugin(updated)
+	mw.pluginsPanel.OnEdit(mw.editPlugin)
+
+	mw.pluginsPanel.OnRemove(func(plugin pom.Plugin) {
+		mw.confirmImpact("Remove Plugin", plugin.ArtifactID, pom.PluginRemovalImpact(plugin), func() {
+			mw.presenter.RemovePlugin(plugin.GroupID, plugin.ArtifactID)
 		})
 	})
 
-	mw.pluginsPanel.OnRemove(func(plugin pom.Plugin) {
-		mw.presenter.RemovePlugin(plugin.GroupID, plugin.ArtifactID)
+	mw.pluginsPanel.OnSkip(func(plugin pom.Plugin) {
+		dialogs.NewSkipDialog(mw.window).Show(mw.presenter.GetCurrentProject(), plugin, func(profileID string, flags map[string]bool) {
+			if err := mw.presenter.SetSkipFlags(profileID, flags); err != nil {
+				dialog.ShowError(err, mw.window)
+			}
+		})
+	})
+
+	mw.pluginsPanel.OnBookmark(func(plugin pom.Plugin) {
+		mw.handleBookmark(state.BookmarkPlugin, plugin.GroupID, plugin.ArtifactID)
+	})
+
+	// XML source panel
+	mw.xmlSourcePanel.OnApply(mw.presenter.ApplyXML)
+
+	mw.statsPanel.OnResolve(mw.handleResolveStats)
+
+	mw.buildOutputPanel.OnRun(mw.handleRunBuild)
+	mw.buildOutputPanel.OnStop(mw.handleStopBuild)
+
+	// Clicking a finding navigates to the offending field
+	mw.errorsPanel.OnErrorClick(mw.navigateToFinding)
+
+	mw.errorsPanel.OnFix(func(finding pom.ValidationError) {
+		if err := mw.presenter.ApplyFix(finding); err != nil {
+			dialog.ShowError(err, mw.window)
+		}
+	})
+
+	mw.errorsPanel.OnResolve(mw.resolveUndefinedProperty)
+
+	mw.errorsPanel.OnFixAll(func() {
+		applied, err := mw.presenter.ApplyAllFixes()
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		if len(applied) > 0 {
+			mw.statusLabel.SetText(fmt.Sprintf("Applied %d fix(es)", len(applied)))
+		}
+	})
+
+	// Start page
+	mw.startPage.OnNew(mw.handleNew)
+	mw.startPage.OnNewFromTemplate(mw.handleNewFromTemplate)
+	mw.startPage.OnOpen(mw.handleOpen)
+	mw.startPage.OnBrowseWorkspace(mw.handleOpenWorkspace)
+	mw.startPage.OnQuickHelp(mw.handleQuickHelp)
+	mw.startPage.OnMavenBasics(mw.handleMavenBasics)
+
+	mw.startPage.OnOpenFile(func(path string) {
+		if err := mw.openFile(path); err != nil {
+			dialog.ShowError(err, mw.window)
+		}
+	})
+
+	mw.startPage.OnOpenWorkspace(mw.openRecentWorkspace)
+
+	mw.startPage.OnPin(func(path string) {
+		settings := mw.appState.GetSettings()
+		settings.PinFile(path)
+		mw.saveSettings(settings)
+	})
+
+	mw.startPage.OnUnpin(func(path string) {
+		settings := mw.appState.GetSettings()
+		settings.UnpinFile(path)
+		mw.saveSettings(settings)
+	})
+
+	// Bookmarks panel
+	mw.bookmarksPanel.OnOpen(func(bookmark state.Bookmark) {
+		section := workspace.SectionDependencies
+		if bookmark.Kind == state.BookmarkPlugin {
+			section = workspace.SectionPlugins
+		}
+		mw.openMatch(workspace.Match{
+			Path:    bookmark.Path,
+			Section: section,
+			Key:     bookmark.Key(),
+		})
+	})
+
+	mw.bookmarksPanel.OnEditNote(func(bookmark state.Bookmark) {
+		mw.showBookmarkNoteDialog(bookmark)
+	})
+
+	mw.bookmarksPanel.OnRemove(func(bookmark state.Bookmark) {
+		mw.bookmarks.Remove(bookmark.Path, bookmark.Kind, bookmark.GroupID, bookmark.ArtifactID)
+		mw.saveBookmarks()
 	})
 
 	// Properties panel
@@ -221,37 +613,203 @@ func (mw *MainWindow) setupCallbacks() {
 		mw.presenter.UpdateProperties(props)
 	})
 
+	mw.propsPanel.OnConfirmRemove(func(key string, remove func()) {
+		impact := pom.PropertyRemovalImpact(mw.presenter.GetCurrentProject(), key)
+		mw.confirmImpact("Remove Property", key, impact, remove)
+	})
+
 	// Lifecycle panel
 	mw.lifecyclePanel.OnAddExecution(func(pluginIndex int, execution pom.PluginExecution) {
 		mw.handleAddExecution(pluginIndex, execution)
 	})
 
-	mw.lifecyclePanel.OnRemoveExecution(func(pluginIndex int, executionID string) {
-		mw.handleRemoveExecution(pluginIndex, executionID)
+	mw.lifecyclePanel.OnEditExecution(func(pluginIndex, executionIndex int) {
+		mw.handleEditExecution(pluginIndex, executionIndex)
 	})
 
-	// Tree panel - navigate to corresponding tab when node selected
-	mw.treePanel.OnNodeSelected(func(nodeType string, id string) {
-		fyne.Do(func() {
-			switch nodeType {
-			case "coordinates":
-				mw.tabContainer.SelectIndex(0) // Coordinates tab
-			case "dependencies", "dep":
-				mw.tabContainer.SelectIndex(1) // Dependencies tab
-			case "plugins", "plugin":
-				mw.tabContainer.SelectIndex(2) // Plugins tab
-			case "properties", "prop":
-				mw.tabContainer.SelectIndex(3) // Properties tab
-			case "profiles", "profile":
-				mw.tabContainer.SelectIndex(4) // Profiles tab
-			}
-		})
+	mw.lifecyclePanel.OnRemoveExecution(func(pluginIndex, executionIndex int) {
+		mw.handleRemoveExecution(pluginIndex, executionIndex)
+	})
+
+	mw.lifecyclePanel.OnMoveExecution(func(phase string, from, to int) {
+		if err := mw.presenter.MoveExecution(phase, from, to); err != nil {
+			dialog.ShowError(err, mw.window)
+		}
 	})
 
+	// Tree panel - navigate to corresponding tab and row when node selected,
+	// and edit the row when double-clicked
+	mw.treePanel.OnNodeSelected(mw.revealTreeNode)
+	mw.treePanel.OnNodeActivated(func(nodeType string, id string) {
+		mw.revealTreeNode(nodeType, id)
+		switch nodeType {
+		case "dep":
+			mw.depsPanel.EditSelected()
+		case "plugin":
+			mw.pluginsPanel.EditSelected()
+		case "prop":
+			mw.propsPanel.EditSelected()
+		}
+	})
+
+	// Right-clicking a tree node or list row shows the same menu
+	mw.treePanel.OnContextMenu(mw.itemMenu)
+	mw.depsPanel.OnContextMenu(mw.itemMenu)
+	mw.pluginsPanel.OnContextMenu(mw.itemMenu)
+	mw.propsPanel.OnContextMenu(mw.itemMenu)
+	mw.profilesPanel.OnContextMenu(mw.itemMenu)
+
 	// Setup keyboard shortcuts
 	mw.setupKeyboardShortcuts()
 }
 
+// revealTreeNode switches to the tab of a tree node and selects its row,
+// such as the dependency of "dep" node 3
+func (mw *MainWindow) revealTreeNode(nodeType string, id string) {
+	fyne.Do(func() {
+		switch nodeType {
+		case "coordinates":
+			mw.tabContainer.SelectIndex(0) // Coordinates tab
+		case "dependencies", "dep":
+			mw.tabContainer.SelectIndex(1) // Dependencies tab
+		case "plugins", "plugin":
+			mw.tabContainer.SelectIndex(2) // Plugins tab
+		case "properties", "prop":
+			mw.tabContainer.SelectIndex(3) // Properties tab
+		case "profiles", "profile":
+			mw.tabContainer.SelectIndex(4) // Profiles tab
+		}
+	})
+
+	project := mw.presenter.GetCurrentProject()
+	if project == nil {
+		return
+	}
+	index, _ := strconv.Atoi(id)
+	switch nodeType {
+	case "dep":
+		mw.depsPanel.SelectDependencyAt(index)
+	case "plugin":
+		if project.Build != nil && index >= 0 && index < len(project.Build.Plugins) {
+			plugin := project.Build.Plugins[index]
+			mw.pluginsPanel.SelectPlugin(plugin.GroupID, plugin.ArtifactID)
+		}
+	case "prop":
+		mw.propsPanel.SelectProperty(id)
+	case "profile":
+		if index >= 0 && index < len(project.Profiles) {
+			mw.profilesPanel.SelectProfile(project.Profiles[index].ID)
+		}
+	}
+}
+
+// itemMenu returns the context menu of a dependency, plugin, property or
+// profile, identified as tree nodes are, or nil for other nodes
+func (mw *MainWindow) itemMenu(nodeType string, id string) *fyne.Menu {
+	project := mw.presenter.GetCurrentProject()
+	if project == nil {
+		return nil
+	}
+
+	// The name for status messages, the XML to copy, and where it is in the
+	// preview
+	var name, field, gav string
+	var xmlSnippet func() (string, error)
+	var edit, remove func()
+	index, _ := strconv.Atoi(id)
+	switch nodeType {
+	case "coordinates":
+		return fyne.NewMenu("", fyne.NewMenuItem("Copy GAV", func() {
+			mw.copyGAV(project.Coordinates.String())
+		}))
+	case "dep":
+		if index < 0 || index >= len(project.Dependencies) {
+			return nil
+		}
+		dep := project.Dependencies[index]
+		name, field, gav = dep.ArtifactID, fmt.Sprintf("dependencies[%d]", index), dep.GAV()
+		xmlSnippet = func() (string, error) { return pom.DependencySnippet([]pom.Dependency{dep}) }
+		edit = mw.depsPanel.EditSelected
+		remove = func() {
+			if err := mw.presenter.RemoveDependency(dep.GroupID, dep.ArtifactID); err != nil {
+				dialog.ShowError(err, mw.window)
+			}
+		}
+	case "plugin":
+		if project.Build == nil || index < 0 || index >= len(project.Build.Plugins) {
+			return nil
+		}
+		plugin := project.Build.Plugins[index]
+		name, field, gav = plugin.ArtifactID, fmt.Sprintf("build.plugins[%d]", index), plugin.GAV()
+		xmlSnippet = func() (string, error) { return pom.PluginSnippet(plugin) }
+		edit = mw.pluginsPanel.EditSelected
+		remove = func() {
+			mw.confirmImpact("Remove Plugin", plugin.ArtifactID, pom.PluginRemovalImpact(plugin), func() {
+				mw.presenter.RemovePlugin(plugin.GroupID, plugin.ArtifactID)
+			})
+		}
+	case "prop":
+		value, ok := project.Properties[id]
+		if !ok {
+			return nil
+		}
+		name, field = id, "properties."+id
+		xmlSnippet = func() (string, error) { return pom.PropertySnippet(id, value) }
+		edit = mw.propsPanel.EditSelected
+		remove = mw.propsPanel.RemoveSelected
+	case "profile":
+		if index < 0 || index >= len(project.Profiles) {
+			return nil
+		}
+		profile := project.Profiles[index]
+		name, field = profile.ID, fmt.Sprintf("profiles[%d]", index)
+		xmlSnippet = func() (string, error) { return pom.ProfileSnippet(profile) }
+	default:
+		return nil
+	}
+
+	var items []*fyne.MenuItem
+	if edit != nil {
+		editItem := fyne.NewMenuItem("Edit...", func() {
+			mw.revealTreeNode(nodeType, id)
+			edit()
+		})
+		removeItem := fyne.NewMenuItem("Remove", func() {
+			mw.revealTreeNode(nodeType, id)
+			remove()
+		})
+		editItem.Disabled = mw.presenter.IsReadOnly()
+		removeItem.Disabled = mw.presenter.IsReadOnly()
+		items = append(items, editItem, removeItem, fyne.NewMenuItemSeparator())
+	}
+	if gav != "" {
+		items = append(items, fyne.NewMenuItem("Copy GAV", func() {
+			mw.copyGAV(gav)
+		}))
+	}
+	items = append(items,
+		fyne.NewMenuItem("Copy as XML", func() {
+			text, err := xmlSnippet()
+			if err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
+			fyne.CurrentApp().Clipboard().SetContent(text)
+			mw.statusLabel.SetText(fmt.Sprintf("Copied %s as XML", name))
+		}),
+		fyne.NewMenuItem("Show in Preview", func() {
+			mw.previewPane.ShowElement(field)
+		}),
+	)
+	return fyne.NewMenu("", items...)
+}
+
+// copyGAV copies groupId:artifactId:version coordinates to the clipboard
+func (mw *MainWindow) copyGAV(gav string) {
+	fyne.CurrentApp().Clipboard().SetContent(gav)
+	mw.statusLabel.SetText("Copied " + gav)
+}
+
 // setupKeyboardShortcuts configures keyboard shortcuts for the main window
 func (mw *MainWindow) setupKeyboardShortcuts() {
 	// Ctrl+N: New POM
@@ -286,12 +844,72 @@ func (mw *MainWindow) setupKeyboardShortcuts() {
 		mw.handleSaveAs()
 	})
 
-	// Ctrl+W: Close
+	// Ctrl+Z: Undo
+	mw.window.Canvas().AddShortcut(&desktop.CustomShortcut{
+		KeyName:  fyne.KeyZ,
+		Modifier: fyne.KeyModifierControl,
+	}, func(shortcut fyne.Shortcut) {
+		mw.handleUndo()
+	})
+
+	// Ctrl+Y / Ctrl+Shift+Z: Redo
+	mw.window.Canvas().AddShortcut(&desktop.CustomShortcut{
+		KeyName:  fyne.KeyY,
+		Modifier: fyne.KeyModifierControl,
+	}, func(shortcut fyne.Shortcut) {
+		mw.handleRedo()
+	})
+	mw.window.Canvas().AddShortcut(&desktop.CustomShortcut{
+		KeyName:  fyne.KeyZ,
+		Modifier: fyne.KeyModifierControl | fyne.KeyModifierShift,
+	}, func(shortcut fyne.Shortcut) {
+		mw.handleRedo()
+	})
+
+	// Ctrl+F: Filter
+	mw.window.Canvas().AddShortcut(&desktop.CustomShortcut{
+		KeyName:  fyne.KeyF,
+		Modifier: fyne.KeyModifierControl,
+	}, func(shortcut fyne.Shortcut) {
+		mw.handleFilter()
+	})
+
+	// Ctrl+Shift+F: Find in Workspace
+	mw.window.Canvas().AddShortcut(&desktop.CustomShortcut{
+		KeyName:  fyne.KeyF,
+		Modifier: fyne.KeyModifierControl | fyne.KeyModifierShift,
+	}, func(shortcut fyne.Shortcut) {
+		mw.handleWorkspaceSearch()
+	})
+
+	// Ctrl+Shift+O: Go to Artifact
+	mw.window.Canvas().AddShortcut(&desktop.CustomShortcut{
+		KeyName:  fyne.KeyO,
+		Modifier: fyne.KeyModifierControl | fyne.KeyModifierShift,
+	}, func(shortcut fyne.Shortcut) {
+		mw.handleGotoArtifact()
+	})
+
+	// Ctrl+W: Close Tab
 	mw.window.Canvas().AddShortcut(&desktop.CustomShortcut{
 		KeyName:  fyne.KeyW,
 		Modifier: fyne.KeyModifierControl,
 	}, func(shortcut fyne.Shortcut) {
-		mw.window.Close()
+		mw.handleCloseDocument()
+	})
+
+	// Ctrl+Tab / Ctrl+Shift+Tab: Next / Previous Tab
+	mw.window.Canvas().AddShortcut(&desktop.CustomShortcut{
+		KeyName:  fyne.KeyTab,
+		Modifier: fyne.KeyModifierControl,
+	}, func(shortcut fyne.Shortcut) {
+		mw.switchDocument(1)
+	})
+	mw.window.Canvas().AddShortcut(&desktop.CustomShortcut{
+		KeyName:  fyne.KeyTab,
+		Modifier: fyne.KeyModifierControl | fyne.KeyModifierShift,
+	}, func(shortcut fyne.Shortcut) {
+		mw.switchDocument(-1)
 	})
 
 	// Ctrl+Q: Quit
@@ -299,7 +917,7 @@ func (mw *MainWindow) setupKeyboardShortcuts() {
 		KeyName:  fyne.KeyQ,
 		Modifier: fyne.KeyModifierControl,
 	}, func(shortcut fyne.Shortcut) {
-		mw.window.Close()
+		mw.handleClose()
 	})
 
 	// F1: Help
@@ -341,13 +959,31 @@ func (mw *MainWindow) debouncedRefreshUI() {
 // refreshUI updates all UI components from current state
 func (mw *MainWindow) refreshUI() {
 	project := mw.presenter.GetCurrentProject()
+	mw.showStartPage(project == nil)
 	if project == nil {
 		return
 	}
 
+	// Remember how the previous file was left before the panels change
+	path := mw.appState.GetFilePath()
+	fileChanged := path != mw.uiStatePath
+	if fileChanged {
+		mw.rememberUIState()
+		mw.uiStatePath = path
+		mw.statsPanel.ClearTransitive()
+		// Changes made on disk while another document was shown
+		fyne.Do(func() {
+			if mw.changedOnDisk[path] {
+				delete(mw.changedOnDisk, path)
+				mw.checkDiskChanges()
+			}
+		})
+	}
+
 	// Update panels
 	mw.coordsPanel.LoadProject(project)
 	mw.depsPanel.LoadDependencies(project.Dependencies)
+	mw.depsPanel.LoadManaged(project.DependencyManagement)
 
 	if project.Build != nil {
 		mw.pluginsPanel.LoadPlugins(project.Build.Plugins)
@@ -356,12 +992,30 @@ func (mw *MainWindow) refreshUI() {
 	mw.propsPanel.LoadProperties(project.Properties)
 	mw.profilesPanel.LoadProfiles(project.Profiles)
 	mw.lifecyclePanel.LoadProject(project)
+	mw.timelinePanel.LoadProject(project)
 	mw.treePanel.LoadProject(project)
+	// Git status only changes on disk, so it is read again for a new file
+	if mw.appState.GetFilePath() != mw.gitStatusPath {
+		mw.refreshGitStatus()
+	}
+	mw.bookmarksPanel.LoadBookmarks(mw.bookmarks.InDirectory(mw.workspaceRoot()))
+	mw.applyReadOnly(mw.appState.IsReadOnly())
+
+	// Show what the project inherits from its parent chain
+	inheritance := mw.presenter.GetInheritance()
+	mw.depsPanel.LoadInherited(inheritance.Dependencies)
+	mw.pluginsPanel.LoadInherited(inheritance.Plugins)
+	mw.propsPanel.LoadInherited(inheritance.Properties)
+	mw.inheritancePanel.LoadInheritance(inheritance)
+	if fileChanged {
+		mw.restoreUIState(path)
+	}
 
 	// Validate and update preview
 	result, _ := mw.presenter.ValidateCurrent()
 
 	// Update errors panel
+	mw.errorsPanel.SetWarnings("Inheritance", inheritance.Warnings)
 	mw.errorsPanel.SetErrors(result)
 
 	// Update preview pane
@@ -369,135 +1023,2131 @@ func (mw *MainWindow) refreshUI() {
 	xmlData, err := generator.Generate(project)
 	if err == nil {
 		mw.previewPane.SetXML(string(xmlData))
+		mw.xmlSourcePanel.SetXML(mw.appState.GetFilePath(), string(xmlData))
 	}
+	mw.statsPanel.LoadProject(project, xmlData)
 
-	errorCount := len(result.Errors.AllErrors())
+	errorCount := result.Errors.Count(pom.SeverityError)
 	mw.previewPane.SetValidationStatus(result.Valid, errorCount)
+	mw.appState.SetErrorCount(errorCount)
 
 	// Update status bar (must be on UI thread)
 	filePath := mw.appState.GetFilePath()
+	modified := ""
+	if mw.appState.IsDirty() {
+		modified = "* "
+	}
 	statusText := ""
-	if filePath != "" {
+	if state.IsScratchPath(filePath) {
+		statusText = fmt.Sprintf("Scratch: %s | %s", filepath.Base(filePath), mw.getValidationStatus(result))
+	} else if filePath != "" {
 		statusText = fmt.Sprintf("File: %s | %s", filePath, mw.getValidationStatus(result))
 	} else {
 		statusText = fmt.Sprintf("Unsaved | %s", mw.getValidationStatus(result))
 	}
 
+	statusText = modified + statusText
+	title := fmt.Sprintf("%sUntitled - %s", modified, mw.baseTitle)
+	if filePath != "" {
+		title = fmt.Sprintf("%s%s - %s", modified, filepath.Base(filePath), mw.baseTitle)
+	}
+
 	fyne.Do(func() {
 		mw.statusLabel.SetText(statusText)
+		mw.window.SetTitle(title)
+		mw.updateUndoMenu()
+		mw.updatePinMenu()
 	})
-}
+	mw.updateFilterCounts()
+	mw.updateDocumentTabs()
+	mw.watchDocuments()
 
-// getValidationStatus returns validation status string
-func (mw *MainWindow) getValidationStatus(result pom.ValidationResult) string {
-	if result.Valid {
-		return "✓ Valid"
-	}
-	errorCount := len(result.Errors.AllErrors())
-	return fmt.Sprintf("✗ Invalid (%d errors)", errorCount)
+	mw.revealPendingMatch()
 }
 
-// Menu handlers
-func (mw *MainWindow) handleNew() {
-	wiz := wizard.NewCreateWizard(mw.window)
-	wiz.Show(func(coords pom.Coordinates, template string) {
-		err := mw.presenter.CreateNewPOM(coords, template)
-		if err != nil {
-			dialog.ShowError(err, mw.window)
+// updateDocumentTabs lists the open documents above the editor, selecting
+// the active one; the tabs are hidden while a single document is open
+func (mw *MainWindow) updateDocumentTabs() {
+	docs := mw.appState.Documents()
+	active := mw.appState.ActiveDocument()
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		items := make([]*container.TabItem, len(docs))
+		for i, doc := range docs {
+			items[i] = container.NewTabItem(documentTitle(doc), container.NewStack())
+		}
+		mw.syncingTabs = true
+		mw.documentTabs.SetItems(items)
+		mw.documentTabs.SelectIndex(active)
+		mw.syncingTabs = false
+		if len(docs) > 1 {
+			mw.documentTabs.Show()
+		} else {
+			mw.documentTabs.Hide()
 		}
 	})
 }
 
-func (mw *MainWindow) handleOpen() {
-	fileDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
-		if err != nil || reader == nil {
-			return
+// watchDocuments watches the files of the open documents for changes made
+// by other programs. Scratch buffers are only written here.
+func (mw *MainWindow) watchDocuments() {
+	if mw.fileWatcher == nil {
+		return
+	}
+	var paths []string
+	for _, doc := range mw.appState.Documents() {
+		if doc.FilePath != "" && !state.IsScratchPath(doc.FilePath) {
+			paths = append(paths, doc.FilePath)
 		}
-		defer reader.Close()
+	}
+	// Files that cannot be watched are simply not reloaded
+	_ = mw.fileWatcher.Watch(paths)
+}
 
-		path := reader.URI().Path()
-		err = mw.presenter.LoadPOM(path)
-		if err != nil {
-			dialog.ShowError(err, mw.window)
+// handleDiskChange checks an open file another program changed: right away
+// when it is shown, otherwise once it is
+func (mw *MainWindow) handleDiskChange(path string) {
+	fyne.Do(func() {
+		if path != mw.appState.GetFilePath() {
+			mw.changedOnDisk[path] = true
 			return
 		}
-
-		// Add to recent files
-		settings := mw.appState.GetSettings()
-		settings.AddRecentFile(path)
-		mw.appState.SetSettings(settings)
-		state.SaveSettings(settings) // Save to persist
-	}, mw.window)
-
-	fileDialog.SetFilter(storage.NewExtensionFileFilter([]string{".xml"}))
-	fileDialog.Show()
+		mw.checkDiskChanges()
+	})
 }
 
-// updateRecentFilesMenu updates the Open Recent submenu
-func (mw *MainWindow) updateRecentFilesMenu(menu *fyne.Menu) {
-	menu.Items = nil // Clear existing items
+// checkDiskChanges offers to reload the file shown when another program
+// changed it, summarizing the unsaved edits and conflicts there are too
+func (mw *MainWindow) checkDiskChanges() {
+	// The dialog showing already offers the latest version
+	if mw.diskPrompt {
+		return
+	}
+
+	name := filepath.Base(mw.appState.GetFilePath())
+	change, err := mw.presenter.CheckDiskChanges()
+	switch {
+	case errors.Is(err, pom.ErrFileNotFound):
+		mw.statusLabel.SetText(fmt.Sprintf("%s was removed or renamed by another program; saving writes it again", name))
+		return
+	case err != nil:
+		// Files caught half-written are read again after the next change
+		mw.statusLabel.SetText(fmt.Sprintf("Could not read %s after it changed: %v", name, err))
+		return
+	case change == nil:
+		return
+	}
+
+	mw.diskPrompt = true
+	changeDialog := dialogs.NewDiskChangeDialog(mw.window, name, change.Merge)
+	changeDialog.Show(func() {
+		mw.diskPrompt = false
+		if err := mw.presenter.LoadPOM(change.Path); err != nil {
+			dialog.ShowError(err, mw.window)
+		}
+	}, func() {
+		mw.diskPrompt = false
+		mw.presenter.KeepLocalChanges(change)
+		// The file may have changed again while the dialog was showing
+		mw.checkDiskChanges()
+	})
+}
+
+// documentTitle labels the tab of a document with its file and directory
+// names, marking unsaved changes and counting validation errors
+func documentTitle(doc state.DocumentInfo) string {
+	title := "Untitled"
+	switch {
+	case state.IsScratchPath(doc.FilePath):
+		title = filepath.Base(doc.FilePath)
+	case doc.FilePath != "":
+		// Most POMs are called pom.xml, so their directory tells them apart
+		title = filepath.Join(filepath.Base(filepath.Dir(doc.FilePath)), filepath.Base(doc.FilePath))
+	}
+	if doc.ReadOnly {
+		title = "🔒 " + title
+	}
+	if doc.Dirty {
+		title = "* " + title
+	}
+	if doc.ErrorCount > 0 {
+		title += fmt.Sprintf(" (%d ✗)", doc.ErrorCount)
+	}
+	return title
+}
+
+// openFile shows the POM at path, switching to its tab when it is open
+// already and opening it in a new tab otherwise
+func (mw *MainWindow) openFile(path string) error {
+	if index := mw.appState.FindDocument(path); index >= 0 {
+		return mw.presenter.SwitchDocument(index)
+	}
+	return mw.openInNewTab(func() error {
+		return mw.presenter.LoadPOM(path)
+	})
+}
+
+// openInNewTab runs open in a new document tab, or in the current one while
+// nothing is loaded. The new tab is closed again when open fails.
+func (mw *MainWindow) openInNewTab(open func() error) error {
+	if mw.presenter.GetCurrentProject() == nil {
+		return open()
+	}
+
+	previous := mw.appState.ActiveDocument()
+	mw.presenter.NewDocument()
+	if err := open(); err != nil {
+		_ = mw.presenter.CloseDocument(mw.appState.ActiveDocument())
+		_ = mw.presenter.SwitchDocument(previous)
+		return err
+	}
+	return nil
+}
+
+// switchDocument activates the document step tabs away, wrapping around
+// (Ctrl+Tab, Ctrl+Shift+Tab)
+func (mw *MainWindow) switchDocument(step int) {
+	count := len(mw.appState.Documents())
+	if count < 2 {
+		return
+	}
+	index := ((mw.appState.ActiveDocument()+step)%count + count) % count
+	if err := mw.presenter.SwitchDocument(index); err != nil {
+		dialog.ShowError(err, mw.window)
+	}
+}
+
+// handleCloseDocument closes the active document (Ctrl+W), or the window
+// when it is the only one
+func (mw *MainWindow) handleCloseDocument() {
+	if len(mw.appState.Documents()) < 2 {
+		mw.handleClose()
+		return
+	}
+	mw.closeDocument(mw.appState.ActiveDocument())
+}
+
+// closeDocument shows the document at index and closes it after confirming
+// unsaved changes; a scratch buffer is saved instead
+func (mw *MainWindow) closeDocument(index int) {
+	if err := mw.presenter.SwitchDocument(index); err != nil {
+		dialog.ShowError(err, mw.window)
+		return
+	}
+	mw.confirmDiscard(func() {
+		if err := mw.presenter.CloseDocument(mw.appState.ActiveDocument()); err != nil {
+			dialog.ShowError(err, mw.window)
+		}
+	})
+}
+
+// handleFilter shows the global filter bar and focuses it
+func (mw *MainWindow) handleFilter() {
+	mw.filterBar.Show()
+	mw.window.Canvas().Focus(mw.filterEntry)
+}
+
+// applyGlobalFilter narrows down the dependencies, plugins, properties and
+// profiles to those containing text, and highlights them in the tree
+func (mw *MainWindow) applyGlobalFilter(text string) {
+	mw.depsPanel.SetFilter(text)
+	mw.pluginsPanel.SetFilter(text)
+	mw.propsPanel.SetFilter(text)
+	mw.profilesPanel.SetFilter(text)
+	mw.treePanel.SetHighlight(text)
+	mw.updateFilterCounts()
+}
+
+// updateFilterCounts shows how many entries match the global filter in the
+// titles of the tabs it narrows down
+func (mw *MainWindow) updateFilterCounts() {
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		filter := strings.TrimSpace(mw.filterEntry.Text)
+		counts := map[fyne.CanvasObject]func(string) int{
+			mw.depsPanel.GetContainer():     mw.depsPanel.CountMatches,
+			mw.pluginsPanel.GetContainer():  mw.pluginsPanel.CountMatches,
+			mw.propsPanel.GetContainer():    mw.propsPanel.CountMatches,
+			mw.profilesPanel.GetContainer(): mw.profilesPanel.CountMatches,
+		}
+		for _, tab := range mw.tabContainer.Items {
+			count, ok := counts[tab.Content]
+			if !ok {
+				continue
+			}
+			tab.Text = tabTitle(tab)
+			if filter != "" {
+				tab.Text += fmt.Sprintf(" (%d)", count(filter))
+			}
+		}
+		mw.tabContainer.Refresh()
+	})
+}
+
+// tabTitle returns the title of a tab without the match count of the
+// global filter
+func tabTitle(tab *container.TabItem) string {
+	return strings.SplitN(tab.Text, " (", 2)[0]
+}
+
+// rememberUIState saves how the editor is left for the file shown, so it
+// looks the same when the file is opened again
+func (mw *MainWindow) rememberUIState() {
+	path := mw.uiStatePath
+	if path == "" || state.IsScratchPath(path) {
+		return
+	}
+
+	mw.uiStates.Set(path, mw.currentUIState())
+	// Losing the UI state is harmless, so errors are not worth a dialog
+	_ = state.SaveUIStates(mw.uiStates)
+}
+
+// currentUIState returns how the editor is left for the file shown
+func (mw *MainWindow) currentUIState() state.FileUIState {
+	uiState := state.FileUIState{
+		ExpandedNodes:    mw.treePanel.ExpandedNodes(),
+		OpenPhases:       mw.lifecyclePanel.OpenPhases(),
+		DependencyFilter: mw.depsPanel.Filter(),
+	}
+	if tab := mw.tabContainer.Selected(); tab != nil {
+		uiState.Tab = tabTitle(tab)
+	}
+	return uiState
+}
+
+// restoreUIState shows a file the way it was left; files without a saved
+// state start with collapsed sections and no filter
+func (mw *MainWindow) restoreUIState(path string) {
+	uiState, _ := mw.uiStates.Get(path)
+	mw.treePanel.SetExpandedNodes(uiState.ExpandedNodes)
+	mw.lifecyclePanel.SetOpenPhases(uiState.OpenPhases)
+	mw.depsPanel.SetFilter(uiState.DependencyFilter)
+	if uiState.Tab == "" {
+		return
+	}
+	fyne.Do(func() {
+		for _, tab := range mw.tabContainer.Items {
+			if tabTitle(tab) == uiState.Tab {
+				mw.tabContainer.Select(tab)
+				return
+			}
+		}
+	})
+}
+
+// applyReadOnly toggles view-only mode across the editor panels
+func (mw *MainWindow) applyReadOnly(readOnly bool) {
+	mw.coordsPanel.SetReadOnly(readOnly)
+	mw.depsPanel.SetReadOnly(readOnly)
+	mw.pluginsPanel.SetReadOnly(readOnly)
+	mw.propsPanel.SetReadOnly(readOnly)
+	mw.lifecyclePanel.SetReadOnly(readOnly)
+	mw.xmlSourcePanel.SetReadOnly(readOnly)
+
+	fyne.Do(func() {
+		if readOnly {
+			mw.readOnlyBanner.Show()
+		} else {
+			mw.readOnlyBanner.Hide()
+		}
+	})
+}
+
+// getValidationStatus returns validation status string
+func (mw *MainWindow) getValidationStatus(result pom.ValidationResult) string {
+	warningCount := result.Errors.Count(pom.SeverityWarning)
+	if result.Valid {
+		if warningCount > 0 {
+			return fmt.Sprintf("✓ Valid (%d warnings)", warningCount)
+		}
+		return "✓ Valid"
+	}
+	errorCount := result.Errors.Count(pom.SeverityError)
+	return fmt.Sprintf("✗ Invalid (%d errors)", errorCount)
+}
+
+// Menu handlers
+func (mw *MainWindow) handleNew() {
+	mw.handleNewFromTemplate("")
+}
+
+// handleNewFromTemplate runs the project wizard with template selected, or
+// the wizard's default for ""
+func (mw *MainWindow) handleNewFromTemplate(template string) {
+	wiz := wizard.NewCreateWizard(mw.window, mw.presenter.TemplateManager())
+	if template != "" {
+		wiz.SetTemplate(template)
+	}
+	wiz.Show(func(coords pom.Coordinates, template string) {
+		err := mw.openInNewTab(func() error {
+			if template == "javacard" {
+				return mw.presenter.CreateJavaCardPOM(coords, wiz.JavaCardApplet())
+			}
+			return mw.presenter.CreateNewPOMWithValues(coords, template, wiz.Values())
+		})
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		// The source tree goes next to the POM, so it must be saved first
+		if wiz.Scaffold() {
+			mw.saveAs(mw.scaffoldSources)
+		}
+	})
+}
+
+// scaffoldSources creates the source tree of the new project next to its
+// saved POM
+func (mw *MainWindow) scaffoldSources() {
+	project := mw.presenter.GetCurrentProject()
+	filePath := mw.appState.GetFilePath()
+	if project == nil || filePath == "" {
+		return
+	}
+
+	dir := filepath.Dir(filePath)
+	var paths []string
+	for _, file := range workspace.ScaffoldFiles(dir, project) {
+		paths = append(paths, file.Path)
+	}
+	var result *workspace.ScaffoldResult
+	err := mw.track(paths, "scaffold "+project.Coordinates.String(), func() error {
+		var err error
+		result, err = workspace.Scaffold(dir, project)
+		return err
+	})
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("creating source tree: %w", err), mw.window)
+		return
+	}
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "Created in %s:\n", dir)
+	for _, path := range result.Created {
+		rel, _ := filepath.Rel(dir, path)
+		fmt.Fprintf(&message, "\n  %s", filepath.ToSlash(rel))
+	}
+	if len(result.Existing) > 0 {
+		fmt.Fprintf(&message, "\n\n%d existing file(s) were left alone.", len(result.Existing))
+	}
+	if result.TestFramework == "" && project.Packaging != pom.PackagingPom {
+		message.WriteString("\n\nThe project has no test framework, so no test class was written.")
+	}
+	dialog.ShowInformation("Source Tree", message.String(), mw.window)
+}
+
+func (mw *MainWindow) handleOpen() {
+	mw.showOpenDialog()
+}
+
+// showOpenDialog lets the user pick a POM file to open in a new tab
+func (mw *MainWindow) showOpenDialog() {
+	fileDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil || reader == nil {
+			return
+		}
+		defer reader.Close()
+
+		mw.openAndRemember(reader.URI().Path())
+	}, mw.window)
+
+	fileDialog.SetFilter(storage.NewExtensionFileFilter([]string{".xml"}))
+	fileDialog.Show()
+}
+
+// openAndRemember opens the POM at path and adds it to the recent files
+func (mw *MainWindow) openAndRemember(path string) {
+	if err := mw.openFile(path); err != nil {
+		dialog.ShowError(err, mw.window)
+		return
+	}
+	settings := mw.appState.GetSettings()
+	settings.AddRecentFile(path)
+	mw.saveSettings(settings)
+}
+
+// handleDrop opens the POMs, and the folders holding one, dropped onto the
+// window, and offers adding dropped JARs as dependencies
+func (mw *MainWindow) handleDrop(_ fyne.Position, uris []fyne.URI) {
+	for _, uri := range uris {
+		path := uri.Path()
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			pomPath := filepath.Join(path, "pom.xml")
+			if _, err := os.Stat(pomPath); err != nil {
+				mw.statusLabel.SetText(fmt.Sprintf("No pom.xml in %s", filepath.Base(path)))
+				continue
+			}
+			mw.openAndRemember(pomPath)
+			continue
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".xml":
+			mw.openAndRemember(path)
+		case ".jar":
+			mw.offerJARDependency(path)
+		default:
+			mw.statusLabel.SetText(fmt.Sprintf("Not a POM or JAR: %s", filepath.Base(path)))
+		}
+	}
+}
+
+// offerJARDependency offers adding the artifact a JAR was built from as a
+// dependency. Shaded JARs bundle several, so one is picked first.
+func (mw *MainWindow) offerJARDependency(path string) {
+	if mw.presenter.GetCurrentProject() == nil {
+		dialog.ShowInformation("Add Dependency from JAR",
+			fmt.Sprintf("Open a POM to add %s to as a dependency.", filepath.Base(path)), mw.window)
+		return
+	}
+	if mw.presenter.IsReadOnly() {
+		dialog.ShowInformation("Add Dependency from JAR", "This file is read-only, so no dependency can be added.", mw.window)
+		return
+	}
+
+	coords, err := jar.Coordinates(path)
+	if err != nil {
+		dialog.ShowError(err, mw.window)
+		return
+	}
+	if len(coords) == 1 {
+		mw.showAddDependency(&pom.Dependency{GroupID: coords[0].GroupID, ArtifactID: coords[0].ArtifactID, Version: coords[0].Version})
+		return
+	}
+
+	options := make([]string, len(coords))
+	for i, c := range coords {
+		options[i] = c.String()
+	}
+	choice := widget.NewRadioGroup(options, nil)
+	choice.SetSelected(options[0])
+	content := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf("%s bundles several artifacts. Which one should be added?", filepath.Base(path))),
+		container.NewVScroll(choice),
+	)
+	dialog.ShowCustomConfirm("Add Dependency from JAR", "Next", "Cancel", content, func(ok bool) {
+		index := slices.Index(options, choice.Selected)
+		if !ok || index < 0 {
+			return
+		}
+		c := coords[index]
+		mw.showAddDependency(&pom.Dependency{GroupID: c.GroupID, ArtifactID: c.ArtifactID, Version: c.Version})
+	}, mw.window)
+}
+
+// showAddDependency shows the dialog adding a dependency, filled in from
+// prefill unless it is nil
+func (mw *MainWindow) showAddDependency(prefill *pom.Dependency) {
+	depDialog := dialogs.NewDependencyDialog(mw.window)
+	depDialog.SetManagedBy(mw.presenter.GetCurrentProject())
+	depDialog.SetVerifier(mw.remoteClient())
+	position, after := mw.insertPosition()
+	depDialog.SetPosition(position, after)
+	add := func(dep pom.Dependency) {
+		mw.presenter.AddDependencyAt(dep, depDialog.Position(), after)
+	}
+	if prefill != nil {
+		depDialog.ShowAddPrefilled(*prefill, add)
+	} else {
+		depDialog.ShowAdd(add)
+	}
+}
+
+// updateRecentFilesMenu updates the Open Recent submenu: pinned files
+// first, then recent files and recent workspaces
+func (mw *MainWindow) updateRecentFilesMenu(menu *fyne.Menu) {
+	menu.Items = nil // Clear existing items
 
 	settings := mw.appState.GetSettings()
 	recentFiles := settings.GetRecentFiles()
 
-	if len(recentFiles) == 0 {
-		menu.Items = append(menu.Items, fyne.NewMenuItem("(No recent files)", nil))
-		menu.Items[0].Disabled = true
+	for _, filePath := range settings.PinnedFiles {
+		path := filePath
+		name := filepath.Join(filepath.Base(filepath.Dir(path)), filepath.Base(path))
+		menu.Items = append(menu.Items, fyne.NewMenuItem("📌 "+name, func() {
+			if err := mw.openFile(path); err != nil {
+				dialog.ShowError(err, mw.window)
+			}
+		}))
+	}
+	if len(settings.PinnedFiles) > 0 {
+		menu.Items = append(menu.Items, fyne.NewMenuItemSeparator())
+	}
+
+	if len(recentFiles) == 0 {
+		empty := fyne.NewMenuItem("(No recent files)", nil)
+		empty.Disabled = true
+		menu.Items = append(menu.Items, empty)
+	}
+
+	for _, filePath := range recentFiles {
+		// Create copy for closure
+		path := filePath
+		// Get file name only for display
+		fileName := filePath
+		if idx := len(filePath) - 1; idx >= 0 {
+			for i := idx; i >= 0; i-- {
+				if filePath[i] == '/' || filePath[i] == '\\' {
+					fileName = filePath[i+1:]
+					break
+				}
+			}
+		}
+
+		item := fyne.NewMenuItem(fileName, func() {
+			if err := mw.openFile(path); err != nil {
+				dialog.ShowError(err, mw.window)
+			}
+		})
+		menu.Items = append(menu.Items, item)
+	}
+
+	if workspaces := settings.GetRecentWorkspaces(); len(workspaces) > 0 {
+		menu.Items = append(menu.Items, fyne.NewMenuItemSeparator())
+		for _, dir := range workspaces {
+			menu.Items = append(menu.Items, fyne.NewMenuItem("📁 "+filepath.Base(dir), func() {
+				mw.openRecentWorkspace(dir)
+			}))
+		}
+	}
+
+	if len(recentFiles) == 0 {
+		return
+	}
+
+	// Add "Clear Recent" option
+	menu.Items = append(menu.Items, fyne.NewMenuItemSeparator())
+	clearItem := fyne.NewMenuItem("Clear Recent Files", func() {
+		settings := mw.appState.GetSettings()
+		settings.RecentFiles = []string{}
+		mw.appState.SetSettings(settings)
+		state.SaveSettings(settings)
+		// Refresh menu
+		mw.createMenu()
+	})
+	menu.Items = append(menu.Items, clearItem)
+}
+
+// handleUndo reverts the most recent edit (Ctrl+Z)
+func (mw *MainWindow) handleUndo() {
+	name := mw.presenter.UndoName()
+	if name == "" {
+		return
+	}
+	if err := mw.presenter.Undo(); err != nil {
+		dialog.ShowError(err, mw.window)
+		return
+	}
+	mw.statusLabel.SetText(fmt.Sprintf("Undid: %s", name))
+}
+
+// handleRedo reapplies the most recently undone edit (Ctrl+Y)
+func (mw *MainWindow) handleRedo() {
+	name := mw.presenter.RedoName()
+	if name == "" {
+		return
+	}
+	if err := mw.presenter.Redo(); err != nil {
+		dialog.ShowError(err, mw.window)
+		return
+	}
+	mw.statusLabel.SetText(fmt.Sprintf("Redid: %s", name))
+}
+
+// updateUndoMenu labels the Undo/Redo menu items with the pending operation
+// names and disables them when there is nothing to undo or redo
+func (mw *MainWindow) updateUndoMenu() {
+	if mw.undoItem == nil || mw.redoItem == nil {
+		return
+	}
+
+	undoName := mw.presenter.UndoName()
+	mw.undoItem.Label = "Undo"
+	if undoName != "" {
+		mw.undoItem.Label = "Undo " + undoName
+	}
+	mw.undoItem.Disabled = undoName == ""
+
+	redoName := mw.presenter.RedoName()
+	mw.redoItem.Label = "Redo"
+	if redoName != "" {
+		mw.redoItem.Label = "Redo " + redoName
+	}
+	mw.redoItem.Disabled = redoName == ""
+
+	if mainMenu := mw.window.MainMenu(); mainMenu != nil {
+		mainMenu.Refresh()
+	}
+}
+
+// handleOpenWorkspace selects the root folder used by workspace-wide features
+func (mw *MainWindow) handleOpenWorkspace() {
+	folderDialog := dialog.NewFolderOpen(func(uri fyne.ListableURI, err error) {
+		if err != nil || uri == nil {
+			return
+		}
+		mw.useWorkspace(uri.Path())
+	}, mw.window)
+	folderDialog.Show()
+}
+
+// useWorkspace makes dir the root of workspace-wide features and adds it
+// to the recent workspaces
+func (mw *MainWindow) useWorkspace(dir string) {
+	mw.appState.SetWorkspaceRoot(dir)
+	mw.workspace = nil
+	if ws, err := mw.openWorkspace(); err == nil {
+		mw.statusLabel.SetText(fmt.Sprintf("Workspace: %s (%d POMs)", dir, len(ws.POMs)))
+	} else {
+		mw.statusLabel.SetText(fmt.Sprintf("Workspace: %s", dir))
+	}
+
+	settings := mw.appState.GetSettings()
+	settings.AddRecentWorkspace(dir)
+	mw.saveSettings(settings)
+}
+
+// openRecentWorkspace opens a workspace from the start page or the Open
+// Recent menu, along with the POM at its root when there is one
+func (mw *MainWindow) openRecentWorkspace(dir string) {
+	mw.useWorkspace(dir)
+	rootPOM := filepath.Join(dir, "pom.xml")
+	if _, err := os.Stat(rootPOM); err != nil {
+		return
+	}
+	if err := mw.openFile(rootPOM); err != nil {
+		dialog.ShowError(err, mw.window)
+	}
+}
+
+// handleTogglePin pins the current file to the start page, or unpins it
+func (mw *MainWindow) handleTogglePin() {
+	filePath := mw.appState.GetFilePath()
+	if filePath == "" || state.IsScratchPath(filePath) {
+		dialog.ShowInformation("Pin to Start Page", "Save the POM before pinning it.", mw.window)
+		return
+	}
+
+	settings := mw.appState.GetSettings()
+	if settings.IsPinned(filePath) {
+		settings.UnpinFile(filePath)
+		mw.statusLabel.SetText("Unpinned " + filePath)
+	} else {
+		settings.PinFile(filePath)
+		mw.statusLabel.SetText("Pinned " + filePath)
+	}
+	mw.saveSettings(settings)
+}
+
+// saveSettings persists settings changed outside the settings dialog, and
+// shows them in the menus and on the start page
+func (mw *MainWindow) saveSettings(settings *state.Settings) {
+	mw.appState.SetSettings(settings)
+	// Rebuilds the Open Recent submenu and the pin state
+	mw.createMenu()
+	mw.loadStartPage()
+	if err := state.SaveSettings(settings); err != nil {
+		mw.statusLabel.SetText(fmt.Sprintf("Failed to save settings: %v", err))
+	}
+}
+
+// updatePinMenu checks Pin to Start Page when the current file is pinned
+func (mw *MainWindow) updatePinMenu() {
+	mw.pinItem.Checked = mw.appState.GetSettings().IsPinned(mw.appState.GetFilePath())
+	if mainMenu := mw.window.MainMenu(); mainMenu != nil {
+		mainMenu.Refresh()
+	}
+}
+
+// loadStartPage lists the templates, the files, the scratch buffers and
+// the recent workspaces on the start page. Pinned files are not repeated
+// among the recent ones.
+func (mw *MainWindow) loadStartPage() {
+	settings := mw.appState.GetSettings()
+	var recent []string
+	for _, path := range settings.GetRecentFiles() {
+		if !settings.IsPinned(path) {
+			recent = append(recent, path)
+		}
+	}
+	mw.startPage.Load(recent, settings.PinnedFiles, settings.GetRecentWorkspaces())
+	mw.startPage.LoadTemplates(mw.presenter.TemplateManager().List())
+
+	// A scratch area that cannot be read has no buffers to offer
+	buffers, _ := state.ListScratchBuffers()
+	mw.startPage.LoadScratchBuffers(buffers)
+}
+
+// showStartPage shows the start page in place of the editor while no POM
+// is loaded
+func (mw *MainWindow) showStartPage(show bool) {
+	if show {
+		mw.loadStartPage()
+	}
+	// UI updates must be called on UI thread
+	fyne.Do(func() {
+		if show {
+			mw.previewSplit.Hide()
+			mw.startPage.GetContainer().Show()
+		} else {
+			mw.startPage.GetContainer().Hide()
+			mw.previewSplit.Show()
+		}
+	})
+}
+
+// workspaceRoot returns the open workspace, falling back to the directory
+// of the current file
+func (mw *MainWindow) workspaceRoot() string {
+	if root := mw.appState.GetWorkspaceRoot(); root != "" {
+		return root
+	}
+	if filePath := mw.appState.GetFilePath(); filePath != "" && !state.IsScratchPath(filePath) {
+		return filepath.Dir(filePath)
+	}
+	return ""
+}
+
+// openWorkspace returns the workspace at workspaceRoot, reusing the one
+// already open so its parsed POMs and index survive between searches. A
+// newly opened workspace starts indexing in the background.
+func (mw *MainWindow) openWorkspace() (*workspace.Workspace, error) {
+	root, err := filepath.Abs(mw.workspaceRoot())
+	if err != nil {
+		return nil, err
+	}
+	if mw.workspace != nil && mw.workspace.Root == root {
+		return mw.workspace, nil
+	}
+
+	ws, err := workspace.Open(root)
+	if err != nil {
+		return nil, err
+	}
+	ws.Index()
+	mw.workspace = ws
+	return ws, nil
+}
+
+// SetRecorder records the files the window writes besides saves, such as
+// new modules and refactorings, in the audit log
+func (mw *MainWindow) SetRecorder(recorder *audit.Recorder) {
+	mw.recorder = recorder
+}
+
+// track runs write, which may change the files at paths, recording the
+// changes when an audit recorder is set
+func (mw *MainWindow) track(paths []string, operation string, write func() error) error {
+	if mw.recorder == nil {
+		return write()
+	}
+	return mw.recorder.TrackFiles(paths, operation, write)
+}
+
+// handleNewModule adds a child module to the current POM, which becomes
+// (or already is) its aggregator
+func (mw *MainWindow) handleNewModule() {
+	filePath := mw.appState.GetFilePath()
+	if filePath == "" || state.IsScratchPath(filePath) {
+		dialog.ShowInformation("New Module", "Save the POM before adding modules to it.", mw.window)
+		return
+	}
+	if mw.presenter.IsReadOnly() {
+		dialog.ShowInformation("New Module", "This file is read-only and cannot be used as an aggregator.", mw.window)
+		return
+	}
+
+	// Modules are written to disk, so the aggregator must be saved first
+	mw.confirmDiscard(func() {
+		aggregator := mw.appState.GetCurrentProject()
+		if aggregator == nil {
+			return
+		}
+
+		var siblings []wizard.SiblingModule
+		if ws, err := workspace.Open(filepath.Dir(filePath)); err == nil {
+			for _, path := range ws.POMs {
+				if path != filePath {
+					siblings = append(siblings, wizard.SiblingModule{Label: ws.RelPath(path), Path: path})
+				}
+			}
+		}
+
+		wiz := wizard.NewModuleWizard(mw.window, aggregator, mw.presenter.Templates(), siblings)
+		wiz.Show(func(spec wizard.ModuleSpec) {
+			modulePath := filepath.Join(filepath.Dir(filePath), filepath.FromSlash(spec.Module), workspace.POMFileName)
+			paths := append([]string{filePath, modulePath}, spec.Dependents...)
+			var result *workspace.ModuleResult
+			err := mw.track(paths, "new module "+spec.Module, func() error {
+				var err error
+				result, err = workspace.CreateModule(workspace.ModuleOptions{
+					AggregatorPath: filePath,
+					Module:         spec.Module,
+					ArtifactID:     spec.ArtifactID,
+					Template:       spec.Template,
+					Dependents:     spec.Dependents,
+				})
+				return err
+			})
+			if err != nil {
+				dialog.ShowError(err, mw.window)
+				if result == nil {
+					return
+				}
+			}
+			// The new module is not in the indexed file list
+			mw.workspace = nil
+
+			if err := mw.presenter.LoadPOM(filePath); err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
+
+			message := fmt.Sprintf("Created %s", result.ModulePath)
+			if result.PackagingChanged {
+				message += "\nAggregator packaging changed to 'pom'."
+			}
+			if len(result.Dependents) > 0 {
+				message += fmt.Sprintf("\nAdded as a dependency of %d module(s).", len(result.Dependents))
+			}
+			dialog.ShowInformation("Module Created", message, mw.window)
+		})
+	})
+}
+
+// handleProjectStructure shows whether the current POM is an aggregator,
+// a parent, or both, and offers to convert between the two patterns
+func (mw *MainWindow) handleProjectStructure() {
+	filePath := mw.appState.GetFilePath()
+	project := mw.presenter.GetCurrentProject()
+	if project == nil || filePath == "" || state.IsScratchPath(filePath) {
+		dialog.ShowInformation("Project Structure", "Open a saved POM file first.", mw.window)
+		return
+	}
+
+	structure, err := workspace.Analyze(filePath, project)
+	if err != nil {
+		dialog.ShowError(err, mw.window)
+		return
+	}
+
+	structureDialog := dialogs.NewStructureDialog(mw.window, structure)
+	structureDialog.OnConvertToParent(func() {
+		mw.convertStructure("Make Modules Inherit",
+			"Add a <parent> pointing at this POM to every module without one? "+
+				"The modules will inherit its properties, dependencies, and plugins.",
+			workspace.ConvertToParent)
+	})
+	structureDialog.OnConvertToAggregator(func() {
+		mw.convertStructure("Detach Modules",
+			"Remove the <parent> reference to this POM from its modules? "+
+				"Anything they inherit from it (properties, dependencies, plugins) must then be declared in each module.",
+			workspace.ConvertToAggregator)
+	})
+	structureDialog.Show()
+}
+
+// resolveUndefinedProperty offers to define a property that is referenced
+// but not defined, point its references at a defined one, or record that it
+// is supplied outside the POM
+func (mw *MainWindow) resolveUndefinedProperty(finding pom.ValidationError) {
+	project := mw.presenter.GetCurrentProject()
+	if project == nil {
+		return
+	}
+
+	key := pom.UndefinedPropertyKey(finding)
+	candidates := pom.DeclaredProperties(project, mw.presenter.GetInheritance())
+	detail := fmt.Sprintf("${%s}: %s", key, finding.Message)
+	if impact := pom.PropertyRemovalImpact(project, key); len(impact) > 0 {
+		detail += "\n\nReferenced by:\n" + impactList(impact)
+	}
+
+	propertyDialog := dialogs.NewUndefinedPropertyDialog(mw.window)
+	propertyDialog.Show(key, detail, candidates, pom.SuggestProperty(key, candidates), func(resolution dialogs.PropertyResolution) {
+		var err error
+		switch resolution.Action {
+		case dialogs.ResolveDefine:
+			updated := project.Clone()
+			if updated.Properties == nil {
+				updated.Properties = make(map[string]string)
+			}
+			updated.Properties[key] = resolution.Value
+			err = mw.presenter.UpdateProject("Define Property "+key, updated)
+		case dialogs.ResolveRename:
+			updated := project.Clone()
+			pom.RenamePropertyReference(updated, key, resolution.RenameTo)
+			err = mw.presenter.UpdateProject(fmt.Sprintf("Change ${%s} to ${%s}", key, resolution.RenameTo), updated)
+		case dialogs.ResolveExternal:
+			var configPath string
+			if configPath, err = mw.presenter.AddExternalProperty(key); err == nil {
+				mw.statusLabel.SetText(fmt.Sprintf("%s is now listed as external in %s", key, configPath))
+			}
+		}
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+		}
+	})
+}
+
+// confirmImpact runs remove at once when nothing else is affected, and
+// otherwise lists what is and asks first
+func (mw *MainWindow) confirmImpact(title, name string, impact []string, remove func()) {
+	if len(impact) == 0 {
+		remove()
+		return
+	}
+
+	message := fmt.Sprintf("Removing %s also affects:\n\n%s\n\nRemove it anyway?", name, impactList(impact))
+	dialog.ShowConfirm(title, message, func(confirmed bool) {
+		if confirmed {
+			remove()
+		}
+	}, mw.window)
+}
+
+// impactList formats affected elements as a bulleted list of at most
+// maxImpactLines
+func impactList(impact []string) string {
+	lines := impact
+	if len(lines) > maxImpactLines {
+		lines = append(lines[:maxImpactLines:maxImpactLines], fmt.Sprintf("... and %d more", len(impact)-maxImpactLines))
+	}
+	return "• " + strings.Join(lines, "\n• ")
+}
+
+// convertStructure confirms and runs a conversion that rewrites module POMs
+func (mw *MainWindow) convertStructure(title, message string, convert func(string) ([]string, error)) {
+	if mw.presenter.IsReadOnly() {
+		dialog.ShowInformation(title, "This file is read-only.", mw.window)
+		return
+	}
+
+	dialog.ShowConfirm(title, message, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		// Conversions read the aggregator from disk
+		mw.confirmDiscard(func() {
+			aggregatorPath := mw.appState.GetFilePath()
+			var paths []string
+			if project := mw.appState.GetCurrentProject(); project != nil {
+				for _, module := range project.Modules {
+					paths = append(paths, filepath.Join(filepath.Dir(aggregatorPath), filepath.FromSlash(module), workspace.POMFileName))
+				}
+			}
+			var changed []string
+			err := mw.track(paths, title, func() error {
+				var err error
+				changed, err = convert(aggregatorPath)
+				return err
+			})
+			if err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
+			dialog.ShowInformation(title, fmt.Sprintf("Updated %d module POM(s).", len(changed)), mw.window)
+		})
+	}, mw.window)
+}
+
+// handleMoveToParent moves a dependency of the current module into the
+// parent's dependencyManagement, stripping the version from all modules
+func (mw *MainWindow) handleMoveToParent(groupID, artifactID string) {
+	parents := mw.presenter.GetInheritance().Parents
+	if len(parents) == 0 {
+		dialog.ShowInformation("Move to Parent",
+			"This POM has no parent on disk. Open a module whose <parent> can be resolved.", mw.window)
+		return
+	}
+	parentPath := parents[0].Path
+
+	// The refactoring reads the POMs from disk, so save pending edits first
+	mw.confirmDiscard(func() {
+		plan, err := workspace.PlanMoveToParent(parentPath, groupID, artifactID)
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+
+		var preview strings.Builder
+		for _, change := range plan.Changes {
+			rel, err := filepath.Rel(filepath.Dir(parentPath), change.Path)
+			if err != nil {
+				rel = change.Path
+			}
+			preview.WriteString(fmt.Sprintf("%s: %s\n", filepath.ToSlash(rel), change.Summary))
+		}
+
+		previewLabel := widget.NewLabel(preview.String())
+		previewLabel.Wrapping = fyne.TextWrapWord
+		content := container.NewBorder(
+			widget.NewLabel(fmt.Sprintf("The following %d file(s) will be changed:", len(plan.Changes))),
+			nil, nil, nil,
+			container.NewVScroll(previewLabel),
+		)
+
+		confirm := dialog.NewCustomConfirm(plan.Title, "Apply", "Cancel", content, func(apply bool) {
+			if !apply {
+				return
+			}
+			paths := make([]string, len(plan.Changes))
+			for i, change := range plan.Changes {
+				paths[i] = change.Path
+			}
+			if err := mw.track(paths, plan.Title, plan.Apply); err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
+			if filePath := mw.appState.GetFilePath(); filePath != "" {
+				if err := mw.presenter.LoadPOM(filePath); err != nil {
+					dialog.ShowError(err, mw.window)
+				}
+			}
+		}, mw.window)
+		confirm.Resize(fyne.NewSize(600, 360))
+		confirm.Show()
+	})
+}
+
+// handleImportGradleCatalog imports libraries of a Gradle libs.versions.toml
+// into the current POM's dependencyManagement
+func (mw *MainWindow) handleImportGradleCatalog() {
+	if mw.presenter.GetCurrentProject() == nil {
+		dialog.ShowInformation("Import Gradle Catalog", "Open or create a POM first.", mw.window)
+		return
+	}
+	if mw.presenter.IsReadOnly() {
+		dialog.ShowError(presenters.ErrReadOnly, mw.window)
+		return
+	}
+
+	fileDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil || reader == nil {
+			return
+		}
+		reader.Close()
+
+		catalog, err := pom.LoadGradleCatalog(reader.URI().Path())
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+
+		dialogs.NewGradleCatalogDialog(mw.window, catalog).Show(func(aliases []string) {
+			project := mw.presenter.GetCurrentProject().Clone()
+			result := pom.ImportGradleCatalog(catalog, project, aliases)
+			if len(result.Managed) == 0 && len(result.Properties) == 0 {
+				dialog.ShowInformation("Import Gradle Catalog", "dependencyManagement is already up to date.", mw.window)
+				return
+			}
+			if err := mw.presenter.UpdateProject("Import Gradle Catalog", project); err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
+
+			summary := fmt.Sprintf("Managed %d dependencies and set %d properties.", len(result.Managed), len(result.Properties))
+			if len(result.Skipped) > 0 {
+				summary += fmt.Sprintf("\nSkipped without a version: %s", strings.Join(result.Skipped, ", "))
+			}
+			dialog.ShowInformation("Import Gradle Catalog", summary, mw.window)
+		})
+	}, mw.window)
+
+	fileDialog.SetFilter(storage.NewExtensionFileFilter([]string{".toml"}))
+	fileDialog.Show()
+}
+
+// handleImportDependencies adds dependencies read from an Ivy or SBT build
+// file to the current POM
+func (mw *MainWindow) handleImportDependencies(format string) {
+	if mw.presenter.GetCurrentProject() == nil {
+		dialog.ShowInformation("Import Dependencies", "Open or create a POM first.", mw.window)
+		return
+	}
+	if mw.presenter.IsReadOnly() {
+		dialog.ShowError(presenters.ErrReadOnly, mw.window)
+		return
+	}
+
+	fileDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil || reader == nil {
+			return
+		}
+		reader.Close()
+
+		path := reader.URI().Path()
+		imported, err := pom.ImportDependencies(path, format)
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+
+		dialogs.NewDependencyImportDialog(mw.window, filepath.Base(path), imported).Show(func(deps []pom.Dependency) {
+			project := mw.presenter.GetCurrentProject().Clone()
+			added, updated := pom.MergeDependencies(project, deps)
+			if err := mw.presenter.UpdateProject("Import Dependencies", project); err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
+			dialog.ShowInformation("Import Dependencies",
+				fmt.Sprintf("Added %d and updated %d dependencies.", len(added), len(updated)), mw.window)
+		})
+	}, mw.window)
+
+	switch format {
+	case pom.ImportFormatSBT:
+		fileDialog.SetFilter(storage.NewExtensionFileFilter([]string{".sbt"}))
+	case pom.ImportFormatGradle:
+		fileDialog.SetFilter(storage.NewExtensionFileFilter([]string{".gradle", ".kts"}))
+	default:
+		fileDialog.SetFilter(storage.NewExtensionFileFilter([]string{".xml"}))
+	}
+	fileDialog.Show()
+}
+
+// handlePasteGradle adds dependencies pasted in Gradle notation, such as
+// implementation 'org.slf4j:slf4j-api:2.0.9' lines from library docs
+func (mw *MainWindow) handlePasteGradle() {
+	if mw.presenter.GetCurrentProject() == nil {
+		dialog.ShowInformation("Import Dependencies", "Open or create a POM first.", mw.window)
+		return
+	}
+	if mw.presenter.IsReadOnly() {
+		dialog.ShowError(presenters.ErrReadOnly, mw.window)
+		return
+	}
+
+	entry := widget.NewMultiLineEntry()
+	entry.SetPlaceHolder("implementation 'org.slf4j:slf4j-api:2.0.9'\ntestImplementation(\"org.junit.jupiter:junit-jupiter:5.10.1\")")
+	entry.TextStyle = fyne.TextStyle{Monospace: true}
+	entry.SetMinRowsVisible(8)
+	entry.SetText(fyne.CurrentApp().Clipboard().Content())
+
+	pasteDialog := dialog.NewCustomConfirm("Gradle Notation", "Next", "Cancel", entry, func(ok bool) {
+		if !ok {
+			return
+		}
+		imported, err := pom.ParseGradleDependencies([]byte(entry.Text))
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		dialogs.NewDependencyImportDialog(mw.window, "the pasted Gradle notation", imported).Show(func(deps []pom.Dependency) {
+			project := mw.presenter.GetCurrentProject().Clone()
+			added, updated := pom.MergeDependencies(project, deps)
+			if err := mw.presenter.UpdateProject("Import Dependencies", project); err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
+			dialog.ShowInformation("Import Dependencies",
+				fmt.Sprintf("Added %d and updated %d dependencies.", len(added), len(updated)), mw.window)
+		})
+	}, mw.window)
+	pasteDialog.Resize(fyne.NewSize(600, 350))
+	pasteDialog.Show()
+}
+
+// handleExport writes a build file for another build tool, generated from
+// the current project
+func (mw *MainWindow) handleExport(format string) {
+	project := mw.presenter.GetCurrentProject()
+	if project == nil {
+		dialog.ShowInformation("Export", "Open or create a POM first.", mw.window)
+		return
+	}
+
+	output, err := convert.Convert(project, format)
+	if err != nil {
+		dialog.ShowError(err, mw.window)
+		return
+	}
+
+	fileDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			return
+		}
+		defer writer.Close()
+
+		if _, err := writer.Write(output.Content); err != nil {
+			dialog.ShowError(fmt.Errorf("writing file: %w", err), mw.window)
+			return
+		}
+
+		message := fmt.Sprintf("Exported to %s.", writer.URI().Name())
+		if len(output.Warnings) > 0 {
+			message += fmt.Sprintf("\n\n%d construct(s) could not be converted exactly:\n- %s",
+				len(output.Warnings), strings.Join(output.Warnings, "\n- "))
+		}
+		dialog.ShowInformation("Export", message, mw.window)
+	}, mw.window)
+
+	fileDialog.SetFileName(output.FileName)
+	if filePath := mw.appState.GetFilePath(); filePath != "" && !state.IsScratchPath(filePath) {
+		if dir, err := storage.ListerForURI(storage.NewFileURI(filepath.Dir(filePath))); err == nil {
+			fileDialog.SetLocation(dir)
+		}
+	}
+	fileDialog.Show()
+}
+
+// handlePasteXML adds the dependencies and plugins of XML from the clipboard,
+// after showing what will be added
+func (mw *MainWindow) handlePasteXML() {
+	if mw.presenter.GetCurrentProject() == nil {
+		dialog.ShowInformation("Paste XML", "Open or create a POM first.", mw.window)
+		return
+	}
+	if mw.presenter.IsReadOnly() {
+		dialog.ShowError(presenters.ErrReadOnly, mw.window)
+		return
+	}
+
+	clipboard := fyne.CurrentApp().Clipboard().Content()
+	dialogs.NewPasteXMLDialog(mw.window).Show(clipboard, func(snippet *pom.Snippet) {
+		project := mw.presenter.GetCurrentProject().Clone()
+		added, updated := pom.MergeSnippet(project, snippet)
+		if err := mw.presenter.UpdateProject("Paste XML", project); err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		mw.statusLabel.SetText(fmt.Sprintf("Pasted XML: added %d, updated %d", len(added), len(updated)))
+	})
+}
+
+// checkClipboard offers to add the dependency on the clipboard when the
+// window regains focus, once per clipboard content
+func (mw *MainWindow) checkClipboard() {
+	text := fyne.CurrentApp().Clipboard().Content()
+	if text == mw.lastClipboard {
+		return
+	}
+	mw.lastClipboard = text
+
+	project := mw.presenter.GetCurrentProject()
+	if project == nil || mw.presenter.IsReadOnly() {
+		return
+	}
+	dep, ok := pom.DetectDependency(text)
+	if !ok {
+		return
+	}
+	for _, existing := range project.Dependencies {
+		if existing.Key() == dep.Key() && existing.Version == dep.Version {
+			return
+		}
+	}
+
+	coords := dep.GroupID + ":" + dep.ArtifactID
+	if dep.Version != "" {
+		coords += ":" + dep.Version
+	}
+	mw.clipboardDep = dep
+	mw.clipboardLabel.SetText(fmt.Sprintf("Add %s from clipboard?", coords))
+	mw.clipboardChip.Show()
+}
+
+// insertPosition returns where added dependencies go by the settings, and
+// the dependency selected in the list to insert after
+func (mw *MainWindow) insertPosition() (string, *pom.Dependency) {
+	position := mw.appState.GetSettings().DependencyInsertion
+	if position == "" {
+		position = pom.InsertAtEnd
+	}
+	if selected, ok := mw.depsPanel.Selected(); ok {
+		return position, &selected
+	}
+	return position, nil
+}
+
+// handleLocalRepository browses the local Maven repository to add installed
+// artifacts as dependencies without network access
+func (mw *MainWindow) handleLocalRepository() {
+	dir := localrepo.Dir(mavenSettings())
+	dialogs.NewLocalRepositoryDialog(mw.window, dir).Show(func(dep pom.Dependency) error {
+		position, after := mw.insertPosition()
+		return mw.presenter.AddDependencyAt(dep, position, after)
+	})
+}
+
+// handleAddClipboardDependency adds the dependency offered by checkClipboard
+func (mw *MainWindow) handleAddClipboardDependency() {
+	mw.clipboardChip.Hide()
+	position, after := mw.insertPosition()
+	if err := mw.presenter.AddDependencyAt(mw.clipboardDep, position, after); err != nil {
+		dialog.ShowError(err, mw.window)
+		return
+	}
+	mw.statusLabel.SetText(fmt.Sprintf("Added %s:%s from clipboard", mw.clipboardDep.GroupID, mw.clipboardDep.ArtifactID))
+}
+
+// handleWorkspaceSearch searches all POMs of the workspace (Ctrl+Shift+F)
+func (mw *MainWindow) handleWorkspaceSearch() {
+	root := mw.workspaceRoot()
+	if root == "" {
+		dialog.ShowInformation("Find in Workspace",
+			"Open a workspace folder (File > Open Workspace...) or a POM file first.", mw.window)
+		return
+	}
+
+	ws, err := mw.openWorkspace()
+	if err != nil {
+		dialog.ShowError(err, mw.window)
+		return
+	}
+
+	searchDialog := dialogs.NewWorkspaceSearchDialog(mw.window, ws)
+	searchDialog.Show(mw.openMatch)
+}
+
+// handleGotoArtifact opens a workspace POM by its coordinates (Ctrl+Shift+O)
+func (mw *MainWindow) handleGotoArtifact() {
+	if mw.workspaceRoot() == "" {
+		dialog.ShowInformation("Go to Artifact",
+			"Open a workspace folder (File > Open Workspace...) or a POM file first.", mw.window)
+		return
+	}
+
+	ws, err := mw.openWorkspace()
+	if err != nil {
+		dialog.ShowError(err, mw.window)
+		return
+	}
+
+	gotoDialog := dialogs.NewGotoArtifactDialog(mw.window, ws)
+	gotoDialog.Show(func(path string) {
+		if err := mw.openFile(path); err != nil {
+			dialog.ShowError(err, mw.window)
+		}
+	})
+}
+
+// openMatch opens the POM containing a search match and reveals the element
+func (mw *MainWindow) openMatch(match workspace.Match) {
+	mw.pendingMatch = &match
+
+	if match.Path == mw.appState.GetFilePath() {
+		mw.refreshUI()
+		return
+	}
+
+	if err := mw.openFile(match.Path); err != nil {
+		mw.pendingMatch = nil
+		dialog.ShowError(err, mw.window)
+	}
+}
+
+// revealPendingMatch switches to the tab of the pending search match and
+// selects the matching element
+func (mw *MainWindow) revealPendingMatch() {
+	match := mw.pendingMatch
+	if match == nil || match.Path != mw.appState.GetFilePath() {
+		return
+	}
+	mw.pendingMatch = nil
+
+	groupID, artifactID, _ := strings.Cut(match.Key, ":")
+	switch match.Section {
+	case workspace.SectionProject, workspace.SectionParent:
+		fyne.Do(func() { mw.tabContainer.SelectIndex(0) })
+	case workspace.SectionDependencies:
+		fyne.Do(func() { mw.tabContainer.SelectIndex(1) })
+		mw.depsPanel.SelectDependency(groupID, artifactID)
+	case workspace.SectionPlugins:
+		fyne.Do(func() { mw.tabContainer.SelectIndex(2) })
+		mw.pluginsPanel.SelectPlugin(groupID, artifactID)
+	case workspace.SectionProperties:
+		fyne.Do(func() { mw.tabContainer.SelectIndex(3) })
+		mw.propsPanel.SelectProperty(match.Key)
+	}
+
+	if match.Line > 0 {
+		fyne.Do(func() {
+			mw.statusLabel.SetText(fmt.Sprintf("%s | Line %d", mw.statusLabel.Text, match.Line))
+		})
+	}
+}
+
+// remoteClient returns a client for the configured repositories, or nil
+// when working offline. The repositories are reached through the mirrors,
+// proxies and credentials of the user's settings.xml, and metadata is
+// checked against its checksums.
+func (mw *MainWindow) remoteClient() remote.Client {
+	settings := mw.appState.GetSettings()
+	if settings.Offline {
+		return nil
+	}
+	mvnSettings := mavenSettings()
+	client := mvnSettings.HTTPClient(time.Duration(settings.MavenCentralTimeout)*time.Second, settings.GetHTTPCache())
+	return remote.NewClient(settings.Repositories, client, mvnSettings, mw.downloadVerifier())
+}
+
+// downloadVerifier returns the verifier of downloads, which shows failures
+// as security warnings in the status bar and records them in the audit log.
+// Downloads without a published checksum are used with a warning.
+func (mw *MainWindow) downloadVerifier() *remote.Verifier {
+	return &remote.Verifier{
+		OnFailure: func(failure remote.Failure) {
+			fyne.Do(func() {
+				mw.statusLabel.SetText("⚠ Security warning: " + failure.Message())
+			})
+			if mw.recorder != nil {
+				if err := mw.recorder.Warn(failure.URL, audit.OperationVerify, failure.Err.Error()); err != nil {
+					fyne.Do(func() {
+						dialog.ShowError(fmt.Errorf("recording security warning: %w", err), mw.window)
+					})
+				}
+			}
+		},
+		OnWarning: func(warning remote.Failure) {
+			fyne.Do(func() {
+				mw.statusLabel.SetText("⚠ Not verified, no checksum published: " + warning.URL)
+			})
+		},
+	}
+}
+
+// handleMavenSettings shows which mirror, proxy and credentials of the
+// user's settings.xml each configured repository is reached with
+func (mw *MainWindow) handleMavenSettings() {
+	path := remote.DefaultSettingsPath()
+	settings, err := remote.LoadSettings(path)
+	dialogs.NewMavenSettingsDialog(mw.window).Show(path, settings, err, mw.appState.GetSettings().Repositories)
+}
+
+// mavenSettings returns the user's settings.xml, nil when there is none or
+// it cannot be read; the settings.xml inspector tells why
+func mavenSettings() *remote.Settings {
+	settings, err := remote.LoadSettings(remote.DefaultSettingsPath())
+	if err != nil {
+		return nil
+	}
+	return settings
+}
+
+// loadCatalog offers the default versions of the last catalog refresh and,
+// when they are older than the configured interval, refreshes them in the
+// background
+func (mw *MainWindow) loadCatalog() {
+	settings := mw.appState.GetSettings()
+	dir, err := settings.GetCacheDir()
+	if err != nil {
+		return
+	}
+	cached, err := catalog.LoadAndApply(dir)
+	if err != nil || settings.CatalogRefreshDays <= 0 {
+		return
+	}
+
+	client := mw.remoteClient()
+	if client == nil || !cached.Stale(time.Duration(settings.CatalogRefreshDays)*24*time.Hour) {
+		return
+	}
+	go func() {
+		if found, _ := cached.Refresh(context.Background(), client); found > 0 && cached.Save(dir) == nil {
+			cached.Apply()
+		}
+	}()
+}
+
+// handleRefreshCatalog looks up the newest releases of the plugins and
+// dependencies offered by templates and dialogs
+func (mw *MainWindow) handleRefreshCatalog() {
+	client := mw.remoteClient()
+	if client == nil {
+		dialog.ShowInformation("Refresh Default Versions", "Turn off Offline in Settings to look up versions.", mw.window)
+		return
+	}
+	dir, err := mw.appState.GetSettings().GetCacheDir()
+	if err != nil {
+		dialog.ShowError(err, mw.window)
+		return
+	}
+
+	mw.statusLabel.SetText("Refreshing default versions...")
+	go func() {
+		cached, err := catalog.Load(dir)
+		found := 0
+		if err == nil {
+			found, err = cached.Refresh(context.Background(), client)
+			if found > 0 {
+				if saveErr := cached.Save(dir); saveErr != nil {
+					err = saveErr
+				}
+				cached.Apply()
+			}
+		}
+
+		fyne.Do(func() {
+			mw.statusLabel.SetText(fmt.Sprintf("Refreshed %d default version(s)", found))
+			switch {
+			case found == 0 && err != nil:
+				dialog.ShowError(fmt.Errorf("refreshing default versions: %w", err), mw.window)
+			case err != nil:
+				dialog.ShowInformation("Refresh Default Versions",
+					fmt.Sprintf("Refreshed %d version(s). Some artifacts keep their previous version:\n\n%v", found, err), mw.window)
+			}
+		})
+	}()
+}
+
+// handleResolveStats resolves the dependency tree of the open POM from the
+// local repository, for the transitive figures of the statistics. Nothing is
+// downloaded, so artifacts missing locally leave the tree incomplete.
+func (mw *MainWindow) handleResolveStats() {
+	project := mw.presenter.GetCurrentProject()
+	if project == nil {
+		return
+	}
+	// Resolve a copy, so edits made meanwhile do not race with resolution
+	project = project.Clone()
+	inheritance := mw.presenter.GetInheritance()
+
+	mw.statsPanel.SetResolving()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), statsResolveTimeout)
+		defer cancel()
+		resolver := classpath.NewResolver(classpath.NewLocalRepository(pom.DefaultLocalRepository()), pom.NewParser())
+		result, err := resolver.Resolve(ctx, project, inheritance)
+		mw.statsPanel.SetTransitive(result, err)
+	}()
+}
+
+// handleCleanUp looks for redundant dependencies in the background and
+// offers to remove them. Transitive dependencies are resolved from the local
+// repository only, like the statistics.
+func (mw *MainWindow) handleCleanUp() {
+	project := mw.presenter.GetCurrentProject()
+	if project == nil {
+		return
+	}
+	// Look at a copy, so edits made meanwhile do not race with resolution
+	project = project.Clone()
+	inheritance := mw.presenter.GetInheritance()
+
+	mw.statusLabel.SetText("Looking for redundant dependencies...")
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), statsResolveTimeout)
+		defer cancel()
+		resolver := classpath.NewResolver(classpath.NewLocalRepository(localrepo.Dir(mavenSettings())), pom.NewParser())
+		report, err := cleanup.Find(ctx, project, inheritance, resolver)
+		fyne.Do(func() {
+			mw.statusLabel.SetText("Ready")
+			if err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
+			current := mw.presenter.GetCurrentProject()
+			if current == nil || !reflect.DeepEqual(current.Dependencies, project.Dependencies) {
+				dialog.ShowInformation("Clean Up Dependencies", "The dependencies changed while looking for redundant ones. Try again.", mw.window)
+				return
+			}
+			dialogs.NewCleanupDialog(mw.window, current, report).Show(func(cleaned *pom.Project) error {
+				if err := mw.presenter.UpdateProject("Clean Up Dependencies", cleaned); err != nil {
+					return err
+				}
+				mw.statusLabel.SetText("Cleaned up redundant dependencies")
+				return nil
+			})
+		})
+	}()
+}
+
+// updateTemplateRegistry fetches the templates of the registry configured in
+// Settings in the background. Unless asked to, the cached templates are kept
+// when they come from the configured source, so the registry is only
+// fetched the first time and when its URL or version changes.
+func (mw *MainWindow) updateTemplateRegistry(force bool) {
+	settings := mw.appState.GetSettings()
+	source := settings.RegistrySource()
+	if source.URL == "" {
+		if force {
+			dialog.ShowInformation("Update Template Registry", "Set a template registry in Settings > Templates first.", mw.window)
+		}
+		return
+	}
+	if settings.Offline {
+		if force {
+			dialog.ShowInformation("Update Template Registry", "Turn off Offline in Settings to update the template registry.", mw.window)
+		}
+		return
+	}
+	cacheDir, err := settings.GetCacheDir()
+	if err != nil {
+		if force {
+			dialog.ShowError(err, mw.window)
+		}
+		return
+	}
+	if !force {
+		if cached, err := registry.LoadState(cacheDir); err == nil && cached != nil && cached.Source == source {
+			return
+		}
+	}
+
+	mw.statusLabel.SetText("Updating template registry...")
+	timeout := time.Duration(settings.MavenCentralTimeout) * time.Second
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), registryUpdateTimeout)
+		defer cancel()
+		updated, err := registry.Update(ctx, cacheDir, source, &http.Client{Timeout: timeout})
+
+		fyne.Do(func() {
+			if err != nil {
+				mw.statusLabel.SetText("Template registry not updated")
+				if force {
+					dialog.ShowError(fmt.Errorf("updating template registry: %w", err), mw.window)
+				}
+				return
+			}
+			mw.presenter.SetTemplateManager(mw.appState.GetSettings().NewTemplateManager())
+			mw.statusLabel.SetText(fmt.Sprintf("Template registry: %d template(s) at %s", len(updated.Templates), updated.ShortRevision()))
+			if force && len(updated.Skipped) > 0 {
+				dialog.ShowInformation("Update Template Registry",
+					fmt.Sprintf("Some templates could not be loaded and were left out:\n\n%s", strings.Join(updated.Skipped, "\n")), mw.window)
+			}
+		})
+	}()
+}
+
+// editPlugin opens the plugin editor for a plugin
+func (mw *MainWindow) editPlugin(plugin pom.Plugin) {
+	pluginDialog := dialogs.NewPluginDialog(mw.window)
+	pluginDialog.ShowEdit(plugin, func(updated pom.Plugin) {
+		mw.presenter.AddPlugin(updated)
+	})
+}
+
+// navigateToFinding reveals the field a validation finding refers to:
+// coordinates are focused, dependencies selected, and plugins opened in the
+// editor. Other findings show their element in the XML source.
+func (mw *MainWindow) navigateToFinding(finding pom.ValidationError) {
+	project := mw.presenter.GetCurrentProject()
+	if project == nil {
+		return
+	}
+
+	field := finding.Field
+	switch field {
+	case "groupId", "artifactId", "version", "packaging", "name", "description":
+		mw.tabContainer.SelectIndex(0) // Coordinates tab
+		mw.coordsPanel.FocusField(field)
+		return
+	}
+
+	if i, ok := fieldIndex(field, "dependencies"); ok && i < len(project.Dependencies) {
+		dep := project.Dependencies[i]
+		mw.tabContainer.SelectIndex(1) // Dependencies tab
+		mw.depsPanel.SelectDependency(dep.GroupID, dep.ArtifactID)
+		return
+	}
+
+	if i, ok := fieldIndex(field, "build.plugins"); ok && project.Build != nil && i < len(project.Build.Plugins) {
+		plugin := project.Build.Plugins[i]
+		mw.tabContainer.SelectIndex(2) // Plugins tab
+		mw.pluginsPanel.SelectPlugin(plugin.GroupID, plugin.ArtifactID)
+		mw.editPlugin(plugin)
+		return
+	}
+
+	if finding.Position.IsKnown() {
+		mw.tabContainer.SelectIndex(8) // XML Source tab
+		mw.xmlSourcePanel.ShowPosition(finding.Position)
+	}
+}
+
+// fieldIndex returns i for finding fields of the form "prefix[i]" or
+// "prefix[i].child"
+func fieldIndex(field, prefix string) (int, bool) {
+	rest, ok := strings.CutPrefix(field, prefix+"[")
+	if !ok {
+		return 0, false
+	}
+	number, _, ok := strings.Cut(rest, "]")
+	if !ok {
+		return 0, false
+	}
+	i, err := strconv.Atoi(number)
+	if err != nil || i < 0 {
+		return 0, false
+	}
+	return i, true
+}
+
+// handleBookmark bookmarks a dependency or plugin of the current POM
+func (mw *MainWindow) handleBookmark(kind, groupID, artifactID string) {
+	filePath := mw.appState.GetFilePath()
+	if filePath == "" {
+		dialog.ShowInformation("Bookmark", "Save the POM before bookmarking its elements.", mw.window)
+		return
+	}
+
+	bookmark := state.Bookmark{
+		Path:       filePath,
+		Kind:       kind,
+		GroupID:    groupID,
+		ArtifactID: artifactID,
+	}
+	if i := mw.bookmarks.Find(filePath, kind, groupID, artifactID); i >= 0 {
+		bookmark = mw.bookmarks.Items[i]
+	}
+
+	mw.showBookmarkNoteDialog(bookmark)
+}
+
+// showBookmarkNoteDialog asks for the note of a new or existing bookmark
+func (mw *MainWindow) showBookmarkNoteDialog(bookmark state.Bookmark) {
+	noteEntry := widget.NewMultiLineEntry()
+	noteEntry.SetPlaceHolder("Why is this under review?")
+	noteEntry.SetText(bookmark.Note)
+
+	form := &widget.Form{
+		Items: []*widget.FormItem{
+			{Text: "Element", Widget: widget.NewLabel(fmt.Sprintf("%s %s", bookmark.Kind, bookmark.Key()))},
+			{Text: "Note", Widget: noteEntry},
+		},
+	}
+
+	noteDialog := dialog.NewCustomConfirm("Bookmark", "Save", "Cancel", form, func(save bool) {
+		if !save {
+			return
+		}
+		bookmark.Note = noteEntry.Text
+		mw.bookmarks.Set(bookmark)
+		mw.saveBookmarks()
+	}, mw.window)
+	noteDialog.Resize(fyne.NewSize(450, 250))
+	noteDialog.Show()
+}
+
+// saveBookmarks persists bookmarks and refreshes the Bookmarks panel
+func (mw *MainWindow) saveBookmarks() {
+	if err := state.SaveBookmarks(mw.bookmarks); err != nil {
+		dialog.ShowError(err, mw.window)
+	}
+	mw.bookmarksPanel.LoadBookmarks(mw.bookmarks.InDirectory(mw.workspaceRoot()))
+}
+
+// handleNewScratch creates an untitled scratch POM from the default template
+func (mw *MainWindow) handleNewScratch() {
+	template := mw.appState.GetSettings().DefaultTemplate
+	err := mw.openInNewTab(func() error {
+		_, err := mw.presenter.CreateScratchPOM(template)
+		return err
+	})
+	if err != nil {
+		dialog.ShowError(err, mw.window)
+		return
+	}
+
+	// Refresh menu so the new buffer is listed
+	mw.createMenu()
+}
+
+// updateScratchMenu updates the Scratch Buffers submenu
+func (mw *MainWindow) updateScratchMenu(menu *fyne.Menu) {
+	menu.Items = nil // Clear existing items
+
+	buffers, err := state.ListScratchBuffers()
+	if err != nil || len(buffers) == 0 {
+		menu.Items = append(menu.Items, fyne.NewMenuItem("(No scratch buffers)", nil))
+		menu.Items[0].Disabled = true
+		return
+	}
+
+	for _, buffer := range buffers {
+		// Create copy for closure
+		path := buffer.Path
+		label := fmt.Sprintf("%s (%s)", buffer.Name, buffer.Modified.Format("2006-01-02 15:04"))
+
+		item := fyne.NewMenuItem(label, func() {
+			if err := mw.openFile(path); err != nil {
+				dialog.ShowError(err, mw.window)
+			}
+		})
+		menu.Items = append(menu.Items, item)
+	}
+
+	// Add "Delete Current Scratch" option
+	menu.Items = append(menu.Items, fyne.NewMenuItemSeparator())
+	deleteItem := fyne.NewMenuItem("Delete Current Scratch", func() {
+		path := mw.appState.GetFilePath()
+		if !state.IsScratchPath(path) {
+			dialog.ShowInformation("Scratch Buffers", "The open POM is not a scratch buffer", mw.window)
+			return
+		}
+		dialog.ShowConfirm("Delete Scratch", fmt.Sprintf("Delete %s?", filepath.Base(path)), func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			if err := state.DeleteScratchBuffer(path); err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
+			mw.appState.SetFilePath("")
+			mw.appState.SetDirty(true)
+			// Refresh menu
+			mw.createMenu()
+		}, mw.window)
+	})
+	menu.Items = append(menu.Items, deleteItem)
+}
+
+func (mw *MainWindow) handleSave() {
+	filePath := mw.appState.GetFilePath()
+	if filePath == "" {
+		mw.handleSaveAs()
+		return
+	}
+
+	if mw.presenter.IsReadOnly() {
+		dialog.ShowConfirm("Read-Only File",
+			"This file cannot be modified. Save a copy to another location?",
+			func(saveCopy bool) {
+				if saveCopy {
+					mw.handleSaveAs()
+				}
+			}, mw.window)
+		return
+	}
+
+	save := func() {
+		err := mw.presenter.SavePOM(filePath)
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+		} else {
+			mw.afterSave(filePath)
+			dialog.ShowInformation("Saved", "POM file saved successfully", mw.window)
+		}
+	}
+
+	if mw.appState.GetSettings().ReviewBeforeSave {
+		mw.reviewChanges(save)
 		return
 	}
+	save()
+}
 
-	for _, filePath := range recentFiles {
-		// Create copy for closure
-		path := filePath
-		// Get file name only for display
-		fileName := filePath
-		if idx := len(filePath) - 1; idx >= 0 {
-			for i := idx; i >= 0; i-- {
-				if filePath[i] == '/' || filePath[i] == '\\' {
-					fileName = filePath[i+1:]
-					break
-				}
-			}
-		}
+// handleReviewChanges shows the diff between the file on disk and the
+// output a save would write
+func (mw *MainWindow) handleReviewChanges() {
+	filePath := mw.appState.GetFilePath()
+	if filePath == "" {
+		dialog.ShowInformation("Review Changes", "The POM has not been saved yet, so there is nothing to compare.", mw.window)
+		return
+	}
 
-		item := fyne.NewMenuItem(fileName, func() {
-			err := mw.presenter.LoadPOM(path)
-			if err != nil {
+	var onSave func()
+	if !mw.presenter.IsReadOnly() {
+		onSave = func() {
+			if err := mw.presenter.SavePOM(filePath); err != nil {
 				dialog.ShowError(err, mw.window)
+				return
 			}
-		})
-		menu.Items = append(menu.Items, item)
+			mw.afterSave(filePath)
+		}
 	}
+	mw.reviewChanges(onSave)
+}
 
-	// Add "Clear Recent" option
-	menu.Items = append(menu.Items, fyne.NewMenuItemSeparator())
-	clearItem := fyne.NewMenuItem("Clear Recent Files", func() {
-		settings := mw.appState.GetSettings()
-		settings.RecentFiles = []string{}
-		mw.appState.SetSettings(settings)
-		state.SaveSettings(settings)
-		// Refresh menu
-		mw.createMenu()
+// reviewChanges compares the current file on disk with the generated XML and
+// calls onSave once the user confirms (right away when nothing differs)
+func (mw *MainWindow) reviewChanges(onSave func()) {
+	filePath := mw.appState.GetFilePath()
+	project := mw.presenter.GetCurrentProject()
+	if project == nil {
+		return
+	}
+
+	pending, err := pom.NewGenerator().Generate(project)
+	if err != nil {
+		dialog.ShowError(err, mw.window)
+		return
+	}
+
+	// A file that no longer exists is compared against an empty document
+	onDisk, err := os.ReadFile(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		dialog.ShowError(err, mw.window)
+		return
+	}
+
+	reviewDialog := dialogs.NewReviewChangesDialog(mw.window, filepath.Base(filePath), onDisk, pending)
+	// Describe the changes when the file on disk still parses
+	if saved, err := pom.NewParser().Parse(onDisk); err == nil {
+		reviewDialog.SetChanges(diff.Projects(saved, project))
+	}
+	reviewDialog.Show(onSave)
+}
+
+// handleChangelog shows the session's changes as a commit message to copy,
+// e.g. into a commit made outside the application
+func (mw *MainWindow) handleChangelog() {
+	if mw.presenter.GetCurrentProject() == nil {
+		dialog.ShowInformation("Changelog", "Open or create a POM first.", mw.window)
+		return
+	}
+	message := mw.presenter.SessionChangelog()
+	if message == "" {
+		dialog.ShowInformation("Changelog", "No changes since the POM was opened or last committed.", mw.window)
+		return
+	}
+
+	entry := widget.NewMultiLineEntry()
+	entry.SetText(message)
+	entry.Wrapping = fyne.TextWrapWord
+	entry.SetMinRowsVisible(8)
+
+	changelogDialog := dialog.NewCustomConfirm("Changelog", "Copy", "Close", entry, func(copyText bool) {
+		if copyText {
+			fyne.CurrentApp().Clipboard().SetContent(entry.Text)
+			mw.statusLabel.SetText("Changelog copied to the clipboard")
+		}
+	}, mw.window)
+	changelogDialog.Resize(fyne.NewSize(600, 320))
+	changelogDialog.Show()
+}
+
+// handleMvnCommand builds an mvn command line for the current POM to copy
+// into a terminal
+func (mw *MainWindow) handleMvnCommand() {
+	project := mw.presenter.GetCurrentProject()
+	if project == nil {
+		dialog.ShowInformation("mvn Command", "Open or create a POM first.", mw.window)
+		return
+	}
+	dialogs.NewMvnCommandDialog(mw.window).Show(project, mw.appState.GetFilePath(), func(command string) {
+		fyne.CurrentApp().Clipboard().SetContent(command)
+		mw.statusLabel.SetText("Copied: " + command)
 	})
-	menu.Items = append(menu.Items, clearItem)
 }
 
-func (mw *MainWindow) handleSave() {
+// handleRunBuild runs Maven on the saved POM with the goals, profiles and
+// properties picked in the Run Build dialog, streaming its output into the
+// Build Output panel
+func (mw *MainWindow) handleRunBuild() {
+	project := mw.presenter.GetCurrentProject()
 	filePath := mw.appState.GetFilePath()
-	if filePath == "" {
-		mw.handleSaveAs()
+	if project == nil || filePath == "" || state.IsScratchPath(filePath) {
+		dialog.ShowInformation("Run Build", "Save the POM first: Maven builds the file on disk.", mw.window)
+		return
+	}
+	if mw.buildOutputPanel.Running() {
+		dialog.ShowInformation("Run Build", "A build is already running. Stop it first.", mw.window)
 		return
 	}
 
-	err := mw.presenter.SavePOM(filePath)
+	dir := filepath.Dir(filePath)
+	mvn, err := maven.Locate(dir, mw.appState.GetSettings().MavenPath)
 	if err != nil {
-		dialog.ShowError(err, mw.window)
-	} else {
-		dialog.ShowInformation("Saved", "POM file saved successfully", mw.window)
+		dialog.ShowError(fmt.Errorf("%w, or set the Maven executable in Settings > Advanced", err), mw.window)
+		return
+	}
+
+	dialogs.NewMvnCommandDialog(mw.window).ShowRun(project, filePath, mvn, func(command maven.Command) {
+		if !mw.appState.IsDirty() {
+			mw.runBuild(mvn, dir, command)
+			return
+		}
+		dialog.ShowConfirm("Run Build",
+			"The POM has unsaved changes, but Maven builds the file on disk. Save them first?",
+			func(save bool) {
+				if save {
+					if err := mw.presenter.SavePOM(filePath); err != nil {
+						dialog.ShowError(err, mw.window)
+						return
+					}
+					mw.afterSave(filePath)
+				}
+				mw.runBuild(mvn, dir, command)
+			}, mw.window)
+	})
+}
+
+// runBuild runs command with the mvn launcher in dir in the background
+func (mw *MainWindow) runBuild(mvn, dir string, command maven.Command) {
+	ctx, cancel := context.WithCancel(context.Background())
+	mw.buildCancel = cancel
+	mw.buildOutputPanel.Start(command.String(), dir)
+	mw.tabContainer.SelectIndex(11) // Build Output tab
+	mw.statusLabel.SetText("Building: " + command.String())
+
+	go func() {
+		err := maven.Stream(ctx, mvn, dir, command.Args(), func(line string) {
+			fyne.Do(func() { mw.buildOutputPanel.AppendLine(line) })
+		})
+		fyne.Do(func() {
+			cancel()
+			mw.buildCancel = nil
+			mw.buildOutputPanel.Finish(err)
+			if err != nil {
+				mw.statusLabel.SetText("Build failed")
+			} else {
+				mw.statusLabel.SetText("Build succeeded")
+			}
+		})
+	}()
+}
+
+// handleStopBuild stops the running build, if any
+func (mw *MainWindow) handleStopBuild() {
+	if mw.buildCancel != nil {
+		mw.buildCancel()
 	}
 }
 
 func (mw *MainWindow) handleSaveAs() {
+	mw.saveAs(func() {
+		dialog.ShowInformation("Saved", "POM file saved successfully", mw.window)
+	})
+}
+
+// handleSaveAsTemplate saves the current project as a custom template, which
+// the New Project wizard offers from then on
+func (mw *MainWindow) handleSaveAsTemplate() {
+	project := mw.presenter.GetCurrentProject()
+	if project == nil {
+		dialog.ShowInformation("Save as Template", "Open or create a POM first.", mw.window)
+		return
+	}
+	dirs := mw.appState.GetSettings().GetTemplateDirs()
+	if len(dirs) == 0 {
+		dialog.ShowError(fmt.Errorf("no template directory: set one in Settings > Templates"), mw.window)
+		return
+	}
+
+	dialogs.NewSaveTemplateDialog(mw.window).Show(project, dirs[0], func(name, description, path string) {
+		definition := pom.NewTemplateDefinition(name, description, project)
+		save := func() {
+			err := mw.track([]string{path}, "save as template "+name, func() error {
+				return definition.WriteFile(path)
+			})
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("saving template: %w", err), mw.window)
+				return
+			}
+			mw.presenter.SetTemplateManager(mw.appState.GetSettings().NewTemplateManager())
+			dialog.ShowInformation("Save as Template",
+				fmt.Sprintf("Saved template %s to %s.\n\nChoose it in File > New to create projects from it.", name, path), mw.window)
+		}
+
+		if _, err := os.Stat(path); err == nil {
+			dialog.ShowConfirm("Replace Template",
+				fmt.Sprintf("A template named %s already exists. Replace it?", name),
+				func(replace bool) {
+					if replace {
+						save()
+					}
+				}, mw.window)
+			return
+		}
+		save()
+	})
+}
+
+// saveAs asks for a destination, saves the POM there and calls onSaved
+func (mw *MainWindow) saveAs(onSaved func()) {
 	fileDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
 		if err != nil || writer == nil {
 			return
@@ -508,8 +3158,11 @@ func (mw *MainWindow) handleSaveAs() {
 		err = mw.presenter.SavePOM(path)
 		if err != nil {
 			dialog.ShowError(err, mw.window)
-		} else {
-			dialog.ShowInformation("Saved", "POM file saved successfully", mw.window)
+			return
+		}
+		mw.afterSave(path)
+		if onSaved != nil {
+			onSaved()
 		}
 	}, mw.window)
 
@@ -518,12 +3171,346 @@ func (mw *MainWindow) handleSaveAs() {
 	fileDialog.Show()
 }
 
+// confirmDiscard runs action right away when there are no unsaved changes,
+// otherwise asks whether to save, discard, or cancel first. Scratch buffers
+// are saved without asking.
+func (mw *MainWindow) confirmDiscard(action func()) {
+	filePath := mw.appState.GetFilePath()
+	if !mw.appState.IsDirty() {
+		action()
+		return
+	}
+
+	// Scratch buffers are saved in place rather than asked about
+	if state.IsScratchPath(filePath) {
+		if err := mw.presenter.SavePOM(filePath); err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		action()
+		return
+	}
+
+	name := "Untitled POM"
+	if filePath != "" {
+		name = filepath.Base(filePath)
+	}
+
+	var prompt dialog.Dialog
+	saveButton := widget.NewButton("Save", func() {
+		prompt.Hide()
+		if filePath == "" || mw.presenter.IsReadOnly() {
+			mw.saveAs(action)
+			return
+		}
+		if err := mw.presenter.SavePOM(filePath); err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		mw.afterSave(filePath)
+		action()
+	})
+	saveButton.Importance = widget.HighImportance
+	discardButton := widget.NewButton("Discard", func() {
+		prompt.Hide()
+		action()
+	})
+	cancelButton := widget.NewButton("Cancel", func() {
+		prompt.Hide()
+	})
+
+	content := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf("%s has unsaved changes. Save them before continuing?", name)),
+		container.NewHBox(saveButton, discardButton, cancelButton),
+	)
+	prompt = dialog.NewCustomWithoutButtons("Unsaved Changes", content, mw.window)
+	prompt.Show()
+}
+
+// confirmDiscardAll asks about the unsaved changes of the documents from
+// index from on in turn, showing each, then runs action. Scratch buffers are
+// left out; save them with SaveScratchBuffers first.
+func (mw *MainWindow) confirmDiscardAll(from int, action func()) {
+	docs := mw.appState.Documents()
+	for i := from; i < len(docs); i++ {
+		if !docs[i].Dirty || state.IsScratchPath(docs[i].FilePath) {
+			continue
+		}
+		if err := mw.presenter.SwitchDocument(i); err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		mw.confirmDiscard(func() {
+			mw.confirmDiscardAll(i+1, action)
+		})
+		return
+	}
+	action()
+}
+
+// afterSave commits a saved POM when Commit on Save is enabled, then shows
+// its new Git status. Outside a repository the commit is skipped with a note
+// in the status bar rather than an error.
+func (mw *MainWindow) afterSave(path string) {
+	// Keep navigation in step with edited coordinates
+	if mw.workspace != nil && mw.workspace.Contains(path) {
+		if project := mw.appState.GetCurrentProject(); project != nil {
+			mw.workspace.Index().Update(path, project)
+		}
+	}
+
+	if !mw.appState.GetSettings().CommitOnSave || state.IsScratchPath(path) {
+		mw.refreshGitStatus()
+		return
+	}
+
+	// Describe the session's edits, falling back for saves that only
+	// reformat the file
+	message := mw.presenter.SessionChangelog()
+	go func() {
+		var note string
+		committed := false
+		repo, err := git.Open(filepath.Dir(path))
+		switch {
+		case errors.Is(err, git.ErrNotRepository), errors.Is(err, git.ErrNotInstalled):
+			note = fmt.Sprintf("Saved %s; not committed, %v", filepath.Base(path), err)
+			err = nil
+		case err != nil:
+		case repo.StatusOf(path).Clean():
+			note = fmt.Sprintf("Saved %s; nothing to commit", filepath.Base(path))
+		default:
+			rel, _ := repo.Relative(path)
+			if message == "" {
+				message = "Update " + rel
+			}
+			if err = repo.Commit(path, message); err == nil {
+				note = fmt.Sprintf("Saved and committed %s", rel)
+				committed = true
+			}
+		}
+
+		fyne.Do(func() {
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("saved, but the commit failed: %w", err), mw.window)
+			} else {
+				if committed {
+					mw.presenter.MarkCommitted()
+				}
+				mw.statusLabel.SetText(note)
+			}
+			mw.refreshGitStatus()
+		})
+	}()
+}
+
+// refreshGitStatus shows the Git status of the open POM and its modules in
+// the tree, and its modification time and last commit in the statistics.
+// Files outside a repository, or without git installed, show none.
+func (mw *MainWindow) refreshGitStatus() {
+	filePath := mw.appState.GetFilePath()
+	mw.gitStatusPath = filePath
+	project := mw.presenter.GetCurrentProject()
+	if filePath == "" || state.IsScratchPath(filePath) || project == nil {
+		mw.treePanel.SetGitStatus("", nil)
+		mw.statsPanel.SetFileInfo(time.Time{}, nil, "")
+		return
+	}
+
+	dir := filepath.Dir(filePath)
+	modules := append([]string(nil), project.Modules...)
+	go func() {
+		var modified time.Time
+		if info, err := os.Stat(filePath); err == nil {
+			modified = info.ModTime()
+		}
+
+		var pomBadge string
+		var commit *git.LastCommit
+		commitNote := "Not committed yet"
+		moduleBadges := make(map[string]string)
+		repo, err := git.Open(dir)
+		if err == nil {
+			pomBadge = repo.StatusOf(filePath).Badge()
+			if last, ok, logErr := repo.LastCommitOf(filePath); logErr != nil {
+				commitNote = logErr.Error()
+			} else if ok {
+				commit = &last
+			}
+			for _, module := range modules {
+				modulePath := filepath.Join(dir, filepath.FromSlash(module))
+				if !strings.HasSuffix(module, ".xml") {
+					modulePath = filepath.Join(modulePath, "pom.xml")
+				}
+				moduleBadges[module] = repo.StatusOf(modulePath).Badge()
+			}
+		} else {
+			commitNote = "Not in a Git repository"
+			if !errors.Is(err, git.ErrNotRepository) {
+				commitNote = err.Error()
+			}
+		}
+		mw.statsPanel.SetFileInfo(modified, commit, commitNote)
+		fyne.Do(func() {
+			mw.treePanel.SetGitStatus(pomBadge, moduleBadges)
+		})
+	}()
+}
+
+// handleClose closes the window after confirming the unsaved changes of
+// every document
+func (mw *MainWindow) handleClose() {
+	// Scratch buffers are kept without asking, whichever tab they are in
+	if err := mw.presenter.SaveScratchBuffers(); err != nil {
+		dialog.ShowError(err, mw.window)
+		return
+	}
+	mw.confirmDiscardAll(0, func() {
+		mw.handleStopBuild()
+		if mw.fileWatcher != nil {
+			_ = mw.fileWatcher.Close()
+		}
+		mw.rememberUIState()
+		mw.rememberSession()
+		mw.window.Close()
+	})
+}
+
+// rememberSession records the open files and the window layout in the
+// settings, which are saved as the window closes. Untitled POMs cannot be
+// reopened and are left out.
+func (mw *MainWindow) rememberSession() {
+	settings := mw.appState.GetSettings()
+	settings.OpenFiles = nil
+	settings.ActiveFile = 0
+
+	active := mw.appState.ActiveDocument()
+	for i, doc := range mw.appState.Documents() {
+		if doc.FilePath == "" {
+			continue
+		}
+		// Other documents were remembered when the panels last showed them
+		uiState, _ := mw.uiStates.Get(doc.FilePath)
+		if i == active {
+			uiState = mw.currentUIState()
+			settings.ActiveFile = len(settings.OpenFiles)
+		}
+		settings.OpenFiles = append(settings.OpenFiles, state.SessionFile{
+			Path:          doc.FilePath,
+			Tab:           uiState.Tab,
+			ExpandedNodes: uiState.ExpandedNodes,
+		})
+	}
+	settings.SplitOffsets = []float64{mw.treeSplit.Offset, mw.previewSplit.Offset}
+}
+
+// RestoreSession reopens the files of the last session in their tabs, each
+// with its editor tab and tree nodes as they were left, and restores the
+// split positions. Files that can no longer be read are skipped.
+func (mw *MainWindow) RestoreSession() {
+	settings := mw.appState.GetSettings()
+	files, active := settings.SessionFiles()
+	for _, file := range files {
+		// The per-file UI state is shown when the file is displayed, so the
+		// session's layout is applied through it. The last opened file of
+		// older settings has no layout and keeps its own.
+		if file.Tab != "" {
+			uiState, _ := mw.uiStates.Get(file.Path)
+			uiState.Tab = file.Tab
+			uiState.ExpandedNodes = file.ExpandedNodes
+			mw.uiStates.Set(file.Path, uiState)
+		}
+		_ = mw.openFile(file.Path)
+	}
+	if len(files) > 0 {
+		if index := mw.appState.FindDocument(files[active].Path); index >= 0 {
+			_ = mw.presenter.SwitchDocument(index)
+		}
+	}
+
+	if offsets := settings.SplitOffsets; len(offsets) == 2 {
+		mw.treeSplit.SetOffset(offsets[0])
+		mw.previewSplit.SetOffset(offsets[1])
+	}
+}
+
+// RecoverSession offers to restore changes auto-saved by a session that did
+// not exit cleanly, then starts the background auto-save
+func (mw *MainWindow) RecoverSession() {
+	recovery, err := state.LoadRecovery()
+	if err != nil || recovery == nil {
+		mw.startAutoSave()
+		return
+	}
+
+	name := "an untitled POM"
+	if recovery.FilePath != "" {
+		name = filepath.Base(recovery.FilePath)
+	}
+	message := fmt.Sprintf("POM Manager did not shut down cleanly.\n\n"+
+		"Restore unsaved changes to %s from %s?", name, recovery.Saved.Format("2006-01-02 15:04"))
+
+	dialog.ShowConfirm("Recover Unsaved Changes", message, func(restore bool) {
+		if restore {
+			if err := mw.presenter.RestoreRecovery(recovery); err != nil {
+				dialog.ShowError(err, mw.window)
+			}
+		} else {
+			_ = state.ClearRecovery()
+		}
+		mw.startAutoSave()
+	}, mw.window)
+}
+
+// startAutoSave (re)starts the auto-save ticker using the interval from
+// settings; an interval of 0 disables auto-save
+func (mw *MainWindow) startAutoSave() {
+	if mw.autoSaveStop != nil {
+		close(mw.autoSaveStop)
+		mw.autoSaveStop = nil
+	}
+
+	minutes := mw.appState.GetSettings().AutoSaveInterval
+	if minutes <= 0 {
+		return
+	}
+
+	stop := make(chan struct{})
+	mw.autoSaveStop = stop
+	ticker := time.NewTicker(time.Duration(minutes) * time.Minute)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := mw.presenter.AutoSave(); err != nil {
+					fyne.Do(func() {
+						mw.statusLabel.SetText(fmt.Sprintf("Auto-save failed: %v", err))
+					})
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
 func (mw *MainWindow) handleSettings() {
 	currentSettings := mw.appState.GetSettings()
 	settingsDialog := dialogs.NewSettingsDialog(mw.window, currentSettings)
 	settingsDialog.Show(func(updatedSettings *state.Settings) {
+		registryChanged := updatedSettings.RegistrySource() != currentSettings.RegistrySource()
+		if updatedSettings.CustomTemplateDir != currentSettings.CustomTemplateDir || registryChanged {
+			mw.presenter.SetTemplateManager(updatedSettings.NewTemplateManager())
+		}
+
 		// Update app state
 		mw.appState.SetSettings(updatedSettings)
+		mw.startAutoSave()
+		if registryChanged {
+			mw.updateTemplateRegistry(false)
+		}
+		pom.SetBannerDefault(updatedSettings.GeneratorBanner)
 
 		// Save to disk
 		if err := state.SaveSettings(updatedSettings); err != nil {
@@ -569,7 +3556,7 @@ func (mw *MainWindow) handleRefresh() {
 		if err == nil {
 			mw.previewPane.SetXML(string(xmlData))
 		}
-		errorCount := len(result.Errors.AllErrors())
+		errorCount := result.Errors.Count(pom.SeverityError)
 		mw.previewPane.SetValidationStatus(result.Valid, errorCount)
 	}
 
@@ -594,35 +3581,48 @@ func (mw *MainWindow) handleAddExecution(pluginIndex int, execution pom.PluginEx
 	// Show execution dialog
 	execDialog := dialogs.NewExecutionDialog(mw.window, project.Build.Plugins)
 	execDialog.ShowAdd(func(selectedPluginIndex int, newExecution pom.PluginExecution) {
-		if selectedPluginIndex >= 0 && selectedPluginIndex < len(project.Build.Plugins) {
-			// Add execution to the selected plugin
-			project.Build.Plugins[selectedPluginIndex].Executions = append(
-				project.Build.Plugins[selectedPluginIndex].Executions,
-				newExecution,
-			)
-			// Notify presenter of change
-			mw.presenter.UpdateProject(project)
+		if err := mw.presenter.AddExecution(selectedPluginIndex, newExecution); err != nil {
+			dialog.ShowError(err, mw.window)
 		}
 	})
 }
 
-// handleRemoveExecution handles removing a plugin execution
-func (mw *MainWindow) handleRemoveExecution(pluginIndex int, executionID string) {
+// handleEditExecution shows the execution dialog for an existing execution
+// and saves the changes through the presenter
+func (mw *MainWindow) handleEditExecution(pluginIndex, executionIndex int) {
 	project := mw.presenter.GetCurrentProject()
-	if project == nil || project.Build == nil || pluginIndex < 0 || pluginIndex >= len(project.Build.Plugins) {
+	if project == nil || project.Build == nil || pluginIndex < 0 || pluginIndex >= len(project.Build.Plugins) ||
+		executionIndex < 0 || executionIndex >= len(project.Build.Plugins[pluginIndex].Executions) {
 		return
 	}
 
-	plugin := &project.Build.Plugins[pluginIndex]
-
-	// Find and remove the execution
-	for i, exec := range plugin.Executions {
-		if exec.ID == executionID {
-			plugin.Executions = append(plugin.Executions[:i], plugin.Executions[i+1:]...)
-			mw.presenter.UpdateProject(project)
-			break
+	execution := project.Build.Plugins[pluginIndex].Executions[executionIndex]
+	execDialog := dialogs.NewExecutionDialog(mw.window, project.Build.Plugins)
+	execDialog.ShowEdit(pluginIndex, execution, func(selectedPluginIndex int, updated pom.PluginExecution) {
+		if err := mw.presenter.UpdateExecution(pluginIndex, executionIndex, selectedPluginIndex, updated); err != nil {
+			dialog.ShowError(err, mw.window)
 		}
+	})
+}
+
+// handleRemoveExecution asks before removing a plugin execution
+func (mw *MainWindow) handleRemoveExecution(pluginIndex, executionIndex int) {
+	project := mw.presenter.GetCurrentProject()
+	if project == nil || project.Build == nil || pluginIndex < 0 || pluginIndex >= len(project.Build.Plugins) ||
+		executionIndex < 0 || executionIndex >= len(project.Build.Plugins[pluginIndex].Executions) {
+		return
 	}
+
+	plugin := project.Build.Plugins[pluginIndex]
+	message := fmt.Sprintf("Remove execution %s of %s?", plugin.Executions[executionIndex].ID, plugin.ArtifactID)
+	dialog.ShowConfirm("Remove Execution", message, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		if err := mw.presenter.RemoveExecution(pluginIndex, executionIndex); err != nil {
+			dialog.ShowError(err, mw.window)
+		}
+	}, mw.window)
 }
 
 // Show displays the window